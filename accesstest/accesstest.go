@@ -0,0 +1,200 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package accesstest provides a fake Flow access node with scripted scenarios.
+//
+// The node serves the Access API over gRPC on a free local port and behaves
+// according to a Scenario: the sequence of latest block heights it reports, the
+// status transitions of transactions and the faults injected into calls. This
+// makes client retry and failover logic testable deterministically:
+//
+//	func TestRetry(t *testing.T) {
+//		scenario := accesstest.NewScenario().
+//			Heights(10, 11, 12).
+//			Inject("GetLatestBlockHeader", accesstest.Unavailable(), accesstest.RateLimited())
+//
+//		node := accesstest.Start(t, scenario)
+//		c := node.Client()
+//		...
+//	}
+package accesstest
+
+import (
+	"context"
+	"net"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+)
+
+// A Node is a running fake access node.
+type Node struct {
+	t        testing.TB
+	scenario *Scenario
+	server   *grpc.Server
+	address  string
+
+	mu           sync.Mutex
+	calls        map[string]int
+	transactions []flow.Transaction
+	byID         map[flow.Identifier]int
+	stopOnce     sync.Once
+}
+
+// Start starts a fake access node serving the scenario.
+//
+// The node is stopped when the test and all its subtests complete.
+func Start(t testing.TB, scenario *Scenario) *Node {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("accesstest: failed to listen: %v", err)
+	}
+
+	n := &Node{
+		t:        t,
+		scenario: scenario,
+		address:  listener.Addr().String(),
+		calls:    make(map[string]int),
+		byID:     make(map[flow.Identifier]int),
+	}
+
+	n.server = grpc.NewServer(grpc.UnaryInterceptor(n.intercept))
+	access.RegisterAccessAPIServer(n.server, &server{node: n})
+
+	go func() {
+		_ = n.server.Serve(listener)
+	}()
+
+	t.Cleanup(n.Stop)
+
+	return n
+}
+
+// Address returns the address the node is listening on.
+func (n *Node) Address() string {
+	return n.address
+}
+
+// Client returns a new client connected to the node.
+//
+// The client is closed when the test completes.
+func (n *Node) Client() *client.Client {
+	n.t.Helper()
+
+	c, err := client.New(n.address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		n.t.Fatalf("accesstest: failed to connect to node: %v", err)
+	}
+
+	n.t.Cleanup(func() {
+		_ = c.Close()
+	})
+
+	return c
+}
+
+// Calls returns the number of calls received for an Access API method, including
+// calls that failed with an injected fault.
+func (n *Node) Calls(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.calls[method]
+}
+
+// Transactions returns the transactions submitted to the node, in order.
+func (n *Node) Transactions() []flow.Transaction {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	transactions := make([]flow.Transaction, len(n.transactions))
+	copy(transactions, n.transactions)
+
+	return transactions
+}
+
+// Stop stops the node, closing all open connections.
+//
+// Stopping a node lets tests exercise failover to another node.
+func (n *Node) Stop() {
+	n.stopOnce.Do(n.server.Stop)
+}
+
+// intercept counts calls and applies the faults the scenario injects into them.
+func (n *Node) intercept(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	method := path.Base(info.FullMethod)
+
+	n.mu.Lock()
+	n.calls[method]++
+	n.mu.Unlock()
+
+	fault, ok := n.scenario.nextFault(method)
+	if ok {
+		if fault.Delay > 0 {
+			timer := time.NewTimer(fault.Delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, status.FromContextError(ctx.Err()).Err()
+			}
+		}
+
+		if fault.Err != nil {
+			return nil, fault.Err
+		}
+	}
+
+	return handler(ctx, req)
+}
+
+func (n *Node) submit(tx flow.Transaction) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.byID[tx.ID()] = len(n.transactions)
+	n.transactions = append(n.transactions, tx)
+}
+
+func (n *Node) transaction(txID flow.Identifier) (flow.Transaction, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	i, ok := n.byID[txID]
+	if !ok {
+		return flow.Transaction{}, false
+	}
+
+	return n.transactions[i], true
+}
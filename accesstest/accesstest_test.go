@@ -0,0 +1,292 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accesstest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/accesstest"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func code(err error) codes.Code {
+	var rpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &rpcErr) {
+		return rpcErr.GRPCStatus().Code()
+	}
+
+	return status.Code(err)
+}
+
+func TestNode_Heights(t *testing.T) {
+	ctx := context.Background()
+
+	node := accesstest.Start(t, accesstest.NewScenario().Heights(10, 11, 12))
+	c := node.Client()
+
+	for _, expected := range []uint64{10, 11, 12, 12} {
+		header, err := c.GetLatestBlockHeader(ctx, true)
+		require.NoError(t, err)
+		assert.Equal(t, expected, header.Height)
+	}
+
+	t.Run("Block by height", func(t *testing.T) {
+		block, err := c.GetBlockByHeight(ctx, 11)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(11), block.Height)
+
+		_, err = c.GetBlockHeaderByHeight(ctx, 13)
+		assert.Equal(t, codes.NotFound, code(err))
+	})
+
+	t.Run("Block by ID", func(t *testing.T) {
+		latest, err := c.GetLatestBlock(ctx, true)
+		require.NoError(t, err)
+
+		parent, err := c.GetBlockHeaderByID(ctx, latest.ParentID)
+		require.NoError(t, err)
+		assert.Equal(t, latest.Height-1, parent.Height)
+		assert.True(t, parent.Timestamp.Before(latest.Timestamp))
+
+		_, err = c.GetBlockByID(ctx, flow.HexToID("ff"))
+		assert.Equal(t, codes.NotFound, code(err))
+	})
+}
+
+func TestNode_TransactionStatuses(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Scripted", func(t *testing.T) {
+		tx := test.TransactionGenerator().New()
+
+		scenario := accesstest.NewScenario().
+			TransactionStatuses(
+				tx.ID(),
+				flow.TransactionStatusPending,
+				flow.TransactionStatusExecuted,
+				flow.TransactionStatusSealed,
+			).
+			TransactionError(tx.ID(), errors.New("boom"))
+
+		c := accesstest.Start(t, scenario).Client()
+
+		for _, expected := range []flow.TransactionStatus{
+			flow.TransactionStatusPending,
+			flow.TransactionStatusExecuted,
+			flow.TransactionStatusSealed,
+			flow.TransactionStatusSealed,
+		} {
+			result, err := c.GetTransactionResult(ctx, tx.ID())
+			require.NoError(t, err)
+			assert.Equal(t, expected, result.Status)
+			assert.Error(t, result.Error)
+		}
+	})
+
+	t.Run("Submitted", func(t *testing.T) {
+		tx := test.TransactionGenerator().New()
+
+		scenario := accesstest.NewScenario().
+			DefaultTransactionStatuses(flow.TransactionStatusPending, flow.TransactionStatusSealed)
+
+		node := accesstest.Start(t, scenario)
+		c := node.Client()
+
+		_, err := c.GetTransactionResult(ctx, tx.ID())
+		assert.Equal(t, codes.NotFound, code(err))
+
+		require.NoError(t, c.SendTransaction(ctx, *tx))
+		require.Len(t, node.Transactions(), 1)
+		assert.Equal(t, tx.ID(), node.Transactions()[0].ID())
+
+		sent, err := c.GetTransaction(ctx, tx.ID())
+		require.NoError(t, err)
+		assert.Equal(t, tx.ID(), sent.ID())
+
+		result, err := c.GetTransactionResult(ctx, tx.ID())
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusPending, result.Status)
+		assert.NoError(t, result.Error)
+
+		result, err = c.GetTransactionResult(ctx, tx.ID())
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+	})
+}
+
+func TestNode_Inject(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Errors", func(t *testing.T) {
+		scenario := accesstest.NewScenario().
+			Inject(
+				"GetLatestBlockHeader",
+				accesstest.Timeout(),
+				accesstest.Pass(),
+				accesstest.RateLimited(),
+				accesstest.NotFound(),
+				accesstest.Unavailable(),
+			)
+
+		node := accesstest.Start(t, scenario)
+		c := node.Client()
+
+		for _, expected := range []codes.Code{
+			codes.DeadlineExceeded,
+			codes.OK,
+			codes.ResourceExhausted,
+			codes.NotFound,
+			codes.Unavailable,
+			codes.OK,
+		} {
+			_, err := c.GetLatestBlockHeader(ctx, true)
+			assert.Equal(t, expected, code(err))
+		}
+
+		assert.Equal(t, 6, node.Calls("GetLatestBlockHeader"))
+		assert.Equal(t, 0, node.Calls("GetLatestBlock"))
+	})
+
+	t.Run("Delay", func(t *testing.T) {
+		scenario := accesstest.NewScenario().
+			Inject("Ping", accesstest.Delay(time.Minute))
+
+		c := accesstest.Start(t, scenario).Client()
+
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		err := c.Ping(ctx)
+		assert.Equal(t, codes.DeadlineExceeded, code(err))
+	})
+
+	t.Run("While serving", func(t *testing.T) {
+		scenario := accesstest.NewScenario()
+		c := accesstest.Start(t, scenario).Client()
+
+		require.NoError(t, c.Ping(ctx))
+
+		scenario.Inject("Ping", accesstest.Unavailable())
+
+		assert.Equal(t, codes.Unavailable, code(c.Ping(ctx)))
+		assert.NoError(t, c.Ping(ctx))
+	})
+}
+
+func TestNode_Account(t *testing.T) {
+	ctx := context.Background()
+
+	account := test.AccountGenerator().New()
+
+	c := accesstest.Start(t, accesstest.NewScenario().Account(account)).Client()
+
+	fetched, err := c.GetAccount(ctx, account.Address)
+	require.NoError(t, err)
+	assert.Equal(t, account.Address, fetched.Address)
+	assert.Equal(t, account.Balance, fetched.Balance)
+
+	_, err = c.GetAccountAtBlockHeight(ctx, account.Address, 0)
+	require.NoError(t, err)
+
+	_, err = c.GetAccount(ctx, flow.HexToAddress("01"))
+	assert.Equal(t, codes.NotFound, code(err))
+}
+
+func TestNode_Stop(t *testing.T) {
+	ctx := context.Background()
+
+	node := accesstest.Start(t, accesstest.NewScenario())
+	c := node.Client()
+
+	require.NoError(t, c.Ping(ctx))
+
+	node.Stop()
+
+	assert.Equal(t, codes.Unavailable, code(c.Ping(ctx)))
+}
+
+func BenchmarkClient(b *testing.B) {
+	ctx := context.Background()
+	tx := test.TransactionGenerator().New()
+
+	scenario := accesstest.NewScenario().
+		TransactionStatuses(tx.ID(), flow.TransactionStatusSealed)
+
+	c := accesstest.Start(b, scenario).Client()
+
+	calls := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "GetLatestBlockHeader",
+			call: func() error {
+				_, err := c.GetLatestBlockHeader(ctx, true)
+				return err
+			},
+		},
+		{
+			name: "SendTransaction",
+			call: func() error {
+				return c.SendTransaction(ctx, *tx)
+			},
+		},
+		{
+			name: "GetTransactionResult",
+			call: func() error {
+				_, err := c.GetTransactionResult(ctx, tx.ID())
+				return err
+			},
+		},
+	}
+
+	for _, call := range calls {
+		b.Run(call.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				if err := call.call(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(call.name+"/parallel", func(b *testing.B) {
+			b.ReportAllocs()
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if err := call.call(); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,239 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accesstest
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// A Fault is injected into a single call to the fake access node.
+//
+// The call is delayed by Delay and then fails with Err. A fault without an error
+// lets the call through after the delay, which allows successful calls to be
+// scripted in between failing ones.
+type Fault struct {
+	Err   error
+	Delay time.Duration
+}
+
+// Pass returns a fault that lets a call through unchanged.
+func Pass() Fault {
+	return Fault{}
+}
+
+// Delay returns a fault that lets a call through after d.
+func Delay(d time.Duration) Fault {
+	return Fault{Delay: d}
+}
+
+// Timeout returns a fault that fails a call with codes.DeadlineExceeded.
+func Timeout() Fault {
+	return Fault{Err: status.Error(codes.DeadlineExceeded, "accesstest: injected timeout")}
+}
+
+// NotFound returns a fault that fails a call with codes.NotFound.
+func NotFound() Fault {
+	return Fault{Err: status.Error(codes.NotFound, "accesstest: injected not found")}
+}
+
+// RateLimited returns a fault that fails a call with codes.ResourceExhausted, the
+// code returned by access nodes when a client exceeds its rate limit.
+func RateLimited() Fault {
+	return Fault{Err: status.Error(codes.ResourceExhausted, "accesstest: injected rate limit")}
+}
+
+// Unavailable returns a fault that fails a call with codes.Unavailable.
+func Unavailable() Fault {
+	return Fault{Err: status.Error(codes.Unavailable, "accesstest: injected unavailable")}
+}
+
+// A Scenario scripts the behaviour of a fake access node.
+//
+// Each scripted sequence is consumed one step per call, and the last step of a
+// sequence is repeated once it is reached. A Scenario may be changed while a node
+// is serving it, for example to inject a fault half way through a test.
+type Scenario struct {
+	mu               sync.Mutex
+	heights          []uint64
+	statuses         map[flow.Identifier][]flow.TransactionStatus
+	defaultStatuses  []flow.TransactionStatus
+	transactionError map[flow.Identifier]error
+	faults           map[string][]Fault
+	accounts         map[flow.Address]*flow.Account
+}
+
+// NewScenario returns a scenario with a chain sealed at height zero, in which
+// every submitted transaction is sealed immediately.
+func NewScenario() *Scenario {
+	return &Scenario{
+		heights:          []uint64{0},
+		statuses:         make(map[flow.Identifier][]flow.TransactionStatus),
+		defaultStatuses:  []flow.TransactionStatus{flow.TransactionStatusSealed},
+		transactionError: make(map[flow.Identifier]error),
+		faults:           make(map[string][]Fault),
+		accounts:         make(map[flow.Address]*flow.Account),
+	}
+}
+
+// Heights sets the sequence of latest block heights.
+//
+// Each request for the latest block or block header advances the chain to the next
+// height. Heights are expected to be increasing; blocks at or below the highest
+// height reported so far can be fetched by height or ID.
+func (s *Scenario) Heights(heights ...uint64) *Scenario {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(heights) > 0 {
+		s.heights = heights
+	}
+
+	return s
+}
+
+// TransactionStatuses sets the sequence of statuses reported for a transaction.
+//
+// Each request for the transaction result advances to the next status. The
+// transaction does not need to be submitted to the node before its result can
+// be fetched.
+func (s *Scenario) TransactionStatuses(txID flow.Identifier, statuses ...flow.TransactionStatus) *Scenario {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(statuses) > 0 {
+		s.statuses[txID] = statuses
+	}
+
+	return s
+}
+
+// DefaultTransactionStatuses sets the sequence of statuses reported for submitted
+// transactions that have no sequence of their own.
+func (s *Scenario) DefaultTransactionStatuses(statuses ...flow.TransactionStatus) *Scenario {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(statuses) > 0 {
+		s.defaultStatuses = statuses
+	}
+
+	return s
+}
+
+// TransactionError sets the execution error reported in the result of a transaction.
+func (s *Scenario) TransactionError(txID flow.Identifier, err error) *Scenario {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.transactionError[txID] = err
+
+	return s
+}
+
+// Inject queues faults for successive calls to an Access API method.
+//
+// The method is the name of the gRPC method, such as "GetLatestBlockHeader" or
+// "SendTransaction". Faults are applied in order, one per call, and the method
+// behaves normally once they are used up.
+func (s *Scenario) Inject(method string, faults ...Fault) *Scenario {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults[method] = append(s.faults[method], faults...)
+
+	return s
+}
+
+// Account adds an account that can be fetched at any height.
+func (s *Scenario) Account(account *flow.Account) *Scenario {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accounts[account.Address] = account
+
+	return s
+}
+
+func (s *Scenario) nextFault(method string) (Fault, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	faults := s.faults[method]
+	if len(faults) == 0 {
+		return Fault{}, false
+	}
+
+	s.faults[method] = faults[1:]
+
+	return faults[0], true
+}
+
+// nextHeight advances the chain and returns the new latest height.
+func (s *Scenario) nextHeight() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	height := s.heights[0]
+	if len(s.heights) > 1 {
+		s.heights = s.heights[1:]
+	}
+
+	return height
+}
+
+// nextResult returns the next result of a transaction, or false if the scenario
+// knows nothing about it.
+func (s *Scenario) nextResult(txID flow.Identifier, submitted bool) (flow.TransactionResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses, ok := s.statuses[txID]
+	if !ok {
+		if !submitted {
+			return flow.TransactionResult{}, false
+		}
+
+		statuses = s.defaultStatuses
+	}
+
+	if len(statuses) > 1 {
+		s.statuses[txID] = statuses[1:]
+	} else {
+		s.statuses[txID] = statuses
+	}
+
+	return flow.TransactionResult{
+		Status: statuses[0],
+		Error:  s.transactionError[txID],
+	}, true
+}
+
+func (s *Scenario) account(address flow.Address) (*flow.Account, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, ok := s.accounts[address]
+	return account, ok
+}
@@ -0,0 +1,295 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accesstest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client/convert"
+	"github.com/onflow/flow-go-sdk/flowtest"
+)
+
+// server implements the Access API methods backed by the scenario. Methods that
+// are not implemented fail with codes.Unimplemented.
+type server struct {
+	access.UnimplementedAccessAPIServer
+
+	node *Node
+
+	mu      sync.Mutex
+	latest  uint64
+	heights map[flow.Identifier]uint64
+}
+
+// header returns the deterministic header of the block at a height.
+func (s *server) header(height uint64) flow.BlockHeader {
+	header := flow.BlockHeader{
+		ID:        blockID(height),
+		Height:    height,
+		Timestamp: flowtest.GenesisTime.Add(time.Duration(height) * time.Second),
+	}
+
+	if height > 0 {
+		header.ParentID = blockID(height - 1)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.heights == nil {
+		s.heights = make(map[flow.Identifier]uint64)
+	}
+
+	s.heights[header.ID] = height
+	if height > 0 {
+		s.heights[header.ParentID] = height - 1
+	}
+
+	return header
+}
+
+func blockID(height uint64) flow.Identifier {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], height)
+
+	hash := sha256.Sum256(b[:])
+
+	return flow.HashToID(hash[:])
+}
+
+// latestHeader advances the chain to the next scripted height.
+func (s *server) latestHeader() flow.BlockHeader {
+	height := s.node.scenario.nextHeight()
+
+	s.mu.Lock()
+	if height > s.latest {
+		s.latest = height
+	}
+	s.mu.Unlock()
+
+	return s.header(height)
+}
+
+func (s *server) headerByHeight(height uint64) (flow.BlockHeader, error) {
+	s.mu.Lock()
+	latest := s.latest
+	s.mu.Unlock()
+
+	if height > latest {
+		return flow.BlockHeader{}, status.Errorf(codes.NotFound, "accesstest: block at height %d not found", height)
+	}
+
+	return s.header(height), nil
+}
+
+func (s *server) headerByID(id []byte) (flow.BlockHeader, error) {
+	s.mu.Lock()
+	height, ok := s.heights[flow.HashToID(id)]
+	s.mu.Unlock()
+
+	if !ok {
+		return flow.BlockHeader{}, status.Errorf(codes.NotFound, "accesstest: block %x not found", id)
+	}
+
+	return s.header(height), nil
+}
+
+func headerResponse(header flow.BlockHeader) (*access.BlockHeaderResponse, error) {
+	msg, err := convert.BlockHeaderToMessage(header)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &access.BlockHeaderResponse{Block: msg}, nil
+}
+
+func blockResponse(header flow.BlockHeader) (*access.BlockResponse, error) {
+	msg, err := convert.BlockToMessage(flow.Block{BlockHeader: header})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &access.BlockResponse{Block: msg}, nil
+}
+
+func (s *server) Ping(context.Context, *access.PingRequest) (*access.PingResponse, error) {
+	return &access.PingResponse{}, nil
+}
+
+func (s *server) GetLatestBlockHeader(
+	context.Context,
+	*access.GetLatestBlockHeaderRequest,
+) (*access.BlockHeaderResponse, error) {
+	return headerResponse(s.latestHeader())
+}
+
+func (s *server) GetBlockHeaderByID(
+	_ context.Context,
+	req *access.GetBlockHeaderByIDRequest,
+) (*access.BlockHeaderResponse, error) {
+	header, err := s.headerByID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return headerResponse(header)
+}
+
+func (s *server) GetBlockHeaderByHeight(
+	_ context.Context,
+	req *access.GetBlockHeaderByHeightRequest,
+) (*access.BlockHeaderResponse, error) {
+	header, err := s.headerByHeight(req.GetHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	return headerResponse(header)
+}
+
+func (s *server) GetLatestBlock(context.Context, *access.GetLatestBlockRequest) (*access.BlockResponse, error) {
+	return blockResponse(s.latestHeader())
+}
+
+func (s *server) GetBlockByID(_ context.Context, req *access.GetBlockByIDRequest) (*access.BlockResponse, error) {
+	header, err := s.headerByID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return blockResponse(header)
+}
+
+func (s *server) GetBlockByHeight(
+	_ context.Context,
+	req *access.GetBlockByHeightRequest,
+) (*access.BlockResponse, error) {
+	header, err := s.headerByHeight(req.GetHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	return blockResponse(header)
+}
+
+func (s *server) SendTransaction(
+	_ context.Context,
+	req *access.SendTransactionRequest,
+) (*access.SendTransactionResponse, error) {
+	tx, err := convert.MessageToTransaction(req.GetTransaction())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s.node.submit(tx)
+
+	return &access.SendTransactionResponse{Id: tx.ID().Bytes()}, nil
+}
+
+func (s *server) GetTransaction(
+	_ context.Context,
+	req *access.GetTransactionRequest,
+) (*access.TransactionResponse, error) {
+	tx, ok := s.node.transaction(flow.HashToID(req.GetId()))
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "accesstest: transaction %x not found", req.GetId())
+	}
+
+	msg, err := convert.TransactionToMessage(tx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &access.TransactionResponse{Transaction: msg}, nil
+}
+
+func (s *server) GetTransactionResult(
+	_ context.Context,
+	req *access.GetTransactionRequest,
+) (*access.TransactionResultResponse, error) {
+	txID := flow.HashToID(req.GetId())
+	_, submitted := s.node.transaction(txID)
+
+	result, ok := s.node.scenario.nextResult(txID, submitted)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "accesstest: transaction %x not found", req.GetId())
+	}
+
+	res, err := convert.TransactionResultToMessage(result)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return res, nil
+}
+
+func (s *server) account(address []byte) (*flow.Account, error) {
+	account, ok := s.node.scenario.account(flow.BytesToAddress(address))
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "accesstest: account %x not found", address)
+	}
+
+	return account, nil
+}
+
+func (s *server) GetAccount(_ context.Context, req *access.GetAccountRequest) (*access.GetAccountResponse, error) {
+	account, err := s.account(req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	return &access.GetAccountResponse{Account: convert.AccountToMessage(*account)}, nil
+}
+
+func (s *server) GetAccountAtLatestBlock(
+	_ context.Context,
+	req *access.GetAccountAtLatestBlockRequest,
+) (*access.AccountResponse, error) {
+	account, err := s.account(req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	return &access.AccountResponse{Account: convert.AccountToMessage(*account)}, nil
+}
+
+func (s *server) GetAccountAtBlockHeight(
+	_ context.Context,
+	req *access.GetAccountAtBlockHeightRequest,
+) (*access.AccountResponse, error) {
+	if _, err := s.headerByHeight(req.GetBlockHeight()); err != nil {
+		return nil, err
+	}
+
+	account, err := s.account(req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	return &access.AccountResponse{Account: convert.AccountToMessage(*account)}, nil
+}
@@ -19,9 +19,8 @@
 package flow
 
 import (
-	"github.com/pkg/errors"
-
 	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
 )
 
 // An Account is an account on the Flow network.
@@ -98,11 +97,17 @@ func (a AccountKey) Encode() []byte {
 // Validate returns an error if this account key is invalid.
 //
 // An account key can be invalid for the following reasons:
+// - It has no public key
 // - It specifies an incompatible signature/hash algorithm pairing
 // - It specifies a valid key weight
 func (a AccountKey) Validate() error {
+	if a.PublicKey == nil {
+		return flowerrors.New(flowerrors.ErrInvalidArgument, "account key has no public key")
+	}
+
 	if !crypto.CompatibleAlgorithms(a.SigAlgo, a.HashAlgo) {
-		return errors.Errorf(
+		return flowerrors.Errorf(
+			flowerrors.ErrInvalidArgument,
 			"signing algorithm (%s) is incompatible with hashing algorithm (%s)",
 			a.SigAlgo,
 			a.HashAlgo,
@@ -110,7 +115,7 @@ func (a AccountKey) Validate() error {
 	}
 
 	if a.Weight < 0 || a.Weight > AccountKeyWeightThreshold {
-		return errors.Errorf("invalid key weight: %d", a.Weight)
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "invalid key weight: %d", a.Weight)
 	}
 
 	return nil
@@ -122,7 +127,7 @@ func DecodeAccountKey(b []byte) (*AccountKey, error) {
 
 	err := rlpDecode(b, &temp)
 	if err != nil {
-		return nil, err
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "failed to decode account key: %w", err)
 	}
 
 	sigAlgo := crypto.SignatureAlgorithm(temp.SigAlgo)
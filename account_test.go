@@ -90,4 +90,14 @@ func TestAccountKey(t *testing.T) {
 		assert.EqualError(t, key.Validate(), "signing algorithm (UNKNOWN) is incompatible with hashing algorithm (SHA3_256)")
 	})
 
+	t.Run("Missing Public Key", func(t *testing.T) {
+		key := AccountKey{
+			SigAlgo:  crypto.ECDSA_P256,
+			HashAlgo: crypto.SHA3_256,
+			Weight:   AccountKeyWeightThreshold,
+		}
+
+		assert.EqualError(t, key.Validate(), "account key has no public key")
+	})
+
 }
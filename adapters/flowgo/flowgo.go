@@ -0,0 +1,478 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package flowgo converts between the types of the SDK and the protocol types of
+// flow-go (github.com/onflow/flow-go/model/flow).
+//
+// Conversions of identifiers, addresses, transactions, events and collections are
+// lossless in both directions: converting a value and converting it back returns an
+// equal value, and a converted transaction has the same ID as the original.
+//
+// Blocks, headers and proofs of the ledger are converted in both directions. SDK
+// blocks do not hold the consensus fields of flow-go headers, such as the view and
+// the proposer, so these are empty in blocks converted from the SDK, and such blocks
+// only have the ID of the original block if it had no consensus fields either.
+package flowgo
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	model "github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/verification"
+)
+
+// IdentifierToSDK converts a flow-go identifier to an SDK identifier.
+func IdentifierToSDK(id model.Identifier) flow.Identifier {
+	return flow.Identifier(id)
+}
+
+// IdentifierFromSDK converts an SDK identifier to a flow-go identifier.
+func IdentifierFromSDK(id flow.Identifier) model.Identifier {
+	return model.Identifier(id)
+}
+
+// IdentifiersToSDK converts flow-go identifiers to SDK identifiers.
+func IdentifiersToSDK(ids []model.Identifier) []flow.Identifier {
+	if ids == nil {
+		return nil
+	}
+
+	sdkIDs := make([]flow.Identifier, len(ids))
+	for i, id := range ids {
+		sdkIDs[i] = IdentifierToSDK(id)
+	}
+
+	return sdkIDs
+}
+
+// IdentifiersFromSDK converts SDK identifiers to flow-go identifiers.
+func IdentifiersFromSDK(ids []flow.Identifier) []model.Identifier {
+	if ids == nil {
+		return nil
+	}
+
+	modelIDs := make([]model.Identifier, len(ids))
+	for i, id := range ids {
+		modelIDs[i] = IdentifierFromSDK(id)
+	}
+
+	return modelIDs
+}
+
+// AddressToSDK converts a flow-go address to an SDK address.
+func AddressToSDK(address model.Address) flow.Address {
+	return flow.Address(address)
+}
+
+// AddressFromSDK converts an SDK address to a flow-go address.
+func AddressFromSDK(address flow.Address) model.Address {
+	return model.Address(address)
+}
+
+// ChainIDToSDK converts a flow-go chain ID to an SDK chain ID.
+func ChainIDToSDK(chainID model.ChainID) flow.ChainID {
+	return flow.ChainID(chainID)
+}
+
+// ChainIDFromSDK converts an SDK chain ID to a flow-go chain ID.
+func ChainIDFromSDK(chainID flow.ChainID) model.ChainID {
+	return model.ChainID(chainID)
+}
+
+// StateCommitmentToSDK converts a flow-go state commitment to an SDK state commitment.
+func StateCommitmentToSDK(commitment model.StateCommitment) flow.StateCommitment {
+	return flow.StateCommitment(commitment)
+}
+
+// StateCommitmentFromSDK converts an SDK state commitment to a flow-go state commitment.
+func StateCommitmentFromSDK(commitment flow.StateCommitment) model.StateCommitment {
+	return model.StateCommitment(commitment)
+}
+
+// TransactionToSDK converts a flow-go transaction body to an SDK transaction.
+func TransactionToSDK(tx *model.TransactionBody) *flow.Transaction {
+	authorizers := make([]flow.Address, len(tx.Authorizers))
+	for i, authorizer := range tx.Authorizers {
+		authorizers[i] = AddressToSDK(authorizer)
+	}
+
+	return &flow.Transaction{
+		Script:           tx.Script,
+		Arguments:        tx.Arguments,
+		ReferenceBlockID: IdentifierToSDK(tx.ReferenceBlockID),
+		GasLimit:         tx.GasLimit,
+		ProposalKey: flow.ProposalKey{
+			Address:        AddressToSDK(tx.ProposalKey.Address),
+			KeyIndex:       int(tx.ProposalKey.KeyIndex),
+			SequenceNumber: tx.ProposalKey.SequenceNumber,
+		},
+		Payer:              AddressToSDK(tx.Payer),
+		Authorizers:        authorizers,
+		PayloadSignatures:  signaturesToSDK(tx.PayloadSignatures),
+		EnvelopeSignatures: signaturesToSDK(tx.EnvelopeSignatures),
+	}
+}
+
+// TransactionFromSDK converts an SDK transaction to a flow-go transaction body.
+func TransactionFromSDK(tx *flow.Transaction) *model.TransactionBody {
+	authorizers := make([]model.Address, len(tx.Authorizers))
+	for i, authorizer := range tx.Authorizers {
+		authorizers[i] = AddressFromSDK(authorizer)
+	}
+
+	return &model.TransactionBody{
+		ReferenceBlockID: IdentifierFromSDK(tx.ReferenceBlockID),
+		Script:           tx.Script,
+		Arguments:        tx.Arguments,
+		GasLimit:         tx.GasLimit,
+		ProposalKey: model.ProposalKey{
+			Address:        AddressFromSDK(tx.ProposalKey.Address),
+			KeyIndex:       uint64(tx.ProposalKey.KeyIndex),
+			SequenceNumber: tx.ProposalKey.SequenceNumber,
+		},
+		Payer:              AddressFromSDK(tx.Payer),
+		Authorizers:        authorizers,
+		PayloadSignatures:  signaturesFromSDK(tx.PayloadSignatures),
+		EnvelopeSignatures: signaturesFromSDK(tx.EnvelopeSignatures),
+	}
+}
+
+func signaturesToSDK(signatures []model.TransactionSignature) []flow.TransactionSignature {
+	if signatures == nil {
+		return nil
+	}
+
+	sdkSignatures := make([]flow.TransactionSignature, len(signatures))
+	for i, sig := range signatures {
+		sdkSignatures[i] = flow.TransactionSignature{
+			Address:     AddressToSDK(sig.Address),
+			SignerIndex: sig.SignerIndex,
+			KeyIndex:    int(sig.KeyIndex),
+			Signature:   sig.Signature,
+		}
+	}
+
+	return sdkSignatures
+}
+
+func signaturesFromSDK(signatures []flow.TransactionSignature) []model.TransactionSignature {
+	if signatures == nil {
+		return nil
+	}
+
+	modelSignatures := make([]model.TransactionSignature, len(signatures))
+	for i, sig := range signatures {
+		modelSignatures[i] = model.TransactionSignature{
+			Address:     AddressFromSDK(sig.Address),
+			SignerIndex: sig.SignerIndex,
+			KeyIndex:    uint64(sig.KeyIndex),
+			Signature:   sig.Signature,
+		}
+	}
+
+	return modelSignatures
+}
+
+// EventToSDK converts a flow-go event to an SDK event, decoding its JSON-CDC payload.
+func EventToSDK(event model.Event) (flow.Event, error) {
+	value, err := jsoncdc.Decode(event.Payload)
+	if err != nil {
+		return flow.Event{}, fmt.Errorf("flowgo: failed to decode payload of event %s: %w", event.Type, err)
+	}
+
+	eventValue, ok := value.(cadence.Event)
+	if !ok {
+		return flow.Event{}, fmt.Errorf("flowgo: payload of event %s is a %T, not an event", event.Type, value)
+	}
+
+	return flow.Event{
+		Type:             string(event.Type),
+		TransactionID:    IdentifierToSDK(event.TransactionID),
+		TransactionIndex: int(event.TransactionIndex),
+		EventIndex:       int(event.EventIndex),
+		Value:            eventValue,
+		Payload:          event.Payload,
+	}, nil
+}
+
+// EventFromSDK converts an SDK event to a flow-go event.
+//
+// The payload of the event is used if it is set, otherwise the value of the event is
+// encoded to JSON-CDC.
+func EventFromSDK(event flow.Event) (model.Event, error) {
+	payload := event.Payload
+	if len(payload) == 0 {
+		var err error
+		payload, err = jsoncdc.Encode(event.Value)
+		if err != nil {
+			return model.Event{}, fmt.Errorf("flowgo: failed to encode value of event %s: %w", event.Type, err)
+		}
+	}
+
+	return model.Event{
+		Type:             model.EventType(event.Type),
+		TransactionID:    IdentifierFromSDK(event.TransactionID),
+		TransactionIndex: uint32(event.TransactionIndex),
+		EventIndex:       uint32(event.EventIndex),
+		Payload:          payload,
+	}, nil
+}
+
+// EventsToSDK converts flow-go events to SDK events.
+func EventsToSDK(events []model.Event) ([]flow.Event, error) {
+	sdkEvents := make([]flow.Event, len(events))
+	for i, event := range events {
+		sdkEvent, err := EventToSDK(event)
+		if err != nil {
+			return nil, err
+		}
+
+		sdkEvents[i] = sdkEvent
+	}
+
+	return sdkEvents, nil
+}
+
+// EventsFromSDK converts SDK events to flow-go events.
+func EventsFromSDK(events []flow.Event) ([]model.Event, error) {
+	modelEvents := make([]model.Event, len(events))
+	for i, event := range events {
+		modelEvent, err := EventFromSDK(event)
+		if err != nil {
+			return nil, err
+		}
+
+		modelEvents[i] = modelEvent
+	}
+
+	return modelEvents, nil
+}
+
+// CollectionToSDK converts a flow-go light collection to an SDK collection.
+func CollectionToSDK(collection *model.LightCollection) *flow.Collection {
+	return &flow.Collection{TransactionIDs: IdentifiersToSDK(collection.Transactions)}
+}
+
+// CollectionFromSDK converts an SDK collection to a flow-go light collection.
+func CollectionFromSDK(collection *flow.Collection) *model.LightCollection {
+	return &model.LightCollection{Transactions: IdentifiersFromSDK(collection.TransactionIDs)}
+}
+
+// BlockHeaderToSDK converts a flow-go header to an SDK block header.
+func BlockHeaderToSDK(header *model.Header) flow.BlockHeader {
+	return flow.BlockHeader{
+		ID:        IdentifierToSDK(header.ID()),
+		ParentID:  IdentifierToSDK(header.ParentID),
+		Height:    header.Height,
+		Timestamp: header.Timestamp,
+	}
+}
+
+// BlockHeaderFromSDK converts an SDK block header to a flow-go header.
+//
+// The ID of the SDK header is not carried over, and the consensus fields and payload
+// hash of the returned header are empty.
+func BlockHeaderFromSDK(header flow.BlockHeader) *model.Header {
+	return &model.Header{
+		ParentID:  IdentifierFromSDK(header.ParentID),
+		Height:    header.Height,
+		Timestamp: header.Timestamp,
+	}
+}
+
+// BlockToSDK converts a flow-go block to an SDK block.
+//
+// The guarantees of flow-go blocks list their signers by node ID, so the signer
+// indices and cluster chain ID of the returned guarantees are empty.
+func BlockToSDK(block *model.Block) *flow.Block {
+	sdkBlock := &flow.Block{BlockHeader: BlockHeaderToSDK(block.Header)}
+
+	if block.Payload == nil {
+		return sdkBlock
+	}
+
+	for _, guarantee := range block.Payload.Guarantees {
+		sdkBlock.CollectionGuarantees = append(sdkBlock.CollectionGuarantees, &flow.CollectionGuarantee{
+			CollectionID:     IdentifierToSDK(guarantee.CollectionID),
+			ReferenceBlockID: IdentifierToSDK(guarantee.ReferenceBlockID),
+			Signature:        guarantee.Signature,
+		})
+	}
+
+	for _, seal := range block.Payload.Seals {
+		sdkBlock.Seals = append(sdkBlock.Seals, SealToSDK(seal))
+	}
+
+	return sdkBlock
+}
+
+// BlockFromSDK converts an SDK block to a flow-go block.
+//
+// The header is converted with BlockHeaderFromSDK, and its payload hash is set to the
+// hash of the converted payload. The guarantees of the returned block have no signer
+// IDs, because SDK guarantees list their signers by index into the cluster.
+func BlockFromSDK(block *flow.Block) *model.Block {
+	var payload model.Payload
+
+	for _, guarantee := range block.CollectionGuarantees {
+		payload.Guarantees = append(payload.Guarantees, &model.CollectionGuarantee{
+			CollectionID:     IdentifierFromSDK(guarantee.CollectionID),
+			ReferenceBlockID: IdentifierFromSDK(guarantee.ReferenceBlockID),
+			Signature:        guarantee.Signature,
+		})
+	}
+
+	for _, seal := range block.Seals {
+		payload.Seals = append(payload.Seals, SealFromSDK(seal))
+	}
+
+	modelBlock := &model.Block{Header: BlockHeaderFromSDK(block.BlockHeader)}
+	modelBlock.SetPayload(payload)
+
+	return modelBlock
+}
+
+// SealToSDK converts a flow-go seal to an SDK block seal.
+//
+// The service events of the seal have no counterpart in SDK seals and are dropped.
+func SealToSDK(seal *model.Seal) *flow.BlockSeal {
+	sigs := make([]*flow.AggregatedSignature, len(seal.AggregatedApprovalSigs))
+	for i, sig := range seal.AggregatedApprovalSigs {
+		verifierSigs := make([][]byte, len(sig.VerifierSignatures))
+		for j, s := range sig.VerifierSignatures {
+			verifierSigs[j] = s
+		}
+
+		sigs[i] = &flow.AggregatedSignature{
+			VerifierSignatures: verifierSigs,
+			SignerIDs:          IdentifiersToSDK(sig.SignerIDs),
+		}
+	}
+
+	return &flow.BlockSeal{
+		BlockID:                IdentifierToSDK(seal.BlockID),
+		ResultID:               IdentifierToSDK(seal.ResultID),
+		FinalState:             StateCommitmentToSDK(seal.FinalState),
+		AggregatedApprovalSigs: sigs,
+	}
+}
+
+// SealFromSDK converts an SDK block seal to a flow-go seal.
+//
+// The execution receipt ID and signatures of the SDK seal have no counterpart in
+// flow-go seals and are dropped.
+func SealFromSDK(seal *flow.BlockSeal) *model.Seal {
+	sigs := make([]model.AggregatedSignature, len(seal.AggregatedApprovalSigs))
+	for i, sig := range seal.AggregatedApprovalSigs {
+		verifierSigs := make([]crypto.Signature, len(sig.VerifierSignatures))
+		for j, s := range sig.VerifierSignatures {
+			verifierSigs[j] = s
+		}
+
+		sigs[i] = model.AggregatedSignature{
+			VerifierSignatures: verifierSigs,
+			SignerIDs:          IdentifiersFromSDK(sig.SignerIDs),
+		}
+	}
+
+	return &model.Seal{
+		BlockID:                IdentifierFromSDK(seal.BlockID),
+		ResultID:               IdentifierFromSDK(seal.ResultID),
+		FinalState:             StateCommitmentFromSDK(seal.FinalState),
+		AggregatedApprovalSigs: sigs,
+	}
+}
+
+// Types of the ledger key parts of registers.
+const (
+	keyPartOwner = 0
+	keyPartKey   = 2
+)
+
+// RegisterProofToSDK converts a proof of the flow-go ledger to an SDK register proof.
+//
+// The key of the proof payload must have an owner and a key part.
+func RegisterProofToSDK(proof *ledger.TrieProof) (*verification.RegisterProof, error) {
+	if proof.Payload == nil {
+		return nil, fmt.Errorf("flowgo: proof of path %x has no payload", proof.Path[:])
+	}
+
+	var owner, key []byte
+	var hasOwner, hasKey bool
+
+	for _, part := range proof.Payload.Key.KeyParts {
+		switch part.Type {
+		case keyPartOwner:
+			owner, hasOwner = part.Value, true
+		case keyPartKey:
+			key, hasKey = part.Value, true
+		}
+	}
+
+	if !hasOwner || !hasKey {
+		return nil, fmt.Errorf("flowgo: proof of path %x is not a register proof", proof.Path[:])
+	}
+
+	interims := make([][32]byte, len(proof.Interims))
+	for i, interim := range proof.Interims {
+		interims[i] = interim
+	}
+
+	return &verification.RegisterProof{
+		RegisterID: flow.RegisterID{
+			Owner: string(owner),
+			Key:   string(key),
+		},
+		Value:    proof.Payload.Value,
+		Steps:    proof.Steps,
+		Flags:    proof.Flags,
+		Interims: interims,
+	}, nil
+}
+
+// RegisterProofFromSDK converts an SDK register proof to a proof of the flow-go ledger.
+//
+// The proof is an inclusion proof if the register has a value, and an exclusion
+// proof otherwise.
+func RegisterProofFromSDK(proof *verification.RegisterProof) *ledger.TrieProof {
+	key := ledger.NewKey([]ledger.KeyPart{
+		ledger.NewKeyPart(keyPartOwner, []byte(proof.RegisterID.Owner)),
+		ledger.NewKeyPart(keyPartKey, []byte(proof.RegisterID.Key)),
+	})
+
+	interims := make([]hash.Hash, len(proof.Interims))
+	for i, interim := range proof.Interims {
+		interims[i] = interim
+	}
+
+	return &ledger.TrieProof{
+		Path:      ledger.Path(verification.RegisterPath(proof.RegisterID)),
+		Payload:   ledger.NewPayload(key, proof.Value),
+		Interims:  interims,
+		Inclusion: len(proof.Value) > 0,
+		Flags:     proof.Flags,
+		Steps:     proof.Steps,
+	}
+}
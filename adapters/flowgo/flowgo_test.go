@@ -0,0 +1,263 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowgo_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	model "github.com/onflow/flow-go/model/flow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/adapters/flowgo"
+	"github.com/onflow/flow-go-sdk/test"
+	"github.com/onflow/flow-go-sdk/verification"
+)
+
+// TestFieldMappings fails when a converted type gains or loses fields, so that the
+// conversions are updated with the types.
+func TestFieldMappings(t *testing.T) {
+	fields := []struct {
+		value interface{}
+		count int
+	}{
+		{model.TransactionBody{}, 9},
+		{flow.Transaction{}, 9},
+		{model.ProposalKey{}, 3},
+		{flow.ProposalKey{}, 3},
+		{model.TransactionSignature{}, 4},
+		{flow.TransactionSignature{}, 4},
+		{model.Event{}, 5},
+		{flow.Event{}, 6},
+		{model.LightCollection{}, 1},
+		{flow.Collection{}, 1},
+		{model.Seal{}, 5},
+		{flow.BlockSeal{}, 7},
+		{model.Header{}, 10},
+		{flow.BlockHeader{}, 4},
+		{model.CollectionGuarantee{}, 4},
+		{flow.CollectionGuarantee{}, 5},
+		{ledger.TrieProof{}, 6},
+		{verification.RegisterProof{}, 5},
+	}
+
+	for _, f := range fields {
+		typ := reflect.TypeOf(f.value)
+		assert.Equal(t, f.count, typ.NumField(), "fields of %s", typ)
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	tx := test.TransactionGenerator().New()
+
+	body := flowgo.TransactionFromSDK(tx)
+
+	// Both types hash the same canonical form.
+	assert.Equal(t, tx.ID(), flowgo.IdentifierToSDK(body.ID()))
+	assert.Equal(t, tx, flowgo.TransactionToSDK(body))
+}
+
+func TestEvent(t *testing.T) {
+	event := test.EventGenerator().New()
+
+	modelEvent, err := flowgo.EventFromSDK(event)
+	require.NoError(t, err)
+	assert.Equal(t, event.Payload, modelEvent.Payload)
+	assert.Equal(t, model.EventType(event.Type), modelEvent.Type)
+
+	converted, err := flowgo.EventToSDK(modelEvent)
+	require.NoError(t, err)
+	assert.Equal(t, event, converted)
+
+	// The value is encoded if the event has no payload.
+	event.Payload = nil
+	modelEvent, err = flowgo.EventFromSDK(event)
+	require.NoError(t, err)
+	assert.Equal(t, converted.Payload, modelEvent.Payload)
+
+	_, err = flowgo.EventToSDK(model.Event{Payload: []byte("{}")})
+	assert.Error(t, err)
+}
+
+func TestCollection(t *testing.T) {
+	collection := test.CollectionGenerator().New()
+
+	assert.Equal(t, collection, flowgo.CollectionToSDK(flowgo.CollectionFromSDK(collection)))
+}
+
+func TestSeal(t *testing.T) {
+	seal := test.BlockSealGenerator().New()
+	seal.ExecutionReceiptID = flow.EmptyID
+	seal.ExecutionReceiptSignatures = nil
+	seal.ResultApprovalSignatures = nil
+
+	assert.Equal(t, seal, flowgo.SealToSDK(flowgo.SealFromSDK(seal)))
+}
+
+func TestBlockToSDK(t *testing.T) {
+	ids := test.IdentifierGenerator()
+	seal := test.BlockSealGenerator().New()
+
+	header := &model.Header{
+		ChainID:   model.Testnet,
+		ParentID:  flowgo.IdentifierFromSDK(ids.New()),
+		Height:    42,
+		Timestamp: time.Unix(1700000000, 0).UTC(),
+		View:      50,
+	}
+
+	guarantee := &model.CollectionGuarantee{
+		CollectionID:     flowgo.IdentifierFromSDK(ids.New()),
+		ReferenceBlockID: flowgo.IdentifierFromSDK(ids.New()),
+		Signature:        []byte{1, 2, 3},
+	}
+
+	block := &model.Block{
+		Header: header,
+		Payload: &model.Payload{
+			Guarantees: []*model.CollectionGuarantee{guarantee},
+			Seals:      []*model.Seal{flowgo.SealFromSDK(seal)},
+		},
+	}
+
+	sdkBlock := flowgo.BlockToSDK(block)
+
+	assert.Equal(t, flowgo.IdentifierToSDK(block.ID()), sdkBlock.ID)
+	assert.Equal(t, flowgo.IdentifierToSDK(header.ParentID), sdkBlock.ParentID)
+	assert.Equal(t, uint64(42), sdkBlock.Height)
+	assert.Equal(t, header.Timestamp, sdkBlock.Timestamp)
+
+	require.Len(t, sdkBlock.CollectionGuarantees, 1)
+	assert.Equal(t, flowgo.IdentifierToSDK(guarantee.CollectionID), sdkBlock.CollectionGuarantees[0].CollectionID)
+	assert.Equal(t, []byte{1, 2, 3}, sdkBlock.CollectionGuarantees[0].Signature)
+
+	require.Len(t, sdkBlock.Seals, 1)
+	assert.Equal(t, seal.BlockID, sdkBlock.Seals[0].BlockID)
+}
+
+func TestBlockHeader(t *testing.T) {
+	ids := test.IdentifierGenerator()
+
+	header := flow.BlockHeader{
+		ParentID:  ids.New(),
+		Height:    42,
+		Timestamp: time.Unix(1700000000, 0).UTC(),
+	}
+
+	modelHeader := flowgo.BlockHeaderFromSDK(header)
+	header.ID = flowgo.IdentifierToSDK(modelHeader.ID())
+
+	assert.Equal(t, header, flowgo.BlockHeaderToSDK(modelHeader))
+	assert.Equal(t, modelHeader, flowgo.BlockHeaderFromSDK(flowgo.BlockHeaderToSDK(modelHeader)))
+}
+
+func TestBlockFromSDK(t *testing.T) {
+	ids := test.IdentifierGenerator()
+	seal := test.BlockSealGenerator().New()
+	seal.ExecutionReceiptID = flow.EmptyID
+	seal.ExecutionReceiptSignatures = nil
+	seal.ResultApprovalSignatures = nil
+
+	block := &model.Block{
+		Header: &model.Header{
+			ParentID:  flowgo.IdentifierFromSDK(ids.New()),
+			Height:    42,
+			Timestamp: time.Unix(1700000000, 0).UTC(),
+		},
+	}
+	block.SetPayload(model.Payload{
+		Guarantees: []*model.CollectionGuarantee{{
+			CollectionID:     flowgo.IdentifierFromSDK(ids.New()),
+			ReferenceBlockID: flowgo.IdentifierFromSDK(ids.New()),
+			Signature:        []byte{1, 2, 3},
+		}},
+		Seals: []*model.Seal{flowgo.SealFromSDK(seal)},
+	})
+
+	sdkBlock := flowgo.BlockToSDK(block)
+	converted := flowgo.BlockFromSDK(sdkBlock)
+
+	assert.Equal(t, block, converted)
+	assert.Equal(t, block.ID(), converted.ID())
+	assert.Equal(t, sdkBlock, flowgo.BlockToSDK(converted))
+}
+
+func TestRegisterProofToSDK(t *testing.T) {
+	owner := flow.HexToAddress("01")
+
+	registerIDs := []flow.RegisterID{
+		flow.NewRegisterID(owner, "public_key_0"),
+		flow.NewRegisterID(owner, "storage_used"),
+		flow.NewRegisterID(flow.HexToAddress("02"), "exists"),
+	}
+
+	paths := make([]ledger.Path, len(registerIDs))
+	payloads := make([]ledger.Payload, len(registerIDs))
+
+	for i, id := range registerIDs {
+		key := ledger.NewKey([]ledger.KeyPart{
+			ledger.NewKeyPart(0, []byte(id.Owner)),
+			ledger.NewKeyPart(2, []byte(id.Key)),
+		})
+
+		path, err := pathfinder.KeyToPath(key, 1)
+		require.NoError(t, err)
+		assert.Equal(t, verification.RegisterPath(id), [32]byte(path))
+
+		paths[i] = path
+		payloads[i] = *ledger.NewPayload(key, ledger.Value{byte(i + 1)})
+	}
+
+	mt, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloads)
+	require.NoError(t, err)
+
+	commitment := flow.StateCommitment(mt.RootHash())
+
+	proofs := make([]*ledger.TrieProof, len(paths))
+	for i := range proofs {
+		proofs[i] = ledger.NewTrieProof()
+		proofs[i].Flags = make([]byte, ledger.PathLen)
+	}
+	mt.UnsafeProofs(append([]ledger.Path(nil), paths...), proofs)
+
+	for _, p := range proofs {
+		proof, err := flowgo.RegisterProofToSDK(p)
+		require.NoError(t, err)
+		assert.NoError(t, proof.Verify(commitment))
+
+		assert.Equal(t, p, flowgo.RegisterProofFromSDK(proof))
+
+		converted, err := flowgo.RegisterProofToSDK(flowgo.RegisterProofFromSDK(proof))
+		require.NoError(t, err)
+		assert.Equal(t, proof, converted)
+
+		proof.Value = []byte{42}
+		assert.True(t, errors.Is(proof.Verify(commitment), verification.ErrInvalidRegisterProof))
+	}
+
+	_, err = flowgo.RegisterProofToSDK(ledger.NewTrieProof())
+	assert.Error(t, err)
+}
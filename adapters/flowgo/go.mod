@@ -0,0 +1,13 @@
+module github.com/onflow/flow-go-sdk/adapters/flowgo
+
+go 1.13
+
+require (
+	github.com/onflow/cadence v0.18.0
+	github.com/onflow/flow-go v0.18.4
+	github.com/onflow/flow-go-sdk v0.21.0
+	github.com/onflow/flow-go/crypto v0.18.0
+	github.com/stretchr/testify v1.8.1
+)
+
+replace github.com/onflow/flow-go-sdk => ../..
@@ -19,10 +19,13 @@
 package flow
 
 import (
+	"database/sql/driver"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"strings"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
 )
 
 // Address represents the 8 byte address of an account.
@@ -66,9 +69,32 @@ func (gen *AddressGenerator) NextAddress() Address {
 	return generateAddress(gen.chainID, gen.state)
 }
 
+// TryNextAddress increments the addressing state and generates an account address.
+//
+// Unlike NextAddress, an error is returned instead of a panic if the chain ID of
+// the generator is unknown or all addressing states have been used.
+func (gen *AddressGenerator) TryNextAddress() (Address, error) {
+	if err := validateChainID(gen.chainID); err != nil {
+		return EmptyAddress, err
+	}
+
+	if uint64(gen.state) > maxState {
+		return EmptyAddress, flowerrors.Errorf(
+			flowerrors.ErrInvalidArgument,
+			"addressing state must be less than or equal to %d",
+			maxState,
+		)
+	}
+
+	gen.state++
+
+	return generateAddress(gen.chainID, gen.state), nil
+}
+
 // Next increments the addressing state.
 //
-// State values are incremented from 0 to 2^k-1.
+// State values are incremented from 0 to 2^k-1. Next panics once all states
+// have been used; see TryNextAddress for a variant that returns an error.
 func (gen *AddressGenerator) Next() *AddressGenerator {
 	if uint64(gen.state) > maxState {
 		panic(
@@ -129,6 +155,8 @@ func zeroAddress(chain ChainID) Address {
 }
 
 // HexToAddress converts a hex string to an Address.
+//
+// Invalid hex is silently decoded as far as possible; use ParseAddress for untrusted input.
 func HexToAddress(h string) Address {
 	trimmed := strings.TrimPrefix(h, "0x")
 	if len(trimmed) % 2 == 1 {
@@ -138,6 +166,28 @@ func HexToAddress(h string) Address {
 	return BytesToAddress(b)
 }
 
+// ParseAddress parses a hex string, optionally prefixed with 0x, into an Address.
+//
+// Unlike HexToAddress, an error is returned if the string is not valid hex or is
+// longer than an address. Shorter strings are padded with zeroes at the front.
+func ParseAddress(h string) (Address, error) {
+	trimmed := strings.TrimPrefix(h, "0x")
+	if len(trimmed)%2 == 1 {
+		trimmed = "0" + trimmed
+	}
+
+	b, err := hex.DecodeString(trimmed)
+	if err != nil {
+		return EmptyAddress, flowerrors.Errorf(flowerrors.ErrDecoding, "invalid address %q: %w", h, err)
+	}
+
+	if len(b) > AddressLength {
+		return EmptyAddress, flowerrors.Errorf(flowerrors.ErrDecoding, "invalid address %q: longer than %d bytes", h, AddressLength)
+	}
+
+	return BytesToAddress(b), nil
+}
+
 // BytesToAddress returns Address with value b.
 //
 // If b is larger than 8, b will be cropped from the left.
@@ -173,6 +223,61 @@ func (a *Address) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalText returns the hex representation of the address, as used for map keys
+// in JSON and other text formats.
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.Hex()), nil
+}
+
+// UnmarshalText parses a hex address, as ParseAddress.
+func (a *Address) UnmarshalText(text []byte) error {
+	address, err := ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+
+	*a = address
+	return nil
+}
+
+// MarshalBinary returns the bytes of the address.
+func (a Address) MarshalBinary() ([]byte, error) {
+	return a.Bytes(), nil
+}
+
+// UnmarshalBinary decodes an address from exactly 8 bytes.
+func (a *Address) UnmarshalBinary(data []byte) error {
+	if len(data) != AddressLength {
+		return flowerrors.Errorf(flowerrors.ErrDecoding, "invalid address: expected %d bytes, got %d", AddressLength, len(data))
+	}
+
+	*a = BytesToAddress(data)
+	return nil
+}
+
+// Value stores the address in a database as its 8 bytes, e.g. in a BYTEA or BLOB
+// column.
+func (a Address) Value() (driver.Value, error) {
+	return a.Bytes(), nil
+}
+
+// Scan reads an address from a database column. Bytes are read as the 8 bytes of
+// the address, as stored by Value, and strings as its hex representation. NULL is
+// read as the empty address.
+func (a *Address) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*a = EmptyAddress
+		return nil
+	case []byte:
+		return a.UnmarshalBinary(src)
+	case string:
+		return a.UnmarshalText([]byte(src))
+	default:
+		return flowerrors.Errorf(flowerrors.ErrDecoding, "cannot scan %T into address", src)
+	}
+}
+
 const (
 	// [n,k,d]-Linear code parameters
 	// The linear code used in the account addressing is a [64,45,7]
@@ -239,7 +344,13 @@ func generateAddress(chain ChainID, state addressState) Address {
 // This is an off-chain check that only tells whether the address format is
 // valid. If the function returns true, this does not mean a Flow account with
 // this address has been generated. Such a test would require an on-chain check.
+//
+// No address is valid for an unknown chain ID.
 func (a *Address) IsValid(chain ChainID) bool {
+	if validateChainID(chain) != nil {
+		return false
+	}
+
 	codeWord := a.uint64()
 	codeWord ^= chainCustomizer(chain)
 
@@ -54,6 +54,37 @@ func TestHexToAddress(t *testing.T) {
 	}
 }
 
+func TestParseAddress(t *testing.T) {
+
+	type testCase struct {
+		literal string
+		value []byte
+	}
+
+	for _, test := range []testCase{
+		{"123", []byte{0x1, 0x23}},
+		{"1", []byte{0x1}},
+		{"01", []byte{0x1}},
+		{"f8d6e0586b0a20c7", []byte{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7}},
+	} {
+
+		expected := BytesToAddress(test.value)
+
+		address, err := ParseAddress(test.literal)
+		require.NoError(t, err)
+		assert.Equal(t, expected, address)
+
+		address, err = ParseAddress("0x" + test.literal)
+		require.NoError(t, err)
+		assert.Equal(t, expected, address)
+	}
+
+	for _, literal := range []string{"zz", "0x12g4", "f8d6e0586b0a20c7ff"} {
+		_, err := ParseAddress(literal)
+		assert.Error(t, err, literal)
+	}
+}
+
 func TestAddressJSON(t *testing.T) {
 	addr := ServiceAddress(Mainnet)
 	data, err := json.Marshal(addressWrapper{Address: addr})
@@ -65,6 +96,43 @@ func TestAddressJSON(t *testing.T) {
 	assert.Equal(t, addr, out.Address)
 }
 
+func TestAddressEncoding(t *testing.T) {
+	addr := ServiceAddress(Mainnet)
+
+	data, err := json.Marshal(map[Address]bool{addr: true})
+	require.NoError(t, err)
+	assert.Equal(t, `{"`+addr.Hex()+`":true}`, string(data))
+
+	var keys map[Address]bool
+	require.NoError(t, json.Unmarshal(data, &keys))
+	assert.Equal(t, map[Address]bool{addr: true}, keys)
+
+	binary, err := addr.MarshalBinary()
+	require.NoError(t, err)
+
+	var decoded Address
+	require.NoError(t, decoded.UnmarshalBinary(binary))
+	assert.Equal(t, addr, decoded)
+	assert.Error(t, decoded.UnmarshalBinary(binary[1:]))
+
+	value, err := addr.Value()
+	require.NoError(t, err)
+	assert.Equal(t, addr.Bytes(), value)
+
+	for _, src := range []interface{}{value, addr.Hex(), "0x" + addr.Hex()} {
+		var scanned Address
+		require.NoError(t, scanned.Scan(src))
+		assert.Equal(t, addr, scanned)
+	}
+
+	var scanned Address
+	assert.Error(t, scanned.Scan([]byte(addr.Hex())))
+	assert.Error(t, scanned.Scan("not an address"))
+	assert.Error(t, scanned.Scan(1.5))
+	require.NoError(t, scanned.Scan(nil))
+	assert.Equal(t, EmptyAddress, scanned)
+}
+
 func TestAddressConstants(t *testing.T) {
 	// check n and k fit in 8 and 6 bytes
 	assert.LessOrEqual(t, linearCodeN, 8*8)
@@ -301,3 +369,42 @@ func TestAddressesIntersection(t *testing.T) {
 		}
 	}
 }
+
+func TestAddressGenerator_TryNextAddress(t *testing.T) {
+	t.Run("Exhausted", func(t *testing.T) {
+		generator := newAddressGeneratorAtState(Mainnet, maxState)
+
+		_, err := generator.TryNextAddress()
+		require.NoError(t, err)
+
+		_, err = generator.TryNextAddress()
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown chain", func(t *testing.T) {
+		generator := NewAddressGenerator(ChainID("flow-unknown"))
+
+		address, err := generator.TryNextAddress()
+		assert.Error(t, err)
+		assert.Equal(t, EmptyAddress, address)
+	})
+
+	t.Run("Matches NextAddress", func(t *testing.T) {
+		expected := NewAddressGenerator(Testnet)
+		generator := NewAddressGenerator(Testnet)
+
+		for i := 0; i < 10; i++ {
+			address, err := generator.TryNextAddress()
+			require.NoError(t, err)
+			assert.Equal(t, expected.NextAddress(), address)
+		}
+	})
+}
+
+func TestAddress_IsValid_UnknownChain(t *testing.T) {
+	address := ServiceAddress(Mainnet)
+
+	assert.NotPanics(t, func() {
+		assert.False(t, address.IsValid(ChainID("flow-unknown")))
+	})
+}
@@ -0,0 +1,173 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// A SignatureRole is a role an account signs a transaction in.
+type SignatureRole string
+
+const (
+	SignatureRoleProposer   SignatureRole = "proposer"
+	SignatureRoleAuthorizer SignatureRole = "authorizer"
+	SignatureRolePayer      SignatureRole = "payer"
+)
+
+// A SignatureRecord describes a signature produced by the SDK.
+type SignatureRecord struct {
+	// Address is the account the signature was produced for, or EmptyAddress
+	// if it is unknown.
+	Address Address
+	// KeyIndex is the index of the account key the signature was produced
+	// with, or -1 if it is unknown.
+	KeyIndex int
+	// PublicKey is the public key of the signer, or nil if the signer does not
+	// expose it.
+	PublicKey crypto.PublicKey
+	// Domain is the domain tag the signed message is prefixed with.
+	Domain DomainTag
+	// MessageHash is the SHA3-256 hash of the signed message, including its
+	// domain tag.
+	MessageHash []byte
+	// Envelope is true for transaction envelope signatures, and false for
+	// transaction payload signatures and other messages.
+	Envelope bool
+	// Roles are the roles of the account in the signed transaction.
+	Roles []SignatureRole
+	// Time is the time the signature was produced.
+	Time time.Time
+}
+
+// A SignatureAuditor records the signatures produced by the SDK, e.g. to an
+// audit log.
+//
+// Implementations must be safe for concurrent use, and should return quickly:
+// AuditSignature is called synchronously, before the signature is returned.
+type SignatureAuditor interface {
+	AuditSignature(record SignatureRecord)
+}
+
+// SignatureAuditorFunc is a SignatureAuditor implemented by a function.
+type SignatureAuditorFunc func(record SignatureRecord)
+
+// AuditSignature calls f(record).
+func (f SignatureAuditorFunc) AuditSignature(record SignatureRecord) {
+	f(record)
+}
+
+var signatureAuditor = struct {
+	sync.RWMutex
+	auditor SignatureAuditor
+}{}
+
+// SetSignatureAuditor sets the auditor of all signatures produced by the SDK,
+// replacing the current one. A nil auditor disables auditing.
+//
+// The auditor is called for the signatures of Transaction.SignPayload,
+// Transaction.SignEnvelope, SignUserMessage and SignAndAudit, and therefore
+// for the signatures of the packages built on them, such as wallet and fcl.
+// Signatures produced by calling the Sign method of a crypto.Signer directly
+// are not audited.
+func SetSignatureAuditor(auditor SignatureAuditor) {
+	signatureAuditor.Lock()
+	defer signatureAuditor.Unlock()
+
+	signatureAuditor.auditor = auditor
+}
+
+// SignAndAudit signs a domain-tagged message and reports the signature to
+// the auditor set with SetSignatureAuditor.
+//
+// The record describes the context of the signature; its Domain, MessageHash,
+// Time and, if the signer exposes it, PublicKey fields are filled in. No
+// signature is reported if signing fails.
+func SignAndAudit(signer crypto.Signer, message []byte, record SignatureRecord) ([]byte, error) {
+	sig, err := signer.Sign(message)
+	if err != nil {
+		return nil, err
+	}
+
+	signatureAuditor.RLock()
+	auditor := signatureAuditor.auditor
+	signatureAuditor.RUnlock()
+
+	if auditor == nil {
+		return sig, nil
+	}
+
+	record.Time = time.Now()
+	record.MessageHash = crypto.NewSHA3_256().ComputeHash(message)
+
+	if tag, _, err := UntagMessage(message); err == nil {
+		record.Domain = tag
+	}
+
+	if record.PublicKey == nil {
+		record.PublicKey = signerPublicKey(signer)
+	}
+
+	auditor.AuditSignature(record)
+
+	return sig, nil
+}
+
+// signerPublicKey returns the public key of a signer, or nil if it does not expose it.
+func signerPublicKey(signer crypto.Signer) crypto.PublicKey {
+	switch s := signer.(type) {
+	case interface{ PublicKey() crypto.PublicKey }:
+		return s.PublicKey()
+	case crypto.InMemorySigner:
+		if s.PrivateKey != nil {
+			return s.PrivateKey.PublicKey()
+		}
+	case *crypto.InMemorySigner:
+		if s != nil && s.PrivateKey != nil {
+			return s.PrivateKey.PublicKey()
+		}
+	}
+
+	return nil
+}
+
+// signatureRoles returns the roles of an account in the transaction.
+func (t *Transaction) signatureRoles(address Address) []SignatureRole {
+	var roles []SignatureRole
+
+	if t.ProposalKey.Address == address {
+		roles = append(roles, SignatureRoleProposer)
+	}
+
+	for _, authorizer := range t.Authorizers {
+		if authorizer == address {
+			roles = append(roles, SignatureRoleAuthorizer)
+			break
+		}
+	}
+
+	if t.Payer == address {
+		roles = append(roles, SignatureRolePayer)
+	}
+
+	return roles
+}
@@ -0,0 +1,89 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/cryptotest"
+)
+
+func TestSetSignatureAuditor(t *testing.T) {
+	var records []flow.SignatureRecord
+	flow.SetSignatureAuditor(flow.SignatureAuditorFunc(func(record flow.SignatureRecord) {
+		records = append(records, record)
+	}))
+	t.Cleanup(func() { flow.SetSignatureAuditor(nil) })
+
+	privateKey := cryptotest.PrivateKey(crypto.ECDSA_P256, 0)
+	signer, err := cryptotest.NewSigner(privateKey, crypto.SHA3_256)
+	require.NoError(t, err)
+
+	proposer := flow.HexToAddress("01")
+	payer := flow.HexToAddress("02")
+
+	tx := flow.NewTransaction().
+		SetScript([]byte("transaction {}")).
+		SetProposalKey(proposer, 3, 7).
+		SetPayer(payer).
+		AddAuthorizer(proposer)
+
+	require.NoError(t, tx.SignPayload(proposer, 3, signer))
+	require.NoError(t, tx.SignEnvelope(payer, 1, signer))
+
+	_, err = flow.SignUserMessage(signer, []byte("hello"))
+	require.NoError(t, err)
+
+	_, err = flow.SignAndAudit(cryptotest.FailingSigner{Err: errors.New("failed")}, []byte("message"), flow.SignatureRecord{})
+	assert.Error(t, err)
+
+	require.Len(t, records, 3)
+
+	payload := records[0]
+	assert.Equal(t, proposer, payload.Address)
+	assert.Equal(t, 3, payload.KeyIndex)
+	assert.True(t, privateKey.PublicKey().Equals(payload.PublicKey))
+	assert.Equal(t, flow.TransactionDomainTag, payload.Domain)
+	assert.Equal(t, []byte(crypto.NewSHA3_256().ComputeHash(flow.TransactionDomainTag.Tag(tx.PayloadMessage()))), payload.MessageHash)
+	assert.False(t, payload.Envelope)
+	assert.Equal(t, []flow.SignatureRole{flow.SignatureRoleProposer, flow.SignatureRoleAuthorizer}, payload.Roles)
+	assert.False(t, payload.Time.IsZero())
+
+	envelope := records[1]
+	assert.Equal(t, payer, envelope.Address)
+	assert.True(t, envelope.Envelope)
+	assert.Equal(t, []flow.SignatureRole{flow.SignatureRolePayer}, envelope.Roles)
+
+	user := records[2]
+	assert.Equal(t, flow.EmptyAddress, user.Address)
+	assert.Equal(t, -1, user.KeyIndex)
+	assert.Equal(t, flow.UserDomainTag, user.Domain)
+	assert.Empty(t, user.Roles)
+
+	flow.SetSignatureAuditor(nil)
+
+	require.NoError(t, tx.SignEnvelope(payer, 1, signer))
+	assert.Len(t, records, 3)
+}
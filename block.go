@@ -54,4 +54,19 @@ type BlockSeal struct {
 
 	ExecutionReceiptSignatures [][]byte
 	ResultApprovalSignatures   [][]byte
+
+	// The ID of the execution result being sealed
+	ResultID Identifier
+
+	// The state commitment after executing the sealed block
+	FinalState StateCommitment
+
+	// The aggregated approval signatures of the verification nodes, one per chunk of the sealed result
+	AggregatedApprovalSigs []*AggregatedSignature
+}
+
+// AggregatedSignature is the set of signatures of the verification nodes that approved a chunk.
+type AggregatedSignature struct {
+	VerifierSignatures [][]byte
+	SignerIDs          []Identifier
 }
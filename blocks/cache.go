@@ -0,0 +1,235 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package blocks provides utilities for working with Flow blocks.
+package blocks
+
+import (
+	"context"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+)
+
+// A HeaderClient is the subset of the Flow Access API client used to look up block headers.
+//
+// It is satisfied by *client.Client.
+type HeaderClient interface {
+	GetBlockHeaderByID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) (*flow.BlockHeader, error)
+	GetBlockHeaderByHeight(ctx context.Context, height uint64, opts ...grpc.CallOption) (*flow.BlockHeader, error)
+}
+
+// A Storage persists the entries of a Cache.
+//
+// The index of block IDs by height and the headers are stored separately, because some
+// sources, such as event subscriptions, only provide the ID of a block at a height.
+// Implementations must be safe for concurrent use.
+type Storage interface {
+	// ID returns the ID of the block at the given height, if known.
+	ID(height uint64) (flow.Identifier, bool, error)
+	// PutID records the ID of the block at the given height.
+	PutID(height uint64, blockID flow.Identifier) error
+	// Header returns the header of the block with the given ID, if known.
+	Header(blockID flow.Identifier) (flow.BlockHeader, bool, error)
+	// PutHeader records a block header.
+	PutHeader(header flow.BlockHeader) error
+}
+
+// DefaultCacheCapacity is the number of blocks kept by the default in-memory storage.
+const DefaultCacheCapacity = 10000
+
+// CacheStats are the lookup statistics of a Cache.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// A Cache maps block heights to block IDs and headers, fetching missing entries from
+// an access node.
+//
+// Sealed blocks are immutable, so entries never expire. The cache can be warmed with
+// the blocks seen by a follower or an event subscription, so repeated lookups, such as
+// resolving reference blocks, avoid extra RPCs.
+type Cache struct {
+	client  HeaderClient
+	storage Storage
+	hits    uint64
+	misses  uint64
+}
+
+// A CacheOption configures a Cache.
+type CacheOption func(*Cache)
+
+// WithStorage sets the storage of the cache. The default is an in-memory storage holding
+// DefaultCacheCapacity blocks.
+func WithStorage(storage Storage) CacheOption {
+	return func(c *Cache) {
+		c.storage = storage
+	}
+}
+
+// NewCache creates a cache that fetches missing entries with the given client.
+func NewCache(client HeaderClient, opts ...CacheOption) *Cache {
+	c := &Cache{
+		client: client,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.storage == nil {
+		c.storage = NewMemoryStorage(DefaultCacheCapacity)
+	}
+
+	return c
+}
+
+// HeaderByHeight returns the header of the block at the given height.
+func (c *Cache) HeaderByHeight(ctx context.Context, height uint64) (*flow.BlockHeader, error) {
+	blockID, ok, err := c.storage.ID(height)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		header, ok, err := c.storage.Header(blockID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			c.hit()
+			return &header, nil
+		}
+	}
+
+	c.miss()
+
+	header, err := c.client.GetBlockHeaderByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.AddHeader(*header)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// HeaderByID returns the header of the block with the given ID.
+func (c *Cache) HeaderByID(ctx context.Context, blockID flow.Identifier) (*flow.BlockHeader, error) {
+	header, ok, err := c.storage.Header(blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		c.hit()
+		return &header, nil
+	}
+
+	c.miss()
+
+	fetched, err := c.client.GetBlockHeaderByID(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.AddHeader(*fetched)
+	if err != nil {
+		return nil, err
+	}
+
+	return fetched, nil
+}
+
+// IDAtHeight returns the ID of the block at the given height.
+func (c *Cache) IDAtHeight(ctx context.Context, height uint64) (flow.Identifier, error) {
+	blockID, ok, err := c.storage.ID(height)
+	if err != nil {
+		return flow.EmptyID, err
+	}
+
+	if ok {
+		c.hit()
+		return blockID, nil
+	}
+
+	header, err := c.HeaderByHeight(ctx, height)
+	if err != nil {
+		return flow.EmptyID, err
+	}
+
+	return header.ID, nil
+}
+
+// HeightOf returns the height of the block with the given ID.
+func (c *Cache) HeightOf(ctx context.Context, blockID flow.Identifier) (uint64, error) {
+	header, err := c.HeaderByID(ctx, blockID)
+	if err != nil {
+		return 0, err
+	}
+
+	return header.Height, nil
+}
+
+// AddHeader adds a block header to the cache.
+func (c *Cache) AddHeader(header flow.BlockHeader) error {
+	err := c.storage.PutHeader(header)
+	if err != nil {
+		return err
+	}
+
+	return c.storage.PutID(header.Height, header.ID)
+}
+
+// ObserveBlock adds the header of a block to the cache.
+//
+// It has the signature of follower.BlockHandler, so it can be chained with the handler of a follower.
+func (c *Cache) ObserveBlock(_ context.Context, block *flow.Block) error {
+	return c.AddHeader(block.BlockHeader)
+}
+
+// ObserveEvents records the ID of the block the events were emitted in.
+//
+// It has the signature of events.Handler, so it can be chained with the handler of an
+// event poller or subscription.
+func (c *Cache) ObserveEvents(_ context.Context, block client.BlockEvents) error {
+	return c.storage.PutID(block.Height, block.BlockID)
+}
+
+// Stats returns the lookup statistics of the cache.
+func (c *Cache) Stats() CacheStats {
+	return CacheStats{
+		Hits:   atomic.LoadUint64(&c.hits),
+		Misses: atomic.LoadUint64(&c.misses),
+	}
+}
+
+func (c *Cache) hit() {
+	atomic.AddUint64(&c.hits, 1)
+}
+
+func (c *Cache) miss() {
+	atomic.AddUint64(&c.misses, 1)
+}
@@ -0,0 +1,164 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package blocks_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/blocks"
+	"github.com/onflow/flow-go-sdk/client"
+)
+
+// fakeHeaders serves headers of a linear chain in which the block at height h has ID h.
+type fakeHeaders struct {
+	calls int
+}
+
+func idAt(height uint64) flow.Identifier {
+	var id flow.Identifier
+	binary.BigEndian.PutUint64(id[:], height)
+	return id
+}
+
+func headerAt(height uint64) flow.BlockHeader {
+	return flow.BlockHeader{
+		ID:       idAt(height),
+		ParentID: idAt(height - 1),
+		Height:   height,
+	}
+}
+
+func (c *fakeHeaders) GetBlockHeaderByID(_ context.Context, blockID flow.Identifier, _ ...grpc.CallOption) (*flow.BlockHeader, error) {
+	c.calls++
+
+	height := binary.BigEndian.Uint64(blockID[:8])
+	if height == 0 {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+
+	header := headerAt(height)
+	return &header, nil
+}
+
+func (c *fakeHeaders) GetBlockHeaderByHeight(_ context.Context, height uint64, _ ...grpc.CallOption) (*flow.BlockHeader, error) {
+	c.calls++
+
+	header := headerAt(height)
+	return &header, nil
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Lookups", func(t *testing.T) {
+		c := &fakeHeaders{}
+		cache := blocks.NewCache(c)
+
+		header, err := cache.HeaderByHeight(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, headerAt(10), *header)
+
+		header, err = cache.HeaderByID(ctx, idAt(10))
+		require.NoError(t, err)
+		assert.Equal(t, headerAt(10), *header)
+
+		id, err := cache.IDAtHeight(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, idAt(10), id)
+
+		height, err := cache.HeightOf(ctx, idAt(11))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(11), height)
+
+		id, err = cache.IDAtHeight(ctx, 11)
+		require.NoError(t, err)
+		assert.Equal(t, idAt(11), id)
+
+		assert.Equal(t, 2, c.calls)
+		assert.Equal(t, blocks.CacheStats{Hits: 3, Misses: 2}, cache.Stats())
+	})
+
+	t.Run("Warmed by follower and events", func(t *testing.T) {
+		c := &fakeHeaders{}
+		cache := blocks.NewCache(c)
+
+		err := cache.ObserveBlock(ctx, &flow.Block{BlockHeader: headerAt(5)})
+		require.NoError(t, err)
+
+		err = cache.ObserveEvents(ctx, client.BlockEvents{BlockID: idAt(6), Height: 6})
+		require.NoError(t, err)
+
+		header, err := cache.HeaderByHeight(ctx, 5)
+		require.NoError(t, err)
+		assert.Equal(t, headerAt(5), *header)
+
+		id, err := cache.IDAtHeight(ctx, 6)
+		require.NoError(t, err)
+		assert.Equal(t, idAt(6), id)
+
+		assert.Equal(t, 0, c.calls)
+
+		// events only provide the block ID, so the header is fetched
+		header, err = cache.HeaderByHeight(ctx, 6)
+		require.NoError(t, err)
+		assert.Equal(t, headerAt(6), *header)
+		assert.Equal(t, 1, c.calls)
+	})
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		c := &fakeHeaders{}
+		cache := blocks.NewCache(c)
+
+		_, err := cache.HeaderByID(ctx, flow.EmptyID)
+		assert.Error(t, err)
+
+		_, err = cache.HeaderByID(ctx, flow.EmptyID)
+		assert.Error(t, err)
+
+		assert.Equal(t, 2, c.calls)
+	})
+
+	t.Run("Bounded memory storage", func(t *testing.T) {
+		c := &fakeHeaders{}
+		cache := blocks.NewCache(c, blocks.WithStorage(blocks.NewMemoryStorage(2)))
+
+		for height := uint64(1); height <= 3; height++ {
+			_, err := cache.HeaderByHeight(ctx, height)
+			require.NoError(t, err)
+		}
+
+		// height 1 was evicted
+		_, err := cache.HeaderByHeight(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 4, c.calls)
+
+		_, err = cache.HeaderByHeight(ctx, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 4, c.calls)
+	})
+}
@@ -0,0 +1,231 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package blocks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// A BlockClient is the subset of the Flow Access API client used to fetch ranges of blocks.
+type BlockClient interface {
+	GetBlockByHeight(ctx context.Context, height uint64, opts ...grpc.CallOption) (*flow.Block, error)
+	GetBlockHeaderByHeight(ctx context.Context, height uint64, opts ...grpc.CallOption) (*flow.BlockHeader, error)
+}
+
+// A RangeIterator delivers the blocks of a height range in order while they are
+// fetched concurrently in the background.
+//
+// Iteration follows the pattern of bufio.Scanner:
+//
+//	it := blocks.FetchRange(ctx, client, from, to, 8)
+//	defer it.Close()
+//
+//	for it.Next() {
+//		block := it.Block()
+//		...
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type RangeIterator struct {
+	ctx      context.Context
+	ordered  chan chan fetchResult
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	complete bool
+
+	header *flow.BlockHeader
+	block  *flow.Block
+	err    error
+	done   bool
+}
+
+type fetchResult struct {
+	header *flow.BlockHeader
+	block  *flow.Block
+	err    error
+}
+
+type fetchFunc func(ctx context.Context, height uint64) fetchResult
+
+// FetchRange fetches the blocks between the given heights (inclusive) using the given
+// number of concurrent requests, and returns an iterator delivering them in height order.
+//
+// At most twice as many blocks as the concurrency are buffered at any time, so a slow
+// consumer applies backpressure to the fetchers. Iteration stops at the first failed
+// request, and the error is reported by Err.
+//
+// The iterator must be closed to release its resources if it is not iterated to its end.
+func FetchRange(ctx context.Context, client BlockClient, from, to uint64, concurrency int) *RangeIterator {
+	return fetchRange(ctx, from, to, concurrency, func(ctx context.Context, height uint64) fetchResult {
+		block, err := client.GetBlockByHeight(ctx, height)
+		if err != nil {
+			return fetchResult{err: fmt.Errorf("blocks: failed to fetch block at height %d: %w", height, err)}
+		}
+		return fetchResult{header: &block.BlockHeader, block: block}
+	})
+}
+
+// FetchHeaderRange behaves like FetchRange, but only fetches block headers.
+//
+// Block returns nil for iterators created by this function.
+func FetchHeaderRange(ctx context.Context, client BlockClient, from, to uint64, concurrency int) *RangeIterator {
+	return fetchRange(ctx, from, to, concurrency, func(ctx context.Context, height uint64) fetchResult {
+		header, err := client.GetBlockHeaderByHeight(ctx, height)
+		if err != nil {
+			return fetchResult{err: fmt.Errorf("blocks: failed to fetch block header at height %d: %w", height, err)}
+		}
+		return fetchResult{header: header}
+	})
+}
+
+func fetchRange(ctx context.Context, from, to uint64, concurrency int, fetch fetchFunc) *RangeIterator {
+	if from > to {
+		return &RangeIterator{err: flowerrors.Errorf(flowerrors.ErrInvalidArgument, "blocks: start height %d is greater than end height %d", from, to)}
+	}
+
+	if concurrency <= 0 {
+		return &RangeIterator{err: flowerrors.Errorf(flowerrors.ErrInvalidArgument, "blocks: concurrency must be greater than zero")}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	it := &RangeIterator{
+		ctx:     ctx,
+		ordered: make(chan chan fetchResult, concurrency),
+		cancel:  cancel,
+	}
+
+	type request struct {
+		height uint64
+		result chan fetchResult
+	}
+
+	requests := make(chan request)
+
+	for i := 0; i < concurrency; i++ {
+		it.wg.Add(1)
+		go func() {
+			defer it.wg.Done()
+			for req := range requests {
+				req.result <- fetch(ctx, req.height)
+			}
+		}()
+	}
+
+	it.wg.Add(1)
+	go func() {
+		defer it.wg.Done()
+		defer close(it.ordered)
+		defer close(requests)
+
+		for height := from; ; height++ {
+			// each result is buffered, so workers never wait for the consumer
+			result := make(chan fetchResult, 1)
+
+			select {
+			case it.ordered <- result:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case requests <- request{height: height, result: result}:
+			case <-ctx.Done():
+				result <- fetchResult{err: ctx.Err()}
+				return
+			}
+
+			if height == to {
+				it.complete = true
+				return
+			}
+		}
+	}()
+
+	return it
+}
+
+// Next advances the iterator to the next block, which is then available through
+// Header and Block.
+//
+// It returns false when the end of the range is reached or an error occurs.
+func (it *RangeIterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	result, ok := <-it.ordered
+	if !ok {
+		if !it.complete {
+			// the range was not exhausted, so the context was cancelled
+			it.err = it.ctx.Err()
+		}
+		it.done = true
+		it.Close()
+		return false
+	}
+
+	r := <-result
+	if r.err != nil {
+		it.err = r.err
+		it.Close()
+		return false
+	}
+
+	it.header = r.header
+	it.block = r.block
+
+	return true
+}
+
+// Header returns the header of the current block.
+func (it *RangeIterator) Header() *flow.BlockHeader {
+	return it.header
+}
+
+// Block returns the current block.
+func (it *RangeIterator) Block() *flow.Block {
+	return it.block
+}
+
+// Err returns the first error encountered during iteration.
+func (it *RangeIterator) Err() error {
+	return it.err
+}
+
+// Close stops all outstanding requests and waits for them to return.
+//
+// It is safe to call Close multiple times.
+func (it *RangeIterator) Close() {
+	if it.cancel == nil {
+		return
+	}
+
+	it.cancel()
+	it.wg.Wait()
+}
@@ -0,0 +1,204 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package blocks_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/blocks"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// fakeBlocks serves blocks after a random delay, failing at the configured height.
+type fakeBlocks struct {
+	mut      sync.Mutex
+	rand     *rand.Rand
+	failAt   uint64
+	inFlight int32
+	maxIn    int32
+	fetched  int32
+	block    chan struct{}
+}
+
+func newFakeBlocks() *fakeBlocks {
+	return &fakeBlocks{rand: rand.New(rand.NewSource(42))}
+}
+
+func (c *fakeBlocks) fetch(ctx context.Context, height uint64) (*flow.BlockHeader, error) {
+	n := atomic.AddInt32(&c.inFlight, 1)
+	defer atomic.AddInt32(&c.inFlight, -1)
+	atomic.AddInt32(&c.fetched, 1)
+
+	c.mut.Lock()
+	if n > c.maxIn {
+		c.maxIn = n
+	}
+	delay := time.Duration(c.rand.Intn(1000)) * time.Microsecond
+	c.mut.Unlock()
+
+	if c.block != nil {
+		select {
+		case <-c.block:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	time.Sleep(delay)
+
+	if c.failAt != 0 && height == c.failAt {
+		return nil, errors.New("unavailable")
+	}
+
+	header := headerAt(height)
+	return &header, nil
+}
+
+func (c *fakeBlocks) GetBlockByHeight(ctx context.Context, height uint64, _ ...grpc.CallOption) (*flow.Block, error) {
+	header, err := c.fetch(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return &flow.Block{BlockHeader: *header}, nil
+}
+
+func (c *fakeBlocks) GetBlockHeaderByHeight(ctx context.Context, height uint64, _ ...grpc.CallOption) (*flow.BlockHeader, error) {
+	return c.fetch(ctx, height)
+}
+
+func TestFetchRange(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Ordered", func(t *testing.T) {
+		c := newFakeBlocks()
+
+		it := blocks.FetchRange(ctx, c, 10, 209, 8)
+		defer it.Close()
+
+		next := uint64(10)
+		for it.Next() {
+			assert.Equal(t, next, it.Block().Height)
+			assert.Equal(t, next, it.Header().Height)
+			next++
+		}
+
+		require.NoError(t, it.Err())
+		assert.Equal(t, uint64(210), next)
+		assert.LessOrEqual(t, c.maxIn, int32(8))
+	})
+
+	t.Run("Single block", func(t *testing.T) {
+		it := blocks.FetchRange(ctx, newFakeBlocks(), 5, 5, 4)
+		defer it.Close()
+
+		require.True(t, it.Next())
+		assert.Equal(t, uint64(5), it.Block().Height)
+		assert.False(t, it.Next())
+		assert.NoError(t, it.Err())
+	})
+
+	t.Run("Headers", func(t *testing.T) {
+		it := blocks.FetchHeaderRange(ctx, newFakeBlocks(), 0, 49, 4)
+		defer it.Close()
+
+		next := uint64(0)
+		for it.Next() {
+			assert.Equal(t, next, it.Header().Height)
+			assert.Nil(t, it.Block())
+			next++
+		}
+
+		require.NoError(t, it.Err())
+		assert.Equal(t, uint64(50), next)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		c := newFakeBlocks()
+		c.failAt = 30
+
+		it := blocks.FetchRange(ctx, c, 0, 99, 4)
+		defer it.Close()
+
+		next := uint64(0)
+		for it.Next() {
+			next++
+		}
+
+		assert.Equal(t, uint64(30), next)
+		assert.Error(t, it.Err())
+		assert.False(t, it.Next())
+	})
+
+	t.Run("Backpressure", func(t *testing.T) {
+		c := newFakeBlocks()
+
+		it := blocks.FetchRange(ctx, c, 0, 999, 4)
+		defer it.Close()
+
+		require.True(t, it.Next())
+		time.Sleep(50 * time.Millisecond)
+
+		assert.LessOrEqual(t, atomic.LoadInt32(&c.fetched), int32(3*4))
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		c := newFakeBlocks()
+		c.block = make(chan struct{})
+
+		ctx, cancel := context.WithCancel(ctx)
+
+		it := blocks.FetchRange(ctx, c, 0, 99, 4)
+		defer it.Close()
+
+		cancel()
+
+		assert.False(t, it.Next())
+		assert.True(t, errors.Is(it.Err(), context.Canceled))
+	})
+
+	t.Run("Close early", func(t *testing.T) {
+		it := blocks.FetchRange(ctx, newFakeBlocks(), 0, 999, 4)
+
+		require.True(t, it.Next())
+		it.Close()
+		it.Close()
+	})
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		it := blocks.FetchRange(ctx, newFakeBlocks(), 10, 5, 4)
+		assert.False(t, it.Next())
+		assert.ErrorIs(t, it.Err(), flowerrors.ErrInvalidArgument)
+		it.Close()
+
+		it = blocks.FetchRange(ctx, newFakeBlocks(), 0, 5, 0)
+		assert.False(t, it.Next())
+		assert.Error(t, it.Err())
+	})
+}
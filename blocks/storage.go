@@ -0,0 +1,132 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package blocks
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// A MemoryStorage is a Storage that keeps a bounded number of entries in memory,
+// evicting the least recently used ones.
+type MemoryStorage struct {
+	mut     sync.Mutex
+	ids     *lru
+	headers *lru
+}
+
+// NewMemoryStorage creates an in-memory storage holding up to capacity block IDs and
+// capacity headers.
+func NewMemoryStorage(capacity int) *MemoryStorage {
+	return &MemoryStorage{
+		ids:     newLRU(capacity),
+		headers: newLRU(capacity),
+	}
+}
+
+func (s *MemoryStorage) ID(height uint64) (flow.Identifier, bool, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	value, ok := s.ids.get(height)
+	if !ok {
+		return flow.EmptyID, false, nil
+	}
+
+	return value.(flow.Identifier), true, nil
+}
+
+func (s *MemoryStorage) PutID(height uint64, blockID flow.Identifier) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.ids.put(height, blockID)
+
+	return nil
+}
+
+func (s *MemoryStorage) Header(blockID flow.Identifier) (flow.BlockHeader, bool, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	value, ok := s.headers.get(blockID)
+	if !ok {
+		return flow.BlockHeader{}, false, nil
+	}
+
+	return value.(flow.BlockHeader), true, nil
+}
+
+func (s *MemoryStorage) PutHeader(header flow.BlockHeader) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.headers.put(header.ID, header)
+
+	return nil
+}
+
+// lru is a least recently used cache. It is not safe for concurrent use.
+type lru struct {
+	capacity int
+	order    *list.List
+	items    map[interface{}]*list.Element
+}
+
+type lruEntry struct {
+	key   interface{}
+	value interface{}
+}
+
+func newLRU(capacity int) *lru {
+	return &lru{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[interface{}]*list.Element),
+	}
+}
+
+func (c *lru) get(key interface{}) (interface{}, bool) {
+	element, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+
+	return element.Value.(*lruEntry).value, true
+}
+
+func (c *lru) put(key interface{}, value interface{}) {
+	if element, ok := c.items[key]; ok {
+		element.Value.(*lruEntry).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
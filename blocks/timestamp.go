@@ -0,0 +1,155 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package blocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// A TimestampClient is the subset of the Flow Access API client used to search blocks by timestamp.
+type TimestampClient interface {
+	GetBlockHeaderByHeight(ctx context.Context, height uint64, opts ...grpc.CallOption) (*flow.BlockHeader, error)
+	GetLatestBlockHeader(ctx context.Context, isSealed bool, opts ...grpc.CallOption) (*flow.BlockHeader, error)
+}
+
+// ErrTimestampOutOfRange is returned when searching for a timestamp outside the searched height range.
+var ErrTimestampOutOfRange = errors.New("blocks: timestamp is outside of the searched range")
+
+// HeaderAtTimestamp returns the header of the last block with a timestamp at or before t,
+// searching between the given heights (inclusive).
+//
+// Block timestamps increase with height, so the block is found with a binary search
+// requiring O(log n) requests.
+//
+// ErrTimestampOutOfRange is returned if the block at startHeight is later than t.
+func HeaderAtTimestamp(
+	ctx context.Context,
+	client TimestampClient,
+	t time.Time,
+	startHeight uint64,
+	endHeight uint64,
+) (*flow.BlockHeader, error) {
+	if startHeight > endHeight {
+		return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "blocks: start height %d is greater than end height %d", startHeight, endHeight)
+	}
+
+	low, err := client.GetBlockHeaderByHeight(ctx, startHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	if low.Timestamp.After(t) {
+		return nil, fmt.Errorf("%w: block %d at %s is after %s", ErrTimestampOutOfRange, low.Height, low.Timestamp, t)
+	}
+
+	high, err := client.GetBlockHeaderByHeight(ctx, endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	if !high.Timestamp.After(t) {
+		return high, nil
+	}
+
+	// invariant: low is at or before t, high is after t
+	for high.Height-low.Height > 1 {
+		// interpolate between the bounds, which converges much faster than bisection
+		// because blocks are produced at a roughly constant rate
+		mid := interpolate(low, high, t)
+
+		header, err := client.GetBlockHeaderByHeight(ctx, mid)
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Timestamp.After(t) {
+			high = header
+		} else {
+			low = header
+		}
+	}
+
+	return low, nil
+}
+
+// HeaderAtTimestampLatest is like HeaderAtTimestamp, searching up to the latest sealed block.
+func HeaderAtTimestampLatest(
+	ctx context.Context,
+	client TimestampClient,
+	t time.Time,
+	startHeight uint64,
+) (*flow.BlockHeader, error) {
+	latest, err := client.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return HeaderAtTimestamp(ctx, client, t, startHeight, latest.Height)
+}
+
+// TimestampAtHeight returns the timestamp of the block at the given height.
+func TimestampAtHeight(ctx context.Context, client TimestampClient, height uint64) (time.Time, error) {
+	header, err := client.GetBlockHeaderByHeight(ctx, height)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return header.Timestamp, nil
+}
+
+// interpolate estimates the height of the block at t, strictly between low and high.
+//
+// To guarantee the logarithmic bound of a binary search, the estimate is clamped to the
+// middle half of the range.
+func interpolate(low *flow.BlockHeader, high *flow.BlockHeader, t time.Time) uint64 {
+	span := high.Height - low.Height
+
+	estimate := low.Height + span/2
+
+	elapsed := high.Timestamp.Sub(low.Timestamp)
+	if elapsed > 0 {
+		fraction := float64(t.Sub(low.Timestamp)) / float64(elapsed)
+		estimate = low.Height + uint64(fraction*float64(span))
+	}
+
+	quarter := span / 4
+	if estimate < low.Height+quarter {
+		estimate = low.Height + quarter
+	}
+	if estimate > high.Height-quarter {
+		estimate = high.Height - quarter
+	}
+
+	if estimate <= low.Height {
+		estimate = low.Height + 1
+	}
+	if estimate >= high.Height {
+		estimate = high.Height - 1
+	}
+
+	return estimate
+}
@@ -0,0 +1,149 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package blocks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/blocks"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+var genesisTime = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// fakeTimestamps serves a chain of headers in which the block at height h
+// is produced at gaps[h] after the previous block.
+type fakeTimestamps struct {
+	timestamps []time.Time
+	requests   int
+}
+
+func newFakeTimestamps(gaps ...time.Duration) *fakeTimestamps {
+	timestamps := make([]time.Time, len(gaps))
+	t := genesisTime
+	for i, gap := range gaps {
+		t = t.Add(gap)
+		timestamps[i] = t
+	}
+	return &fakeTimestamps{timestamps: timestamps}
+}
+
+func (c *fakeTimestamps) GetBlockHeaderByHeight(_ context.Context, height uint64, _ ...grpc.CallOption) (*flow.BlockHeader, error) {
+	c.requests++
+	if height >= uint64(len(c.timestamps)) {
+		return nil, errors.New("not found")
+	}
+	return &flow.BlockHeader{Height: height, Timestamp: c.timestamps[height]}, nil
+}
+
+func (c *fakeTimestamps) GetLatestBlockHeader(ctx context.Context, _ bool, _ ...grpc.CallOption) (*flow.BlockHeader, error) {
+	return c.GetBlockHeaderByHeight(ctx, uint64(len(c.timestamps)-1))
+}
+
+func uniformGaps(n int, gap time.Duration) []time.Duration {
+	gaps := make([]time.Duration, n)
+	for i := range gaps {
+		gaps[i] = gap
+	}
+	return gaps
+}
+
+func TestHeaderAtTimestamp(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Exact", func(t *testing.T) {
+		c := newFakeTimestamps(uniformGaps(1000, time.Second)...)
+
+		header, err := blocks.HeaderAtTimestamp(ctx, c, c.timestamps[421], 0, 999)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(421), header.Height)
+	})
+
+	t.Run("Between blocks", func(t *testing.T) {
+		c := newFakeTimestamps(uniformGaps(1000, time.Second)...)
+
+		header, err := blocks.HeaderAtTimestamp(ctx, c, c.timestamps[421].Add(500*time.Millisecond), 0, 999)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(421), header.Height)
+	})
+
+	t.Run("Irregular block times", func(t *testing.T) {
+		gaps := uniformGaps(10000, time.Second)
+		for i := 5000; i < 5100; i++ {
+			gaps[i] = time.Hour
+		}
+		c := newFakeTimestamps(gaps...)
+
+		for _, height := range []uint64{0, 1, 4999, 5000, 5050, 5099, 5100, 9998, 9999} {
+			header, err := blocks.HeaderAtTimestamp(ctx, c, c.timestamps[height], 0, 9999)
+			require.NoError(t, err)
+			assert.Equal(t, height, header.Height)
+		}
+	})
+
+	t.Run("Logarithmic requests", func(t *testing.T) {
+		c := newFakeTimestamps(uniformGaps(1<<16, time.Second)...)
+
+		_, err := blocks.HeaderAtTimestamp(ctx, c, c.timestamps[12345], 0, 1<<16-1)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, c.requests, 2+2*16)
+	})
+
+	t.Run("After end", func(t *testing.T) {
+		c := newFakeTimestamps(uniformGaps(100, time.Second)...)
+
+		header, err := blocks.HeaderAtTimestamp(ctx, c, c.timestamps[99].Add(time.Hour), 0, 50)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(50), header.Height)
+	})
+
+	t.Run("Before start", func(t *testing.T) {
+		c := newFakeTimestamps(uniformGaps(100, time.Second)...)
+
+		_, err := blocks.HeaderAtTimestamp(ctx, c, genesisTime, 10, 99)
+		assert.True(t, errors.Is(err, blocks.ErrTimestampOutOfRange))
+	})
+
+	t.Run("Invalid range", func(t *testing.T) {
+		c := newFakeTimestamps(uniformGaps(100, time.Second)...)
+
+		_, err := blocks.HeaderAtTimestamp(ctx, c, genesisTime, 10, 5)
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+	})
+
+	t.Run("Latest", func(t *testing.T) {
+		c := newFakeTimestamps(uniformGaps(100, time.Second)...)
+
+		header, err := blocks.HeaderAtTimestampLatest(ctx, c, c.timestamps[77], 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(77), header.Height)
+
+		timestamp, err := blocks.TimestampAtHeight(ctx, c, 77)
+		require.NoError(t, err)
+		assert.Equal(t, c.timestamps[77], timestamp)
+	})
+}
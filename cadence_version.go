@@ -0,0 +1,56 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// A CadenceVersion is a version of the Cadence language targeted by the code
+// sent to a network, e.g. the built-in transaction templates.
+type CadenceVersion int
+
+const (
+	// CadenceV0 is the Cadence language before version 1.0, run by the networks
+	// before the Crescendo upgrade.
+	CadenceV0 CadenceVersion = iota
+	// CadenceV1 is Cadence 1.0, run by the networks since the Crescendo upgrade.
+	CadenceV1
+)
+
+func (v CadenceVersion) String() string {
+	switch v {
+	case CadenceV0:
+		return "pre-1.0"
+	case CadenceV1:
+		return "1.0"
+	default:
+		return "unknown"
+	}
+}
+
+// Validate returns an error matching flowerrors.ErrInvalidArgument if the version
+// is not one of the versions of Cadence known to the SDK.
+func (v CadenceVersion) Validate() error {
+	if v != CadenceV0 && v != CadenceV1 {
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "unknown Cadence version %d", int(v))
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.7 --name AccessClient --output mocks --outpkg mocks --filename access_client.go
+
+import (
+	"context"
+
+	"github.com/onflow/cadence"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// An AccessClient reads state from and submits transactions to the Flow Access API.
+//
+// AccessClient is implemented by Client. Code that depends on an AccessClient instead of a
+// *Client can be tested against the generated mock in the client/mocks package.
+//
+// Helpers built on top of the Access API are not part of the interface. For example,
+// the Snapshot method of Client is available for any AccessClient as NewSnapshot.
+type AccessClient interface {
+	Close() error
+	Ping(ctx context.Context, opts ...grpc.CallOption) error
+	GetNodeVersionInfo(ctx context.Context, opts ...grpc.CallOption) (*NodeVersionInfo, error)
+	Capabilities(ctx context.Context, opts ...grpc.CallOption) (*Capabilities, error)
+	DetectCapabilities(ctx context.Context, opts ...grpc.CallOption) (*Capabilities, error)
+
+	// Blocks
+
+	GetLatestBlockHeader(ctx context.Context, isSealed bool, opts ...grpc.CallOption) (*flow.BlockHeader, error)
+	GetLatestSealedBlockHeader(ctx context.Context, opts ...grpc.CallOption) (*flow.BlockHeader, error)
+	GetLatestFinalizedBlockHeader(ctx context.Context, opts ...grpc.CallOption) (*flow.BlockHeader, error)
+	GetBlockHeaderByID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) (*flow.BlockHeader, error)
+	GetBlockHeaderByHeight(ctx context.Context, height uint64, opts ...grpc.CallOption) (*flow.BlockHeader, error)
+	GetChainStatus(ctx context.Context, opts ...grpc.CallOption) (*ChainStatus, error)
+	GetLatestBlock(ctx context.Context, isSealed bool, opts ...grpc.CallOption) (*flow.Block, error)
+	GetLatestSealedBlock(ctx context.Context, opts ...grpc.CallOption) (*flow.Block, error)
+	GetLatestFinalizedBlock(ctx context.Context, opts ...grpc.CallOption) (*flow.Block, error)
+	GetBlockByID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) (*flow.Block, error)
+	GetBlockByHeight(ctx context.Context, height uint64, opts ...grpc.CallOption) (*flow.Block, error)
+	GetBlockWithTransactionsAndResults(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) (*BlockWithTransactions, error)
+	GetBlockByHeightWithTransactionsAndResults(ctx context.Context, height uint64, opts ...grpc.CallOption) (*BlockWithTransactions, error)
+
+	// Collections and transactions
+
+	GetCollection(ctx context.Context, colID flow.Identifier, opts ...grpc.CallOption) (*flow.Collection, error)
+	SendTransaction(ctx context.Context, tx flow.Transaction, opts ...grpc.CallOption) error
+	GetTransaction(ctx context.Context, txID flow.Identifier, opts ...grpc.CallOption) (*flow.Transaction, error)
+	GetTransactionResult(ctx context.Context, txID flow.Identifier, opts ...grpc.CallOption) (*flow.TransactionResult, error)
+	WaitForStatus(ctx context.Context, txID flow.Identifier, target flow.TransactionStatus, opts ...grpc.CallOption) (*flow.TransactionResult, error)
+	SendAndWaitForStatus(ctx context.Context, tx flow.Transaction, target flow.TransactionStatus, opts ...grpc.CallOption) (*flow.TransactionResult, error)
+
+	// Accounts and scripts
+
+	GetAccount(ctx context.Context, address flow.Address, opts ...grpc.CallOption) (*flow.Account, error)
+	GetAccountAtLatestBlock(ctx context.Context, address flow.Address, opts ...grpc.CallOption) (*flow.Account, error)
+	GetAccountAtBlockHeight(ctx context.Context, address flow.Address, blockHeight uint64, opts ...grpc.CallOption) (*flow.Account, error)
+	ExecuteScriptAtLatestBlock(ctx context.Context, script []byte, arguments []cadence.Value, opts ...grpc.CallOption) (cadence.Value, error)
+	ExecuteScriptAtBlockID(ctx context.Context, blockID flow.Identifier, script []byte, arguments []cadence.Value, opts ...grpc.CallOption) (cadence.Value, error)
+	ExecuteScriptAtBlockHeight(ctx context.Context, height uint64, script []byte, arguments []cadence.Value, opts ...grpc.CallOption) (cadence.Value, error)
+
+	// Events
+
+	GetEventsForHeightRange(ctx context.Context, query EventRangeQuery, opts ...grpc.CallOption) ([]BlockEvents, error)
+	GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier, opts ...grpc.CallOption) ([]BlockEvents, error)
+	GetEventsForHeightRangeWithFilter(ctx context.Context, filter flow.EventFilter, startHeight uint64, endHeight uint64, opts ...grpc.CallOption) ([]BlockEvents, error)
+	GetEventsForBlockIDsWithFilter(ctx context.Context, filter flow.EventFilter, blockIDs []flow.Identifier, opts ...grpc.CallOption) ([]BlockEvents, error)
+	SubscribeEventsByBlockHeight(ctx context.Context, startHeight uint64, filter flow.EventFilter, opts ...grpc.CallOption) (<-chan BlockEvents, <-chan error, error)
+	SubscribeAccountStatusesByBlockHeight(ctx context.Context, startHeight uint64, filter flow.AccountStatusFilter, opts ...grpc.CallOption) (<-chan AccountStatus, <-chan error, error)
+
+	// Protocol state and execution results
+
+	GetLatestProtocolStateSnapshot(ctx context.Context, opts ...grpc.CallOption) ([]byte, error)
+	GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) (*flow.ExecutionResult, error)
+	GetExecutionResultByID(ctx context.Context, resultID flow.Identifier, opts ...grpc.CallOption) (*flow.ExecutionResult, error)
+	GetServiceEventsForBlockID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) ([]*flow.ServiceEvent, error)
+	GetServiceEventsForBlockHeight(ctx context.Context, height uint64, opts ...grpc.CallOption) ([]*flow.ServiceEvent, error)
+	GetDecodedServiceEventsForBlockID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) ([]interface{}, error)
+	GetExecutionDataByBlockID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) (*flow.BlockExecutionData, error)
+	GetRegisterValues(ctx context.Context, blockHeight uint64, registerIDs []flow.RegisterID, opts ...grpc.CallOption) ([][]byte, error)
+
+	// Cadence versions
+
+	CadenceVersion() flow.CadenceVersion
+	SetCadenceVersion(version flow.CadenceVersion) error
+	DetectCadenceVersion(ctx context.Context, opts ...grpc.CallOption) (flow.CadenceVersion, error)
+}
+
+var _ AccessClient = (*Client)(nil)
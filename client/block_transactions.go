@@ -0,0 +1,226 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client/convert"
+)
+
+// maxConcurrentRequests is the maximum number of requests sent concurrently when
+// resolving the contents of a block.
+const maxConcurrentRequests = 16
+
+// A TransactionWithResult is a transaction together with its result.
+type TransactionWithResult struct {
+	Transaction *flow.Transaction
+	Result      *flow.TransactionResult
+}
+
+// A BlockWithTransactions is a block together with all of its collections,
+// transactions and transaction results.
+type BlockWithTransactions struct {
+	Block *flow.Block
+	// Collections are the collections of the block, in guarantee order.
+	Collections []*flow.Collection
+	// Transactions are the user transactions of the block, in execution order.
+	//
+	// The system chunk transaction, which the protocol executes after the user
+	// transactions of every block, is not part of any collection and is not included.
+	Transactions []TransactionWithResult
+}
+
+// GetBlockWithTransactionsAndResults gets a full block by ID, together with all of its
+// transactions and their results.
+//
+// Collections, transactions and results are fetched concurrently, with a bounded number
+// of requests in flight. The call fails if any of the requests fails. Results are
+// looked up by their index in the block, so they always belong to this block even if
+// the same transaction ID appears elsewhere. The system chunk transaction is not
+// included; see BlockWithTransactions.
+func (c *Client) GetBlockWithTransactionsAndResults(
+	ctx context.Context,
+	blockID flow.Identifier,
+	opts ...grpc.CallOption,
+) (*BlockWithTransactions, error) {
+	block, err := c.GetBlockByID(ctx, blockID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.resolveBlock(ctx, block, opts...)
+}
+
+// GetBlockByHeightWithTransactionsAndResults gets a full block by height, together with all of
+// its transactions and their results.
+func (c *Client) GetBlockByHeightWithTransactionsAndResults(
+	ctx context.Context,
+	height uint64,
+	opts ...grpc.CallOption,
+) (*BlockWithTransactions, error) {
+	block, err := c.GetBlockByHeight(ctx, height, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.resolveBlock(ctx, block, opts...)
+}
+
+func (c *Client) resolveBlock(
+	ctx context.Context,
+	block *flow.Block,
+	opts ...grpc.CallOption,
+) (*BlockWithTransactions, error) {
+	collections := make([]*flow.Collection, len(block.CollectionGuarantees))
+
+	err := forEachConcurrently(ctx, len(collections), func(ctx context.Context, i int) error {
+		collection, err := c.GetCollection(ctx, block.CollectionGuarantees[i].CollectionID, opts...)
+		if err != nil {
+			return err
+		}
+
+		collections[i] = collection
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionIDs []flow.Identifier
+	for _, collection := range collections {
+		transactionIDs = append(transactionIDs, collection.TransactionIDs...)
+	}
+
+	transactions := make([]TransactionWithResult, len(transactionIDs))
+
+	// each transaction needs two requests, which are scheduled as separate tasks
+	err = forEachConcurrently(ctx, 2*len(transactionIDs), func(ctx context.Context, i int) error {
+		txID := transactionIDs[i/2]
+
+		if i%2 == 0 {
+			tx, err := c.GetTransaction(ctx, txID, opts...)
+			if err != nil {
+				return err
+			}
+			transactions[i/2].Transaction = tx
+			return nil
+		}
+
+		result, err := c.getTransactionResultByIndex(ctx, block.ID, uint32(i/2), opts...)
+		if err != nil {
+			return err
+		}
+		transactions[i/2].Result = result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockWithTransactions{
+		Block:        block,
+		Collections:  collections,
+		Transactions: transactions,
+	}, nil
+}
+
+// getTransactionResultByIndex gets the result of the transaction at the given index
+// of a block, in execution order.
+func (c *Client) getTransactionResultByIndex(
+	ctx context.Context,
+	blockID flow.Identifier,
+	index uint32,
+	opts ...grpc.CallOption,
+) (*flow.TransactionResult, error) {
+	req := &access.GetTransactionByIndexRequest{
+		BlockId: blockID.Bytes(),
+		Index:   index,
+	}
+
+	res, err := c.rpcClient.GetTransactionResultByIndex(ctx, req, opts...)
+	if err != nil {
+		return nil, newRPCError(err)
+	}
+
+	res, err = c.legacyTransactionResult(res)
+	if err != nil {
+		return nil, newMessageToEntityError(entityTransactionResult, err)
+	}
+
+	result, err := convert.MessageToTransactionResult(res)
+	if err != nil {
+		return nil, newMessageToEntityError(entityTransactionResult, err)
+	}
+
+	return &result, nil
+}
+
+// forEachConcurrently calls f for every index in [0, n) with at most maxConcurrentRequests
+// calls in flight, and returns the first error. Remaining calls are cancelled after an error.
+func forEachConcurrently(ctx context.Context, n int, f func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	sem := make(chan struct{}, maxConcurrentRequests)
+
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := f(ctx, i)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,383 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// A CircuitState is the state of the circuit of an access node endpoint.
+type CircuitState int
+
+const (
+	// CircuitClosed is the state of a healthy endpoint: all calls are made.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen is the state of an unhealthy endpoint: calls fail with a
+	// CircuitOpenError without reaching the node.
+	CircuitOpen
+	// CircuitHalfOpen is the state of an endpoint that is probed for recovery:
+	// probe calls are made one at a time, and other calls fail with a
+	// CircuitOpenError.
+	CircuitHalfOpen
+)
+
+// String returns the string representation of a circuit state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// A CircuitOpenError is returned for calls that are short-circuited because the
+// circuit of the endpoint is open.
+//
+// It matches flowerrors.ErrUnavailable, and has the gRPC status code Unavailable.
+type CircuitOpenError struct {
+	// Target is the endpoint of the circuit.
+	Target string
+	// RetryAfter is the time until the circuit is probed for recovery, or zero if
+	// it is being probed.
+	RetryAfter time.Duration
+}
+
+func (e CircuitOpenError) Error() string {
+	if e.RetryAfter > 0 {
+		return errorMessage("circuit of %s is open, retry after %s", e.Target, e.RetryAfter)
+	}
+
+	return errorMessage("circuit of %s is open, recovery is being probed", e.Target)
+}
+
+func (e CircuitOpenError) Is(target error) bool {
+	return target == flowerrors.ErrUnavailable
+}
+
+// GRPCStatus returns the gRPC status for this error.
+func (e CircuitOpenError) GRPCStatus() *status.Status {
+	return status.New(codes.Unavailable, e.Error())
+}
+
+// Default settings of a CircuitBreaker.
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 10 * time.Second
+	defaultHalfOpenProbes   = 1
+)
+
+// A CircuitBreakerOption configures a CircuitBreaker.
+type CircuitBreakerOption func(*CircuitBreaker)
+
+// WithFailureThreshold sets the number of consecutive failed calls that open a
+// circuit. The default is 5.
+func WithFailureThreshold(n int) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.failureThreshold = n
+	}
+}
+
+// WithOpenTimeout sets how long a circuit stays open before it is probed for
+// recovery. The default is 10 seconds.
+func WithOpenTimeout(d time.Duration) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.openTimeout = d
+	}
+}
+
+// WithHalfOpenProbes sets the number of successful probe calls that close a
+// half-open circuit. The default is 1.
+func WithHalfOpenProbes(n int) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.halfOpenProbes = n
+	}
+}
+
+// WithStateChangeHook sets a function called every time a circuit changes
+// state, e.g. to log or count outages. It is called without locks held.
+func WithStateChangeHook(hook func(target string, from, to CircuitState)) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.onStateChange = hook
+	}
+}
+
+// A CircuitBreaker fails calls to unhealthy access nodes fast.
+//
+// The breaker keeps one circuit per endpoint. A circuit opens after a number of
+// consecutive calls fail with a transient error, as classified by
+// flowerrors.IsTransient: the node is unavailable, rate limits the client or does
+// not respond in time. Calls that end because their context is canceled or has
+// expired say nothing about the node, and are not recorded. While a circuit is
+// open, calls fail with a CircuitOpenError without reaching the node. Once the
+// open timeout has passed, the circuit is half-open: calls are let through one at
+// a time as probes, and the circuit closes once enough probes succeed, or opens
+// again if one fails. Streams are failed if they cannot be opened.
+//
+// The breaker is installed in clients with its dial options, and can be shared
+// by the clients of several endpoints:
+//
+//	breaker := client.NewCircuitBreaker(client.WithFailureThreshold(3))
+//	c, err := client.New(addr, append(opts, breaker.DialOptions()...)...)
+type CircuitBreaker struct {
+	failureThreshold int
+	openTimeout      time.Duration
+	halfOpenProbes   int
+	onStateChange    func(target string, from, to CircuitState)
+
+	mut      sync.Mutex
+	circuits map[string]*circuit
+}
+
+// circuit is the state of the circuit of a single endpoint.
+type circuit struct {
+	state    CircuitState
+	failures int
+	probes   int
+	probing  bool
+	openedAt time.Time
+}
+
+// NewCircuitBreaker returns a circuit breaker, with all circuits closed.
+func NewCircuitBreaker(opts ...CircuitBreakerOption) *CircuitBreaker {
+	b := &CircuitBreaker{
+		failureThreshold: defaultFailureThreshold,
+		openTimeout:      defaultOpenTimeout,
+		halfOpenProbes:   defaultHalfOpenProbes,
+		circuits:         make(map[string]*circuit),
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	if b.failureThreshold < 1 {
+		b.failureThreshold = 1
+	}
+
+	if b.halfOpenProbes < 1 {
+		b.halfOpenProbes = 1
+	}
+
+	return b
+}
+
+// DialOptions returns the dial options that install the breaker in a client.
+func (b *CircuitBreaker) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(b.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(b.StreamClientInterceptor()),
+	}
+}
+
+// State returns the state of the circuit of an endpoint, as named by the target
+// the client was created with.
+func (b *CircuitBreaker) State(target string) CircuitState {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	c, ok := b.circuits[target]
+	if !ok {
+		return CircuitClosed
+	}
+
+	if c.state == CircuitOpen && time.Since(c.openedAt) >= b.openTimeout {
+		return CircuitHalfOpen
+	}
+
+	return c.state
+}
+
+// UnaryClientInterceptor returns an interceptor applying the breaker to unary
+// calls, for clients not created with DialOptions.
+func (b *CircuitBreaker) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		target := cc.Target()
+
+		probe, err := b.allow(target)
+		if err != nil {
+			return err
+		}
+
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		b.record(target, probe, callOutcome(ctx, err))
+
+		return err
+	}
+}
+
+// StreamClientInterceptor returns an interceptor applying the breaker to the
+// opening of streams, for clients not created with DialOptions.
+func (b *CircuitBreaker) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		target := cc.Target()
+
+		probe, err := b.allow(target)
+		if err != nil {
+			return nil, err
+		}
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		b.record(target, probe, callOutcome(ctx, err))
+
+		return stream, err
+	}
+}
+
+// allow returns an error if a call to the target must be short-circuited, and
+// whether the call is a probe of a half-open circuit.
+func (b *CircuitBreaker) allow(target string) (bool, error) {
+	b.mut.Lock()
+
+	c, ok := b.circuits[target]
+	if !ok {
+		c = &circuit{}
+		b.circuits[target] = c
+	}
+
+	from := c.state
+
+	switch c.state {
+	case CircuitOpen:
+		wait := b.openTimeout - time.Since(c.openedAt)
+		if wait > 0 {
+			b.mut.Unlock()
+			return false, CircuitOpenError{Target: target, RetryAfter: wait}
+		}
+
+		c.state = CircuitHalfOpen
+		c.probes = 0
+		fallthrough
+
+	case CircuitHalfOpen:
+		if c.probing {
+			b.mut.Unlock()
+			return false, CircuitOpenError{Target: target}
+		}
+
+		c.probing = true
+		b.mut.Unlock()
+		b.stateChanged(target, from, CircuitHalfOpen)
+		return true, nil
+
+	default:
+		b.mut.Unlock()
+		return false, nil
+	}
+}
+
+// record updates the circuit of the target with the outcome of a call.
+func (b *CircuitBreaker) record(target string, probe bool, result outcome) {
+	b.mut.Lock()
+
+	c := b.circuits[target]
+	from := c.state
+
+	switch {
+	case probe:
+		c.probing = false
+
+		switch result {
+		case outcomeFailure:
+			c.state = CircuitOpen
+			c.openedAt = time.Now()
+		case outcomeSuccess:
+			c.probes++
+			if c.probes >= b.halfOpenProbes {
+				c.state = CircuitClosed
+				c.failures = 0
+			}
+		}
+
+	case c.state == CircuitClosed:
+		switch result {
+		case outcomeFailure:
+			c.failures++
+			if c.failures >= b.failureThreshold {
+				c.state = CircuitOpen
+				c.openedAt = time.Now()
+			}
+		case outcomeSuccess:
+			c.failures = 0
+		}
+	}
+
+	to := c.state
+	b.mut.Unlock()
+
+	b.stateChanged(target, from, to)
+}
+
+func (b *CircuitBreaker) stateChanged(target string, from, to CircuitState) {
+	if from != to && b.onStateChange != nil {
+		b.onStateChange(target, from, to)
+	}
+}
+
+// An outcome is the result of a call, as recorded by a circuit.
+type outcome int
+
+const (
+	// outcomeSuccess is a call answered by the node, including with an error of the request.
+	outcomeSuccess outcome = iota
+	// outcomeFailure is a call that failed because the node is unhealthy.
+	outcomeFailure
+	// outcomeIgnored is a call ended by its caller, which is not recorded.
+	outcomeIgnored
+)
+
+// callOutcome classifies the error of a call made with ctx.
+func callOutcome(ctx context.Context, err error) outcome {
+	switch {
+	case err == nil:
+		return outcomeSuccess
+	case ctx.Err() != nil:
+		return outcomeIgnored
+	case flowerrors.IsTransient(newRPCError(err)):
+		return outcomeFailure
+	default:
+		return outcomeSuccess
+	}
+}
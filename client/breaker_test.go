@@ -0,0 +1,200 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk/accesstest"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+func breakerClient(t *testing.T, node *accesstest.Node, breaker *client.CircuitBreaker) *client.Client {
+	opts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, breaker.DialOptions()...)
+
+	c, err := client.New(node.Address(), opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	return c
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Open and recover", func(t *testing.T) {
+		scenario := accesstest.NewScenario().
+			Inject("Ping", accesstest.Unavailable(), accesstest.Timeout(), accesstest.Unavailable())
+		node := accesstest.Start(t, scenario)
+
+		var mut sync.Mutex
+		var changes []client.CircuitState
+		breaker := client.NewCircuitBreaker(
+			client.WithFailureThreshold(3),
+			client.WithOpenTimeout(50*time.Millisecond),
+			client.WithStateChangeHook(func(target string, from, to client.CircuitState) {
+				assert.Equal(t, node.Address(), target)
+				mut.Lock()
+				changes = append(changes, to)
+				mut.Unlock()
+			}),
+		)
+		c := breakerClient(t, node, breaker)
+
+		for i := 0; i < 3; i++ {
+			assert.Error(t, c.Ping(ctx))
+		}
+		assert.Equal(t, client.CircuitOpen, breaker.State(node.Address()))
+
+		err := c.Ping(ctx)
+		var openErr client.CircuitOpenError
+		require.True(t, errors.As(err, &openErr))
+		assert.Equal(t, node.Address(), openErr.Target)
+		assert.True(t, openErr.RetryAfter > 0)
+		assert.True(t, errors.Is(err, flowerrors.ErrUnavailable))
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, 3, node.Calls("Ping"))
+
+		time.Sleep(60 * time.Millisecond)
+		assert.Equal(t, client.CircuitHalfOpen, breaker.State(node.Address()))
+
+		require.NoError(t, c.Ping(ctx))
+		assert.Equal(t, client.CircuitClosed, breaker.State(node.Address()))
+		assert.Equal(t, 4, node.Calls("Ping"))
+
+		mut.Lock()
+		defer mut.Unlock()
+		assert.Equal(t, []client.CircuitState{client.CircuitOpen, client.CircuitHalfOpen, client.CircuitClosed}, changes)
+	})
+
+	t.Run("Failed probe", func(t *testing.T) {
+		scenario := accesstest.NewScenario().
+			Inject("Ping", accesstest.Unavailable(), accesstest.RateLimited())
+		node := accesstest.Start(t, scenario)
+
+		breaker := client.NewCircuitBreaker(
+			client.WithFailureThreshold(1),
+			client.WithOpenTimeout(20*time.Millisecond),
+		)
+		c := breakerClient(t, node, breaker)
+
+		assert.Error(t, c.Ping(ctx))
+		assert.Equal(t, client.CircuitOpen, breaker.State(node.Address()))
+
+		time.Sleep(30 * time.Millisecond)
+
+		// the failed probe opens the circuit again
+		assert.Error(t, c.Ping(ctx))
+		assert.Equal(t, client.CircuitOpen, breaker.State(node.Address()))
+		assert.Equal(t, 2, node.Calls("Ping"))
+
+		err := c.Ping(ctx)
+		assert.True(t, errors.As(err, &client.CircuitOpenError{}))
+		assert.Equal(t, 2, node.Calls("Ping"))
+	})
+
+	t.Run("Request errors", func(t *testing.T) {
+		scenario := accesstest.NewScenario().
+			Inject("Ping", accesstest.NotFound(), accesstest.NotFound(), accesstest.Unavailable(), accesstest.NotFound())
+		node := accesstest.Start(t, scenario)
+
+		breaker := client.NewCircuitBreaker(client.WithFailureThreshold(2))
+		c := breakerClient(t, node, breaker)
+
+		// errors of the request do not count as failures, and reset the count
+		for i := 0; i < 4; i++ {
+			assert.Error(t, c.Ping(ctx))
+		}
+
+		assert.Equal(t, client.CircuitClosed, breaker.State(node.Address()))
+		require.NoError(t, c.Ping(ctx))
+		assert.Equal(t, 5, node.Calls("Ping"))
+	})
+	t.Run("Internal errors", func(t *testing.T) {
+		internal := accesstest.Fault{Err: status.Error(codes.Internal, "internal")}
+		scenario := accesstest.NewScenario().
+			Inject("Ping", internal, internal, accesstest.Pass())
+		node := accesstest.Start(t, scenario)
+
+		breaker := client.NewCircuitBreaker(client.WithFailureThreshold(2))
+		c := breakerClient(t, node, breaker)
+
+		assert.Error(t, c.Ping(ctx))
+		assert.Error(t, c.Ping(ctx))
+		assert.Equal(t, client.CircuitClosed, breaker.State(node.Address()))
+	})
+
+	t.Run("Calls ended by the caller", func(t *testing.T) {
+		scenario := accesstest.NewScenario().
+			Inject("Ping", accesstest.Delay(time.Second), accesstest.Delay(time.Second))
+		node := accesstest.Start(t, scenario)
+
+		breaker := client.NewCircuitBreaker(client.WithFailureThreshold(1))
+		c := breakerClient(t, node, breaker)
+
+		// an expired deadline of the caller is not a failure of the node
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(c.Ping(timeoutCtx)))
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(10*time.Millisecond, cancel)
+		assert.Equal(t, codes.Canceled, status.Code(c.Ping(cancelCtx)))
+
+		assert.Equal(t, client.CircuitClosed, breaker.State(node.Address()))
+	})
+
+	t.Run("Canceled probe", func(t *testing.T) {
+		scenario := accesstest.NewScenario().
+			Inject("Ping", accesstest.Unavailable(), accesstest.Delay(time.Second), accesstest.Unavailable())
+		node := accesstest.Start(t, scenario)
+
+		breaker := client.NewCircuitBreaker(
+			client.WithFailureThreshold(1),
+			client.WithOpenTimeout(20*time.Millisecond),
+		)
+		c := breakerClient(t, node, breaker)
+
+		assert.Error(t, c.Ping(ctx))
+		time.Sleep(30 * time.Millisecond)
+
+		// the canceled probe neither closes nor opens the circuit
+		probeCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		assert.Error(t, c.Ping(probeCtx))
+		assert.Equal(t, client.CircuitHalfOpen, breaker.State(node.Address()))
+
+		// the next call probes the node again
+		assert.Error(t, c.Ping(ctx))
+		assert.Equal(t, client.CircuitOpen, breaker.State(node.Address()))
+		assert.Equal(t, 3, node.Calls("Ping"))
+	})
+}
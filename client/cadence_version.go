@@ -0,0 +1,192 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client/convert"
+)
+
+// CadenceVersion returns the version of Cadence of the network the client is
+// connected to, Cadence before version 1.0 unless switched with
+// SetCadenceVersion or DetectCadenceVersion.
+func (c *Client) CadenceVersion() flow.CadenceVersion {
+	c.cadenceVersionMut.RLock()
+	defer c.cadenceVersionMut.RUnlock()
+
+	return c.cadenceVersion
+}
+
+// SetCadenceVersion switches the version of Cadence of the network the client
+// is connected to.
+//
+// With Cadence 1.0, the Cadence values returned by the node, such as event
+// payloads and script results, are rewritten with convert.Cadence1ToLegacyJSONCDC
+// before they are decoded. Together with the system contracts and templates for
+// the version, e.g. templates.ForCadence(c.CadenceVersion()), this lets one
+// program target both networks that run Cadence 1.0 and older networks, e.g.
+// emulator snapshots taken before the upgrade.
+//
+// An error matching flowerrors.ErrInvalidArgument is returned, and the version of
+// the client is kept, for an unknown version.
+func (c *Client) SetCadenceVersion(version flow.CadenceVersion) error {
+	if err := version.Validate(); err != nil {
+		return fmt.Errorf(errorMessagePrefix+"%w", err)
+	}
+
+	c.cadenceVersionMut.Lock()
+	defer c.cadenceVersionMut.Unlock()
+
+	c.cadenceVersion = version
+
+	return nil
+}
+
+// DetectCadenceVersion switches the client to the version of Cadence run by the
+// node it is connected to, as derived from the version of the node software by
+// NodeVersionInfo.CadenceVersion.
+//
+// An error is returned, and the version of the client is kept, if the node does
+// not report a version the Cadence version can be derived from.
+func (c *Client) DetectCadenceVersion(ctx context.Context, opts ...grpc.CallOption) (flow.CadenceVersion, error) {
+	info, err := c.GetNodeVersionInfo(ctx, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	version, err := info.CadenceVersion()
+	if err != nil {
+		return 0, err
+	}
+
+	err = c.SetCadenceVersion(version)
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// legacyValue rewrites a JSON-CDC value returned by a node running Cadence 1.0
+// for decoding, and returns it unchanged for older nodes.
+func (c *Client) legacyValue(m []byte) ([]byte, error) {
+	if c.CadenceVersion() == flow.CadenceV0 || len(m) == 0 {
+		return m, nil
+	}
+
+	return convert.Cadence1ToLegacyJSONCDC(m)
+}
+
+// legacyEvents returns event messages with their payloads rewritten with
+// legacyValue.
+//
+// The messages are never modified, as they may be shared by concurrent calls:
+// rewritten events are copies, and older nodes get the messages unchanged.
+func (c *Client) legacyEvents(events []*entities.Event) ([]*entities.Event, error) {
+	if c.CadenceVersion() == flow.CadenceV0 {
+		return events, nil
+	}
+
+	legacy := make([]*entities.Event, len(events))
+	for i, event := range events {
+		if event != nil {
+			legacy[i] = proto.Clone(event).(*entities.Event)
+		}
+	}
+
+	err := c.rewritePayloads(legacy)
+	if err != nil {
+		return nil, err
+	}
+
+	return legacy, nil
+}
+
+// legacyTransactionResult returns a transaction result message with the payloads
+// of its events rewritten with legacyValue, without modifying the message.
+func (c *Client) legacyTransactionResult(m *access.TransactionResultResponse) (*access.TransactionResultResponse, error) {
+	if c.CadenceVersion() == flow.CadenceV0 || m == nil {
+		return m, nil
+	}
+
+	legacy := proto.Clone(m).(*access.TransactionResultResponse)
+
+	err := c.rewritePayloads(legacy.GetEvents())
+	if err != nil {
+		return nil, err
+	}
+
+	return legacy, nil
+}
+
+// legacyExecutionData returns execution data with the payloads of its events
+// rewritten with legacyValue, without modifying the message.
+func (c *Client) legacyExecutionData(m *entities.BlockExecutionData) (*entities.BlockExecutionData, error) {
+	if c.CadenceVersion() == flow.CadenceV0 || m == nil {
+		return m, nil
+	}
+
+	legacy := proto.Clone(m).(*entities.BlockExecutionData)
+
+	for _, chunk := range legacy.GetChunkExecutionData() {
+		err := c.rewritePayloads(chunk.GetEvents())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return legacy, nil
+}
+
+// rewritePayloads rewrites the payloads of event messages with legacyValue, in
+// place. It must only be called with copies of the messages returned by the node.
+func (c *Client) rewritePayloads(events []*entities.Event) error {
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+
+		payload, err := c.legacyValue(event.GetPayload())
+		if err != nil {
+			return err
+		}
+
+		event.Payload = payload
+	}
+
+	return nil
+}
+
+// messageToEvent converts an event message, rewriting its payload with legacyValue.
+func (c *Client) messageToEvent(m *entities.Event) (flow.Event, error) {
+	events, err := c.legacyEvents([]*entities.Event{m})
+	if err != nil {
+		return flow.Event{}, err
+	}
+
+	return convert.MessageToEvent(events[0])
+}
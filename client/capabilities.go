@@ -0,0 +1,278 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/executiondata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// NodeVersionInfo describes the software and the data of the node a client is connected to.
+type NodeVersionInfo struct {
+	// Semver is the version of the node software.
+	Semver string
+	// Commit is the git commit of the node software.
+	Commit string
+	// SporkID identifies the network of the current spork.
+	SporkID flow.Identifier
+	// SporkRootBlockHeight is the height of the first block of the current spork.
+	SporkRootBlockHeight uint64
+	// NodeRootBlockHeight is the height of the first block the node has data
+	// for. It is higher than SporkRootBlockHeight if the node joined the
+	// network after the start of the spork.
+	NodeRootBlockHeight uint64
+	// CompatibleRange is the range of heights the node software can serve,
+	// or nil if the node does not report one.
+	CompatibleRange *CompatibleRange
+}
+
+// cadenceV1MinorVersion is the minor version of the first node software running
+// Cadence 1.0, released for the Crescendo upgrade.
+const cadenceV1MinorVersion = 37
+
+// CadenceVersion returns the version of Cadence run by the node, derived from
+// the version of the node software: Cadence 1.0 is run by versions v0.37.0 and
+// later.
+//
+// An error is returned if the node reports no version or a version that is not
+// a semantic version, e.g. an emulator or a development build.
+func (v *NodeVersionInfo) CadenceVersion() (flow.CadenceVersion, error) {
+	semver := strings.TrimPrefix(v.Semver, "v")
+
+	// pre-release and build metadata do not change the version of Cadence
+	if i := strings.IndexAny(semver, "-+"); i >= 0 {
+		semver = semver[:i]
+	}
+
+	parts := strings.Split(semver, ".")
+	if len(parts) != 3 {
+		return 0, flowerrors.Errorf(flowerrors.ErrUnsupported, "client: invalid node version %q", v.Semver)
+	}
+
+	numbers := make([]uint64, len(parts))
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return 0, flowerrors.Errorf(flowerrors.ErrUnsupported, "client: invalid node version %q", v.Semver)
+		}
+		numbers[i] = n
+	}
+
+	if numbers[0] == 0 && numbers[1] < cadenceV1MinorVersion {
+		return flow.CadenceV0, nil
+	}
+
+	return flow.CadenceV1, nil
+}
+
+// A CompatibleRange is a range of block heights, inclusive. An EndHeight of
+// zero means the range is open-ended.
+type CompatibleRange struct {
+	StartHeight uint64
+	EndHeight   uint64
+}
+
+// GetNodeVersionInfo gets the version of the node software and the range of
+// heights the node has data for.
+func (c *Client) GetNodeVersionInfo(ctx context.Context, opts ...grpc.CallOption) (*NodeVersionInfo, error) {
+	res, err := c.rpcClient.GetNodeVersionInfo(ctx, &access.GetNodeVersionInfoRequest{}, opts...)
+	if err != nil {
+		return nil, newRPCError(err)
+	}
+
+	info := res.GetInfo()
+
+	version := &NodeVersionInfo{
+		Semver:               info.GetSemver(),
+		Commit:               info.GetCommit(),
+		SporkID:              flow.BytesToID(info.GetSporkId()),
+		SporkRootBlockHeight: info.GetSporkRootBlockHeight(),
+		NodeRootBlockHeight:  info.GetNodeRootBlockHeight(),
+	}
+
+	if r := info.GetCompatibleRange(); r != nil {
+		version.CompatibleRange = &CompatibleRange{
+			StartHeight: r.GetStartHeight(),
+			EndHeight:   r.GetEndHeight(),
+		}
+	}
+
+	return version, nil
+}
+
+// Capabilities are the optional features of the Access API served by the node
+// a client is connected to.
+type Capabilities struct {
+	// NodeVersion is the version of the node, or nil if the node does not
+	// implement GetNodeVersionInfo.
+	NodeVersion *NodeVersionInfo
+	// ExecutionData is true if the node serves the Execution Data API.
+	ExecutionData bool
+	// Streaming is true if the node streams events, as required by
+	// SubscribeEventsByBlockHeight.
+	Streaming bool
+}
+
+// LowestHeight returns the lowest block height the node has data for, and
+// false if the node does not report it.
+func (c *Capabilities) LowestHeight() (uint64, bool) {
+	if c.NodeVersion == nil {
+		return 0, false
+	}
+
+	return c.NodeVersion.NodeRootBlockHeight, true
+}
+
+// ServesHeight returns true if the node has data for the given block height.
+//
+// If the node does not report the heights it has data for, ServesHeight
+// returns true and requests for unavailable heights fail with an error.
+func (c *Capabilities) ServesHeight(height uint64) bool {
+	lowest, ok := c.LowestHeight()
+	return !ok || height >= lowest
+}
+
+// streamProbeTimeout bounds the wait for the first message of the stream
+// opened to detect streaming support.
+const streamProbeTimeout = 5 * time.Second
+
+// Capabilities returns the optional features served by the node the client is
+// connected to.
+//
+// The features are detected by the first call and cached for the lifetime of
+// the client; use DetectCapabilities to detect them again, e.g. after the node
+// was upgraded. Libraries built on the client can use them to degrade
+// gracefully instead of failing mid-run.
+func (c *Client) Capabilities(ctx context.Context, opts ...grpc.CallOption) (*Capabilities, error) {
+	c.capabilitiesMut.Lock()
+	caps := c.capabilities
+	c.capabilitiesMut.Unlock()
+
+	if caps != nil {
+		return caps, nil
+	}
+
+	return c.DetectCapabilities(ctx, opts...)
+}
+
+// DetectCapabilities probes the node the client is connected to for optional
+// features and caches the result for Capabilities.
+//
+// Each feature is detected by calling the API that provides it: a feature is
+// unsupported if the call fails with an Unimplemented or Unavailable status.
+// Detecting streaming support can take up to 5 seconds if the node streams no
+// message in that time.
+func (c *Client) DetectCapabilities(ctx context.Context, opts ...grpc.CallOption) (*Capabilities, error) {
+	caps := &Capabilities{}
+
+	version, err := c.GetNodeVersionInfo(ctx, opts...)
+	switch {
+	case err == nil:
+		caps.NodeVersion = version
+	case !unsupported(err):
+		return nil, err
+	}
+
+	if c.executionDataClient != nil {
+		caps.ExecutionData, err = c.probeExecutionData(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		caps.Streaming, err = c.probeStreaming(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.capabilitiesMut.Lock()
+	c.capabilities = caps
+	c.capabilitiesMut.Unlock()
+
+	return caps, nil
+}
+
+// probeExecutionData requests the execution data of a block that does not
+// exist: any error other than an unsupported one shows that the API is served.
+func (c *Client) probeExecutionData(ctx context.Context, opts ...grpc.CallOption) (bool, error) {
+	_, err := c.executionDataClient.GetExecutionDataByBlockID(
+		ctx,
+		&executiondata.GetExecutionDataByBlockIDRequest{BlockId: flow.EmptyID.Bytes()},
+		opts...,
+	)
+	if err != nil && ctx.Err() != nil {
+		return false, newRPCError(err)
+	}
+
+	return err == nil || !unsupported(err), nil
+}
+
+// probeStreaming opens an event stream and waits for its first message.
+func (c *Client) probeStreaming(ctx context.Context, opts ...grpc.CallOption) (bool, error) {
+	probeCtx, cancel := context.WithTimeout(ctx, streamProbeTimeout)
+	defer cancel()
+
+	stream, err := c.executionDataClient.SubscribeEventsFromLatest(
+		probeCtx,
+		&executiondata.SubscribeEventsFromLatestRequest{
+			Filter:            &executiondata.EventFilter{},
+			HeartbeatInterval: 1,
+		},
+		opts...,
+	)
+	if err == nil {
+		_, err = stream.Recv()
+	}
+
+	switch {
+	case err == nil:
+		return true, nil
+	case ctx.Err() != nil:
+		return false, newRPCError(err)
+	case probeCtx.Err() != nil:
+		// The stream is open, but no block was sealed before the timeout.
+		return true, nil
+	case unsupported(err):
+		return false, nil
+	default:
+		return false, newRPCError(err)
+	}
+}
+
+// unsupported returns true if err is a gRPC error showing that the node does
+// not serve the called method.
+func unsupported(err error) bool {
+	switch status.Code(err) {
+	case codes.Unimplemented, codes.Unavailable:
+		return true
+	default:
+		return false
+	}
+}
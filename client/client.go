@@ -26,18 +26,24 @@
 package client
 
 //go:generate go run github.com/vektra/mockery/cmd/mockery -name RPCClient -filename=mock_client_test.go -structname=MockRPCClient -output=. -outpkg=client_test
+//go:generate go run github.com/vektra/mockery/cmd/mockery -name ExecutionDataRPCClient -filename=mock_execution_data_client_test.go -structname=MockExecutionDataRPCClient -output=. -outpkg=client_test
 
 import (
 	"context"
+	"io"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/onflow/cadence"
-	"github.com/onflow/flow-go/crypto/hash"
 	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/onflow/flow/protobuf/go/flow/executiondata"
 	"google.golang.org/grpc"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/client/convert"
+	"github.com/onflow/flow-go-sdk/flowerrors"
 )
 
 // An RPCClient is an RPC client for the Flow Access API.
@@ -45,10 +51,25 @@ type RPCClient interface {
 	access.AccessAPIClient
 }
 
+// An ExecutionDataRPCClient is an RPC client for the Flow Execution Data API.
+type ExecutionDataRPCClient interface {
+	executiondata.ExecutionDataAPIClient
+}
+
 // A Client is a gRPC Client for the Flow Access API.
+//
+// A Client is safe for concurrent use by multiple goroutines. All calls share the
+// underlying connection, which should be reused rather than created per call.
 type Client struct {
-	rpcClient RPCClient
-	close     func() error
+	rpcClient           RPCClient
+	executionDataClient ExecutionDataRPCClient
+	close               func() error
+
+	capabilitiesMut sync.Mutex
+	capabilities    *Capabilities
+
+	cadenceVersionMut sync.RWMutex
+	cadenceVersion    flow.CadenceVersion
 }
 
 // New initializes a Flow client with the default gRPC provider.
@@ -61,18 +82,30 @@ func New(addr string, opts ...grpc.DialOption) (*Client, error) {
 	}
 
 	grpcClient := access.NewAccessAPIClient(conn)
+	executionDataClient := executiondata.NewExecutionDataAPIClient(conn)
 
 	return &Client{
-		rpcClient: grpcClient,
-		close:     func() error { return conn.Close() },
+		rpcClient:           grpcClient,
+		executionDataClient: executionDataClient,
+		close:               func() error { return conn.Close() },
 	}, nil
 }
 
 // NewFromRPCClient initializes a Flow client using a pre-configured gRPC provider.
+//
+// A client created this way does not support the streaming methods that require
+// the Execution Data API; use NewFromRPCClients to provide both providers.
 func NewFromRPCClient(rpcClient RPCClient) *Client {
+	return NewFromRPCClients(rpcClient, nil)
+}
+
+// NewFromRPCClients initializes a Flow client using pre-configured gRPC providers
+// for the Access API and the Execution Data API.
+func NewFromRPCClients(rpcClient RPCClient, executionDataClient ExecutionDataRPCClient) *Client {
 	return &Client{
-		rpcClient: rpcClient,
-		close:     func() error { return nil },
+		rpcClient:           rpcClient,
+		executionDataClient: executionDataClient,
+		close:               func() error { return nil },
 	}
 }
 
@@ -84,10 +117,18 @@ func (c *Client) Close() error {
 // Ping is used to check if the access node is alive and healthy.
 func (c *Client) Ping(ctx context.Context, opts ...grpc.CallOption) error {
 	_, err := c.rpcClient.Ping(ctx, &access.PingRequest{}, opts...)
-	return err
+	if err != nil {
+		return newRPCError(err)
+	}
+
+	return nil
 }
 
 // GetLatestBlockHeader gets the latest sealed or unsealed block header.
+//
+// If isSealed is false, the latest finalized block header is returned. Prefer
+// GetLatestSealedBlockHeader and GetLatestFinalizedBlockHeader, which make the
+// finality of the result explicit.
 func (c *Client) GetLatestBlockHeader(
 	ctx context.Context,
 	isSealed bool,
@@ -142,6 +183,55 @@ func (c *Client) GetBlockHeaderByHeight(
 	return getBlockHeaderResult(res)
 }
 
+// GetLatestSealedBlockHeader gets the header of the latest sealed block.
+//
+// The execution results of a sealed block have been verified, so its state can be queried.
+func (c *Client) GetLatestSealedBlockHeader(ctx context.Context, opts ...grpc.CallOption) (*flow.BlockHeader, error) {
+	return c.GetLatestBlockHeader(ctx, true, opts...)
+}
+
+// GetLatestFinalizedBlockHeader gets the header of the latest finalized block.
+//
+// A finalized block is part of the canonical chain but may not have been executed and
+// sealed yet.
+func (c *Client) GetLatestFinalizedBlockHeader(ctx context.Context, opts ...grpc.CallOption) (*flow.BlockHeader, error) {
+	return c.GetLatestBlockHeader(ctx, false, opts...)
+}
+
+// ChainStatus describes the latest finalized and sealed blocks known to an access node.
+type ChainStatus struct {
+	Finalized flow.BlockHeader
+	Sealed    flow.BlockHeader
+}
+
+// SealingLag returns the number of finalized blocks that are not sealed yet.
+func (s ChainStatus) SealingLag() uint64 {
+	if s.Finalized.Height < s.Sealed.Height {
+		return 0
+	}
+	return s.Finalized.Height - s.Sealed.Height
+}
+
+// GetChainStatus gets the headers of both the latest finalized and the latest sealed block.
+//
+// The sealed header is fetched first, so the finalized height is never lower than the sealed height.
+func (c *Client) GetChainStatus(ctx context.Context, opts ...grpc.CallOption) (*ChainStatus, error) {
+	sealed, err := c.GetLatestSealedBlockHeader(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	finalized, err := c.GetLatestFinalizedBlockHeader(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChainStatus{
+		Finalized: *finalized,
+		Sealed:    *sealed,
+	}, nil
+}
+
 func getBlockHeaderResult(res *access.BlockHeaderResponse) (*flow.BlockHeader, error) {
 	header, err := convert.MessageToBlockHeader(res.GetBlock())
 	if err != nil {
@@ -152,6 +242,10 @@ func getBlockHeaderResult(res *access.BlockHeaderResponse) (*flow.BlockHeader, e
 }
 
 // GetLatestBlock gets the full payload of the latest sealed or unsealed block.
+//
+// If isSealed is false, the latest finalized block is returned. Prefer
+// GetLatestSealedBlock and GetLatestFinalizedBlock, which make the finality of
+// the result explicit.
 func (c *Client) GetLatestBlock(
 	ctx context.Context,
 	isSealed bool,
@@ -169,6 +263,16 @@ func (c *Client) GetLatestBlock(
 	return getBlockResult(res)
 }
 
+// GetLatestSealedBlock gets the full payload of the latest sealed block.
+func (c *Client) GetLatestSealedBlock(ctx context.Context, opts ...grpc.CallOption) (*flow.Block, error) {
+	return c.GetLatestBlock(ctx, true, opts...)
+}
+
+// GetLatestFinalizedBlock gets the full payload of the latest finalized block.
+func (c *Client) GetLatestFinalizedBlock(ctx context.Context, opts ...grpc.CallOption) (*flow.Block, error) {
+	return c.GetLatestBlock(ctx, false, opts...)
+}
+
 // GetBlockByID gets a full block by ID.
 func (c *Client) GetBlockByID(
 	ctx context.Context,
@@ -298,6 +402,11 @@ func (c *Client) GetTransactionResult(
 		return nil, newRPCError(err)
 	}
 
+	res, err = c.legacyTransactionResult(res)
+	if err != nil {
+		return nil, newMessageToEntityError(entityTransactionResult, err)
+	}
+
 	result, err := convert.MessageToTransactionResult(res)
 	if err != nil {
 		return nil, newMessageToEntityError(entityTransactionResult, err)
@@ -382,7 +491,7 @@ func (c *Client) ExecuteScriptAtLatestBlock(
 		return nil, newRPCError(err)
 	}
 
-	return executeScriptResult(res)
+	return c.executeScriptResult(res)
 }
 
 // ExecuteScriptAtBlockID executes a ready-only Cadence script against the execution state
@@ -411,7 +520,7 @@ func (c *Client) ExecuteScriptAtBlockID(
 		return nil, newRPCError(err)
 	}
 
-	return executeScriptResult(res)
+	return c.executeScriptResult(res)
 }
 
 // ExecuteScriptAtBlockHeight executes a ready-only Cadence script against the execution state
@@ -440,11 +549,16 @@ func (c *Client) ExecuteScriptAtBlockHeight(
 		return nil, newRPCError(err)
 	}
 
-	return executeScriptResult(res)
+	return c.executeScriptResult(res)
 }
 
-func executeScriptResult(res *access.ExecuteScriptResponse) (cadence.Value, error) {
-	value, err := convert.MessageToCadenceValue(res.GetValue())
+func (c *Client) executeScriptResult(res *access.ExecuteScriptResponse) (cadence.Value, error) {
+	payload, err := c.legacyValue(res.GetValue())
+	if err != nil {
+		return nil, newMessageToEntityError(entityCadenceValue, err)
+	}
+
+	value, err := convert.MessageToCadenceValue(payload)
 	if err != nil {
 		return nil, newMessageToEntityError(entityCadenceValue, err)
 	}
@@ -488,7 +602,7 @@ func (c *Client) GetEventsForHeightRange(
 		return nil, newRPCError(err)
 	}
 
-	return getEventsResult(res)
+	return c.getEventsResult(res)
 }
 
 // GetEventsForBlockIDs retrieves events with the given type from the specified block IDs.
@@ -508,10 +622,399 @@ func (c *Client) GetEventsForBlockIDs(
 		return nil, newRPCError(err)
 	}
 
-	return getEventsResult(res)
+	return c.getEventsResult(res)
+}
+
+// GetEventsForHeightRangeWithFilter retrieves events matching the given filter for all sealed
+// blocks between the start and end block heights (inclusive).
+//
+// The filter selects the same events as it does for SubscribeEventsByBlockHeight. The Access
+// API can only list events by type, so the filter must contain at least one event type and
+// no addresses or contracts, which would also select events of other types; other filters
+// are rejected with an error matching flowerrors.ErrInvalidArgument. The results for each
+// type are merged into a single list ordered by block height.
+func (c *Client) GetEventsForHeightRangeWithFilter(
+	ctx context.Context,
+	filter flow.EventFilter,
+	startHeight uint64,
+	endHeight uint64,
+	opts ...grpc.CallOption,
+) ([]BlockEvents, error) {
+	if err := validateQueryFilter(filter); err != nil {
+		return nil, err
+	}
+
+	results := make([][]BlockEvents, len(filter.EventTypes))
+	for i, eventType := range filter.EventTypes {
+		blocks, err := c.GetEventsForHeightRange(ctx, EventRangeQuery{
+			Type:        eventType,
+			StartHeight: startHeight,
+			EndHeight:   endHeight,
+		}, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		results[i] = blocks
+	}
+
+	return mergeBlockEvents(filter, results), nil
+}
+
+// GetEventsForBlockIDsWithFilter retrieves events matching the given filter from the specified block IDs.
+//
+// The filter must contain only event types; see GetEventsForHeightRangeWithFilter.
+func (c *Client) GetEventsForBlockIDsWithFilter(
+	ctx context.Context,
+	filter flow.EventFilter,
+	blockIDs []flow.Identifier,
+	opts ...grpc.CallOption,
+) ([]BlockEvents, error) {
+	if err := validateQueryFilter(filter); err != nil {
+		return nil, err
+	}
+
+	results := make([][]BlockEvents, len(filter.EventTypes))
+	for i, eventType := range filter.EventTypes {
+		blocks, err := c.GetEventsForBlockIDs(ctx, eventType, blockIDs, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		results[i] = blocks
+	}
+
+	return mergeBlockEvents(filter, results), nil
 }
 
-func getEventsResult(res *access.EventsResponse) ([]BlockEvents, error) {
+// validateQueryFilter checks that the events selected by a filter can be listed by type.
+func validateQueryFilter(filter flow.EventFilter) error {
+	if len(filter.EventTypes) == 0 {
+		return flowerrors.New(flowerrors.ErrInvalidArgument, errorMessage("event filter must contain at least one event type"))
+	}
+
+	if len(filter.Addresses) > 0 || len(filter.Contracts) > 0 {
+		return flowerrors.New(
+			flowerrors.ErrInvalidArgument,
+			errorMessage("event filter with addresses or contracts can only be used to subscribe to events"),
+		)
+	}
+
+	return nil
+}
+
+// mergeBlockEvents combines the per-type query results into one entry per block,
+// ordered by height, keeping only the events that match the filter.
+func mergeBlockEvents(filter flow.EventFilter, results [][]BlockEvents) []BlockEvents {
+	byBlock := make(map[flow.Identifier]*BlockEvents)
+
+	for _, blocks := range results {
+		for _, block := range blocks {
+			merged, ok := byBlock[block.BlockID]
+			if !ok {
+				merged = &BlockEvents{
+					BlockID:        block.BlockID,
+					Height:         block.Height,
+					BlockTimestamp: block.BlockTimestamp,
+					Events:         []flow.Event{},
+				}
+				byBlock[block.BlockID] = merged
+			}
+
+			for _, event := range block.Events {
+				if filter.Matches(event) {
+					merged.Events = append(merged.Events, event)
+				}
+			}
+		}
+	}
+
+	merged := make([]BlockEvents, 0, len(byBlock))
+	for _, block := range byBlock {
+		sortEvents(block.Events)
+		merged = append(merged, *block)
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Height < merged[j].Height
+	})
+
+	return merged
+}
+
+// sortEvents orders events by their position in the block.
+func sortEvents(events []flow.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].TransactionIndex != events[j].TransactionIndex {
+			return events[i].TransactionIndex < events[j].TransactionIndex
+		}
+		return events[i].EventIndex < events[j].EventIndex
+	})
+}
+
+// SubscribeEventsByBlockHeight subscribes to events matching the given filter, starting at the
+// given block height.
+//
+// Each sealed block that is searched is delivered on the returned channel, including blocks
+// without matching events, which act as heartbeats. The subscription ends when the context
+// is cancelled or the stream fails, in which case the error is delivered on the error channel.
+// Both channels are closed when the subscription ends.
+func (c *Client) SubscribeEventsByBlockHeight(
+	ctx context.Context,
+	startHeight uint64,
+	filter flow.EventFilter,
+	opts ...grpc.CallOption,
+) (<-chan BlockEvents, <-chan error, error) {
+	if c.executionDataClient == nil {
+		return nil, nil, errNoExecutionDataClient
+	}
+
+	req := &executiondata.SubscribeEventsFromStartHeightRequest{
+		StartBlockHeight: startHeight,
+		Filter: &executiondata.EventFilter{
+			EventType: filter.EventTypes,
+			Address:   filter.Addresses,
+			Contract:  filter.Contracts,
+		},
+	}
+
+	stream, err := c.executionDataClient.SubscribeEventsFromStartHeight(ctx, req, opts...)
+	if err != nil {
+		return nil, nil, newRPCError(err)
+	}
+
+	blocksChan := make(chan BlockEvents)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(blocksChan)
+		defer close(errChan)
+
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					errChan <- newRPCError(err)
+				}
+				return
+			}
+
+			block, err := c.subscribeEventsResult(res)
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case blocksChan <- block:
+			}
+		}
+	}()
+
+	return blocksChan, errChan, nil
+}
+
+// GetExecutionDataByBlockID gets the execution data of a sealed block: the collections
+// executed in each of its chunks, together with the emitted events and register updates.
+//
+// The execution data is served as stored by the access node. It can be checked against
+// the sealed execution result of the block with verification.VerifyExecutionData.
+func (c *Client) GetExecutionDataByBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+	opts ...grpc.CallOption,
+) (*flow.BlockExecutionData, error) {
+	if c.executionDataClient == nil {
+		return nil, errNoExecutionDataClient
+	}
+
+	req := &executiondata.GetExecutionDataByBlockIDRequest{
+		BlockId:              convert.IdentifierToMessage(blockID),
+		EventEncodingVersion: entities.EventEncodingVersion_JSON_CDC_V0,
+	}
+
+	res, err := c.executionDataClient.GetExecutionDataByBlockID(ctx, req, opts...)
+	if err != nil {
+		return nil, newRPCError(err)
+	}
+
+	m, err := c.legacyExecutionData(res.GetBlockExecutionData())
+	if err != nil {
+		return nil, newMessageToEntityError(entityExecutionData, err)
+	}
+
+	data, err := convert.MessageToBlockExecutionData(m)
+	if err != nil {
+		return nil, newMessageToEntityError(entityExecutionData, err)
+	}
+
+	return data, nil
+}
+
+// GetRegisterValues gets the raw values of registers of the execution state at the given
+// block height.
+//
+// The values are returned in the order of the register IDs. Registers that are not
+// allocated have an empty value. Register IDs can be constructed with flow.NewRegisterID,
+// flow.AccountStatusRegisterID and flow.GlobalRegisterID.
+func (c *Client) GetRegisterValues(
+	ctx context.Context,
+	blockHeight uint64,
+	registerIDs []flow.RegisterID,
+	opts ...grpc.CallOption,
+) ([][]byte, error) {
+	if c.executionDataClient == nil {
+		return nil, errNoExecutionDataClient
+	}
+
+	req := &executiondata.GetRegisterValuesRequest{
+		BlockHeight: blockHeight,
+		RegisterIds: convert.RegisterIDsToMessages(registerIDs),
+	}
+
+	res, err := c.executionDataClient.GetRegisterValues(ctx, req, opts...)
+	if err != nil {
+		return nil, newRPCError(err)
+	}
+
+	values := res.GetValues()
+	if len(values) != len(registerIDs) {
+		return nil, newMessageToEntityError(
+			entityRegister,
+			flowerrors.Errorf(flowerrors.ErrDecoding, "expected %d register values, got %d", len(registerIDs), len(values)),
+		)
+	}
+
+	return values, nil
+}
+
+var errNoExecutionDataClient = flowerrors.New(flowerrors.ErrUnsupported, errorMessage("client was not configured with an Execution Data API client"))
+
+func (c *Client) subscribeEventsResult(res *executiondata.SubscribeEventsResponse) (BlockEvents, error) {
+	events := make([]flow.Event, len(res.GetEvents()))
+	for i, m := range res.GetEvents() {
+		evt, err := c.messageToEvent(m)
+		if err != nil {
+			return BlockEvents{}, newMessageToEntityError(entityEvent, err)
+		}
+
+		events[i] = evt
+	}
+
+	return BlockEvents{
+		BlockID:        flow.HashToID(res.GetBlockId()),
+		Height:         res.GetBlockHeight(),
+		BlockTimestamp: res.GetBlockTimestamp().AsTime(),
+		Events:         events,
+	}, nil
+}
+
+// AccountStatus holds the changes to the subscribed accounts in a sealed block.
+type AccountStatus struct {
+	BlockID flow.Identifier
+	Height  uint64
+	// MessageIndex is the index of the message in the subscription, starting at 0.
+	MessageIndex uint64
+	// Events holds the account events of the block by account. It is empty for
+	// blocks without changes to the subscribed accounts.
+	Events map[flow.Address][]flow.Event
+}
+
+// SubscribeAccountStatusesByBlockHeight subscribes to changes of accounts, such as key
+// changes and contract updates, starting at the given block height.
+//
+// Only the account events matching the filter are delivered. Each sealed block that is
+// searched is delivered on the returned channel, including blocks without changes, which
+// act as heartbeats. The subscription ends when the context is cancelled or the stream
+// fails, in which case the error is delivered on the error channel. Both channels are
+// closed when the subscription ends.
+func (c *Client) SubscribeAccountStatusesByBlockHeight(
+	ctx context.Context,
+	startHeight uint64,
+	filter flow.AccountStatusFilter,
+	opts ...grpc.CallOption,
+) (<-chan AccountStatus, <-chan error, error) {
+	if c.executionDataClient == nil {
+		return nil, nil, errNoExecutionDataClient
+	}
+
+	addresses := make([]string, len(filter.Addresses))
+	for i, address := range filter.Addresses {
+		addresses[i] = address.Hex()
+	}
+
+	req := &executiondata.SubscribeAccountStatusesFromStartHeightRequest{
+		StartBlockHeight: startHeight,
+		Filter: &executiondata.StatusFilter{
+			EventType: filter.EventTypes,
+			Address:   addresses,
+		},
+	}
+
+	stream, err := c.executionDataClient.SubscribeAccountStatusesFromStartHeight(ctx, req, opts...)
+	if err != nil {
+		return nil, nil, newRPCError(err)
+	}
+
+	statusesChan := make(chan AccountStatus)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(statusesChan)
+		defer close(errChan)
+
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					errChan <- newRPCError(err)
+				}
+				return
+			}
+
+			status, err := c.accountStatusResult(res)
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case statusesChan <- status:
+			}
+		}
+	}()
+
+	return statusesChan, errChan, nil
+}
+
+func (c *Client) accountStatusResult(res *executiondata.SubscribeAccountStatusesResponse) (AccountStatus, error) {
+	events := make(map[flow.Address][]flow.Event, len(res.GetResults()))
+	for _, result := range res.GetResults() {
+		address := flow.BytesToAddress(result.GetAddress())
+
+		for _, m := range result.GetEvents() {
+			evt, err := c.messageToEvent(m)
+			if err != nil {
+				return AccountStatus{}, newMessageToEntityError(entityEvent, err)
+			}
+
+			events[address] = append(events[address], evt)
+		}
+	}
+
+	return AccountStatus{
+		BlockID:      flow.HashToID(res.GetBlockId()),
+		Height:       res.GetBlockHeight(),
+		MessageIndex: res.GetMessageIndex(),
+		Events:       events,
+	}, nil
+}
+
+func (c *Client) getEventsResult(res *access.EventsResponse) ([]BlockEvents, error) {
 	resultMessages := res.GetResults()
 
 	results := make([]BlockEvents, len(resultMessages))
@@ -521,7 +1024,7 @@ func getEventsResult(res *access.EventsResponse) ([]BlockEvents, error) {
 		events := make([]flow.Event, len(eventMessages))
 
 		for i, m := range eventMessages {
-			evt, err := convert.MessageToEvent(m)
+			evt, err := c.messageToEvent(m)
 			if err != nil {
 				return nil, newMessageToEntityError(entityEvent, err)
 			}
@@ -561,33 +1064,85 @@ func (c *Client) GetExecutionResultForBlockID(ctx context.Context, blockID flow.
 		return nil, newRPCError(err)
 	}
 
-	chunks := make([]*flow.Chunk, len(er.ExecutionResult.Chunks))
-	serviceEvents := make([]*flow.ServiceEvent, len(er.ExecutionResult.ServiceEvents))
+	return getExecutionResult(er.GetExecutionResult())
+}
 
-	for i, chunk := range er.ExecutionResult.Chunks {
-		chunks[i] = &flow.Chunk{
-			CollectionIndex:      uint(chunk.CollectionIndex),
-			StartState:           flow.BytesToStateCommitment(chunk.StartState),
-			EventCollection:      hash.BytesToHash(chunk.EventCollection),
-			BlockID:              flow.BytesToID(chunk.BlockId),
-			TotalComputationUsed: chunk.TotalComputationUsed,
-			NumberOfTransactions: uint16(chunk.NumberOfTransactions),
-			Index:                chunk.Index,
-			EndState:             flow.BytesToStateCommitment(chunk.EndState),
-		}
+// GetExecutionResultByID gets an execution result by its ID.
+func (c *Client) GetExecutionResultByID(ctx context.Context, resultID flow.Identifier, opts ...grpc.CallOption) (*flow.ExecutionResult, error) {
+	er, err := c.rpcClient.GetExecutionResultByID(ctx, &access.GetExecutionResultByIDRequest{
+		Id: convert.IdentifierToMessage(resultID),
+	}, opts...)
+	if err != nil {
+		return nil, newRPCError(err)
+	}
+
+	return getExecutionResult(er.GetExecutionResult())
+}
+
+func getExecutionResult(er *entities.ExecutionResult) (*flow.ExecutionResult, error) {
+	result, err := convert.MessageToExecutionResult(er)
+	if err != nil {
+		return nil, newMessageToEntityError(entityExecutionResult, err)
 	}
 
-	for i, serviceEvent := range er.ExecutionResult.ServiceEvents {
-		serviceEvents[i] = &flow.ServiceEvent{
-			Type:    serviceEvent.Type,
-			Payload: serviceEvent.Payload,
+	return result, nil
+}
+
+// GetServiceEventsForBlockID gets the protocol service events emitted by the system chunk
+// of the block with the given ID.
+//
+// The payload of each service event can be decoded into its typed representation
+// (e.g. flow.EpochSetup) with ServiceEvent.Decode.
+func (c *Client) GetServiceEventsForBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+	opts ...grpc.CallOption,
+) ([]*flow.ServiceEvent, error) {
+	result, err := c.GetExecutionResultForBlockID(ctx, blockID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.ServiceEvents, nil
+}
+
+// GetServiceEventsForBlockHeight gets the protocol service events emitted by the system chunk
+// of the block at the given height.
+func (c *Client) GetServiceEventsForBlockHeight(
+	ctx context.Context,
+	height uint64,
+	opts ...grpc.CallOption,
+) ([]*flow.ServiceEvent, error) {
+	header, err := c.GetBlockHeaderByHeight(ctx, height, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetServiceEventsForBlockID(ctx, header.ID, opts...)
+}
+
+// GetDecodedServiceEventsForBlockID gets the protocol service events emitted by the system chunk
+// of the block with the given ID, decoded into their typed representations.
+//
+// Each returned value is one of the types documented on ServiceEvent.Decode, in the order
+// the events were emitted. An error is returned if any service event is of an unsupported type.
+func (c *Client) GetDecodedServiceEventsForBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+	opts ...grpc.CallOption,
+) ([]interface{}, error) {
+	serviceEvents, err := c.GetServiceEventsForBlockID(ctx, blockID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := make([]interface{}, len(serviceEvents))
+	for i, serviceEvent := range serviceEvents {
+		decoded[i], err = serviceEvent.Decode()
+		if err != nil {
+			return nil, newMessageToEntityError(entityServiceEvent, err)
 		}
 	}
 
-	return &flow.ExecutionResult{
-		PreviousResultID: flow.BytesToID(er.ExecutionResult.PreviousResultId),
-		BlockID:          flow.BytesToID(er.ExecutionResult.BlockId),
-		Chunks:           chunks,
-		ServiceEvents:    serviceEvents,
-	}, nil
+	return decoded, nil
 }
@@ -20,16 +20,22 @@ package client_test
 
 import (
 	"context"
+	"errors"
+	"io"
 	"math/rand"
+	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/flow/protobuf/go/flow/access"
 	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/onflow/flow/protobuf/go/flow/executiondata"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -37,6 +43,8 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/client"
 	"github.com/onflow/flow-go-sdk/client/convert"
+	"github.com/onflow/flow-go-sdk/client/mocks"
+	"github.com/onflow/flow-go-sdk/flowerrors"
 	"github.com/onflow/flow-go-sdk/test"
 )
 
@@ -57,6 +65,41 @@ func clientTest(
 	}
 }
 
+func executionDataClientTest(
+	f func(t *testing.T, ctx context.Context, rpc *MockExecutionDataRPCClient, client *client.Client),
+) func(t *testing.T) {
+	return func(t *testing.T) {
+		ctx := context.Background()
+		rpc := &MockExecutionDataRPCClient{}
+		c := client.NewFromRPCClients(&MockRPCClient{}, rpc)
+		f(t, ctx, rpc, c)
+		rpc.AssertExpectations(t)
+	}
+}
+
+// TestAccessClient_Methods fails when a method is added to Client but not to
+// AccessClient, so that the interface and its mock are kept up to date.
+func TestAccessClient_Methods(t *testing.T) {
+	clientType := reflect.TypeOf((*client.Client)(nil))
+	accessClientType := reflect.TypeOf((*client.AccessClient)(nil)).Elem()
+
+	// helpers wrapping the Access API, which are available to any AccessClient
+	helpers := map[string]bool{
+		"Snapshot":                    true,
+		"SnapshotAtLatestSealedBlock": true,
+	}
+
+	for i := 0; i < clientType.NumMethod(); i++ {
+		name := clientType.Method(i).Name
+		if helpers[name] {
+			continue
+		}
+
+		_, ok := accessClientType.MethodByName(name)
+		assert.True(t, ok, "method %s is missing from AccessClient", name)
+	}
+}
+
 func TestClient_Ping(t *testing.T) {
 	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
 		response := &access.PingResponse{}
@@ -109,6 +152,41 @@ func TestClient_GetLatestBlockHeader(t *testing.T) {
 	}))
 }
 
+func TestRPCError_Is(t *testing.T) {
+	type testCase struct {
+		code     codes.Code
+		sentinel error
+	}
+
+	for _, test := range []testCase{
+		{codes.InvalidArgument, flowerrors.ErrInvalidArgument},
+		{codes.NotFound, flowerrors.ErrNotFound},
+		{codes.Unimplemented, flowerrors.ErrUnsupported},
+		{codes.Unavailable, flowerrors.ErrUnavailable},
+		{codes.ResourceExhausted, flowerrors.ErrUnavailable},
+		{codes.DeadlineExceeded, flowerrors.ErrTimeout},
+	} {
+		t.Run(test.code.String(), clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+			rpc.On("Ping", ctx, mock.Anything).Return(nil, status.Error(test.code, "failed"))
+
+			err := c.Ping(ctx)
+
+			var rpcErr client.RPCError
+			require.True(t, errors.As(err, &rpcErr))
+			assert.ErrorIs(t, err, test.sentinel)
+			assert.Equal(t, test.sentinel, flowerrors.KindOf(err).Sentinel())
+		}))
+	}
+
+	t.Run("Internal", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("Ping", ctx, mock.Anything).Return(nil, errInternal)
+
+		err := c.Ping(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, flowerrors.KindUnknown, flowerrors.KindOf(err))
+	}))
+}
+
 func TestClient_GetBlockHeaderByID(t *testing.T) {
 	blocks := test.BlockGenerator()
 	ids := test.IdentifierGenerator()
@@ -141,6 +219,7 @@ func TestClient_GetBlockHeaderByID(t *testing.T) {
 		header, err := c.GetBlockHeaderByID(ctx, blockID)
 		assert.Error(t, err)
 		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.ErrorIs(t, err, flowerrors.ErrNotFound)
 		assert.Nil(t, header)
 	}))
 }
@@ -209,6 +288,77 @@ func TestClient_GetLatestBlock(t *testing.T) {
 	}))
 }
 
+func TestClient_GetLatestSealedAndFinalizedBlock(t *testing.T) {
+	blocks := test.BlockGenerator()
+
+	isSealed := func(sealed bool) interface{} {
+		return mock.MatchedBy(func(req *access.GetLatestBlockRequest) bool {
+			return req.GetIsSealed() == sealed
+		})
+	}
+
+	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		sealedBlock := blocks.New()
+		finalizedBlock := blocks.New()
+
+		sealedMsg, err := convert.BlockToMessage(*sealedBlock)
+		require.NoError(t, err)
+		finalizedMsg, err := convert.BlockToMessage(*finalizedBlock)
+		require.NoError(t, err)
+
+		rpc.On("GetLatestBlock", ctx, isSealed(true)).Return(&access.BlockResponse{Block: sealedMsg}, nil)
+		rpc.On("GetLatestBlock", ctx, isSealed(false)).Return(&access.BlockResponse{Block: finalizedMsg}, nil)
+
+		block, err := c.GetLatestSealedBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, sealedBlock, block)
+
+		block, err = c.GetLatestFinalizedBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, finalizedBlock, block)
+	}))
+}
+
+func TestClient_GetChainStatus(t *testing.T) {
+	headers := test.BlockHeaderGenerator()
+
+	isSealed := func(sealed bool) interface{} {
+		return mock.MatchedBy(func(req *access.GetLatestBlockHeaderRequest) bool {
+			return req.GetIsSealed() == sealed
+		})
+	}
+
+	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		sealed := headers.New()
+		headers.New()
+		finalized := headers.New()
+
+		sealedMsg, err := convert.BlockHeaderToMessage(sealed)
+		require.NoError(t, err)
+		finalizedMsg, err := convert.BlockHeaderToMessage(finalized)
+		require.NoError(t, err)
+
+		rpc.On("GetLatestBlockHeader", ctx, isSealed(true)).Return(&access.BlockHeaderResponse{Block: sealedMsg}, nil)
+		rpc.On("GetLatestBlockHeader", ctx, isSealed(false)).Return(&access.BlockHeaderResponse{Block: finalizedMsg}, nil)
+
+		status, err := c.GetChainStatus(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, sealed, status.Sealed)
+		assert.Equal(t, finalized, status.Finalized)
+		assert.Equal(t, uint64(2), status.SealingLag())
+	}))
+
+	t.Run("Internal error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetLatestBlockHeader", ctx, mock.Anything).Return(nil, errInternal)
+
+		chainStatus, err := c.GetChainStatus(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Nil(t, chainStatus)
+	}))
+}
+
 func TestClient_GetBlockByID(t *testing.T) {
 	blocks := test.BlockGenerator()
 	ids := test.IdentifierGenerator()
@@ -309,6 +459,85 @@ func TestClient_GetCollection(t *testing.T) {
 	}))
 }
 
+func TestClient_GetBlockWithTransactionsAndResults(t *testing.T) {
+	blocks := test.BlockGenerator()
+	collections := test.CollectionGenerator()
+	transactions := test.TransactionGenerator()
+	results := test.TransactionResultGenerator()
+
+	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		expectedBlock := blocks.New()
+
+		b, err := convert.BlockToMessage(*expectedBlock)
+		require.NoError(t, err)
+
+		rpc.On("GetBlockByID", ctx, mock.Anything).Return(&access.BlockResponse{Block: b}, nil)
+
+		expectedCollections := make([]*flow.Collection, len(expectedBlock.CollectionGuarantees))
+		for i, guarantee := range expectedBlock.CollectionGuarantees {
+			collectionID := guarantee.CollectionID
+			expectedCollections[i] = collections.New()
+
+			rpc.On("GetCollectionByID", mock.Anything, mock.MatchedBy(func(req *access.GetCollectionByIDRequest) bool {
+				return flow.HashToID(req.GetId()) == collectionID
+			})).Return(&access.CollectionResponse{
+				Collection: convert.CollectionToMessage(*expectedCollections[i]),
+			}, nil)
+		}
+
+		expectedTx := transactions.New()
+		txMsg, err := convert.TransactionToMessage(*expectedTx)
+		require.NoError(t, err)
+
+		rpc.On("GetTransaction", mock.Anything, mock.Anything).
+			Return(&access.TransactionResponse{Transaction: txMsg}, nil)
+
+		expectedResults := make([]flow.TransactionResult, 6)
+		for i := range expectedResults {
+			index := uint32(i)
+			expectedResults[i] = results.New()
+
+			resultMsg, err := convert.TransactionResultToMessage(expectedResults[i])
+			require.NoError(t, err)
+
+			rpc.On("GetTransactionResultByIndex", mock.Anything, mock.MatchedBy(func(req *access.GetTransactionByIndexRequest) bool {
+				return flow.BytesToID(req.GetBlockId()) == expectedBlock.ID && req.GetIndex() == index
+			})).Return(resultMsg, nil)
+		}
+
+		block, err := c.GetBlockWithTransactionsAndResults(ctx, expectedBlock.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedBlock, block.Block)
+		assert.Equal(t, expectedCollections, block.Collections)
+		require.Len(t, block.Transactions, 6)
+
+		for i, tx := range block.Transactions {
+			assert.Equal(t, expectedTx, tx.Transaction)
+			assert.Equal(t, expectedResults[i], *tx.Result)
+		}
+
+		rpc.AssertNumberOfCalls(t, "GetTransaction", 6)
+		rpc.AssertNumberOfCalls(t, "GetTransactionResultByIndex", 6)
+		rpc.AssertNotCalled(t, "GetTransactionResult", mock.Anything, mock.Anything)
+	}))
+
+	t.Run("Collection error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		expectedBlock := blocks.New()
+
+		b, err := convert.BlockToMessage(*expectedBlock)
+		require.NoError(t, err)
+
+		rpc.On("GetBlockByID", ctx, mock.Anything).Return(&access.BlockResponse{Block: b}, nil)
+		rpc.On("GetCollectionByID", mock.Anything, mock.Anything).Return(nil, errNotFound)
+
+		block, err := c.GetBlockWithTransactionsAndResults(ctx, expectedBlock.ID)
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Nil(t, block)
+	}))
+}
+
 func TestClient_SendTransaction(t *testing.T) {
 	transactions := test.TransactionGenerator()
 
@@ -628,6 +857,97 @@ func TestClient_ExecuteScriptAtBlockHeight(t *testing.T) {
 	}))
 }
 
+func TestClient_Snapshot(t *testing.T) {
+	accounts := test.AccountGenerator()
+	height := uint64(42)
+
+	t.Run("Account reads", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		expectedAccount := accounts.New()
+		expectedAccount.Contracts = map[string][]byte{"Foo": []byte("pub contract Foo {}")}
+
+		response := &access.AccountResponse{
+			Account: convert.AccountToMessage(*expectedAccount),
+		}
+
+		rpc.On("GetAccountAtBlockHeight", ctx, &access.GetAccountAtBlockHeightRequest{
+			Address:     expectedAccount.Address.Bytes(),
+			BlockHeight: height,
+		}).Return(response, nil).Once()
+
+		snapshot := c.Snapshot(height)
+		assert.Equal(t, height, snapshot.Height())
+
+		account, err := snapshot.GetAccount(ctx, expectedAccount.Address)
+		require.NoError(t, err)
+		assert.Equal(t, expectedAccount, account)
+
+		balance, err := snapshot.GetBalance(ctx, expectedAccount.Address)
+		require.NoError(t, err)
+		assert.Equal(t, expectedAccount.Balance, balance)
+
+		code, err := snapshot.GetContract(ctx, expectedAccount.Address, "Foo")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("pub contract Foo {}"), code)
+
+		_, err = snapshot.GetContract(ctx, expectedAccount.Address, "Bar")
+		assert.True(t, errors.Is(err, client.ErrContractNotFound))
+
+		// the account is only fetched once
+		rpc.AssertNumberOfCalls(t, "GetAccountAtBlockHeight", 1)
+	}))
+
+	t.Run("Script", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		expectedValue := cadence.NewInt(42)
+		encodedValue, err := jsoncdc.Encode(expectedValue)
+		require.NoError(t, err)
+
+		rpc.On("ExecuteScriptAtBlockHeight", ctx, &access.ExecuteScriptAtBlockHeightRequest{
+			BlockHeight: height,
+			Script:      []byte("foo"),
+			Arguments:   [][]byte{},
+		}).Return(&access.ExecuteScriptResponse{Value: encodedValue}, nil)
+
+		value, err := c.Snapshot(height).ExecuteScript(ctx, []byte("foo"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, expectedValue, value)
+	}))
+
+	t.Run("Latest sealed", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		header := test.BlockHeaderGenerator().New()
+
+		b, err := convert.BlockHeaderToMessage(header)
+		require.NoError(t, err)
+
+		rpc.On("GetLatestBlockHeader", ctx, &access.GetLatestBlockHeaderRequest{IsSealed: true}).
+			Return(&access.BlockHeaderResponse{Block: b}, nil)
+
+		snapshot, err := c.SnapshotAtLatestSealedBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, header.Height, snapshot.Height())
+	}))
+
+	t.Run("Mock client", func(t *testing.T) {
+		ctx := context.Background()
+		account := accounts.New()
+
+		c := mocks.NewAccessClient(t)
+		c.On("GetAccountAtBlockHeight", ctx, account.Address, height).Return(account, nil).Once()
+
+		balance, err := client.NewSnapshot(c, height).GetBalance(ctx, account.Address)
+		require.NoError(t, err)
+		assert.Equal(t, account.Balance, balance)
+	})
+
+	t.Run("Not found error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetAccountAtBlockHeight", ctx, mock.Anything).
+			Return(nil, errNotFound)
+
+		_, err := c.Snapshot(height).GetBalance(ctx, test.AddressGenerator().New())
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	}))
+}
+
 func TestClient_GetEventsForHeightRange(t *testing.T) {
 	ids := test.IdentifierGenerator()
 	events := test.EventGenerator()
@@ -814,62 +1134,706 @@ func TestClient_GetEventsForBlockIDs(t *testing.T) {
 	}))
 }
 
-func TestClient_GetLatestProtocolStateSnapshot(t *testing.T) {
-	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
-		expected := &access.ProtocolStateSnapshotResponse{
-			SerializedSnapshot: make([]byte, 128),
+func TestClient_GetEventsForHeightRangeWithFilter(t *testing.T) {
+	ids := test.IdentifierGenerator()
+	events := test.EventGenerator()
+
+	t.Run(
+		"Merged result",
+		clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+			blockIDA, blockIDB := ids.New(), ids.New()
+			eventA, eventB, eventC := events.New(), events.New(), events.New()
+
+			eventAMsg, _ := convert.EventToMessage(eventA)
+			eventBMsg, _ := convert.EventToMessage(eventB)
+			eventCMsg, _ := convert.EventToMessage(eventC)
+
+			rpc.On("GetEventsForHeightRange", ctx, mock.MatchedBy(func(req *access.GetEventsForHeightRangeRequest) bool {
+				return req.Type == eventA.Type
+			})).Return(&access.EventsResponse{
+				Results: []*access.EventsResponse_Result{
+					{
+						BlockId:        blockIDB.Bytes(),
+						BlockHeight:    2,
+						BlockTimestamp: timestamppb.Now(),
+						Events:         []*entities.Event{eventAMsg},
+					},
+				},
+			}, nil)
+
+			rpc.On("GetEventsForHeightRange", ctx, mock.MatchedBy(func(req *access.GetEventsForHeightRangeRequest) bool {
+				return req.Type == eventB.Type
+			})).Return(&access.EventsResponse{
+				Results: []*access.EventsResponse_Result{
+					{
+						BlockId:        blockIDA.Bytes(),
+						BlockHeight:    1,
+						BlockTimestamp: timestamppb.Now(),
+						Events:         []*entities.Event{eventCMsg},
+					},
+					{
+						BlockId:        blockIDB.Bytes(),
+						BlockHeight:    2,
+						BlockTimestamp: timestamppb.Now(),
+						Events:         []*entities.Event{eventBMsg},
+					},
+				},
+			}, nil)
+
+			filter := flow.EventFilter{
+				EventTypes: []string{eventA.Type, eventB.Type},
+			}
+
+			blocks, err := c.GetEventsForHeightRangeWithFilter(ctx, filter, 1, 10)
+			require.NoError(t, err)
+
+			require.Len(t, blocks, 2)
+
+			assert.Equal(t, blockIDA, blocks[0].BlockID)
+			assert.Empty(t, blocks[0].Events)
+
+			assert.Equal(t, blockIDB, blocks[1].BlockID)
+			assert.Equal(t, []flow.Event{eventA, eventB}, blocks[1].Events)
+		}),
+	)
+
+	t.Run("No event types", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		filter := flow.EventFilter{
+			Addresses: []string{"f8d6e0586b0a20c7"},
 		}
-		_, err := rand.Read(expected.SerializedSnapshot)
-		assert.NoError(t, err)
 
-		rpc.On("GetLatestProtocolStateSnapshot", ctx, mock.Anything).Return(expected, nil)
+		blocks, err := c.GetEventsForHeightRangeWithFilter(ctx, filter, 1, 10)
+		assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+		assert.Empty(t, blocks)
+	}))
 
-		res, err := c.GetLatestProtocolStateSnapshot(ctx)
-		assert.NoError(t, err)
-		assert.Equal(t, expected.SerializedSnapshot, res)
+	t.Run("Addresses and contracts", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		// The filter also selects events of other types from the address, which
+		// cannot be listed by type.
+		filter := flow.EventFilter{
+			EventTypes: []string{"A.f8d6e0586b0a20c7.FlowToken.TokensDeposited"},
+			Addresses:  []string{"01cf0e2f2f715450"},
+		}
+
+		blocks, err := c.GetEventsForHeightRangeWithFilter(ctx, filter, 1, 10)
+		assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+		assert.Empty(t, blocks)
 	}))
 
 	t.Run("Internal error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
-		rpc.On("GetLatestProtocolStateSnapshot", ctx, mock.Anything).
+		rpc.On("GetEventsForHeightRange", ctx, mock.Anything).
 			Return(nil, errInternal)
 
-		_, err := c.GetLatestProtocolStateSnapshot(ctx)
+		filter := flow.EventFilter{
+			EventTypes: []string{"foo"},
+		}
+
+		blocks, err := c.GetEventsForHeightRangeWithFilter(ctx, filter, 1, 10)
 		assert.Error(t, err)
 		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Empty(t, blocks)
 	}))
 }
 
-func TestClient_GetExecutionResultForBlockID(t *testing.T) {
+func TestClient_GetEventsForBlockIDsWithFilter(t *testing.T) {
 	ids := test.IdentifierGenerator()
-	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+	events := test.EventGenerator()
+
+	t.Run("Merged result", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
 		blockID := ids.New()
-		executionResult := &entities.ExecutionResult{
-			PreviousResultId: ids.New().Bytes(),
-			BlockId:          blockID.Bytes(),
-			Chunks: []*entities.Chunk{
-				{
-					CollectionIndex:      0,
-					StartState:           ids.New().Bytes(),
-					EventCollection:      ids.New().Bytes(),
-					BlockId:              blockID.Bytes(),
-					TotalComputationUsed: 22,
-					NumberOfTransactions: 33,
-					Index:                0,
-					EndState:             ids.New().Bytes(),
-				},
-				{
-					CollectionIndex:      1,
-					StartState:           ids.New().Bytes(),
-					EventCollection:      ids.New().Bytes(),
-					BlockId:              blockID.Bytes(),
-					TotalComputationUsed: 222,
-					NumberOfTransactions: 333,
-					Index:                1,
-					EndState:             ids.New().Bytes(),
+		eventA, eventB := events.New(), events.New()
+
+		for _, event := range []flow.Event{eventA, eventB} {
+			event := event
+			msg, _ := convert.EventToMessage(event)
+
+			rpc.On("GetEventsForBlockIDs", ctx, mock.MatchedBy(func(req *access.GetEventsForBlockIDsRequest) bool {
+				return req.Type == event.Type
+			})).Return(&access.EventsResponse{
+				Results: []*access.EventsResponse_Result{
+					{
+						BlockId:        blockID.Bytes(),
+						BlockHeight:    1,
+						BlockTimestamp: timestamppb.Now(),
+						Events:         []*entities.Event{msg},
+					},
 				},
-			},
-			ServiceEvents: []*entities.ServiceEvent{
-				{
+			}, nil).Once()
+		}
+
+		filter := flow.EventFilter{
+			EventTypes: []string{eventB.Type, eventA.Type},
+		}
+
+		blocks, err := c.GetEventsForBlockIDsWithFilter(ctx, filter, []flow.Identifier{blockID})
+		require.NoError(t, err)
+
+		require.Len(t, blocks, 1)
+		assert.Equal(t, []flow.Event{eventA, eventB}, blocks[0].Events)
+	}))
+
+	t.Run("No event types", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		filter := flow.EventFilter{
+			Contracts: []string{"A.01cf0e2f2f715450.Kibble"},
+		}
+
+		blocks, err := c.GetEventsForBlockIDsWithFilter(ctx, filter, []flow.Identifier{ids.New()})
+		assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+		assert.Empty(t, blocks)
+	}))
+
+	t.Run("Addresses and contracts", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		filter := flow.EventFilter{
+			EventTypes: []string{"A.f8d6e0586b0a20c7.FlowToken.TokensDeposited"},
+			Contracts:  []string{"A.01cf0e2f2f715450.Kibble"},
+		}
+
+		blocks, err := c.GetEventsForBlockIDsWithFilter(ctx, filter, []flow.Identifier{ids.New()})
+		assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+		assert.Empty(t, blocks)
+	}))
+}
+
+// TestClient_EventFilterSemantics checks that a filter selects the same events
+// from a query and from a subscription.
+func TestClient_EventFilterSemantics(t *testing.T) {
+	ctx := context.Background()
+	ids := test.IdentifierGenerator()
+	events := test.EventGenerator()
+
+	blockID := ids.New()
+	eventA, eventB := events.New(), events.New()
+	eventA.Type = "A.f8d6e0586b0a20c7.FlowToken.TokensDeposited"
+	eventB.Type = "A.01cf0e2f2f715450.Kibble.TokensDeposited"
+	eventA.TransactionIndex, eventB.TransactionIndex = 0, 1
+
+	msgA, _ := convert.EventToMessage(eventA)
+	msgB, _ := convert.EventToMessage(eventB)
+	timestamp := timestamppb.Now()
+
+	filter := flow.EventFilter{
+		EventTypes: []string{eventA.Type, eventB.Type},
+	}
+
+	rpc := &MockRPCClient{}
+	executionDataRPC := &MockExecutionDataRPCClient{}
+	c := client.NewFromRPCClients(rpc, executionDataRPC)
+
+	// The access node returns the events of one type per query, and the events
+	// matching any criteria of the filter on the stream.
+	for _, msg := range []*entities.Event{msgA, msgB} {
+		msg := msg
+		rpc.On("GetEventsForHeightRange", ctx, mock.MatchedBy(func(req *access.GetEventsForHeightRangeRequest) bool {
+			return req.Type == msg.Type
+		})).Return(&access.EventsResponse{
+			Results: []*access.EventsResponse_Result{
+				{BlockId: blockID.Bytes(), BlockHeight: 1, BlockTimestamp: timestamp, Events: []*entities.Event{msg}},
+			},
+		}, nil).Once()
+	}
+
+	executionDataRPC.On("SubscribeEventsFromStartHeight", ctx, &executiondata.SubscribeEventsFromStartHeightRequest{
+		StartBlockHeight: 1,
+		Filter:           &executiondata.EventFilter{EventType: filter.EventTypes},
+	}).Return(&mockEventsStream{
+		responses: []*executiondata.SubscribeEventsResponse{
+			{BlockId: blockID.Bytes(), BlockHeight: 1, BlockTimestamp: timestamp, Events: []*entities.Event{msgA, msgB}},
+		},
+		err: io.EOF,
+	}, nil)
+
+	queried, err := c.GetEventsForHeightRangeWithFilter(ctx, filter, 1, 1)
+	require.NoError(t, err)
+
+	blocksChan, errChan, err := c.SubscribeEventsByBlockHeight(ctx, 1, filter)
+	require.NoError(t, err)
+
+	var subscribed []client.BlockEvents
+	for block := range blocksChan {
+		subscribed = append(subscribed, block)
+	}
+	require.NoError(t, <-errChan)
+
+	assert.Equal(t, subscribed, queried)
+	require.Len(t, queried, 1)
+	for _, event := range queried[0].Events {
+		assert.True(t, filter.Matches(event))
+	}
+
+	rpc.AssertExpectations(t)
+	executionDataRPC.AssertExpectations(t)
+}
+
+type mockEventsStream struct {
+	grpc.ClientStream
+	responses []*executiondata.SubscribeEventsResponse
+	err       error
+}
+
+func (s *mockEventsStream) Recv() (*executiondata.SubscribeEventsResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, s.err
+	}
+
+	res := s.responses[0]
+	s.responses = s.responses[1:]
+	return res, nil
+}
+
+func TestClient_SubscribeEventsByBlockHeight(t *testing.T) {
+	ids := test.IdentifierGenerator()
+	events := test.EventGenerator()
+
+	t.Run("Success", executionDataClientTest(func(t *testing.T, ctx context.Context, rpc *MockExecutionDataRPCClient, c *client.Client) {
+		blockIDA, blockIDB := ids.New(), ids.New()
+		eventA := events.New()
+
+		eventAMsg, _ := convert.EventToMessage(eventA)
+
+		stream := &mockEventsStream{
+			responses: []*executiondata.SubscribeEventsResponse{
+				{
+					BlockId:        blockIDA.Bytes(),
+					BlockHeight:    1,
+					BlockTimestamp: timestamppb.Now(),
+					Events:         []*entities.Event{eventAMsg},
+				},
+				{
+					BlockId:        blockIDB.Bytes(),
+					BlockHeight:    2,
+					BlockTimestamp: timestamppb.Now(),
+				},
+			},
+			err: io.EOF,
+		}
+
+		filter := flow.EventFilter{
+			EventTypes: []string{eventA.Type},
+		}
+
+		rpc.On("SubscribeEventsFromStartHeight", ctx, &executiondata.SubscribeEventsFromStartHeightRequest{
+			StartBlockHeight: 1,
+			Filter: &executiondata.EventFilter{
+				EventType: filter.EventTypes,
+			},
+		}).Return(stream, nil)
+
+		blocksChan, errChan, err := c.SubscribeEventsByBlockHeight(ctx, 1, filter)
+		require.NoError(t, err)
+
+		var blocks []client.BlockEvents
+		for block := range blocksChan {
+			blocks = append(blocks, block)
+		}
+
+		require.Len(t, blocks, 2)
+		assert.Equal(t, blockIDA, blocks[0].BlockID)
+		assert.Equal(t, []flow.Event{eventA}, blocks[0].Events)
+		assert.Equal(t, blockIDB, blocks[1].BlockID)
+		assert.Empty(t, blocks[1].Events)
+
+		assert.NoError(t, <-errChan)
+	}))
+
+	t.Run("Stream error", executionDataClientTest(func(t *testing.T, ctx context.Context, rpc *MockExecutionDataRPCClient, c *client.Client) {
+		stream := &mockEventsStream{err: errInternal}
+
+		rpc.On("SubscribeEventsFromStartHeight", ctx, mock.Anything).Return(stream, nil)
+
+		blocksChan, errChan, err := c.SubscribeEventsByBlockHeight(ctx, 1, flow.EventFilter{})
+		require.NoError(t, err)
+
+		for range blocksChan {
+		}
+
+		err = <-errChan
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	}))
+
+	t.Run("No execution data client", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		_, _, err := c.SubscribeEventsByBlockHeight(ctx, 1, flow.EventFilter{})
+		assert.Error(t, err)
+	}))
+}
+
+type mockAccountStatusesStream struct {
+	grpc.ClientStream
+	responses []*executiondata.SubscribeAccountStatusesResponse
+	err       error
+}
+
+func (s *mockAccountStatusesStream) Recv() (*executiondata.SubscribeAccountStatusesResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, s.err
+	}
+
+	res := s.responses[0]
+	s.responses = s.responses[1:]
+	return res, nil
+}
+
+func TestClient_SubscribeAccountStatusesByBlockHeight(t *testing.T) {
+	ids := test.IdentifierGenerator()
+	events := test.EventGenerator()
+	addresses := test.AddressGenerator()
+
+	t.Run("Success", executionDataClientTest(func(t *testing.T, ctx context.Context, rpc *MockExecutionDataRPCClient, c *client.Client) {
+		blockIDA, blockIDB := ids.New(), ids.New()
+		address := addresses.New()
+		eventA := events.New()
+
+		eventAMsg, _ := convert.EventToMessage(eventA)
+
+		stream := &mockAccountStatusesStream{
+			responses: []*executiondata.SubscribeAccountStatusesResponse{
+				{
+					BlockId:     blockIDA.Bytes(),
+					BlockHeight: 1,
+					Results: []*executiondata.SubscribeAccountStatusesResponse_Result{
+						{Address: address.Bytes(), Events: []*entities.Event{eventAMsg}},
+					},
+				},
+				{
+					BlockId:      blockIDB.Bytes(),
+					BlockHeight:  2,
+					MessageIndex: 1,
+				},
+			},
+			err: io.EOF,
+		}
+
+		filter := flow.AccountStatusFilter{
+			EventTypes: []string{flow.EventAccountContractUpdated},
+			Addresses:  []flow.Address{address},
+		}
+
+		rpc.On("SubscribeAccountStatusesFromStartHeight", ctx, &executiondata.SubscribeAccountStatusesFromStartHeightRequest{
+			StartBlockHeight: 1,
+			Filter: &executiondata.StatusFilter{
+				EventType: filter.EventTypes,
+				Address:   []string{address.Hex()},
+			},
+		}).Return(stream, nil)
+
+		statusesChan, errChan, err := c.SubscribeAccountStatusesByBlockHeight(ctx, 1, filter)
+		require.NoError(t, err)
+
+		var statuses []client.AccountStatus
+		for s := range statusesChan {
+			statuses = append(statuses, s)
+		}
+
+		require.Len(t, statuses, 2)
+		assert.Equal(t, blockIDA, statuses[0].BlockID)
+		assert.Equal(t, map[flow.Address][]flow.Event{address: {eventA}}, statuses[0].Events)
+		assert.Equal(t, blockIDB, statuses[1].BlockID)
+		assert.Equal(t, uint64(1), statuses[1].MessageIndex)
+		assert.Empty(t, statuses[1].Events)
+
+		assert.NoError(t, <-errChan)
+	}))
+
+	t.Run("Stream error", executionDataClientTest(func(t *testing.T, ctx context.Context, rpc *MockExecutionDataRPCClient, c *client.Client) {
+		stream := &mockAccountStatusesStream{err: errInternal}
+
+		rpc.On("SubscribeAccountStatusesFromStartHeight", ctx, mock.Anything).Return(stream, nil)
+
+		statusesChan, errChan, err := c.SubscribeAccountStatusesByBlockHeight(ctx, 1, flow.AccountStatusFilter{})
+		require.NoError(t, err)
+
+		for range statusesChan {
+		}
+
+		err = <-errChan
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	}))
+
+	t.Run("No execution data client", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		_, _, err := c.SubscribeAccountStatusesByBlockHeight(ctx, 1, flow.AccountStatusFilter{})
+		assert.Error(t, err)
+	}))
+}
+
+func TestClient_GetExecutionDataByBlockID(t *testing.T) {
+	ids := test.IdentifierGenerator()
+	transactions := test.TransactionGenerator()
+	events := test.EventGenerator()
+
+	t.Run("Success", executionDataClientTest(func(t *testing.T, ctx context.Context, rpc *MockExecutionDataRPCClient, c *client.Client) {
+		blockID := ids.New()
+		startState := flow.StateCommitment(ids.New())
+		tx := transactions.New()
+		event := events.New()
+
+		txMsg, err := convert.TransactionToMessage(*tx)
+		require.NoError(t, err)
+
+		eventMsg, err := convert.EventToMessage(event)
+		require.NoError(t, err)
+
+		response := &executiondata.GetExecutionDataByBlockIDResponse{
+			BlockExecutionData: &entities.BlockExecutionData{
+				BlockId: blockID.Bytes(),
+				ChunkExecutionData: []*entities.ChunkExecutionData{
+					{
+						Collection: &entities.ExecutionDataCollection{Transactions: []*entities.Transaction{txMsg}},
+						Events:     []*entities.Event{eventMsg},
+						TrieUpdate: &entities.TrieUpdate{
+							RootHash: startState[:],
+							Paths:    [][]byte{{1, 2}},
+							Payloads: []*entities.Payload{
+								{
+									KeyPart: []*entities.KeyPart{
+										{Type: 0, Value: []byte{0, 0, 0, 0, 0, 0, 0, 1}},
+										{Type: 2, Value: []byte("balance")},
+									},
+									Value: []byte{42},
+								},
+							},
+						},
+					},
+					{},
+				},
+			},
+		}
+
+		rpc.On("GetExecutionDataByBlockID", ctx, &executiondata.GetExecutionDataByBlockIDRequest{
+			BlockId:              blockID.Bytes(),
+			EventEncodingVersion: entities.EventEncodingVersion_JSON_CDC_V0,
+		}).Return(response, nil)
+
+		data, err := c.GetExecutionDataByBlockID(ctx, blockID)
+		require.NoError(t, err)
+
+		assert.Equal(t, blockID, data.BlockID)
+		require.Len(t, data.ChunkExecutionData, 2)
+
+		chunk := data.ChunkExecutionData[0]
+		require.Len(t, chunk.Transactions, 1)
+		assert.Equal(t, tx.ID(), chunk.Transactions[0].ID())
+		assert.Equal(t, []flow.Event{event}, chunk.Events)
+		assert.Equal(t, &flow.TrieUpdate{
+			RootHash: startState,
+			Registers: []flow.RegisterUpdate{
+				{
+					Path:       []byte{1, 2},
+					RegisterID: flow.NewRegisterID(flow.HexToAddress("01"), "balance"),
+					Value:      []byte{42},
+				},
+			},
+		}, chunk.TrieUpdate)
+
+		systemChunk := data.ChunkExecutionData[1]
+		assert.Empty(t, systemChunk.Transactions)
+		assert.Empty(t, systemChunk.Events)
+		assert.Nil(t, systemChunk.TrieUpdate)
+	}))
+
+	t.Run("Internal error", executionDataClientTest(func(t *testing.T, ctx context.Context, rpc *MockExecutionDataRPCClient, c *client.Client) {
+		rpc.On("GetExecutionDataByBlockID", ctx, mock.Anything).Return(nil, errInternal)
+
+		_, err := c.GetExecutionDataByBlockID(ctx, ids.New())
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	}))
+
+	t.Run("Invalid trie update", executionDataClientTest(func(t *testing.T, ctx context.Context, rpc *MockExecutionDataRPCClient, c *client.Client) {
+		response := &executiondata.GetExecutionDataByBlockIDResponse{
+			BlockExecutionData: &entities.BlockExecutionData{
+				ChunkExecutionData: []*entities.ChunkExecutionData{
+					{TrieUpdate: &entities.TrieUpdate{Paths: [][]byte{{1}}}},
+				},
+			},
+		}
+
+		rpc.On("GetExecutionDataByBlockID", ctx, mock.Anything).Return(response, nil)
+
+		_, err := c.GetExecutionDataByBlockID(ctx, ids.New())
+		assert.True(t, errors.Is(err, flowerrors.ErrDecoding))
+	}))
+
+	t.Run("No execution data client", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		_, err := c.GetExecutionDataByBlockID(ctx, ids.New())
+		assert.True(t, errors.Is(err, flowerrors.ErrUnsupported))
+	}))
+}
+
+func TestClient_GetRegisterValues(t *testing.T) {
+	address := flow.HexToAddress("01")
+	registerIDs := []flow.RegisterID{
+		flow.AccountStatusRegisterID(address),
+		flow.NewRegisterID(address, "contract_names"),
+	}
+
+	t.Run("Success", executionDataClientTest(func(t *testing.T, ctx context.Context, rpc *MockExecutionDataRPCClient, c *client.Client) {
+		rpc.On("GetRegisterValues", ctx, &executiondata.GetRegisterValuesRequest{
+			BlockHeight: 42,
+			RegisterIds: []*entities.RegisterID{
+				{Owner: address.Bytes(), Key: []byte("account_status")},
+				{Owner: address.Bytes(), Key: []byte("contract_names")},
+			},
+		}).Return(&executiondata.GetRegisterValuesResponse{Values: [][]byte{{1, 2}, {}}}, nil)
+
+		values, err := c.GetRegisterValues(ctx, 42, registerIDs)
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{{1, 2}, {}}, values)
+	}))
+
+	t.Run("Missing values", executionDataClientTest(func(t *testing.T, ctx context.Context, rpc *MockExecutionDataRPCClient, c *client.Client) {
+		rpc.On("GetRegisterValues", ctx, mock.Anything).
+			Return(&executiondata.GetRegisterValuesResponse{Values: [][]byte{{1, 2}}}, nil)
+
+		_, err := c.GetRegisterValues(ctx, 42, registerIDs)
+		assert.True(t, errors.Is(err, flowerrors.ErrDecoding))
+	}))
+
+	t.Run("Not found", executionDataClientTest(func(t *testing.T, ctx context.Context, rpc *MockExecutionDataRPCClient, c *client.Client) {
+		rpc.On("GetRegisterValues", ctx, mock.Anything).Return(nil, errNotFound)
+
+		_, err := c.GetRegisterValues(ctx, 42, registerIDs)
+		assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+	}))
+
+	t.Run("No execution data client", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		_, err := c.GetRegisterValues(ctx, 42, registerIDs)
+		assert.True(t, errors.Is(err, flowerrors.ErrUnsupported))
+	}))
+}
+
+func TestClient_GetLatestProtocolStateSnapshot(t *testing.T) {
+	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		expected := &access.ProtocolStateSnapshotResponse{
+			SerializedSnapshot: make([]byte, 128),
+		}
+		_, err := rand.Read(expected.SerializedSnapshot)
+		assert.NoError(t, err)
+
+		rpc.On("GetLatestProtocolStateSnapshot", ctx, mock.Anything).Return(expected, nil)
+
+		res, err := c.GetLatestProtocolStateSnapshot(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.SerializedSnapshot, res)
+	}))
+
+	t.Run("Internal error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetLatestProtocolStateSnapshot", ctx, mock.Anything).
+			Return(nil, errInternal)
+
+		_, err := c.GetLatestProtocolStateSnapshot(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	}))
+}
+
+func TestClient_GetServiceEvents(t *testing.T) {
+	blocks := test.BlockGenerator()
+
+	serviceEvents := []*entities.ServiceEvent{
+		{
+			Type:    flow.ServiceEventVersionBeacon,
+			Payload: []byte(`{"VersionBoundaries": [{"BlockHeight": 42, "Version": "0.33.0"}], "Sequence": 7}`),
+		},
+		{
+			Type:    flow.ServiceEventSetEpochExtensionViewCount,
+			Payload: []byte(`{"Value": 100000}`),
+		},
+	}
+
+	t.Run("By height", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		header := blocks.New().BlockHeader
+
+		b, err := convert.BlockHeaderToMessage(header)
+		require.NoError(t, err)
+
+		rpc.On("GetBlockHeaderByHeight", ctx, &access.GetBlockHeaderByHeightRequest{Height: header.Height}).
+			Return(&access.BlockHeaderResponse{Block: b}, nil)
+
+		rpc.On("GetExecutionResultForBlockID", ctx, &access.GetExecutionResultForBlockIDRequest{
+			BlockId: header.ID.Bytes(),
+		}).Return(&access.ExecutionResultForBlockIDResponse{
+			ExecutionResult: &entities.ExecutionResult{
+				BlockId:       header.ID.Bytes(),
+				ServiceEvents: serviceEvents,
+			},
+		}, nil)
+
+		events, err := c.GetServiceEventsForBlockHeight(ctx, header.Height)
+		require.NoError(t, err)
+
+		require.Len(t, events, 2)
+		assert.Equal(t, flow.ServiceEventVersionBeacon, events[0].Type)
+		assert.Equal(t, flow.ServiceEventSetEpochExtensionViewCount, events[1].Type)
+	}))
+
+	t.Run("Decoded", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		blockID := blocks.New().ID
+
+		rpc.On("GetExecutionResultForBlockID", ctx, mock.Anything).
+			Return(&access.ExecutionResultForBlockIDResponse{
+				ExecutionResult: &entities.ExecutionResult{
+					BlockId:       blockID.Bytes(),
+					ServiceEvents: serviceEvents,
+				},
+			}, nil)
+
+		events, err := c.GetDecodedServiceEventsForBlockID(ctx, blockID)
+		require.NoError(t, err)
+
+		assert.Equal(t, []interface{}{
+			&flow.VersionBeacon{
+				VersionBoundaries: []flow.VersionBoundary{{BlockHeight: 42, Version: "0.33.0"}},
+				Sequence:          7,
+			},
+			&flow.SetEpochExtensionViewCount{Value: 100000},
+		}, events)
+	}))
+
+	t.Run("Unsupported type", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetExecutionResultForBlockID", ctx, mock.Anything).
+			Return(&access.ExecutionResultForBlockIDResponse{
+				ExecutionResult: &entities.ExecutionResult{
+					ServiceEvents: []*entities.ServiceEvent{{Type: "foo"}},
+				},
+			}, nil)
+
+		_, err := c.GetDecodedServiceEventsForBlockID(ctx, blocks.New().ID)
+		assert.Error(t, err)
+	}))
+}
+
+func TestClient_GetExecutionResultForBlockID(t *testing.T) {
+	ids := test.IdentifierGenerator()
+	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		blockID := ids.New()
+		executionResult := &entities.ExecutionResult{
+			PreviousResultId: ids.New().Bytes(),
+			BlockId:          blockID.Bytes(),
+			Chunks: []*entities.Chunk{
+				{
+					CollectionIndex:      0,
+					StartState:           ids.New().Bytes(),
+					EventCollection:      ids.New().Bytes(),
+					BlockId:              blockID.Bytes(),
+					TotalComputationUsed: 22,
+					NumberOfTransactions: 33,
+					Index:                0,
+					EndState:             ids.New().Bytes(),
+				},
+				{
+					CollectionIndex:      1,
+					StartState:           ids.New().Bytes(),
+					EventCollection:      ids.New().Bytes(),
+					BlockId:              blockID.Bytes(),
+					TotalComputationUsed: 222,
+					NumberOfTransactions: 333,
+					Index:                1,
+					EndState:             ids.New().Bytes(),
+				},
+			},
+			ServiceEvents: []*entities.ServiceEvent{
+				{
 					Type:    "serviceEvent",
 					Payload: []byte("{\"whatever\":21}"),
 				},
@@ -920,3 +1884,256 @@ func TestClient_GetExecutionResultForBlockID(t *testing.T) {
 		assert.Equal(t, codes.Internal, status.Code(err))
 	}))
 }
+
+func TestClient_GetExecutionResultByID(t *testing.T) {
+	ids := test.IdentifierGenerator()
+
+	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		resultID := ids.New()
+		blockID := ids.New()
+		endState := ids.New()
+
+		rpc.On("GetExecutionResultByID", ctx, &access.GetExecutionResultByIDRequest{
+			Id: resultID.Bytes(),
+		}).Return(&access.ExecutionResultByIDResponse{
+			ExecutionResult: &entities.ExecutionResult{
+				BlockId: blockID.Bytes(),
+				Chunks: []*entities.Chunk{
+					{BlockId: blockID.Bytes(), EndState: endState.Bytes()},
+				},
+			},
+		}, nil)
+
+		res, err := c.GetExecutionResultByID(ctx, resultID)
+		require.NoError(t, err)
+
+		assert.Equal(t, blockID, res.BlockID)
+		require.Len(t, res.Chunks, 1)
+		assert.Equal(t, flow.StateCommitment(endState), res.Chunks[0].EndState)
+	}))
+
+	t.Run("Not found error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetExecutionResultByID", ctx, mock.Anything).
+			Return(nil, errNotFound)
+
+		res, err := c.GetExecutionResultByID(ctx, ids.New())
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Nil(t, res)
+	}))
+}
+
+func TestClient_Capabilities(t *testing.T) {
+	errUnimplemented := status.Error(codes.Unimplemented, "unimplemented")
+
+	t.Run("All features", func(t *testing.T) {
+		ctx := context.Background()
+		rpc := &MockRPCClient{}
+		execRPC := &MockExecutionDataRPCClient{}
+		c := client.NewFromRPCClients(rpc, execRPC)
+
+		rpc.On("GetNodeVersionInfo", ctx, mock.Anything).
+			Return(&access.GetNodeVersionInfoResponse{
+				Info: &entities.NodeVersionInfo{
+					Semver:               "v0.33.1",
+					SporkRootBlockHeight: 100,
+					NodeRootBlockHeight:  150,
+					CompatibleRange:      &entities.CompatibleRange{StartHeight: 100},
+				},
+			}, nil).
+			Once()
+
+		execRPC.On("GetExecutionDataByBlockID", ctx, mock.Anything).
+			Return(nil, errNotFound).
+			Once()
+
+		execRPC.On("SubscribeEventsFromLatest", mock.Anything, mock.Anything).
+			Return(&mockEventsStream{
+				responses: []*executiondata.SubscribeEventsResponse{{BlockHeight: 200}},
+			}, nil).
+			Once()
+
+		caps, err := c.Capabilities(ctx)
+		require.NoError(t, err)
+
+		assert.True(t, caps.ExecutionData)
+		assert.True(t, caps.Streaming)
+		require.NotNil(t, caps.NodeVersion)
+		assert.Equal(t, "v0.33.1", caps.NodeVersion.Semver)
+		assert.Equal(t, &client.CompatibleRange{StartHeight: 100}, caps.NodeVersion.CompatibleRange)
+
+		lowest, ok := caps.LowestHeight()
+		assert.True(t, ok)
+		assert.Equal(t, uint64(150), lowest)
+		assert.False(t, caps.ServesHeight(120))
+		assert.True(t, caps.ServesHeight(150))
+
+		// Capabilities are cached after the first call.
+		cached, err := c.Capabilities(ctx)
+		require.NoError(t, err)
+		assert.Same(t, caps, cached)
+
+		rpc.AssertExpectations(t)
+		execRPC.AssertExpectations(t)
+	})
+
+	t.Run("Unimplemented features", func(t *testing.T) {
+		ctx := context.Background()
+		rpc := &MockRPCClient{}
+		execRPC := &MockExecutionDataRPCClient{}
+		c := client.NewFromRPCClients(rpc, execRPC)
+
+		rpc.On("GetNodeVersionInfo", ctx, mock.Anything).Return(nil, errUnimplemented)
+		execRPC.On("GetExecutionDataByBlockID", ctx, mock.Anything).Return(nil, errUnimplemented)
+		execRPC.On("SubscribeEventsFromLatest", mock.Anything, mock.Anything).
+			Return(&mockEventsStream{err: errUnimplemented}, nil)
+
+		caps, err := c.Capabilities(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, &client.Capabilities{}, caps)
+		assert.True(t, caps.ServesHeight(0))
+
+		rpc.AssertExpectations(t)
+		execRPC.AssertExpectations(t)
+	})
+
+	t.Run("No execution data client", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetNodeVersionInfo", ctx, mock.Anything).
+			Return(&access.GetNodeVersionInfoResponse{Info: &entities.NodeVersionInfo{}}, nil)
+
+		caps, err := c.Capabilities(ctx)
+		require.NoError(t, err)
+
+		assert.NotNil(t, caps.NodeVersion)
+		assert.False(t, caps.ExecutionData)
+		assert.False(t, caps.Streaming)
+	}))
+
+	t.Run("Error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetNodeVersionInfo", ctx, mock.Anything).Return(nil, errInternal)
+
+		caps, err := c.Capabilities(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Nil(t, caps)
+	}))
+}
+
+func TestNodeVersionInfo_CadenceVersion(t *testing.T) {
+	for semver, expected := range map[string]flow.CadenceVersion{
+		"v0.33.1":        flow.CadenceV0,
+		"0.36.9":         flow.CadenceV0,
+		"v0.37.0":        flow.CadenceV1,
+		"v0.37.10-rc.1":  flow.CadenceV1,
+		"v0.38.0+abcdef": flow.CadenceV1,
+		"v1.0.0":         flow.CadenceV1,
+	} {
+		version, err := (&client.NodeVersionInfo{Semver: semver}).CadenceVersion()
+		require.NoError(t, err, semver)
+		assert.Equal(t, expected, version, semver)
+	}
+
+	for _, semver := range []string{"", "undefined", "v0.37", "v0.x.0"} {
+		_, err := (&client.NodeVersionInfo{Semver: semver}).CadenceVersion()
+		assert.ErrorIs(t, err, flowerrors.ErrUnsupported, semver)
+	}
+
+	assert.Equal(t, "1.0", flow.CadenceV1.String())
+}
+
+func TestClient_CadenceVersion(t *testing.T) {
+	// a script result of Cadence 1.0 that the SDK cannot decode as is
+	cadence1Value := []byte(`{"type":"Type","value":{"staticType":{"kind":"Optional","type":{"kind":"String"}}}}`)
+
+	t.Run("Script result", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).
+			Return(&access.ExecuteScriptResponse{Value: cadence1Value}, nil)
+
+		assert.Equal(t, flow.CadenceV0, c.CadenceVersion())
+
+		_, err := c.ExecuteScriptAtLatestBlock(ctx, []byte("foo"), nil)
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+
+		require.NoError(t, c.SetCadenceVersion(flow.CadenceV1))
+
+		value, err := c.ExecuteScriptAtLatestBlock(ctx, []byte("foo"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.TypeValue{StaticType: "String?"}, value)
+	}))
+
+	t.Run("Transaction result events", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(&access.TransactionResultResponse{
+				Status: entities.TransactionStatus_SEALED,
+				Events: []*entities.Event{{
+					Type: "A.0000000000000001.Foo.Bar",
+					Payload: []byte(`{"type":"Event","value":{"id":"A.0000000000000001.Foo.Bar","fields":[` +
+						`{"name":"initial","value":{"type":"Character","value":"a"}}]}}`),
+				}},
+			}, nil)
+
+		require.NoError(t, c.SetCadenceVersion(flow.CadenceV1))
+
+		result, err := c.GetTransactionResult(ctx, flow.EmptyID)
+		require.NoError(t, err)
+		require.Len(t, result.Events, 1)
+		assert.Equal(t, []cadence.Value{cadence.String("a")}, result.Events[0].Value.Fields)
+	}))
+
+	// Responses may be shared by concurrent calls: run with -race to detect writes to them.
+	t.Run("Shared messages", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		payload := []byte(`{"type":"Event","value":{"id":"A.0000000000000001.Foo.Bar","fields":[` +
+			`{"name":"initial","value":{"type":"Character","value":"a"}}]}}`)
+
+		response := &access.TransactionResultResponse{
+			Status: entities.TransactionStatus_SEALED,
+			Events: []*entities.Event{{Type: "A.0000000000000001.Foo.Bar", Payload: payload}},
+		}
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(response, nil)
+
+		for _, version := range []flow.CadenceVersion{flow.CadenceV0, flow.CadenceV1} {
+			require.NoError(t, c.SetCadenceVersion(version))
+
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, _ = c.GetTransactionResult(ctx, flow.EmptyID)
+				}()
+			}
+			wg.Wait()
+		}
+
+		assert.Equal(t, payload, response.Events[0].Payload)
+	}))
+
+	t.Run("Detect", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetNodeVersionInfo", ctx, mock.Anything).
+			Return(&access.GetNodeVersionInfoResponse{Info: &entities.NodeVersionInfo{Semver: "v0.37.1"}}, nil).
+			Once()
+
+		version, err := c.DetectCadenceVersion(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, flow.CadenceV1, version)
+		assert.Equal(t, flow.CadenceV1, c.CadenceVersion())
+
+		rpc.On("GetNodeVersionInfo", ctx, mock.Anything).
+			Return(&access.GetNodeVersionInfoResponse{Info: &entities.NodeVersionInfo{Semver: "undefined"}}, nil).
+			Once()
+
+		_, err = c.DetectCadenceVersion(ctx)
+		assert.ErrorIs(t, err, flowerrors.ErrUnsupported)
+		assert.Equal(t, flow.CadenceV1, c.CadenceVersion())
+	}))
+
+	t.Run("Legacy", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		assert.Equal(t, flow.CadenceV0, c.CadenceVersion())
+
+		err := c.SetCadenceVersion(flow.CadenceVersion(42))
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+		assert.Equal(t, flow.CadenceV0, c.CadenceVersion())
+	}))
+}
@@ -0,0 +1,174 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/accesstest"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+// callAll calls every method of v in the method set of methods, except Close, with
+// zero arguments, and returns the names of the methods that panicked.
+//
+// Streaming methods are cancelled as soon as they return, and their channels are
+// drained until closed.
+func callAll(v interface{}, methods reflect.Type) []string {
+	var panicked []string
+
+	value := reflect.ValueOf(v)
+
+	for i := 0; i < methods.NumMethod(); i++ {
+		name := methods.Method(i).Name
+		if name == "Close" {
+			continue
+		}
+
+		if !callMethod(value.MethodByName(name)) {
+			panicked = append(panicked, name)
+		}
+	}
+
+	return panicked
+}
+
+func callMethod(method reflect.Value) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	methodType := method.Type()
+
+	numIn := methodType.NumIn()
+	if methodType.IsVariadic() {
+		numIn--
+	}
+
+	args := make([]reflect.Value, numIn)
+	for i := range args {
+		in := methodType.In(i)
+
+		if in == reflect.TypeOf((*context.Context)(nil)).Elem() {
+			args[i] = reflect.ValueOf(ctx)
+			continue
+		}
+
+		args[i] = reflect.Zero(in)
+	}
+
+	results := method.Call(args)
+
+	cancel()
+
+	for _, result := range results {
+		if result.Kind() != reflect.Chan || result.IsNil() {
+			continue
+		}
+
+		for {
+			if _, open := result.Recv(); !open {
+				break
+			}
+		}
+	}
+
+	return true
+}
+
+// TestClient_Concurrent exercises all methods of a single client concurrently. Run
+// with -race to detect state shared between calls.
+func TestClient_Concurrent(t *testing.T) {
+	account := test.AccountGenerator().New()
+	account.Address = flow.EmptyAddress
+
+	scenario := accesstest.NewScenario().
+		Heights(1, 2, 3, 4, 5).
+		DefaultTransactionStatuses(flow.TransactionStatusPending, flow.TransactionStatusSealed).
+		Account(account)
+
+	node := accesstest.Start(t, scenario)
+
+	clientMethods := reflect.TypeOf((*client.AccessClient)(nil)).Elem()
+
+	t.Run("Calls", func(t *testing.T) {
+		c := node.Client()
+
+		runConcurrently(t, func() []string { return callAll(c, clientMethods) }, nil)
+	})
+
+	t.Run("Close during calls", func(t *testing.T) {
+		c := node.Client()
+
+		runConcurrently(t, func() []string { return callAll(c, clientMethods) }, func() {
+			_ = c.Close()
+		})
+	})
+
+	t.Run("Snapshot", func(t *testing.T) {
+		snapshot := node.Client().Snapshot(1)
+		snapshotMethods := reflect.TypeOf(snapshot)
+
+		runConcurrently(t, func() []string { return callAll(snapshot, snapshotMethods) }, nil)
+	})
+}
+
+// runConcurrently runs calls from several goroutines at once, and runs during while
+// they are running.
+func runConcurrently(t *testing.T, calls func() []string, during func()) {
+	const goroutines = 8
+
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if panicked := calls(); len(panicked) > 0 {
+				errs <- fmt.Errorf("methods panicked: %v", panicked)
+			}
+		}()
+	}
+
+	if during != nil {
+		during()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,189 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// Cadence1ToLegacyJSONCDC rewrites a JSON-CDC value encoded by Cadence 1.0 to
+// the encoding of the Cadence values of the SDK, so that it can be decoded with
+// MessageToCadenceValue.
+//
+// Types, e.g. of type values and of the borrow type of capabilities, are
+// rewritten to their type IDs, and characters to strings. Capabilities are
+// identified by an ID instead of a path in Cadence 1.0: their ID is dropped and
+// their path is empty. Values of kinds introduced by Cadence 1.0, such as
+// functions and ranges, are kept and fail to decode.
+func Cadence1ToLegacyJSONCDC(m []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(m))
+	dec.UseNumber()
+
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "convert: %w", err)
+	}
+
+	b, err := json.Marshal(legacyValue(value))
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "convert: %w", err)
+	}
+
+	return b, nil
+}
+
+// legacyValue rewrites a decoded JSON-CDC value.
+func legacyValue(v interface{}) interface{} {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	value, _ := obj["value"].(map[string]interface{})
+
+	switch obj["type"] {
+	case "Character":
+		obj["type"] = "String"
+
+	case "Optional":
+		if obj["value"] != nil {
+			obj["value"] = legacyValue(obj["value"])
+		}
+
+	case "Array":
+		if elements, ok := obj["value"].([]interface{}); ok {
+			for i, element := range elements {
+				elements[i] = legacyValue(element)
+			}
+		}
+
+	case "Dictionary":
+		if pairs, ok := obj["value"].([]interface{}); ok {
+			for _, pair := range pairs {
+				if pair, ok := pair.(map[string]interface{}); ok {
+					pair["key"] = legacyValue(pair["key"])
+					pair["value"] = legacyValue(pair["value"])
+				}
+			}
+		}
+
+	case "Struct", "Resource", "Event", "Contract", "Enum":
+		if fields, ok := value["fields"].([]interface{}); ok {
+			for _, field := range fields {
+				if field, ok := field.(map[string]interface{}); ok {
+					field["value"] = legacyValue(field["value"])
+				}
+			}
+		}
+
+	case "Type":
+		if staticType, ok := value["staticType"]; ok && staticType != nil {
+			value["staticType"] = typeID(staticType)
+		}
+
+	case "Capability":
+		if _, ok := value["path"]; !ok && value != nil {
+			delete(value, "id")
+			value["path"] = map[string]interface{}{
+				"type":  "Path",
+				"value": map[string]interface{}{"domain": "", "identifier": ""},
+			}
+		}
+		if borrowType, ok := value["borrowType"]; ok {
+			value["borrowType"] = typeID(borrowType)
+		}
+	}
+
+	return obj
+}
+
+// typeID returns the type ID of a JSON-CDC type. Types already encoded as a
+// string, such as repeated composite types, are returned as is.
+func typeID(t interface{}) string {
+	switch t := t.(type) {
+	case string:
+		return t
+	case map[string]interface{}:
+		if id, ok := t["typeID"].(string); ok && id != "" {
+			return id
+		}
+
+		kind, _ := t["kind"].(string)
+
+		switch kind {
+		case "Optional":
+			return typeID(t["type"]) + "?"
+		case "VariableSizedArray":
+			return "[" + typeID(t["type"]) + "]"
+		case "ConstantSizedArray":
+			return fmt.Sprintf("[%s; %v]", typeID(t["type"]), t["size"])
+		case "Dictionary":
+			return "{" + typeID(t["key"]) + ": " + typeID(t["value"]) + "}"
+		case "Intersection", "Restriction":
+			return "{" + typeIDs(t["types"], ", ") + "}"
+		case "Capability":
+			if t["type"] == nil || t["type"] == "" {
+				return "Capability"
+			}
+			return "Capability<" + typeID(t["type"]) + ">"
+		case "Reference":
+			return authorization(t["authorization"]) + "&" + typeID(t["type"])
+		default:
+			return kind
+		}
+	default:
+		return ""
+	}
+}
+
+// authorization returns the authorization of a reference type, with a
+// trailing space, or an empty string for an unauthorized reference.
+func authorization(a interface{}) string {
+	obj, ok := a.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	switch obj["kind"] {
+	case "EntitlementConjunctionSet":
+		return "auth(" + typeIDs(obj["entitlements"], ", ") + ") "
+	case "EntitlementDisjunctionSet":
+		return "auth(" + typeIDs(obj["entitlements"], " | ") + ") "
+	case "EntitlementMapAuthorization":
+		return "auth(mapping " + typeIDs(obj["entitlements"], "") + ") "
+	default:
+		return ""
+	}
+}
+
+func typeIDs(types interface{}, sep string) string {
+	list, _ := types.([]interface{})
+
+	ids := make([]string, len(list))
+	for i, t := range list {
+		ids[i] = typeID(t)
+	}
+
+	return strings.Join(ids, sep)
+}
@@ -0,0 +1,86 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/client/convert"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+func TestCadence1ToLegacyJSONCDC(t *testing.T) {
+	// an event of Cadence 1.0 with fields that the SDK cannot decode as is
+	payload := []byte(`{
+		"type": "Event",
+		"value": {
+			"id": "A.0000000000000001.Foo.Bar",
+			"fields": [
+				{"name": "initial", "value": {"type": "Character", "value": "a"}},
+				{"name": "type", "value": {"type": "Type", "value": {"staticType": {
+					"kind": "Reference",
+					"authorization": {
+						"kind": "EntitlementConjunctionSet",
+						"entitlements": [{"kind": "Entitlement", "typeID": "A.0000000000000001.Foo.E"}]
+					},
+					"type": {"kind": "VariableSizedArray", "type": {"kind": "Int"}}
+				}}}},
+				{"name": "cap", "value": {"type": "Optional", "value": {"type": "Capability", "value": {
+					"id": "3",
+					"address": "0x0000000000000001",
+					"borrowType": {"kind": "Reference", "authorization": {"kind": "Unauthorized"}, "type": {
+						"kind": "Intersection",
+						"typeID": "",
+						"types": [{"kind": "ResourceInterface", "typeID": "A.0000000000000001.Foo.I", "fields": [], "initializers": [], "type": ""}]
+					}}
+				}}}},
+				{"name": "amounts", "value": {"type": "Dictionary", "value": [
+					{"key": {"type": "String", "value": "a"}, "value": {"type": "Optional", "value": null}}
+				]}}
+			]
+		}
+	}`)
+
+	legacy, err := convert.Cadence1ToLegacyJSONCDC(payload)
+	require.NoError(t, err)
+
+	value, err := convert.MessageToCadenceValue(legacy)
+	require.NoError(t, err)
+
+	event, ok := value.(cadence.Event)
+	require.True(t, ok)
+	require.Len(t, event.Fields, 4)
+
+	assert.Equal(t, cadence.String("a"), event.Fields[0])
+	assert.Equal(t, cadence.TypeValue{StaticType: "auth(A.0000000000000001.Foo.E) &[Int]"}, event.Fields[1])
+	assert.Equal(t, cadence.NewOptional(cadence.Capability{
+		Address:    cadence.BytesToAddress([]byte{1}),
+		BorrowType: "&{A.0000000000000001.Foo.I}",
+	}), event.Fields[2])
+
+	_, err = convert.MessageToCadenceValue(payload)
+	assert.Error(t, err)
+
+	_, err = convert.Cadence1ToLegacyJSONCDC([]byte("{"))
+	assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+}
@@ -0,0 +1,210 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package convert
+
+import (
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/runtime/protoiface"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/internal/protov2"
+)
+
+// A Format is a serialization format of the Flow protobuf messages, used to store
+// and transport SDK entities in the schema of the Access API.
+type Format int
+
+const (
+	// FormatProtobuf is the binary protobuf wire format. Encoding is deterministic
+	// for a given version of the SDK.
+	FormatProtobuf Format = iota
+	// FormatJSON is the canonical JSON mapping of protobuf messages, in which byte
+	// fields are base64-encoded. Its whitespace is not stable, so encoded values
+	// should be compared after decoding.
+	FormatJSON
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatProtobuf:
+		return "protobuf"
+	case FormatJSON:
+		return "json"
+	default:
+		return "unknown"
+	}
+}
+
+func marshal(m protoiface.MessageV1, format Format) ([]byte, error) {
+	switch format {
+	case FormatProtobuf:
+		return proto.MarshalOptions{Deterministic: true}.Marshal(protov2.Message(m))
+	case FormatJSON:
+		return protojson.Marshal(protov2.Message(m))
+	default:
+		return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "convert: unknown format %d", format)
+	}
+}
+
+func unmarshal(b []byte, m protoiface.MessageV1, format Format) error {
+	var err error
+
+	switch format {
+	case FormatProtobuf:
+		err = proto.Unmarshal(b, protov2.Message(m))
+	case FormatJSON:
+		err = protojson.Unmarshal(b, protov2.Message(m))
+	default:
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "convert: unknown format %d", format)
+	}
+
+	if err != nil {
+		return flowerrors.Errorf(flowerrors.ErrDecoding, "convert: failed to decode %s message: %w", format, err)
+	}
+
+	return nil
+}
+
+// EncodeAccount encodes an account as an entities.Account message.
+func EncodeAccount(a flow.Account, format Format) ([]byte, error) {
+	return marshal(AccountToMessage(a), format)
+}
+
+// DecodeAccount decodes an account encoded with EncodeAccount.
+func DecodeAccount(b []byte, format Format) (flow.Account, error) {
+	var m entities.Account
+	if err := unmarshal(b, &m, format); err != nil {
+		return flow.Account{}, err
+	}
+
+	return MessageToAccount(&m)
+}
+
+// EncodeBlock encodes a block as an entities.Block message.
+func EncodeBlock(b flow.Block, format Format) ([]byte, error) {
+	m, err := BlockToMessage(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal(m, format)
+}
+
+// DecodeBlock decodes a block encoded with EncodeBlock.
+func DecodeBlock(b []byte, format Format) (flow.Block, error) {
+	var m entities.Block
+	if err := unmarshal(b, &m, format); err != nil {
+		return flow.Block{}, err
+	}
+
+	return MessageToBlock(&m)
+}
+
+// EncodeBlockHeader encodes a block header as an entities.BlockHeader message.
+func EncodeBlockHeader(h flow.BlockHeader, format Format) ([]byte, error) {
+	m, err := BlockHeaderToMessage(h)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal(m, format)
+}
+
+// DecodeBlockHeader decodes a block header encoded with EncodeBlockHeader.
+func DecodeBlockHeader(b []byte, format Format) (flow.BlockHeader, error) {
+	var m entities.BlockHeader
+	if err := unmarshal(b, &m, format); err != nil {
+		return flow.BlockHeader{}, err
+	}
+
+	return MessageToBlockHeader(&m)
+}
+
+// EncodeCollection encodes a collection as an entities.Collection message.
+func EncodeCollection(c flow.Collection, format Format) ([]byte, error) {
+	return marshal(CollectionToMessage(c), format)
+}
+
+// DecodeCollection decodes a collection encoded with EncodeCollection.
+func DecodeCollection(b []byte, format Format) (flow.Collection, error) {
+	var m entities.Collection
+	if err := unmarshal(b, &m, format); err != nil {
+		return flow.Collection{}, err
+	}
+
+	return MessageToCollection(&m)
+}
+
+// EncodeTransaction encodes a transaction as an entities.Transaction message.
+func EncodeTransaction(t flow.Transaction, format Format) ([]byte, error) {
+	m, err := TransactionToMessage(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal(m, format)
+}
+
+// DecodeTransaction decodes a transaction encoded with EncodeTransaction.
+func DecodeTransaction(b []byte, format Format) (flow.Transaction, error) {
+	var m entities.Transaction
+	if err := unmarshal(b, &m, format); err != nil {
+		return flow.Transaction{}, err
+	}
+
+	return MessageToTransaction(&m)
+}
+
+// EncodeEvent encodes an event as an entities.Event message, with a JSON-CDC payload.
+func EncodeEvent(e flow.Event, format Format) ([]byte, error) {
+	m, err := EventToMessage(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal(m, format)
+}
+
+// DecodeEvent decodes an event encoded with EncodeEvent.
+func DecodeEvent(b []byte, format Format) (flow.Event, error) {
+	var m entities.Event
+	if err := unmarshal(b, &m, format); err != nil {
+		return flow.Event{}, err
+	}
+
+	return MessageToEvent(&m)
+}
+
+// EncodeExecutionResult encodes an execution result as an entities.ExecutionResult message.
+func EncodeExecutionResult(er flow.ExecutionResult, format Format) ([]byte, error) {
+	return marshal(ExecutionResultToMessage(er), format)
+}
+
+// DecodeExecutionResult decodes an execution result encoded with EncodeExecutionResult.
+func DecodeExecutionResult(b []byte, format Format) (*flow.ExecutionResult, error) {
+	var m entities.ExecutionResult
+	if err := unmarshal(b, &m, format); err != nil {
+		return nil, err
+	}
+
+	return MessageToExecutionResult(&m)
+}
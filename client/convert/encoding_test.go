@@ -0,0 +1,150 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package convert_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client/convert"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+var formats = []convert.Format{convert.FormatProtobuf, convert.FormatJSON}
+
+func TestEncoding(t *testing.T) {
+	ids := test.IdentifierGenerator()
+
+	account := test.AccountGenerator().New()
+	block := test.BlockGenerator().New()
+	header := test.BlockHeaderGenerator().New()
+
+	// empty lists are not distinguished from missing lists once encoded
+	for _, seal := range block.Seals {
+		seal.ExecutionReceiptSignatures = nil
+		seal.ResultApprovalSignatures = nil
+	}
+
+	collection := test.CollectionGenerator().New()
+	tx := test.TransactionGenerator().New()
+	event := test.EventGenerator().New()
+	result := &flow.ExecutionResult{
+		PreviousResultID: ids.New(),
+		BlockID:          ids.New(),
+		Chunks: []*flow.Chunk{
+			{
+				CollectionIndex:      1,
+				StartState:           flow.StateCommitment(ids.New()),
+				EventCollection:      ids.New().Bytes(),
+				BlockID:              ids.New(),
+				TotalComputationUsed: 100,
+				NumberOfTransactions: 2,
+				Index:                1,
+				EndState:             flow.StateCommitment(ids.New()),
+			},
+		},
+		ServiceEvents: []*flow.ServiceEvent{
+			{Type: "flow.EpochSetup", Payload: []byte("{}")},
+		},
+	}
+
+	for _, format := range formats {
+		t.Run(format.String(), func(t *testing.T) {
+			b, err := convert.EncodeAccount(*account, format)
+			require.NoError(t, err)
+			decodedAccount, err := convert.DecodeAccount(b, format)
+			require.NoError(t, err)
+			assert.Equal(t, *account, decodedAccount)
+
+			b, err = convert.EncodeBlock(*block, format)
+			require.NoError(t, err)
+			decodedBlock, err := convert.DecodeBlock(b, format)
+			require.NoError(t, err)
+			assert.Equal(t, *block, decodedBlock)
+
+			b, err = convert.EncodeBlockHeader(header, format)
+			require.NoError(t, err)
+			decodedHeader, err := convert.DecodeBlockHeader(b, format)
+			require.NoError(t, err)
+			assert.Equal(t, header, decodedHeader)
+
+			b, err = convert.EncodeCollection(*collection, format)
+			require.NoError(t, err)
+			decodedCollection, err := convert.DecodeCollection(b, format)
+			require.NoError(t, err)
+			assert.Equal(t, *collection, decodedCollection)
+
+			b, err = convert.EncodeTransaction(*tx, format)
+			require.NoError(t, err)
+			decodedTx, err := convert.DecodeTransaction(b, format)
+			require.NoError(t, err)
+			assert.Equal(t, tx.ID(), decodedTx.ID())
+
+			b, err = convert.EncodeEvent(event, format)
+			require.NoError(t, err)
+			decodedEvent, err := convert.DecodeEvent(b, format)
+			require.NoError(t, err)
+			assert.Equal(t, event, decodedEvent)
+
+			b, err = convert.EncodeExecutionResult(*result, format)
+			require.NoError(t, err)
+			decodedResult, err := convert.DecodeExecutionResult(b, format)
+			require.NoError(t, err)
+			assert.Equal(t, result, decodedResult)
+		})
+	}
+}
+
+func TestEncoding_Deterministic(t *testing.T) {
+	block := test.BlockGenerator().New()
+
+	a, err := convert.EncodeBlock(*block, convert.FormatProtobuf)
+	require.NoError(t, err)
+
+	b, err := convert.EncodeBlock(*block, convert.FormatProtobuf)
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b)
+}
+
+func TestEncoding_JSONSchema(t *testing.T) {
+	b, err := convert.EncodeCollection(flow.Collection{TransactionIDs: []flow.Identifier{flow.HexToID("01")}}, convert.FormatJSON)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"transactionIds": ["AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="]}`, string(b))
+}
+
+func TestEncoding_Invalid(t *testing.T) {
+	_, err := convert.DecodeTransaction([]byte("not a transaction"), convert.FormatJSON)
+	assert.True(t, errors.Is(err, flowerrors.ErrDecoding))
+
+	_, err = convert.DecodeBlock([]byte{0xff, 0xff}, convert.FormatProtobuf)
+	assert.True(t, errors.Is(err, flowerrors.ErrDecoding))
+
+	_, err = convert.EncodeCollection(flow.Collection{}, convert.Format(42))
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+
+	_, err = convert.DecodeCollection(nil, convert.Format(42))
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+}
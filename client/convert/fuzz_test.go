@@ -0,0 +1,50 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+
+	"github.com/onflow/flow-go-sdk/client/convert"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func FuzzMessageToEvent(f *testing.F) {
+	msg, err := convert.EventToMessage(test.EventGenerator().New())
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(msg.GetPayload())
+	f.Add([]byte(`{"type":"Int","value":"42"}`))
+	f.Add([]byte(`{"type":"Event","value":{"id":"","fields":[]}}`))
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		_, _ = convert.MessageToEvent(&entities.Event{
+			Type:    msg.GetType(),
+			Payload: payload,
+		})
+	})
+}
@@ -16,6 +16,8 @@
  * limitations under the License.
  */
 
+// Package convert converts between SDK entities and the messages of the Flow protobuf
+// schema, and encodes entities to binary protobuf or JSON with these messages.
 package convert
 
 import (
@@ -31,9 +33,10 @@ import (
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
 )
 
-var ErrEmptyMessage = errors.New("protobuf message is empty")
+var ErrEmptyMessage = flowerrors.New(flowerrors.ErrDecoding, "protobuf message is empty")
 
 func AccountToMessage(a flow.Account) *entities.Account {
 	accountKeys := make([]*entities.AccountKey, len(a.Keys))
@@ -193,7 +196,7 @@ func MessageToBlockHeader(m *entities.BlockHeader) (flow.BlockHeader, error) {
 func CadenceValueToMessage(value cadence.Value) ([]byte, error) {
 	b, err := jsoncdc.Encode(value)
 	if err != nil {
-		return nil, fmt.Errorf("convert: %w", err)
+		return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "convert: %w", err)
 	}
 
 	return b, nil
@@ -214,7 +217,7 @@ func CadenceValuesToMessages(values []cadence.Value) ([][]byte, error) {
 func MessageToCadenceValue(m []byte) (cadence.Value, error) {
 	v, err := jsoncdc.Decode(m)
 	if err != nil {
-		return nil, fmt.Errorf("convert: %w", err)
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "convert: %w", err)
 	}
 
 	return v, nil
@@ -250,7 +253,11 @@ func MessageToCollection(m *entities.Collection) (flow.Collection, error) {
 
 func CollectionGuaranteeToMessage(g flow.CollectionGuarantee) *entities.CollectionGuarantee {
 	return &entities.CollectionGuarantee{
-		CollectionId: g.CollectionID.Bytes(),
+		CollectionId:     g.CollectionID.Bytes(),
+		ReferenceBlockId: resultIDToMessage(g.ReferenceBlockID),
+		ClusterChainId:   clusterChainIDToMessage(g.ClusterChainID),
+		SignerIndices:    g.SignerIndices,
+		Signature:        g.Signature,
 	}
 }
 
@@ -260,16 +267,84 @@ func BlockSealToMessage(g flow.BlockSeal) *entities.BlockSeal {
 		ExecutionReceiptId:         g.ExecutionReceiptID.Bytes(),
 		ExecutionReceiptSignatures: g.ExecutionReceiptSignatures,
 		ResultApprovalSignatures:   g.ResultApprovalSignatures,
+		FinalState:                 stateCommitmentToMessage(g.FinalState),
+		ResultId:                   resultIDToMessage(g.ResultID),
+		AggregatedApprovalSigs:     AggregatedSignaturesToMessages(g.AggregatedApprovalSigs),
 	}
 }
 
+func stateCommitmentToMessage(commitment flow.StateCommitment) []byte {
+	if commitment == (flow.StateCommitment{}) {
+		return nil
+	}
+	return commitment[:]
+}
+
+func resultIDToMessage(id flow.Identifier) []byte {
+	if id == flow.EmptyID {
+		return nil
+	}
+	return id.Bytes()
+}
+
+func clusterChainIDToMessage(chainID flow.ChainID) []byte {
+	if chainID == "" {
+		return nil
+	}
+	return []byte(chainID)
+}
+
+func AggregatedSignaturesToMessages(l []*flow.AggregatedSignature) []*entities.AggregatedSignature {
+	if l == nil {
+		return nil
+	}
+
+	results := make([]*entities.AggregatedSignature, len(l))
+	for i, item := range l {
+		signerIDs := make([][]byte, len(item.SignerIDs))
+		for j, id := range item.SignerIDs {
+			signerIDs[j] = id.Bytes()
+		}
+
+		results[i] = &entities.AggregatedSignature{
+			VerifierSignatures: item.VerifierSignatures,
+			SignerIds:          signerIDs,
+		}
+	}
+	return results
+}
+
+func MessagesToAggregatedSignatures(l []*entities.AggregatedSignature) []*flow.AggregatedSignature {
+	if l == nil {
+		return nil
+	}
+
+	results := make([]*flow.AggregatedSignature, len(l))
+	for i, item := range l {
+		signerIDs := make([]flow.Identifier, len(item.GetSignerIds()))
+		for j, id := range item.GetSignerIds() {
+			signerIDs[j] = flow.BytesToID(id)
+		}
+
+		results[i] = &flow.AggregatedSignature{
+			VerifierSignatures: item.GetVerifierSignatures(),
+			SignerIDs:          signerIDs,
+		}
+	}
+	return results
+}
+
 func MessageToCollectionGuarantee(m *entities.CollectionGuarantee) (flow.CollectionGuarantee, error) {
 	if m == nil {
 		return flow.CollectionGuarantee{}, ErrEmptyMessage
 	}
 
 	return flow.CollectionGuarantee{
-		CollectionID: flow.HashToID(m.CollectionId),
+		CollectionID:     flow.HashToID(m.CollectionId),
+		ReferenceBlockID: flow.BytesToID(m.ReferenceBlockId),
+		ClusterChainID:   flow.ChainID(m.ClusterChainId),
+		SignerIndices:    m.SignerIndices,
+		Signature:        m.Signature,
 	}, nil
 }
 
@@ -283,6 +358,9 @@ func MessageToBlockSeal(m *entities.BlockSeal) (flow.BlockSeal, error) {
 		ExecutionReceiptID:         flow.BytesToID(m.ExecutionReceiptId),
 		ExecutionReceiptSignatures: m.ExecutionReceiptSignatures,
 		ResultApprovalSignatures:   m.ResultApprovalSignatures,
+		ResultID:                   flow.BytesToID(m.ResultId),
+		FinalState:                 flow.BytesToStateCommitment(m.FinalState),
+		AggregatedApprovalSigs:     MessagesToAggregatedSignatures(m.AggregatedApprovalSigs),
 	}, nil
 }
 
@@ -349,7 +427,7 @@ func MessageToEvent(m *entities.Event) (flow.Event, error) {
 
 	eventValue, isEvent := value.(cadence.Event)
 	if !isEvent {
-		return flow.Event{}, fmt.Errorf("convert: expected Event value, got %s", eventValue.Type().ID())
+		return flow.Event{}, flowerrors.Errorf(flowerrors.ErrDecoding, "convert: expected Event value, got %T", value)
 	}
 
 	return flow.Event{
@@ -537,3 +615,195 @@ func MessageToTransactionResult(m *access.TransactionResultResponse) (flow.Trans
 		Events: events,
 	}, nil
 }
+
+func ExecutionResultToMessage(er flow.ExecutionResult) *entities.ExecutionResult {
+	chunks := make([]*entities.Chunk, len(er.Chunks))
+	for i, chunk := range er.Chunks {
+		chunks[i] = &entities.Chunk{
+			CollectionIndex:      uint32(chunk.CollectionIndex),
+			StartState:           stateCommitmentToMessage(chunk.StartState),
+			EventCollection:      chunk.EventCollection,
+			BlockId:              chunk.BlockID.Bytes(),
+			TotalComputationUsed: chunk.TotalComputationUsed,
+			NumberOfTransactions: uint32(chunk.NumberOfTransactions),
+			Index:                chunk.Index,
+			EndState:             stateCommitmentToMessage(chunk.EndState),
+		}
+	}
+
+	serviceEvents := make([]*entities.ServiceEvent, len(er.ServiceEvents))
+	for i, serviceEvent := range er.ServiceEvents {
+		serviceEvents[i] = &entities.ServiceEvent{
+			Type:    serviceEvent.Type,
+			Payload: serviceEvent.Payload,
+		}
+	}
+
+	return &entities.ExecutionResult{
+		PreviousResultId: er.PreviousResultID.Bytes(),
+		BlockId:          er.BlockID.Bytes(),
+		Chunks:           chunks,
+		ServiceEvents:    serviceEvents,
+	}
+}
+
+func MessageToExecutionResult(m *entities.ExecutionResult) (*flow.ExecutionResult, error) {
+	if m == nil {
+		return nil, ErrEmptyMessage
+	}
+
+	chunks := make([]*flow.Chunk, len(m.GetChunks()))
+	for i, chunk := range m.GetChunks() {
+		chunks[i] = &flow.Chunk{
+			CollectionIndex:      uint(chunk.GetCollectionIndex()),
+			StartState:           flow.BytesToStateCommitment(chunk.GetStartState()),
+			EventCollection:      crypto.Hash(chunk.GetEventCollection()),
+			BlockID:              flow.BytesToID(chunk.GetBlockId()),
+			TotalComputationUsed: chunk.GetTotalComputationUsed(),
+			NumberOfTransactions: uint16(chunk.GetNumberOfTransactions()),
+			Index:                chunk.GetIndex(),
+			EndState:             flow.BytesToStateCommitment(chunk.GetEndState()),
+		}
+	}
+
+	serviceEvents := make([]*flow.ServiceEvent, len(m.GetServiceEvents()))
+	for i, serviceEvent := range m.GetServiceEvents() {
+		serviceEvents[i] = &flow.ServiceEvent{
+			Type:    serviceEvent.GetType(),
+			Payload: serviceEvent.GetPayload(),
+		}
+	}
+
+	return &flow.ExecutionResult{
+		PreviousResultID: flow.BytesToID(m.GetPreviousResultId()),
+		BlockID:          flow.BytesToID(m.GetBlockId()),
+		Chunks:           chunks,
+		ServiceEvents:    serviceEvents,
+	}, nil
+}
+
+func RegisterIDToMessage(id flow.RegisterID) *entities.RegisterID {
+	return &entities.RegisterID{
+		Owner: []byte(id.Owner),
+		Key:   []byte(id.Key),
+	}
+}
+
+func RegisterIDsToMessages(ids []flow.RegisterID) []*entities.RegisterID {
+	results := make([]*entities.RegisterID, len(ids))
+	for i, id := range ids {
+		results[i] = RegisterIDToMessage(id)
+	}
+	return results
+}
+
+func MessageToRegisterID(m *entities.RegisterID) flow.RegisterID {
+	return flow.RegisterID{
+		Owner: string(m.GetOwner()),
+		Key:   string(m.GetKey()),
+	}
+}
+
+// Types of the ledger key parts of registers.
+const (
+	keyPartOwner = 0
+	keyPartKey   = 2
+)
+
+func MessageToBlockExecutionData(m *entities.BlockExecutionData) (*flow.BlockExecutionData, error) {
+	if m == nil {
+		return nil, ErrEmptyMessage
+	}
+
+	chunks := make([]*flow.ChunkExecutionData, len(m.GetChunkExecutionData()))
+	for i, chunkMsg := range m.GetChunkExecutionData() {
+		chunk, err := MessageToChunkExecutionData(chunkMsg)
+		if err != nil {
+			return nil, fmt.Errorf("chunk %d: %w", i, err)
+		}
+
+		chunks[i] = chunk
+	}
+
+	return &flow.BlockExecutionData{
+		BlockID:            flow.HashToID(m.GetBlockId()),
+		ChunkExecutionData: chunks,
+	}, nil
+}
+
+func MessageToChunkExecutionData(m *entities.ChunkExecutionData) (*flow.ChunkExecutionData, error) {
+	if m == nil {
+		return nil, ErrEmptyMessage
+	}
+
+	transactionMessages := m.GetCollection().GetTransactions()
+
+	transactions := make([]*flow.Transaction, len(transactionMessages))
+	for i, txMsg := range transactionMessages {
+		tx, err := MessageToTransaction(txMsg)
+		if err != nil {
+			return nil, err
+		}
+
+		transactions[i] = &tx
+	}
+
+	events := make([]flow.Event, len(m.GetEvents()))
+	for i, eventMsg := range m.GetEvents() {
+		event, err := MessageToEvent(eventMsg)
+		if err != nil {
+			return nil, err
+		}
+
+		events[i] = event
+	}
+
+	trieUpdate, err := MessageToTrieUpdate(m.GetTrieUpdate())
+	if err != nil {
+		return nil, err
+	}
+
+	return &flow.ChunkExecutionData{
+		Transactions: transactions,
+		Events:       events,
+		TrieUpdate:   trieUpdate,
+	}, nil
+}
+
+// MessageToTrieUpdate converts a trie update message, returning nil for a nil message.
+func MessageToTrieUpdate(m *entities.TrieUpdate) (*flow.TrieUpdate, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	paths := m.GetPaths()
+	payloads := m.GetPayloads()
+
+	if len(paths) != len(payloads) {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "convert: trie update has %d paths but %d payloads", len(paths), len(payloads))
+	}
+
+	registers := make([]flow.RegisterUpdate, len(paths))
+	for i, payload := range payloads {
+		var id flow.RegisterID
+		for _, part := range payload.GetKeyPart() {
+			switch part.GetType() {
+			case keyPartOwner:
+				id.Owner = string(part.GetValue())
+			case keyPartKey:
+				id.Key = string(part.GetValue())
+			}
+		}
+
+		registers[i] = flow.RegisterUpdate{
+			Path:       paths[i],
+			RegisterID: id,
+			Value:      payload.GetValue(),
+		}
+	}
+
+	return &flow.TrieUpdate{
+		RootHash:  flow.BytesToStateCommitment(m.GetRootHash()),
+		Registers: registers,
+	}, nil
+}
@@ -204,6 +204,16 @@ func TestConvert_Event(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, eventA, eventB)
+
+	t.Run("Non-event payload", func(t *testing.T) {
+		payload, err := convert.CadenceValueToMessage(cadence.NewInt(42))
+		require.NoError(t, err)
+
+		msg.Payload = payload
+
+		_, err = convert.MessageToEvent(msg)
+		assert.Error(t, err)
+	})
 }
 
 func TestConvert_Identifier(t *testing.T) {
@@ -267,3 +277,31 @@ func TestConvert_TransactionResult(t *testing.T) {
 
 	assert.Equal(t, resultA, resultB)
 }
+
+func BenchmarkMessageToEvent(b *testing.B) {
+	msg, err := convert.EventToMessage(test.EventGenerator().New())
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(msg.GetPayload())))
+
+	for i := 0; i < b.N; i++ {
+		_, err := convert.MessageToEvent(msg)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEventToMessage(b *testing.B) {
+	event := test.EventGenerator().New()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, err := convert.EventToMessage(event)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -3,7 +3,10 @@ package client
 import (
 	"fmt"
 
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
 )
 
 const errorMessagePrefix = "client: "
@@ -14,7 +17,9 @@ func errorMessage(format string, a ...interface{}) string {
 
 // An RPCError is an error returned by an RPC call to an Access API.
 //
-// An RPC error can be unwrapped to produce the original gRPC error.
+// An RPC error can be unwrapped to produce the original gRPC error, and matches
+// the flowerrors sentinel corresponding to its status code, e.g. a NotFound
+// status matches flowerrors.ErrNotFound.
 type RPCError struct {
 	GRPCErr error
 }
@@ -31,6 +36,29 @@ func (e RPCError) Unwrap() error {
 	return e.GRPCErr
 }
 
+// Is reports whether target is the flowerrors sentinel for the status code of this error.
+func (e RPCError) Is(target error) bool {
+	kind := codeKind(status.Code(e.GRPCErr))
+	return kind != nil && target == kind
+}
+
+func codeKind(code codes.Code) error {
+	switch code {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return flowerrors.ErrInvalidArgument
+	case codes.NotFound:
+		return flowerrors.ErrNotFound
+	case codes.Unimplemented:
+		return flowerrors.ErrUnsupported
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return flowerrors.ErrUnavailable
+	case codes.DeadlineExceeded:
+		return flowerrors.ErrTimeout
+	default:
+		return nil
+	}
+}
+
 // GRPCStatus returns the gRPC status for this error.
 //
 // This function satisfies the interface defined in the status.FromError function.
@@ -48,9 +76,15 @@ const (
 	entityAccount           = "flow.Account"
 	entityEvent             = "flow.Event"
 	entityCadenceValue      = "cadence.Value"
+	entityExecutionResult   = "flow.ExecutionResult"
+	entityServiceEvent      = "flow.ServiceEvent"
+	entityExecutionData     = "flow.BlockExecutionData"
+	entityRegister          = "flow.Register"
 )
 
 // An EntityToMessageError indicates that an entity could not be converted to a protobuf message.
+//
+// It matches flowerrors.ErrInvalidArgument.
 type EntityToMessageError struct {
 	Entity string
 	Err    error
@@ -71,7 +105,13 @@ func (e EntityToMessageError) Unwrap() error {
 	return e.Err
 }
 
+func (e EntityToMessageError) Is(target error) bool {
+	return target == flowerrors.ErrInvalidArgument
+}
+
 // A MessageToEntityError indicates that a protobuf message could not be converted to an SDK entity.
+//
+// It matches flowerrors.ErrDecoding.
 type MessageToEntityError struct {
 	Entity string
 	Err    error
@@ -91,3 +131,7 @@ func (e MessageToEntityError) Error() string {
 func (e MessageToEntityError) Unwrap() error {
 	return e.Err
 }
+
+func (e MessageToEntityError) Is(target error) bool {
+	return target == flowerrors.ErrDecoding
+}
@@ -0,0 +1,183 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoiface"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/internal/protov2"
+	"github.com/onflow/flow-go-sdk/logging"
+)
+
+// WithLogger returns a dial option that logs every call made by the client.
+//
+// Successful calls are logged at debug level and failed calls at warn level, with
+// the method, duration, status code and the identifiers of the request, such as
+// the transaction ID, block ID or height:
+//
+//	c, err := client.New(addr, grpc.WithInsecure(), client.WithLogger(logger))
+func WithLogger(logger logging.Logger) grpc.DialOption {
+	return grpc.WithStatsHandler(&rpcLogger{logger: logger})
+}
+
+type rpcLogger struct {
+	logger logging.Logger
+}
+
+type rpcLogKey struct{}
+
+// rpcLog collects the fields of a single call.
+type rpcLog struct {
+	mut         sync.Mutex
+	method      string
+	fields      []logging.Field
+	sawRequest  bool
+	sawResponse bool
+}
+
+func (l *rpcLogger) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, rpcLogKey{}, &rpcLog{method: path.Base(info.FullMethodName)})
+}
+
+func (l *rpcLogger) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	log, ok := ctx.Value(rpcLogKey{}).(*rpcLog)
+	if !ok {
+		return
+	}
+
+	log.mut.Lock()
+	defer log.mut.Unlock()
+
+	switch s := s.(type) {
+	case *stats.OutPayload:
+		if !log.sawRequest {
+			log.sawRequest = true
+			log.fields = append(log.fields, requestFields(log.method, s.Payload)...)
+		}
+
+	case *stats.InPayload:
+		// the ID of a sent transaction is only known once the response arrives
+		if !log.sawResponse && log.method == "SendTransaction" {
+			log.sawResponse = true
+			if id, ok := bytesField(s.Payload, "id"); ok {
+				log.fields = append(log.fields, logging.TransactionID(flow.HashToID(id)))
+			}
+		}
+
+	case *stats.End:
+		fields := append([]logging.Field{
+			logging.String("method", log.method),
+			logging.Duration("duration", s.EndTime.Sub(s.BeginTime).Round(time.Microsecond)),
+		}, log.fields...)
+
+		code := status.Code(s.Error)
+		fields = append(fields, logging.String("code", code.String()))
+
+		if s.Error == nil || code == codes.Canceled {
+			l.logger.Log(logging.DebugLevel, "access API call", fields...)
+			return
+		}
+
+		l.logger.Log(logging.WarnLevel, "access API call failed", append(fields, logging.Error(s.Error))...)
+	}
+}
+
+func (l *rpcLogger) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (l *rpcLogger) HandleConn(context.Context, stats.ConnStats) {}
+
+// requestFields returns the fields identifying the entities a request refers to.
+func requestFields(method string, payload interface{}) []logging.Field {
+	var fields []logging.Field
+
+	if id, ok := bytesField(payload, "id"); ok {
+		switch {
+		case strings.Contains(method, "Transaction"):
+			fields = append(fields, logging.TransactionID(flow.HashToID(id)))
+		case strings.Contains(method, "Block"):
+			fields = append(fields, logging.BlockID(flow.HashToID(id)))
+		default:
+			fields = append(fields, logging.String("id", flow.HashToID(id).String()))
+		}
+	}
+
+	if id, ok := bytesField(payload, "block_id"); ok {
+		fields = append(fields, logging.BlockID(flow.HashToID(id)))
+	}
+
+	for _, name := range []protoreflect.Name{"height", "block_height", "start_height", "start_block_height"} {
+		if height, ok := uintField(payload, name); ok {
+			fields = append(fields, logging.Height(height))
+		}
+	}
+
+	if address, ok := bytesField(payload, "address"); ok {
+		fields = append(fields, logging.Address(flow.BytesToAddress(address)))
+	}
+
+	return fields
+}
+
+func field(payload interface{}, name protoreflect.Name) (protoreflect.Value, protoreflect.FieldDescriptor, bool) {
+	msg, ok := payload.(protoiface.MessageV1)
+	if !ok {
+		return protoreflect.Value{}, nil, false
+	}
+
+	m := protov2.Message(msg).ProtoReflect()
+
+	fd := m.Descriptor().Fields().ByName(name)
+	if fd == nil || fd.IsList() || fd.IsMap() || !m.Has(fd) {
+		return protoreflect.Value{}, nil, false
+	}
+
+	return m.Get(fd), fd, true
+}
+
+func bytesField(payload interface{}, name protoreflect.Name) ([]byte, bool) {
+	value, fd, ok := field(payload, name)
+	if !ok || fd.Kind() != protoreflect.BytesKind {
+		return nil, false
+	}
+
+	return value.Bytes(), true
+}
+
+func uintField(payload interface{}, name protoreflect.Name) (uint64, bool) {
+	value, fd, ok := field(payload, name)
+	if !ok || (fd.Kind() != protoreflect.Uint64Kind && fd.Kind() != protoreflect.Uint32Kind) {
+		return 0, false
+	}
+
+	return value.Uint(), true
+}
@@ -0,0 +1,136 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/accesstest"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/logging"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+type logEntry struct {
+	level  logging.Level
+	msg    string
+	fields map[string]interface{}
+}
+
+type logRecorder struct {
+	mut     sync.Mutex
+	entries []logEntry
+}
+
+func (r *logRecorder) Log(level logging.Level, msg string, fields ...logging.Field) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	entry := logEntry{level: level, msg: msg, fields: make(map[string]interface{})}
+	for _, field := range fields {
+		entry.fields[field.Key] = field.Value
+	}
+
+	r.entries = append(r.entries, entry)
+}
+
+func (r *logRecorder) last(t *testing.T) logEntry {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	require.NotEmpty(t, r.entries)
+	return r.entries[len(r.entries)-1]
+}
+
+func TestClient_WithLogger(t *testing.T) {
+	ctx := context.Background()
+	tx := test.TransactionGenerator().New()
+
+	scenario := accesstest.NewScenario().
+		Heights(5).
+		Inject("GetBlockByHeight", accesstest.Unavailable())
+
+	node := accesstest.Start(t, scenario)
+
+	logs := &logRecorder{}
+
+	c, err := client.New(
+		node.Address(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		client.WithLogger(logs),
+	)
+	require.NoError(t, err)
+	defer c.Close()
+
+	t.Run("Transaction", func(t *testing.T) {
+		err := c.SendTransaction(ctx, *tx)
+		require.NoError(t, err)
+
+		entry := logs.last(t)
+		assert.Equal(t, logging.DebugLevel, entry.level)
+		assert.Equal(t, "SendTransaction", entry.fields["method"])
+		assert.Equal(t, "OK", entry.fields["code"])
+		assert.Equal(t, tx.ID().String(), entry.fields[logging.TransactionIDKey])
+
+		_, err = c.GetTransactionResult(ctx, tx.ID())
+		require.NoError(t, err)
+
+		entry = logs.last(t)
+		assert.Equal(t, "GetTransactionResult", entry.fields["method"])
+		assert.Equal(t, tx.ID().String(), entry.fields[logging.TransactionIDKey])
+	})
+
+	t.Run("Failed call", func(t *testing.T) {
+		_, err := c.GetBlockByHeight(ctx, 3)
+		require.Error(t, err)
+
+		entry := logs.last(t)
+		assert.Equal(t, logging.WarnLevel, entry.level)
+		assert.Equal(t, "GetBlockByHeight", entry.fields["method"])
+		assert.Equal(t, "Unavailable", entry.fields["code"])
+		assert.Equal(t, uint64(3), entry.fields[logging.HeightKey])
+		assert.Contains(t, entry.fields, logging.ErrorKey)
+	})
+
+	t.Run("Block", func(t *testing.T) {
+		header, err := c.GetLatestBlockHeader(ctx, true)
+		require.NoError(t, err)
+
+		_, err = c.GetBlockByID(ctx, header.ID)
+		require.NoError(t, err)
+
+		entry := logs.last(t)
+		assert.Equal(t, header.ID.String(), entry.fields[logging.BlockIDKey])
+	})
+
+	t.Run("Account", func(t *testing.T) {
+		_, _ = c.GetAccount(ctx, flow.HexToAddress("01"))
+
+		entry := logs.last(t)
+		assert.Equal(t, "0000000000000001", entry.fields[logging.AddressKey])
+	})
+}
@@ -0,0 +1,1709 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	cadence "github.com/onflow/cadence"
+	client "github.com/onflow/flow-go-sdk/client"
+
+	context "context"
+
+	flow "github.com/onflow/flow-go-sdk"
+
+	grpc "google.golang.org/grpc"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccessClient is an autogenerated mock type for the AccessClient type
+type AccessClient struct {
+	mock.Mock
+}
+
+// CadenceVersion provides a mock function with no fields
+func (_m *AccessClient) CadenceVersion() flow.CadenceVersion {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CadenceVersion")
+	}
+
+	var r0 flow.CadenceVersion
+	if rf, ok := ret.Get(0).(func() flow.CadenceVersion); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(flow.CadenceVersion)
+	}
+
+	return r0
+}
+
+// Capabilities provides a mock function with given fields: ctx, opts
+func (_m *AccessClient) Capabilities(ctx context.Context, opts ...grpc.CallOption) (*client.Capabilities, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Capabilities")
+	}
+
+	var r0 *client.Capabilities
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) (*client.Capabilities, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) *client.Capabilities); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Capabilities)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Close provides a mock function with no fields
+func (_m *AccessClient) Close() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DetectCadenceVersion provides a mock function with given fields: ctx, opts
+func (_m *AccessClient) DetectCadenceVersion(ctx context.Context, opts ...grpc.CallOption) (flow.CadenceVersion, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DetectCadenceVersion")
+	}
+
+	var r0 flow.CadenceVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) (flow.CadenceVersion, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) flow.CadenceVersion); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		r0 = ret.Get(0).(flow.CadenceVersion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DetectCapabilities provides a mock function with given fields: ctx, opts
+func (_m *AccessClient) DetectCapabilities(ctx context.Context, opts ...grpc.CallOption) (*client.Capabilities, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DetectCapabilities")
+	}
+
+	var r0 *client.Capabilities
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) (*client.Capabilities, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) *client.Capabilities); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Capabilities)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExecuteScriptAtBlockHeight provides a mock function with given fields: ctx, height, script, arguments, opts
+func (_m *AccessClient) ExecuteScriptAtBlockHeight(ctx context.Context, height uint64, script []byte, arguments []cadence.Value, opts ...grpc.CallOption) (cadence.Value, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, height, script, arguments)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteScriptAtBlockHeight")
+	}
+
+	var r0 cadence.Value
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []byte, []cadence.Value, ...grpc.CallOption) (cadence.Value, error)); ok {
+		return rf(ctx, height, script, arguments, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []byte, []cadence.Value, ...grpc.CallOption) cadence.Value); ok {
+		r0 = rf(ctx, height, script, arguments, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cadence.Value)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, []byte, []cadence.Value, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, height, script, arguments, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExecuteScriptAtBlockID provides a mock function with given fields: ctx, blockID, script, arguments, opts
+func (_m *AccessClient) ExecuteScriptAtBlockID(ctx context.Context, blockID flow.Identifier, script []byte, arguments []cadence.Value, opts ...grpc.CallOption) (cadence.Value, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, blockID, script, arguments)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteScriptAtBlockID")
+	}
+
+	var r0 cadence.Value
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, []byte, []cadence.Value, ...grpc.CallOption) (cadence.Value, error)); ok {
+		return rf(ctx, blockID, script, arguments, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, []byte, []cadence.Value, ...grpc.CallOption) cadence.Value); ok {
+		r0 = rf(ctx, blockID, script, arguments, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cadence.Value)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, []byte, []cadence.Value, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, blockID, script, arguments, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExecuteScriptAtLatestBlock provides a mock function with given fields: ctx, script, arguments, opts
+func (_m *AccessClient) ExecuteScriptAtLatestBlock(ctx context.Context, script []byte, arguments []cadence.Value, opts ...grpc.CallOption) (cadence.Value, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, script, arguments)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteScriptAtLatestBlock")
+	}
+
+	var r0 cadence.Value
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []cadence.Value, ...grpc.CallOption) (cadence.Value, error)); ok {
+		return rf(ctx, script, arguments, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []cadence.Value, ...grpc.CallOption) cadence.Value); ok {
+		r0 = rf(ctx, script, arguments, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cadence.Value)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []cadence.Value, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, script, arguments, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccount provides a mock function with given fields: ctx, address, opts
+func (_m *AccessClient) GetAccount(ctx context.Context, address flow.Address, opts ...grpc.CallOption) (*flow.Account, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, address)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccount")
+	}
+
+	var r0 *flow.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, ...grpc.CallOption) (*flow.Account, error)); ok {
+		return rf(ctx, address, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, ...grpc.CallOption) *flow.Account); ok {
+		r0 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, address, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountAtBlockHeight provides a mock function with given fields: ctx, address, blockHeight, opts
+func (_m *AccessClient) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, blockHeight uint64, opts ...grpc.CallOption) (*flow.Account, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, address, blockHeight)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountAtBlockHeight")
+	}
+
+	var r0 *flow.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, ...grpc.CallOption) (*flow.Account, error)); ok {
+		return rf(ctx, address, blockHeight, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, ...grpc.CallOption) *flow.Account); ok {
+		r0 = rf(ctx, address, blockHeight, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, address, blockHeight, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountAtLatestBlock provides a mock function with given fields: ctx, address, opts
+func (_m *AccessClient) GetAccountAtLatestBlock(ctx context.Context, address flow.Address, opts ...grpc.CallOption) (*flow.Account, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, address)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountAtLatestBlock")
+	}
+
+	var r0 *flow.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, ...grpc.CallOption) (*flow.Account, error)); ok {
+		return rf(ctx, address, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, ...grpc.CallOption) *flow.Account); ok {
+		r0 = rf(ctx, address, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, address, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockByHeight provides a mock function with given fields: ctx, height, opts
+func (_m *AccessClient) GetBlockByHeight(ctx context.Context, height uint64, opts ...grpc.CallOption) (*flow.Block, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, height)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockByHeight")
+	}
+
+	var r0 *flow.Block
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, ...grpc.CallOption) (*flow.Block, error)); ok {
+		return rf(ctx, height, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, ...grpc.CallOption) *flow.Block); ok {
+		r0 = rf(ctx, height, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, height, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockByHeightWithTransactionsAndResults provides a mock function with given fields: ctx, height, opts
+func (_m *AccessClient) GetBlockByHeightWithTransactionsAndResults(ctx context.Context, height uint64, opts ...grpc.CallOption) (*client.BlockWithTransactions, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, height)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockByHeightWithTransactionsAndResults")
+	}
+
+	var r0 *client.BlockWithTransactions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, ...grpc.CallOption) (*client.BlockWithTransactions, error)); ok {
+		return rf(ctx, height, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, ...grpc.CallOption) *client.BlockWithTransactions); ok {
+		r0 = rf(ctx, height, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.BlockWithTransactions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, height, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockByID provides a mock function with given fields: ctx, blockID, opts
+func (_m *AccessClient) GetBlockByID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) (*flow.Block, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, blockID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockByID")
+	}
+
+	var r0 *flow.Block
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) (*flow.Block, error)); ok {
+		return rf(ctx, blockID, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) *flow.Block); ok {
+		r0 = rf(ctx, blockID, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, blockID, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockHeaderByHeight provides a mock function with given fields: ctx, height, opts
+func (_m *AccessClient) GetBlockHeaderByHeight(ctx context.Context, height uint64, opts ...grpc.CallOption) (*flow.BlockHeader, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, height)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockHeaderByHeight")
+	}
+
+	var r0 *flow.BlockHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, ...grpc.CallOption) (*flow.BlockHeader, error)); ok {
+		return rf(ctx, height, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, ...grpc.CallOption) *flow.BlockHeader); ok {
+		r0 = rf(ctx, height, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.BlockHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, height, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockHeaderByID provides a mock function with given fields: ctx, blockID, opts
+func (_m *AccessClient) GetBlockHeaderByID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) (*flow.BlockHeader, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, blockID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockHeaderByID")
+	}
+
+	var r0 *flow.BlockHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) (*flow.BlockHeader, error)); ok {
+		return rf(ctx, blockID, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) *flow.BlockHeader); ok {
+		r0 = rf(ctx, blockID, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.BlockHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, blockID, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockWithTransactionsAndResults provides a mock function with given fields: ctx, blockID, opts
+func (_m *AccessClient) GetBlockWithTransactionsAndResults(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) (*client.BlockWithTransactions, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, blockID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockWithTransactionsAndResults")
+	}
+
+	var r0 *client.BlockWithTransactions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) (*client.BlockWithTransactions, error)); ok {
+		return rf(ctx, blockID, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) *client.BlockWithTransactions); ok {
+		r0 = rf(ctx, blockID, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.BlockWithTransactions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, blockID, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChainStatus provides a mock function with given fields: ctx, opts
+func (_m *AccessClient) GetChainStatus(ctx context.Context, opts ...grpc.CallOption) (*client.ChainStatus, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainStatus")
+	}
+
+	var r0 *client.ChainStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) (*client.ChainStatus, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) *client.ChainStatus); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.ChainStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCollection provides a mock function with given fields: ctx, colID, opts
+func (_m *AccessClient) GetCollection(ctx context.Context, colID flow.Identifier, opts ...grpc.CallOption) (*flow.Collection, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, colID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCollection")
+	}
+
+	var r0 *flow.Collection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) (*flow.Collection, error)); ok {
+		return rf(ctx, colID, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) *flow.Collection); ok {
+		r0 = rf(ctx, colID, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Collection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, colID, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDecodedServiceEventsForBlockID provides a mock function with given fields: ctx, blockID, opts
+func (_m *AccessClient) GetDecodedServiceEventsForBlockID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) ([]interface{}, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, blockID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDecodedServiceEventsForBlockID")
+	}
+
+	var r0 []interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) ([]interface{}, error)); ok {
+		return rf(ctx, blockID, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) []interface{}); ok {
+		r0 = rf(ctx, blockID, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, blockID, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEventsForBlockIDs provides a mock function with given fields: ctx, eventType, blockIDs, opts
+func (_m *AccessClient) GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier, opts ...grpc.CallOption) ([]client.BlockEvents, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, eventType, blockIDs)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsForBlockIDs")
+	}
+
+	var r0 []client.BlockEvents
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []flow.Identifier, ...grpc.CallOption) ([]client.BlockEvents, error)); ok {
+		return rf(ctx, eventType, blockIDs, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []flow.Identifier, ...grpc.CallOption) []client.BlockEvents); ok {
+		r0 = rf(ctx, eventType, blockIDs, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.BlockEvents)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []flow.Identifier, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, eventType, blockIDs, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEventsForBlockIDsWithFilter provides a mock function with given fields: ctx, filter, blockIDs, opts
+func (_m *AccessClient) GetEventsForBlockIDsWithFilter(ctx context.Context, filter flow.EventFilter, blockIDs []flow.Identifier, opts ...grpc.CallOption) ([]client.BlockEvents, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, filter, blockIDs)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsForBlockIDsWithFilter")
+	}
+
+	var r0 []client.BlockEvents
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.EventFilter, []flow.Identifier, ...grpc.CallOption) ([]client.BlockEvents, error)); ok {
+		return rf(ctx, filter, blockIDs, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.EventFilter, []flow.Identifier, ...grpc.CallOption) []client.BlockEvents); ok {
+		r0 = rf(ctx, filter, blockIDs, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.BlockEvents)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.EventFilter, []flow.Identifier, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, filter, blockIDs, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEventsForHeightRange provides a mock function with given fields: ctx, query, opts
+func (_m *AccessClient) GetEventsForHeightRange(ctx context.Context, query client.EventRangeQuery, opts ...grpc.CallOption) ([]client.BlockEvents, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, query)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsForHeightRange")
+	}
+
+	var r0 []client.BlockEvents
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.EventRangeQuery, ...grpc.CallOption) ([]client.BlockEvents, error)); ok {
+		return rf(ctx, query, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.EventRangeQuery, ...grpc.CallOption) []client.BlockEvents); ok {
+		r0 = rf(ctx, query, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.BlockEvents)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.EventRangeQuery, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, query, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEventsForHeightRangeWithFilter provides a mock function with given fields: ctx, filter, startHeight, endHeight, opts
+func (_m *AccessClient) GetEventsForHeightRangeWithFilter(ctx context.Context, filter flow.EventFilter, startHeight uint64, endHeight uint64, opts ...grpc.CallOption) ([]client.BlockEvents, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, filter, startHeight, endHeight)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsForHeightRangeWithFilter")
+	}
+
+	var r0 []client.BlockEvents
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.EventFilter, uint64, uint64, ...grpc.CallOption) ([]client.BlockEvents, error)); ok {
+		return rf(ctx, filter, startHeight, endHeight, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.EventFilter, uint64, uint64, ...grpc.CallOption) []client.BlockEvents); ok {
+		r0 = rf(ctx, filter, startHeight, endHeight, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.BlockEvents)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.EventFilter, uint64, uint64, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, filter, startHeight, endHeight, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExecutionDataByBlockID provides a mock function with given fields: ctx, blockID, opts
+func (_m *AccessClient) GetExecutionDataByBlockID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) (*flow.BlockExecutionData, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, blockID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExecutionDataByBlockID")
+	}
+
+	var r0 *flow.BlockExecutionData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) (*flow.BlockExecutionData, error)); ok {
+		return rf(ctx, blockID, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) *flow.BlockExecutionData); ok {
+		r0 = rf(ctx, blockID, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.BlockExecutionData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, blockID, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExecutionResultByID provides a mock function with given fields: ctx, resultID, opts
+func (_m *AccessClient) GetExecutionResultByID(ctx context.Context, resultID flow.Identifier, opts ...grpc.CallOption) (*flow.ExecutionResult, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, resultID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExecutionResultByID")
+	}
+
+	var r0 *flow.ExecutionResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) (*flow.ExecutionResult, error)); ok {
+		return rf(ctx, resultID, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) *flow.ExecutionResult); ok {
+		r0 = rf(ctx, resultID, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.ExecutionResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, resultID, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExecutionResultForBlockID provides a mock function with given fields: ctx, blockID, opts
+func (_m *AccessClient) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) (*flow.ExecutionResult, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, blockID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExecutionResultForBlockID")
+	}
+
+	var r0 *flow.ExecutionResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) (*flow.ExecutionResult, error)); ok {
+		return rf(ctx, blockID, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) *flow.ExecutionResult); ok {
+		r0 = rf(ctx, blockID, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.ExecutionResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, blockID, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestBlock provides a mock function with given fields: ctx, isSealed, opts
+func (_m *AccessClient) GetLatestBlock(ctx context.Context, isSealed bool, opts ...grpc.CallOption) (*flow.Block, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, isSealed)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestBlock")
+	}
+
+	var r0 *flow.Block
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool, ...grpc.CallOption) (*flow.Block, error)); ok {
+		return rf(ctx, isSealed, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool, ...grpc.CallOption) *flow.Block); ok {
+		r0 = rf(ctx, isSealed, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, isSealed, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestBlockHeader provides a mock function with given fields: ctx, isSealed, opts
+func (_m *AccessClient) GetLatestBlockHeader(ctx context.Context, isSealed bool, opts ...grpc.CallOption) (*flow.BlockHeader, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, isSealed)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestBlockHeader")
+	}
+
+	var r0 *flow.BlockHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool, ...grpc.CallOption) (*flow.BlockHeader, error)); ok {
+		return rf(ctx, isSealed, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool, ...grpc.CallOption) *flow.BlockHeader); ok {
+		r0 = rf(ctx, isSealed, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.BlockHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, isSealed, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestFinalizedBlock provides a mock function with given fields: ctx, opts
+func (_m *AccessClient) GetLatestFinalizedBlock(ctx context.Context, opts ...grpc.CallOption) (*flow.Block, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestFinalizedBlock")
+	}
+
+	var r0 *flow.Block
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) (*flow.Block, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) *flow.Block); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestFinalizedBlockHeader provides a mock function with given fields: ctx, opts
+func (_m *AccessClient) GetLatestFinalizedBlockHeader(ctx context.Context, opts ...grpc.CallOption) (*flow.BlockHeader, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestFinalizedBlockHeader")
+	}
+
+	var r0 *flow.BlockHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) (*flow.BlockHeader, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) *flow.BlockHeader); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.BlockHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestProtocolStateSnapshot provides a mock function with given fields: ctx, opts
+func (_m *AccessClient) GetLatestProtocolStateSnapshot(ctx context.Context, opts ...grpc.CallOption) ([]byte, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestProtocolStateSnapshot")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) ([]byte, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) []byte); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestSealedBlock provides a mock function with given fields: ctx, opts
+func (_m *AccessClient) GetLatestSealedBlock(ctx context.Context, opts ...grpc.CallOption) (*flow.Block, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestSealedBlock")
+	}
+
+	var r0 *flow.Block
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) (*flow.Block, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) *flow.Block); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestSealedBlockHeader provides a mock function with given fields: ctx, opts
+func (_m *AccessClient) GetLatestSealedBlockHeader(ctx context.Context, opts ...grpc.CallOption) (*flow.BlockHeader, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestSealedBlockHeader")
+	}
+
+	var r0 *flow.BlockHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) (*flow.BlockHeader, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) *flow.BlockHeader); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.BlockHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNodeVersionInfo provides a mock function with given fields: ctx, opts
+func (_m *AccessClient) GetNodeVersionInfo(ctx context.Context, opts ...grpc.CallOption) (*client.NodeVersionInfo, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNodeVersionInfo")
+	}
+
+	var r0 *client.NodeVersionInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) (*client.NodeVersionInfo, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) *client.NodeVersionInfo); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.NodeVersionInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRegisterValues provides a mock function with given fields: ctx, blockHeight, registerIDs, opts
+func (_m *AccessClient) GetRegisterValues(ctx context.Context, blockHeight uint64, registerIDs []flow.RegisterID, opts ...grpc.CallOption) ([][]byte, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, blockHeight, registerIDs)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRegisterValues")
+	}
+
+	var r0 [][]byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []flow.RegisterID, ...grpc.CallOption) ([][]byte, error)); ok {
+		return rf(ctx, blockHeight, registerIDs, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []flow.RegisterID, ...grpc.CallOption) [][]byte); ok {
+		r0 = rf(ctx, blockHeight, registerIDs, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, []flow.RegisterID, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, blockHeight, registerIDs, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceEventsForBlockHeight provides a mock function with given fields: ctx, height, opts
+func (_m *AccessClient) GetServiceEventsForBlockHeight(ctx context.Context, height uint64, opts ...grpc.CallOption) ([]*flow.ServiceEvent, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, height)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServiceEventsForBlockHeight")
+	}
+
+	var r0 []*flow.ServiceEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, ...grpc.CallOption) ([]*flow.ServiceEvent, error)); ok {
+		return rf(ctx, height, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, ...grpc.CallOption) []*flow.ServiceEvent); ok {
+		r0 = rf(ctx, height, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*flow.ServiceEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, height, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceEventsForBlockID provides a mock function with given fields: ctx, blockID, opts
+func (_m *AccessClient) GetServiceEventsForBlockID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) ([]*flow.ServiceEvent, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, blockID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServiceEventsForBlockID")
+	}
+
+	var r0 []*flow.ServiceEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) ([]*flow.ServiceEvent, error)); ok {
+		return rf(ctx, blockID, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) []*flow.ServiceEvent); ok {
+		r0 = rf(ctx, blockID, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*flow.ServiceEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, blockID, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransaction provides a mock function with given fields: ctx, txID, opts
+func (_m *AccessClient) GetTransaction(ctx context.Context, txID flow.Identifier, opts ...grpc.CallOption) (*flow.Transaction, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, txID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransaction")
+	}
+
+	var r0 *flow.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) (*flow.Transaction, error)); ok {
+		return rf(ctx, txID, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) *flow.Transaction); ok {
+		r0 = rf(ctx, txID, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, txID, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionResult provides a mock function with given fields: ctx, txID, opts
+func (_m *AccessClient) GetTransactionResult(ctx context.Context, txID flow.Identifier, opts ...grpc.CallOption) (*flow.TransactionResult, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, txID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionResult")
+	}
+
+	var r0 *flow.TransactionResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) (*flow.TransactionResult, error)); ok {
+		return rf(ctx, txID, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, ...grpc.CallOption) *flow.TransactionResult); ok {
+		r0 = rf(ctx, txID, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, txID, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Ping provides a mock function with given fields: ctx, opts
+func (_m *AccessClient) Ping(ctx context.Context, opts ...grpc.CallOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) error); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendAndWaitForStatus provides a mock function with given fields: ctx, tx, target, opts
+func (_m *AccessClient) SendAndWaitForStatus(ctx context.Context, tx flow.Transaction, target flow.TransactionStatus, opts ...grpc.CallOption) (*flow.TransactionResult, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, tx, target)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendAndWaitForStatus")
+	}
+
+	var r0 *flow.TransactionResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Transaction, flow.TransactionStatus, ...grpc.CallOption) (*flow.TransactionResult, error)); ok {
+		return rf(ctx, tx, target, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Transaction, flow.TransactionStatus, ...grpc.CallOption) *flow.TransactionResult); ok {
+		r0 = rf(ctx, tx, target, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Transaction, flow.TransactionStatus, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, tx, target, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendTransaction provides a mock function with given fields: ctx, tx, opts
+func (_m *AccessClient) SendTransaction(ctx context.Context, tx flow.Transaction, opts ...grpc.CallOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, tx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Transaction, ...grpc.CallOption) error); ok {
+		r0 = rf(ctx, tx, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCadenceVersion provides a mock function with given fields: version
+func (_m *AccessClient) SetCadenceVersion(version flow.CadenceVersion) error {
+	ret := _m.Called(version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCadenceVersion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(flow.CadenceVersion) error); ok {
+		r0 = rf(version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubscribeAccountStatusesByBlockHeight provides a mock function with given fields: ctx, startHeight, filter, opts
+func (_m *AccessClient) SubscribeAccountStatusesByBlockHeight(ctx context.Context, startHeight uint64, filter flow.AccountStatusFilter, opts ...grpc.CallOption) (<-chan client.AccountStatus, <-chan error, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, startHeight, filter)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeAccountStatusesByBlockHeight")
+	}
+
+	var r0 <-chan client.AccountStatus
+	var r1 <-chan error
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, flow.AccountStatusFilter, ...grpc.CallOption) (<-chan client.AccountStatus, <-chan error, error)); ok {
+		return rf(ctx, startHeight, filter, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, flow.AccountStatusFilter, ...grpc.CallOption) <-chan client.AccountStatus); ok {
+		r0 = rf(ctx, startHeight, filter, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan client.AccountStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, flow.AccountStatusFilter, ...grpc.CallOption) <-chan error); ok {
+		r1 = rf(ctx, startHeight, filter, opts...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(<-chan error)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64, flow.AccountStatusFilter, ...grpc.CallOption) error); ok {
+		r2 = rf(ctx, startHeight, filter, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SubscribeEventsByBlockHeight provides a mock function with given fields: ctx, startHeight, filter, opts
+func (_m *AccessClient) SubscribeEventsByBlockHeight(ctx context.Context, startHeight uint64, filter flow.EventFilter, opts ...grpc.CallOption) (<-chan client.BlockEvents, <-chan error, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, startHeight, filter)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeEventsByBlockHeight")
+	}
+
+	var r0 <-chan client.BlockEvents
+	var r1 <-chan error
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, flow.EventFilter, ...grpc.CallOption) (<-chan client.BlockEvents, <-chan error, error)); ok {
+		return rf(ctx, startHeight, filter, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, flow.EventFilter, ...grpc.CallOption) <-chan client.BlockEvents); ok {
+		r0 = rf(ctx, startHeight, filter, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan client.BlockEvents)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, flow.EventFilter, ...grpc.CallOption) <-chan error); ok {
+		r1 = rf(ctx, startHeight, filter, opts...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(<-chan error)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64, flow.EventFilter, ...grpc.CallOption) error); ok {
+		r2 = rf(ctx, startHeight, filter, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WaitForStatus provides a mock function with given fields: ctx, txID, target, opts
+func (_m *AccessClient) WaitForStatus(ctx context.Context, txID flow.Identifier, target flow.TransactionStatus, opts ...grpc.CallOption) (*flow.TransactionResult, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, txID, target)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitForStatus")
+	}
+
+	var r0 *flow.TransactionResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, flow.TransactionStatus, ...grpc.CallOption) (*flow.TransactionResult, error)); ok {
+		return rf(ctx, txID, target, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, flow.TransactionStatus, ...grpc.CallOption) *flow.TransactionResult); ok {
+		r0 = rf(ctx, txID, target, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, flow.TransactionStatus, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, txID, target, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAccessClient creates a new instance of AccessClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccessClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccessClient {
+	mock := &AccessClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
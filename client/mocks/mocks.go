@@ -0,0 +1,30 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package mocks provides generated mocks of the client interfaces.
+//
+// The mocks are generated with mockery and are regenerated by running go generate
+// in the client package whenever the interfaces change:
+//
+//	accessClient := mocks.NewAccessClient(t)
+//	accessClient.On("GetLatestBlockHeader", mock.Anything, true).Return(header, nil)
+package mocks
+
+import "github.com/onflow/flow-go-sdk/client"
+
+var _ client.AccessClient = (*AccessClient)(nil)
@@ -0,0 +1,147 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/onflow/cadence"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// ErrContractNotFound is returned when a contract is not deployed to an account.
+var ErrContractNotFound = flowerrors.New(flowerrors.ErrNotFound, errorMessage("contract not found"))
+
+// A Snapshot performs reads against the execution state at a single block height.
+//
+// Because the state at a sealed height never changes, all reads made through a snapshot
+// are consistent with each other, and accounts are fetched at most once per snapshot.
+// A Snapshot is safe for concurrent use.
+type Snapshot struct {
+	client AccessClient
+	height uint64
+
+	mut      sync.Mutex
+	accounts map[flow.Address]*flow.Account
+}
+
+// NewSnapshot returns a reader for the execution state at the given block height,
+// reading with c.
+//
+// No request is made until the first read.
+func NewSnapshot(c AccessClient, height uint64) *Snapshot {
+	return &Snapshot{
+		client:   c,
+		height:   height,
+		accounts: make(map[flow.Address]*flow.Account),
+	}
+}
+
+// Snapshot returns a reader for the execution state at the given block height.
+//
+// No request is made until the first read.
+func (c *Client) Snapshot(height uint64) *Snapshot {
+	return NewSnapshot(c, height)
+}
+
+// SnapshotAtLatestSealedBlock returns a reader pinned to the height of the latest sealed block.
+func (c *Client) SnapshotAtLatestSealedBlock(ctx context.Context, opts ...grpc.CallOption) (*Snapshot, error) {
+	header, err := c.GetLatestSealedBlockHeader(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Snapshot(header.Height), nil
+}
+
+// Height returns the block height this snapshot reads at.
+func (s *Snapshot) Height() uint64 {
+	return s.height
+}
+
+// GetBlockHeader gets the header of the block this snapshot reads at.
+func (s *Snapshot) GetBlockHeader(ctx context.Context, opts ...grpc.CallOption) (*flow.BlockHeader, error) {
+	return s.client.GetBlockHeaderByHeight(ctx, s.height, opts...)
+}
+
+// GetAccount gets an account by address.
+//
+// The returned account is shared by all reads of this snapshot and must not be modified.
+func (s *Snapshot) GetAccount(ctx context.Context, address flow.Address, opts ...grpc.CallOption) (*flow.Account, error) {
+	s.mut.Lock()
+	account, ok := s.accounts[address]
+	s.mut.Unlock()
+
+	if ok {
+		return account, nil
+	}
+
+	account, err := s.client.GetAccountAtBlockHeight(ctx, address, s.height, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mut.Lock()
+	s.accounts[address] = account
+	s.mut.Unlock()
+
+	return account, nil
+}
+
+// GetBalance gets the FLOW balance of an account.
+func (s *Snapshot) GetBalance(ctx context.Context, address flow.Address, opts ...grpc.CallOption) (uint64, error) {
+	account, err := s.GetAccount(ctx, address, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	return account.Balance, nil
+}
+
+// GetContract gets the code of a contract deployed to an account.
+//
+// ErrContractNotFound is returned if no contract with the given name is deployed to the account.
+func (s *Snapshot) GetContract(ctx context.Context, address flow.Address, name string, opts ...grpc.CallOption) ([]byte, error) {
+	account, err := s.GetAccount(ctx, address, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	code, ok := account.Contracts[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s on account %s", ErrContractNotFound, name, address)
+	}
+
+	return code, nil
+}
+
+// ExecuteScript executes a read-only Cadence script against the execution state of this snapshot.
+func (s *Snapshot) ExecuteScript(
+	ctx context.Context,
+	script []byte,
+	arguments []cadence.Value,
+	opts ...grpc.CallOption,
+) (cadence.Value, error) {
+	return s.client.ExecuteScriptAtBlockHeight(ctx, s.height, script, arguments, opts...)
+}
@@ -0,0 +1,279 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client/convert"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// The bounds of the interval between polls of a transaction result. Polling
+// starts fast, as the status of a new transaction changes within a few blocks,
+// and backs off while the status does not change.
+const (
+	minPollInterval = 100 * time.Millisecond
+	maxPollInterval = 2 * time.Second
+)
+
+// WaitForStatus waits until a transaction reaches the target status or a later
+// one, and returns its result.
+//
+// The result is checked every time a block is finalized, as streamed by the node.
+// If the node does not stream blocks, the result is polled instead, at intervals
+// growing from 100 milliseconds to 2 seconds while the status does not change.
+//
+// An error matching flowerrors.ErrTimeout is returned together with the result if
+// the transaction expires before it reaches the target status. A transaction that
+// fails is sealed with an error: check the error of the result.
+func (c *Client) WaitForStatus(
+	ctx context.Context,
+	txID flow.Identifier,
+	target flow.TransactionStatus,
+	opts ...grpc.CallOption,
+) (*flow.TransactionResult, error) {
+	if err := checkTargetStatus(target); err != nil {
+		return nil, err
+	}
+
+	result, err := c.GetTransactionResult(ctx, txID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if done, err := statusReached(txID, result, target); done {
+		return result, err
+	}
+
+	result, done, err := c.waitForBlocks(ctx, txID, target, opts...)
+	if done {
+		return result, err
+	}
+
+	return c.pollStatus(ctx, txID, target, opts...)
+}
+
+// waitForBlocks checks the result of a transaction on every finalized block
+// streamed by the node. It returns false if the node does not stream blocks or
+// the stream ends, in which case the caller should fall back to polling.
+func (c *Client) waitForBlocks(
+	ctx context.Context,
+	txID flow.Identifier,
+	target flow.TransactionStatus,
+	opts ...grpc.CallOption,
+) (*flow.TransactionResult, bool, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.rpcClient.SubscribeBlockDigestsFromLatest(
+		streamCtx,
+		&access.SubscribeBlockDigestsFromLatestRequest{BlockStatus: entities.BlockStatus_BLOCK_FINALIZED},
+		opts...,
+	)
+	if err != nil {
+		return c.streamFailed(ctx, err)
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err != nil {
+			return c.streamFailed(ctx, err)
+		}
+
+		result, err := c.GetTransactionResult(ctx, txID, opts...)
+		if err != nil {
+			return nil, true, err
+		}
+
+		if done, err := statusReached(txID, result, target); done {
+			return result, true, err
+		}
+	}
+}
+
+// streamFailed ends the wait with the error of the context if it is done, and
+// otherwise lets the wait fall back to polling.
+func (c *Client) streamFailed(ctx context.Context, err error) (*flow.TransactionResult, bool, error) {
+	if ctx.Err() != nil {
+		return nil, true, newRPCError(err)
+	}
+
+	return nil, false, nil
+}
+
+// pollStatus polls the result of a transaction with a growing interval until it
+// reaches the target status.
+func (c *Client) pollStatus(
+	ctx context.Context,
+	txID flow.Identifier,
+	target flow.TransactionStatus,
+	opts ...grpc.CallOption,
+) (*flow.TransactionResult, error) {
+	interval := minPollInterval
+	last := flow.TransactionStatusUnknown
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, flowerrors.Errorf(flowerrors.ErrTimeout, errorMessagePrefix+"transaction %s did not reach status %s: %w", txID, target, ctx.Err())
+		case <-timer.C:
+		}
+
+		result, err := c.GetTransactionResult(ctx, txID, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if done, err := statusReached(txID, result, target); done {
+			return result, err
+		}
+
+		if result.Status != last {
+			last = result.Status
+			interval = minPollInterval
+		} else if interval *= 2; interval > maxPollInterval {
+			interval = maxPollInterval
+		}
+
+		timer.Reset(interval)
+	}
+}
+
+// SendAndWaitForStatus submits a transaction to the network and waits until it
+// reaches the target status or a later one, and returns its result.
+//
+// The status is streamed by the node as the transaction progresses. If the node
+// does not stream transaction statuses, the transaction is sent with
+// SendTransaction and its status is waited for with WaitForStatus.
+func (c *Client) SendAndWaitForStatus(
+	ctx context.Context,
+	tx flow.Transaction,
+	target flow.TransactionStatus,
+	opts ...grpc.CallOption,
+) (*flow.TransactionResult, error) {
+	if err := checkTargetStatus(target); err != nil {
+		return nil, err
+	}
+
+	txMsg, err := convert.TransactionToMessage(tx)
+	if err != nil {
+		return nil, newEntityToMessageError(entityTransaction, err)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req := &access.SendAndSubscribeTransactionStatusesRequest{
+		Transaction:          txMsg,
+		EventEncodingVersion: entities.EventEncodingVersion_JSON_CDC_V0,
+	}
+
+	stream, err := c.rpcClient.SendAndSubscribeTransactionStatuses(streamCtx, req, opts...)
+	if err != nil {
+		return c.sendStreamFailed(ctx, tx, target, false, err, opts...)
+	}
+
+	sent := false
+
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			return c.sendStreamFailed(ctx, tx, target, sent, err, opts...)
+		}
+
+		sent = true
+
+		m, err := c.legacyTransactionResult(res.GetTransactionResults())
+		if err != nil {
+			return nil, newMessageToEntityError(entityTransactionResult, err)
+		}
+
+		result, err := convert.MessageToTransactionResult(m)
+		if err != nil {
+			return nil, newMessageToEntityError(entityTransactionResult, err)
+		}
+
+		if done, err := statusReached(tx.ID(), &result, target); done {
+			return &result, err
+		}
+	}
+}
+
+// sendStreamFailed handles the end of a transaction status stream. A transaction
+// that was not accepted because the method is not implemented is sent again with
+// SendTransaction; the status of a sent transaction is waited for with
+// WaitForStatus.
+func (c *Client) sendStreamFailed(
+	ctx context.Context,
+	tx flow.Transaction,
+	target flow.TransactionStatus,
+	sent bool,
+	err error,
+	opts ...grpc.CallOption,
+) (*flow.TransactionResult, error) {
+	if ctx.Err() != nil {
+		return nil, newRPCError(err)
+	}
+
+	if !sent {
+		if status.Code(err) != codes.Unimplemented {
+			return nil, newRPCError(err)
+		}
+
+		err = c.SendTransaction(ctx, tx, opts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.WaitForStatus(ctx, tx.ID(), target, opts...)
+}
+
+func checkTargetStatus(target flow.TransactionStatus) error {
+	if target <= flow.TransactionStatusUnknown || target > flow.TransactionStatusExpired {
+		return flowerrors.New(flowerrors.ErrInvalidArgument, errorMessage("invalid target transaction status %d", target))
+	}
+
+	return nil
+}
+
+// statusReached returns true if the wait for the target status is over: the
+// transaction reached the target status or a later one, or expired.
+func statusReached(txID flow.Identifier, result *flow.TransactionResult, target flow.TransactionStatus) (bool, error) {
+	switch {
+	case result.Status == target:
+		return true, nil
+	case result.Status == flow.TransactionStatusExpired:
+		return true, flowerrors.New(flowerrors.ErrTimeout, errorMessage("transaction %s expired before reaching status %s", txID, target))
+	default:
+		return result.Status > target, nil
+	}
+}
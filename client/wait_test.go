@@ -0,0 +1,201 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+type mockBlockDigestsStream struct {
+	grpc.ClientStream
+	responses []*access.SubscribeBlockDigestsResponse
+	err       error
+}
+
+func (s *mockBlockDigestsStream) Recv() (*access.SubscribeBlockDigestsResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, s.err
+	}
+
+	res := s.responses[0]
+	s.responses = s.responses[1:]
+	return res, nil
+}
+
+type mockTransactionStatusesStream struct {
+	grpc.ClientStream
+	responses []*access.SendAndSubscribeTransactionStatusesResponse
+	err       error
+}
+
+func (s *mockTransactionStatusesStream) Recv() (*access.SendAndSubscribeTransactionStatusesResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, s.err
+	}
+
+	res := s.responses[0]
+	s.responses = s.responses[1:]
+	return res, nil
+}
+
+func resultResponse(status flow.TransactionStatus) *access.TransactionResultResponse {
+	return &access.TransactionResultResponse{Status: entities.TransactionStatus(status)}
+}
+
+func TestClient_WaitForStatus(t *testing.T) {
+	ids := test.IdentifierGenerator()
+	errUnimplemented := status.Error(codes.Unimplemented, "unimplemented")
+
+	t.Run("Streamed blocks", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(resultResponse(flow.TransactionStatusPending), nil).Once()
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(resultResponse(flow.TransactionStatusFinalized), nil).Once()
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(resultResponse(flow.TransactionStatusSealed), nil).Once()
+
+		rpc.On("SubscribeBlockDigestsFromLatest", mock.Anything, mock.Anything).
+			Return(&mockBlockDigestsStream{
+				responses: []*access.SubscribeBlockDigestsResponse{{BlockHeight: 1}, {BlockHeight: 2}, {BlockHeight: 3}},
+			}, nil).
+			Once()
+
+		result, err := c.WaitForStatus(ctx, ids.New(), flow.TransactionStatusSealed)
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+	}))
+
+	t.Run("Polling", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(resultResponse(flow.TransactionStatusPending), nil).Twice()
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(resultResponse(flow.TransactionStatusExecuted), nil).Once()
+
+		rpc.On("SubscribeBlockDigestsFromLatest", mock.Anything, mock.Anything).
+			Return(nil, errUnimplemented).
+			Once()
+
+		// a later status than the target ends the wait
+		result, err := c.WaitForStatus(ctx, ids.New(), flow.TransactionStatusFinalized)
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusExecuted, result.Status)
+	}))
+
+	t.Run("Stream ended", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(resultResponse(flow.TransactionStatusPending), nil).Twice()
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(resultResponse(flow.TransactionStatusSealed), nil).Once()
+
+		rpc.On("SubscribeBlockDigestsFromLatest", mock.Anything, mock.Anything).
+			Return(&mockBlockDigestsStream{
+				responses: []*access.SubscribeBlockDigestsResponse{{BlockHeight: 1}},
+				err:       io.EOF,
+			}, nil).
+			Once()
+
+		result, err := c.WaitForStatus(ctx, ids.New(), flow.TransactionStatusSealed)
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+	}))
+
+	t.Run("Expired", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(resultResponse(flow.TransactionStatusExpired), nil).Once()
+
+		result, err := c.WaitForStatus(ctx, ids.New(), flow.TransactionStatusSealed)
+		assert.True(t, errors.Is(err, flowerrors.ErrTimeout))
+		require.NotNil(t, result)
+		assert.Equal(t, flow.TransactionStatusExpired, result.Status)
+	}))
+
+	t.Run("Context done", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(resultResponse(flow.TransactionStatusPending), nil).Once()
+		rpc.On("SubscribeBlockDigestsFromLatest", mock.Anything, mock.Anything).
+			Run(func(mock.Arguments) { cancel() }).
+			Return(nil, status.Error(codes.Canceled, "canceled")).
+			Once()
+
+		_, err := c.WaitForStatus(ctx, ids.New(), flow.TransactionStatusSealed)
+		assert.Equal(t, codes.Canceled, status.Code(err))
+	}))
+
+	t.Run("Invalid target", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		_, err := c.WaitForStatus(ctx, ids.New(), flow.TransactionStatusUnknown)
+		assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+	}))
+}
+
+func TestClient_SendAndWaitForStatus(t *testing.T) {
+	transactions := test.TransactionGenerator()
+
+	t.Run("Streamed statuses", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		tx := transactions.New()
+
+		rpc.On("SendAndSubscribeTransactionStatuses", mock.Anything, mock.Anything).
+			Return(&mockTransactionStatusesStream{
+				responses: []*access.SendAndSubscribeTransactionStatusesResponse{
+					{TransactionResults: resultResponse(flow.TransactionStatusPending)},
+					{TransactionResults: resultResponse(flow.TransactionStatusFinalized), MessageIndex: 1},
+					{TransactionResults: resultResponse(flow.TransactionStatusSealed), MessageIndex: 2},
+				},
+			}, nil).
+			Once()
+
+		result, err := c.SendAndWaitForStatus(ctx, *tx, flow.TransactionStatusSealed)
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+	}))
+
+	t.Run("Unimplemented", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		tx := transactions.New()
+
+		rpc.On("SendAndSubscribeTransactionStatuses", mock.Anything, mock.Anything).
+			Return(&mockTransactionStatusesStream{err: status.Error(codes.Unimplemented, "unimplemented")}, nil).
+			Once()
+		rpc.On("SendTransaction", ctx, mock.Anything).Return(&access.SendTransactionResponse{}, nil).Once()
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(resultResponse(flow.TransactionStatusFinalized), nil).Once()
+
+		result, err := c.SendAndWaitForStatus(ctx, *tx, flow.TransactionStatusFinalized)
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusFinalized, result.Status)
+	}))
+
+	t.Run("Rejected", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		tx := transactions.New()
+
+		rpc.On("SendAndSubscribeTransactionStatuses", mock.Anything, mock.Anything).
+			Return(&mockTransactionStatusesStream{err: status.Error(codes.InvalidArgument, "invalid transaction")}, nil).
+			Once()
+
+		_, err := c.SendAndWaitForStatus(ctx, *tx, flow.TransactionStatusSealed)
+		assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+	}))
+}
@@ -46,4 +46,14 @@ func (c Collection) Encode() []byte {
 // A CollectionGuarantee is an attestation signed by the nodes that have guaranteed a collection.
 type CollectionGuarantee struct {
 	CollectionID Identifier
+	// ReferenceBlockID is the ID of the block the collection references for expiry.
+	ReferenceBlockID Identifier
+	// ClusterChainID identifies the collection cluster that built the collection.
+	ClusterChainID ChainID
+	// SignerIndices encodes which members of the cluster signed the guarantee.
+	//
+	// It can be decoded into node IDs with DecodeSignerIndices, given the cluster members.
+	SignerIndices []byte
+	// Signature is the aggregated signature of the signers.
+	Signature []byte
 }
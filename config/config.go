@@ -0,0 +1,254 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config loads the settings an application needs to reach a Flow
+// network: the network itself, the access node endpoint and the service
+// account together with a reference to its key material.
+//
+// Settings are read from environment variables in the 12-factor style, so a
+// containerized deployment can be configured without shipping a flow.json
+// file that embeds private keys. Environment variables may also be supplied
+// through a .env file, and a flow.json file can provide a base configuration
+// that the environment overrides:
+//
+//	cfg, err := config.Load(
+//	    config.WithFlowJSON("flow.json"),
+//	    config.WithDotEnv(".env"),
+//	)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	flowClient, err := client.New(cfg.AccessNode, grpc.WithInsecure())
+//
+// Values are resolved in the following order, from highest to lowest precedence:
+// process environment, .env file, flow.json and finally the network defaults.
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// Default access node endpoints for the networks known to the SDK.
+const (
+	EmulatorAccessNode = "127.0.0.1:3569"
+	TestnetAccessNode  = "access.devnet.nodes.onflow.org:9000"
+	MainnetAccessNode  = "access.mainnet.nodes.onflow.org:9000"
+)
+
+// Config is the resolved configuration for a Flow network.
+type Config struct {
+	// Network is the chain ID of the network to connect to.
+	Network flow.ChainID
+	// AccessNode is the gRPC endpoint of the access node.
+	AccessNode string
+	// ServiceAccount is the account used to sign and pay for transactions.
+	// Its address is empty if no service account is configured.
+	ServiceAccount Account
+}
+
+// Account is an account address and a reference to one of its keys.
+type Account struct {
+	Address flow.Address
+	Key     Key
+}
+
+// Key is a reference to the key material of an account key.
+//
+// At most one of PrivateKey, PrivateKeyFile and KMSResourceID is set.
+type Key struct {
+	// Index is the index of the key on the account.
+	Index    int
+	SigAlgo  crypto.SignatureAlgorithm
+	HashAlgo crypto.HashAlgorithm
+	// PrivateKey is a hex-encoded private key.
+	PrivateKey string
+	// PrivateKeyFile is the path of a file containing a hex-encoded private key,
+	// such as a mounted container secret.
+	PrivateKeyFile string
+	// KMSResourceID is the resource ID of a Google Cloud KMS key version.
+	KMSResourceID string
+}
+
+// HasKeyMaterial reports whether the key references any key material.
+func (k Key) HasKeyMaterial() bool {
+	return k.PrivateKey != "" || k.PrivateKeyFile != "" || k.KMSResourceID != ""
+}
+
+// IsKMS reports whether the key is stored in Google Cloud KMS.
+func (k Key) IsKMS() bool {
+	return k.KMSResourceID != ""
+}
+
+// LoadPrivateKey decodes the private key referenced by the key, reading it
+// from PrivateKeyFile if necessary.
+//
+// Keys stored in Cloud KMS cannot be loaded into memory; an error wrapping
+// flowerrors.ErrUnsupported is returned for them. Use the
+// github.com/onflow/flow-go-sdk/crypto/cloudkms module to sign with such keys.
+func (k Key) LoadPrivateKey() (crypto.PrivateKey, error) {
+	var encoded string
+
+	switch {
+	case k.IsKMS():
+		return nil, flowerrors.Errorf(
+			flowerrors.ErrUnsupported,
+			"config: key %s is stored in Cloud KMS, use the cloudkms module to sign with it",
+			k.KMSResourceID,
+		)
+	case k.PrivateKeyFile != "":
+		b, err := ioutil.ReadFile(k.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("config: failed to read private key file: %w", err)
+		}
+		encoded = string(b)
+	case k.PrivateKey != "":
+		encoded = k.PrivateKey
+	default:
+		return nil, flowerrors.New(flowerrors.ErrNotFound, "config: no private key configured")
+	}
+
+	encoded = strings.TrimPrefix(strings.TrimSpace(encoded), "0x")
+
+	privateKey, err := crypto.DecodePrivateKeyHex(k.SigAlgo, encoded)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	return privateKey, nil
+}
+
+// Signer returns an in-memory signer for the private key referenced by the key.
+//
+// See LoadPrivateKey for the keys that can be loaded.
+func (k Key) Signer() (crypto.Signer, error) {
+	privateKey, err := k.LoadPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.NewInMemorySigner(privateKey, k.HashAlgo), nil
+}
+
+// Validate returns an error if the configuration is incomplete or inconsistent.
+func (c Config) Validate() error {
+	if _, err := flow.ParseChainID(string(c.Network)); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	if c.AccessNode == "" {
+		return flowerrors.New(flowerrors.ErrInvalidArgument, "config: access node is not set")
+	}
+
+	if c.ServiceAccount.Address == flow.EmptyAddress {
+		if c.ServiceAccount.Key.HasKeyMaterial() {
+			return flowerrors.New(flowerrors.ErrInvalidArgument, "config: service account key is set without an address")
+		}
+		return nil
+	}
+
+	if !c.ServiceAccount.Address.IsValid(c.Network) {
+		return flowerrors.Errorf(
+			flowerrors.ErrInvalidArgument,
+			"config: service account address %s is not valid on %s",
+			c.ServiceAccount.Address, c.Network,
+		)
+	}
+
+	key := c.ServiceAccount.Key
+
+	if key.Index < 0 {
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "config: invalid key index %d", key.Index)
+	}
+
+	sources := 0
+	for _, s := range []string{key.PrivateKey, key.PrivateKeyFile, key.KMSResourceID} {
+		if s != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return flowerrors.New(flowerrors.ErrInvalidArgument, "config: more than one source of key material is set")
+	}
+
+	if !crypto.CompatibleAlgorithms(key.SigAlgo, key.HashAlgo) {
+		return flowerrors.Errorf(
+			flowerrors.ErrInvalidArgument,
+			"config: signing algorithm (%s) and hashing algorithm (%s) are not a valid pair for a service account key",
+			key.SigAlgo, key.HashAlgo,
+		)
+	}
+
+	return nil
+}
+
+// DefaultAccessNode returns the default access node endpoint for a network, or
+// an empty string if the network is unknown.
+func DefaultAccessNode(network flow.ChainID) string {
+	switch network {
+	case flow.Mainnet:
+		return MainnetAccessNode
+	case flow.Testnet:
+		return TestnetAccessNode
+	case flow.Emulator:
+		return EmulatorAccessNode
+	default:
+		return ""
+	}
+}
+
+// ParseNetwork parses a network name.
+//
+// Both chain IDs ("flow-testnet") and the short names used by flow.json
+// ("testnet") are accepted.
+func ParseNetwork(s string) (flow.ChainID, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if !strings.HasPrefix(s, "flow-") {
+		s = "flow-" + s
+	}
+
+	return flow.ParseChainID(s)
+}
+
+// parseSigAlgo parses a signature algorithm name such as ECDSA_P256, ignoring case.
+func parseSigAlgo(s string) (crypto.SignatureAlgorithm, error) {
+	for _, sigAlgo := range []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1} {
+		if strings.EqualFold(strings.TrimSpace(s), sigAlgo.String()) {
+			return sigAlgo, nil
+		}
+	}
+
+	return crypto.UnknownSignatureAlgorithm, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "unknown signature algorithm %q", s)
+}
+
+// parseHashAlgo parses a hash algorithm name such as SHA3_256, ignoring case.
+func parseHashAlgo(s string) (crypto.HashAlgorithm, error) {
+	for _, hashAlgo := range []crypto.HashAlgorithm{crypto.SHA2_256, crypto.SHA3_256} {
+		if strings.EqualFold(strings.TrimSpace(s), hashAlgo.String()) {
+			return hashAlgo, nil
+		}
+	}
+
+	return crypto.UnknownHashAlgorithm, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "unknown hash algorithm %q", s)
+}
@@ -0,0 +1,296 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/config"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+const (
+	testAddress    = "f8d6e0586b0a20c7"
+	testPrivateKey = "68ee617d9bf67a4677af80aaca5a090fcda80ff2f4dbc340e0e36201fa1f1d8c"
+)
+
+func lookup(vars map[string]string) config.Option {
+	return config.WithLookup(func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	})
+}
+
+func writeFile(t *testing.T, name, contents string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg, err := config.Load(lookup(nil))
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.Emulator, cfg.Network)
+		assert.Equal(t, config.EmulatorAccessNode, cfg.AccessNode)
+		assert.Equal(t, flow.EmptyAddress, cfg.ServiceAccount.Address)
+		assert.False(t, cfg.ServiceAccount.Key.HasKeyMaterial())
+	})
+
+	t.Run("Environment", func(t *testing.T) {
+		cfg, err := config.Load(lookup(map[string]string{
+			"FLOW_NETWORK":             "testnet",
+			"FLOW_SERVICE_ADDRESS":     "0x8c5303eaa26202d6",
+			"FLOW_SERVICE_KEY_INDEX":   "2",
+			"FLOW_SERVICE_SIG_ALGO":    "ECDSA_secp256k1",
+			"FLOW_SERVICE_HASH_ALGO":   "SHA2_256",
+			"FLOW_SERVICE_KMS_KEY":     "projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1",
+			"FLOW_UNRELATED_VARIABLE":  "ignored",
+			"FLOW_SERVICE_PRIVATE_KEY": "",
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.Testnet, cfg.Network)
+		assert.Equal(t, config.TestnetAccessNode, cfg.AccessNode)
+		assert.Equal(t, flow.HexToAddress("8c5303eaa26202d6"), cfg.ServiceAccount.Address)
+		assert.Equal(t, config.Key{
+			Index:         2,
+			SigAlgo:       crypto.ECDSA_secp256k1,
+			HashAlgo:      crypto.SHA2_256,
+			KMSResourceID: "projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1",
+		}, cfg.ServiceAccount.Key)
+		assert.True(t, cfg.ServiceAccount.Key.IsKMS())
+	})
+
+	t.Run("Prefix", func(t *testing.T) {
+		cfg, err := config.Load(
+			config.WithPrefix("APP_FLOW_"),
+			lookup(map[string]string{
+				"FLOW_ACCESS_NODE":     "ignored:9000",
+				"APP_FLOW_ACCESS_NODE": "access.example.com:9000",
+			}),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "access.example.com:9000", cfg.AccessNode)
+	})
+
+	t.Run("Invalid variables", func(t *testing.T) {
+		vars := map[string]string{
+			"FLOW_NETWORK":           "devnet",
+			"FLOW_SERVICE_ADDRESS":   "not-an-address",
+			"FLOW_SERVICE_KEY_INDEX": "first",
+			"FLOW_SERVICE_SIG_ALGO":  "RSA",
+			"FLOW_SERVICE_HASH_ALGO": "MD5",
+		}
+
+		for name, value := range vars {
+			_, err := config.Load(lookup(map[string]string{name: value}))
+			require.Error(t, err, name)
+			assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument, name)
+			assert.Contains(t, err.Error(), name)
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		_, err := config.Load(lookup(map[string]string{
+			"FLOW_SERVICE_ADDRESS":     testAddress,
+			"FLOW_SERVICE_PRIVATE_KEY": testPrivateKey,
+			"FLOW_SERVICE_KMS_KEY":     "projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1",
+		}))
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+
+		_, err = config.Load(lookup(map[string]string{
+			"FLOW_SERVICE_PRIVATE_KEY": testPrivateKey,
+		}))
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+
+		_, err = config.Load(lookup(map[string]string{
+			"FLOW_NETWORK":         "mainnet",
+			"FLOW_SERVICE_ADDRESS": testAddress,
+		}))
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+	})
+
+	t.Run("DotEnv", func(t *testing.T) {
+		path := writeFile(t, ".env", strings.Join([]string{
+			"# emulator service account",
+			"export FLOW_SERVICE_ADDRESS=" + testAddress,
+			`FLOW_SERVICE_PRIVATE_KEY="` + testPrivateKey + `"`,
+			"FLOW_ACCESS_NODE=localhost:3569 # overridden",
+		}, "\n"))
+
+		cfg, err := config.Load(
+			config.WithDotEnv(path),
+			config.WithDotEnv(filepath.Join(t.TempDir(), "missing.env")),
+			lookup(map[string]string{
+				"FLOW_ACCESS_NODE": "emulator:3569",
+			}),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "emulator:3569", cfg.AccessNode)
+		assert.Equal(t, flow.HexToAddress(testAddress), cfg.ServiceAccount.Address)
+		assert.Equal(t, testPrivateKey, cfg.ServiceAccount.Key.PrivateKey)
+	})
+
+	t.Run("FlowJSON", func(t *testing.T) {
+		path := writeFile(t, "flow.json", `{
+			"emulators": {"default": {"serviceAccount": "service"}},
+			"networks": {
+				"emulator": "127.0.0.1:3570",
+				"testnet": {"host": "testnet.example.com:9000"}
+			},
+			"accounts": {
+				"service": {
+					"address": "`+testAddress+`",
+					"keys": [{
+						"type": "hex",
+						"index": 1,
+						"signatureAlgorithm": "ECDSA_P256",
+						"hashAlgorithm": "SHA2_256",
+						"context": {"privateKey": "`+testPrivateKey+`"}
+					}]
+				},
+				"deployer": {
+					"address": "0x8c5303eaa26202d6",
+					"key": {"type": "file", "location": "deployer.pkey"}
+				}
+			}
+		}`)
+
+		cfg, err := config.Load(config.WithFlowJSON(path), lookup(nil))
+		require.NoError(t, err)
+
+		assert.Equal(t, "127.0.0.1:3570", cfg.AccessNode)
+		assert.Equal(t, flow.HexToAddress(testAddress), cfg.ServiceAccount.Address)
+		assert.Equal(t, config.Key{
+			Index:      1,
+			SigAlgo:    crypto.ECDSA_P256,
+			HashAlgo:   crypto.SHA2_256,
+			PrivateKey: testPrivateKey,
+		}, cfg.ServiceAccount.Key)
+
+		// the environment overrides flow.json, and key material replaces the file's key
+		cfg, err = config.Load(
+			config.WithFlowJSON(path),
+			config.WithAccount("deployer"),
+			lookup(map[string]string{
+				"FLOW_NETWORK":              "testnet",
+				"FLOW_SERVICE_PRIVATE_KEY":  testPrivateKey,
+				"FLOW_SERVICE_KEY_INDEX":    "3",
+				"FLOW_SERVICE_KMS_KEY":      "",
+				"FLOW_SERVICE_ADDRESS_HINT": "ignored",
+			}),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "testnet.example.com:9000", cfg.AccessNode)
+		assert.Equal(t, flow.HexToAddress("8c5303eaa26202d6"), cfg.ServiceAccount.Address)
+		assert.Equal(t, config.Key{
+			Index:      3,
+			SigAlgo:    crypto.ECDSA_P256,
+			HashAlgo:   crypto.SHA3_256,
+			PrivateKey: testPrivateKey,
+		}, cfg.ServiceAccount.Key)
+
+		_, err = config.Load(config.WithFlowJSON(filepath.Join(t.TempDir(), "flow.json")), lookup(nil))
+		assert.Error(t, err)
+
+		invalid := writeFile(t, "flow.json", `{"accounts": {"emulator-account": {"address": "xyz"}}}`)
+		_, err = config.Load(config.WithFlowJSON(invalid), lookup(nil))
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+	})
+}
+
+func TestKey_Signer(t *testing.T) {
+	privateKey, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, testPrivateKey)
+	require.NoError(t, err)
+
+	t.Run("Hex", func(t *testing.T) {
+		key := config.Key{SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, PrivateKey: "0x" + testPrivateKey}
+
+		loaded, err := key.LoadPrivateKey()
+		require.NoError(t, err)
+		assert.True(t, privateKey.Equals(loaded))
+
+		signer, err := key.Signer()
+		require.NoError(t, err)
+		assert.NotNil(t, signer)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		path := writeFile(t, "service.pkey", testPrivateKey+"\n")
+		key := config.Key{SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, PrivateKeyFile: path}
+
+		loaded, err := key.LoadPrivateKey()
+		require.NoError(t, err)
+		assert.True(t, privateKey.Equals(loaded))
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		_, err := config.Key{KMSResourceID: "projects/p"}.Signer()
+		assert.ErrorIs(t, err, flowerrors.ErrUnsupported)
+
+		_, err = config.Key{}.Signer()
+		assert.ErrorIs(t, err, flowerrors.ErrNotFound)
+
+		_, err = config.Key{SigAlgo: crypto.ECDSA_P256, PrivateKey: "zz"}.Signer()
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+	})
+}
+
+func TestParseDotEnv(t *testing.T) {
+	vars, err := config.ParseDotEnv(strings.NewReader(strings.Join([]string{
+		"",
+		"# comment",
+		"PLAIN=value",
+		"SPACED = value with spaces # trailing comment",
+		`DOUBLE="line\nbreak" # comment`,
+		`SINGLE='no \n escapes'`,
+		"export EXPORTED=1",
+		"EMPTY=",
+		"URL=http://example.com/#anchor",
+	}, "\n")))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"PLAIN":    "value",
+		"SPACED":   "value with spaces",
+		"DOUBLE":   "line\nbreak",
+		"SINGLE":   `no \n escapes`,
+		"EXPORTED": "1",
+		"EMPTY":    "",
+		"URL":      "http://example.com/#anchor",
+	}, vars)
+
+	for _, line := range []string{"NO_SEPARATOR", "=value", `OPEN="value`, "OPEN='value", "TWO WORDS=value"} {
+		_, err := config.ParseDotEnv(strings.NewReader(line))
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding, line)
+	}
+}
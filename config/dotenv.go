@@ -0,0 +1,130 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// ParseDotEnv parses variables in the .env file format.
+//
+// Each non-empty line is a KEY=VALUE assignment, optionally preceded by
+// "export". Lines starting with # are comments. Values may be wrapped in
+// double quotes, in which case Go escape sequences such as \n are expanded,
+// or in single quotes, in which case they are taken literally. Unquoted values
+// end at the first " #".
+func ParseDotEnv(r io.Reader) (map[string]string, error) {
+	vars := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		text = strings.TrimSpace(strings.TrimPrefix(text, "export "))
+
+		i := strings.Index(text, "=")
+		if i < 0 {
+			return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "config: .env line %d: missing '='", line)
+		}
+
+		key := strings.TrimSpace(text[:i])
+		if key == "" || strings.ContainsAny(key, " \t") {
+			return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "config: .env line %d: invalid variable name %q", line, key)
+		}
+
+		value, err := parseDotEnvValue(strings.TrimSpace(text[i+1:]))
+		if err != nil {
+			return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "config: .env line %d: %w", line, err)
+		}
+
+		vars[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "config: failed to read .env file: %w", err)
+	}
+
+	return vars, nil
+}
+
+func parseDotEnvValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	switch value[0] {
+	case '"':
+		end := closingQuote(value)
+		if end < 0 {
+			return "", flowerrors.New(flowerrors.ErrDecoding, "unterminated double-quoted value")
+		}
+		return strconv.Unquote(value[:end+1])
+	case '\'':
+		end := strings.IndexByte(value[1:], '\'')
+		if end < 0 {
+			return "", flowerrors.New(flowerrors.ErrDecoding, "unterminated single-quoted value")
+		}
+		return value[1 : end+1], nil
+	}
+
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+
+	return value, nil
+}
+
+// closingQuote returns the index of the double quote that closes the
+// double-quoted string s, or -1 if it is not terminated.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
+
+// ReadDotEnv reads and parses the .env file at path.
+func ReadDotEnv(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseDotEnv(f)
+}
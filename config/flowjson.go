@@ -0,0 +1,212 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// defaultServiceAccountName is the account used as the service account when
+// flow.json does not name one in its default emulator.
+const defaultServiceAccountName = "emulator-account"
+
+// flowJSON is the subset of the flow.json format read by this package.
+type flowJSON struct {
+	Emulators map[string]struct {
+		ServiceAccount string `json:"serviceAccount"`
+	} `json:"emulators"`
+	Networks map[string]json.RawMessage `json:"networks"`
+	Accounts map[string]flowJSONAccount `json:"accounts"`
+}
+
+type flowJSONAccount struct {
+	Address string          `json:"address"`
+	Key     json.RawMessage `json:"key"`
+	Keys    json.RawMessage `json:"keys"`
+}
+
+type flowJSONKey struct {
+	Type               string `json:"type"`
+	Index              int    `json:"index"`
+	SignatureAlgorithm string `json:"signatureAlgorithm"`
+	HashAlgorithm      string `json:"hashAlgorithm"`
+	PrivateKey         string `json:"privateKey"`
+	Location           string `json:"location"`
+	ResourceID         string `json:"resourceID"`
+	Context            struct {
+		PrivateKey string `json:"privateKey"`
+		ResourceID string `json:"resourceID"`
+	} `json:"context"`
+}
+
+// flowJSONConfig holds the values read from a flow.json file.
+type flowJSONConfig struct {
+	networks       map[string]string
+	serviceAccount *Account
+}
+
+// readFlowJSON reads the networks and the service account from the flow.json
+// file at path.
+//
+// The service account is the account named by accountName or, if it is empty,
+// by the default emulator, falling back to "emulator-account". It is nil if
+// the file contains no such account.
+func readFlowJSON(path, accountName string) (*flowJSONConfig, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to read flow.json: %w", err)
+	}
+
+	var file flowJSON
+	if err := json.Unmarshal(b, &file); err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "config: failed to decode %s: %w", path, err)
+	}
+
+	conf := &flowJSONConfig{
+		networks: make(map[string]string, len(file.Networks)),
+	}
+
+	for name, raw := range file.Networks {
+		host, err := decodeFlowJSONNetwork(raw)
+		if err != nil {
+			return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "config: invalid network %q in %s: %w", name, path, err)
+		}
+		conf.networks[name] = host
+	}
+
+	if accountName == "" {
+		accountName = file.Emulators["default"].ServiceAccount
+	}
+	if accountName == "" {
+		accountName = defaultServiceAccountName
+	}
+
+	account, ok := file.Accounts[accountName]
+	if !ok {
+		return conf, nil
+	}
+
+	serviceAccount, err := decodeFlowJSONAccount(account)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "config: invalid account %q in %s: %w", accountName, path, err)
+	}
+	conf.serviceAccount = serviceAccount
+
+	return conf, nil
+}
+
+// decodeFlowJSONNetwork decodes a network entry, which is either a host
+// string or an object with a host field.
+func decodeFlowJSONNetwork(raw json.RawMessage) (string, error) {
+	var host string
+	if err := json.Unmarshal(raw, &host); err == nil {
+		return host, nil
+	}
+
+	var network struct {
+		Host string `json:"host"`
+	}
+	if err := json.Unmarshal(raw, &network); err != nil {
+		return "", err
+	}
+
+	return network.Host, nil
+}
+
+// decodeFlowJSONAccount decodes an account entry. The key is read from either
+// the "key" field, which holds a hex-encoded private key or a key object, or
+// the first element of the older "keys" array.
+func decodeFlowJSONAccount(account flowJSONAccount) (*Account, error) {
+	address, err := flow.ParseAddress(account.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	key := flowJSONKey{Type: "hex"}
+
+	switch {
+	case len(account.Key) > 0:
+		var hex string
+		if err := json.Unmarshal(account.Key, &hex); err == nil {
+			key.PrivateKey = hex
+		} else if err := json.Unmarshal(account.Key, &key); err != nil {
+			return nil, err
+		}
+	case len(account.Keys) > 0:
+		var keys []flowJSONKey
+		if err := json.Unmarshal(account.Keys, &keys); err != nil {
+			return nil, err
+		}
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("account has no keys")
+		}
+		key = keys[0]
+	}
+
+	result := Account{
+		Address: address,
+		Key: Key{
+			Index:    key.Index,
+			SigAlgo:  crypto.ECDSA_P256,
+			HashAlgo: crypto.SHA3_256,
+		},
+	}
+
+	if key.SignatureAlgorithm != "" {
+		if result.Key.SigAlgo, err = parseSigAlgo(key.SignatureAlgorithm); err != nil {
+			return nil, err
+		}
+	}
+
+	if key.HashAlgorithm != "" {
+		if result.Key.HashAlgo, err = parseHashAlgo(key.HashAlgorithm); err != nil {
+			return nil, err
+		}
+	}
+
+	switch strings.ToLower(key.Type) {
+	case "", "hex":
+		result.Key.PrivateKey = firstNonEmpty(key.PrivateKey, key.Context.PrivateKey)
+	case "file":
+		result.Key.PrivateKeyFile = key.Location
+	case "google-kms":
+		result.Key.KMSResourceID = firstNonEmpty(key.ResourceID, key.Context.ResourceID)
+	default:
+		return nil, flowerrors.Errorf(flowerrors.ErrUnsupported, "unsupported key type %q", key.Type)
+	}
+
+	return &result, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,284 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// DefaultPrefix is the prefix of the environment variables read by Load.
+const DefaultPrefix = "FLOW_"
+
+// Names of the environment variables read by Load, without the prefix.
+const (
+	// EnvNetwork is the network to connect to, either a chain ID or a short
+	// name such as "testnet".
+	EnvNetwork = "NETWORK"
+	// EnvAccessNode is the gRPC endpoint of the access node.
+	EnvAccessNode = "ACCESS_NODE"
+	// EnvServiceAddress is the address of the service account.
+	EnvServiceAddress = "SERVICE_ADDRESS"
+	// EnvServiceKeyIndex is the index of the service account key.
+	EnvServiceKeyIndex = "SERVICE_KEY_INDEX"
+	// EnvServiceSigAlgo is the signature algorithm of the service account key.
+	EnvServiceSigAlgo = "SERVICE_SIG_ALGO"
+	// EnvServiceHashAlgo is the hash algorithm of the service account key.
+	EnvServiceHashAlgo = "SERVICE_HASH_ALGO"
+	// EnvServicePrivateKey is the hex-encoded private key of the service account.
+	EnvServicePrivateKey = "SERVICE_PRIVATE_KEY"
+	// EnvServicePrivateKeyFile is the path of a file containing the hex-encoded
+	// private key of the service account.
+	EnvServicePrivateKeyFile = "SERVICE_PRIVATE_KEY_FILE"
+	// EnvServiceKMSKey is the Cloud KMS resource ID of the service account key.
+	EnvServiceKMSKey = "SERVICE_KMS_KEY"
+)
+
+type options struct {
+	prefix   string
+	lookup   func(string) (string, bool)
+	dotEnv   []string
+	flowJSON string
+	account  string
+	network  flow.ChainID
+}
+
+// An Option configures Load.
+type Option func(*options)
+
+// WithPrefix sets the prefix of the environment variables read by Load.
+//
+// The default prefix is DefaultPrefix.
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithLookup sets the function used to look up environment variables.
+//
+// The default is os.LookupEnv.
+func WithLookup(lookup func(string) (string, bool)) Option {
+	return func(o *options) {
+		o.lookup = lookup
+	}
+}
+
+// WithDotEnv reads additional variables from the .env file at path.
+//
+// Variables set in the environment take precedence over those in the file,
+// and files given earlier take precedence over later ones. A missing file is
+// not an error, so the same option can be used both in development and in
+// deployments that are configured through the environment only.
+func WithDotEnv(path string) Option {
+	return func(o *options) {
+		o.dotEnv = append(o.dotEnv, path)
+	}
+}
+
+// WithFlowJSON reads a base configuration from the flow.json file at path.
+//
+// The access node is taken from the "networks" section and the service
+// account from the "accounts" section. Unlike .env files, the file must exist.
+func WithFlowJSON(path string) Option {
+	return func(o *options) {
+		o.flowJSON = path
+	}
+}
+
+// WithAccount sets the name of the flow.json account used as the service account.
+//
+// By default the service account of the default emulator is used, falling
+// back to the account named "emulator-account".
+func WithAccount(name string) Option {
+	return func(o *options) {
+		o.account = name
+	}
+}
+
+// WithNetwork sets the network used when none is set in the environment.
+//
+// The default network is flow.Emulator.
+func WithNetwork(network flow.ChainID) Option {
+	return func(o *options) {
+		o.network = network
+	}
+}
+
+// Load resolves the configuration from the environment and the sources
+// configured by opts, and validates it.
+func Load(opts ...Option) (*Config, error) {
+	o := options{
+		prefix:  DefaultPrefix,
+		lookup:  os.LookupEnv,
+		network: flow.Emulator,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	env, err := o.environment()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		Network: o.network,
+		ServiceAccount: Account{
+			Key: Key{
+				SigAlgo:  crypto.ECDSA_P256,
+				HashAlgo: crypto.SHA3_256,
+			},
+		},
+	}
+
+	if v, ok := env(EnvNetwork); ok {
+		if cfg.Network, err = ParseNetwork(v); err != nil {
+			return nil, o.invalid(EnvNetwork, err)
+		}
+	}
+
+	var fileConf *flowJSONConfig
+	if o.flowJSON != "" {
+		if fileConf, err = readFlowJSON(o.flowJSON, o.account); err != nil {
+			return nil, err
+		}
+
+		if fileConf.serviceAccount != nil {
+			cfg.ServiceAccount = *fileConf.serviceAccount
+		}
+	}
+
+	cfg.AccessNode = DefaultAccessNode(cfg.Network)
+	if fileConf != nil {
+		if host, ok := fileConf.networks[shortNetworkName(cfg.Network)]; ok && host != "" {
+			cfg.AccessNode = host
+		}
+	}
+	if v, ok := env(EnvAccessNode); ok {
+		cfg.AccessNode = v
+	}
+
+	if err := o.applyServiceAccount(env, &cfg.ServiceAccount); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (o options) applyServiceAccount(env func(string) (string, bool), account *Account) error {
+	var err error
+
+	if v, ok := env(EnvServiceAddress); ok {
+		if account.Address, err = flow.ParseAddress(v); err != nil {
+			return o.invalid(EnvServiceAddress, err)
+		}
+	}
+
+	key := &account.Key
+
+	if v, ok := env(EnvServiceKeyIndex); ok {
+		index, err := strconv.Atoi(v)
+		if err != nil {
+			return o.invalid(EnvServiceKeyIndex, err)
+		}
+		key.Index = index
+	}
+
+	if v, ok := env(EnvServiceSigAlgo); ok {
+		if key.SigAlgo, err = parseSigAlgo(v); err != nil {
+			return o.invalid(EnvServiceSigAlgo, err)
+		}
+	}
+
+	if v, ok := env(EnvServiceHashAlgo); ok {
+		if key.HashAlgo, err = parseHashAlgo(v); err != nil {
+			return o.invalid(EnvServiceHashAlgo, err)
+		}
+	}
+
+	privateKey, hasPrivateKey := env(EnvServicePrivateKey)
+	privateKeyFile, hasPrivateKeyFile := env(EnvServicePrivateKeyFile)
+	kmsKey, hasKMSKey := env(EnvServiceKMSKey)
+
+	// key material set in the environment replaces the key from flow.json
+	// rather than being merged with it
+	if hasPrivateKey || hasPrivateKeyFile || hasKMSKey {
+		key.PrivateKey = privateKey
+		key.PrivateKeyFile = privateKeyFile
+		key.KMSResourceID = kmsKey
+	}
+
+	return nil
+}
+
+// environment returns a function that looks up the value of a variable,
+// first in the environment and then in the .env files. Empty values are
+// treated as unset.
+func (o options) environment() (func(string) (string, bool), error) {
+	var files []map[string]string
+
+	for _, path := range o.dotEnv {
+		vars, err := ReadDotEnv(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("config: failed to read %s: %w", path, err)
+		}
+		files = append(files, vars)
+	}
+
+	return func(name string) (string, bool) {
+		name = o.prefix + name
+
+		if v, ok := o.lookup(name); ok && v != "" {
+			return strings.TrimSpace(v), true
+		}
+
+		for _, vars := range files {
+			if v, ok := vars[name]; ok && v != "" {
+				return v, true
+			}
+		}
+
+		return "", false
+	}, nil
+}
+
+func (o options) invalid(name string, err error) error {
+	return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "config: invalid %s%s: %w", o.prefix, name, err)
+}
+
+// shortNetworkName returns the name used for a network in flow.json.
+func shortNetworkName(network flow.ChainID) string {
+	return strings.TrimPrefix(string(network), "flow-")
+}
@@ -32,6 +32,7 @@ import (
 	kmspb "google.golang.org/genproto/googleapis/cloud/kms/v1"
 
 	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
 )
 
 const (
@@ -73,11 +74,11 @@ func KeyFromResourceID(resourceID string) (Key, error) {
 		&key.ProjectID, &key.LocationID, &key.KeyRingID, &key.KeyID, &key.KeyVersion, // arguments to fill
 	)
 	if err != nil {
-		return key, fmt.Errorf("cloudkms: failed to parse resource ID %s, scanf error: %w", resourceID, err)
+		return key, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "cloudkms: failed to parse resource ID %s, scanf error: %w", resourceID, err)
 	}
 
 	if scanned != resourceIDArgumentCount {
-		return key, fmt.Errorf("cloudkms: failed to parse resource ID %s, found %d arguments but expected %d", resourceID, scanned, resourceIDArgumentCount)
+		return key, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "cloudkms: failed to parse resource ID %s, found %d arguments but expected %d", resourceID, scanned, resourceIDArgumentCount)
 	}
 
 	return key, nil
@@ -115,14 +116,15 @@ func (c *Client) GetPublicKey(ctx context.Context, key Key) (crypto.PublicKey, c
 	if err != nil {
 		return nil,
 			crypto.UnknownHashAlgorithm,
-			fmt.Errorf("cloudkms: failed to fetch public key from KMS API: %v", err)
+			fmt.Errorf("cloudkms: failed to fetch public key from KMS API: %w", err)
 	}
 
 	sigAlgo := parseSignatureAlgorithm(result.Algorithm)
 	if sigAlgo == crypto.UnknownSignatureAlgorithm {
 		return nil,
 			crypto.UnknownHashAlgorithm,
-			fmt.Errorf(
+			flowerrors.Errorf(
+				flowerrors.ErrUnsupported,
 				"cloudkms: unsupported signature algorithm %s",
 				result.Algorithm.String(),
 			)
@@ -132,7 +134,8 @@ func (c *Client) GetPublicKey(ctx context.Context, key Key) (crypto.PublicKey, c
 	if hashAlgo == crypto.UnknownHashAlgorithm {
 		return nil,
 			crypto.UnknownHashAlgorithm,
-			fmt.Errorf(
+			flowerrors.Errorf(
+				flowerrors.ErrUnsupported,
 				"cloudkms: unsupported hash algorithm %s",
 				result.Algorithm.String(),
 			)
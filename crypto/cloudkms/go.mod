@@ -0,0 +1,13 @@
+module github.com/onflow/flow-go-sdk/crypto/cloudkms
+
+go 1.13
+
+require (
+	cloud.google.com/go/kms v1.6.0
+	github.com/onflow/flow-go-sdk v0.21.0
+	github.com/stretchr/testify v1.8.1
+	google.golang.org/api v0.103.0
+	google.golang.org/genproto v0.0.0-20221207170731-23e4bf6bdc37
+)
+
+replace github.com/onflow/flow-go-sdk => ../..
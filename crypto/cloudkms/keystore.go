@@ -0,0 +1,45 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package cloudkms
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/keystore"
+)
+
+// KeystoreSigners returns a keystore.SignerFactory that creates Cloud KMS
+// signers for keys that reference a KMS key, and in-memory signers for keys
+// that hold their private key.
+func (c *Client) KeystoreSigners() keystore.SignerFactory {
+	return func(ctx context.Context, key *keystore.Key) (crypto.Signer, error) {
+		if key.KMSResourceID == "" {
+			return keystore.InMemorySigner(ctx, key)
+		}
+
+		kmsKey, err := KeyFromResourceID(key.KMSResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.SignerForKey(ctx, kmsKey)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	kmspb "google.golang.org/genproto/googleapis/cloud/kms/v1"
 
 	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
 )
 
 // Signer is a Google Cloud KMS implementation of crypto.Signer.
@@ -36,7 +37,6 @@ type Signer struct {
 	client   *kms.KeyManagementClient
 	key      Key
 	hashAlgo crypto.HashAlgorithm
-	hasher   crypto.Hasher
 }
 
 // SignerForKey returns a new Google Cloud KMS signer for an asymmetric key version.
@@ -49,7 +49,7 @@ func (c *Client) SignerForKey(
 		return nil, err
 	}
 
-	hasher, err := crypto.NewHasher(hashAlgo)
+	_, err = crypto.NewHasher(hashAlgo)
 	if err != nil {
 		return nil, fmt.Errorf("cloudkms: failed to instantiate hasher: %w", err)
 	}
@@ -59,15 +59,21 @@ func (c *Client) SignerForKey(
 		client:   c.client,
 		key:      key,
 		hashAlgo: hashAlgo,
-		hasher:   hasher,
 	}, nil
 }
 
 // Sign signs the given message using the KMS signing key for this signer.
 //
+// A new hasher is used for each message, so a Signer is safe for concurrent use.
+//
 // Reference: https://cloud.google.com/kms/docs/create-validate-signatures
 func (s *Signer) Sign(message []byte) ([]byte, error) {
-	digest := s.hasher.ComputeHash(message)
+	hasher, err := crypto.NewHasher(s.hashAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("cloudkms: failed to instantiate hasher: %w", err)
+	}
+
+	digest := hasher.ComputeHash(message)
 
 	digestMessage, err := makeDigest(s.hashAlgo, digest)
 	if err != nil {
@@ -100,7 +106,7 @@ func makeDigest(hashAlgo crypto.HashAlgorithm, digest []byte) (*kmspb.Digest, er
 		return &kmspb.Digest{Digest: &kmspb.Digest_Sha384{Sha384: digest}}, nil
 	}
 
-	return nil, fmt.Errorf("unsupported hash algorithm %s", hashAlgo)
+	return nil, flowerrors.Errorf(flowerrors.ErrUnsupported, "unsupported hash algorithm %s", hashAlgo)
 }
 
 // ecCoupleComponentSize is size of a component in either (r,s) couple for an elliptical curve signature
@@ -117,7 +123,7 @@ const ecCoupleComponentSize = 32
 func parseSignature(signature []byte) ([]byte, error) {
 	var parsedSig struct{ R, S *big.Int }
 	if _, err := asn1.Unmarshal(signature, &parsedSig); err != nil {
-		return nil, fmt.Errorf("asn1.Unmarshal: %w", err)
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "asn1.Unmarshal: %w", err)
 	}
 
 	rBytes := parsedSig.R.Bytes()
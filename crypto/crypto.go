@@ -23,11 +23,12 @@ import (
 	"encoding/asn1"
 	"encoding/hex"
 	"encoding/pem"
-	"errors"
 	"fmt"
 
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/crypto/hash"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
 )
 
 // SignatureAlgorithm is an identifier for a signature algorithm (and parameters if applicable).
@@ -125,8 +126,21 @@ func NewInMemorySigner(privateKey PrivateKey, hashAlgo HashAlgorithm) InMemorySi
 	}
 }
 
+// Sign signs the given message with this signer.
+//
+// Hashers are stateful, so the message is hashed with a new hasher of the same
+// algorithm as Hasher. This makes an InMemorySigner safe for concurrent use,
+// unless Hasher uses an algorithm not supported by NewHasher.
 func (s InMemorySigner) Sign(message []byte) ([]byte, error) {
-	return s.PrivateKey.Sign(message, s.Hasher)
+	hasher := s.Hasher
+
+	if hasher != nil {
+		if h, err := NewHasher(hasher.Algorithm()); err == nil {
+			hasher = h
+		}
+	}
+
+	return s.PrivateKey.Sign(message, hasher)
 }
 
 // NaiveSigner is an alias for InMemorySigner.
@@ -153,7 +167,8 @@ func keyGenerationKMACTag(sigAlgo SignatureAlgorithm) []byte {
 func GeneratePrivateKey(sigAlgo SignatureAlgorithm, seed []byte) (PrivateKey, error) {
 	// check the seed has minimum entropy
 	if len(seed) < MinSeedLength {
-		return nil, fmt.Errorf(
+		return nil, flowerrors.Errorf(
+			flowerrors.ErrInvalidArgument,
 			"crypto: insufficient seed length %d, must be at least %d bytes for %s",
 			len(seed),
 			MinSeedLength,
@@ -169,7 +184,8 @@ func GeneratePrivateKey(sigAlgo SignatureAlgorithm, seed []byte) (PrivateKey, er
 	case ECDSA_secp256k1:
 		seedLen = crypto.KeyGenSeedMinLenECDSASecp256k1
 	default:
-		return nil, fmt.Errorf(
+		return nil, flowerrors.Errorf(
+			flowerrors.ErrUnsupported,
 			"crypto: Go SDK does not support key generation for %s algorithm",
 			sigAlgo,
 		)
@@ -194,26 +210,40 @@ func GeneratePrivateKey(sigAlgo SignatureAlgorithm, seed []byte) (PrivateKey, er
 }
 
 // DecodePrivateKey decodes a raw byte encoded private key with the given signature algorithm.
-var DecodePrivateKey = crypto.DecodePrivateKey
+func DecodePrivateKey(sigAlgo SignatureAlgorithm, b []byte) (PrivateKey, error) {
+	privKey, err := crypto.DecodePrivateKey(sigAlgo, b)
+	if err != nil {
+		return nil, flowerrors.Wrap(flowerrors.ErrDecoding, err)
+	}
+
+	return privKey, nil
+}
 
 // DecodePrivateKeyHex decodes a raw hex encoded private key with the given signature algorithm.
 func DecodePrivateKeyHex(sigAlgo SignatureAlgorithm, s string) (PrivateKey, error) {
 	b, err := hex.DecodeString(s)
 	if err != nil {
-		return nil, err
+		return nil, flowerrors.Wrap(flowerrors.ErrDecoding, err)
 	}
 
 	return DecodePrivateKey(sigAlgo, b)
 }
 
 // DecodePublicKey decodes a raw byte encoded public key with the given signature algorithm.
-var DecodePublicKey = crypto.DecodePublicKey
+func DecodePublicKey(sigAlgo SignatureAlgorithm, b []byte) (PublicKey, error) {
+	pubKey, err := crypto.DecodePublicKey(sigAlgo, b)
+	if err != nil {
+		return nil, flowerrors.Wrap(flowerrors.ErrDecoding, err)
+	}
+
+	return pubKey, nil
+}
 
 // DecodePublicKeyHex decodes a raw hex encoded public key with the given signature algorithm.
 func DecodePublicKeyHex(sigAlgo SignatureAlgorithm, s string) (PublicKey, error) {
 	b, err := hex.DecodeString(s)
 	if err != nil {
-		return nil, err
+		return nil, flowerrors.Wrap(flowerrors.ErrDecoding, err)
 	}
 
 	return DecodePublicKey(sigAlgo, b)
@@ -225,18 +255,21 @@ func DecodePublicKeyHex(sigAlgo SignatureAlgorithm, s string) (PublicKey, error)
 func DecodePublicKeyPEM(sigAlgo SignatureAlgorithm, s string) (PublicKey, error) {
 
 	if sigAlgo != ECDSA_P256 && sigAlgo != ECDSA_secp256k1 {
-		return nil, fmt.Errorf("crypto: only ECDSA algorithms are supported")
+		return nil, flowerrors.New(flowerrors.ErrUnsupported, "crypto: only ECDSA algorithms are supported")
 	}
 
 	block, rest := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, flowerrors.New(flowerrors.ErrDecoding, "crypto: failed to parse PEM string, no PEM block found")
+	}
 	if len(rest) > 0 {
-		return nil, fmt.Errorf("crypto: failed to parse PEM string, not all bytes in PEM key were decoded: %x", rest)
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "crypto: failed to parse PEM string, not all bytes in PEM key were decoded: %x", rest)
 	}
 
 	// parse the public key data and extract the raw public key
 	pkBytes, err := x509ParseECDSAPublicKey(block.Bytes)
 	if err != nil {
-		return nil, fmt.Errorf("crypto: failed to parse PEM string: %w", err)
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "crypto: failed to parse PEM string: %w", err)
 	}
 
 	// decode the point and check the resulting key is a valid point on the curve
@@ -264,14 +297,14 @@ func x509ParseECDSAPublicKey(derBytes []byte) ([]byte, error) {
 
 	var pki publicKeyInfo
 	if rest, err := asn1.Unmarshal(derBytes, &pki); err != nil {
-		return nil, err
+		return nil, flowerrors.Wrap(flowerrors.ErrDecoding, err)
 	} else if len(rest) != 0 {
-		return nil, errors.New("x509: trailing data after ASN.1 of public-key")
+		return nil, flowerrors.New(flowerrors.ErrDecoding, "x509: trailing data after ASN.1 of public-key")
 	}
 
 	// Only ECDSA is supported
 	if !pki.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
-		return nil, errors.New("x509: unknown public key algorithm")
+		return nil, flowerrors.New(flowerrors.ErrDecoding, "x509: unknown public key algorithm")
 	}
 
 	asn1Data := pki.PublicKey.RightAlign()
@@ -279,20 +312,20 @@ func x509ParseECDSAPublicKey(derBytes []byte) ([]byte, error) {
 	namedCurveOID := new(asn1.ObjectIdentifier)
 	rest, err := asn1.Unmarshal(paramsData, namedCurveOID)
 	if err != nil {
-		return nil, errors.New("x509: failed to parse ECDSA parameters as named curve")
+		return nil, flowerrors.New(flowerrors.ErrDecoding, "x509: failed to parse ECDSA parameters as named curve")
 	}
 	if len(rest) != 0 {
-		return nil, errors.New("x509: trailing data after ECDSA parameters")
+		return nil, flowerrors.New(flowerrors.ErrDecoding, "x509: trailing data after ECDSA parameters")
 	}
 
 	// Check the curve is supported
 	if !(namedCurveOID.Equal(oidNamedCurveP256) || namedCurveOID.Equal(oidNamedCurveSECP256K1)) {
-		return nil, errors.New("x509: unsupported elliptic curve")
+		return nil, flowerrors.New(flowerrors.ErrDecoding, "x509: unsupported elliptic curve")
 	}
 
 	// the candidate field length - this function doesn't check the length is valid
-	if asn1Data[0] != 4 { // uncompressed form
-		return nil, errors.New("x509: only uncompressed keys are supported")
+	if len(asn1Data) == 0 || asn1Data[0] != 4 { // uncompressed form
+		return nil, flowerrors.New(flowerrors.ErrDecoding, "x509: only uncompressed keys are supported")
 	}
 	return asn1Data[1:], nil
 }
@@ -24,12 +24,14 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/pem"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
 	fgcrypto "github.com/onflow/flow-go/crypto"
 )
 
@@ -71,7 +73,7 @@ func TestGeneratePrivateKey(t *testing.T) {
 
 			t.Run("Seed length too short", func(t *testing.T) {
 				sk, err := crypto.GeneratePrivateKey(sigAlgo, shortSeed)
-				assert.Error(t, err)
+				assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
 				assert.Nil(t, sk)
 			})
 
@@ -190,4 +192,67 @@ hbOhaN5CWXjw0HQAZeXqjoswiWlVH0baBuwAPwFcdk5fG/KW60QvOYPExA==
 
 		assert.Equal(t, expected[key], pk.String())
 	})
+
+	t.Run("Not PEM", func(t *testing.T) {
+		pk, err := crypto.DecodePublicKeyPEM(crypto.ECDSA_P256, "not a key")
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+		assert.Nil(t, pk)
+	})
+
+	t.Run("Malformed key", func(t *testing.T) {
+		pk, err := crypto.DecodePublicKeyPEM(crypto.ECDSA_P256, "-----BEGIN PUBLIC KEY-----\nbm90IGEga2V5\n-----END PUBLIC KEY-----\n")
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+		assert.Nil(t, pk)
+	})
+
+	t.Run("Unsupported algorithm", func(t *testing.T) {
+		pk, err := crypto.DecodePublicKeyPEM(fgcrypto.BLSBLS12381, pemECDSAKeySECP256K1)
+		assert.ErrorIs(t, err, flowerrors.ErrUnsupported)
+		assert.Nil(t, pk)
+	})
+}
+
+func TestDecodePublicKeyHex_Invalid(t *testing.T) {
+	for _, s := range []string{"zz", "00ff"} {
+		pk, err := crypto.DecodePublicKeyHex(crypto.ECDSA_P256, s)
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding, s)
+		assert.Nil(t, pk)
+	}
+}
+
+func TestInMemorySigner_Concurrent(t *testing.T) {
+	seed := make([]byte, crypto.MinSeedLength)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_secp256k1, seed)
+	require.NoError(t, err)
+
+	signer := crypto.NewInMemorySigner(privateKey, crypto.SHA3_256)
+	publicKey := privateKey.PublicKey()
+	message := []byte("hello world")
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 10; j++ {
+				signature, err := signer.Sign(message)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				valid, err := publicKey.Verify(signature, message, crypto.NewSHA3_256())
+				assert.NoError(t, err)
+				assert.True(t, valid)
+			}
+		}()
+	}
+
+	wg.Wait()
 }
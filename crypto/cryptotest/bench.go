@@ -0,0 +1,81 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cryptotest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// BenchmarkMessageSizes are the message sizes signed by BenchmarkSigner. They span a
+// typical transaction envelope up to one with a large script and arguments.
+var BenchmarkMessageSizes = []int{256, 1 << 10, 16 << 10}
+
+// BenchmarkSigner benchmarks a signer, so that signer backends such as a KMS or HSM
+// can be measured against each other and against the in-memory signer:
+//
+//	func BenchmarkKMSSigner(b *testing.B) {
+//		cryptotest.BenchmarkSigner(b, newKMSSigner(b))
+//	}
+//
+// Messages of each of BenchmarkMessageSizes are signed sequentially, and then from
+// GOMAXPROCS goroutines to measure the throughput of concurrent signing.
+func BenchmarkSigner(b *testing.B, signer crypto.Signer) {
+	for _, size := range BenchmarkMessageSizes {
+		message := benchmarkMessage(size)
+
+		b.Run(fmt.Sprintf("%dB", size), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(size))
+
+			for i := 0; i < b.N; i++ {
+				_, err := signer.Sign(message)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("%dB/parallel", size), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(size))
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_, err := signer.Sign(message)
+					if err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+func benchmarkMessage(size int) []byte {
+	message := make([]byte, size)
+	for i := range message {
+		message[i] = byte(i)
+	}
+
+	return message
+}
@@ -0,0 +1,78 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cryptotest
+
+import (
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// FlipBit returns a copy of the signature with the given bit inverted.
+//
+// Bit 0 is the most significant bit of the first byte.
+func FlipBit(signature []byte, bit int) []byte {
+	corrupted := append([]byte{}, signature...)
+	corrupted[bit/8] ^= 0x80 >> uint(bit%8)
+	return corrupted
+}
+
+// Truncate returns a copy of the signature without its last byte.
+func Truncate(signature []byte) []byte {
+	if len(signature) == 0 {
+		return []byte{}
+	}
+	return append([]byte{}, signature[:len(signature)-1]...)
+}
+
+// Zero returns an all-zero signature of the same length.
+func Zero(signature []byte) []byte {
+	return make([]byte, len(signature))
+}
+
+// SwapHalves returns a copy of the signature with its halves exchanged,
+// which for ECDSA swaps the r and s components.
+func SwapHalves(signature []byte) []byte {
+	half := len(signature) / 2
+	return append(append([]byte{}, signature[half:]...), signature[:half]...)
+}
+
+// A CorruptingSigner passes the signatures of a signer through a corruption function.
+type CorruptingSigner struct {
+	Signer  crypto.Signer
+	Corrupt func(signature []byte) []byte
+}
+
+// Sign signs the message with the underlying signer and returns the corrupted signature.
+func (s CorruptingSigner) Sign(message []byte) ([]byte, error) {
+	signature, err := s.Signer.Sign(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Corrupt(signature), nil
+}
+
+// A FailingSigner fails every signing request with an error.
+type FailingSigner struct {
+	Err error
+}
+
+// Sign returns the error of the signer.
+func (s FailingSigner) Sign(message []byte) ([]byte, error) {
+	return nil, s.Err
+}
@@ -0,0 +1,86 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cryptotest provides deterministic keys and signers for testing signature handling.
+//
+// Regular ECDSA signatures use a random nonce, so signing the same message twice
+// produces different signatures. The signers in this package derive the nonce from
+// the private key and message instead, so that signatures are reproducible across
+// runs and can be stored in golden files. Keys are derived from fixed seeds.
+//
+// Private keys and signers returned by this package must only be used in tests.
+package cryptotest
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// keySeedTag domain-separates the seeds of test keys.
+const keySeedTag = "FLOW-SDK-CRYPTOTEST-KEY"
+
+// PrivateKey returns the test private key with the given index for a signature algorithm.
+//
+// The same index always returns the same key.
+func PrivateKey(sigAlgo crypto.SignatureAlgorithm, index int) crypto.PrivateKey {
+	seed := sha256.New()
+	seed.Write([]byte(keySeedTag))
+	seed.Write([]byte(sigAlgo.String()))
+
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(index))
+	seed.Write(b[:])
+
+	privateKey, err := crypto.GeneratePrivateKey(sigAlgo, seed.Sum(nil))
+	if err != nil {
+		panic(fmt.Errorf("cryptotest: failed to generate %s key: %w", sigAlgo, err))
+	}
+
+	return privateKey
+}
+
+// PrivateKeys returns the first n test private keys for a signature algorithm.
+func PrivateKeys(sigAlgo crypto.SignatureAlgorithm, n int) []crypto.PrivateKey {
+	keys := make([]crypto.PrivateKey, n)
+	for i := range keys {
+		keys[i] = PrivateKey(sigAlgo, i)
+	}
+	return keys
+}
+
+// AccountKey returns a full-weight account key for the test private key with the given index,
+// and a deterministic signer for it.
+func AccountKey(sigAlgo crypto.SignatureAlgorithm, hashAlgo crypto.HashAlgorithm, index int) (*flow.AccountKey, *Signer) {
+	privateKey := PrivateKey(sigAlgo, index)
+
+	signer, err := NewSigner(privateKey, hashAlgo)
+	if err != nil {
+		panic(err)
+	}
+
+	accountKey := flow.NewAccountKey().
+		FromPrivateKey(privateKey).
+		SetHashAlgo(hashAlgo).
+		SetWeight(flow.AccountKeyWeightThreshold)
+
+	return accountKey, signer
+}
@@ -0,0 +1,171 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cryptotest_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/cryptotest"
+)
+
+func verify(t *testing.T, signer *cryptotest.Signer, signature []byte, message []byte) bool {
+	hasher, err := crypto.NewHasher(signer.HashAlgo())
+	require.NoError(t, err)
+
+	valid, err := signer.PublicKey().Verify(signature, message, hasher)
+	require.NoError(t, err)
+
+	return valid
+}
+
+func TestPrivateKey(t *testing.T) {
+	for _, sigAlgo := range []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1} {
+		a := cryptotest.PrivateKey(sigAlgo, 0)
+		b := cryptotest.PrivateKey(sigAlgo, 0)
+		c := cryptotest.PrivateKey(sigAlgo, 1)
+
+		assert.True(t, a.Equals(b))
+		assert.False(t, a.Equals(c))
+		assert.Equal(t, sigAlgo, a.Algorithm())
+	}
+
+	keys := cryptotest.PrivateKeys(crypto.ECDSA_P256, 3)
+	require.Len(t, keys, 3)
+	assert.True(t, keys[2].Equals(cryptotest.PrivateKey(crypto.ECDSA_P256, 2)))
+}
+
+func TestSigner(t *testing.T) {
+	message := []byte("hello world")
+
+	for _, sigAlgo := range []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1} {
+		for _, hashAlgo := range []crypto.HashAlgorithm{crypto.SHA2_256, crypto.SHA3_256} {
+			t.Run(sigAlgo.String()+"/"+hashAlgo.String(), func(t *testing.T) {
+				accountKey, signer := cryptotest.AccountKey(sigAlgo, hashAlgo, 7)
+
+				assert.Equal(t, signer.PublicKey().String(), accountKey.PublicKey.String())
+
+				a, err := signer.Sign(message)
+				require.NoError(t, err)
+
+				b, err := signer.Sign(message)
+				require.NoError(t, err)
+
+				assert.Equal(t, a, b)
+				assert.Len(t, a, 64)
+				assert.True(t, verify(t, signer, a, message))
+
+				other, err := signer.Sign([]byte("other"))
+				require.NoError(t, err)
+				assert.NotEqual(t, a, other)
+			})
+		}
+	}
+}
+
+// TestSigner_RFC6979 checks the test vector of RFC 6979, appendix A.2.5 (P-256, SHA-256, "sample").
+func TestSigner_RFC6979(t *testing.T) {
+	privateKey, err := crypto.DecodePrivateKeyHex(
+		crypto.ECDSA_P256,
+		"c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721",
+	)
+	require.NoError(t, err)
+
+	signer, err := cryptotest.NewSigner(privateKey, crypto.SHA2_256)
+	require.NoError(t, err)
+
+	signature, err := signer.Sign([]byte("sample"))
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		"efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716"+
+			"f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8",
+		hex.EncodeToString(signature),
+	)
+}
+
+func TestSigner_UnsupportedHash(t *testing.T) {
+	_, err := cryptotest.NewSigner(cryptotest.PrivateKey(crypto.ECDSA_P256, 0), crypto.UnknownHashAlgorithm)
+	assert.Error(t, err)
+}
+
+func TestCorruption(t *testing.T) {
+	message := []byte("hello world")
+
+	_, signer := cryptotest.AccountKey(crypto.ECDSA_P256, crypto.SHA3_256, 0)
+
+	signature, err := signer.Sign(message)
+	require.NoError(t, err)
+
+	corruptions := map[string]func([]byte) []byte{
+		"FlipBit":    func(sig []byte) []byte { return cryptotest.FlipBit(sig, 100) },
+		"Truncate":   cryptotest.Truncate,
+		"Zero":       cryptotest.Zero,
+		"SwapHalves": cryptotest.SwapHalves,
+	}
+
+	for name, corrupt := range corruptions {
+		t.Run(name, func(t *testing.T) {
+			corrupting := cryptotest.CorruptingSigner{Signer: signer, Corrupt: corrupt}
+
+			corrupted, err := corrupting.Sign(message)
+			require.NoError(t, err)
+
+			assert.False(t, verify(t, signer, corrupted, message))
+
+			// the original signature is left intact
+			assert.True(t, verify(t, signer, signature, message))
+		})
+	}
+
+	t.Run("FailingSigner", func(t *testing.T) {
+		expected := errors.New("device unavailable")
+
+		_, err := cryptotest.FailingSigner{Err: expected}.Sign(message)
+		assert.Equal(t, expected, err)
+	})
+}
+
+func BenchmarkSigners(b *testing.B) {
+	for _, sigAlgo := range []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1} {
+		privateKey := cryptotest.PrivateKey(sigAlgo, 0)
+
+		b.Run(sigAlgo.String()+"/deterministic", func(b *testing.B) {
+			signer, err := cryptotest.NewSigner(privateKey, crypto.SHA3_256)
+			require.NoError(b, err)
+
+			cryptotest.BenchmarkSigner(b, signer)
+		})
+	}
+
+	// The in-memory P-256 signer of the pinned flow-go/crypto release builds its
+	// ecdsa.PrivateKey without a public point, which recent Go releases reject, so
+	// only secp256k1 is compared against the in-memory signer.
+	b.Run("ECDSA_secp256k1/in-memory", func(b *testing.B) {
+		privateKey := cryptotest.PrivateKey(crypto.ECDSA_secp256k1, 0)
+
+		cryptotest.BenchmarkSigner(b, crypto.NewInMemorySigner(privateKey, crypto.SHA3_256))
+	})
+}
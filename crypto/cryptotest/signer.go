@@ -0,0 +1,221 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cryptotest
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// A Signer produces reproducible ECDSA signatures.
+//
+// The nonce of each signature is derived from the private key and the message hash
+// following RFC 6979, using HMAC-SHA-256. For keys hashing with SHA2-256, signatures
+// are therefore identical to those of other RFC 6979 implementations.
+//
+// Signatures are valid for the public key of the signer, and can be verified with
+// the standard Flow verification. Signer implements crypto.Signer.
+type Signer struct {
+	curve      elliptic.Curve
+	d          *big.Int
+	hashAlgo   crypto.HashAlgorithm
+	privateKey crypto.PrivateKey
+}
+
+var _ crypto.Signer = &Signer{}
+
+// NewSigner returns a deterministic signer for an ECDSA private key.
+func NewSigner(privateKey crypto.PrivateKey, hashAlgo crypto.HashAlgorithm) (*Signer, error) {
+	var curve elliptic.Curve
+
+	switch privateKey.Algorithm() {
+	case crypto.ECDSA_P256:
+		curve = elliptic.P256()
+	case crypto.ECDSA_secp256k1:
+		curve = btcec.S256()
+	default:
+		return nil, fmt.Errorf("cryptotest: unsupported signature algorithm %s", privateKey.Algorithm())
+	}
+
+	if _, err := crypto.NewHasher(hashAlgo); err != nil {
+		return nil, fmt.Errorf("cryptotest: %w", err)
+	}
+
+	return &Signer{
+		curve:      curve,
+		d:          new(big.Int).SetBytes(privateKey.Encode()),
+		hashAlgo:   hashAlgo,
+		privateKey: privateKey,
+	}, nil
+}
+
+// PrivateKey returns the private key of the signer.
+func (s *Signer) PrivateKey() crypto.PrivateKey {
+	return s.privateKey
+}
+
+// PublicKey returns the public key for signatures of the signer.
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return s.privateKey.PublicKey()
+}
+
+// HashAlgo returns the hash algorithm the signer hashes messages with.
+func (s *Signer) HashAlgo() crypto.HashAlgorithm {
+	return s.hashAlgo
+}
+
+// Sign signs the message, always returning the same signature for the same message.
+func (s *Signer) Sign(message []byte) ([]byte, error) {
+	hasher, err := crypto.NewHasher(s.hashAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("cryptotest: %w", err)
+	}
+
+	r, sig := s.signHash(hasher.ComputeHash(message))
+
+	size := (s.curve.Params().N.BitLen() + 7) / 8
+
+	return append(leftPad(r, size), leftPad(sig, size)...), nil
+}
+
+func (s *Signer) signHash(hash []byte) (*big.Int, *big.Int) {
+	n := s.curve.Params().N
+	e := hashToInt(hash, n)
+
+	nonces := newNonceGenerator(s.d, e, n)
+
+	for {
+		k := nonces.next()
+
+		x, _ := s.curve.ScalarBaseMult(k.Bytes())
+
+		r := new(big.Int).Mod(x, n)
+		if r.Sign() == 0 {
+			continue
+		}
+
+		// s = k^-1 (e + r d) mod n
+		sig := new(big.Int).Mul(r, s.d)
+		sig.Add(sig, e)
+		sig.Mul(sig, new(big.Int).ModInverse(k, n))
+		sig.Mod(sig, n)
+
+		if sig.Sign() == 0 {
+			continue
+		}
+
+		return r, sig
+	}
+}
+
+// hashToInt converts a hash to an integer as done by ECDSA, keeping the leftmost bits
+// of the hash up to the bit length of the curve order.
+func hashToInt(hash []byte, n *big.Int) *big.Int {
+	orderBits := n.BitLen()
+	orderBytes := (orderBits + 7) / 8
+
+	if len(hash) > orderBytes {
+		hash = hash[:orderBytes]
+	}
+
+	e := new(big.Int).SetBytes(hash)
+
+	excess := len(hash)*8 - orderBits
+	if excess > 0 {
+		e.Rsh(e, uint(excess))
+	}
+
+	return e
+}
+
+// nonceGenerator derives ECDSA nonces with the HMAC-DRBG of RFC 6979, section 3.2.
+type nonceGenerator struct {
+	n    *big.Int
+	k, v []byte
+}
+
+func newNonceGenerator(d *big.Int, e *big.Int, n *big.Int) *nonceGenerator {
+	size := (n.BitLen() + 7) / 8
+
+	x := leftPad(d, size)
+	h := leftPad(new(big.Int).Mod(e, n), size)
+
+	g := &nonceGenerator{
+		n: n,
+		k: make([]byte, sha256.Size),
+		v: make([]byte, sha256.Size),
+	}
+
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+
+	g.k = g.mac(g.v, []byte{0x00}, x, h)
+	g.v = g.mac(g.v)
+	g.k = g.mac(g.v, []byte{0x01}, x, h)
+	g.v = g.mac(g.v)
+
+	return g
+}
+
+func (g *nonceGenerator) mac(data ...[]byte) []byte {
+	m := hmac.New(sha256.New, g.k)
+	for _, d := range data {
+		m.Write(d)
+	}
+	return m.Sum(nil)
+}
+
+// next returns the next nonce candidate in [1, n-1].
+func (g *nonceGenerator) next() *big.Int {
+	size := (g.n.BitLen() + 7) / 8
+
+	for {
+		var t []byte
+		for len(t) < size {
+			g.v = g.mac(g.v)
+			t = append(t, g.v...)
+		}
+
+		k := hashToInt(t, g.n)
+
+		// prepare the state for the next candidate, whether or not this one is used
+		g.k = g.mac(g.v, []byte{0x00})
+		g.v = g.mac(g.v)
+
+		if k.Sign() > 0 && k.Cmp(g.n) < 0 {
+			return k
+		}
+	}
+}
+
+// leftPad returns the big-endian encoding of x, padded with zeros to size bytes.
+func leftPad(x *big.Int, size int) []byte {
+	b := x.Bytes()
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
@@ -19,9 +19,9 @@
 package crypto
 
 import (
-	"fmt"
-
 	"github.com/onflow/flow-go/crypto/hash"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
 )
 
 type Hasher = hash.Hasher
@@ -41,7 +41,7 @@ func NewHasher(algo HashAlgorithm) (Hasher, error) {
 	case SHA3_384:
 		return NewSHA3_384(), nil
 	default:
-		return nil, fmt.Errorf("invalid hash algorithm %s", algo)
+		return nil, flowerrors.Errorf(flowerrors.ErrUnsupported, "invalid hash algorithm %s", algo)
 	}
 }
 
@@ -0,0 +1,199 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulatortest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+// DefaultGasLimit is the gas limit of transactions sent by an Emulator.
+const DefaultGasLimit = 9999
+
+// An Account is an emulator account with a full-weight key and its signer.
+type Account struct {
+	Address flow.Address
+	Key     *flow.AccountKey
+	Signer  crypto.Signer
+}
+
+// CreateAccount creates an account with a new full-weight key and the given contracts.
+func (e *Emulator) CreateAccount(contracts ...templates.Contract) *Account {
+	e.t.Helper()
+
+	privateKey, err := generatePrivateKey()
+	if err != nil {
+		e.t.Fatalf("emulatortest: failed to generate key: %s", err)
+	}
+
+	accountKey := flow.NewAccountKey().
+		FromPrivateKey(privateKey).
+		SetHashAlgo(crypto.SHA3_256).
+		SetWeight(flow.AccountKeyWeightThreshold)
+
+	tx := templates.CreateAccount([]*flow.AccountKey{accountKey}, contracts, e.service.Address)
+
+	result := e.SendTransaction(tx)
+
+	for _, event := range result.Events {
+		if event.Type == flow.EventAccountCreated {
+			address, err := flow.AccountCreatedEvent(event).DecodeAddress()
+			if err != nil {
+				e.t.Fatalf("emulatortest: %s", err)
+			}
+
+			return &Account{
+				Address: address,
+				Key:     accountKey,
+				Signer:  crypto.NewInMemorySigner(privateKey, accountKey.HashAlgo),
+			}
+		}
+	}
+
+	e.t.Fatalf("emulatortest: transaction %s did not emit %s", tx.ID(), flow.EventAccountCreated)
+	return nil
+}
+
+// DeployContract deploys a contract to the account.
+func (e *Emulator) DeployContract(account *Account, contract templates.Contract) {
+	e.t.Helper()
+
+	e.SendTransaction(templates.AddAccountContract(account.Address, contract), account)
+}
+
+// UpdateContract updates a contract deployed to the account.
+func (e *Emulator) UpdateContract(account *Account, contract templates.Contract) {
+	e.t.Helper()
+
+	e.SendTransaction(templates.UpdateAccountContract(account.Address, contract), account)
+}
+
+// FundAccount mints FLOW to the account with the given address.
+func (e *Emulator) FundAccount(address flow.Address, amount cadence.UFix64) {
+	e.t.Helper()
+
+	script, err := templates.Get("mint_flow", flow.Emulator)
+	if err != nil {
+		e.t.Fatalf("emulatortest: %s", err)
+	}
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(script)).
+		AddAuthorizer(e.service.Address)
+
+	for _, arg := range []cadence.Value{cadence.NewAddress(address), amount} {
+		err := tx.AddArgument(arg)
+		if err != nil {
+			e.t.Fatalf("emulatortest: failed to add argument: %s", err)
+		}
+	}
+
+	e.SendTransaction(tx)
+}
+
+// SendTransaction sends a transaction, waits for it to be sealed and returns its result.
+//
+// The service account proposes and pays for the transaction, and each given account
+// signs the payload. The test fails if the transaction cannot be sent or the
+// transaction fails.
+func (e *Emulator) SendTransaction(tx *flow.Transaction, signers ...*Account) *flow.TransactionResult {
+	e.t.Helper()
+
+	result, err := e.Submit(tx, signers...)
+	if err != nil {
+		e.t.Fatalf("emulatortest: %s", err)
+	}
+
+	if result.Error != nil {
+		e.t.Fatalf("emulatortest: transaction %s failed: %s", tx.ID(), result.Error)
+	}
+
+	return result
+}
+
+// Submit behaves like SendTransaction, but returns the result of a failed transaction
+// instead of failing the test, so that tests can assert on expected failures.
+func (e *Emulator) Submit(tx *flow.Transaction, signers ...*Account) (*flow.TransactionResult, error) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+
+	ctx := context.Background()
+
+	serviceAccount, err := e.client.GetAccount(ctx, e.service.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	latest, err := e.client.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	tx.
+		SetReferenceBlockID(latest.ID).
+		SetProposalKey(e.service.Address, e.service.Key.Index, serviceAccount.Keys[0].SequenceNumber).
+		SetPayer(e.service.Address)
+
+	if tx.GasLimit == 0 {
+		tx.SetGasLimit(DefaultGasLimit)
+	}
+
+	for _, signer := range signers {
+		if signer.Address == e.service.Address {
+			continue
+		}
+
+		err = tx.SignPayload(signer.Address, signer.Key.Index, signer.Signer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign transaction as %s: %w", signer.Address, err)
+		}
+	}
+
+	err = tx.SignEnvelope(e.service.Address, e.service.Key.Index, e.service.Signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction as service account: %w", err)
+	}
+
+	err = e.client.SendTransaction(ctx, *tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	return e.sealTransaction(ctx, tx.ID())
+}
+
+// ExecuteScript executes a script against the latest sealed block and returns its result.
+//
+// The test fails if the script cannot be executed.
+func (e *Emulator) ExecuteScript(script string, arguments ...cadence.Value) cadence.Value {
+	e.t.Helper()
+
+	value, err := e.client.ExecuteScriptAtLatestBlock(context.Background(), []byte(script), arguments)
+	if err != nil {
+		e.t.Fatalf("emulatortest: failed to execute script: %s", err)
+	}
+
+	return value
+}
@@ -0,0 +1,149 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulatortest
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// MaxSealBlocks is the number of blocks committed while waiting for a
+// transaction to be sealed before giving up.
+const MaxSealBlocks = 10
+
+// CommitBlock forces the emulator to execute its pending transactions and commit
+// a block, and returns the header of the new latest block.
+//
+// The test fails if the block cannot be committed.
+func (e *Emulator) CommitBlock() *flow.BlockHeader {
+	e.t.Helper()
+
+	return e.CommitBlocks(1)
+}
+
+// CommitBlocks commits n blocks and returns the header of the new latest block.
+//
+// The test fails if the blocks cannot be committed.
+func (e *Emulator) CommitBlocks(n int) *flow.BlockHeader {
+	e.t.Helper()
+
+	ctx := context.Background()
+
+	for i := 0; i < n; i++ {
+		err := e.commitBlock(ctx)
+		if err != nil {
+			e.t.Fatalf("emulatortest: %s", err)
+		}
+	}
+
+	header, err := e.client.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		e.t.Fatalf("emulatortest: failed to get latest block: %s", err)
+	}
+
+	return header
+}
+
+// AdvanceToHeight commits blocks until the latest block is at least at the given
+// height, and returns its header.
+//
+// The test fails if the blocks cannot be committed.
+func (e *Emulator) AdvanceToHeight(height uint64) *flow.BlockHeader {
+	e.t.Helper()
+
+	header, err := e.client.GetLatestBlockHeader(context.Background(), true)
+	if err != nil {
+		e.t.Fatalf("emulatortest: failed to get latest block: %s", err)
+	}
+
+	if header.Height >= height {
+		return header
+	}
+
+	return e.CommitBlocks(int(height - header.Height))
+}
+
+// SealTransaction commits blocks until a transaction sent to the emulator is
+// sealed, and returns its result.
+//
+// The test fails if the transaction is not sealed after MaxSealBlocks blocks. A
+// failed transaction does not fail the test.
+func (e *Emulator) SealTransaction(txID flow.Identifier) *flow.TransactionResult {
+	e.t.Helper()
+
+	result, err := e.sealTransaction(context.Background(), txID)
+	if err != nil {
+		e.t.Fatalf("emulatortest: %s", err)
+	}
+
+	return result
+}
+
+func (e *Emulator) sealTransaction(ctx context.Context, txID flow.Identifier) (*flow.TransactionResult, error) {
+	for i := 0; ; i++ {
+		result, err := e.client.GetTransactionResult(ctx, txID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get result of transaction %s: %w", txID, err)
+		}
+
+		if result.Status == flow.TransactionStatusSealed {
+			return result, nil
+		}
+
+		if i == MaxSealBlocks {
+			return nil, fmt.Errorf("transaction %s not sealed after %d blocks", txID, MaxSealBlocks)
+		}
+
+		err = e.commitBlock(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// commitBlock commits a block through the emulator admin API.
+func (e *Emulator) commitBlock(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.admin+"/emulator/newBlock", nil)
+	if err != nil {
+		return fmt.Errorf("failed to commit block: %w", err)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		select {
+		case <-e.exited:
+			return fmt.Errorf("emulator exited before committing block")
+		default:
+		}
+
+		return fmt.Errorf("failed to commit block: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("failed to commit block: %s: %s", res.Status, body)
+	}
+
+	return nil
+}
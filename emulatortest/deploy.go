@@ -0,0 +1,211 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulatortest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+// contractPattern matches the declaration of a contract or contract interface.
+var contractPattern = regexp.MustCompile(`(?m)^\s*(?:pub|access\(all\))?\s*contract\s+(?:interface\s+)?([A-Za-z_]\w*)`)
+
+// CoreContracts returns the addresses of the core contracts deployed to the emulator.
+func CoreContracts() map[string]flow.Address {
+	return map[string]flow.Address{
+		"FungibleToken": FungibleTokenAddress,
+		"FlowToken":     FlowTokenAddress,
+	}
+}
+
+// LoadContracts reads the contracts in the .cdc files of a directory and returns
+// them in dependency order, so that each contract comes after the contracts it
+// imports.
+//
+// The name of each contract is taken from its declaration. Imports of contracts
+// that are not in the directory are ignored for ordering. An error is returned if
+// a file does not declare a contract, two files declare the same contract or the
+// imports are cyclic.
+func LoadContracts(dir string) ([]templates.Contract, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.cdc"))
+	if err != nil {
+		return nil, fmt.Errorf("emulatortest: %w", err)
+	}
+
+	contracts := make(map[string]templates.Contract, len(paths))
+	names := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		source, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("emulatortest: %w", err)
+		}
+
+		match := contractPattern.FindSubmatch(source)
+		if match == nil {
+			return nil, fmt.Errorf("emulatortest: %s does not declare a contract", path)
+		}
+
+		name := string(match[1])
+		if _, ok := contracts[name]; ok {
+			return nil, fmt.Errorf("emulatortest: contract %s is declared more than once in %s", name, dir)
+		}
+
+		contracts[name] = templates.Contract{Name: name, Source: string(source)}
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(names))
+	ordered := make([]templates.Contract, 0, len(names))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("emulatortest: cyclic imports: %s", strings.Join(append(path, name), " -> "))
+		}
+
+		state[name] = visiting
+
+		for _, dependency := range templates.Imports(contracts[name].Source) {
+			if _, ok := contracts[dependency]; !ok {
+				continue
+			}
+
+			err := visit(dependency, append(path, name))
+			if err != nil {
+				return err
+			}
+		}
+
+		state[name] = visited
+		ordered = append(ordered, contracts[name])
+
+		return nil
+	}
+
+	for _, name := range names {
+		err := visit(name, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// ResolveImports rewrites the imports of the named contracts in code to import
+// them from the given addresses.
+//
+// It is an alias of templates.ResolveImports.
+func ResolveImports(code string, addresses map[string]flow.Address) string {
+	return templates.ResolveImports(code, addresses)
+}
+
+// A Deployment is a set of contracts deployed to an emulator account.
+type Deployment struct {
+	Account   *Account
+	Contracts []templates.Contract
+	t         testing.TB
+	addresses map[string]flow.Address
+}
+
+// Address returns the address a contract is deployed to. The core contracts of the
+// emulator are included.
+//
+// The test fails if the contract is unknown.
+func (d *Deployment) Address(name string) flow.Address {
+	d.t.Helper()
+
+	address, ok := d.addresses[name]
+	if !ok {
+		d.t.Fatalf("emulatortest: contract %s is not deployed", name)
+	}
+
+	return address
+}
+
+// Addresses returns the addresses of all deployed contracts by name, including the
+// core contracts of the emulator.
+func (d *Deployment) Addresses() map[string]flow.Address {
+	addresses := make(map[string]flow.Address, len(d.addresses))
+	for name, address := range d.addresses {
+		addresses[name] = address
+	}
+
+	return addresses
+}
+
+// Resolve rewrites the imports of a script or transaction to the deployed
+// addresses.
+func (d *Deployment) Resolve(code string) string {
+	return ResolveImports(code, d.addresses)
+}
+
+// DeployContracts deploys the contracts in the .cdc files of a directory to the
+// account in dependency order, rewriting imports of each other and of the core
+// contracts to their addresses.
+//
+// The test fails if the contracts cannot be loaded or deployed.
+func (e *Emulator) DeployContracts(account *Account, dir string) *Deployment {
+	e.t.Helper()
+
+	contracts, err := LoadContracts(dir)
+	if err != nil {
+		e.t.Fatalf("%s", err)
+	}
+
+	d := &Deployment{
+		Account:   account,
+		Contracts: make([]templates.Contract, len(contracts)),
+		t:         e.t,
+		addresses: CoreContracts(),
+	}
+
+	for _, contract := range contracts {
+		d.addresses[contract.Name] = account.Address
+	}
+
+	for i, contract := range contracts {
+		contract.Source = d.Resolve(contract.Source)
+		d.Contracts[i] = contract
+
+		e.DeployContract(account, contract)
+	}
+
+	return d
+}
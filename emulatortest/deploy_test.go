@@ -0,0 +1,153 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulatortest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/emulatortest"
+)
+
+func TestLoadContracts(t *testing.T) {
+	t.Run("Dependency order", func(t *testing.T) {
+		contracts, err := emulatortest.LoadContracts("testdata/contracts")
+		require.NoError(t, err)
+
+		names := make([]string, len(contracts))
+		for i, contract := range contracts {
+			names[i] = contract.Name
+		}
+
+		assert.Equal(t, []string{"Token", "Kitty", "Market"}, names)
+	})
+
+	write := func(t *testing.T, files map[string]string) string {
+		dir, err := ioutil.TempDir("", "emulatortest")
+		require.NoError(t, err)
+		t.Cleanup(func() { os.RemoveAll(dir) })
+
+		for name, source := range files {
+			err := ioutil.WriteFile(filepath.Join(dir, name), []byte(source), 0600)
+			require.NoError(t, err)
+		}
+
+		return dir
+	}
+
+	t.Run("Cyclic imports", func(t *testing.T) {
+		dir := write(t, map[string]string{
+			"A.cdc": "import B from 0x01\npub contract A {}",
+			"B.cdc": "import A from 0x01\npub contract B {}",
+		})
+
+		_, err := emulatortest.LoadContracts(dir)
+		assert.EqualError(t, err, "emulatortest: cyclic imports: A -> B -> A")
+	})
+
+	t.Run("Duplicate contract", func(t *testing.T) {
+		dir := write(t, map[string]string{
+			"A.cdc": "pub contract A {}",
+			"B.cdc": "pub contract A {}",
+		})
+
+		_, err := emulatortest.LoadContracts(dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("No contract", func(t *testing.T) {
+		dir := write(t, map[string]string{
+			"main.cdc": "pub fun main() {}",
+		})
+
+		_, err := emulatortest.LoadContracts(dir)
+		assert.Error(t, err)
+	})
+}
+
+func TestResolveImports(t *testing.T) {
+	addresses := map[string]flow.Address{
+		"Foo": flow.HexToAddress("01"),
+		"Bar": flow.HexToAddress("02"),
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		expected string
+	}{
+		{
+			name:     "Address",
+			code:     "import Foo from 0xf8d6e0586b0a20c7\n",
+			expected: "import Foo from 0x0000000000000001\n",
+		},
+		{
+			name:     "File",
+			code:     "\timport Foo from \"../contracts/Foo.cdc\"\n",
+			expected: "\timport Foo from 0x0000000000000001\n",
+		},
+		{
+			name:     "String",
+			code:     "import \"Bar\"\n",
+			expected: "import Bar from 0x0000000000000002\n",
+		},
+		{
+			name:     "Multiple",
+			code:     "import Foo, Bar from 0x01\n",
+			expected: "import Foo from 0x0000000000000001\nimport Bar from 0x0000000000000002\n",
+		},
+		{
+			name:     "Unknown",
+			code:     "import Foo, Baz from 0x03\nimport \"Baz\"\n",
+			expected: "import Foo, Baz from 0x03\nimport \"Baz\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, emulatortest.ResolveImports(tt.code, addresses))
+		})
+	}
+}
+
+func TestEmulator_DeployContracts(t *testing.T) {
+	emulator := emulatortest.Start(t)
+
+	account := emulator.CreateAccount()
+	deployment := emulator.DeployContracts(account, "testdata/contracts")
+
+	assert.Equal(t, account.Address, deployment.Address("Market"))
+	assert.Equal(t, emulatortest.FlowTokenAddress, deployment.Address("FlowToken"))
+
+	price := emulator.ExecuteScript(deployment.Resolve(`
+		import Market from "../contracts/Market.cdc"
+
+		pub fun main(): UFix64 {
+			return Market.price()
+		}
+	`))
+	assert.Equal(t, cadence.UFix64(3_00000000), price)
+}
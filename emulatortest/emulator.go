@@ -0,0 +1,373 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package emulatortest runs a Flow emulator for the duration of a test.
+//
+// The emulator is started as a child process from the Flow CLI binary, listening on
+// free local ports with a freshly generated service account key, so tests can run in
+// parallel and no flow.json has to be maintained by hand:
+//
+//	func TestTransfer(t *testing.T) {
+//		emulator := emulatortest.Start(t)
+//
+//		alice := emulator.CreateAccount()
+//		emulator.DeployContract(alice, templates.Contract{Name: "Foo", Source: fooSource})
+//
+//		result := emulator.SendTransaction(tx, alice)
+//		...
+//	}
+//
+// Transactions sent through an Emulator are sealed by forcing the emulator to commit
+// blocks rather than by sleeping, and WithManualBlocks leaves block production
+// entirely to the test.
+//
+// Tests are skipped if the Flow CLI is not installed.
+package emulatortest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/systemcontracts"
+)
+
+// BinaryEnv is the environment variable that overrides the path of the Flow CLI binary.
+const BinaryEnv = "FLOW_CLI"
+
+// DefaultBinary is the name of the Flow CLI binary looked up in PATH.
+const DefaultBinary = "flow"
+
+// DefaultStartTimeout is the default time to wait for the emulator to accept requests.
+const DefaultStartTimeout = 30 * time.Second
+
+// Addresses of the core contracts deployed to the emulator.
+var (
+	FungibleTokenAddress = systemcontracts.MustForChain(flow.Emulator).FungibleToken.Address
+	FlowTokenAddress     = systemcontracts.MustForChain(flow.Emulator).FlowToken.Address
+)
+
+// An Emulator is a running Flow emulator.
+type Emulator struct {
+	t       testing.TB
+	client  *client.Client
+	admin   string
+	service *Account
+	cmd     *exec.Cmd
+	exited  chan struct{}
+
+	// mut serializes transactions, which are all proposed by the service account
+	mut sync.Mutex
+}
+
+type config struct {
+	binary       string
+	args         []string
+	startTimeout time.Duration
+}
+
+// An Option configures the emulator started by Start.
+type Option func(*config)
+
+// WithBinary sets the path of the Flow CLI binary.
+func WithBinary(path string) Option {
+	return func(c *config) {
+		c.binary = path
+	}
+}
+
+// WithArgs passes additional command line arguments to the emulator, e.g. "--block-time=1s".
+func WithArgs(args ...string) Option {
+	return func(c *config) {
+		c.args = append(c.args, args...)
+	}
+}
+
+// WithManualBlocks makes the emulator produce blocks only when a test forces them,
+// with CommitBlock or by sending a transaction, instead of on every transaction.
+func WithManualBlocks() Option {
+	return WithArgs("--block-time", manualBlockTime.String())
+}
+
+// manualBlockTime is a block interval long enough that the emulator never produces
+// blocks on its own during a test.
+const manualBlockTime = 24 * time.Hour
+
+// WithStartTimeout sets the time to wait for the emulator to accept requests.
+func WithStartTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.startTimeout = timeout
+	}
+}
+
+// Start starts an emulator and stops it when the test and its subtests complete.
+//
+// The Flow CLI binary is taken from the FLOW_CLI environment variable, or looked up in
+// PATH. The test is skipped if the binary cannot be found, and fails if the emulator
+// does not start.
+func Start(t testing.TB, opts ...Option) *Emulator {
+	t.Helper()
+
+	cfg := config{
+		binary:       os.Getenv(BinaryEnv),
+		startTimeout: DefaultStartTimeout,
+	}
+
+	if cfg.binary == "" {
+		cfg.binary = DefaultBinary
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	binary, err := exec.LookPath(cfg.binary)
+	if err != nil {
+		t.Skipf("emulatortest: Flow CLI not found, set %s to its path: %s", BinaryEnv, err)
+		return nil
+	}
+
+	privateKey, err := generatePrivateKey()
+	if err != nil {
+		t.Fatalf("emulatortest: failed to generate service key: %s", err)
+	}
+
+	ports, err := freePorts(3)
+	if err != nil {
+		t.Fatalf("emulatortest: failed to allocate ports: %s", err)
+	}
+
+	grpcPort, restPort, adminPort := ports[0], ports[1], ports[2]
+
+	dir, err := ioutil.TempDir("", "emulatortest")
+	if err != nil {
+		t.Fatalf("emulatortest: failed to create directory: %s", err)
+	}
+
+	serviceAddress := flow.ServiceAddress(flow.Emulator)
+
+	err = writeConfig(filepath.Join(dir, "flow.json"), serviceAddress, privateKey, grpcPort)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("emulatortest: failed to write configuration: %s", err)
+	}
+
+	args := append([]string{
+		"emulator",
+		"--port", strconv.Itoa(grpcPort),
+		"--rest-port", strconv.Itoa(restPort),
+		"--admin-port", strconv.Itoa(adminPort),
+	}, cfg.args...)
+
+	cmd := exec.Command(binary, args...)
+	cmd.Dir = dir
+
+	logs, err := os.Create(filepath.Join(dir, "emulator.log"))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("emulatortest: failed to create log file: %s", err)
+	}
+
+	cmd.Stdout = logs
+	cmd.Stderr = logs
+
+	err = cmd.Start()
+	if err != nil {
+		logs.Close()
+		os.RemoveAll(dir)
+		t.Fatalf("emulatortest: failed to start emulator: %s", err)
+	}
+
+	e := &Emulator{
+		t:      t,
+		admin:  fmt.Sprintf("http://127.0.0.1:%d", adminPort),
+		cmd:    cmd,
+		exited: make(chan struct{}),
+	}
+
+	go func() {
+		_ = cmd.Wait()
+		close(e.exited)
+	}()
+
+	t.Cleanup(func() {
+		e.stop()
+		logs.Close()
+
+		if t.Failed() {
+			// keep the emulator output around to debug the failure
+			if output, err := ioutil.ReadFile(logs.Name()); err == nil {
+				t.Logf("emulatortest: emulator output:\n%s", output)
+			}
+		}
+
+		os.RemoveAll(dir)
+	})
+
+	e.client, err = e.connect(fmt.Sprintf("127.0.0.1:%d", grpcPort), cfg.startTimeout)
+	if err != nil {
+		t.Fatalf("emulatortest: %s", err)
+	}
+
+	hashAlgo := crypto.SHA3_256
+
+	e.service = &Account{
+		Address: serviceAddress,
+		Key: &flow.AccountKey{
+			Index:     0,
+			PublicKey: privateKey.PublicKey(),
+			SigAlgo:   privateKey.Algorithm(),
+			HashAlgo:  hashAlgo,
+			Weight:    flow.AccountKeyWeightThreshold,
+		},
+		Signer: crypto.NewInMemorySigner(privateKey, hashAlgo),
+	}
+
+	return e
+}
+
+// connect waits until the emulator accepts requests on the given address.
+func (e *Emulator) connect(address string, timeout time.Duration) (*client.Client, error) {
+	c, err := client.New(address, grpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err = c.Ping(ctx)
+		cancel()
+
+		if err == nil {
+			return c, nil
+		}
+
+		select {
+		case <-e.exited:
+			c.Close()
+			return nil, fmt.Errorf("emulator exited before accepting requests")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		if time.Now().After(deadline) {
+			c.Close()
+			return nil, fmt.Errorf("emulator did not accept requests within %s: %w", timeout, err)
+		}
+	}
+}
+
+// stop interrupts the emulator process, and kills it if it does not exit in time.
+func (e *Emulator) stop() {
+	if e.client != nil {
+		e.client.Close()
+	}
+
+	_ = e.cmd.Process.Signal(os.Interrupt)
+
+	select {
+	case <-e.exited:
+	case <-time.After(5 * time.Second):
+		_ = e.cmd.Process.Kill()
+		<-e.exited
+	}
+}
+
+// Client returns a client connected to the emulator.
+func (e *Emulator) Client() *client.Client {
+	return e.client
+}
+
+// ServiceAccount returns the funded service account of the emulator.
+func (e *Emulator) ServiceAccount() *Account {
+	return e.service
+}
+
+func generatePrivateKey() (crypto.PrivateKey, error) {
+	seed := make([]byte, crypto.MinSeedLength)
+
+	_, err := rand.Read(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.GeneratePrivateKey(crypto.ECDSA_P256, seed)
+}
+
+// freePorts returns n distinct ports that are currently free on the loopback interface.
+func freePorts(n int) ([]int, error) {
+	ports := make([]int, n)
+
+	for i := range ports {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return nil, err
+		}
+		// keep the listener open until all ports are allocated, so they are distinct
+		defer l.Close()
+
+		ports[i] = l.Addr().(*net.TCPAddr).Port
+	}
+
+	return ports, nil
+}
+
+// writeConfig writes a flow.json configuring the emulator service account.
+func writeConfig(path string, serviceAddress flow.Address, privateKey crypto.PrivateKey, port int) error {
+	conf := map[string]interface{}{
+		"emulators": map[string]interface{}{
+			"default": map[string]interface{}{
+				"port":           port,
+				"serviceAccount": "emulator-account",
+			},
+		},
+		"networks": map[string]string{
+			"emulator": fmt.Sprintf("127.0.0.1:%d", port),
+		},
+		"accounts": map[string]interface{}{
+			"emulator-account": map[string]interface{}{
+				"address": serviceAddress.Hex(),
+				"key":     hex.EncodeToString(privateKey.Encode()),
+			},
+		},
+	}
+
+	b, err := json.MarshalIndent(conf, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, b, 0600)
+}
@@ -0,0 +1,130 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulatortest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/emulatortest"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+// skipRecorder records whether a test was skipped instead of stopping it.
+type skipRecorder struct {
+	testing.TB
+	skipped bool
+}
+
+func (r *skipRecorder) Skipf(format string, args ...interface{}) {
+	r.skipped = true
+}
+
+func TestStart_MissingBinary(t *testing.T) {
+	recorder := &skipRecorder{TB: t}
+
+	emulator := emulatortest.Start(recorder, emulatortest.WithBinary("flow-cli-does-not-exist"))
+
+	assert.True(t, recorder.skipped)
+	assert.Nil(t, emulator)
+}
+
+const counterContract = `
+pub contract Counter {
+	pub var count: Int
+
+	pub fun increment() {
+		self.count = self.count + 1
+	}
+
+	init() {
+		self.count = 0
+	}
+}
+`
+
+func TestEmulator(t *testing.T) {
+	emulator := emulatortest.Start(t)
+
+	account := emulator.CreateAccount()
+	emulator.FundAccount(account.Address, cadence.UFix64(10_00000000))
+	emulator.DeployContract(account, templates.Contract{Name: "Counter", Source: counterContract})
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(fmt.Sprintf(`
+			import Counter from 0x%s
+
+			transaction {
+				prepare(signer: AuthAccount) {}
+				execute { Counter.increment() }
+			}`,
+			account.Address.Hex(),
+		))).
+		AddAuthorizer(account.Address)
+
+	emulator.SendTransaction(tx, account)
+
+	count := emulator.ExecuteScript(fmt.Sprintf(`
+		import Counter from 0x%s
+
+		pub fun main(): Int {
+			return Counter.count
+		}`,
+		account.Address.Hex(),
+	))
+	assert.Equal(t, cadence.NewInt(1), count)
+
+	t.Run("Failed transaction", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript([]byte(`transaction { execute { panic("expected") } }`))
+
+		result, err := emulator.Submit(tx)
+		require.NoError(t, err)
+		assert.Error(t, result.Error)
+	})
+}
+
+func TestEmulator_ManualBlocks(t *testing.T) {
+	emulator := emulatortest.Start(t, emulatortest.WithManualBlocks())
+
+	start := emulator.CommitBlock()
+
+	header := emulator.CommitBlocks(3)
+	assert.Equal(t, start.Height+3, header.Height)
+
+	header = emulator.AdvanceToHeight(start.Height + 5)
+	assert.Equal(t, start.Height+5, header.Height)
+
+	header = emulator.AdvanceToHeight(start.Height)
+	assert.Equal(t, start.Height+5, header.Height)
+
+	// transactions are sealed by committing blocks rather than waiting for the block timer
+	tx := flow.NewTransaction().
+		SetScript([]byte(`transaction { execute { log("sealed") } }`))
+
+	emulator.SendTransaction(tx)
+
+	result := emulator.SealTransaction(tx.ID())
+	assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+}
@@ -0,0 +1,190 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulatortest
+
+import (
+	"context"
+	"regexp"
+
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/systemcontracts"
+)
+
+// A TransactionSource provides sealed transactions to replay, such as a client
+// connected to a mainnet access node.
+type TransactionSource interface {
+	GetTransaction(ctx context.Context, txID flow.Identifier, opts ...grpc.CallOption) (*flow.Transaction, error)
+	GetTransactionResult(ctx context.Context, txID flow.Identifier, opts ...grpc.CallOption) (*flow.TransactionResult, error)
+}
+
+// NetworkCoreContracts maps the addresses of the core contracts on Mainnet and
+// Testnet to their addresses on the emulator.
+var NetworkCoreContracts = map[flow.Address]flow.Address{
+	// Mainnet
+	systemcontracts.MustForChain(flow.Mainnet).FungibleToken.Address: FungibleTokenAddress,
+	systemcontracts.MustForChain(flow.Mainnet).FlowToken.Address:     FlowTokenAddress,
+	// Testnet
+	systemcontracts.MustForChain(flow.Testnet).FungibleToken.Address: FungibleTokenAddress,
+	systemcontracts.MustForChain(flow.Testnet).FlowToken.Address:     FlowTokenAddress,
+}
+
+// addressPattern matches an address literal in Cadence code or JSON-Cadence arguments.
+var addressPattern = regexp.MustCompile(`0x[0-9a-fA-F]{1,16}\b`)
+
+// SubstituteAddresses replaces the address literals in Cadence code or a
+// JSON-Cadence encoded argument that have an entry in addresses.
+func SubstituteAddresses(code []byte, addresses map[flow.Address]flow.Address) []byte {
+	return addressPattern.ReplaceAllFunc(code, func(literal []byte) []byte {
+		replacement, ok := addresses[flow.HexToAddress(string(literal))]
+		if !ok {
+			return literal
+		}
+
+		return []byte("0x" + replacement.Hex())
+	})
+}
+
+// A Replay is a transaction fetched from a network and replayed on the emulator.
+type Replay struct {
+	// Original is the transaction as it was sent to the network.
+	Original *flow.Transaction
+	// OriginalResult is the result of the transaction on the network.
+	OriginalResult *flow.TransactionResult
+	// Transaction is the transaction sent to the emulator.
+	Transaction *flow.Transaction
+	// Result is the result of the transaction on the emulator.
+	Result *flow.TransactionResult
+	// Accounts maps each original authorizer to the account that authorized the
+	// replayed transaction in its place.
+	Accounts map[flow.Address]*Account
+}
+
+type replayConfig struct {
+	accounts  map[flow.Address]*Account
+	addresses map[flow.Address]flow.Address
+}
+
+// A ReplayOption configures ReplayTransaction.
+type ReplayOption func(*replayConfig)
+
+// WithAccount authorizes a replayed transaction with account in place of the
+// original authorizer.
+func WithAccount(original flow.Address, account *Account) ReplayOption {
+	return func(c *replayConfig) {
+		c.accounts[original] = account
+	}
+}
+
+// WithAddress replaces an address in the script and arguments of a replayed
+// transaction, for example the address of a contract deployed to the emulator.
+func WithAddress(original, replacement flow.Address) ReplayOption {
+	return func(c *replayConfig) {
+		c.addresses[original] = replacement
+	}
+}
+
+// ReplayTransaction fetches a sealed transaction from source and sends its script
+// and arguments to the emulator, to reproduce its behaviour in a test.
+//
+// Each original authorizer is replaced by the account given with WithAccount, or by
+// a new account. The addresses of the authorizers, of the core contracts (see
+// NetworkCoreContracts) and those given with WithAddress are substituted in the
+// script and arguments. The test fails if the transaction cannot be fetched or
+// sent, but not if the replayed transaction fails, so the failure can be asserted.
+func (e *Emulator) ReplayTransaction(
+	source TransactionSource,
+	txID flow.Identifier,
+	opts ...ReplayOption,
+) *Replay {
+	e.t.Helper()
+
+	cfg := replayConfig{
+		accounts:  make(map[flow.Address]*Account),
+		addresses: make(map[flow.Address]flow.Address),
+	}
+
+	for original, replacement := range NetworkCoreContracts {
+		cfg.addresses[original] = replacement
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	ctx := context.Background()
+
+	original, err := source.GetTransaction(ctx, txID)
+	if err != nil {
+		e.t.Fatalf("emulatortest: failed to get transaction %s: %s", txID, err)
+	}
+
+	originalResult, err := source.GetTransactionResult(ctx, txID)
+	if err != nil {
+		e.t.Fatalf("emulatortest: failed to get result of transaction %s: %s", txID, err)
+	}
+
+	if originalResult.Status != flow.TransactionStatusSealed {
+		e.t.Fatalf("emulatortest: transaction %s is %s, not sealed", txID, originalResult.Status)
+	}
+
+	replay := &Replay{
+		Original:       original,
+		OriginalResult: originalResult,
+		Accounts:       make(map[flow.Address]*Account, len(original.Authorizers)),
+	}
+
+	signers := make([]*Account, 0, len(original.Authorizers))
+
+	for _, authorizer := range original.Authorizers {
+		account, ok := cfg.accounts[authorizer]
+		if !ok {
+			account = e.CreateAccount()
+		}
+
+		if _, ok := cfg.addresses[authorizer]; !ok {
+			cfg.addresses[authorizer] = account.Address
+		}
+
+		replay.Accounts[authorizer] = account
+		signers = append(signers, account)
+	}
+
+	tx := flow.NewTransaction().
+		SetScript(SubstituteAddresses(original.Script, cfg.addresses)).
+		SetGasLimit(original.GasLimit)
+
+	for _, argument := range original.Arguments {
+		tx.AddRawArgument(SubstituteAddresses(argument, cfg.addresses))
+	}
+
+	for _, account := range signers {
+		tx.AddAuthorizer(account.Address)
+	}
+
+	replay.Transaction = tx
+
+	replay.Result, err = e.Submit(tx, signers...)
+	if err != nil {
+		e.t.Fatalf("emulatortest: failed to replay transaction %s: %s", txID, err)
+	}
+
+	return replay
+}
@@ -0,0 +1,98 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulatortest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/accesstest"
+	"github.com/onflow/flow-go-sdk/emulatortest"
+)
+
+func TestSubstituteAddresses(t *testing.T) {
+	addresses := map[flow.Address]flow.Address{
+		flow.HexToAddress("f233dcee88fe0abe"): flow.HexToAddress("ee82856bf20e2aa6"),
+		flow.HexToAddress("01"):               flow.HexToAddress("0ae53cb6e3f42a79"),
+	}
+
+	t.Run("Script", func(t *testing.T) {
+		script := []byte("import FungibleToken from 0xf233dcee88fe0abe\nimport Foo from 0x02\n")
+
+		assert.Equal(t,
+			"import FungibleToken from 0xee82856bf20e2aa6\nimport Foo from 0x02\n",
+			string(emulatortest.SubstituteAddresses(script, addresses)),
+		)
+	})
+
+	t.Run("Arguments", func(t *testing.T) {
+		argument, err := jsoncdc.Encode(cadence.NewArray([]cadence.Value{
+			cadence.NewAddress(flow.HexToAddress("01")),
+			cadence.NewAddress(flow.HexToAddress("02")),
+		}))
+		require.NoError(t, err)
+
+		value, err := jsoncdc.Decode(emulatortest.SubstituteAddresses(argument, addresses))
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewArray([]cadence.Value{
+			cadence.NewAddress(flow.HexToAddress("0ae53cb6e3f42a79")),
+			cadence.NewAddress(flow.HexToAddress("02")),
+		}), value)
+	})
+}
+
+func TestEmulator_ReplayTransaction(t *testing.T) {
+	emulator := emulatortest.Start(t)
+
+	// a fake mainnet access node serving the transaction to replay
+	network := accesstest.Start(t, accesstest.NewScenario())
+	c := network.Client()
+
+	original := flow.HexToAddress("1e4aa0b87d10b141")
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(`
+			transaction(expected: Address) {
+				prepare(signer: AuthAccount) {
+					assert(signer.address == expected)
+				}
+			}
+		`)).
+		AddAuthorizer(original)
+
+	err := tx.AddArgument(cadence.NewAddress(original))
+	require.NoError(t, err)
+
+	err = c.SendTransaction(context.Background(), *tx)
+	require.NoError(t, err)
+
+	replay := emulator.ReplayTransaction(c, tx.ID())
+	require.NoError(t, replay.Result.Error)
+
+	account := replay.Accounts[original]
+	require.NotNil(t, account)
+	assert.Equal(t, []flow.Address{account.Address}, replay.Transaction.Authorizers)
+}
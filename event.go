@@ -20,9 +20,11 @@ package flow
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
 	"github.com/onflow/flow-go/crypto/hash"
 )
 
@@ -34,6 +36,9 @@ const (
 	EventAccountContractAdded   string = "flow.AccountContractAdded"
 	EventAccountContractUpdated string = "flow.AccountContractUpdated"
 	EventAccountContractRemoved string = "flow.AccountContractRemoved"
+	EventInboxValuePublished    string = "flow.InboxValuePublished"
+	EventInboxValueUnpublished  string = "flow.InboxValueUnpublished"
+	EventInboxValueClaimed      string = "flow.InboxValueClaimed"
 )
 
 type Event struct {
@@ -115,6 +120,102 @@ func CalculateEventsHash(es []Event) (crypto.Hash, error) {
 type AccountCreatedEvent Event
 
 // Address returns the address of the newly-created account.
+//
+// Address panics if the event does not carry an address field; see DecodeAddress
+// for a variant that returns an error.
 func (evt AccountCreatedEvent) Address() Address {
 	return BytesToAddress(evt.Value.Fields[0].(cadence.Address).Bytes())
 }
+
+// DecodeAddress returns the address of the newly-created account, or an error if
+// the event is malformed.
+func (evt AccountCreatedEvent) DecodeAddress() (Address, error) {
+	if evt.Type != EventAccountCreated {
+		return EmptyAddress, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "expected %s event, got %s", EventAccountCreated, evt.Type)
+	}
+
+	if len(evt.Value.Fields) == 0 {
+		return EmptyAddress, flowerrors.Errorf(flowerrors.ErrDecoding, "%s event has no fields", EventAccountCreated)
+	}
+
+	address, ok := evt.Value.Fields[0].(cadence.Address)
+	if !ok {
+		return EmptyAddress, flowerrors.Errorf(flowerrors.ErrDecoding, "%s event has address of type %T", EventAccountCreated, evt.Value.Fields[0])
+	}
+
+	return BytesToAddress(address.Bytes()), nil
+}
+
+// An EventFilter selects events by type, emitting account or emitting contract.
+//
+// The filter mirrors the event filter accepted by the Access streaming API. An event
+// matches the filter if it matches any of the listed event types, addresses or contracts.
+// An empty filter matches all events.
+type EventFilter struct {
+	// EventTypes is a list of fully-qualified event types (e.g. A.f8d6e0586b0a20c7.Foo.Bar).
+	EventTypes []string
+	// Addresses is a list of addresses whose contracts emit the events.
+	Addresses []string
+	// Contracts is a list of contracts (e.g. A.f8d6e0586b0a20c7.Foo) that emit the events.
+	Contracts []string
+}
+
+// IsEmpty returns true if the filter does not restrict events in any way.
+func (f EventFilter) IsEmpty() bool {
+	return len(f.EventTypes) == 0 && len(f.Addresses) == 0 && len(f.Contracts) == 0
+}
+
+// Matches returns true if the given event matches this filter.
+func (f EventFilter) Matches(e Event) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	for _, eventType := range f.EventTypes {
+		if e.Type == eventType {
+			return true
+		}
+	}
+
+	address, contract, ok := splitEventType(e.Type)
+	if !ok {
+		return false
+	}
+
+	for _, addr := range f.Addresses {
+		if HexToAddress(addr) == address {
+			return true
+		}
+	}
+
+	for _, c := range f.Contracts {
+		if c == contract {
+			return true
+		}
+	}
+
+	return false
+}
+
+// An AccountStatusFilter selects the account events of the Access account status
+// streaming API.
+//
+// An event matches the filter if it has one of the listed types and relates to one
+// of the listed accounts. Empty lists match all event types or all accounts.
+type AccountStatusFilter struct {
+	// EventTypes is a list of built-in account event types (e.g. flow.AccountKeyAdded).
+	EventTypes []string
+	// Addresses is a list of accounts whose changes are selected.
+	Addresses []Address
+}
+
+// splitEventType extracts the emitting address and contract from an event type
+// of the form A.<address>.<contract>.<event>.
+func splitEventType(eventType string) (Address, string, bool) {
+	parts := strings.Split(eventType, ".")
+	if len(parts) != 4 || parts[0] != "A" {
+		return EmptyAddress, "", false
+	}
+
+	return HexToAddress(parts[1]), strings.Join(parts[:3], "."), true
+}
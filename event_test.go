@@ -0,0 +1,109 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+func TestEventFilter_Matches(t *testing.T) {
+	deposit := flow.Event{Type: "A.f8d6e0586b0a20c7.FlowToken.TokensDeposited"}
+	withdraw := flow.Event{Type: "A.f8d6e0586b0a20c7.FlowToken.TokensWithdrawn"}
+	other := flow.Event{Type: "A.01cf0e2f2f715450.Kibble.TokensDeposited"}
+	created := flow.Event{Type: flow.EventAccountCreated}
+
+	t.Run("Empty filter", func(t *testing.T) {
+		filter := flow.EventFilter{}
+
+		assert.True(t, filter.Matches(deposit))
+		assert.True(t, filter.Matches(created))
+	})
+
+	t.Run("Event types", func(t *testing.T) {
+		filter := flow.EventFilter{
+			EventTypes: []string{deposit.Type, flow.EventAccountCreated},
+		}
+
+		assert.True(t, filter.Matches(deposit))
+		assert.True(t, filter.Matches(created))
+		assert.False(t, filter.Matches(withdraw))
+	})
+
+	t.Run("Addresses", func(t *testing.T) {
+		filter := flow.EventFilter{
+			Addresses: []string{"0xf8d6e0586b0a20c7"},
+		}
+
+		assert.True(t, filter.Matches(deposit))
+		assert.True(t, filter.Matches(withdraw))
+		assert.False(t, filter.Matches(other))
+		assert.False(t, filter.Matches(created))
+	})
+
+	t.Run("Contracts", func(t *testing.T) {
+		filter := flow.EventFilter{
+			Contracts: []string{"A.01cf0e2f2f715450.Kibble"},
+		}
+
+		assert.True(t, filter.Matches(other))
+		assert.False(t, filter.Matches(deposit))
+	})
+}
+
+func TestAccountCreatedEvent_DecodeAddress(t *testing.T) {
+	address := flow.HexToAddress("f8d6e0586b0a20c7")
+
+	newEvent := func(fields ...cadence.Value) flow.AccountCreatedEvent {
+		return flow.AccountCreatedEvent{
+			Type:  flow.EventAccountCreated,
+			Value: cadence.NewEvent(fields),
+		}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		decoded, err := newEvent(cadence.NewAddress(address)).DecodeAddress()
+		require.NoError(t, err)
+		assert.Equal(t, address, decoded)
+	})
+
+	t.Run("No fields", func(t *testing.T) {
+		_, err := newEvent().DecodeAddress()
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+	})
+
+	t.Run("Wrong field type", func(t *testing.T) {
+		_, err := newEvent(cadence.String("f8d6e0586b0a20c7")).DecodeAddress()
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+	})
+
+	t.Run("Wrong event type", func(t *testing.T) {
+		evt := newEvent(cadence.NewAddress(address))
+		evt.Type = flow.EventAccountKeyRemoved
+
+		_, err := evt.DecodeAddress()
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+	})
+}
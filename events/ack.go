@@ -0,0 +1,372 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+)
+
+// An EventKey uniquely identifies an event on the chain.
+type EventKey struct {
+	BlockID          flow.Identifier
+	TransactionIndex int
+	EventIndex       int
+}
+
+// String returns the string representation of this key.
+func (k EventKey) String() string {
+	return fmt.Sprintf("%s/%d/%d", k.BlockID, k.TransactionIndex, k.EventIndex)
+}
+
+// A Delivery is an event delivered to an AckHandler.
+type Delivery struct {
+	Key    EventKey
+	Height uint64
+	Event  flow.Event
+	ack    func() error
+}
+
+// Ack acknowledges the delivery, preventing the event from being delivered again.
+//
+// Ack may be called after the handler returns, e.g. once asynchronous processing completes.
+func (d Delivery) Ack() error {
+	return d.ack()
+}
+
+// An AckHandler processes a single delivered event.
+//
+// Events that are not acknowledged are delivered again when the poller restarts.
+type AckHandler func(ctx context.Context, delivery Delivery) error
+
+// An AckStore persists acknowledgements and the height from which a poller resumes.
+//
+// Implementations must be safe for concurrent use.
+type AckStore interface {
+	// IsAcked returns true if the event with the given key has been acknowledged.
+	IsAcked(key EventKey) (bool, error)
+	// Ack records the acknowledgement of the event with the given key.
+	Ack(key EventKey, height uint64) error
+	// ResumeHeight returns the height from which delivery should resume,
+	// or false if no height has been recorded yet.
+	ResumeHeight() (uint64, bool, error)
+	// SetResumeHeight records the height from which delivery should resume.
+	//
+	// Acknowledgements for events below this height are no longer needed and may be discarded.
+	SetResumeHeight(height uint64) error
+}
+
+// RunWithAck polls for newly sealed blocks and delivers their events one by one to the handler,
+// providing exactly-once processing for handlers that acknowledge events idempotently.
+//
+// Delivery resumes from the height recorded in the store, skipping events that have already
+// been acknowledged. The resume height only advances past a block once all of its events
+// have been acknowledged, so unacknowledged events are delivered again after a restart.
+func (p *Poller) RunWithAck(ctx context.Context, store AckStore, handler AckHandler) error {
+	startHeight := p.startHeight
+
+	resumeHeight, ok, err := store.ResumeHeight()
+	if err != nil {
+		return fmt.Errorf("events: failed to read resume height: %w", err)
+	}
+	if ok {
+		startHeight = resumeHeight
+	}
+
+	tracker := newAckTracker(store)
+
+	return p.run(ctx, startHeight, func(ctx context.Context, block client.BlockEvents) error {
+		for _, event := range block.Events {
+			key := EventKey{
+				BlockID:          block.BlockID,
+				TransactionIndex: event.TransactionIndex,
+				EventIndex:       event.EventIndex,
+			}
+
+			acked, err := store.IsAcked(key)
+			if err != nil {
+				return fmt.Errorf("events: failed to read acknowledgement for %s: %w", key, err)
+			}
+			if acked {
+				continue
+			}
+
+			tracker.add(key, block.Height)
+
+			err = handler(ctx, Delivery{
+				Key:    key,
+				Height: block.Height,
+				Event:  event,
+				ack: func() error {
+					return tracker.ack(key, block.Height)
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return tracker.processed(block.Height)
+	})
+}
+
+// ackTracker keeps track of unacknowledged deliveries and advances the resume height
+// of the store to the lowest block with unacknowledged events.
+type ackTracker struct {
+	mut             sync.Mutex
+	store           AckStore
+	pending         map[uint64]map[EventKey]struct{}
+	processedHeight uint64
+	started         bool
+}
+
+func newAckTracker(store AckStore) *ackTracker {
+	return &ackTracker{
+		store:   store,
+		pending: make(map[uint64]map[EventKey]struct{}),
+	}
+}
+
+func (t *ackTracker) add(key EventKey, height uint64) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	keys, ok := t.pending[height]
+	if !ok {
+		keys = make(map[EventKey]struct{})
+		t.pending[height] = keys
+	}
+
+	keys[key] = struct{}{}
+}
+
+func (t *ackTracker) ack(key EventKey, height uint64) error {
+	err := t.store.Ack(key, height)
+	if err != nil {
+		return err
+	}
+
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	if keys, ok := t.pending[height]; ok {
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(t.pending, height)
+		}
+	}
+
+	return t.checkpoint()
+}
+
+func (t *ackTracker) processed(height uint64) error {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	t.processedHeight = height
+	t.started = true
+
+	return t.checkpoint()
+}
+
+// checkpoint records the resume height. It must be called with the lock held.
+func (t *ackTracker) checkpoint() error {
+	if !t.started {
+		return nil
+	}
+
+	resumeHeight := t.processedHeight + 1
+	for height := range t.pending {
+		if height < resumeHeight {
+			resumeHeight = height
+		}
+	}
+
+	return t.store.SetResumeHeight(resumeHeight)
+}
+
+// A MemoryAckStore is an AckStore that keeps acknowledgements in memory.
+//
+// It is useful for testing, or for applications that persist progress elsewhere.
+type MemoryAckStore struct {
+	mut          sync.Mutex
+	acks         map[EventKey]uint64
+	resumeHeight uint64
+	hasHeight    bool
+}
+
+// NewMemoryAckStore creates an empty in-memory acknowledgement store.
+func NewMemoryAckStore() *MemoryAckStore {
+	return &MemoryAckStore{
+		acks: make(map[EventKey]uint64),
+	}
+}
+
+func (s *MemoryAckStore) IsAcked(key EventKey) (bool, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	_, ok := s.acks[key]
+	return ok, nil
+}
+
+func (s *MemoryAckStore) Ack(key EventKey, height uint64) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.acks[key] = height
+	return nil
+}
+
+func (s *MemoryAckStore) ResumeHeight() (uint64, bool, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	return s.resumeHeight, s.hasHeight, nil
+}
+
+func (s *MemoryAckStore) SetResumeHeight(height uint64) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.resumeHeight = height
+	s.hasHeight = true
+
+	for key, h := range s.acks {
+		if h < height {
+			delete(s.acks, key)
+		}
+	}
+
+	return nil
+}
+
+// A FileAckStore is an AckStore that persists acknowledgements to a JSON file.
+//
+// The file is rewritten on every change, which makes it suitable for low-volume
+// event streams. High-volume consumers should implement AckStore on top of their database.
+type FileAckStore struct {
+	path  string
+	mut   sync.Mutex
+	state fileAckState
+}
+
+type fileAckState struct {
+	ResumeHeight *uint64           `json:"resumeHeight,omitempty"`
+	Acks         map[string]uint64 `json:"acks"`
+}
+
+// NewFileAckStore opens the acknowledgement store at the given path, creating it if it does not exist.
+func NewFileAckStore(path string) (*FileAckStore, error) {
+	s := &FileAckStore{
+		path: path,
+		state: fileAckState{
+			Acks: make(map[string]uint64),
+		},
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(b, &s.state)
+	if err != nil {
+		return nil, fmt.Errorf("events: failed to decode ack store %s: %w", path, err)
+	}
+
+	if s.state.Acks == nil {
+		s.state.Acks = make(map[string]uint64)
+	}
+
+	return s, nil
+}
+
+func (s *FileAckStore) IsAcked(key EventKey) (bool, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	_, ok := s.state.Acks[key.String()]
+	return ok, nil
+}
+
+func (s *FileAckStore) Ack(key EventKey, height uint64) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.state.Acks[key.String()] = height
+	return s.save()
+}
+
+func (s *FileAckStore) ResumeHeight() (uint64, bool, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.state.ResumeHeight == nil {
+		return 0, false, nil
+	}
+
+	return *s.state.ResumeHeight, true, nil
+}
+
+func (s *FileAckStore) SetResumeHeight(height uint64) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.state.ResumeHeight != nil && *s.state.ResumeHeight == height {
+		return nil
+	}
+
+	s.state.ResumeHeight = &height
+
+	for key, h := range s.state.Acks {
+		if h < height {
+			delete(s.state.Acks, key)
+		}
+	}
+
+	return s.save()
+}
+
+// save atomically writes the state to disk. It must be called with the lock held.
+func (s *FileAckStore) save() error {
+	b, err := json.Marshal(s.state)
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+
+	err = ioutil.WriteFile(tmp, b, 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,106 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/events"
+)
+
+func TestPoller_RunWithAck(t *testing.T) {
+	filter := flow.EventFilter{EventTypes: []string{"foo"}}
+
+	run := func(t *testing.T, store events.AckStore, ackHeight func(uint64) bool) []uint64 {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// stop once the poller has caught up with the latest block
+		polls := 0
+		c := &fakeClient{
+			latestHeight: 3,
+			onPoll: func() {
+				polls++
+				if polls > 1 {
+					cancel()
+				}
+			},
+		}
+
+		p := events.NewPoller(c, filter, 1, events.WithPollInterval(time.Millisecond))
+
+		var delivered []uint64
+		err := p.RunWithAck(ctx, store, func(_ context.Context, d events.Delivery) error {
+			delivered = append(delivered, d.Height)
+
+			if ackHeight(d.Height) {
+				require.NoError(t, d.Ack())
+			}
+
+			return nil
+		})
+		assert.Equal(t, context.Canceled, err)
+
+		return delivered
+	}
+
+	testStore := func(t *testing.T, store events.AckStore, reopen func() events.AckStore) {
+		delivered := run(t, store, func(height uint64) bool { return height != 2 })
+		assert.Equal(t, []uint64{1, 2, 3}, delivered)
+
+		height, ok, err := store.ResumeHeight()
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, uint64(2), height)
+
+		store = reopen()
+
+		delivered = run(t, store, func(uint64) bool { return true })
+		assert.Equal(t, []uint64{2}, delivered)
+
+		height, _, err = store.ResumeHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), height)
+	}
+
+	t.Run("Memory store", func(t *testing.T) {
+		store := events.NewMemoryAckStore()
+		testStore(t, store, func() events.AckStore { return store })
+	})
+
+	t.Run("File store", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "acks.json")
+
+		store, err := events.NewFileAckStore(path)
+		require.NoError(t, err)
+
+		testStore(t, store, func() events.AckStore {
+			store, err := events.NewFileAckStore(path)
+			require.NoError(t, err)
+			return store
+		})
+	})
+}
@@ -0,0 +1,284 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// DefaultChunkSize is the default number of blocks requested per query.
+//
+// Access nodes reject height range queries spanning more than 250 blocks.
+const DefaultChunkSize = 250
+
+// Progress describes the state of a running backfill.
+type Progress struct {
+	// StartHeight is the first height of the backfilled range.
+	StartHeight uint64
+	// EndHeight is the last height of the backfilled range.
+	EndHeight uint64
+	// Height is the last height that has been processed.
+	Height uint64
+	// BlocksProcessed is the number of blocks passed to the handler so far.
+	BlocksProcessed uint64
+	// Elapsed is the time since the backfill started.
+	Elapsed time.Duration
+}
+
+// HeightsProcessed returns the number of heights of the range processed so far,
+// including heights without events.
+func (p Progress) HeightsProcessed() uint64 {
+	if p.Height < p.StartHeight {
+		return 0
+	}
+	return p.Height - p.StartHeight + 1
+}
+
+// Fraction returns the processed fraction of the range, between 0 and 1.
+func (p Progress) Fraction() float64 {
+	return float64(p.HeightsProcessed()) / float64(p.EndHeight-p.StartHeight+1)
+}
+
+// ETA estimates the time until the backfill completes from the rate at which
+// heights have been processed so far. It returns zero once the range is
+// processed or before any height is.
+func (p Progress) ETA() time.Duration {
+	processed := p.HeightsProcessed()
+	if processed == 0 || p.Height >= p.EndHeight {
+		return 0
+	}
+
+	remaining := p.EndHeight - p.Height
+	return time.Duration(float64(p.Elapsed) / float64(processed) * float64(remaining))
+}
+
+// A BackfillOption configures a backfill.
+type BackfillOption func(*backfillConfig)
+
+type backfillConfig struct {
+	chunkSize    uint64
+	interval     time.Duration
+	maxAttempts  int
+	retryBackoff time.Duration
+	onProgress   func(Progress)
+	workers      int
+}
+
+func defaultBackfillConfig() backfillConfig {
+	return backfillConfig{
+		chunkSize:    DefaultChunkSize,
+		maxAttempts:  5,
+		retryBackoff: time.Second,
+		workers:      DefaultWorkers,
+	}
+}
+
+// WithChunkSize sets the number of blocks requested per query.
+func WithChunkSize(size uint64) BackfillOption {
+	return func(c *backfillConfig) {
+		c.chunkSize = size
+	}
+}
+
+// WithRateLimit limits the number of queries sent to the access node per second.
+//
+// Each event type in the filter results in a separate query for every chunk, so
+// chunks are fetched at the given rate divided by the number of event types.
+func WithRateLimit(queriesPerSecond float64) BackfillOption {
+	return func(c *backfillConfig) {
+		if queriesPerSecond > 0 {
+			c.interval = time.Duration(float64(time.Second) / queriesPerSecond)
+		}
+	}
+}
+
+// WithRetry sets the number of attempts made for a chunk that fails with a transient
+// error, and the initial backoff between attempts. The backoff doubles after every attempt.
+func WithRetry(maxAttempts int, backoff time.Duration) BackfillOption {
+	return func(c *backfillConfig) {
+		c.maxAttempts = maxAttempts
+		c.retryBackoff = backoff
+	}
+}
+
+// WithProgress registers a function that is called after every processed chunk,
+// e.g. to render the progress and the estimated remaining time of the backfill.
+func WithProgress(f func(Progress)) BackfillOption {
+	return func(c *backfillConfig) {
+		c.onProgress = f
+	}
+}
+
+// Backfill fetches all events matching the filter between the given heights (inclusive)
+// and passes them to the handler block by block, in height order.
+//
+// The range is split into chunks that are queried one after another, at the configured
+// rate. Chunks that fail with a transient error are retried with exponential backoff.
+func Backfill(
+	ctx context.Context,
+	flowClient Client,
+	filter flow.EventFilter,
+	fromHeight uint64,
+	toHeight uint64,
+	handler Handler,
+	opts ...BackfillOption,
+) error {
+	cfg, err := newBackfillConfig(fromHeight, toHeight, opts)
+	if err != nil {
+		return err
+	}
+
+	throttle, stop := cfg.throttle()
+	defer stop()
+
+	started := time.Now()
+	progress := Progress{
+		StartHeight: fromHeight,
+		EndHeight:   toHeight,
+	}
+
+	for start := fromHeight; start <= toHeight; {
+		end := cfg.chunkEnd(start, toHeight)
+
+		blocks, err := fetchWithRetry(ctx, cfg, throttle, queryCount(filter), func() ([]client.BlockEvents, error) {
+			return flowClient.GetEventsForHeightRangeWithFilter(ctx, filter, start, end)
+		})
+		if err != nil {
+			return fmt.Errorf("events: failed to fetch events for heights %d-%d: %w", start, end, err)
+		}
+
+		for _, block := range blocks {
+			err := handler(ctx, block)
+			if err != nil {
+				return err
+			}
+
+			progress.BlocksProcessed++
+		}
+
+		progress.Height = end
+		progress.Elapsed = time.Since(started)
+		if cfg.onProgress != nil {
+			cfg.onProgress(progress)
+		}
+
+		if end == toHeight {
+			break
+		}
+
+		start = end + 1
+	}
+
+	return nil
+}
+
+func newBackfillConfig(fromHeight uint64, toHeight uint64, opts []BackfillOption) (backfillConfig, error) {
+	if fromHeight > toHeight {
+		return backfillConfig{}, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "events: start height %d is greater than end height %d", fromHeight, toHeight)
+	}
+
+	cfg := defaultBackfillConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.chunkSize == 0 {
+		return backfillConfig{}, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "events: chunk size must be greater than zero")
+	}
+
+	return cfg, nil
+}
+
+// throttle returns a channel that paces queries at the configured rate, or nil if
+// queries are not rate limited, and a function that releases its resources.
+func (c backfillConfig) throttle() (<-chan time.Time, func()) {
+	if c.interval <= 0 {
+		return nil, func() {}
+	}
+
+	ticker := time.NewTicker(c.interval)
+	return ticker.C, ticker.Stop
+}
+
+// chunkEnd returns the last height of the chunk starting at start.
+func (c backfillConfig) chunkEnd(start uint64, toHeight uint64) uint64 {
+	end := start + c.chunkSize - 1
+	if end > toHeight || end < start {
+		return toHeight
+	}
+	return end
+}
+
+// queryCount returns the number of queries sent to the access node to fetch the
+// events of a chunk, which is one per event type of the filter.
+func queryCount(filter flow.EventFilter) int {
+	if len(filter.EventTypes) == 0 {
+		return 1
+	}
+	return len(filter.EventTypes)
+}
+
+// fetchWithRetry calls fetch until it succeeds or fails with a permanent error.
+//
+// Before every attempt, one tick of the throttle is taken for each of the queries
+// made by fetch.
+func fetchWithRetry(
+	ctx context.Context,
+	cfg backfillConfig,
+	throttle <-chan time.Time,
+	queries int,
+	fetch func() ([]client.BlockEvents, error),
+) ([]client.BlockEvents, error) {
+	backoff := cfg.retryBackoff
+
+	for attempt := 1; ; attempt++ {
+		if throttle != nil {
+			for i := 0; i < queries; i++ {
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-throttle:
+				}
+			}
+		}
+
+		blocks, err := fetch()
+		if err == nil {
+			return blocks, nil
+		}
+
+		if !isTransient(err) || attempt >= cfg.maxAttempts {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
@@ -0,0 +1,283 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events_test
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/events"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+// fakeClient serves one BlockEvents entry per height, each with a single event.
+type fakeClient struct {
+	mut          sync.Mutex
+	latency      time.Duration
+	latestHeight uint64
+	onPoll       func()
+	ranges       [][2]uint64
+	failures     int
+	err          error
+}
+
+func (c *fakeClient) GetEventsForHeightRangeWithFilter(
+	_ context.Context,
+	_ flow.EventFilter,
+	startHeight uint64,
+	endHeight uint64,
+	_ ...grpc.CallOption,
+) ([]client.BlockEvents, error) {
+	if c.latency > 0 {
+		time.Sleep(c.latency)
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.failures > 0 {
+		c.failures--
+		return nil, c.err
+	}
+
+	c.ranges = append(c.ranges, [2]uint64{startHeight, endHeight})
+
+	generator := test.EventGenerator()
+
+	var blocks []client.BlockEvents
+	for height := startHeight; height <= endHeight; height++ {
+		event := generator.New()
+		event.TransactionIndex = 0
+		event.EventIndex = 0
+
+		blocks = append(blocks, client.BlockEvents{
+			BlockID: blockIDAtHeight(height),
+			Height:  height,
+			Events:  []flow.Event{event},
+		})
+	}
+
+	return blocks, nil
+}
+
+func (c *fakeClient) GetLatestBlockHeader(_ context.Context, _ bool, _ ...grpc.CallOption) (*flow.BlockHeader, error) {
+	if c.onPoll != nil {
+		c.onPoll()
+	}
+
+	return &flow.BlockHeader{
+		ID:     blockIDAtHeight(c.latestHeight),
+		Height: c.latestHeight,
+	}, nil
+}
+
+func blockIDAtHeight(height uint64) flow.Identifier {
+	var id flow.Identifier
+	binary.BigEndian.PutUint64(id[:], height)
+	return id
+}
+
+func TestBackfill(t *testing.T) {
+	ctx := context.Background()
+	filter := flow.EventFilter{EventTypes: []string{"foo"}}
+
+	t.Run("Chunks range", func(t *testing.T) {
+		c := &fakeClient{}
+
+		var heights []uint64
+		var progress []events.Progress
+
+		err := events.Backfill(
+			ctx, c, filter, 10, 34,
+			func(_ context.Context, block client.BlockEvents) error {
+				heights = append(heights, block.Height)
+				return nil
+			},
+			events.WithChunkSize(10),
+			events.WithProgress(func(p events.Progress) {
+				progress = append(progress, p)
+			}),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, [][2]uint64{{10, 19}, {20, 29}, {30, 34}}, c.ranges)
+		require.Len(t, heights, 25)
+		assert.Equal(t, uint64(10), heights[0])
+		assert.Equal(t, uint64(34), heights[24])
+
+		require.Len(t, progress, 3)
+		last := progress[2]
+		assert.Positive(t, last.Elapsed)
+		last.Elapsed = 0
+		assert.Equal(t, events.Progress{StartHeight: 10, EndHeight: 34, Height: 34, BlocksProcessed: 25}, last)
+	})
+
+	t.Run("Retries transient errors", func(t *testing.T) {
+		c := &fakeClient{
+			failures: 2,
+			err:      client.RPCError{GRPCErr: status.Error(codes.Unavailable, "unavailable")},
+		}
+
+		err := events.Backfill(
+			ctx, c, filter, 1, 5,
+			func(context.Context, client.BlockEvents) error { return nil },
+			events.WithRetry(3, time.Millisecond),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, [][2]uint64{{1, 5}}, c.ranges)
+	})
+
+	t.Run("Gives up after max attempts", func(t *testing.T) {
+		c := &fakeClient{
+			failures: 3,
+			err:      client.RPCError{GRPCErr: status.Error(codes.Unavailable, "unavailable")},
+		}
+
+		err := events.Backfill(
+			ctx, c, filter, 1, 5,
+			func(context.Context, client.BlockEvents) error { return nil },
+			events.WithRetry(3, time.Millisecond),
+		)
+		assert.ErrorIs(t, err, c.err)
+	})
+
+	t.Run("Does not retry permanent errors", func(t *testing.T) {
+		c := &fakeClient{
+			failures: 1,
+			err:      client.RPCError{GRPCErr: status.Error(codes.InvalidArgument, "invalid")},
+		}
+
+		err := events.Backfill(
+			ctx, c, filter, 1, 5,
+			func(context.Context, client.BlockEvents) error { return nil },
+			events.WithRetry(3, time.Millisecond),
+		)
+		assert.Error(t, err)
+		assert.Empty(t, c.ranges)
+	})
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		handler := func(context.Context, client.BlockEvents) error { return nil }
+
+		err := events.Backfill(ctx, &fakeClient{}, filter, 10, 5, handler)
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+
+		err = events.Backfill(ctx, &fakeClient{}, filter, 1, 5, handler, events.WithChunkSize(0))
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+	})
+
+	t.Run("Rate limited", func(t *testing.T) {
+		c := &fakeClient{}
+
+		start := time.Now()
+
+		err := events.Backfill(
+			ctx, c, filter, 1, 3,
+			func(context.Context, client.BlockEvents) error { return nil },
+			events.WithChunkSize(1),
+			events.WithRateLimit(100),
+		)
+		require.NoError(t, err)
+
+		assert.Len(t, c.ranges, 3)
+		assert.True(t, time.Since(start) >= 30*time.Millisecond)
+	})
+
+	t.Run("Rate limits queries per event type", func(t *testing.T) {
+		rpc := &recordingRPCClient{}
+		c := client.NewFromRPCClient(rpc)
+
+		const rate = 50
+		interval := time.Second / rate
+
+		start := time.Now()
+
+		err := events.Backfill(
+			ctx, c, flow.EventFilter{EventTypes: []string{"foo", "bar"}}, 1, 3,
+			func(context.Context, client.BlockEvents) error { return nil },
+			events.WithChunkSize(1),
+			events.WithRateLimit(rate),
+		)
+		require.NoError(t, err)
+
+		calls := rpc.times()
+		require.Len(t, calls, 6)
+
+		// The n-th query cannot be sent before n intervals have elapsed.
+		for i, at := range calls {
+			assert.GreaterOrEqual(t, int64(at.Sub(start)), int64(i)*int64(interval), "query %d", i)
+		}
+	})
+}
+
+// recordingRPCClient records the times of the event queries it receives, and
+// returns no events.
+type recordingRPCClient struct {
+	access.AccessAPIClient
+
+	mut   sync.Mutex
+	calls []time.Time
+}
+
+func (c *recordingRPCClient) GetEventsForHeightRange(
+	context.Context,
+	*access.GetEventsForHeightRangeRequest,
+	...grpc.CallOption,
+) (*access.EventsResponse, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.calls = append(c.calls, time.Now())
+
+	return &access.EventsResponse{}, nil
+}
+
+func (c *recordingRPCClient) times() []time.Time {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return append([]time.Time(nil), c.calls...)
+}
+
+func TestProgress(t *testing.T) {
+	p := events.Progress{StartHeight: 10, EndHeight: 109, Height: 34, Elapsed: 5 * time.Second}
+
+	assert.Equal(t, uint64(25), p.HeightsProcessed())
+	assert.Equal(t, 0.25, p.Fraction())
+	assert.Equal(t, 15*time.Second, p.ETA())
+
+	p.Height = 109
+	assert.Equal(t, 1.0, p.Fraction())
+	assert.Zero(t, p.ETA())
+
+	assert.Zero(t, events.Progress{StartHeight: 10, EndHeight: 20}.ETA())
+}
@@ -0,0 +1,83 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command eventgen generates typed Go structs and decoders from the event
+// declarations of Cadence contracts.
+//
+// Usage:
+//
+//	eventgen -package <name> [-address <hex>] [-o <file>] <contract.cdc>...
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/onflow/flow-go-sdk/events/eventgen"
+)
+
+func main() {
+	pkg := flag.String("package", "", "name of the generated Go package")
+	address := flag.String("address", "", "address the contracts are deployed to (optional)")
+	output := flag.String("o", "", "output file (defaults to stdout)")
+	flag.Parse()
+
+	if *pkg == "" || flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: eventgen -package <name> [-address <hex>] [-o <file>] <contract.cdc>...")
+		os.Exit(2)
+	}
+
+	var events []eventgen.Event
+	for _, path := range flag.Args() {
+		code, err := ioutil.ReadFile(path)
+		if err != nil {
+			fail(err)
+		}
+
+		declared, err := eventgen.Parse(string(code))
+		if err != nil {
+			fail(fmt.Errorf("%s: %w", path, err))
+		}
+
+		events = append(events, declared...)
+	}
+
+	src, err := eventgen.GenerateEvents(events, eventgen.Config{
+		Package: *pkg,
+		Address: *address,
+	})
+	if err != nil {
+		fail(err)
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = ioutil.WriteFile(*output, src, 0644)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,94 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/internal/cadencedecode"
+)
+
+// FieldTag is the struct tag that maps a Go struct field to a Cadence event field.
+const FieldTag = cadencedecode.FieldTag
+
+// DecodeEvent decodes the fields of an event into the struct pointed to by target.
+//
+// Struct fields are matched to event fields by their `cadence:"<name>"` tag. Fields
+// without a tag are ignored. Cadence values are converted to the corresponding Go
+// values: optionals to pointers (or to the zero value when nil), arrays to slices,
+// dictionaries to maps and addresses to flow.Address. A field of type cadence.Value
+// receives the Cadence value as is.
+func DecodeEvent(event flow.Event, target interface{}) error {
+	ptr := reflect.ValueOf(target)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() || ptr.Elem().Kind() != reflect.Struct {
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "events: decode target must be a non-nil struct pointer, got %T", target)
+	}
+
+	if event.Value.EventType == nil {
+		return fmt.Errorf("events: event %s has no type information", event.Type)
+	}
+
+	fields := make(map[string]cadence.Value, len(event.Value.Fields))
+	for i, field := range event.Value.EventType.Fields {
+		if i < len(event.Value.Fields) {
+			fields[field.Identifier] = event.Value.Fields[i]
+		}
+	}
+
+	dst := ptr.Elem()
+	dstType := dst.Type()
+
+	for i := 0; i < dstType.NumField(); i++ {
+		name, ok := dstType.Field(i).Tag.Lookup(FieldTag)
+		if !ok || name == "" || name == "-" {
+			continue
+		}
+
+		value, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("events: event %s has no field %s", event.Type, name)
+		}
+
+		err := cadencedecode.Assign(dst.Field(i), value)
+		if err != nil {
+			return fmt.Errorf("events: failed to decode field %s of event %s: %w", name, event.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// DecodeValue decodes a Cadence value, such as the result of a script, into the Go
+// value pointed to by target.
+//
+// Values are converted as described for DecodeEvent. Structs, resources and events
+// additionally decode into Go structs whose fields carry a `cadence:"<name>"` tag.
+func DecodeValue(value cadence.Value, target interface{}) error {
+	err := cadencedecode.Decode(value, target)
+	if err != nil {
+		return fmt.Errorf("events: %w", err)
+	}
+
+	return nil
+}
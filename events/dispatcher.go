@@ -0,0 +1,309 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk/client"
+)
+
+// A BackpressurePolicy determines what a Dispatcher does when a consumer's buffer is full.
+type BackpressurePolicy int
+
+const (
+	// Block waits until the consumer has room in its buffer, slowing down delivery
+	// to all consumers.
+	Block BackpressurePolicy = iota
+	// DropNewest discards the block that does not fit into the consumer's buffer.
+	DropNewest
+	// DropOldest discards the oldest buffered block to make room for the new one.
+	DropOldest
+)
+
+// A Dispatcher fans out a single upstream event subscription to multiple consumers.
+//
+// Each consumer has its own buffer and backpressure policy, so a slow consumer
+// only affects other consumers if it uses the Block policy.
+type Dispatcher struct {
+	upstream  <-chan client.BlockEvents
+	errs      <-chan error
+	mut       sync.Mutex
+	consumers map[*Subscription]struct{}
+	stopped   bool
+}
+
+// NewDispatcher creates a dispatcher for the given upstream block and error channels,
+// such as the ones returned by client.SubscribeEventsByBlockHeight.
+//
+// The errs channel may be nil.
+func NewDispatcher(upstream <-chan client.BlockEvents, errs <-chan error) *Dispatcher {
+	return &Dispatcher{
+		upstream:  upstream,
+		errs:      errs,
+		consumers: make(map[*Subscription]struct{}),
+	}
+}
+
+// A Subscription is a consumer registered with a Dispatcher.
+type Subscription struct {
+	// C delivers the blocks dispatched to this consumer. It is closed when the
+	// subscription or the dispatcher stops.
+	C <-chan client.BlockEvents
+
+	ch        chan client.BlockEvents
+	policy    BackpressurePolicy
+	predicate Predicate
+	done      chan struct{}
+	closeOnce sync.Once
+	mut       sync.Mutex
+	err       error
+	dropped   uint64
+}
+
+// Close unregisters the subscription from its dispatcher.
+func (s *Subscription) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+// Err returns the error returned by the subscription's handler, if any.
+func (s *Subscription) Err() error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.err
+}
+
+// Dropped returns the number of blocks discarded for this subscription because
+// its buffer was full.
+func (s *Subscription) Dropped() uint64 {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.dropped
+}
+
+func (s *Subscription) setErr(err error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.err = err
+}
+
+// Subscribe registers a channel-based consumer with the given buffer size and backpressure policy.
+//
+// The drop policies require a buffer, so their buffer size is at least one.
+func (d *Dispatcher) Subscribe(bufferSize int, policy BackpressurePolicy) *Subscription {
+	return d.subscribe(bufferSize, policy, nil)
+}
+
+// SubscribeWhere registers a channel-based consumer that only receives the events matched
+// by the predicate. Blocks without matching events are not delivered and do not count
+// towards the consumer's buffer.
+func (d *Dispatcher) SubscribeWhere(bufferSize int, policy BackpressurePolicy, predicate Predicate) *Subscription {
+	return d.subscribe(bufferSize, policy, predicate)
+}
+
+func (d *Dispatcher) subscribe(bufferSize int, policy BackpressurePolicy, predicate Predicate) *Subscription {
+	if policy != Block && bufferSize < 1 {
+		bufferSize = 1
+	}
+
+	ch := make(chan client.BlockEvents, bufferSize)
+
+	sub := &Subscription{
+		C:         ch,
+		ch:        ch,
+		policy:    policy,
+		predicate: predicate,
+		done:      make(chan struct{}),
+	}
+
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	if d.stopped {
+		close(ch)
+		return sub
+	}
+
+	d.consumers[sub] = struct{}{}
+
+	return sub
+}
+
+// SubscribeFunc registers a handler-based consumer with the given buffer size and backpressure policy.
+//
+// The handler is called from its own goroutine. If the handler returns an error, the
+// subscription is closed and the error is available from Err.
+func (d *Dispatcher) SubscribeFunc(
+	ctx context.Context,
+	handler Handler,
+	bufferSize int,
+	policy BackpressurePolicy,
+) *Subscription {
+	sub := d.Subscribe(bufferSize, policy)
+
+	go func() {
+		for {
+			select {
+			case <-sub.done:
+				return
+			case <-ctx.Done():
+				sub.setErr(ctx.Err())
+				sub.Close()
+				return
+			case block, ok := <-sub.C:
+				if !ok {
+					return
+				}
+
+				err := handler(ctx, block)
+				if err != nil {
+					sub.setErr(err)
+					sub.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	return sub
+}
+
+// Run reads from the upstream subscription and dispatches every block to all
+// registered consumers until the upstream ends or the context is cancelled.
+//
+// Run returns the upstream error, if any. All consumer channels are closed when Run returns.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	defer d.stop()
+
+	errs := d.errs
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		case block, ok := <-d.upstream:
+			if !ok {
+				// the upstream may report its error after closing the block channel
+				if errs != nil {
+					select {
+					case err := <-errs:
+						return err
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+				return nil
+			}
+
+			d.dispatch(ctx, block)
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, upstream client.BlockEvents) {
+	for _, sub := range d.activeConsumers() {
+		block := upstream
+		if sub.predicate != nil {
+			block = FilterBlock(upstream, sub.predicate)
+			if len(block.Events) == 0 {
+				continue
+			}
+		}
+
+		switch sub.policy {
+		case DropNewest:
+			if !trySend(sub.ch, block) {
+				sub.drop()
+			}
+		case DropOldest:
+			for !trySend(sub.ch, block) {
+				select {
+				case <-sub.ch:
+					sub.drop()
+				default:
+				}
+			}
+		default:
+			select {
+			case sub.ch <- block:
+			case <-sub.done:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func trySend(ch chan client.BlockEvents, block client.BlockEvents) bool {
+	select {
+	case ch <- block:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Subscription) drop() {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.dropped++
+}
+
+// activeConsumers returns the registered consumers, closing and removing the ones
+// that have been closed since the last dispatch.
+func (d *Dispatcher) activeConsumers() []*Subscription {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	subs := make([]*Subscription, 0, len(d.consumers))
+	for sub := range d.consumers {
+		select {
+		case <-sub.done:
+			delete(d.consumers, sub)
+			close(sub.ch)
+		default:
+			subs = append(subs, sub)
+		}
+	}
+
+	return subs
+}
+
+func (d *Dispatcher) stop() {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	for sub := range d.consumers {
+		delete(d.consumers, sub)
+		close(sub.ch)
+	}
+
+	d.stopped = true
+}
@@ -0,0 +1,198 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/events"
+)
+
+func blockRange(n int) []client.BlockEvents {
+	blocks := make([]client.BlockEvents, n)
+	for i := range blocks {
+		blocks[i] = client.BlockEvents{Height: uint64(i + 1)}
+	}
+	return blocks
+}
+
+func heights(blocks []client.BlockEvents) []uint64 {
+	result := make([]uint64, len(blocks))
+	for i, block := range blocks {
+		result[i] = block.Height
+	}
+	return result
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Fans out to all consumers", func(t *testing.T) {
+		upstream := make(chan client.BlockEvents)
+		d := events.NewDispatcher(upstream, nil)
+
+		subA := d.Subscribe(0, events.Block)
+		subB := d.Subscribe(10, events.Block)
+
+		var mut sync.Mutex
+		var handled []client.BlockEvents
+		subC := d.SubscribeFunc(ctx, func(_ context.Context, block client.BlockEvents) error {
+			mut.Lock()
+			defer mut.Unlock()
+			handled = append(handled, block)
+			return nil
+		}, 0, events.Block)
+
+		done := make(chan error)
+		go func() { done <- d.Run(ctx) }()
+
+		var receivedA, receivedB []client.BlockEvents
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for block := range subA.C {
+				receivedA = append(receivedA, block)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for block := range subB.C {
+				receivedB = append(receivedB, block)
+			}
+		}()
+
+		for _, block := range blockRange(5) {
+			upstream <- block
+		}
+		close(upstream)
+
+		require.NoError(t, <-done)
+		wg.Wait()
+
+		assert.Equal(t, []uint64{1, 2, 3, 4, 5}, heights(receivedA))
+		assert.Equal(t, []uint64{1, 2, 3, 4, 5}, heights(receivedB))
+		assert.NoError(t, subC.Err())
+	})
+
+	t.Run("Drop newest", func(t *testing.T) {
+		upstream := make(chan client.BlockEvents, 5)
+		for _, block := range blockRange(5) {
+			upstream <- block
+		}
+		close(upstream)
+
+		d := events.NewDispatcher(upstream, nil)
+		sub := d.Subscribe(2, events.DropNewest)
+
+		require.NoError(t, d.Run(ctx))
+
+		var received []client.BlockEvents
+		for block := range sub.C {
+			received = append(received, block)
+		}
+
+		assert.Equal(t, []uint64{1, 2}, heights(received))
+		assert.Equal(t, uint64(3), sub.Dropped())
+	})
+
+	t.Run("Drop oldest", func(t *testing.T) {
+		upstream := make(chan client.BlockEvents, 5)
+		for _, block := range blockRange(5) {
+			upstream <- block
+		}
+		close(upstream)
+
+		d := events.NewDispatcher(upstream, nil)
+		sub := d.Subscribe(2, events.DropOldest)
+
+		require.NoError(t, d.Run(ctx))
+
+		var received []client.BlockEvents
+		for block := range sub.C {
+			received = append(received, block)
+		}
+
+		assert.Equal(t, []uint64{4, 5}, heights(received))
+		assert.Equal(t, uint64(3), sub.Dropped())
+	})
+
+	t.Run("Closed subscription stops receiving", func(t *testing.T) {
+		upstream := make(chan client.BlockEvents)
+		d := events.NewDispatcher(upstream, nil)
+
+		sub := d.Subscribe(0, events.Block)
+		sub.Close()
+
+		done := make(chan error)
+		go func() { done <- d.Run(ctx) }()
+
+		upstream <- client.BlockEvents{Height: 1}
+		close(upstream)
+
+		require.NoError(t, <-done)
+
+		_, ok := <-sub.C
+		assert.False(t, ok)
+	})
+
+	t.Run("Handler error closes subscription", func(t *testing.T) {
+		upstream := make(chan client.BlockEvents)
+		d := events.NewDispatcher(upstream, nil)
+
+		handlerErr := errors.New("handler failed")
+		sub := d.SubscribeFunc(ctx, func(context.Context, client.BlockEvents) error {
+			return handlerErr
+		}, 0, events.Block)
+
+		done := make(chan error)
+		go func() { done <- d.Run(ctx) }()
+
+		upstream <- client.BlockEvents{Height: 1}
+		upstream <- client.BlockEvents{Height: 2}
+		close(upstream)
+
+		require.NoError(t, <-done)
+		assert.Equal(t, handlerErr, sub.Err())
+	})
+
+	t.Run("Upstream error", func(t *testing.T) {
+		upstream := make(chan client.BlockEvents)
+		errs := make(chan error, 1)
+
+		streamErr := errors.New("stream failed")
+		errs <- streamErr
+		close(upstream)
+
+		d := events.NewDispatcher(upstream, errs)
+		sub := d.Subscribe(1, events.Block)
+
+		assert.Equal(t, streamErr, d.Run(ctx))
+
+		_, ok := <-sub.C
+		assert.False(t, ok)
+	})
+}
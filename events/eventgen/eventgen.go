@@ -0,0 +1,358 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package eventgen generates typed Go structs and decoders from Cadence event declarations.
+//
+// The generated code registers its decoders in events.DefaultRegistry, so typed
+// events can be obtained with events.Decode. It is usually invoked through the
+// eventgen command from a go:generate directive:
+//
+//	//go:generate go run github.com/onflow/flow-go-sdk/events/cmd/eventgen -package tokens -address 0xf233dcee88fe0abe -o events_gen.go FungibleToken.cdc
+package eventgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// Config configures the generated code.
+type Config struct {
+	// Package is the name of the generated Go package.
+	Package string
+	// Address is the account the contracts are deployed to. If it is empty, decoders are
+	// registered for the contract-qualified event identifier and match any address.
+	Address string
+}
+
+// An Event is an event declared in a Cadence contract.
+type Event struct {
+	// Contract is the name of the declaring contract or contract interface.
+	Contract string
+	// Name is the event identifier.
+	Name string
+	// Fields are the event parameters, in declaration order.
+	Fields []Field
+}
+
+// A Field is an event parameter.
+type Field struct {
+	Name string
+	// CadenceType is the Cadence type of the field, as written in the declaration.
+	CadenceType string
+	// GoType is the Go type the field decodes to.
+	GoType string
+}
+
+// QualifiedIdentifier returns the contract-qualified identifier of the event, e.g. "Token.Deposited".
+func (e Event) QualifiedIdentifier() string {
+	return e.Contract + "." + e.Name
+}
+
+// GoName returns the name of the generated struct, e.g. "TokenDeposited".
+func (e Event) GoName() string {
+	return exportedName(e.Contract) + exportedName(e.Name)
+}
+
+// Parse extracts the event declarations from Cadence source code.
+func Parse(code string) ([]Event, error) {
+	program, err := parser2.ParseProgram(code)
+	if err != nil {
+		return nil, fmt.Errorf("eventgen: failed to parse program: %w", err)
+	}
+
+	var events []Event
+
+	for _, contract := range program.CompositeDeclarations() {
+		if contract.CompositeKind != common.CompositeKindContract {
+			continue
+		}
+		events = append(events, parseEvents(contract.Identifier.Identifier, contract.Members)...)
+	}
+
+	for _, contract := range program.InterfaceDeclarations() {
+		if contract.CompositeKind != common.CompositeKindContract {
+			continue
+		}
+		events = append(events, parseEvents(contract.Identifier.Identifier, contract.Members)...)
+	}
+
+	return events, nil
+}
+
+func parseEvents(contract string, members *ast.Members) []Event {
+	var events []Event
+
+	for _, composite := range members.Composites() {
+		if composite.CompositeKind != common.CompositeKindEvent {
+			continue
+		}
+
+		event := Event{
+			Contract: contract,
+			Name:     composite.Identifier.Identifier,
+		}
+
+		// the parameters of an event declaration are the parameters of its initializer
+		for _, initializer := range composite.Members.SpecialFunctions() {
+			for _, parameter := range initializer.FunctionDeclaration.ParameterList.Parameters {
+				event.Fields = append(event.Fields, Field{
+					Name:        parameter.Identifier.Identifier,
+					CadenceType: parameter.TypeAnnotation.Type.String(),
+					GoType:      goType(parameter.TypeAnnotation.Type),
+				})
+			}
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+const cadenceValueType = "cadence.Value"
+
+var nominalTypes = map[string]string{
+	"String":    "string",
+	"Character": "string",
+	"Bool":      "bool",
+	"Address":   "flow.Address",
+	"Int8":      "int8",
+	"Int16":     "int16",
+	"Int32":     "int32",
+	"Int64":     "int64",
+	"UInt8":     "uint8",
+	"UInt16":    "uint16",
+	"UInt32":    "uint32",
+	"UInt64":    "uint64",
+	"Word8":     "uint8",
+	"Word16":    "uint16",
+	"Word32":    "uint32",
+	"Word64":    "uint64",
+	"Int":       "*big.Int",
+	"Int128":    "*big.Int",
+	"Int256":    "*big.Int",
+	"UInt":      "*big.Int",
+	"UInt128":   "*big.Int",
+	"UInt256":   "*big.Int",
+	"Fix64":     "cadence.Fix64",
+	"UFix64":    "cadence.UFix64",
+}
+
+// goType returns the Go type a Cadence type decodes to. Types without a native Go
+// representation, such as composites, decode to cadence.Value.
+func goType(t ast.Type) string {
+	switch t := t.(type) {
+	case *ast.NominalType:
+		if len(t.NestedIdentifiers) == 0 {
+			if goType, ok := nominalTypes[t.Identifier.Identifier]; ok {
+				return goType
+			}
+		}
+		return cadenceValueType
+
+	case *ast.OptionalType:
+		inner := goType(t.Type)
+		if isNilable(inner) {
+			return inner
+		}
+		return "*" + inner
+
+	case *ast.VariableSizedType:
+		return "[]" + goType(t.Type)
+
+	case *ast.ConstantSizedType:
+		return "[]" + goType(t.Type)
+
+	case *ast.DictionaryType:
+		key := goType(t.KeyType)
+		if !isComparable(key) {
+			return cadenceValueType
+		}
+		return "map[" + key + "]" + goType(t.ValueType)
+
+	default:
+		return cadenceValueType
+	}
+}
+
+func isNilable(goType string) bool {
+	return goType == cadenceValueType ||
+		strings.HasPrefix(goType, "*") ||
+		strings.HasPrefix(goType, "[]") ||
+		strings.HasPrefix(goType, "map[")
+}
+
+func isComparable(goType string) bool {
+	return !isNilable(goType)
+}
+
+// Generate generates Go code for the events declared in the given Cadence source code.
+func Generate(code string, config Config) ([]byte, error) {
+	events, err := Parse(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return GenerateEvents(events, config)
+}
+
+// GenerateEvents generates Go code for the given events.
+func GenerateEvents(events []Event, config Config) ([]byte, error) {
+	if config.Package == "" {
+		return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "eventgen: package name is required")
+	}
+
+	var address flow.Address
+	if config.Address != "" {
+		address = flow.HexToAddress(config.Address)
+		if address == flow.EmptyAddress {
+			return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "eventgen: invalid address %s", config.Address)
+		}
+	}
+
+	sorted := make([]Event, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].GoName() < sorted[j].GoName()
+	})
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// Code generated by eventgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", config.Package)
+
+	std, external := imports(sorted)
+
+	b.WriteString("import (\n")
+	for _, imp := range std {
+		fmt.Fprintf(&b, "\t%q\n", imp)
+	}
+	if len(std) > 0 {
+		b.WriteString("\n")
+	}
+	for _, imp := range external {
+		fmt.Fprintf(&b, "\t%q\n", imp)
+	}
+	b.WriteString(")\n")
+
+	for _, event := range sorted {
+		name := event.GoName()
+
+		eventType := event.QualifiedIdentifier()
+		if config.Address != "" {
+			eventType = fmt.Sprintf("A.%s.%s", address.Hex(), eventType)
+		}
+
+		fmt.Fprintf(&b, "\n// %sType is the type of the %s event.\n", name, event.QualifiedIdentifier())
+		fmt.Fprintf(&b, "const %sType = %q\n", name, eventType)
+
+		fmt.Fprintf(&b, "\n// %s is the %s event.\n", name, event.QualifiedIdentifier())
+		fmt.Fprintf(&b, "type %s struct {\n", name)
+		for _, field := range event.Fields {
+			fmt.Fprintf(&b, "\t%s %s `%s:%q` // %s\n", exportedName(field.Name), field.GoType, "cadence", field.Name, field.CadenceType)
+		}
+		b.WriteString("}\n")
+
+		fmt.Fprintf(&b, "\n// Decode%s decodes a %s event.\n", name, event.QualifiedIdentifier())
+		fmt.Fprintf(&b, "func Decode%s(event flow.Event) (*%s, error) {\n", name, name)
+		fmt.Fprintf(&b, "\tvar e %s\n", name)
+		b.WriteString("\tif err := events.DecodeEvent(event, &e); err != nil {\n\t\treturn nil, err\n\t}\n")
+		b.WriteString("\treturn &e, nil\n}\n")
+	}
+
+	if len(sorted) > 0 {
+		b.WriteString("\nfunc init() {\n")
+		for _, event := range sorted {
+			name := event.GoName()
+			fmt.Fprintf(&b, "\tevents.Register(%sType, func(event flow.Event) (interface{}, error) {\n", name)
+			fmt.Fprintf(&b, "\t\treturn Decode%s(event)\n\t})\n", name)
+		}
+		b.WriteString("}\n")
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("eventgen: failed to format generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+// imports returns the standard library and external packages used by the generated code.
+func imports(events []Event) (std []string, external []string) {
+	var usesBig, usesCadence bool
+
+	for _, event := range events {
+		for _, field := range event.Fields {
+			if strings.Contains(field.GoType, "big.Int") {
+				usesBig = true
+			}
+			if strings.Contains(field.GoType, "cadence.") {
+				usesCadence = true
+			}
+		}
+	}
+
+	if usesBig {
+		std = append(std, "math/big")
+	}
+	if usesCadence {
+		external = append(external, "github.com/onflow/cadence")
+	}
+	external = append(external, "github.com/onflow/flow-go-sdk", "github.com/onflow/flow-go-sdk/events")
+
+	return std, external
+}
+
+var initialisms = map[string]string{
+	"id":   "ID",
+	"ids":  "IDs",
+	"uuid": "UUID",
+	"url":  "URL",
+	"nft":  "NFT",
+}
+
+// exportedName converts a Cadence identifier into an exported Go identifier.
+func exportedName(identifier string) string {
+	if initialism, ok := initialisms[identifier]; ok {
+		return initialism
+	}
+
+	for _, suffix := range [][2]string{{"Ids", "IDs"}, {"Id", "ID"}, {"Url", "URL"}} {
+		if strings.HasSuffix(identifier, suffix[0]) && len(identifier) > len(suffix[0]) {
+			identifier = strings.TrimSuffix(identifier, suffix[0]) + suffix[1]
+			break
+		}
+	}
+
+	runes := []rune(identifier)
+	runes[0] = unicode.ToUpper(runes[0])
+
+	return string(runes)
+}
@@ -0,0 +1,115 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventgen_test
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/events/eventgen"
+)
+
+const tokenContract = `
+pub contract Token {
+    pub event TokensDeposited(amount: UFix64, to: Address?)
+    pub event Minted(id: UInt64, tokenIds: [UInt64], meta: {String: String}, supply: UInt256?, vault: @Vault?)
+
+    pub resource Vault {}
+}
+`
+
+func TestParse(t *testing.T) {
+	t.Run("Contract", func(t *testing.T) {
+		events, err := eventgen.Parse(tokenContract)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+
+		deposited := events[0]
+		assert.Equal(t, "Token.TokensDeposited", deposited.QualifiedIdentifier())
+		assert.Equal(t, "TokenTokensDeposited", deposited.GoName())
+		assert.Equal(t, []eventgen.Field{
+			{Name: "amount", CadenceType: "UFix64", GoType: "cadence.UFix64"},
+			{Name: "to", CadenceType: "Address?", GoType: "*flow.Address"},
+		}, deposited.Fields)
+
+		minted := events[1]
+		require.Len(t, minted.Fields, 5)
+		assert.Equal(t, "uint64", minted.Fields[0].GoType)
+		assert.Equal(t, "[]uint64", minted.Fields[1].GoType)
+		assert.Equal(t, "map[string]string", minted.Fields[2].GoType)
+		assert.Equal(t, "*big.Int", minted.Fields[3].GoType)
+		assert.Equal(t, "cadence.Value", minted.Fields[4].GoType)
+	})
+
+	t.Run("Contract interface", func(t *testing.T) {
+		events, err := eventgen.Parse(`
+			pub contract interface FungibleToken {
+				pub event TokensWithdrawn(amount: UFix64, from: Address?)
+			}
+		`)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+
+		assert.Equal(t, "FungibleToken.TokensWithdrawn", events[0].QualifiedIdentifier())
+	})
+
+	t.Run("Invalid program", func(t *testing.T) {
+		_, err := eventgen.Parse("pub contract {")
+		assert.Error(t, err)
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("With address", func(t *testing.T) {
+		src, err := eventgen.Generate(tokenContract, eventgen.Config{
+			Package: "tokens",
+			Address: "0x01",
+		})
+		require.NoError(t, err)
+
+		_, err = parser.ParseFile(token.NewFileSet(), "events_gen.go", src, 0)
+		require.NoError(t, err)
+
+		code := string(src)
+		assert.Contains(t, code, "// Code generated by eventgen. DO NOT EDIT.")
+		assert.Contains(t, code, "package tokens")
+		assert.Contains(t, code, `"math/big"`)
+		assert.Contains(t, code, `const TokenTokensDepositedType = "A.0000000000000001.Token.TokensDeposited"`)
+		assert.Contains(t, code, "type TokenTokensDeposited struct {")
+		assert.Contains(t, code, "func DecodeTokenMinted(event flow.Event) (*TokenMinted, error) {")
+		assert.Contains(t, code, "events.Register(TokenMintedType,")
+		assert.Contains(t, code, "TokenIDs []uint64")
+	})
+
+	t.Run("Without address", func(t *testing.T) {
+		src, err := eventgen.Generate(tokenContract, eventgen.Config{Package: "tokens"})
+		require.NoError(t, err)
+
+		assert.Contains(t, string(src), `const TokenTokensDepositedType = "Token.TokensDeposited"`)
+	})
+
+	t.Run("Missing package", func(t *testing.T) {
+		_, err := eventgen.Generate(tokenContract, eventgen.Config{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,54 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package events provides utilities for consuming Flow events at scale.
+package events
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// A Client is the subset of the Flow Access API client used to query events.
+//
+// It is satisfied by *client.Client.
+type Client interface {
+	GetEventsForHeightRangeWithFilter(
+		ctx context.Context,
+		filter flow.EventFilter,
+		startHeight uint64,
+		endHeight uint64,
+		opts ...grpc.CallOption,
+	) ([]client.BlockEvents, error)
+}
+
+// A Handler processes the events of a single block.
+//
+// Handlers are called once per block, in increasing height order. Returning an
+// error stops processing.
+type Handler func(ctx context.Context, block client.BlockEvents) error
+
+// isTransient returns true if the error is likely to succeed when retried.
+func isTransient(err error) bool {
+	return flowerrors.IsTransient(err)
+}
@@ -0,0 +1,109 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventstore
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// decodeEvent reconstructs an event from its stored fields.
+func decodeEvent(
+	eventType string,
+	transactionID flow.Identifier,
+	transactionIndex int,
+	eventIndex int,
+	payload []byte,
+) (flow.Event, error) {
+	value, err := jsoncdc.Decode(payload)
+	if err != nil {
+		return flow.Event{}, fmt.Errorf("eventstore: failed to decode event payload: %w", err)
+	}
+
+	eventValue, ok := value.(cadence.Event)
+	if !ok {
+		return flow.Event{}, fmt.Errorf("eventstore: expected Event value, got %T", value)
+	}
+
+	return flow.Event{
+		Type:             eventType,
+		TransactionID:    transactionID,
+		TransactionIndex: transactionIndex,
+		EventIndex:       eventIndex,
+		Value:            eventValue,
+		Payload:          payload,
+	}, nil
+}
+
+// fileRecord is the representation of a record in a FileStore log.
+type fileRecord struct {
+	BlockID          string    `json:"blockId"`
+	Height           uint64    `json:"height"`
+	BlockTimestamp   time.Time `json:"blockTimestamp"`
+	Type             string    `json:"type"`
+	TransactionID    string    `json:"transactionId"`
+	TransactionIndex int       `json:"transactionIndex"`
+	EventIndex       int       `json:"eventIndex"`
+	Payload          []byte    `json:"payload"`
+	Addresses        []string  `json:"addresses"`
+}
+
+func toFileRecord(r Record) fileRecord {
+	addresses := make([]string, len(r.Addresses))
+	for i, address := range r.Addresses {
+		addresses[i] = address.Hex()
+	}
+
+	return fileRecord{
+		BlockID:          r.BlockID.Hex(),
+		Height:           r.Height,
+		BlockTimestamp:   r.BlockTimestamp,
+		Type:             r.Event.Type,
+		TransactionID:    r.Event.TransactionID.Hex(),
+		TransactionIndex: r.Event.TransactionIndex,
+		EventIndex:       r.Event.EventIndex,
+		Payload:          r.Event.Payload,
+		Addresses:        addresses,
+	}
+}
+
+func fromFileRecord(f fileRecord) (Record, error) {
+	event, err := decodeEvent(f.Type, flow.HexToID(f.TransactionID), f.TransactionIndex, f.EventIndex, f.Payload)
+	if err != nil {
+		return Record{}, err
+	}
+
+	addresses := make([]flow.Address, len(f.Addresses))
+	for i, address := range f.Addresses {
+		addresses[i] = flow.HexToAddress(address)
+	}
+
+	return Record{
+		BlockID:        flow.HexToID(f.BlockID),
+		Height:         f.Height,
+		BlockTimestamp: f.BlockTimestamp,
+		Event:          event,
+		Addresses:      addresses,
+	}, nil
+}
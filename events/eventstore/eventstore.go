@@ -0,0 +1,226 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package eventstore provides a local index of Flow events.
+//
+// Events are ingested from any event source (a Poller, a Dispatcher subscription or a
+// Backfill) and can be queried by type, address and height range without further
+// round trips to an access node.
+package eventstore
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+)
+
+// A Record is an indexed event together with the block it was emitted in.
+type Record struct {
+	BlockID        flow.Identifier
+	Height         uint64
+	BlockTimestamp time.Time
+	Event          flow.Event
+	// Addresses are the accounts the event relates to: the account of the emitting
+	// contract and all addresses contained in the event fields.
+	Addresses []flow.Address
+}
+
+// A Query selects indexed events.
+//
+// Empty criteria are ignored, so the zero Query selects all events.
+type Query struct {
+	// Types restricts the results to the given event types.
+	Types []string
+	// Addresses restricts the results to events related to any of the given accounts.
+	Addresses []flow.Address
+	// StartHeight is the lowest block height to include.
+	StartHeight uint64
+	// EndHeight is the highest block height to include. Zero means no upper bound.
+	EndHeight uint64
+	// Limit is the maximum number of records returned. Zero means no limit.
+	Limit int
+}
+
+// A Store is a queryable index of events.
+//
+// Implementations must be safe for concurrent use and must return records in chain
+// order: by height, transaction index and event index.
+type Store interface {
+	// Put indexes the given records. Records that are already indexed are ignored.
+	Put(ctx context.Context, records ...Record) error
+	// Query returns the records matching the query.
+	Query(ctx context.Context, query Query) ([]Record, error)
+	// Close releases the resources held by the store.
+	Close() error
+}
+
+// Ingest returns a handler that indexes the events of every block it receives into the store.
+//
+// The returned function can be passed to events.Poller.Run, events.Backfill or
+// events.Dispatcher.SubscribeFunc.
+func Ingest(store Store) func(ctx context.Context, block client.BlockEvents) error {
+	return func(ctx context.Context, block client.BlockEvents) error {
+		records := make([]Record, len(block.Events))
+		for i, event := range block.Events {
+			records[i] = NewRecord(block, event)
+		}
+
+		return store.Put(ctx, records...)
+	}
+}
+
+// NewRecord creates a record for an event emitted in the given block.
+func NewRecord(block client.BlockEvents, event flow.Event) Record {
+	return Record{
+		BlockID:        block.BlockID,
+		Height:         block.Height,
+		BlockTimestamp: block.BlockTimestamp,
+		Event:          event,
+		Addresses:      eventAddresses(event),
+	}
+}
+
+// eventAddresses returns the deduplicated addresses related to an event.
+func eventAddresses(event flow.Event) []flow.Address {
+	seen := make(map[flow.Address]struct{})
+	var addresses []flow.Address
+
+	add := func(address flow.Address) {
+		if _, ok := seen[address]; ok {
+			return
+		}
+		seen[address] = struct{}{}
+		addresses = append(addresses, address)
+	}
+
+	if address, ok := contractAddress(event.Type); ok {
+		add(address)
+	}
+
+	for _, field := range event.Value.Fields {
+		if optional, ok := field.(cadence.Optional); ok {
+			field = optional.Value
+		}
+
+		if address, ok := field.(cadence.Address); ok {
+			add(flow.BytesToAddress(address.Bytes()))
+		}
+	}
+
+	return addresses
+}
+
+func contractAddress(eventType string) (flow.Address, bool) {
+	// event types of contracts are A.<address>.<contract>.<event>
+	if len(eventType) < 2 || eventType[:2] != "A." {
+		return flow.EmptyAddress, false
+	}
+
+	rest := eventType[2:]
+	for i := 0; i < len(rest); i++ {
+		if rest[i] == '.' {
+			return flow.HexToAddress(rest[:i]), true
+		}
+	}
+
+	return flow.EmptyAddress, false
+}
+
+// Matches returns true if the record satisfies the query criteria, ignoring the limit.
+func (q Query) Matches(r Record) bool {
+	if r.Height < q.StartHeight {
+		return false
+	}
+
+	if q.EndHeight != 0 && r.Height > q.EndHeight {
+		return false
+	}
+
+	if len(q.Types) > 0 && !containsString(q.Types, r.Event.Type) {
+		return false
+	}
+
+	if len(q.Addresses) > 0 {
+		found := false
+		for _, address := range r.Addresses {
+			if containsAddress(q.Addresses, address) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsString(l []string, s string) bool {
+	for _, item := range l {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAddress(l []flow.Address, a flow.Address) bool {
+	for _, item := range l {
+		if item == a {
+			return true
+		}
+	}
+	return false
+}
+
+// recordKey uniquely identifies a record.
+type recordKey struct {
+	BlockID          flow.Identifier
+	TransactionIndex int
+	EventIndex       int
+}
+
+func keyOf(r Record) recordKey {
+	return recordKey{
+		BlockID:          r.BlockID,
+		TransactionIndex: r.Event.TransactionIndex,
+		EventIndex:       r.Event.EventIndex,
+	}
+}
+
+func less(a, b Record) bool {
+	if a.Height != b.Height {
+		return a.Height < b.Height
+	}
+	if a.Event.TransactionIndex != b.Event.TransactionIndex {
+		return a.Event.TransactionIndex < b.Event.TransactionIndex
+	}
+	return a.Event.EventIndex < b.Event.EventIndex
+}
+
+func sortRecords(records []Record) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return less(records[i], records[j])
+	})
+}
@@ -0,0 +1,279 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventstore_test
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/events/eventstore"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+var (
+	contractAddress = flow.HexToAddress("01")
+	userAddress     = flow.HexToAddress("02")
+)
+
+// depositEvent creates an A.01.Token.Deposited event with the given recipient.
+func depositEvent(t *testing.T, to flow.Address, transactionIndex, eventIndex int) flow.Event {
+	location := common.AddressLocation{
+		Address: common.Address(contractAddress),
+		Name:    "Token",
+	}
+
+	eventType := &cadence.EventType{
+		Location:            location,
+		QualifiedIdentifier: "Token.Deposited",
+		Fields: []cadence.Field{
+			{Identifier: "amount", Type: cadence.UInt64Type{}},
+			{Identifier: "to", Type: cadence.OptionalType{Type: cadence.AddressType{}}},
+		},
+	}
+
+	value := cadence.NewEvent([]cadence.Value{
+		cadence.NewUInt64(10),
+		cadence.NewOptional(cadence.NewAddress(to)),
+	}).WithType(eventType)
+
+	payload, err := jsoncdc.Encode(value)
+	require.NoError(t, err)
+
+	return flow.Event{
+		Type:             string(location.TypeID("Token.Deposited")),
+		TransactionID:    test.IdentifierGenerator().New(),
+		TransactionIndex: transactionIndex,
+		EventIndex:       eventIndex,
+		Value:            value,
+		Payload:          payload,
+	}
+}
+
+func blockAt(height uint64, events ...flow.Event) client.BlockEvents {
+	var id flow.Identifier
+	id[0] = byte(height)
+
+	return client.BlockEvents{
+		BlockID:        id,
+		Height:         height,
+		BlockTimestamp: time.Unix(int64(height), 0).UTC(),
+		Events:         events,
+	}
+}
+
+func ingestFixtures(t *testing.T, store eventstore.Store) (flow.Event, flow.Event) {
+	ctx := context.Background()
+
+	other := test.EventGenerator().New()
+	other.TransactionIndex = 0
+	other.EventIndex = 0
+	deposit := depositEvent(t, userAddress, 0, 1)
+
+	ingest := eventstore.Ingest(store)
+
+	require.NoError(t, ingest(ctx, blockAt(1, other)))
+	require.NoError(t, ingest(ctx, blockAt(2, deposit)))
+	require.NoError(t, ingest(ctx, blockAt(3, deposit, other)))
+
+	// ingesting the same block again is a no-op
+	require.NoError(t, ingest(ctx, blockAt(2, deposit)))
+
+	return other, deposit
+}
+
+func testStore(t *testing.T, store eventstore.Store) {
+	ctx := context.Background()
+
+	other, deposit := ingestFixtures(t, store)
+
+	t.Run("All", func(t *testing.T) {
+		records, err := store.Query(ctx, eventstore.Query{})
+		require.NoError(t, err)
+		require.Len(t, records, 4)
+
+		assert.Equal(t, uint64(1), records[0].Height)
+		assert.Equal(t, uint64(2), records[1].Height)
+		assert.Equal(t, uint64(3), records[2].Height)
+		assert.Equal(t, other.Type, records[2].Event.Type)
+		assert.Equal(t, deposit.Type, records[3].Event.Type)
+	})
+
+	t.Run("By type", func(t *testing.T) {
+		records, err := store.Query(ctx, eventstore.Query{Types: []string{deposit.Type}})
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+
+		assert.Equal(t, deposit.Value, records[0].Event.Value)
+		assert.Equal(t, deposit.Payload, records[0].Event.Payload)
+		assert.Equal(t, time.Unix(2, 0).UTC(), records[0].BlockTimestamp)
+	})
+
+	t.Run("By address", func(t *testing.T) {
+		for _, address := range []flow.Address{contractAddress, userAddress} {
+			records, err := store.Query(ctx, eventstore.Query{Addresses: []flow.Address{address}})
+			require.NoError(t, err)
+			require.Len(t, records, 2)
+			assert.Equal(t, deposit.Type, records[0].Event.Type)
+		}
+
+		records, err := store.Query(ctx, eventstore.Query{Addresses: []flow.Address{flow.HexToAddress("03")}})
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+
+	t.Run("By height range", func(t *testing.T) {
+		records, err := store.Query(ctx, eventstore.Query{StartHeight: 2, EndHeight: 2})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, uint64(2), records[0].Height)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		records, err := store.Query(ctx, eventstore.Query{StartHeight: 2, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, uint64(2), records[0].Height)
+		assert.Equal(t, uint64(3), records[1].Height)
+	})
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := eventstore.NewMemoryStore()
+	defer store.Close()
+
+	testStore(t, store)
+}
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eventstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "events.log")
+
+	store, err := eventstore.OpenFile(path)
+	require.NoError(t, err)
+
+	testStore(t, store)
+	require.NoError(t, store.Close())
+
+	t.Run("Reopen", func(t *testing.T) {
+		store, err := eventstore.OpenFile(path)
+		require.NoError(t, err)
+		defer store.Close()
+
+		testStore(t, store)
+	})
+
+	t.Run("Incomplete last record", func(t *testing.T) {
+		log, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+
+		err = ioutil.WriteFile(path, append(log, []byte(`{"blockId":"01`)...), 0600)
+		require.NoError(t, err)
+
+		store, err := eventstore.OpenFile(path)
+		require.NoError(t, err)
+		testStore(t, store)
+
+		deposit := depositEvent(t, userAddress, 1, 0)
+		require.NoError(t, eventstore.Ingest(store)(context.Background(), blockAt(4, deposit)))
+		require.NoError(t, store.Close())
+
+		store, err = eventstore.OpenFile(path)
+		require.NoError(t, err)
+		defer store.Close()
+
+		records, err := store.Query(context.Background(), eventstore.Query{StartHeight: 4})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, deposit.TransactionID, records[0].Event.TransactionID)
+	})
+
+	t.Run("Failed write", func(t *testing.T) {
+		store, err := eventstore.OpenFile(path)
+		require.NoError(t, err)
+		require.NoError(t, store.Close())
+
+		// Writing to the closed log fails, and the record is not indexed.
+		deposit := depositEvent(t, userAddress, 2, 0)
+		err = eventstore.Ingest(store)(context.Background(), blockAt(5, deposit))
+		require.Error(t, err)
+
+		records, err := store.Query(context.Background(), eventstore.Query{StartHeight: 5})
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+
+	t.Run("Corrupt log", func(t *testing.T) {
+		err := ioutil.WriteFile(path, []byte("not json\n"), 0600)
+		require.NoError(t, err)
+
+		_, err = eventstore.OpenFile(path)
+		assert.Error(t, err)
+	})
+}
+
+func TestSQLStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eventstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "events.db")
+
+	openStore := func(t *testing.T) *eventstore.SQLStore {
+		db, err := sql.Open("sqlite3", path)
+		require.NoError(t, err)
+
+		if err := db.Ping(); err != nil {
+			_ = db.Close()
+			t.Skipf("sqlite3 driver unavailable: %v", err)
+		}
+
+		store, err := eventstore.NewSQLStore(context.Background(), db)
+		require.NoError(t, err)
+
+		return store
+	}
+
+	store := openStore(t)
+	testStore(t, store)
+	require.NoError(t, store.Close())
+
+	t.Run("Reopen", func(t *testing.T) {
+		store := openStore(t)
+		defer store.Close()
+
+		testStore(t, store)
+	})
+}
@@ -0,0 +1,144 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventstore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// A FileStore is an embedded Store that persists records to an append-only log file.
+//
+// The log is loaded into an in-memory index when the store is opened, which makes
+// the FileStore suitable for small applications that need fast local lookups
+// without running a database.
+//
+// Records are indexed only once they are written to the log, so a failed Put can be
+// retried. An incomplete last record, left by a crash while writing, is discarded
+// when the store is opened.
+type FileStore struct {
+	*MemoryStore
+	file *os.File
+	// size is the length of the complete records in the log.
+	size int64
+}
+
+// OpenFile opens the store at the given path, creating the log file if it does not exist.
+func OpenFile(path string) (*FileStore, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	memory := NewMemoryStore()
+
+	r := bufio.NewReader(file)
+	var size int64
+
+	for line := 1; ; line++ {
+		b, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+
+		var f fileRecord
+
+		err = json.Unmarshal(b, &f)
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("eventstore: failed to decode %s:%d: %w", path, line, err)
+		}
+
+		record, err := fromFileRecord(f)
+		if err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+
+		memory.put([]Record{record})
+		size += int64(len(b))
+	}
+
+	// Drop the incomplete record left by an interrupted write, if any, so that
+	// the next record starts on its own line.
+	err = file.Truncate(size)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return &FileStore{
+		MemoryStore: memory,
+		file:        file,
+		size:        size,
+	}, nil
+}
+
+func (s *FileStore) Put(_ context.Context, records ...Record) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	added := s.missing(records)
+	if len(added) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, record := range added {
+		b, err := json.Marshal(toFileRecord(record))
+		if err != nil {
+			return err
+		}
+
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+
+	_, err := s.file.Write(buf.Bytes())
+	if err == nil {
+		err = s.file.Sync()
+	}
+	if err != nil {
+		// Remove what was written of the records, which are not indexed and are
+		// written again when the Put is retried.
+		_ = s.file.Truncate(s.size)
+		return err
+	}
+
+	s.size += int64(buf.Len())
+	s.put(added)
+
+	return nil
+}
+
+func (s *FileStore) Close() error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	return s.file.Close()
+}
@@ -0,0 +1,161 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventstore
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// A MemoryStore is a Store that keeps all records in memory.
+//
+// Records are indexed by type and address, so queries only scan the candidate records.
+type MemoryStore struct {
+	mut       sync.RWMutex
+	records   []Record
+	keys      map[recordKey]struct{}
+	byType    map[string][]int
+	byAddress map[flow.Address][]int
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		keys:      make(map[recordKey]struct{}),
+		byType:    make(map[string][]int),
+		byAddress: make(map[flow.Address][]int),
+	}
+}
+
+func (s *MemoryStore) Put(_ context.Context, records ...Record) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.put(records)
+
+	return nil
+}
+
+// missing returns the records that are not indexed yet, without duplicates.
+// It must be called with the lock held.
+func (s *MemoryStore) missing(records []Record) []Record {
+	var missing []Record
+	seen := make(map[recordKey]struct{})
+
+	for _, record := range records {
+		key := keyOf(record)
+		if _, ok := s.keys[key]; ok {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		missing = append(missing, record)
+	}
+
+	return missing
+}
+
+// put indexes the records and returns the ones that were not indexed yet.
+// It must be called with the lock held.
+func (s *MemoryStore) put(records []Record) []Record {
+	var added []Record
+
+	for _, record := range records {
+		key := keyOf(record)
+		if _, ok := s.keys[key]; ok {
+			continue
+		}
+
+		s.keys[key] = struct{}{}
+
+		i := len(s.records)
+		s.records = append(s.records, record)
+
+		s.byType[record.Event.Type] = append(s.byType[record.Event.Type], i)
+		for _, address := range record.Addresses {
+			s.byAddress[address] = append(s.byAddress[address], i)
+		}
+
+		added = append(added, record)
+	}
+
+	return added
+}
+
+func (s *MemoryStore) Query(_ context.Context, query Query) ([]Record, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	results := make([]Record, 0)
+	for _, i := range s.candidates(query) {
+		record := s.records[i]
+		if query.Matches(record) {
+			results = append(results, record)
+		}
+	}
+
+	sortRecords(results)
+
+	if query.Limit > 0 && len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+
+	return results, nil
+}
+
+// candidates returns the indices of the records that may match the query.
+func (s *MemoryStore) candidates(query Query) []int {
+	var indices []int
+
+	switch {
+	case len(query.Types) > 0:
+		for _, eventType := range query.Types {
+			indices = append(indices, s.byType[eventType]...)
+		}
+	case len(query.Addresses) > 0:
+		seen := make(map[int]struct{})
+		for _, address := range query.Addresses {
+			for _, i := range s.byAddress[address] {
+				if _, ok := seen[i]; !ok {
+					seen[i] = struct{}{}
+					indices = append(indices, i)
+				}
+			}
+		}
+	default:
+		indices = make([]int, len(s.records))
+		for i := range indices {
+			indices[i] = i
+		}
+	}
+
+	sort.Ints(indices)
+
+	return indices
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,214 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// sqlSchema is the schema of a SQLStore, written in the SQLite dialect.
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS events (
+		block_id TEXT NOT NULL,
+		height INTEGER NOT NULL,
+		block_timestamp INTEGER NOT NULL,
+		type TEXT NOT NULL,
+		transaction_id TEXT NOT NULL,
+		transaction_index INTEGER NOT NULL,
+		event_index INTEGER NOT NULL,
+		payload BLOB NOT NULL,
+		PRIMARY KEY (block_id, transaction_index, event_index)
+	)`,
+	`CREATE INDEX IF NOT EXISTS events_type_height ON events (type, height)`,
+	`CREATE INDEX IF NOT EXISTS events_height ON events (height)`,
+	`CREATE TABLE IF NOT EXISTS event_addresses (
+		block_id TEXT NOT NULL,
+		transaction_index INTEGER NOT NULL,
+		event_index INTEGER NOT NULL,
+		address TEXT NOT NULL,
+		PRIMARY KEY (address, block_id, transaction_index, event_index)
+	)`,
+}
+
+// A SQLStore is a Store backed by a SQLite database.
+//
+// The SDK does not depend on a SQLite driver; open the database with the driver
+// of your choice (e.g. github.com/mattn/go-sqlite3 or modernc.org/sqlite) and pass
+// it to NewSQLStore.
+type SQLStore struct {
+	db *sql.DB
+}
+
+// NewSQLStore creates a store on the given database, creating its tables if needed.
+func NewSQLStore(ctx context.Context, db *sql.DB) (*SQLStore, error) {
+	for _, stmt := range sqlSchema {
+		_, err := db.ExecContext(ctx, stmt)
+		if err != nil {
+			return nil, fmt.Errorf("eventstore: failed to create schema: %w", err)
+		}
+	}
+
+	return &SQLStore{db: db}, nil
+}
+
+func (s *SQLStore) Put(ctx context.Context, records ...Record) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for _, r := range records {
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT OR IGNORE INTO events
+				(block_id, height, block_timestamp, type, transaction_id, transaction_index, event_index, payload)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			r.BlockID.Hex(),
+			int64(r.Height),
+			r.BlockTimestamp.UnixNano(),
+			r.Event.Type,
+			r.Event.TransactionID.Hex(),
+			r.Event.TransactionIndex,
+			r.Event.EventIndex,
+			r.Event.Payload,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, address := range r.Addresses {
+			_, err = tx.ExecContext(
+				ctx,
+				`INSERT OR IGNORE INTO event_addresses
+					(block_id, transaction_index, event_index, address)
+					VALUES (?, ?, ?, ?)`,
+				r.BlockID.Hex(),
+				r.Event.TransactionIndex,
+				r.Event.EventIndex,
+				address.Hex(),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLStore) Query(ctx context.Context, query Query) ([]Record, error) {
+	var where []string
+	var args []interface{}
+
+	where = append(where, "e.height >= ?")
+	args = append(args, int64(query.StartHeight))
+
+	if query.EndHeight != 0 {
+		where = append(where, "e.height <= ?")
+		args = append(args, int64(query.EndHeight))
+	}
+
+	if len(query.Types) > 0 {
+		where = append(where, "e.type IN ("+placeholders(len(query.Types))+")")
+		for _, eventType := range query.Types {
+			args = append(args, eventType)
+		}
+	}
+
+	if len(query.Addresses) > 0 {
+		where = append(where, `EXISTS (SELECT 1 FROM event_addresses a
+			WHERE a.block_id = e.block_id
+			AND a.transaction_index = e.transaction_index
+			AND a.event_index = e.event_index
+			AND a.address IN (`+placeholders(len(query.Addresses))+`))`)
+		for _, address := range query.Addresses {
+			args = append(args, address.Hex())
+		}
+	}
+
+	stmt := `SELECT e.block_id, e.height, e.block_timestamp, e.type, e.transaction_id,
+			e.transaction_index, e.event_index, e.payload
+		FROM events e
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY e.height, e.transaction_index, e.event_index`
+
+	if query.Limit > 0 {
+		stmt += " LIMIT ?"
+		args = append(args, query.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]Record, 0)
+
+	for rows.Next() {
+		var (
+			blockID, eventType, transactionID string
+			height, timestamp                 int64
+			transactionIndex, eventIndex      int
+			payload                           []byte
+		)
+
+		err := rows.Scan(&blockID, &height, &timestamp, &eventType, &transactionID, &transactionIndex, &eventIndex, &payload)
+		if err != nil {
+			return nil, err
+		}
+
+		event, err := decodeEvent(eventType, flow.HexToID(transactionID), transactionIndex, eventIndex, payload)
+		if err != nil {
+			return nil, err
+		}
+
+		record := Record{
+			BlockID:        flow.HexToID(blockID),
+			Height:         uint64(height),
+			BlockTimestamp: time.Unix(0, timestamp).UTC(),
+			Event:          event,
+		}
+		record.Addresses = eventAddresses(event)
+
+		results = append(results, record)
+	}
+
+	return results, rows.Err()
+}
+
+// Close closes the underlying database.
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
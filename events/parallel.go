@@ -0,0 +1,185 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// DefaultWorkers is the default number of concurrent queries made by BackfillParallel.
+const DefaultWorkers = 4
+
+// WithWorkers sets the number of chunks BackfillParallel fetches concurrently.
+func WithWorkers(workers int) BackfillOption {
+	return func(c *backfillConfig) {
+		c.workers = workers
+	}
+}
+
+// chunk is a height range fetched by a worker.
+type chunk struct {
+	start  uint64
+	end    uint64
+	result chan chunkResult
+}
+
+type chunkResult struct {
+	blocks []client.BlockEvents
+	err    error
+}
+
+// BackfillParallel behaves like Backfill, but fetches chunks of the range concurrently.
+//
+// The range is split into chunks that are distributed across the configured number of
+// workers. Results are buffered and passed to the handler strictly in height order, so
+// handlers written for Backfill can be used unchanged. At most twice as many chunks as
+// there are workers are held in memory at any time.
+//
+// The rate limit configured with WithRateLimit is shared by all workers.
+func BackfillParallel(
+	ctx context.Context,
+	flowClient Client,
+	filter flow.EventFilter,
+	fromHeight uint64,
+	toHeight uint64,
+	handler Handler,
+	opts ...BackfillOption,
+) error {
+	cfg, err := newBackfillConfig(fromHeight, toHeight, opts)
+	if err != nil {
+		return err
+	}
+
+	if cfg.workers <= 0 {
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "events: number of workers must be greater than zero")
+	}
+
+	throttle, stop := cfg.throttle()
+	defer stop()
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+	defer func() {
+		// stop the producer and the workers before returning
+		cancel()
+		wg.Wait()
+	}()
+
+	jobs := make(chan chunk)
+	ordered := make(chan chunk, cfg.workers)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		defer close(ordered)
+
+		for start := fromHeight; start <= toHeight; {
+			end := cfg.chunkEnd(start, toHeight)
+
+			c := chunk{
+				start:  start,
+				end:    end,
+				result: make(chan chunkResult, 1),
+			}
+
+			// chunks are queued for the handler before they are fetched,
+			// which bounds the number of buffered results
+			select {
+			case <-ctx.Done():
+				return
+			case ordered <- c:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- c:
+			}
+
+			if end == toHeight {
+				break
+			}
+
+			start = end + 1
+		}
+	}()
+
+	for i := 0; i < cfg.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for c := range jobs {
+				start, end := c.start, c.end
+
+				blocks, err := fetchWithRetry(ctx, cfg, throttle, queryCount(filter), func() ([]client.BlockEvents, error) {
+					return flowClient.GetEventsForHeightRangeWithFilter(ctx, filter, start, end)
+				})
+
+				c.result <- chunkResult{blocks: blocks, err: err}
+			}
+		}()
+	}
+
+	started := time.Now()
+	progress := Progress{
+		StartHeight: fromHeight,
+		EndHeight:   toHeight,
+	}
+
+	for c := range ordered {
+		var result chunkResult
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case result = <-c.result:
+		}
+
+		if result.err != nil {
+			return fmt.Errorf("events: failed to fetch events for heights %d-%d: %w", c.start, c.end, result.err)
+		}
+
+		for _, block := range result.blocks {
+			err := handler(ctx, block)
+			if err != nil {
+				return err
+			}
+
+			progress.BlocksProcessed++
+		}
+
+		progress.Height = c.end
+		progress.Elapsed = time.Since(started)
+		if cfg.onProgress != nil {
+			cfg.onProgress(progress)
+		}
+	}
+
+	return ctx.Err()
+}
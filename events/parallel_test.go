@@ -0,0 +1,173 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/events"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+func TestBackfillParallel(t *testing.T) {
+	ctx := context.Background()
+	filter := flow.EventFilter{EventTypes: []string{"foo"}}
+
+	t.Run("Ordered output", func(t *testing.T) {
+		c := &fakeClient{latency: time.Millisecond}
+
+		var heights []uint64
+		var progress []events.Progress
+
+		err := events.BackfillParallel(
+			ctx, c, filter, 10, 109,
+			func(_ context.Context, block client.BlockEvents) error {
+				heights = append(heights, block.Height)
+				return nil
+			},
+			events.WithChunkSize(10),
+			events.WithWorkers(4),
+			events.WithProgress(func(p events.Progress) {
+				progress = append(progress, p)
+			}),
+		)
+		require.NoError(t, err)
+
+		require.Len(t, heights, 100)
+		for i, height := range heights {
+			assert.Equal(t, uint64(10+i), height)
+		}
+
+		ranges := c.ranges
+		sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+		require.Len(t, ranges, 10)
+		assert.Equal(t, [2]uint64{100, 109}, ranges[9])
+
+		require.Len(t, progress, 10)
+		last := progress[9]
+		assert.Positive(t, last.Elapsed)
+		last.Elapsed = 0
+		assert.Equal(t, events.Progress{StartHeight: 10, EndHeight: 109, Height: 109, BlocksProcessed: 100}, last)
+	})
+
+	t.Run("Retries transient errors", func(t *testing.T) {
+		c := &fakeClient{
+			failures: 2,
+			err:      client.RPCError{GRPCErr: status.Error(codes.Unavailable, "unavailable")},
+		}
+
+		var count int
+		err := events.BackfillParallel(
+			ctx, c, filter, 1, 50,
+			func(context.Context, client.BlockEvents) error {
+				count++
+				return nil
+			},
+			events.WithChunkSize(5),
+			events.WithRetry(3, time.Millisecond),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 50, count)
+	})
+
+	t.Run("Fetch error", func(t *testing.T) {
+		c := &fakeClient{
+			failures: 1,
+			err:      client.RPCError{GRPCErr: status.Error(codes.InvalidArgument, "invalid")},
+		}
+
+		err := events.BackfillParallel(
+			ctx, c, filter, 1, 50,
+			func(context.Context, client.BlockEvents) error { return nil },
+			events.WithChunkSize(5),
+		)
+		assert.Error(t, err)
+	})
+
+	t.Run("Handler error", func(t *testing.T) {
+		c := &fakeClient{}
+		handlerErr := errors.New("handler failed")
+
+		err := events.BackfillParallel(
+			ctx, c, filter, 1, 1000,
+			func(_ context.Context, block client.BlockEvents) error {
+				if block.Height == 20 {
+					return handlerErr
+				}
+				return nil
+			},
+			events.WithChunkSize(5),
+		)
+		assert.ErrorIs(t, err, handlerErr)
+	})
+
+	t.Run("Invalid workers", func(t *testing.T) {
+		err := events.BackfillParallel(
+			ctx, &fakeClient{}, filter, 1, 10,
+			func(context.Context, client.BlockEvents) error { return nil },
+			events.WithWorkers(0),
+		)
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+	})
+}
+
+// benchmarkLatency simulates the round trip time of an access node query.
+const benchmarkLatency = 10 * time.Millisecond
+
+func BenchmarkBackfill(b *testing.B) {
+	ctx := context.Background()
+	filter := flow.EventFilter{EventTypes: []string{"foo"}}
+	handler := func(context.Context, client.BlockEvents) error { return nil }
+
+	for i := 0; i < b.N; i++ {
+		c := &fakeClient{latency: benchmarkLatency}
+
+		err := events.Backfill(ctx, c, filter, 1, 2500, handler)
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkBackfillParallel(b *testing.B) {
+	ctx := context.Background()
+	filter := flow.EventFilter{EventTypes: []string{"foo"}}
+	handler := func(context.Context, client.BlockEvents) error { return nil }
+
+	for _, workers := range []int{2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("%d workers", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c := &fakeClient{latency: benchmarkLatency}
+
+				err := events.BackfillParallel(ctx, c, filter, 1, 2500, handler, events.WithWorkers(workers))
+				require.NoError(b, err)
+			}
+		})
+	}
+}
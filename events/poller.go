@@ -0,0 +1,154 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/logging"
+)
+
+// A PollerClient is the subset of the Flow Access API client used by a Poller.
+//
+// It is satisfied by *client.Client.
+type PollerClient interface {
+	Client
+	GetLatestBlockHeader(ctx context.Context, isSealed bool, opts ...grpc.CallOption) (*flow.BlockHeader, error)
+}
+
+// DefaultPollInterval is the default time between two polls of the access node.
+const DefaultPollInterval = time.Second
+
+// A Poller follows the sealed chain and delivers the events matching a filter.
+type Poller struct {
+	client      PollerClient
+	filter      flow.EventFilter
+	startHeight uint64
+	interval    time.Duration
+	backfill    []BackfillOption
+	predicate   Predicate
+	logger      logging.Logger
+}
+
+// A PollerOption configures a Poller.
+type PollerOption func(*Poller)
+
+// WithPollInterval sets the time between two polls of the access node.
+func WithPollInterval(interval time.Duration) PollerOption {
+	return func(p *Poller) {
+		p.interval = interval
+	}
+}
+
+// WithBackfillOptions sets the options used to fetch the events of newly sealed blocks.
+func WithBackfillOptions(opts ...BackfillOption) PollerOption {
+	return func(p *Poller) {
+		p.backfill = opts
+	}
+}
+
+// WithPredicate restricts the delivered events to the ones matched by the predicate.
+//
+// Blocks are still delivered when none of their events match, so that acknowledgement-based
+// delivery can advance its resume height.
+func WithPredicate(predicate Predicate) PollerOption {
+	return func(p *Poller) {
+		p.predicate = predicate
+	}
+}
+
+// WithLogger sets the logger the poller reports polls and transient errors to.
+func WithLogger(logger logging.Logger) PollerOption {
+	return func(p *Poller) {
+		p.logger = logging.OrNop(logger)
+	}
+}
+
+// NewPoller creates a poller that delivers the events matching the filter,
+// starting at the given block height.
+func NewPoller(client PollerClient, filter flow.EventFilter, startHeight uint64, opts ...PollerOption) *Poller {
+	p := &Poller{
+		client:      client,
+		filter:      filter,
+		startHeight: startHeight,
+		interval:    DefaultPollInterval,
+		logger:      logging.Nop(),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Run polls for newly sealed blocks and passes their events to the handler, block by block,
+// until the context is cancelled or the handler returns an error.
+func (p *Poller) Run(ctx context.Context, handler Handler) error {
+	return p.run(ctx, p.startHeight, handler)
+}
+
+func (p *Poller) run(ctx context.Context, nextHeight uint64, handler Handler) error {
+	if p.predicate != nil {
+		next := handler
+		handler = func(ctx context.Context, block client.BlockEvents) error {
+			return next(ctx, FilterBlock(block, p.predicate))
+		}
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		header, err := p.client.GetLatestBlockHeader(ctx, true)
+		if err != nil && !isTransient(err) {
+			return err
+		}
+
+		if err != nil {
+			p.logger.Log(logging.WarnLevel, "failed to get latest sealed block, retrying", logging.Error(err))
+		}
+
+		if err == nil && header.Height >= nextHeight {
+			p.logger.Log(logging.DebugLevel, "delivering events of sealed blocks",
+				logging.Uint64("from_height", nextHeight),
+				logging.Uint64("to_height", header.Height),
+				logging.BlockID(header.ID),
+			)
+
+			err = Backfill(ctx, p.client, p.filter, nextHeight, header.Height, handler, p.backfill...)
+			if err != nil {
+				return err
+			}
+
+			nextHeight = header.Height + 1
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
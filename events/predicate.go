@@ -0,0 +1,181 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+)
+
+// A Predicate reports whether an event is relevant to a consumer.
+//
+// Predicates complement flow.EventFilter, which is evaluated by the access node, with
+// conditions on the decoded event payload, which can only be evaluated client-side.
+type Predicate func(event flow.Event) bool
+
+// TypeIs returns a predicate that matches events of any of the given types.
+func TypeIs(types ...string) Predicate {
+	return func(event flow.Event) bool {
+		for _, eventType := range types {
+			if event.Type == eventType {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// FieldMatches returns a predicate that matches events that have a field with the given
+// name for which match returns true.
+//
+// Optional values are unwrapped before they are passed to match. A nil optional is
+// passed as nil.
+func FieldMatches(name string, match func(value cadence.Value) bool) Predicate {
+	return func(event flow.Event) bool {
+		value, ok := eventField(event, name)
+		if !ok {
+			return false
+		}
+		return match(unwrapOptional(value))
+	}
+}
+
+// FieldEquals returns a predicate that matches events with a field of the given name and value,
+// e.g. FieldEquals("to", cadence.NewAddress(address)) for TokensDeposited events.
+//
+// Optional values are unwrapped before they are compared.
+func FieldEquals(name string, value cadence.Value) Predicate {
+	expected := unwrapOptional(value)
+
+	return FieldMatches(name, func(actual cadence.Value) bool {
+		return reflect.DeepEqual(actual, expected)
+	})
+}
+
+// AddressFieldEquals returns a predicate that matches events whose address field of the
+// given name is set to address.
+func AddressFieldEquals(name string, address flow.Address) Predicate {
+	return FieldEquals(name, cadence.NewAddress(address))
+}
+
+// And returns a predicate that matches events matched by all of the given predicates.
+func And(predicates ...Predicate) Predicate {
+	return func(event flow.Event) bool {
+		for _, predicate := range predicates {
+			if !predicate(event) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a predicate that matches events matched by any of the given predicates.
+func Or(predicates ...Predicate) Predicate {
+	return func(event flow.Event) bool {
+		for _, predicate := range predicates {
+			if predicate(event) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a predicate that matches events not matched by the given predicate.
+func Not(predicate Predicate) Predicate {
+	return func(event flow.Event) bool {
+		return !predicate(event)
+	}
+}
+
+// FilterBlock returns a copy of the block containing only the events matched by the predicate.
+func FilterBlock(block client.BlockEvents, predicate Predicate) client.BlockEvents {
+	events := make([]flow.Event, 0, len(block.Events))
+	for _, event := range block.Events {
+		if predicate(event) {
+			events = append(events, event)
+		}
+	}
+
+	block.Events = events
+
+	return block
+}
+
+// Where returns a handler that passes only the events matched by the predicate to handler.
+//
+// Blocks without matching events are skipped.
+func Where(predicate Predicate, handler Handler) Handler {
+	return func(ctx context.Context, block client.BlockEvents) error {
+		block = FilterBlock(block, predicate)
+		if len(block.Events) == 0 {
+			return nil
+		}
+
+		return handler(ctx, block)
+	}
+}
+
+// eventField returns the value of the event field with the given name, decoding the
+// event payload if the event value is not available.
+func eventField(event flow.Event, name string) (cadence.Value, bool) {
+	value := event.Value
+
+	if value.EventType == nil {
+		if len(event.Payload) == 0 {
+			return nil, false
+		}
+
+		decoded, err := jsoncdc.Decode(event.Payload)
+		if err != nil {
+			return nil, false
+		}
+
+		var ok bool
+		value, ok = decoded.(cadence.Event)
+		if !ok || value.EventType == nil {
+			return nil, false
+		}
+	}
+
+	for i, field := range value.EventType.Fields {
+		if field.Identifier == name && i < len(value.Fields) {
+			return value.Fields[i], true
+		}
+	}
+
+	return nil, false
+}
+
+func unwrapOptional(value cadence.Value) cadence.Value {
+	for {
+		optional, ok := value.(cadence.Optional)
+		if !ok {
+			return value
+		}
+		value = optional.Value
+	}
+}
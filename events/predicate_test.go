@@ -0,0 +1,157 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/events"
+)
+
+func TestPredicates(t *testing.T) {
+	alice := flow.HexToAddress("02")
+	bob := flow.HexToAddress("03")
+
+	toAlice := depositedEvent(cadence.NewOptional(cadence.NewAddress(alice)))
+	toBob := depositedEvent(cadence.NewOptional(cadence.NewAddress(bob)))
+	toNobody := depositedEvent(cadence.NewOptional(nil))
+
+	t.Run("Field equals", func(t *testing.T) {
+		predicate := events.AddressFieldEquals("to", alice)
+
+		assert.True(t, predicate(toAlice))
+		assert.False(t, predicate(toBob))
+		assert.False(t, predicate(toNobody))
+
+		assert.True(t, events.FieldEquals("amount", cadence.UFix64(150000000))(toAlice))
+		assert.False(t, events.FieldEquals("missing", cadence.UFix64(150000000))(toAlice))
+	})
+
+	t.Run("Field matches", func(t *testing.T) {
+		predicate := events.FieldMatches("amount", func(value cadence.Value) bool {
+			return value.(cadence.UFix64) > 100000000
+		})
+
+		assert.True(t, predicate(toAlice))
+	})
+
+	t.Run("Decodes payload", func(t *testing.T) {
+		payload, err := jsoncdc.Encode(toAlice.Value)
+		require.NoError(t, err)
+
+		event := flow.Event{Type: toAlice.Type, Payload: payload}
+
+		assert.True(t, events.AddressFieldEquals("to", alice)(event))
+	})
+
+	t.Run("Combinators", func(t *testing.T) {
+		either := events.Or(events.AddressFieldEquals("to", alice), events.AddressFieldEquals("to", bob))
+		assert.True(t, either(toAlice))
+		assert.True(t, either(toBob))
+		assert.False(t, either(toNobody))
+
+		both := events.And(events.TypeIs(toAlice.Type), events.Not(events.AddressFieldEquals("to", alice)))
+		assert.False(t, both(toAlice))
+		assert.True(t, both(toBob))
+	})
+
+	t.Run("Where", func(t *testing.T) {
+		var delivered []client.BlockEvents
+		handler := events.Where(events.AddressFieldEquals("to", alice), func(_ context.Context, block client.BlockEvents) error {
+			delivered = append(delivered, block)
+			return nil
+		})
+
+		ctx := context.Background()
+		require.NoError(t, handler(ctx, client.BlockEvents{Height: 1, Events: []flow.Event{toBob, toAlice}}))
+		require.NoError(t, handler(ctx, client.BlockEvents{Height: 2, Events: []flow.Event{toBob}}))
+
+		require.Len(t, delivered, 1)
+		assert.Equal(t, []flow.Event{toAlice}, delivered[0].Events)
+	})
+}
+
+func TestDispatcher_SubscribeWhere(t *testing.T) {
+	alice := flow.HexToAddress("02")
+	toAlice := depositedEvent(cadence.NewOptional(cadence.NewAddress(alice)))
+	toNobody := depositedEvent(cadence.NewOptional(nil))
+
+	upstream := make(chan client.BlockEvents, 3)
+	upstream <- client.BlockEvents{Height: 1, Events: []flow.Event{toNobody}}
+	upstream <- client.BlockEvents{Height: 2, Events: []flow.Event{toNobody, toAlice}}
+	upstream <- client.BlockEvents{Height: 3}
+	close(upstream)
+
+	d := events.NewDispatcher(upstream, nil)
+	sub := d.SubscribeWhere(10, events.Block, events.AddressFieldEquals("to", alice))
+
+	require.NoError(t, d.Run(context.Background()))
+
+	var received []client.BlockEvents
+	for block := range sub.C {
+		received = append(received, block)
+	}
+
+	require.Len(t, received, 1)
+	assert.Equal(t, uint64(2), received[0].Height)
+	assert.Equal(t, []flow.Event{toAlice}, received[0].Events)
+}
+
+func TestPoller_WithPredicate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polls := 0
+	c := &fakeClient{latestHeight: 3}
+	c.onPoll = func() {
+		polls++
+		if polls > 1 {
+			cancel()
+		}
+	}
+
+	// the fake client numbers the "a" field of its events 1, 2, 3
+	p := events.NewPoller(
+		c,
+		flow.EventFilter{EventTypes: []string{"foo"}},
+		1,
+		events.WithPollInterval(time.Millisecond),
+		events.WithPredicate(events.FieldEquals("a", cadence.NewInt(2))),
+	)
+
+	var delivered []uint64
+	err := p.Run(ctx, func(_ context.Context, block client.BlockEvents) error {
+		for range block.Events {
+			delivered = append(delivered, block.Height)
+		}
+		return nil
+	})
+	assert.Equal(t, context.Canceled, err)
+
+	assert.Equal(t, []uint64{2}, delivered)
+}
@@ -0,0 +1,108 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publish
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/client/convert"
+	"github.com/onflow/flow-go-sdk/internal/protov2"
+)
+
+// An Encoder serializes events into message values.
+//
+// Custom encoders, such as one producing CCF with a Cadence version that supports it,
+// can be plugged in with WithEncoder.
+type Encoder interface {
+	Encode(block client.BlockEvents, event flow.Event) ([]byte, error)
+	// ContentType is the MIME type of the encoded values.
+	ContentType() string
+}
+
+// A JSONMessage is the value written by JSONEncoder.
+type JSONMessage struct {
+	BlockID          string          `json:"blockId"`
+	BlockHeight      uint64          `json:"blockHeight"`
+	BlockTimestamp   time.Time       `json:"blockTimestamp"`
+	Type             string          `json:"type"`
+	TransactionID    string          `json:"transactionId"`
+	TransactionIndex int             `json:"transactionIndex"`
+	EventIndex       int             `json:"eventIndex"`
+	Payload          json.RawMessage `json:"payload"`
+}
+
+// JSONEncoder encodes events as JSONMessage values, with the event payload in JSON-Cadence format.
+type JSONEncoder struct{}
+
+func (JSONEncoder) Encode(block client.BlockEvents, event flow.Event) ([]byte, error) {
+	return json.Marshal(JSONMessage{
+		BlockID:          block.BlockID.Hex(),
+		BlockHeight:      block.Height,
+		BlockTimestamp:   block.BlockTimestamp,
+		Type:             event.Type,
+		TransactionID:    event.TransactionID.Hex(),
+		TransactionIndex: event.TransactionIndex,
+		EventIndex:       event.EventIndex,
+		Payload:          event.Payload,
+	})
+}
+
+func (JSONEncoder) ContentType() string {
+	return "application/json"
+}
+
+// PayloadEncoder encodes events as their raw JSON-Cadence payload.
+//
+// Block information is only available from the message headers.
+type PayloadEncoder struct{}
+
+func (PayloadEncoder) Encode(_ client.BlockEvents, event flow.Event) ([]byte, error) {
+	if len(event.Payload) == 0 {
+		return nil, fmt.Errorf("event has no payload")
+	}
+	return event.Payload, nil
+}
+
+func (PayloadEncoder) ContentType() string {
+	return "application/json"
+}
+
+// ProtobufEncoder encodes events as flow.entities.Event protobuf messages.
+//
+// Block information is only available from the message headers.
+type ProtobufEncoder struct{}
+
+func (ProtobufEncoder) Encode(_ client.BlockEvents, event flow.Event) ([]byte, error) {
+	message, err := convert.EventToMessage(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(protov2.Message(message))
+}
+
+func (ProtobufEncoder) ContentType() string {
+	return "application/x-protobuf"
+}
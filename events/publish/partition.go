@@ -0,0 +1,81 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publish
+
+import (
+	"strings"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+)
+
+// A Partitioner returns the key of the message an event is published in.
+//
+// Message queues route messages with the same key to the same partition, which
+// preserves their relative order. A nil key leaves the choice to the sink.
+type Partitioner func(block client.BlockEvents, event flow.Event) []byte
+
+// PartitionByContract keys messages by the address of the contract that emitted the event,
+// so all events of a contract are delivered in order.
+func PartitionByContract(_ client.BlockEvents, event flow.Event) []byte {
+	// event types of contracts are A.<address>.<contract>.<event>
+	parts := strings.SplitN(event.Type, ".", 3)
+	if len(parts) != 3 || parts[0] != "A" {
+		return nil
+	}
+
+	return []byte(flow.HexToAddress(parts[1]).Hex())
+}
+
+// PartitionByType keys messages by event type.
+func PartitionByType(_ client.BlockEvents, event flow.Event) []byte {
+	return []byte(event.Type)
+}
+
+// PartitionByAddressField keys messages by the address stored in the event field
+// of the given name, e.g. "to" for TokensDeposited, so all events concerning an
+// account are delivered in order.
+//
+// Events without the field, or whose field is nil, have no key.
+func PartitionByAddressField(name string) Partitioner {
+	return func(_ client.BlockEvents, event flow.Event) []byte {
+		if event.Value.EventType == nil {
+			return nil
+		}
+
+		for i, field := range event.Value.EventType.Fields {
+			if field.Identifier != name || i >= len(event.Value.Fields) {
+				continue
+			}
+
+			value := event.Value.Fields[i]
+			if optional, ok := value.(cadence.Optional); ok {
+				value = optional.Value
+			}
+
+			if address, ok := value.(cadence.Address); ok {
+				return []byte(flow.BytesToAddress(address.Bytes()).Hex())
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,197 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package publish bridges Flow events to message queues such as Kafka and NATS.
+//
+// A Publisher is an events.Handler: every event it receives is serialized with an
+// Encoder, assigned a partition key by a Partitioner and written to a Sink. The SDK
+// does not depend on any message queue client; the sinks are defined in terms of small
+// interfaces that existing clients satisfy directly or with a few lines of glue code.
+//
+// For example, to publish to Kafka using github.com/segmentio/kafka-go:
+//
+//	writer := &kafka.Writer{Addr: kafka.TCP("localhost:9092")}
+//
+//	sink := publish.KafkaSink(publish.KafkaWriterFunc(
+//		func(ctx context.Context, topic string, key, value []byte, headers map[string]string) error {
+//			msg := kafka.Message{Topic: topic, Key: key, Value: value}
+//			for k, v := range headers {
+//				msg.Headers = append(msg.Headers, kafka.Header{Key: k, Value: []byte(v)})
+//			}
+//			return writer.WriteMessages(ctx, msg)
+//		},
+//	))
+//
+//	publisher := publish.NewPublisher(sink, publish.WithPartitioner(publish.PartitionByContract))
+//
+// A *nats.Conn from github.com/nats-io/nats.go can be passed to NATSSink as is, but
+// drops the message headers. To keep them, publish nats.Msg values instead:
+//
+//	sink := publish.NATSSink(publish.NATSPublisherFunc(
+//		func(subject string, data []byte, headers map[string]string) error {
+//			msg := nats.NewMsg(subject)
+//			msg.Data = data
+//			for k, v := range headers {
+//				msg.Header.Set(k, v)
+//			}
+//			return conn.PublishMsg(msg)
+//		},
+//	))
+package publish
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+)
+
+// Headers attached to every published message.
+const (
+	HeaderBlockID     = "flow-block-id"
+	HeaderBlockHeight = "flow-block-height"
+	HeaderEventType   = "flow-event-type"
+	HeaderContentType = "content-type"
+)
+
+// DefaultTopic is the topic events are published to unless a topic function is configured.
+const DefaultTopic = "flow.events"
+
+// A Message is a serialized event ready to be written to a message queue.
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// A Sink writes messages to a message queue.
+type Sink interface {
+	Write(ctx context.Context, messages []Message) error
+}
+
+// A Publisher publishes the events of every block it handles to a sink.
+type Publisher struct {
+	sink        Sink
+	encoder     Encoder
+	partitioner Partitioner
+	topic       func(event flow.Event) string
+}
+
+// An Option configures a Publisher.
+type Option func(*Publisher)
+
+// WithEncoder sets the encoder used to serialize events. The default is JSONEncoder.
+func WithEncoder(encoder Encoder) Option {
+	return func(p *Publisher) {
+		p.encoder = encoder
+	}
+}
+
+// WithPartitioner sets the partitioner that assigns message keys. By default messages have no key.
+func WithPartitioner(partitioner Partitioner) Option {
+	return func(p *Publisher) {
+		p.partitioner = partitioner
+	}
+}
+
+// WithTopic sets the function that chooses the topic (or NATS subject) of an event.
+func WithTopic(topic func(event flow.Event) string) Option {
+	return func(p *Publisher) {
+		p.topic = topic
+	}
+}
+
+// TopicPerType returns a topic function that publishes each event type to its own topic,
+// named prefix followed by the event type, e.g. "flow.events.A.0000000000000001.Token.Deposited".
+func TopicPerType(prefix string) func(event flow.Event) string {
+	return func(event flow.Event) string {
+		return prefix + "." + event.Type
+	}
+}
+
+// NewPublisher creates a publisher that writes to the given sink.
+func NewPublisher(sink Sink, opts ...Option) *Publisher {
+	p := &Publisher{
+		sink:    sink,
+		encoder: JSONEncoder{},
+		topic: func(flow.Event) string {
+			return DefaultTopic
+		},
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Handle publishes the events of a block. All messages of a block are written to the
+// sink in a single call, in event order.
+//
+// Handle has the signature of events.Handler.
+func (p *Publisher) Handle(ctx context.Context, block client.BlockEvents) error {
+	if len(block.Events) == 0 {
+		return nil
+	}
+
+	messages := make([]Message, 0, len(block.Events))
+
+	for _, event := range block.Events {
+		message, err := p.message(block, event)
+		if err != nil {
+			return err
+		}
+
+		messages = append(messages, message)
+	}
+
+	err := p.sink.Write(ctx, messages)
+	if err != nil {
+		return fmt.Errorf("publish: failed to write events of block %s: %w", block.BlockID, err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) message(block client.BlockEvents, event flow.Event) (Message, error) {
+	value, err := p.encoder.Encode(block, event)
+	if err != nil {
+		return Message{}, fmt.Errorf("publish: failed to encode event %s: %w", event.Type, err)
+	}
+
+	var key []byte
+	if p.partitioner != nil {
+		key = p.partitioner(block, event)
+	}
+
+	return Message{
+		Topic: p.topic(event),
+		Key:   key,
+		Value: value,
+		Headers: map[string]string{
+			HeaderBlockID:     block.BlockID.Hex(),
+			HeaderBlockHeight: strconv.FormatUint(block.Height, 10),
+			HeaderEventType:   event.Type,
+			HeaderContentType: p.encoder.ContentType(),
+		},
+	}, nil
+}
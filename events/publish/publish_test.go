@@ -0,0 +1,224 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publish_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/events/publish"
+	"github.com/onflow/flow-go-sdk/internal/protov2"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+type record struct {
+	topic   string
+	key     []byte
+	value   []byte
+	headers map[string]string
+}
+
+type fakeKafka struct {
+	records []record
+	err     error
+}
+
+func (k *fakeKafka) WriteMessage(
+	_ context.Context,
+	topic string,
+	key []byte,
+	value []byte,
+	headers map[string]string,
+) error {
+	if k.err != nil {
+		return k.err
+	}
+	k.records = append(k.records, record{topic: topic, key: key, value: value, headers: headers})
+	return nil
+}
+
+type fakeNATS struct {
+	subjects []string
+	data     [][]byte
+}
+
+func (n *fakeNATS) Publish(subject string, data []byte) error {
+	n.subjects = append(n.subjects, subject)
+	n.data = append(n.data, data)
+	return nil
+}
+
+func depositEvent(to flow.Address) flow.Event {
+	location := common.AddressLocation{Address: common.BytesToAddress([]byte{1}), Name: "Token"}
+
+	value := cadence.NewEvent([]cadence.Value{
+		cadence.NewOptional(cadence.NewAddress(to)),
+	}).WithType(&cadence.EventType{
+		Location:            location,
+		QualifiedIdentifier: "Token.Deposited",
+		Fields: []cadence.Field{
+			{Identifier: "to", Type: cadence.OptionalType{Type: cadence.AddressType{}}},
+		},
+	})
+
+	return flow.Event{
+		Type:  string(location.TypeID("Token.Deposited")),
+		Value: value,
+	}
+}
+
+func testBlock(events ...flow.Event) client.BlockEvents {
+	return client.BlockEvents{
+		BlockID:        test.IdentifierGenerator().New(),
+		Height:         42,
+		BlockTimestamp: time.Unix(1600000000, 0).UTC(),
+		Events:         events,
+	}
+}
+
+func TestPublisher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Kafka JSON", func(t *testing.T) {
+		kafka := &fakeKafka{}
+		publisher := publish.NewPublisher(publish.KafkaSink(kafka))
+
+		event := test.EventGenerator().New()
+		block := testBlock(event)
+
+		err := publisher.Handle(ctx, block)
+		require.NoError(t, err)
+
+		require.Len(t, kafka.records, 1)
+		r := kafka.records[0]
+
+		assert.Equal(t, publish.DefaultTopic, r.topic)
+		assert.Nil(t, r.key)
+		assert.Equal(t, map[string]string{
+			publish.HeaderBlockID:     block.BlockID.Hex(),
+			publish.HeaderBlockHeight: "42",
+			publish.HeaderEventType:   event.Type,
+			publish.HeaderContentType: "application/json",
+		}, r.headers)
+
+		var message publish.JSONMessage
+		require.NoError(t, json.Unmarshal(r.value, &message))
+		assert.Equal(t, block.BlockID.Hex(), message.BlockID)
+		assert.Equal(t, event.Type, message.Type)
+		assert.Equal(t, event.TransactionID.Hex(), message.TransactionID)
+		assert.JSONEq(t, string(event.Payload), string(message.Payload))
+	})
+
+	t.Run("Kafka protobuf", func(t *testing.T) {
+		kafka := &fakeKafka{}
+		publisher := publish.NewPublisher(publish.KafkaSink(kafka), publish.WithEncoder(publish.ProtobufEncoder{}))
+
+		event := test.EventGenerator().New()
+
+		err := publisher.Handle(ctx, testBlock(event))
+		require.NoError(t, err)
+
+		require.Len(t, kafka.records, 1)
+
+		var message entities.Event
+		require.NoError(t, proto.Unmarshal(kafka.records[0].value, protov2.Message(&message)))
+		assert.Equal(t, event.Type, message.Type)
+		assert.Equal(t, event.TransactionID.Bytes(), message.TransactionId)
+	})
+
+	t.Run("Partitioning", func(t *testing.T) {
+		alice := flow.HexToAddress("02")
+		event := depositEvent(alice)
+
+		assert.Equal(t, []byte("0000000000000001"), publish.PartitionByContract(client.BlockEvents{}, event))
+		assert.Equal(t, []byte(event.Type), publish.PartitionByType(client.BlockEvents{}, event))
+		assert.Equal(t, []byte(alice.Hex()), publish.PartitionByAddressField("to")(client.BlockEvents{}, event))
+		assert.Nil(t, publish.PartitionByAddressField("from")(client.BlockEvents{}, event))
+	})
+
+	t.Run("NATS subjects", func(t *testing.T) {
+		nats := &fakeNATS{}
+		publisher := publish.NewPublisher(
+			publish.NATSSink(nats),
+			publish.WithEncoder(publish.PayloadEncoder{}),
+			publish.WithTopic(publish.TopicPerType("flow")),
+			publish.WithPartitioner(publish.PartitionByAddressField("to")),
+		)
+
+		event := test.EventGenerator().New()
+		deposit := depositEvent(flow.HexToAddress("02"))
+		deposit.Payload = event.Payload
+
+		err := publisher.Handle(ctx, testBlock(event, deposit))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"flow." + event.Type,
+			"flow." + deposit.Type + ".0000000000000002",
+		}, nats.subjects)
+		assert.Equal(t, event.Payload, nats.data[0])
+	})
+
+	t.Run("NATS headers", func(t *testing.T) {
+		var headers []map[string]string
+		var subjects []string
+		conn := publish.NATSPublisherFunc(func(subject string, data []byte, h map[string]string) error {
+			subjects = append(subjects, subject)
+			headers = append(headers, h)
+			return nil
+		})
+		publisher := publish.NewPublisher(
+			publish.NATSSink(conn),
+			publish.WithEncoder(publish.PayloadEncoder{}),
+			publish.WithTopic(publish.TopicPerType("flow")),
+		)
+
+		event := test.EventGenerator().New()
+		block := testBlock(event)
+
+		err := publisher.Handle(ctx, block)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"flow." + event.Type}, subjects)
+		require.Len(t, headers, 1)
+		assert.Equal(t, block.BlockID.Hex(), headers[0][publish.HeaderBlockID])
+		assert.Equal(t, event.Type, headers[0][publish.HeaderEventType])
+		assert.Equal(t, "application/json", headers[0][publish.HeaderContentType])
+	})
+
+	t.Run("Sink error", func(t *testing.T) {
+		kafka := &fakeKafka{err: errors.New("broker unavailable")}
+		publisher := publish.NewPublisher(publish.KafkaSink(kafka))
+
+		err := publisher.Handle(ctx, testBlock(test.EventGenerator().New()))
+		assert.ErrorIs(t, err, kafka.err)
+	})
+}
@@ -0,0 +1,135 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publish
+
+import (
+	"context"
+)
+
+// A KafkaWriter writes a record to a Kafka topic.
+//
+// Kafka clients differ in their message types, so applications adapt their producer
+// with a KafkaWriterFunc.
+type KafkaWriter interface {
+	WriteMessage(ctx context.Context, topic string, key []byte, value []byte, headers map[string]string) error
+}
+
+// KafkaWriterFunc adapts a function to the KafkaWriter interface.
+type KafkaWriterFunc func(ctx context.Context, topic string, key []byte, value []byte, headers map[string]string) error
+
+func (f KafkaWriterFunc) WriteMessage(
+	ctx context.Context,
+	topic string,
+	key []byte,
+	value []byte,
+	headers map[string]string,
+) error {
+	return f(ctx, topic, key, value, headers)
+}
+
+type kafkaSink struct {
+	writer KafkaWriter
+}
+
+// KafkaSink returns a sink that writes every message as a Kafka record, keyed by the
+// message key.
+func KafkaSink(writer KafkaWriter) Sink {
+	return &kafkaSink{writer: writer}
+}
+
+func (s *kafkaSink) Write(ctx context.Context, messages []Message) error {
+	for _, message := range messages {
+		err := s.writer.WriteMessage(ctx, message.Topic, message.Key, message.Value, message.Headers)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// A NATSPublisher publishes data to a NATS subject.
+//
+// It is satisfied by *nats.Conn from github.com/nats-io/nats.go.
+type NATSPublisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// A NATSHeaderPublisher publishes data with headers to a NATS subject.
+//
+// NATSSink prefers it over NATSPublisher.Publish, which has no way to carry the
+// message headers.
+type NATSHeaderPublisher interface {
+	NATSPublisher
+	PublishWithHeaders(subject string, data []byte, headers map[string]string) error
+}
+
+// NATSPublisherFunc adapts a function to the NATSHeaderPublisher interface.
+type NATSPublisherFunc func(subject string, data []byte, headers map[string]string) error
+
+func (f NATSPublisherFunc) Publish(subject string, data []byte) error {
+	return f(subject, data, nil)
+}
+
+func (f NATSPublisherFunc) PublishWithHeaders(subject string, data []byte, headers map[string]string) error {
+	return f(subject, data, headers)
+}
+
+type natsSink struct {
+	conn NATSPublisher
+}
+
+// NATSSink returns a sink that publishes every message to a NATS subject.
+//
+// NATS has no message keys; instead the key, if any, is appended to the topic as the
+// last subject token, so subscribers can select partitions with subject wildcards
+// (e.g. "flow.events.0000000000000001" or "flow.events.>").
+//
+// Message headers are only published if conn is a NATSHeaderPublisher,
+// otherwise they are dropped.
+func NATSSink(conn NATSPublisher) Sink {
+	return &natsSink{conn: conn}
+}
+
+func (s *natsSink) Write(ctx context.Context, messages []Message) error {
+	headerConn, withHeaders := s.conn.(NATSHeaderPublisher)
+
+	for _, message := range messages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		subject := message.Topic
+		if len(message.Key) > 0 {
+			subject += "." + string(message.Key)
+		}
+
+		var err error
+		if withHeaders {
+			err = headerConn.PublishWithHeaders(subject, message.Value, message.Headers)
+		} else {
+			err = s.conn.Publish(subject, message.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// ErrUnregisteredEvent is returned when decoding an event type that has no registered decoder.
+var ErrUnregisteredEvent = errors.New("events: no decoder registered for event type")
+
+// A Decoder converts a raw event into a typed Go value.
+type Decoder func(event flow.Event) (interface{}, error)
+
+// A Registry maps event types to decoders.
+//
+// Decoders can be registered for a fully-qualified event type
+// (e.g. "A.f233dcee88fe0abe.FungibleToken.TokensDeposited") or for a contract-qualified
+// identifier (e.g. "FungibleToken.TokensDeposited"), which matches the event emitted by a
+// contract with that name deployed to any address.
+type Registry struct {
+	mut      sync.RWMutex
+	decoders map[string]Decoder
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		decoders: make(map[string]Decoder),
+	}
+}
+
+// Register registers the decoder for the given event type, replacing any existing decoder.
+func (r *Registry) Register(eventType string, decoder Decoder) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.decoders[eventType] = decoder
+}
+
+// Lookup returns the decoder for an event type.
+//
+// A decoder registered for the exact type takes precedence over one registered for its
+// contract-qualified identifier.
+func (r *Registry) Lookup(eventType string) (Decoder, bool) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	if decoder, ok := r.decoders[eventType]; ok {
+		return decoder, true
+	}
+
+	if identifier, ok := qualifiedIdentifier(eventType); ok {
+		decoder, ok := r.decoders[identifier]
+		return decoder, ok
+	}
+
+	return nil, false
+}
+
+// Decode decodes the event using the decoder registered for its type.
+func (r *Registry) Decode(event flow.Event) (interface{}, error) {
+	decoder, ok := r.Lookup(event.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnregisteredEvent, event.Type)
+	}
+
+	return decoder(event)
+}
+
+// Types returns the registered event types in lexical order.
+func (r *Registry) Types() []string {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	types := make([]string, 0, len(r.decoders))
+	for eventType := range r.decoders {
+		types = append(types, eventType)
+	}
+
+	sort.Strings(types)
+
+	return types
+}
+
+// qualifiedIdentifier strips the address location prefix from an event type,
+// e.g. "A.0000000000000001.Token.Deposited" becomes "Token.Deposited".
+func qualifiedIdentifier(eventType string) (string, bool) {
+	parts := strings.SplitN(eventType, ".", 3)
+	if len(parts) != 3 || parts[0] != "A" {
+		return "", false
+	}
+
+	return parts[2], true
+}
+
+// DefaultRegistry is the registry used by Register and Decode.
+//
+// Code generated by eventgen registers its decoders here.
+var DefaultRegistry = NewRegistry()
+
+// Register registers the decoder for the given event type in the default registry.
+func Register(eventType string, decoder Decoder) {
+	DefaultRegistry.Register(eventType, decoder)
+}
+
+// Decode decodes the event using the default registry.
+func Decode(event flow.Event) (interface{}, error) {
+	return DefaultRegistry.Decode(event)
+}
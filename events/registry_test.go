@@ -0,0 +1,248 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/events"
+)
+
+// tokenDeposited mirrors the code generated by eventgen for Token.TokensDeposited.
+type tokenDeposited struct {
+	Amount cadence.UFix64    `cadence:"amount"`
+	To     *flow.Address     `cadence:"to"`
+	IDs    []uint64          `cadence:"ids"`
+	Meta   map[string]string `cadence:"meta"`
+	Supply *big.Int          `cadence:"supply"`
+	Vault  cadence.Value     `cadence:"vault"`
+}
+
+func depositedEvent(to cadence.Value) flow.Event {
+	location := common.AddressLocation{Address: common.BytesToAddress([]byte{1}), Name: "Token"}
+
+	value := cadence.NewEvent([]cadence.Value{
+		cadence.UFix64(150000000),
+		to,
+		cadence.NewArray([]cadence.Value{cadence.NewUInt64(1), cadence.NewUInt64(2)}),
+		cadence.NewDictionary([]cadence.KeyValuePair{{Key: cadence.String("name"), Value: cadence.String("foo")}}),
+		cadence.NewOptional(cadence.NewUInt256(42)),
+		cadence.NewOptional(nil),
+	}).WithType(&cadence.EventType{
+		Location:            location,
+		QualifiedIdentifier: "Token.TokensDeposited",
+		Fields: []cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: "to", Type: cadence.OptionalType{Type: cadence.AddressType{}}},
+			{Identifier: "ids", Type: cadence.VariableSizedArrayType{ElementType: cadence.UInt64Type{}}},
+			{Identifier: "meta", Type: cadence.DictionaryType{KeyType: cadence.StringType{}, ElementType: cadence.StringType{}}},
+			{Identifier: "supply", Type: cadence.OptionalType{Type: cadence.UInt256Type{}}},
+			{Identifier: "vault", Type: cadence.OptionalType{Type: cadence.AnyResourceType{}}},
+		},
+	})
+
+	return flow.Event{
+		Type:  string(location.TypeID("Token.TokensDeposited")),
+		Value: value,
+	}
+}
+
+func TestDecodeEvent(t *testing.T) {
+	t.Run("Fields", func(t *testing.T) {
+		event := depositedEvent(cadence.NewOptional(cadence.NewAddress(flow.HexToAddress("02"))))
+
+		var deposited tokenDeposited
+		err := events.DecodeEvent(event, &deposited)
+		require.NoError(t, err)
+
+		to := flow.HexToAddress("02")
+		assert.Equal(t, tokenDeposited{
+			Amount: cadence.UFix64(150000000),
+			To:     &to,
+			IDs:    []uint64{1, 2},
+			Meta:   map[string]string{"name": "foo"},
+			Supply: big.NewInt(42),
+		}, deposited)
+	})
+
+	t.Run("Nil optional", func(t *testing.T) {
+		event := depositedEvent(cadence.NewOptional(nil))
+
+		var deposited tokenDeposited
+		err := events.DecodeEvent(event, &deposited)
+		require.NoError(t, err)
+
+		assert.Nil(t, deposited.To)
+	})
+
+	t.Run("Missing field", func(t *testing.T) {
+		event := depositedEvent(cadence.NewOptional(nil))
+
+		var target struct {
+			Missing string `cadence:"missing"`
+		}
+		err := events.DecodeEvent(event, &target)
+		assert.Error(t, err)
+	})
+
+	t.Run("Mismatched type", func(t *testing.T) {
+		event := depositedEvent(cadence.NewOptional(nil))
+
+		var target struct {
+			Amount bool `cadence:"amount"`
+		}
+		err := events.DecodeEvent(event, &target)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid target", func(t *testing.T) {
+		event := depositedEvent(cadence.NewOptional(nil))
+
+		var target tokenDeposited
+		err := events.DecodeEvent(event, target)
+		assert.Error(t, err)
+	})
+}
+
+func TestDecodeValue(t *testing.T) {
+	type owner struct {
+		Address flow.Address `cadence:"address"`
+	}
+
+	type collection struct {
+		Name   string  `cadence:"name"`
+		Owner  owner   `cadence:"owner"`
+		Owners []owner `cadence:"owners"`
+	}
+
+	ownerType := &cadence.StructType{
+		QualifiedIdentifier: "Owner",
+		Fields:              []cadence.Field{{Identifier: "address", Type: cadence.AddressType{}}},
+	}
+
+	newOwner := func(address flow.Address) cadence.Value {
+		return cadence.NewStruct([]cadence.Value{cadence.NewAddress(address)}).WithType(ownerType)
+	}
+
+	value := cadence.NewStruct([]cadence.Value{
+		cadence.String("kitties"),
+		newOwner(flow.HexToAddress("01")),
+		cadence.NewArray([]cadence.Value{newOwner(flow.HexToAddress("02"))}),
+	}).WithType(&cadence.StructType{
+		QualifiedIdentifier: "Collection",
+		Fields: []cadence.Field{
+			{Identifier: "name", Type: cadence.StringType{}},
+			{Identifier: "owner", Type: ownerType},
+			{Identifier: "owners", Type: cadence.VariableSizedArrayType{ElementType: ownerType}},
+		},
+	})
+
+	t.Run("Struct", func(t *testing.T) {
+		var decoded collection
+		err := events.DecodeValue(value, &decoded)
+		require.NoError(t, err)
+
+		assert.Equal(t, collection{
+			Name:   "kitties",
+			Owner:  owner{Address: flow.HexToAddress("01")},
+			Owners: []owner{{Address: flow.HexToAddress("02")}},
+		}, decoded)
+	})
+
+	t.Run("Scalar", func(t *testing.T) {
+		var decoded uint64
+		err := events.DecodeValue(cadence.NewUInt64(42), &decoded)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(42), decoded)
+	})
+
+	t.Run("Missing field", func(t *testing.T) {
+		var target struct {
+			Missing string `cadence:"missing"`
+		}
+		err := events.DecodeValue(value, &target)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid target", func(t *testing.T) {
+		var decoded collection
+		assert.Error(t, events.DecodeValue(value, decoded))
+		assert.Error(t, events.DecodeValue(nil, &decoded))
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	decoder := func(event flow.Event) (interface{}, error) {
+		var deposited tokenDeposited
+		if err := events.DecodeEvent(event, &deposited); err != nil {
+			return nil, err
+		}
+		return &deposited, nil
+	}
+
+	t.Run("Exact type", func(t *testing.T) {
+		registry := events.NewRegistry()
+		registry.Register("A.0000000000000001.Token.TokensDeposited", decoder)
+
+		value, err := registry.Decode(depositedEvent(cadence.NewOptional(nil)))
+		require.NoError(t, err)
+		assert.IsType(t, &tokenDeposited{}, value)
+	})
+
+	t.Run("Qualified identifier", func(t *testing.T) {
+		registry := events.NewRegistry()
+		registry.Register("Token.TokensDeposited", decoder)
+
+		value, err := registry.Decode(depositedEvent(cadence.NewOptional(nil)))
+		require.NoError(t, err)
+		assert.IsType(t, &tokenDeposited{}, value)
+
+		assert.Equal(t, []string{"Token.TokensDeposited"}, registry.Types())
+	})
+
+	t.Run("Unregistered", func(t *testing.T) {
+		registry := events.NewRegistry()
+
+		_, err := registry.Decode(depositedEvent(cadence.NewOptional(nil)))
+		assert.True(t, errors.Is(err, events.ErrUnregisteredEvent))
+	})
+}
+
+func BenchmarkDecodeEvent(b *testing.B) {
+	event := depositedEvent(cadence.NewOptional(cadence.NewAddress(flow.HexToAddress("02"))))
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		var deposited tokenDeposited
+		err := events.DecodeEvent(event, &deposited)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
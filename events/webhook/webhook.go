@@ -0,0 +1,279 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package webhook forwards Flow events to HTTP endpoints.
+//
+// A Forwarder is an events.Handler, so it can be driven by a Poller, a Dispatcher
+// subscription or a Backfill.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+)
+
+const (
+	// SignatureHeader carries the hex-encoded HMAC-SHA256 signature of the request.
+	//
+	// The signature is computed over the timestamp header value, a period and the request body.
+	SignatureHeader = "X-Flow-Signature"
+	// TimestampHeader carries the Unix time at which the request was signed.
+	TimestampHeader = "X-Flow-Timestamp"
+)
+
+// An Endpoint is a webhook URL that receives the events matching its filter.
+type Endpoint struct {
+	URL string
+	// Secret is the key used to sign requests. Requests are not signed if it is empty.
+	Secret []byte
+	Filter flow.EventFilter
+}
+
+// A Payload is the JSON body posted to an endpoint for a single event.
+type Payload struct {
+	BlockID          string          `json:"blockId"`
+	BlockHeight      uint64          `json:"blockHeight"`
+	BlockTimestamp   time.Time       `json:"blockTimestamp"`
+	Type             string          `json:"type"`
+	TransactionID    string          `json:"transactionId"`
+	TransactionIndex int             `json:"transactionIndex"`
+	EventIndex       int             `json:"eventIndex"`
+	Payload          json.RawMessage `json:"payload"`
+}
+
+// A DeadLetter is a delivery that failed permanently.
+type DeadLetter struct {
+	URL      string
+	Payload  Payload
+	Attempts int
+	Err      error
+}
+
+// A DeadLetterQueue stores deliveries that could not be completed.
+type DeadLetterQueue interface {
+	Put(ctx context.Context, letter DeadLetter) error
+}
+
+// A MemoryDeadLetterQueue is a DeadLetterQueue that keeps dead letters in memory.
+type MemoryDeadLetterQueue struct {
+	mut     sync.Mutex
+	letters []DeadLetter
+}
+
+func (q *MemoryDeadLetterQueue) Put(_ context.Context, letter DeadLetter) error {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+	q.letters = append(q.letters, letter)
+	return nil
+}
+
+// Letters returns the dead letters received so far.
+func (q *MemoryDeadLetterQueue) Letters() []DeadLetter {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+	return append([]DeadLetter(nil), q.letters...)
+}
+
+// A Forwarder posts events to webhook endpoints.
+type Forwarder struct {
+	endpoints   []Endpoint
+	httpClient  *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	deadLetters DeadLetterQueue
+	now         func() time.Time
+}
+
+// An Option configures a Forwarder.
+type Option func(*Forwarder)
+
+// WithHTTPClient sets the HTTP client used to post events.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(f *Forwarder) {
+		f.httpClient = httpClient
+	}
+}
+
+// WithRetry sets the number of delivery attempts per event and endpoint, and the
+// initial backoff between attempts. The backoff doubles after every attempt.
+func WithRetry(maxAttempts int, backoff time.Duration) Option {
+	return func(f *Forwarder) {
+		f.maxAttempts = maxAttempts
+		f.backoff = backoff
+	}
+}
+
+// WithDeadLetterQueue sets the queue receiving deliveries that failed all attempts.
+//
+// Without a dead letter queue, a failed delivery stops the forwarder with an error.
+func WithDeadLetterQueue(queue DeadLetterQueue) Option {
+	return func(f *Forwarder) {
+		f.deadLetters = queue
+	}
+}
+
+// NewForwarder creates a forwarder for the given endpoints.
+func NewForwarder(endpoints []Endpoint, opts ...Option) *Forwarder {
+	f := &Forwarder{
+		endpoints:   endpoints,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		maxAttempts: 5,
+		backoff:     time.Second,
+		now:         time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// Handle forwards the events of a block to every endpoint whose filter they match.
+func (f *Forwarder) Handle(ctx context.Context, block client.BlockEvents) error {
+	for _, event := range block.Events {
+		payload := Payload{
+			BlockID:          block.BlockID.String(),
+			BlockHeight:      block.Height,
+			BlockTimestamp:   block.BlockTimestamp,
+			Type:             event.Type,
+			TransactionID:    event.TransactionID.String(),
+			TransactionIndex: event.TransactionIndex,
+			EventIndex:       event.EventIndex,
+			Payload:          event.Payload,
+		}
+
+		for _, endpoint := range f.endpoints {
+			if !endpoint.Filter.Matches(event) {
+				continue
+			}
+
+			err := f.deliver(ctx, endpoint, payload)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f *Forwarder) deliver(ctx context.Context, endpoint Endpoint, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("webhook: failed to encode payload: %w", err)
+	}
+
+	backoff := f.backoff
+
+	var attempt int
+	for attempt = 1; ; attempt++ {
+		err = f.post(ctx, endpoint, body)
+		if err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if attempt >= f.maxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+
+	if f.deadLetters == nil {
+		return fmt.Errorf("webhook: failed to deliver event to %s after %d attempts: %w", endpoint.URL, attempt, err)
+	}
+
+	return f.deadLetters.Put(ctx, DeadLetter{
+		URL:      endpoint.URL,
+		Payload:  payload,
+		Attempts: attempt,
+		Err:      err,
+	})
+}
+
+func (f *Forwarder) post(ctx context.Context, endpoint Endpoint, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	if len(endpoint.Secret) > 0 {
+		timestamp := strconv.FormatInt(f.now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(endpoint.Secret, timestamp, body))
+	}
+
+	res, err := f.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %s", res.Status)
+	}
+
+	return nil
+}
+
+// Sign computes the signature sent in the SignatureHeader for the given timestamp and body.
+//
+// Receivers can use Sign to verify incoming requests.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns true if the signature is valid for the given timestamp and body.
+func Verify(secret []byte, timestamp string, body []byte, signature string) bool {
+	expected := Sign(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
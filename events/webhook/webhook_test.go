@@ -0,0 +1,154 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/events/webhook"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestForwarder(t *testing.T) {
+	ctx := context.Background()
+	secret := []byte("secret")
+
+	events := test.EventGenerator()
+	eventA, eventB := events.New(), events.New()
+
+	block := client.BlockEvents{
+		BlockID: test.IdentifierGenerator().New(),
+		Height:  42,
+		Events:  []flow.Event{eventA, eventB},
+	}
+
+	t.Run("Delivers matching events", func(t *testing.T) {
+		var mut sync.Mutex
+		var received []webhook.Payload
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			assert.True(t, webhook.Verify(
+				secret,
+				r.Header.Get(webhook.TimestampHeader),
+				body,
+				r.Header.Get(webhook.SignatureHeader),
+			))
+
+			var payload webhook.Payload
+			require.NoError(t, json.Unmarshal(body, &payload))
+
+			mut.Lock()
+			received = append(received, payload)
+			mut.Unlock()
+		}))
+		defer server.Close()
+
+		f := webhook.NewForwarder([]webhook.Endpoint{
+			{
+				URL:    server.URL,
+				Secret: secret,
+				Filter: flow.EventFilter{EventTypes: []string{eventB.Type}},
+			},
+		})
+
+		err := f.Handle(ctx, block)
+		require.NoError(t, err)
+
+		require.Len(t, received, 1)
+		assert.Equal(t, eventB.Type, received[0].Type)
+		assert.Equal(t, uint64(42), received[0].BlockHeight)
+		assert.Equal(t, eventB.TransactionID.String(), received[0].TransactionID)
+		assert.JSONEq(t, string(eventB.Payload), string(received[0].Payload))
+	})
+
+	t.Run("Retries failed deliveries", func(t *testing.T) {
+		attempts := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+
+		f := webhook.NewForwarder(
+			[]webhook.Endpoint{{URL: server.URL, Filter: flow.EventFilter{EventTypes: []string{eventA.Type}}}},
+			webhook.WithRetry(3, time.Millisecond),
+		)
+
+		err := f.Handle(ctx, block)
+		require.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("Dead letters", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		queue := &webhook.MemoryDeadLetterQueue{}
+
+		f := webhook.NewForwarder(
+			[]webhook.Endpoint{{URL: server.URL}},
+			webhook.WithRetry(2, time.Millisecond),
+			webhook.WithDeadLetterQueue(queue),
+		)
+
+		err := f.Handle(ctx, block)
+		require.NoError(t, err)
+
+		letters := queue.Letters()
+		require.Len(t, letters, 2)
+		assert.Equal(t, server.URL, letters[0].URL)
+		assert.Equal(t, 2, letters[0].Attempts)
+		assert.Error(t, letters[0].Err)
+	})
+
+	t.Run("Fails without dead letter queue", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		f := webhook.NewForwarder(
+			[]webhook.Endpoint{{URL: server.URL}},
+			webhook.WithRetry(1, time.Millisecond),
+		)
+
+		err := f.Handle(ctx, block)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,116 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evm
+
+import (
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"path"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/systemcontracts"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+// scripts holds the Cadence transactions and scripts of this package.
+//
+//go:embed cadence/*.cdc
+var scripts embed.FS
+
+// attoflowPerUnit is the number of attoflow in the smallest FLOW amount a
+// UFix64 can hold, 10^-8 FLOW.
+var attoflowPerUnit = big.NewInt(10_000_000_000)
+
+// FlowToAttoflow converts an amount of FLOW to attoflow, the unit of FLOW
+// balances in Flow EVM (10^-18 FLOW).
+func FlowToAttoflow(amount cadence.UFix64) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(uint64(amount)), attoflowPerUnit)
+}
+
+// AttoflowToFlow converts an amount of attoflow to FLOW, rounded down to the
+// precision of UFix64.
+func AttoflowToFlow(attoflow *big.Int) (cadence.UFix64, error) {
+	if attoflow.Sign() < 0 {
+		return 0, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "evm: negative amount %s", attoflow)
+	}
+
+	units := new(big.Int).Quo(attoflow, attoflowPerUnit)
+	if !units.IsUint64() {
+		return 0, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "evm: amount %s overflows UFix64", attoflow)
+	}
+
+	return cadence.UFix64(units.Uint64()), nil
+}
+
+// DepositFlow returns a transaction that sends FLOW from the vault of a Flow
+// account to an EVM address.
+//
+// The account is added as the authorizer of the transaction.
+func DepositFlow(chainID flow.ChainID, from flow.Address, to common.Address, amount cadence.UFix64) (*flow.Transaction, error) {
+	code, err := script("deposit_flow", chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(jsoncdc.MustEncode(cadence.String(hex.EncodeToString(to.Bytes())))).
+		AddRawArgument(jsoncdc.MustEncode(amount)).
+		AddAuthorizer(from), nil
+}
+
+// WithdrawFlow returns a transaction that moves FLOW from the Cadence-owned
+// account (COA) of a Flow account back to its FLOW vault.
+//
+// The COA must be stored at /storage/evm. The account is added as the
+// authorizer of the transaction.
+func WithdrawFlow(chainID flow.ChainID, account flow.Address, amount cadence.UFix64) (*flow.Transaction, error) {
+	code, err := script("withdraw_flow", chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(jsoncdc.MustEncode(amount)).
+		AddAuthorizer(account), nil
+}
+
+// script returns the code of a Cadence file of this package with the imports
+// of core contracts resolved for the network with the given chain ID.
+func script(name string, chainID flow.ChainID) ([]byte, error) {
+	code, err := scripts.ReadFile(path.Join("cadence", name+".cdc"))
+	if err != nil {
+		return nil, fmt.Errorf("evm: missing script %s: %w", name, err)
+	}
+
+	contracts, err := systemcontracts.ForChain(chainID)
+	if err != nil {
+		return nil, fmt.Errorf("evm: %w", err)
+	}
+
+	return []byte(templates.ResolveImports(string(code), contracts.Addresses())), nil
+}
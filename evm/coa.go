@@ -0,0 +1,224 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evm
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// A ScriptClient executes Cadence scripts.
+//
+// It is satisfied by *client.Client.
+type ScriptClient interface {
+	ExecuteScriptAtLatestBlock(
+		ctx context.Context,
+		script []byte,
+		arguments []cadence.Value,
+		opts ...grpc.CallOption,
+	) (cadence.Value, error)
+}
+
+// CreateCOA returns a transaction that creates a Cadence-owned account (COA)
+// for a Flow account, funded with amount FLOW from the vault of the account.
+//
+// The COA is stored at /storage/evm and a capability to it is published at
+// /public/evm. The transaction fails if the account already has a COA.
+func CreateCOA(chainID flow.ChainID, account flow.Address, amount cadence.UFix64) (*flow.Transaction, error) {
+	code, err := script("create_coa", chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(jsoncdc.MustEncode(amount)).
+		AddAuthorizer(account), nil
+}
+
+// FundCOA returns a transaction that moves amount FLOW from the vault of a
+// Flow account to its COA.
+func FundCOA(chainID flow.ChainID, account flow.Address, amount cadence.UFix64) (*flow.Transaction, error) {
+	code, err := script("fund_coa", chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(jsoncdc.MustEncode(amount)).
+		AddAuthorizer(account), nil
+}
+
+// COACall returns a transaction that calls an EVM contract from the COA of a
+// Flow account, sending value attoflow with the call.
+//
+// The transaction fails if the call does not succeed. value may be nil.
+func COACall(
+	chainID flow.ChainID,
+	account flow.Address,
+	to common.Address,
+	data []byte,
+	gasLimit uint64,
+	value *big.Int,
+) (*flow.Transaction, error) {
+	code, err := script("coa_call", chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	attoflow, err := uintArg(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(jsoncdc.MustEncode(addressArg(to))).
+		AddRawArgument(jsoncdc.MustEncode(cadence.String(hex.EncodeToString(data)))).
+		AddRawArgument(jsoncdc.MustEncode(cadence.NewUInt64(gasLimit))).
+		AddRawArgument(jsoncdc.MustEncode(attoflow)).
+		AddAuthorizer(account), nil
+}
+
+// COADeploy returns a transaction that deploys an EVM contract from the COA
+// of a Flow account. bytecode is the creation code of the contract, including
+// its encoded constructor arguments.
+//
+// The transaction fails if the deployment does not succeed. The address of
+// the contract is reported in the EVM.TransactionExecuted event of the
+// transaction; see DecodeTransactionExecuted. value may be nil.
+func COADeploy(
+	chainID flow.ChainID,
+	account flow.Address,
+	bytecode []byte,
+	gasLimit uint64,
+	value *big.Int,
+) (*flow.Transaction, error) {
+	code, err := script("coa_deploy", chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	attoflow, err := uintArg(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(jsoncdc.MustEncode(cadence.String(hex.EncodeToString(bytecode)))).
+		AddRawArgument(jsoncdc.MustEncode(cadence.NewUInt64(gasLimit))).
+		AddRawArgument(jsoncdc.MustEncode(attoflow)).
+		AddAuthorizer(account), nil
+}
+
+// COAAddress returns the EVM address of the COA published by a Flow account
+// at /public/evm.
+//
+// An error wrapping flowerrors.ErrNotFound is returned if the account has no COA.
+func COAAddress(ctx context.Context, c ScriptClient, chainID flow.ChainID, account flow.Address) (common.Address, error) {
+	code, err := script("coa_address", chainID)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	value, err := c.ExecuteScriptAtLatestBlock(ctx, code, []cadence.Value{cadence.BytesToAddress(account.Bytes())})
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	optional, ok := value.(cadence.Optional)
+	if !ok {
+		return common.Address{}, flowerrors.Errorf(flowerrors.ErrDecoding, "evm: expected an optional string, got %T", value)
+	}
+
+	if optional.Value == nil {
+		return common.Address{}, flowerrors.Errorf(flowerrors.ErrNotFound, "evm: account %s has no COA", account)
+	}
+
+	s, ok := optional.Value.(cadence.String)
+	if !ok || !common.IsHexAddress(string(s)) {
+		return common.Address{}, flowerrors.Errorf(flowerrors.ErrDecoding, "evm: invalid COA address %s", optional.Value)
+	}
+
+	return common.HexToAddress(string(s)), nil
+}
+
+// DryCall executes an EVM call from any address in a Cadence script, without
+// changing state, and returns its result.
+func DryCall(
+	ctx context.Context,
+	c ScriptClient,
+	chainID flow.ChainID,
+	from common.Address,
+	to common.Address,
+	data []byte,
+	gasLimit uint64,
+	value *big.Int,
+) (*Result, error) {
+	code, err := script("dry_call", chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	attoflow, err := uintArg(value)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.ExecuteScriptAtLatestBlock(ctx, code, []cadence.Value{
+		addressArg(from),
+		addressArg(to),
+		cadence.String(hex.EncodeToString(data)),
+		cadence.NewUInt64(gasLimit),
+		attoflow,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeResult(result)
+}
+
+// addressArg returns an EVM address in the form expected by EVM.addressFromString.
+func addressArg(address common.Address) cadence.String {
+	return cadence.String(hex.EncodeToString(address.Bytes()))
+}
+
+func uintArg(value *big.Int) (cadence.UInt, error) {
+	if value == nil {
+		return cadence.NewUInt(0), nil
+	}
+
+	if value.Sign() < 0 {
+		return cadence.UInt{}, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "evm: negative value %s", value)
+	}
+
+	return cadence.NewUIntFromBig(value), nil
+}
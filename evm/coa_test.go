@@ -0,0 +1,123 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evm_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client/mocks"
+	"github.com/onflow/flow-go-sdk/evm"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+func TestCreateCOA(t *testing.T) {
+	account := flow.HexToAddress("01")
+
+	tx, err := evm.CreateCOA(flow.Emulator, account, 100)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(tx.Script), "import EVM from 0xf8d6e0586b0a20c7\n")
+	assert.Contains(t, string(tx.Script), "EVM.createCadenceOwnedAccount()")
+	assert.Equal(t, []flow.Address{account}, tx.Authorizers)
+
+	tx, err = evm.FundCOA(flow.Emulator, account, 100)
+	require.NoError(t, err)
+	assert.Contains(t, string(tx.Script), "coa.deposit")
+}
+
+func TestCOACall(t *testing.T) {
+	account := flow.HexToAddress("01")
+	to := common.HexToAddress("0x00000000000000000000000200000000000000a1")
+
+	tx, err := evm.COACall(flow.Mainnet, account, to, []byte{0xa9, 0x05}, 100000, big.NewInt(5))
+	require.NoError(t, err)
+	require.Len(t, tx.Arguments, 4)
+
+	args := make([]cadence.Value, len(tx.Arguments))
+	for i, b := range tx.Arguments {
+		args[i], err = jsoncdc.Decode(b)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, cadence.String("00000000000000000000000200000000000000a1"), args[0])
+	assert.Equal(t, cadence.String("a905"), args[1])
+	assert.Equal(t, cadence.NewUInt64(100000), args[2])
+	assert.Equal(t, cadence.NewUInt(5), args[3])
+
+	_, err = evm.COACall(flow.Mainnet, account, to, nil, 100000, big.NewInt(-1))
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+
+	tx, err = evm.COADeploy(flow.Mainnet, account, []byte{0x60, 0x80}, 1000000, nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(tx.Script), "self.coa.deploy(")
+}
+
+func TestCOAAddress(t *testing.T) {
+	ctx := context.Background()
+	account := flow.HexToAddress("01")
+	coa := common.HexToAddress("0x000000000000000000000002f9e7d9b5e3b1c4a1")
+
+	t.Run("Found", func(t *testing.T) {
+		c := mocks.NewAccessClient(t)
+		c.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything, []cadence.Value{cadence.BytesToAddress(account.Bytes())}).
+			Return(cadence.NewOptional(cadence.String(coa.Hex()[2:])), nil).
+			Once()
+
+		address, err := evm.COAAddress(ctx, c, flow.Mainnet, account)
+		require.NoError(t, err)
+		assert.Equal(t, coa, address)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		c := mocks.NewAccessClient(t)
+		c.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything, mock.Anything).
+			Return(cadence.NewOptional(nil), nil).
+			Once()
+
+		_, err := evm.COAAddress(ctx, c, flow.Mainnet, account)
+		assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+	})
+}
+
+func TestDryCall(t *testing.T) {
+	ctx := context.Background()
+	from := common.HexToAddress("0x01")
+	to := common.HexToAddress("0x02")
+
+	c := mocks.NewAccessClient(t)
+	c.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything, mock.Anything).
+		Return(resultValue(evm.StatusSuccessful, nil), nil).
+		Once()
+
+	result, err := evm.DryCall(ctx, c, flow.Testnet, from, to, []byte{0x01}, 50000, nil)
+	require.NoError(t, err)
+	assert.Equal(t, evm.StatusSuccessful, result.Status)
+	assert.Equal(t, []byte{0xca, 0xfe}, result.Data)
+}
@@ -0,0 +1,400 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// A Status is the status of an EVM call made from Cadence, as reported by EVM.Result.
+type Status uint8
+
+// Values of EVM.Status.
+const (
+	StatusUnknown Status = iota
+	StatusInvalid
+	StatusFailed
+	StatusSuccessful
+)
+
+// String returns the name of the status in Cadence.
+func (s Status) String() string {
+	switch s {
+	case StatusInvalid:
+		return "invalid"
+	case StatusFailed:
+		return "failed"
+	case StatusSuccessful:
+		return "successful"
+	default:
+		return "unknown"
+	}
+}
+
+// A Result is the result of an EVM call made from Cadence: an EVM.Result value.
+type Result struct {
+	Status       Status
+	ErrorCode    uint64
+	ErrorMessage string
+	GasUsed      uint64
+	Data         []byte
+	// DeployedContract is the address of the contract created by a deployment.
+	DeployedContract *common.Address
+}
+
+// Err returns an error describing a call that did not succeed, or nil.
+func (r *Result) Err() error {
+	if r.Status == StatusSuccessful {
+		return nil
+	}
+
+	return fmt.Errorf("evm: call %s with code %d: %s", r.Status, r.ErrorCode, r.ErrorMessage)
+}
+
+// DecodeResult decodes an EVM.Result value returned by a Cadence script.
+func DecodeResult(value cadence.Value) (*Result, error) {
+	fields, ok := compositeFields(value)
+	if !ok {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "evm: expected an EVM.Result, got %T", value)
+	}
+
+	d := decoder{fields: fields}
+
+	result := &Result{
+		Status:       Status(d.enum("status")),
+		ErrorCode:    d.uint64("errorCode"),
+		ErrorMessage: d.string("errorMessage"),
+		GasUsed:      d.uint64("gasUsed"),
+		Data:         d.bytes("data"),
+	}
+
+	if deployed, ok := fields["deployedContract"]; ok {
+		if optional, ok := deployed.(cadence.Optional); ok {
+			deployed = optional.Value
+		}
+
+		if deployed != nil {
+			address, err := decodeEVMAddress(deployed)
+			if err != nil {
+				return nil, err
+			}
+			result.DeployedContract = &address
+		}
+	}
+
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	return result, nil
+}
+
+// decodeEVMAddress decodes an EVM.EVMAddress value.
+func decodeEVMAddress(value cadence.Value) (common.Address, error) {
+	fields, ok := compositeFields(value)
+	if !ok {
+		return common.Address{}, flowerrors.Errorf(flowerrors.ErrDecoding, "evm: expected an EVM.EVMAddress, got %T", value)
+	}
+
+	d := decoder{fields: fields}
+	b := d.bytes("bytes")
+	if d.err != nil {
+		return common.Address{}, d.err
+	}
+
+	if len(b) != common.AddressLength {
+		return common.Address{}, flowerrors.Errorf(flowerrors.ErrDecoding, "evm: invalid address length %d", len(b))
+	}
+
+	return common.BytesToAddress(b), nil
+}
+
+// TransactionExecutedEvent is the name of the event emitted for every EVM
+// transaction, qualified by the EVM contract location.
+const TransactionExecutedEvent = "EVM.TransactionExecuted"
+
+// A TransactionExecuted is an EVM.TransactionExecuted event, emitted to Cadence
+// for every EVM transaction, including calls made by COAs.
+type TransactionExecuted struct {
+	BlockHeight  uint64
+	Hash         common.Hash
+	Index        uint64
+	Type         uint8
+	Payload      []byte
+	ErrorCode    uint64
+	ErrorMessage string
+	GasConsumed  uint64
+	// ContractAddress is the address of the contract created by the transaction, if any.
+	ContractAddress *common.Address
+	Logs            []*Log
+	ReturnedData    []byte
+}
+
+// Successful returns true if the transaction was executed without error.
+func (e *TransactionExecuted) Successful() bool {
+	return e.ErrorCode == 0
+}
+
+// DecodeTransactionExecuted decodes an EVM.TransactionExecuted event.
+//
+// Fields added to the event by later versions of the EVM contract are left
+// empty if the event does not have them.
+func DecodeTransactionExecuted(event flow.Event) (*TransactionExecuted, error) {
+	if !strings.HasSuffix(event.Type, "."+TransactionExecutedEvent) {
+		return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "evm: expected an %s event, got %s", TransactionExecutedEvent, event.Type)
+	}
+
+	fields, ok := compositeFields(event.Value)
+	if !ok {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "evm: event %s has no type information", event.Type)
+	}
+
+	d := decoder{fields: fields}
+
+	e := &TransactionExecuted{
+		BlockHeight:  d.uint64("blockHeight"),
+		Hash:         d.hash("hash"),
+		Index:        d.uint64("index"),
+		Type:         uint8(d.uint64("type")),
+		Payload:      d.bytes("payload"),
+		ErrorCode:    d.uint64("errorCode"),
+		ErrorMessage: d.string("errorMessage"),
+		GasConsumed:  d.uint64("gasConsumed"),
+	}
+
+	if _, ok := fields["returnedData"]; ok {
+		e.ReturnedData = d.bytes("returnedData")
+	}
+
+	if address := d.string("contractAddress"); address != "" {
+		contract := common.HexToAddress(address)
+		e.ContractAddress = &contract
+	}
+
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	logs, err := decodeLogs(d.bytes("logs"))
+	if d.err != nil {
+		return nil, d.err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, log := range logs {
+		log.TransactionHash = e.Hash
+		log.BlockNumber = hexutil.Uint64(e.BlockHeight)
+	}
+	e.Logs = logs
+
+	return e, nil
+}
+
+// rlpLog is the consensus encoding of an EVM log.
+type rlpLog struct {
+	Address common.Address
+	Topics  []common.Hash
+	Data    []byte
+}
+
+// decodeLogs decodes the RLP-encoded logs of an EVM.TransactionExecuted event.
+func decodeLogs(b []byte) ([]*Log, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	var encoded []rlpLog
+	if err := rlp.DecodeBytes(b, &encoded); err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "evm: invalid logs: %w", err)
+	}
+
+	logs := make([]*Log, len(encoded))
+	for i, l := range encoded {
+		logs[i] = &Log{
+			Address: l.Address,
+			Topics:  l.Topics,
+			Data:    l.Data,
+		}
+	}
+
+	return logs, nil
+}
+
+// compositeFields returns the fields of a struct or event value by name.
+func compositeFields(value cadence.Value) (map[string]cadence.Value, bool) {
+	var (
+		types  []cadence.Field
+		values []cadence.Value
+	)
+
+	switch value := value.(type) {
+	case cadence.Struct:
+		if value.StructType == nil {
+			return nil, false
+		}
+		types, values = value.StructType.Fields, value.Fields
+	case cadence.Event:
+		if value.EventType == nil {
+			return nil, false
+		}
+		types, values = value.EventType.Fields, value.Fields
+	case cadence.Enum:
+		if value.EnumType == nil {
+			return nil, false
+		}
+		types, values = value.EnumType.Fields, value.Fields
+	default:
+		return nil, false
+	}
+
+	fields := make(map[string]cadence.Value, len(values))
+	for i, field := range types {
+		if i < len(values) {
+			fields[field.Identifier] = values[i]
+		}
+	}
+
+	return fields, true
+}
+
+// A decoder reads the fields of a composite value, recording the first error.
+type decoder struct {
+	fields map[string]cadence.Value
+	err    error
+}
+
+func (d *decoder) field(name string) cadence.Value {
+	value, ok := d.fields[name]
+	if !ok && d.err == nil {
+		d.err = flowerrors.Errorf(flowerrors.ErrDecoding, "evm: missing field %s", name)
+	}
+
+	return value
+}
+
+func (d *decoder) fail(name string, value cadence.Value) {
+	if d.err == nil {
+		d.err = flowerrors.Errorf(flowerrors.ErrDecoding, "evm: invalid field %s: %T", name, value)
+	}
+}
+
+func (d *decoder) uint64(name string) uint64 {
+	switch v := d.field(name).(type) {
+	case nil:
+	case cadence.UInt8:
+		return uint64(v)
+	case cadence.UInt16:
+		return uint64(v)
+	case cadence.UInt32:
+		return uint64(v)
+	case cadence.UInt64:
+		return uint64(v)
+	default:
+		d.fail(name, v)
+	}
+
+	return 0
+}
+
+func (d *decoder) string(name string) string {
+	switch v := d.field(name).(type) {
+	case nil:
+	case cadence.String:
+		return string(v)
+	default:
+		d.fail(name, v)
+	}
+
+	return ""
+}
+
+// enum returns the raw value of an enum field.
+func (d *decoder) enum(name string) uint64 {
+	value := d.field(name)
+	if value == nil {
+		return 0
+	}
+
+	fields, ok := compositeFields(value)
+	if !ok {
+		d.fail(name, value)
+		return 0
+	}
+
+	raw := decoder{fields: fields}
+	v := raw.uint64("rawValue")
+	if raw.err != nil && d.err == nil {
+		d.err = raw.err
+	}
+
+	return v
+}
+
+// bytes returns a [UInt8] field.
+func (d *decoder) bytes(name string) []byte {
+	value := d.field(name)
+	if value == nil {
+		return nil
+	}
+
+	array, ok := value.(cadence.Array)
+	if !ok {
+		d.fail(name, value)
+		return nil
+	}
+
+	b := make([]byte, len(array.Values))
+	for i, elem := range array.Values {
+		u, ok := elem.(cadence.UInt8)
+		if !ok {
+			d.fail(name, value)
+			return nil
+		}
+		b[i] = uint8(u)
+	}
+
+	return b
+}
+
+// hash returns a hash field, encoded either as a hex string or as a [UInt8; 32].
+func (d *decoder) hash(name string) common.Hash {
+	value := d.field(name)
+
+	if s, ok := value.(cadence.String); ok {
+		return common.HexToHash(string(s))
+	}
+
+	b := d.bytes(name)
+	if d.err == nil && len(b) != common.HashLength {
+		d.fail(name, value)
+	}
+
+	return common.BytesToHash(b)
+}
@@ -0,0 +1,155 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evm_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/evm"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+func bytesValue(b []byte) cadence.Array {
+	values := make([]cadence.Value, len(b))
+	for i, c := range b {
+		values[i] = cadence.NewUInt8(c)
+	}
+
+	return cadence.NewArray(values)
+}
+
+func fields(names ...string) []cadence.Field {
+	fields := make([]cadence.Field, len(names))
+	for i, name := range names {
+		fields[i] = cadence.Field{Identifier: name}
+	}
+
+	return fields
+}
+
+func evmAddressValue(address common.Address) cadence.Struct {
+	return cadence.NewStruct([]cadence.Value{bytesValue(address.Bytes())}).
+		WithType(&cadence.StructType{QualifiedIdentifier: "EVM.EVMAddress", Fields: fields("bytes")})
+}
+
+func resultValue(status evm.Status, deployed cadence.Value) cadence.Struct {
+	statusValue := cadence.NewEnum([]cadence.Value{cadence.NewUInt8(uint8(status))}).
+		WithType(&cadence.EnumType{QualifiedIdentifier: "EVM.Status", Fields: fields("rawValue")})
+
+	return cadence.NewStruct([]cadence.Value{
+		statusValue,
+		cadence.NewUInt64(3),
+		cadence.String("execution reverted"),
+		cadence.NewUInt64(21000),
+		bytesValue([]byte{0xca, 0xfe}),
+		cadence.NewOptional(deployed),
+	}).WithType(&cadence.StructType{
+		QualifiedIdentifier: "EVM.Result",
+		Fields:              fields("status", "errorCode", "errorMessage", "gasUsed", "data", "deployedContract"),
+	})
+}
+
+func TestDecodeResult(t *testing.T) {
+	t.Run("Deployment", func(t *testing.T) {
+		contract := common.HexToAddress("0x00000000000000000000000200000000000000a1")
+
+		result, err := evm.DecodeResult(resultValue(evm.StatusSuccessful, evmAddressValue(contract)))
+		require.NoError(t, err)
+
+		assert.Equal(t, evm.StatusSuccessful, result.Status)
+		assert.Equal(t, uint64(21000), result.GasUsed)
+		assert.Equal(t, []byte{0xca, 0xfe}, result.Data)
+		require.NotNil(t, result.DeployedContract)
+		assert.Equal(t, contract, *result.DeployedContract)
+		assert.NoError(t, result.Err())
+	})
+
+	t.Run("Failed call", func(t *testing.T) {
+		result, err := evm.DecodeResult(resultValue(evm.StatusFailed, nil))
+		require.NoError(t, err)
+
+		assert.Nil(t, result.DeployedContract)
+		assert.EqualError(t, result.Err(), "evm: call failed with code 3: execution reverted")
+	})
+
+	t.Run("Not a result", func(t *testing.T) {
+		_, err := evm.DecodeResult(cadence.String("nope"))
+		assert.True(t, errors.Is(err, flowerrors.ErrDecoding))
+	})
+}
+
+func TestDecodeTransactionExecuted(t *testing.T) {
+	emitter := common.HexToAddress("0x00000000000000000000000200000000000000a1")
+	hash := common.HexToHash("0x0102")
+	topic := common.HexToHash("0xddf252ad")
+
+	logs, err := rlp.EncodeToBytes([]interface{}{
+		[]interface{}{emitter, []common.Hash{topic}, []byte{0x01}},
+	})
+	require.NoError(t, err)
+
+	eventType := "A.e467b9dd11fa00df.EVM.TransactionExecuted"
+
+	value := cadence.NewEvent([]cadence.Value{
+		cadence.NewUInt64(42),
+		bytesValue(hash.Bytes()),
+		cadence.NewUInt16(1),
+		cadence.NewUInt8(2),
+		bytesValue([]byte{0x02, 0xf8}),
+		cadence.NewUInt16(0),
+		cadence.String(""),
+		cadence.NewUInt64(50000),
+		cadence.String(""),
+		bytesValue(logs),
+	}).WithType(&cadence.EventType{
+		QualifiedIdentifier: "EVM.TransactionExecuted",
+		Fields: fields(
+			"blockHeight", "hash", "index", "type", "payload", "errorCode",
+			"errorMessage", "gasConsumed", "contractAddress", "logs",
+		),
+	})
+
+	e, err := evm.DecodeTransactionExecuted(flow.Event{Type: eventType, Value: value})
+	require.NoError(t, err)
+
+	assert.True(t, e.Successful())
+	assert.Equal(t, uint64(42), e.BlockHeight)
+	assert.Equal(t, hash, e.Hash)
+	assert.Equal(t, uint8(2), e.Type)
+	assert.Equal(t, uint64(50000), e.GasConsumed)
+	assert.Nil(t, e.ContractAddress)
+	assert.Nil(t, e.ReturnedData)
+
+	require.Len(t, e.Logs, 1)
+	assert.Equal(t, emitter, e.Logs[0].Address)
+	assert.Equal(t, []common.Hash{topic}, e.Logs[0].Topics)
+	assert.Equal(t, []byte{0x01}, []byte(e.Logs[0].Data))
+	assert.Equal(t, hash, e.Logs[0].TransactionHash)
+
+	_, err = evm.DecodeTransactionExecuted(flow.Event{Type: "A.e467b9dd11fa00df.EVM.BlockExecuted", Value: value})
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+}
@@ -0,0 +1,347 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package evm provides access to Flow EVM, the EVM environment embedded in Flow.
+//
+// A Client calls the Ethereum JSON-RPC API of a Flow EVM gateway:
+//
+//	evmClient := evm.NewClient(evm.MainnetGatewayURL)
+//
+//	receipt, err := evmClient.WaitForReceipt(ctx, txHash, time.Second)
+//
+// The bridge helpers build Cadence transactions that move FLOW between a Flow
+// account and Flow EVM:
+//
+//	tx, err := evm.DepositFlow(flow.Mainnet, to, amount)
+package evm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// URLs of the public Flow EVM gateways.
+const (
+	MainnetGatewayURL = "https://mainnet.evm.nodes.onflow.org"
+	TestnetGatewayURL = "https://testnet.evm.nodes.onflow.org"
+)
+
+// EVM chain IDs of the Flow networks.
+const (
+	MainnetChainID = 747
+	TestnetChainID = 545
+)
+
+// A CallMsg is a message call, as passed to eth_call and eth_estimateGas.
+type CallMsg struct {
+	From     *common.Address `json:"from,omitempty"`
+	To       *common.Address `json:"to,omitempty"`
+	Gas      hexutil.Uint64  `json:"gas,omitempty"`
+	GasPrice *hexutil.Big    `json:"gasPrice,omitempty"`
+	Value    *hexutil.Big    `json:"value,omitempty"`
+	Data     hexutil.Bytes   `json:"data,omitempty"`
+}
+
+// A Log is an event emitted by an EVM contract.
+type Log struct {
+	Address          common.Address `json:"address"`
+	Topics           []common.Hash  `json:"topics"`
+	Data             hexutil.Bytes  `json:"data"`
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	TransactionHash  common.Hash    `json:"transactionHash"`
+	TransactionIndex hexutil.Uint   `json:"transactionIndex"`
+	BlockHash        common.Hash    `json:"blockHash"`
+	LogIndex         hexutil.Uint   `json:"logIndex"`
+	Removed          bool           `json:"removed"`
+}
+
+// Receipt statuses.
+const (
+	ReceiptStatusFailed     = 0
+	ReceiptStatusSuccessful = 1
+)
+
+// A Receipt is the receipt of an executed EVM transaction.
+type Receipt struct {
+	Type              hexutil.Uint64  `json:"type"`
+	Status            hexutil.Uint64  `json:"status"`
+	CumulativeGasUsed hexutil.Uint64  `json:"cumulativeGasUsed"`
+	Logs              []*Log          `json:"logs"`
+	TransactionHash   common.Hash     `json:"transactionHash"`
+	ContractAddress   *common.Address `json:"contractAddress"`
+	GasUsed           hexutil.Uint64  `json:"gasUsed"`
+	EffectiveGasPrice *hexutil.Big    `json:"effectiveGasPrice"`
+	BlockHash         common.Hash     `json:"blockHash"`
+	BlockNumber       hexutil.Uint64  `json:"blockNumber"`
+	TransactionIndex  hexutil.Uint    `json:"transactionIndex"`
+	From              common.Address  `json:"from"`
+	To                *common.Address `json:"to"`
+	RevertReason      hexutil.Bytes   `json:"revertReason,omitempty"`
+}
+
+// Successful returns true if the transaction was executed without reverting.
+func (r *Receipt) Successful() bool {
+	return r.Status == ReceiptStatusSuccessful
+}
+
+// An RPCError is an error returned by the gateway.
+//
+// Data holds the revert data of a reverted call, if any.
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("evm: %s (code %d)", e.Message, e.Code)
+}
+
+// A Client is a client of the JSON-RPC API of a Flow EVM gateway.
+type Client struct {
+	url        string
+	httpClient *http.Client
+	nextID     uint64
+}
+
+// An Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used to call the gateway.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient returns a client of the gateway at url.
+func NewClient(url string, opts ...Option) *Client {
+	c := &Client{
+		url:        url,
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// ChainID returns the EVM chain ID of the network.
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	var id hexutil.Big
+	if err := c.Call(ctx, &id, "eth_chainId"); err != nil {
+		return nil, err
+	}
+
+	return (*big.Int)(&id), nil
+}
+
+// BlockNumber returns the height of the latest EVM block.
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	var number hexutil.Uint64
+	if err := c.Call(ctx, &number, "eth_blockNumber"); err != nil {
+		return 0, err
+	}
+
+	return uint64(number), nil
+}
+
+// BalanceAt returns the balance of an address in attoflow at the latest block.
+func (c *Client) BalanceAt(ctx context.Context, address common.Address) (*big.Int, error) {
+	var balance hexutil.Big
+	if err := c.Call(ctx, &balance, "eth_getBalance", address, "latest"); err != nil {
+		return nil, err
+	}
+
+	return (*big.Int)(&balance), nil
+}
+
+// NonceAt returns the nonce of an address, including pending transactions.
+func (c *Client) NonceAt(ctx context.Context, address common.Address) (uint64, error) {
+	var nonce hexutil.Uint64
+	if err := c.Call(ctx, &nonce, "eth_getTransactionCount", address, "pending"); err != nil {
+		return 0, err
+	}
+
+	return uint64(nonce), nil
+}
+
+// GasPrice returns the gas price suggested by the gateway.
+func (c *Client) GasPrice(ctx context.Context) (*big.Int, error) {
+	var price hexutil.Big
+	if err := c.Call(ctx, &price, "eth_gasPrice"); err != nil {
+		return nil, err
+	}
+
+	return (*big.Int)(&price), nil
+}
+
+// CallContract executes a message call at the latest block without creating a transaction.
+func (c *Client) CallContract(ctx context.Context, msg CallMsg) ([]byte, error) {
+	var result hexutil.Bytes
+	if err := c.Call(ctx, &result, "eth_call", msg, "latest"); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// EstimateGas estimates the gas needed to execute a message call.
+func (c *Client) EstimateGas(ctx context.Context, msg CallMsg) (uint64, error) {
+	var gas hexutil.Uint64
+	if err := c.Call(ctx, &gas, "eth_estimateGas", msg); err != nil {
+		return 0, err
+	}
+
+	return uint64(gas), nil
+}
+
+// SendRawTransaction submits a signed, RLP-encoded EVM transaction and
+// returns its hash.
+func (c *Client) SendRawTransaction(ctx context.Context, signedTx []byte) (common.Hash, error) {
+	var hash common.Hash
+	if err := c.Call(ctx, &hash, "eth_sendRawTransaction", hexutil.Bytes(signedTx)); err != nil {
+		return common.Hash{}, err
+	}
+
+	return hash, nil
+}
+
+// TransactionReceipt returns the receipt of a transaction.
+//
+// An error wrapping flowerrors.ErrNotFound is returned if the transaction is
+// unknown or has not been executed yet.
+func (c *Client) TransactionReceipt(ctx context.Context, hash common.Hash) (*Receipt, error) {
+	var receipt *Receipt
+	if err := c.Call(ctx, &receipt, "eth_getTransactionReceipt", hash); err != nil {
+		return nil, err
+	}
+
+	if receipt == nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrNotFound, "evm: receipt of transaction %s not found", hash.Hex())
+	}
+
+	return receipt, nil
+}
+
+// WaitForReceipt polls the receipt of a transaction until it is available.
+func (c *Client) WaitForReceipt(ctx context.Context, hash common.Hash, interval time.Duration) (*Receipt, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := c.TransactionReceipt(ctx, hash)
+		if err == nil {
+			return receipt, nil
+		}
+		if flowerrors.KindOf(err) != flowerrors.KindNotFound {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// Call calls a JSON-RPC method of the gateway and decodes its result into result.
+//
+// It can be used for the methods the client has no dedicated function for.
+func (c *Client) Call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	body, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      atomic.AddUint64(&c.nextID, 1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return flowerrors.Wrap(flowerrors.ErrInvalidArgument, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return flowerrors.Errorf(flowerrors.ErrUnavailable, "evm: %s failed: %w", method, err)
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return flowerrors.Errorf(flowerrors.ErrUnavailable, "evm: %s failed: %w", method, err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return flowerrors.Errorf(flowerrors.ErrUnavailable, "evm: %s failed with status %d", method, res.StatusCode)
+	}
+
+	var rpcRes rpcResponse
+	if err := json.Unmarshal(b, &rpcRes); err != nil {
+		return flowerrors.Errorf(flowerrors.ErrDecoding, "evm: invalid %s response: %w", method, err)
+	}
+
+	if rpcRes.Error != nil {
+		return rpcRes.Error
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(rpcRes.Result, result); err != nil {
+		return flowerrors.Errorf(flowerrors.ErrDecoding, "evm: invalid %s result: %w", method, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evm_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/evm"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+type rpcCall struct {
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// newGateway returns a fake gateway answering each method with a fixed result.
+func newGateway(t *testing.T, results map[string]interface{}) (*evm.Client, *[]rpcCall) {
+	var calls []rpcCall
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID uint64 `json:"id"`
+			rpcCall
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		calls = append(calls, req.rpcCall)
+
+		res := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+
+		result, ok := results[req.Method]
+		switch {
+		case !ok:
+			res["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		case result == nil:
+			res["result"] = nil
+		default:
+			res["result"] = result
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	t.Cleanup(server.Close)
+
+	return evm.NewClient(server.URL), &calls
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	address := common.HexToAddress("0x000000000000000000000002f9e7d9b5e3b1c4a1")
+	hash := common.HexToHash("0x3f1e2a")
+
+	client, calls := newGateway(t, map[string]interface{}{
+		"eth_chainId":             "0x2eb",
+		"eth_blockNumber":         "0x10",
+		"eth_getBalance":          "0xde0b6b3a7640000",
+		"eth_call":                "0x0102",
+		"eth_sendRawTransaction":  hash.Hex(),
+		"eth_getTransactionCount": "0x3",
+		"eth_getTransactionReceipt": map[string]interface{}{
+			"type":              "0x0",
+			"status":            "0x1",
+			"cumulativeGasUsed": "0x5208",
+			"logs":              []interface{}{},
+			"transactionHash":   hash.Hex(),
+			"contractAddress":   nil,
+			"gasUsed":           "0x5208",
+			"blockHash":         hash.Hex(),
+			"blockNumber":       "0x10",
+			"transactionIndex":  "0x0",
+			"from":              address.Hex(),
+			"to":                address.Hex(),
+		},
+	})
+
+	chainID, err := client.ChainID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(evm.MainnetChainID), chainID.Int64())
+
+	number, err := client.BlockNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(16), number)
+
+	balance, err := client.BalanceAt(ctx, address)
+	require.NoError(t, err)
+	assert.Equal(t, "1000000000000000000", balance.String())
+
+	nonce, err := client.NonceAt(ctx, address)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), nonce)
+
+	result, err := client.CallContract(ctx, evm.CallMsg{To: &address, Data: []byte{0xaa}})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, result)
+
+	sent, err := client.SendRawTransaction(ctx, []byte{0xf8})
+	require.NoError(t, err)
+	assert.Equal(t, hash, sent)
+
+	receipt, err := client.TransactionReceipt(ctx, hash)
+	require.NoError(t, err)
+	assert.True(t, receipt.Successful())
+	assert.Equal(t, uint64(21000), uint64(receipt.GasUsed))
+	assert.Equal(t, address, *receipt.To)
+
+	require.Len(t, *calls, 7)
+	assert.Equal(t, "eth_call", (*calls)[4].Method)
+	assert.JSONEq(t, `{"to":"0x000000000000000000000002f9e7d9b5e3b1c4a1","data":"0xaa"}`, string((*calls)[4].Params[0]))
+	assert.JSONEq(t, `"0xf8"`, string((*calls)[5].Params[0]))
+}
+
+func TestClient_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	client, _ := newGateway(t, map[string]interface{}{
+		"eth_getTransactionReceipt": nil,
+	})
+
+	_, err := client.TransactionReceipt(ctx, common.Hash{})
+	assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+
+	_, err = client.GasPrice(ctx)
+	var rpcErr *evm.RPCError
+	require.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, -32601, rpcErr.Code)
+
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	_, err = client.WaitForReceipt(ctx, common.Hash{}, 10*time.Millisecond)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestFlowToAttoflow(t *testing.T) {
+	amount, err := cadence.NewUFix64("1.5")
+	require.NoError(t, err)
+
+	attoflow := evm.FlowToAttoflow(amount)
+	assert.Equal(t, "1500000000000000000", attoflow.String())
+
+	back, err := evm.AttoflowToFlow(new(big.Int).Add(attoflow, big.NewInt(1)))
+	require.NoError(t, err)
+	assert.Equal(t, amount, back)
+
+	_, err = evm.AttoflowToFlow(big.NewInt(-1))
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+
+	_, err = evm.AttoflowToFlow(new(big.Int).Lsh(big.NewInt(1), 128))
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+}
+
+func TestDepositFlow(t *testing.T) {
+	from := flow.HexToAddress("01")
+	to := common.HexToAddress("0x000000000000000000000002f9e7d9b5e3b1c4a1")
+	amount, err := cadence.NewUFix64("10.0")
+	require.NoError(t, err)
+
+	tx, err := evm.DepositFlow(flow.Mainnet, from, to, amount)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(tx.Script), "import EVM from 0xe467b9dd11fa00df\n")
+	assert.Equal(t, []flow.Address{from}, tx.Authorizers)
+	require.Len(t, tx.Arguments, 2)
+
+	arg, err := jsoncdc.Decode(tx.Arguments[0])
+	require.NoError(t, err)
+	assert.Equal(t, cadence.String("000000000000000000000002f9e7d9b5e3b1c4a1"), arg)
+
+	_, err = evm.DepositFlow("flow-unknown", from, to, amount)
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+}
+
+func TestWithdrawFlow(t *testing.T) {
+	account := flow.HexToAddress("01")
+
+	tx, err := evm.WithdrawFlow(flow.Testnet, account, 100)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(tx.Script), "import EVM from 0x8c5303eaa26202d6\n")
+	assert.Contains(t, string(tx.Script), "from: /storage/evm")
+	assert.Equal(t, []flow.Address{account}, tx.Authorizers)
+}
@@ -54,27 +54,19 @@ func GoogleCloudKMSDemo() {
 	}
 
 	kmsClient, err := cloudkms.NewClient(ctx)
-	if err != nil {
-		panic(err)
-	}
+	examples.Handle(err)
 
 	accountKMSSigner, err := kmsClient.SignerForKey(
 		ctx,
 		accountKMSKey,
 	)
-	if err != nil {
-		panic(err)
-	}
+	examples.Handle(err)
 
 	serviceAccount, err := flowClient.GetAccount(ctx, accountAddress)
-	if err != nil {
-		panic(err)
-	}
+	examples.Handle(err)
 
 	latestBlock, err := flowClient.GetLatestBlockHeader(ctx, true)
-	if err != nil {
-		panic(err)
-	}
+	examples.Handle(err)
 
 	accountKey := serviceAccount.Keys[accountKeyID]
 
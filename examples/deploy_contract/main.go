@@ -81,7 +81,8 @@ func DeployContractDemo() {
 	for _, event := range accountCreationTxRes.Events {
 		if event.Type == flow.EventAccountCreated {
 			accountCreatedEvent := flow.AccountCreatedEvent(event)
-			myAddress = accountCreatedEvent.Address()
+			myAddress, err = accountCreatedEvent.DecodeAddress()
+			examples.Handle(err)
 		}
 	}
 
@@ -125,7 +126,8 @@ func DeployContractDemo() {
 	for _, event := range deployContractTxResp.Events {
 		if event.Type == flow.EventAccountCreated {
 			accountCreatedEvent := flow.AccountCreatedEvent(event)
-			nftAddress = accountCreatedEvent.Address()
+			nftAddress, err = accountCreatedEvent.DecodeAddress()
+			examples.Handle(err)
 		}
 	}
 
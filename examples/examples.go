@@ -28,11 +28,11 @@ import (
 	"github.com/onflow/cadence/runtime/sema"
 	"io/ioutil"
 	"os"
-	"time"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/client"
 	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/logging"
 	"github.com/onflow/flow-go-sdk/templates"
 )
 
@@ -40,6 +40,9 @@ const configPath = "./flow.json"
 
 var (
 	conf config
+
+	// Logger is the logger the examples report progress to.
+	Logger = logging.NewTextLogger(os.Stdout, logging.InfoLevel)
 )
 
 type key struct {
@@ -98,7 +101,9 @@ func ServiceAccount(flowClient *client.Client) (flow.Address, *flow.AccountKey,
 	privateKey, err := crypto.DecodePrivateKeyHex(sigAlgo, conf.Accounts.Service.Keys[0].Context.PrivateKey)
 	Handle(err)
 
-	addr := flow.HexToAddress(conf.Accounts.Service.Address)
+	addr, err := flow.ParseAddress(conf.Accounts.Service.Address)
+	Handle(err)
+
 	acc, err := flowClient.GetAccount(context.Background(), addr)
 	Handle(err)
 
@@ -168,13 +173,17 @@ func CreateAccountWithContracts(flowClient *client.Client, publicKeys []*flow.Ac
 		}
 		accountCreatedEvent := flow.AccountCreatedEvent(event)
 
-		addr := accountCreatedEvent.Address()
+		addr, err := accountCreatedEvent.DecodeAddress()
+		Handle(err)
+
 		account, err := flowClient.GetAccount(ctx, addr)
 		Handle(err)
 
 		return account
 	}
-	panic("could not find an AccountCreatedEvent")
+
+	Handle(fmt.Errorf("transaction %s did not emit an %s event", createAccountTx.ID(), flow.EventAccountCreated))
+	return nil
 }
 
 /**
@@ -219,8 +228,10 @@ func FundAccountInEmulator(flowClient *client.Client, address flow.Address, amou
 
 	referenceBlockID := GetReferenceBlockId(flowClient)
 
-	fungibleTokenAddress := flow.HexToAddress(conf.Contracts["FungibleToken"])
-	flowTokenAddress := flow.HexToAddress(conf.Contracts["FlowToken"])
+	fungibleTokenAddress, err := flow.ParseAddress(conf.Contracts["FungibleToken"])
+	Handle(err)
+	flowTokenAddress, err := flow.ParseAddress(conf.Contracts["FlowToken"])
+	Handle(err)
 
 	recipient := cadence.NewAddress(address)
 	uintAmount := uint64(amount * sema.Fix64Factor)
@@ -236,7 +247,7 @@ func FundAccountInEmulator(flowClient *client.Client, address flow.Address, amou
 			SetReferenceBlockID(referenceBlockID).
 			SetPayer(serviceAcctAddr)
 
-	err := fundAccountTx.SignEnvelope(serviceAcctAddr, serviceAcctKey.Index, serviceSigner)
+	err = fundAccountTx.SignEnvelope(serviceAcctAddr, serviceAcctKey.Index, serviceSigner)
 	Handle(err)
 
 	ctx := context.Background()
@@ -251,27 +262,23 @@ func CreateAccount(flowClient *client.Client, publicKeys []*flow.AccountKey) *fl
 	return CreateAccountWithContracts(flowClient, publicKeys, nil)
 }
 
+// Handle reports err and exits the example if err is not nil.
+//
+// The examples stop at the first error rather than panicking, so a failure prints
+// the error itself instead of a stack trace.
 func Handle(err error) {
 	if err != nil {
 		fmt.Println("err:", err.Error())
-		panic(err)
+		os.Exit(1)
 	}
 }
 
 func WaitForSeal(ctx context.Context, c *client.Client, id flow.Identifier) *flow.TransactionResult {
-	result, err := c.GetTransactionResult(ctx, id)
-	Handle(err)
-
-	fmt.Printf("Waiting for transaction %s to be sealed...\n", id)
+	Logger.Log(logging.InfoLevel, "waiting for transaction to be sealed", logging.TransactionID(id))
 
-	for result.Status != flow.TransactionStatusSealed {
-		time.Sleep(time.Second)
-		fmt.Print(".")
-		result, err = c.GetTransactionResult(ctx, id)
-		Handle(err)
-	}
+	result, err := c.WaitForStatus(ctx, id, flow.TransactionStatusSealed)
+	Handle(err)
 
-	fmt.Println()
-	fmt.Printf("Transaction %s sealed\n", id)
+	Logger.Log(logging.InfoLevel, "transaction sealed", logging.TransactionID(id))
 	return result
 }
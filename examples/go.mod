@@ -0,0 +1,15 @@
+module github.com/onflow/flow-go-sdk/examples
+
+go 1.13
+
+require (
+	github.com/onflow/cadence v0.18.0
+	github.com/onflow/flow-go-sdk v0.21.0
+	github.com/onflow/flow-go-sdk/crypto/cloudkms v0.21.0
+	google.golang.org/grpc v1.51.0
+)
+
+replace (
+	github.com/onflow/flow-go-sdk => ../
+	github.com/onflow/flow-go-sdk/crypto/cloudkms => ../crypto/cloudkms
+)
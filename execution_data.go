@@ -0,0 +1,63 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+// BlockExecutionData is the data produced by the execution of a block, as served
+// by the Execution Data API.
+type BlockExecutionData struct {
+	BlockID Identifier
+	// ChunkExecutionData holds the data of every chunk of the block, in the order of
+	// the chunks of the execution result. The last chunk is the system chunk.
+	ChunkExecutionData []*ChunkExecutionData
+}
+
+// ChunkExecutionData is the data produced by the execution of a chunk: the
+// executed collection, the emitted events and the updated registers.
+type ChunkExecutionData struct {
+	Transactions []*Transaction
+	Events       []Event
+	// TrieUpdate is nil if the chunk did not update any register.
+	TrieUpdate *TrieUpdate
+}
+
+// Collection returns the collection executed in the chunk.
+func (c *ChunkExecutionData) Collection() Collection {
+	transactionIDs := make([]Identifier, len(c.Transactions))
+	for i, tx := range c.Transactions {
+		transactionIDs[i] = tx.ID()
+	}
+
+	return Collection{TransactionIDs: transactionIDs}
+}
+
+// TrieUpdate is an update of the registers of the execution state.
+type TrieUpdate struct {
+	// RootHash is the state commitment the update is applied to.
+	RootHash StateCommitment
+	// Registers holds the new values of the updated registers.
+	Registers []RegisterUpdate
+}
+
+// RegisterUpdate is the new value of a register.
+type RegisterUpdate struct {
+	// Path is the path of the register in the execution state trie.
+	Path       []byte
+	RegisterID RegisterID
+	Value      []byte
+}
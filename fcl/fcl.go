@@ -0,0 +1,287 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fcl provides server-side building blocks for wallets that implement
+// the Flow Client Library (FCL) wallet provider protocol.
+//
+// The package implements the FCL wire formats (services, polling responses,
+// authentication responses, signables and composite signatures) and HTTP
+// handlers for the authn, authz and user-signature services, so that a wallet
+// or back-channel service can be written in Go without reimplementing them:
+//
+//	http.Handle("/authn", fcl.AuthnHandler(func(ctx context.Context, req *fcl.AuthnRequest) (*fcl.AuthnResponse, error) {
+//	    authz := fcl.NewService(fcl.ServiceTypeAuthz, fcl.MethodHTTPPost, "https://wallet.example.com/authz")
+//	    authz.Identity = fcl.NewIdentity(address, keyIndex)
+//
+//	    return fcl.NewAuthnResponse(address, authz), nil
+//	}))
+//
+//	http.Handle("/authz", fcl.AuthzHandler(fcl.SignerAuthz(address, keyIndex, signer, approve)))
+//
+// Reference: https://github.com/onflow/fcl-js/blob/master/packages/fcl/src/wallet-provider-spec
+package fcl
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// Types of FCL services.
+const (
+	ServiceTypeAuthn          = "authn"
+	ServiceTypeAuthnRefresh   = "authn-refresh"
+	ServiceTypeAuthz          = "authz"
+	ServiceTypePreAuthz       = "pre-authz"
+	ServiceTypeUserSignature  = "user-signature"
+	ServiceTypeAccountProof   = "account-proof"
+	ServiceTypeBackChannelRPC = "back-channel-rpc"
+	ServiceTypeOpenID         = "open-id"
+)
+
+// Methods by which FCL communicates with a service.
+const (
+	MethodHTTPPost  = "HTTP/POST"
+	MethodIframeRPC = "IFRAME/RPC"
+	MethodPopRPC    = "POP/RPC"
+	MethodTabRPC    = "TAB/RPC"
+	MethodExtRPC    = "EXT/RPC"
+	MethodData      = "DATA"
+)
+
+// Statuses of a PollingResponse.
+const (
+	StatusApproved = "APPROVED"
+	StatusDeclined = "DECLINED"
+	StatusPending  = "PENDING"
+	StatusRedirect = "REDIRECT"
+)
+
+// A Service is an FCL service, such as the authz service of a wallet.
+type Service struct {
+	FType    string            `json:"f_type"`
+	FVsn     string            `json:"f_vsn"`
+	Type     string            `json:"type"`
+	Method   string            `json:"method,omitempty"`
+	UID      string            `json:"uid,omitempty"`
+	Endpoint string            `json:"endpoint,omitempty"`
+	ID       string            `json:"id,omitempty"`
+	Identity *Identity         `json:"identity,omitempty"`
+	Provider *Provider         `json:"provider,omitempty"`
+	Params   map[string]string `json:"params,omitempty"`
+	Data     interface{}       `json:"data,omitempty"`
+}
+
+// NewService returns a service of the given type, reached with method at endpoint.
+func NewService(serviceType, method, endpoint string) Service {
+	return Service{
+		FType:    "Service",
+		FVsn:     "1.0.0",
+		Type:     serviceType,
+		Method:   method,
+		Endpoint: endpoint,
+	}
+}
+
+// An Identity is the account key a service signs with.
+type Identity struct {
+	FType   string `json:"f_type"`
+	FVsn    string `json:"f_vsn"`
+	Address string `json:"address"`
+	KeyID   int    `json:"keyId"`
+}
+
+// NewIdentity returns the identity of an account key.
+func NewIdentity(address flow.Address, keyIndex int) *Identity {
+	return &Identity{
+		FType:   "Identity",
+		FVsn:    "1.0.0",
+		Address: formatAddress(address),
+		KeyID:   keyIndex,
+	}
+}
+
+// A Provider describes the wallet that provides a service.
+type Provider struct {
+	FType       string `json:"f_type"`
+	FVsn        string `json:"f_vsn"`
+	Address     string `json:"address,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Icon        string `json:"icon,omitempty"`
+	Description string `json:"description,omitempty"`
+	Website     string `json:"website,omitempty"`
+}
+
+// NewProvider returns a provider with the given name.
+func NewProvider(address flow.Address, name string) *Provider {
+	return &Provider{
+		FType:   "ServiceProvider",
+		FVsn:    "1.0.0",
+		Address: formatAddress(address),
+		Name:    name,
+	}
+}
+
+// An AuthnResponse is the data of an approved authn request: the address of
+// the authenticated account and the services it offers.
+type AuthnResponse struct {
+	FType    string    `json:"f_type"`
+	FVsn     string    `json:"f_vsn"`
+	Addr     string    `json:"addr"`
+	Services []Service `json:"services"`
+}
+
+// NewAuthnResponse returns an authn response for an account.
+func NewAuthnResponse(address flow.Address, services ...Service) *AuthnResponse {
+	if services == nil {
+		services = []Service{}
+	}
+
+	return &AuthnResponse{
+		FType:    "AuthnResponse",
+		FVsn:     "1.0.0",
+		Addr:     formatAddress(address),
+		Services: services,
+	}
+}
+
+// A PreAuthzResponse is the data of an approved pre-authz request: the
+// services that sign for each role of a transaction.
+type PreAuthzResponse struct {
+	FType         string    `json:"f_type"`
+	FVsn          string    `json:"f_vsn"`
+	Proposer      *Service  `json:"proposer"`
+	Payer         []Service `json:"payer"`
+	Authorization []Service `json:"authorization"`
+}
+
+// NewPreAuthzResponse returns a pre-authz response.
+func NewPreAuthzResponse(proposer *Service, payer []Service, authorization []Service) *PreAuthzResponse {
+	return &PreAuthzResponse{
+		FType:         "PreAuthzResponse",
+		FVsn:          "1.0.0",
+		Proposer:      proposer,
+		Payer:         payer,
+		Authorization: authorization,
+	}
+}
+
+// A CompositeSignature is a signature together with the account key that produced it.
+type CompositeSignature struct {
+	FType     string `json:"f_type"`
+	FVsn      string `json:"f_vsn"`
+	Addr      string `json:"addr"`
+	KeyID     int    `json:"keyId"`
+	Signature string `json:"signature"`
+}
+
+// NewCompositeSignature returns a composite signature.
+func NewCompositeSignature(address flow.Address, keyIndex int, signature []byte) *CompositeSignature {
+	return &CompositeSignature{
+		FType:     "CompositeSignature",
+		FVsn:      "1.0.0",
+		Addr:      formatAddress(address),
+		KeyID:     keyIndex,
+		Signature: hex.EncodeToString(signature),
+	}
+}
+
+// Address returns the address of the account that produced the signature.
+func (s *CompositeSignature) Address() (flow.Address, error) {
+	return parseAddress(s.Addr)
+}
+
+// SignatureBytes returns the decoded signature.
+func (s *CompositeSignature) SignatureBytes() ([]byte, error) {
+	return decodeHex("signature", s.Signature)
+}
+
+// A PollingResponse is the response of a service to an FCL request.
+type PollingResponse struct {
+	FType   string      `json:"f_type"`
+	FVsn    string      `json:"f_vsn"`
+	Status  string      `json:"status"`
+	Reason  *string     `json:"reason"`
+	Data    interface{} `json:"data,omitempty"`
+	Updates *Service    `json:"updates,omitempty"`
+	Local   *Service    `json:"local,omitempty"`
+}
+
+func newPollingResponse(status string) *PollingResponse {
+	return &PollingResponse{
+		FType:  "PollingResponse",
+		FVsn:   "1.0.0",
+		Status: status,
+	}
+}
+
+// Approved returns a response approving a request with the given data.
+func Approved(data interface{}) *PollingResponse {
+	res := newPollingResponse(StatusApproved)
+	res.Data = data
+	return res
+}
+
+// Declined returns a response declining a request for the given reason.
+func Declined(reason string) *PollingResponse {
+	res := newPollingResponse(StatusDeclined)
+	res.Reason = &reason
+	return res
+}
+
+// Pending returns a response telling FCL that the request is not decided yet.
+//
+// FCL polls the updates service, usually a back-channel-rpc service, until
+// it returns a response that is not pending. The local service, if any, is
+// displayed to the user in the meantime.
+func Pending(updates *Service, local *Service) *PollingResponse {
+	res := newPollingResponse(StatusPending)
+	res.Updates = updates
+	res.Local = local
+	return res
+}
+
+// formatAddress returns the 0x-prefixed hex form of an address used by FCL.
+func formatAddress(address flow.Address) string {
+	return "0x" + address.Hex()
+}
+
+// parseAddress parses an address with or without the 0x prefix.
+func parseAddress(s string) (flow.Address, error) {
+	if s == "" {
+		return flow.EmptyAddress, flowerrors.New(flowerrors.ErrDecoding, "fcl: address is empty")
+	}
+
+	address, err := flow.ParseAddress(s)
+	if err != nil {
+		return flow.EmptyAddress, flowerrors.Errorf(flowerrors.ErrDecoding, "fcl: %w", err)
+	}
+
+	return address, nil
+}
+
+func decodeHex(field, s string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "fcl: invalid %s: %w", field, err)
+	}
+
+	return b, nil
+}
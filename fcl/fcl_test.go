@@ -0,0 +1,294 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fcl_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/fcl"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+type authzFixture struct {
+	tx     *flow.Transaction
+	key    *flow.AccountKey
+	signer crypto.Signer
+}
+
+func newAuthzFixture() *authzFixture {
+	key, signer := test.AccountKeyGenerator().NewWithSigner()
+	tx := test.TransactionGenerator().NewUnsigned()
+
+	return &authzFixture{tx: tx, key: key, signer: signer}
+}
+
+func verify(t *testing.T, key *flow.AccountKey, sig *fcl.CompositeSignature, message []byte) {
+	signature, err := sig.SignatureBytes()
+	require.NoError(t, err)
+
+	hasher, err := crypto.NewHasher(key.HashAlgo)
+	require.NoError(t, err)
+
+	valid, err := key.PublicKey.Verify(signature, message, hasher)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func post(t *testing.T, handler http.Handler, body interface{}) (int, fcl.PollingResponse) {
+	b, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(b)))
+
+	var res fcl.PollingResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+	return rec.Code, res
+}
+
+func TestVoucher_Transaction(t *testing.T) {
+	tx := test.TransactionGenerator().New()
+
+	b, err := json.Marshal(fcl.NewVoucher(tx))
+	require.NoError(t, err)
+
+	var voucher fcl.Voucher
+	require.NoError(t, json.Unmarshal(b, &voucher))
+
+	decoded, err := voucher.Transaction()
+	require.NoError(t, err)
+
+	assert.Equal(t, tx.Script, decoded.Script)
+	assert.Equal(t, tx.ReferenceBlockID, decoded.ReferenceBlockID)
+	assert.Equal(t, tx.ProposalKey, decoded.ProposalKey)
+	assert.Equal(t, tx.Payer, decoded.Payer)
+	assert.Equal(t, tx.Authorizers, decoded.Authorizers)
+	require.Len(t, decoded.Arguments, len(tx.Arguments))
+	for i, arg := range tx.Arguments {
+		assert.Equal(t, bytes.TrimSpace(arg), decoded.Arguments[i])
+	}
+	assert.Equal(t, tx.PayloadSignatures, decoded.PayloadSignatures)
+	assert.Equal(t, tx.EnvelopeSignatures, decoded.EnvelopeSignatures)
+}
+
+func TestSignable_Validate(t *testing.T) {
+	t.Run("Proposer", func(t *testing.T) {
+		tx := test.TransactionGenerator().NewUnsigned()
+
+		signable := fcl.NewSignable(tx, tx.ProposalKey.Address, tx.ProposalKey.KeyIndex, fcl.Roles{Proposer: true})
+		assert.NoError(t, signable.Validate())
+	})
+
+	t.Run("Payer signs envelope", func(t *testing.T) {
+		tx := test.TransactionGenerator().NewUnsigned()
+
+		signable := fcl.NewSignable(tx, tx.Payer, 0, fcl.Roles{Payer: true})
+		require.NoError(t, signable.Validate())
+
+		decoded, err := signable.Transaction()
+		require.NoError(t, err)
+
+		message, err := signable.MessageBytes()
+		require.NoError(t, err)
+		assert.Equal(t, append(flow.TransactionDomainTag[:], decoded.EnvelopeMessage()...), message)
+	})
+
+	t.Run("Not the payer", func(t *testing.T) {
+		tx := test.TransactionGenerator().NewUnsigned()
+
+		signable := fcl.NewSignable(tx, tx.Authorizers[0], 0, fcl.Roles{Payer: true})
+		err := signable.Validate()
+		assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+	})
+
+	t.Run("No roles", func(t *testing.T) {
+		tx := test.TransactionGenerator().NewUnsigned()
+
+		signable := fcl.NewSignable(tx, tx.Payer, 0, fcl.Roles{})
+		err := signable.Validate()
+		assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+	})
+
+	t.Run("Tampered voucher", func(t *testing.T) {
+		tx := test.TransactionGenerator().NewUnsigned()
+
+		signable := fcl.NewSignable(tx, tx.Authorizers[0], 0, fcl.Roles{Authorizer: true})
+		signable.Voucher.Cadence = "transaction { execute { panic(\"no\") } }"
+
+		err := signable.Validate()
+		assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+	})
+}
+
+func TestDecodeSignable(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		tx := test.TransactionGenerator().NewUnsigned()
+
+		b, err := json.Marshal(fcl.NewSignable(tx, tx.Payer, 0, fcl.Roles{Payer: true}))
+		require.NoError(t, err)
+
+		signable, err := fcl.DecodeSignable(b)
+		require.NoError(t, err)
+		assert.NoError(t, signable.Validate())
+
+		expected, err := fcl.NewVoucher(tx).Transaction()
+		require.NoError(t, err)
+
+		decoded, err := signable.Transaction()
+		require.NoError(t, err)
+		assert.Equal(t, expected.ID(), decoded.ID())
+	})
+
+	t.Run("Wrong type", func(t *testing.T) {
+		_, err := fcl.DecodeSignable([]byte(`{"f_type":"PreSignable"}`))
+		assert.True(t, errors.Is(err, flowerrors.ErrDecoding))
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := fcl.DecodeSignable([]byte(`{`))
+		assert.True(t, errors.Is(err, flowerrors.ErrDecoding))
+	})
+}
+
+func TestAuthnHandler(t *testing.T) {
+	address := test.AddressGenerator().New()
+
+	handler := fcl.AuthnHandler(func(ctx context.Context, req *fcl.AuthnRequest) (*fcl.AuthnResponse, error) {
+		assert.Equal(t, "1.0.0", req.FclVersion)
+
+		authz := fcl.NewService(fcl.ServiceTypeAuthz, fcl.MethodHTTPPost, "https://wallet.example.com/authz")
+		authz.Identity = fcl.NewIdentity(address, 0)
+
+		return fcl.NewAuthnResponse(address, authz), nil
+	})
+
+	code, res := post(t, handler, fcl.AuthnRequest{FclVersion: "1.0.0"})
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, fcl.StatusApproved, res.Status)
+
+	data := res.Data.(map[string]interface{})
+	assert.Equal(t, "0x"+address.Hex(), data["addr"])
+	assert.Len(t, data["services"], 1)
+}
+
+func TestAuthzHandler(t *testing.T) {
+	t.Run("Approved", func(t *testing.T) {
+		f := newAuthzFixture()
+		signable := fcl.NewSignable(f.tx, f.tx.Payer, 0, fcl.Roles{Payer: true})
+
+		handler := fcl.AuthzHandler(fcl.SignerAuthz(f.tx.Payer, 0, f.signer, nil))
+
+		code, res := post(t, handler, signable)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, fcl.StatusApproved, res.Status)
+
+		b, err := json.Marshal(res.Data)
+		require.NoError(t, err)
+
+		var sig fcl.CompositeSignature
+		require.NoError(t, json.Unmarshal(b, &sig))
+
+		address, err := sig.Address()
+		require.NoError(t, err)
+		assert.Equal(t, f.tx.Payer, address)
+
+		message, err := signable.MessageBytes()
+		require.NoError(t, err)
+		verify(t, f.key, &sig, message)
+	})
+
+	t.Run("Declined by user", func(t *testing.T) {
+		f := newAuthzFixture()
+		signable := fcl.NewSignable(f.tx, f.tx.Payer, 0, fcl.Roles{Payer: true})
+
+		approve := func(ctx context.Context, request interface{}) error {
+			assert.IsType(t, &fcl.Signable{}, request)
+			return errors.New("user declined")
+		}
+
+		code, res := post(t, fcl.AuthzHandler(fcl.SignerAuthz(f.tx.Payer, 0, f.signer, approve)), signable)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, fcl.StatusDeclined, res.Status)
+		require.NotNil(t, res.Reason)
+		assert.Equal(t, "user declined", *res.Reason)
+	})
+
+	t.Run("Other account", func(t *testing.T) {
+		f := newAuthzFixture()
+		signable := fcl.NewSignable(f.tx, f.tx.Authorizers[0], 0, fcl.Roles{Authorizer: true})
+
+		code, res := post(t, fcl.AuthzHandler(fcl.SignerAuthz(f.tx.Payer, 0, f.signer, nil)), signable)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, fcl.StatusDeclined, res.Status)
+	})
+
+	t.Run("Malformed signable", func(t *testing.T) {
+		f := newAuthzFixture()
+
+		code, res := post(t, fcl.AuthzHandler(fcl.SignerAuthz(f.tx.Payer, 0, f.signer, nil)), []int{1})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, fcl.StatusDeclined, res.Status)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		f := newAuthzFixture()
+
+		rec := httptest.NewRecorder()
+		fcl.AuthzHandler(fcl.SignerAuthz(f.tx.Payer, 0, f.signer, nil)).
+			ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
+
+func TestUserSignatureHandler(t *testing.T) {
+	key, signer := test.AccountKeyGenerator().NewWithSigner()
+	address := test.AddressGenerator().New()
+	message := []byte("hello world")
+
+	handler := fcl.UserSignatureHandler(fcl.SignerUserSignature(address, key.Index, signer, nil))
+
+	code, res := post(t, handler, fcl.UserSignatureRequest{Message: hex.EncodeToString(message)})
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, fcl.StatusApproved, res.Status)
+
+	b, err := json.Marshal(res.Data)
+	require.NoError(t, err)
+
+	var sigs []fcl.CompositeSignature
+	require.NoError(t, json.Unmarshal(b, &sigs))
+	require.Len(t, sigs, 1)
+	assert.Equal(t, key.Index, sigs[0].KeyID)
+
+	verify(t, key, &sigs[0], append(flow.UserDomainTag[:], message...))
+}
@@ -0,0 +1,248 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fcl
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// maxRequestSize is the maximum size of a request body accepted by the handlers.
+const maxRequestSize = 1 << 20
+
+// An AuthnRequest is a request of FCL to authenticate a user.
+type AuthnRequest struct {
+	FclVersion    string          `json:"fclVersion"`
+	AppIdentifier string          `json:"appIdentifier,omitempty"`
+	Nonce         string          `json:"nonce,omitempty"`
+	Config        json.RawMessage `json:"config,omitempty"`
+	// Params are the query parameters of the request, set from the params
+	// of the service FCL called.
+	Params url.Values `json:"-"`
+}
+
+// A UserSignatureRequest is a request of FCL to sign an arbitrary message.
+type UserSignatureRequest struct {
+	// Message is the hex-encoded message to sign, without domain tag.
+	Message string `json:"message"`
+	// Params are the query parameters of the request.
+	Params url.Values `json:"-"`
+}
+
+// MessageBytes returns the decoded message to sign.
+func (r *UserSignatureRequest) MessageBytes() ([]byte, error) {
+	return decodeHex("message", r.Message)
+}
+
+// An AuthnFunc authenticates a user.
+type AuthnFunc func(ctx context.Context, req *AuthnRequest) (*AuthnResponse, error)
+
+// An AuthzFunc signs the signable of a transaction.
+type AuthzFunc func(ctx context.Context, signable *Signable) (*CompositeSignature, error)
+
+// A UserSignatureFunc signs a user message.
+type UserSignatureFunc func(ctx context.Context, req *UserSignatureRequest) ([]*CompositeSignature, error)
+
+// An ApproveFunc asks the user to approve a request. It returns an error if
+// the request is declined.
+type ApproveFunc func(ctx context.Context, request interface{}) error
+
+// AuthnHandler returns an HTTP handler for an authn service.
+//
+// The handler responds with an approved polling response containing the
+// AuthnResponse returned by fn, or with a declined response if fn fails.
+func AuthnHandler(fn AuthnFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req AuthnRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+		req.Params = r.URL.Query()
+
+		res, err := fn(r.Context(), &req)
+		if err != nil {
+			WriteResponse(w, http.StatusOK, Declined(err.Error()))
+			return
+		}
+
+		WriteResponse(w, http.StatusOK, Approved(res))
+	})
+}
+
+// AuthzHandler returns an HTTP handler for an authz service.
+//
+// The handler decodes the signable posted by FCL and responds with an
+// approved polling response containing the composite signature returned by
+// fn, or with a declined response if fn fails.
+func AuthzHandler(fn AuthzFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readRequest(w, r)
+		if !ok {
+			return
+		}
+
+		signable, err := DecodeSignable(body)
+		if err != nil {
+			WriteResponse(w, http.StatusBadRequest, Declined(err.Error()))
+			return
+		}
+
+		sig, err := fn(r.Context(), signable)
+		if err != nil {
+			WriteResponse(w, http.StatusOK, Declined(err.Error()))
+			return
+		}
+
+		WriteResponse(w, http.StatusOK, Approved(sig))
+	})
+}
+
+// UserSignatureHandler returns an HTTP handler for a user-signature service.
+//
+// The handler responds with an approved polling response containing the
+// composite signatures returned by fn, or with a declined response if fn fails.
+func UserSignatureHandler(fn UserSignatureFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req UserSignatureRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+		req.Params = r.URL.Query()
+
+		sigs, err := fn(r.Context(), &req)
+		if err != nil {
+			WriteResponse(w, http.StatusOK, Declined(err.Error()))
+			return
+		}
+
+		WriteResponse(w, http.StatusOK, Approved(sigs))
+	})
+}
+
+// SignerAuthz returns an AuthzFunc that signs signables addressed to the
+// given account key with signer.
+//
+// Signables are validated before they are passed to approve, which may be
+// nil to sign without asking the user.
+func SignerAuthz(address flow.Address, keyIndex int, signer crypto.Signer, approve ApproveFunc) AuthzFunc {
+	return func(ctx context.Context, signable *Signable) (*CompositeSignature, error) {
+		signableAddress, err := signable.Address()
+		if err != nil {
+			return nil, err
+		}
+
+		if signableAddress != address || signable.KeyID != keyIndex {
+			return nil, flowerrors.Errorf(
+				flowerrors.ErrInvalidArgument,
+				"fcl: signable is for key %d of %s, not key %d of %s",
+				signable.KeyID, signableAddress, keyIndex, address,
+			)
+		}
+
+		if err := signable.Validate(); err != nil {
+			return nil, err
+		}
+
+		if approve != nil {
+			if err := approve(ctx, signable); err != nil {
+				return nil, err
+			}
+		}
+
+		return signable.Sign(signer)
+	}
+}
+
+// SignerUserSignature returns a UserSignatureFunc that signs user messages
+// with the given account key.
+//
+// Messages are prefixed with the user domain tag before they are signed.
+// approve may be nil to sign without asking the user.
+func SignerUserSignature(address flow.Address, keyIndex int, signer crypto.Signer, approve ApproveFunc) UserSignatureFunc {
+	return func(ctx context.Context, req *UserSignatureRequest) ([]*CompositeSignature, error) {
+		message, err := req.MessageBytes()
+		if err != nil {
+			return nil, err
+		}
+
+		if approve != nil {
+			if err := approve(ctx, req); err != nil {
+				return nil, err
+			}
+		}
+
+		sig, err := flow.SignAndAudit(signer, flow.UserDomainTag.Tag(message), flow.SignatureRecord{
+			Address:  address,
+			KeyIndex: keyIndex,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return []*CompositeSignature{NewCompositeSignature(address, keyIndex, sig)}, nil
+	}
+}
+
+// WriteResponse writes a polling response as JSON with the given status code.
+func WriteResponse(w http.ResponseWriter, statusCode int, res *PollingResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func readRequest(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		WriteResponse(w, http.StatusMethodNotAllowed, Declined("fcl: method not allowed"))
+		return nil, false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		WriteResponse(w, http.StatusBadRequest, Declined("fcl: failed to read request"))
+		return nil, false
+	}
+
+	return body, true
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	body, ok := readRequest(w, r)
+	if !ok {
+		return false
+	}
+
+	if len(body) == 0 {
+		return true
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		WriteResponse(w, http.StatusBadRequest, Declined("fcl: failed to decode request"))
+		return false
+	}
+
+	return true
+}
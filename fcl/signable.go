@@ -0,0 +1,380 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fcl
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// Roles are the roles an account key signs a transaction for.
+type Roles struct {
+	Proposer   bool `json:"proposer"`
+	Authorizer bool `json:"authorizer"`
+	Payer      bool `json:"payer"`
+	Param      bool `json:"param"`
+}
+
+// signatureRoles returns the transaction roles, for auditing.
+func (r Roles) signatureRoles() []flow.SignatureRole {
+	var roles []flow.SignatureRole
+	if r.Proposer {
+		roles = append(roles, flow.SignatureRoleProposer)
+	}
+	if r.Authorizer {
+		roles = append(roles, flow.SignatureRoleAuthorizer)
+	}
+	if r.Payer {
+		roles = append(roles, flow.SignatureRolePayer)
+	}
+	return roles
+}
+
+// A ProposalKey is the proposal key of a voucher.
+type ProposalKey struct {
+	Address     string `json:"address"`
+	KeyID       int    `json:"keyId"`
+	SequenceNum uint64 `json:"sequenceNum"`
+}
+
+// A VoucherSignature is a transaction signature in a voucher. Sig is nil for
+// signatures that have not been collected yet.
+type VoucherSignature struct {
+	Address string  `json:"address"`
+	KeyID   int     `json:"keyId"`
+	Sig     *string `json:"sig"`
+}
+
+// A Voucher is the transaction of a signable, in the form FCL sends it.
+type Voucher struct {
+	Cadence      string             `json:"cadence"`
+	RefBlock     string             `json:"refBlock"`
+	ComputeLimit uint64             `json:"computeLimit"`
+	Arguments    []json.RawMessage  `json:"arguments"`
+	ProposalKey  ProposalKey        `json:"proposalKey"`
+	Payer        string             `json:"payer"`
+	Authorizers  []string           `json:"authorizers"`
+	PayloadSigs  []VoucherSignature `json:"payloadSigs"`
+	EnvelopeSigs []VoucherSignature `json:"envelopeSigs"`
+}
+
+// NewVoucher returns the voucher of a transaction.
+func NewVoucher(tx *flow.Transaction) Voucher {
+	v := Voucher{
+		Cadence:      string(tx.Script),
+		RefBlock:     tx.ReferenceBlockID.Hex(),
+		ComputeLimit: tx.GasLimit,
+		Arguments:    make([]json.RawMessage, len(tx.Arguments)),
+		ProposalKey: ProposalKey{
+			Address:     formatAddress(tx.ProposalKey.Address),
+			KeyID:       tx.ProposalKey.KeyIndex,
+			SequenceNum: tx.ProposalKey.SequenceNumber,
+		},
+		Payer:        formatAddress(tx.Payer),
+		Authorizers:  make([]string, len(tx.Authorizers)),
+		PayloadSigs:  voucherSignatures(tx.PayloadSignatures),
+		EnvelopeSigs: voucherSignatures(tx.EnvelopeSignatures),
+	}
+
+	for i, arg := range tx.Arguments {
+		v.Arguments[i] = json.RawMessage(bytes.TrimSpace(arg))
+	}
+
+	for i, authorizer := range tx.Authorizers {
+		v.Authorizers[i] = formatAddress(authorizer)
+	}
+
+	return v
+}
+
+func voucherSignatures(signatures []flow.TransactionSignature) []VoucherSignature {
+	sigs := make([]VoucherSignature, len(signatures))
+
+	for i, signature := range signatures {
+		sig := hex.EncodeToString(signature.Signature)
+		sigs[i] = VoucherSignature{
+			Address: formatAddress(signature.Address),
+			KeyID:   signature.KeyIndex,
+			Sig:     &sig,
+		}
+	}
+
+	return sigs
+}
+
+// Transaction returns the transaction described by the voucher, including
+// the signatures collected so far.
+//
+// Arguments are encoded in the compact JSON form FCL uses when it computes
+// the signed message.
+func (v Voucher) Transaction() (*flow.Transaction, error) {
+	refBlockID, err := flow.ParseID(v.RefBlock)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "fcl: invalid reference block: %w", err)
+	}
+
+	proposer, err := parseAddress(v.ProposalKey.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	payer, err := parseAddress(v.Payer)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &flow.Transaction{
+		Script:           []byte(v.Cadence),
+		ReferenceBlockID: refBlockID,
+		GasLimit:         v.ComputeLimit,
+	}
+	tx.SetProposalKey(proposer, v.ProposalKey.KeyID, v.ProposalKey.SequenceNum)
+	tx.SetPayer(payer)
+
+	for _, arg := range v.Arguments {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, arg); err != nil {
+			return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "fcl: invalid argument: %w", err)
+		}
+		tx.AddRawArgument(compact.Bytes())
+	}
+
+	for _, authorizer := range v.Authorizers {
+		address, err := parseAddress(authorizer)
+		if err != nil {
+			return nil, err
+		}
+		tx.AddAuthorizer(address)
+	}
+
+	for _, signature := range v.PayloadSigs {
+		address, sig, ok, err := signature.decode()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			tx.AddPayloadSignature(address, signature.KeyID, sig)
+		}
+	}
+
+	for _, signature := range v.EnvelopeSigs {
+		address, sig, ok, err := signature.decode()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			tx.AddEnvelopeSignature(address, signature.KeyID, sig)
+		}
+	}
+
+	return tx, nil
+}
+
+// decode returns the address and signature of a voucher signature. ok is
+// false if the signature has not been collected yet.
+func (s VoucherSignature) decode() (address flow.Address, sig []byte, ok bool, err error) {
+	if s.Sig == nil || *s.Sig == "" {
+		return flow.EmptyAddress, nil, false, nil
+	}
+
+	address, err = parseAddress(s.Address)
+	if err != nil {
+		return flow.EmptyAddress, nil, false, err
+	}
+
+	sig, err = decodeHex("signature", *s.Sig)
+	if err != nil {
+		return flow.EmptyAddress, nil, false, err
+	}
+
+	return address, sig, true, nil
+}
+
+// A Signable is a request to sign a transaction, sent by FCL to an authz service.
+//
+// Message is the hex-encoded message to sign: the domain-tagged transaction
+// payload, or the domain-tagged envelope if the key signs as the payer.
+type Signable struct {
+	FType       string            `json:"f_type"`
+	FVsn        string            `json:"f_vsn"`
+	Message     string            `json:"message"`
+	Addr        string            `json:"addr"`
+	KeyID       int               `json:"keyId"`
+	Roles       Roles             `json:"roles"`
+	Cadence     string            `json:"cadence"`
+	Args        []json.RawMessage `json:"args"`
+	Data        json.RawMessage   `json:"data,omitempty"`
+	Interaction json.RawMessage   `json:"interaction,omitempty"`
+	Voucher     Voucher           `json:"voucher"`
+}
+
+// NewSignable returns a signable asking the given account key to sign a
+// transaction for the given roles.
+//
+// The message is computed over the transaction as FCL sees it, with arguments
+// in compact JSON form. Signatures collected for the signable are only valid
+// for the transaction returned by its Transaction method.
+func NewSignable(tx *flow.Transaction, address flow.Address, keyIndex int, roles Roles) *Signable {
+	voucher := NewVoucher(tx)
+	if normalized, err := voucher.Transaction(); err == nil {
+		tx = normalized
+	}
+
+	message := flow.TransactionDomainTag.Tag(tx.PayloadMessage())
+	if roles.Payer {
+		message = flow.TransactionDomainTag.Tag(tx.EnvelopeMessage())
+	}
+
+	return &Signable{
+		FType:   "Signable",
+		FVsn:    "1.0.1",
+		Message: hex.EncodeToString(message),
+		Addr:    address.Hex(),
+		KeyID:   keyIndex,
+		Roles:   roles,
+		Cadence: voucher.Cadence,
+		Args:    voucher.Arguments,
+		Voucher: voucher,
+	}
+}
+
+// DecodeSignable decodes a JSON-encoded signable.
+func DecodeSignable(b []byte) (*Signable, error) {
+	var signable Signable
+	if err := json.Unmarshal(b, &signable); err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "fcl: failed to decode signable: %w", err)
+	}
+
+	if signable.FType != "" && signable.FType != "Signable" {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "fcl: expected a Signable, got %q", signable.FType)
+	}
+
+	return &signable, nil
+}
+
+// Address returns the address of the account asked to sign.
+func (s *Signable) Address() (flow.Address, error) {
+	return parseAddress(s.Addr)
+}
+
+// Transaction returns the transaction of the signable.
+func (s *Signable) Transaction() (*flow.Transaction, error) {
+	return s.Voucher.Transaction()
+}
+
+// MessageBytes returns the decoded message to sign.
+func (s *Signable) MessageBytes() ([]byte, error) {
+	return decodeHex("message", s.Message)
+}
+
+// Validate checks that the account key is a party of the transaction in each
+// of its roles, and that the message is the message the key must sign for
+// those roles, as computed from the voucher.
+//
+// A wallet must validate a signable before signing it: the message is what
+// gets signed, but the voucher is what is shown to the user.
+func (s *Signable) Validate() error {
+	address, err := s.Address()
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.Transaction()
+	if err != nil {
+		return err
+	}
+
+	if !s.Roles.Proposer && !s.Roles.Authorizer && !s.Roles.Payer {
+		return flowerrors.New(flowerrors.ErrInvalidArgument, "fcl: signable has no signing roles")
+	}
+
+	if s.Roles.Proposer && (tx.ProposalKey.Address != address || tx.ProposalKey.KeyIndex != s.KeyID) {
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "fcl: key %d of %s is not the proposal key", s.KeyID, address)
+	}
+
+	if s.Roles.Authorizer && !containsAddress(tx.Authorizers, address) {
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "fcl: %s is not an authorizer", address)
+	}
+
+	if s.Roles.Payer && tx.Payer != address {
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "fcl: %s is not the payer", address)
+	}
+
+	message, err := s.MessageBytes()
+	if err != nil {
+		return err
+	}
+
+	expected := flow.TransactionDomainTag.Tag(tx.PayloadMessage())
+	if s.Roles.Payer {
+		expected = flow.TransactionDomainTag.Tag(tx.EnvelopeMessage())
+	}
+
+	if !bytes.Equal(message, expected) {
+		return flowerrors.New(flowerrors.ErrInvalidArgument, "fcl: signable message does not match its voucher")
+	}
+
+	return nil
+}
+
+// Sign validates the signable and signs its message.
+func (s *Signable) Sign(signer crypto.Signer) (*CompositeSignature, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	address, err := s.Address()
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.MessageBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	record := flow.SignatureRecord{
+		Address:  address,
+		KeyIndex: s.KeyID,
+		Envelope: s.Roles.Payer,
+		Roles:    s.Roles.signatureRoles(),
+	}
+
+	sig, err := flow.SignAndAudit(signer, message, record)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCompositeSignature(address, s.KeyID, sig), nil
+}
+
+func containsAddress(addresses []flow.Address, address flow.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,210 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package walletconnect
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+const keyLength = 32
+
+// envelopeType0 is the envelope of messages encrypted with a symmetric key
+// known to both peers.
+const envelopeType0 = 0
+
+// A keyPair is an X25519 key pair used to agree on the symmetric key of a session.
+type keyPair struct {
+	private [keyLength]byte
+	public  [keyLength]byte
+}
+
+func generateKeyPair() (*keyPair, error) {
+	var kp keyPair
+	if _, err := io.ReadFull(rand.Reader, kp.private[:]); err != nil {
+		return nil, err
+	}
+
+	public, err := curve25519.X25519(kp.private[:], curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	copy(kp.public[:], public)
+
+	return &kp, nil
+}
+
+// deriveSymKey derives the symmetric key shared with the owner of the given
+// hex-encoded public key.
+func (kp *keyPair) deriveSymKey(peerPublicKey string) ([]byte, error) {
+	peer, err := hex.DecodeString(peerPublicKey)
+	if err != nil || len(peer) != keyLength {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "walletconnect: invalid public key %q", peerPublicKey)
+	}
+
+	shared, err := curve25519.X25519(kp.private[:], peer)
+	if err != nil {
+		return nil, flowerrors.Wrap(flowerrors.ErrInvalidArgument, err)
+	}
+
+	symKey := make([]byte, keyLength)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, nil, nil), symKey); err != nil {
+		return nil, err
+	}
+
+	return symKey, nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// topicOf returns the topic of the session encrypted with symKey.
+func topicOf(symKey []byte) string {
+	hash := sha256.Sum256(symKey)
+	return hex.EncodeToString(hash[:])
+}
+
+// encrypt seals a message with symKey in a base64-encoded type 0 envelope.
+func encrypt(symKey []byte, message []byte) (string, error) {
+	aead, err := chacha20poly1305.New(symKey)
+	if err != nil {
+		return "", err
+	}
+
+	iv, err := randomBytes(aead.NonceSize())
+	if err != nil {
+		return "", err
+	}
+
+	envelope := append([]byte{envelopeType0}, iv...)
+	envelope = aead.Seal(envelope, iv, message, nil)
+
+	return base64.StdEncoding.EncodeToString(envelope), nil
+}
+
+// decrypt opens a base64-encoded type 0 envelope sealed with symKey.
+func decrypt(symKey []byte, encoded string) ([]byte, error) {
+	envelope, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "walletconnect: invalid envelope: %w", err)
+	}
+
+	aead, err := chacha20poly1305.New(symKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(envelope) < 1+aead.NonceSize() || envelope[0] != envelopeType0 {
+		return nil, flowerrors.New(flowerrors.ErrDecoding, "walletconnect: unsupported envelope")
+	}
+
+	iv := envelope[1 : 1+aead.NonceSize()]
+
+	message, err := aead.Open(nil, iv, envelope[1+aead.NonceSize():], nil)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "walletconnect: failed to decrypt envelope: %w", err)
+	}
+
+	return message, nil
+}
+
+// multicodecEd25519 is the multicodec prefix of an Ed25519 public key in a did:key.
+var multicodecEd25519 = []byte{0xed, 0x01}
+
+// authToken returns the JWT a client authenticates to the relay at aud with.
+//
+// The token is signed by an Ed25519 key identifying the client, as a did:key issuer.
+func authToken(key ed25519.PrivateKey, aud string, ttl time.Duration) (string, error) {
+	sub, err := randomBytes(keyLength)
+	if err != nil {
+		return "", err
+	}
+
+	public := key.Public().(ed25519.PublicKey)
+	now := time.Now()
+
+	header, err := json.Marshal(map[string]string{"alg": "EdDSA", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss": "did:key:z" + base58Encode(append(multicodecEd25519, public...)),
+		"sub": hex.EncodeToString(sub),
+		"aud": aud,
+		"iat": now.Unix(),
+		"exp": now.Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	data := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	sig := ed25519.Sign(key, []byte(data))
+
+	return fmt.Sprintf("%s.%s", data, base64.RawURLEncoding.EncodeToString(sig)), nil
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Encode encodes b with the Bitcoin base58 alphabet.
+func base58Encode(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return string(out)
+}
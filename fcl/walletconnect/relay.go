@@ -0,0 +1,338 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package walletconnect
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// DefaultRelayURL is the URL of the public WalletConnect relay.
+const DefaultRelayURL = "wss://relay.walletconnect.com"
+
+// A Message is an encrypted message published to a topic of the relay.
+type Message struct {
+	Topic   string
+	Message string
+}
+
+// A Relay is a WalletConnect relay: a publish/subscribe service peers
+// exchange encrypted messages through.
+type Relay interface {
+	// Subscribe subscribes to the messages published to topic.
+	Subscribe(ctx context.Context, topic string) error
+	// Unsubscribe stops the subscription to topic.
+	Unsubscribe(ctx context.Context, topic string) error
+	// Publish publishes a message to topic. The relay keeps the message for
+	// ttl if the peer is not connected.
+	Publish(ctx context.Context, topic string, message string, ttl time.Duration, tag int) error
+	// Messages returns the channel messages of subscribed topics are
+	// delivered to. It is closed when the relay is closed.
+	Messages() <-chan Message
+	// Close closes the connection to the relay.
+	Close() error
+}
+
+// A WebsocketRelay is a Relay reached over the WalletConnect v2 relay protocol.
+type WebsocketRelay struct {
+	conn     *websocket.Conn
+	writeMut sync.Mutex
+	messages chan Message
+	done     chan struct{}
+
+	mut           sync.Mutex
+	pending       map[int64]chan rpcResponse
+	subscriptions map[string]string
+	err           error
+}
+
+var _ Relay = (*WebsocketRelay)(nil)
+
+// DialRelay connects to the relay at relayURL with a WalletConnect Cloud project ID.
+//
+// The client authenticates with a newly generated identity key.
+func DialRelay(relayURL string, projectID string) (*WebsocketRelay, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := authToken(key, relayURL, 24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(relayURL)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "walletconnect: invalid relay URL: %w", err)
+	}
+
+	query := u.Query()
+	query.Set("auth", token)
+	query.Set("projectId", projectID)
+	u.RawQuery = query.Encode()
+
+	conn, err := websocket.Dial(u.String(), "", "http://localhost/")
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrUnavailable, "walletconnect: failed to connect to relay: %w", err)
+	}
+
+	r := &WebsocketRelay{
+		conn:          conn,
+		messages:      make(chan Message, 16),
+		done:          make(chan struct{}),
+		pending:       make(map[int64]chan rpcResponse),
+		subscriptions: make(map[string]string),
+	}
+
+	go r.read()
+
+	return r, nil
+}
+
+func (r *WebsocketRelay) Subscribe(ctx context.Context, topic string) error {
+	var id string
+	if err := r.call(ctx, "irn_subscribe", map[string]interface{}{"topic": topic}, &id); err != nil {
+		return err
+	}
+
+	r.mut.Lock()
+	r.subscriptions[topic] = id
+	r.mut.Unlock()
+
+	return nil
+}
+
+func (r *WebsocketRelay) Unsubscribe(ctx context.Context, topic string) error {
+	r.mut.Lock()
+	id, ok := r.subscriptions[topic]
+	delete(r.subscriptions, topic)
+	r.mut.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	return r.call(ctx, "irn_unsubscribe", map[string]interface{}{"topic": topic, "id": id}, nil)
+}
+
+func (r *WebsocketRelay) Publish(ctx context.Context, topic string, message string, ttl time.Duration, tag int) error {
+	params := map[string]interface{}{
+		"topic":   topic,
+		"message": message,
+		"ttl":     int64(ttl / time.Second),
+		"tag":     tag,
+		"prompt":  tag == tagSessionRequest,
+	}
+
+	return r.call(ctx, "irn_publish", params, nil)
+}
+
+func (r *WebsocketRelay) Messages() <-chan Message {
+	return r.messages
+}
+
+func (r *WebsocketRelay) Close() error {
+	return r.conn.Close()
+}
+
+func (r *WebsocketRelay) call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	req, err := newRPCRequest(method, params)
+	if err != nil {
+		return err
+	}
+
+	ch := make(chan rpcResponse, 1)
+
+	r.mut.Lock()
+	if r.err != nil {
+		r.mut.Unlock()
+		return r.err
+	}
+	r.pending[req.ID] = ch
+	r.mut.Unlock()
+
+	defer func() {
+		r.mut.Lock()
+		delete(r.pending, req.ID)
+		r.mut.Unlock()
+	}()
+
+	if err := r.send(req); err != nil {
+		return err
+	}
+
+	select {
+	case res := <-ch:
+		if res.Error != nil {
+			return flowerrors.Errorf(flowerrors.ErrUnavailable, "walletconnect: %s failed: %w", method, res.Error)
+		}
+		if result != nil {
+			if err := json.Unmarshal(res.Result, result); err != nil {
+				return flowerrors.Errorf(flowerrors.ErrDecoding, "walletconnect: invalid %s result: %w", method, err)
+			}
+		}
+		return nil
+	case <-r.done:
+		return r.closedErr()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *WebsocketRelay) send(v interface{}) error {
+	r.writeMut.Lock()
+	defer r.writeMut.Unlock()
+
+	if err := websocket.JSON.Send(r.conn, v); err != nil {
+		return flowerrors.Errorf(flowerrors.ErrUnavailable, "walletconnect: failed to send to relay: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WebsocketRelay) closedErr() error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	return r.err
+}
+
+// read dispatches the frames received from the relay until the connection is closed.
+func (r *WebsocketRelay) read() {
+	defer close(r.messages)
+
+	for {
+		var frame rpcFrame
+		if err := websocket.JSON.Receive(r.conn, &frame); err != nil {
+			r.mut.Lock()
+			r.err = flowerrors.Errorf(flowerrors.ErrUnavailable, "walletconnect: relay connection closed: %w", err)
+			r.mut.Unlock()
+			close(r.done)
+			return
+		}
+
+		if frame.Method == "" {
+			r.mut.Lock()
+			ch, ok := r.pending[frame.ID]
+			r.mut.Unlock()
+
+			if ok {
+				ch <- rpcResponse{ID: frame.ID, Result: frame.Result, Error: frame.Error}
+			}
+			continue
+		}
+
+		if frame.Method != "irn_subscription" {
+			continue
+		}
+
+		var params struct {
+			Data struct {
+				Topic   string `json:"topic"`
+				Message string `json:"message"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(frame.Params, &params); err != nil {
+			continue
+		}
+
+		_ = r.send(rpcResponse{ID: frame.ID, JSONRPC: jsonRPCVersion, Result: json.RawMessage("true")})
+
+		r.messages <- Message{Topic: params.Data.Topic, Message: params.Data.Message}
+	}
+}
+
+// An rpcFrame is a JSON-RPC request or response.
+type rpcFrame struct {
+	ID     int64           `json:"id"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *rpcError       `json:"error,omitempty"`
+}
+
+type rpcRequest struct {
+	ID      int64           `json:"id"`
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type rpcResponse struct {
+	ID      int64           `json:"id"`
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+const jsonRPCVersion = "2.0"
+
+var (
+	idMut  sync.Mutex
+	lastID int64
+)
+
+// newID returns a JSON-RPC ID: the current time in milliseconds followed by
+// three digits, as WalletConnect clients use. IDs are increasing, so that
+// requests sent in the same millisecond get different IDs.
+func newID() int64 {
+	idMut.Lock()
+	defer idMut.Unlock()
+
+	id := time.Now().UnixNano() / int64(time.Millisecond) * 1000
+	if id <= lastID {
+		id = lastID + 1
+	}
+	lastID = id
+
+	return id
+}
+
+func newRPCRequest(method string, params interface{}) (rpcRequest, error) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return rpcRequest{}, err
+	}
+
+	return rpcRequest{
+		ID:      newID(),
+		JSONRPC: jsonRPCVersion,
+		Method:  method,
+		Params:  b,
+	}, nil
+}
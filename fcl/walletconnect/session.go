@@ -0,0 +1,255 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package walletconnect
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/fcl"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// A Session is a session settled with a wallet.
+type Session struct {
+	client   *Client
+	topic    string
+	peer     Metadata
+	accounts []flow.Address
+	expiry   time.Time
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// Topic returns the relay topic of the session.
+func (s *Session) Topic() string {
+	return s.topic
+}
+
+// Peer returns the metadata of the wallet.
+func (s *Session) Peer() Metadata {
+	return s.peer
+}
+
+// Accounts returns the accounts the wallet shares in the session.
+func (s *Session) Accounts() []flow.Address {
+	return s.accounts
+}
+
+// Expiry returns the time the session expires at.
+func (s *Session) Expiry() time.Time {
+	return s.expiry
+}
+
+// Done returns a channel that is closed when the session is disconnected.
+func (s *Session) Done() <-chan struct{} {
+	return s.closed
+}
+
+func (s *Session) close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+		s.client.removeSymKey(s.topic)
+		_ = s.client.relay.Unsubscribe(context.Background(), s.topic)
+	})
+}
+
+// Disconnect deletes the session.
+func (s *Session) Disconnect(ctx context.Context) error {
+	req, err := newRPCRequest("wc_sessionDelete", map[string]interface{}{
+		"code":    codeUserDisconnected,
+		"message": "User disconnected.",
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.client.publish(ctx, s.topic, req, requestTTL, tagSessionDelete)
+	s.close()
+
+	return err
+}
+
+// Authenticate asks the wallet to authenticate the user.
+func (s *Session) Authenticate(ctx context.Context) (*fcl.AuthnResponse, error) {
+	var res fcl.AuthnResponse
+	if err := s.request(ctx, MethodAuthn, map[string]interface{}{}, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// Authorize asks the wallet to sign a signable.
+//
+// The signature is not verified: a signature of a wrong key is rejected when
+// the transaction is executed.
+func (s *Session) Authorize(ctx context.Context, signable *fcl.Signable) (*fcl.CompositeSignature, error) {
+	var sig fcl.CompositeSignature
+	if err := s.request(ctx, MethodAuthz, signable, &sig); err != nil {
+		return nil, err
+	}
+
+	return &sig, nil
+}
+
+// SignUserMessage asks the wallet to sign a message in the user domain.
+func (s *Session) SignUserMessage(ctx context.Context, message []byte) ([]*fcl.CompositeSignature, error) {
+	var sigs []*fcl.CompositeSignature
+	req := fcl.UserSignatureRequest{Message: hex.EncodeToString(message)}
+
+	if err := s.request(ctx, MethodUserSign, req, &sigs); err != nil {
+		return nil, err
+	}
+
+	return sigs, nil
+}
+
+// Signer returns a signer that asks the wallet to sign tx with the given account key.
+//
+// The signer can only sign the payload or the envelope of tx. Since the
+// wallet receives the transaction in FCL form, its arguments must be encoded
+// in compact JSON form; use fcl.Voucher.Transaction to normalize a transaction.
+func (s *Session) Signer(ctx context.Context, tx *flow.Transaction, address flow.Address, keyIndex int) crypto.Signer {
+	return &signer{
+		ctx:      ctx,
+		session:  s,
+		tx:       tx,
+		address:  address,
+		keyIndex: keyIndex,
+	}
+}
+
+type signer struct {
+	ctx      context.Context
+	session  *Session
+	tx       *flow.Transaction
+	address  flow.Address
+	keyIndex int
+}
+
+func (s *signer) Sign(message []byte) ([]byte, error) {
+	roles := fcl.Roles{
+		Proposer:   s.tx.ProposalKey.Address == s.address && s.tx.ProposalKey.KeyIndex == s.keyIndex,
+		Authorizer: containsAddress(s.tx.Authorizers, s.address),
+	}
+
+	signable := fcl.NewSignable(s.tx, s.address, s.keyIndex, fcl.Roles{Payer: true})
+	if !s.matches(signable, message) {
+		signable = fcl.NewSignable(s.tx, s.address, s.keyIndex, roles)
+		if !s.matches(signable, message) {
+			return nil, flowerrors.New(
+				flowerrors.ErrInvalidArgument,
+				"walletconnect: message is not the payload or envelope of the transaction in FCL form",
+			)
+		}
+	}
+
+	sig, err := s.session.Authorize(s.ctx, signable)
+	if err != nil {
+		return nil, err
+	}
+
+	return sig.SignatureBytes()
+}
+
+func (s *signer) matches(signable *fcl.Signable, message []byte) bool {
+	b, err := signable.MessageBytes()
+	return err == nil && bytes.Equal(b, message)
+}
+
+// request sends an FCL request to the wallet and decodes the data of the
+// approved polling response into v.
+func (s *Session) request(ctx context.Context, method string, body interface{}, v interface{}) error {
+	select {
+	case <-s.closed:
+		return ErrSessionClosed
+	default:
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	// FCL sends the request body and the service data as JSON-encoded strings
+	params := map[string]interface{}{
+		"request": map[string]interface{}{
+			"method": method,
+			"params": []string{string(b), "{}"},
+		},
+		"chainId": s.client.chain(),
+	}
+
+	result, err := s.client.call(ctx, s.topic, "wc_sessionRequest", params, tagSessionRequest)
+	if err != nil {
+		return err
+	}
+
+	// some wallets return the polling response as a JSON-encoded string
+	var encoded string
+	if err := json.Unmarshal(result, &encoded); err == nil {
+		result = json.RawMessage(encoded)
+	}
+
+	var res struct {
+		Status string          `json:"status"`
+		Reason *string         `json:"reason"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(result, &res); err != nil {
+		return flowerrors.Errorf(flowerrors.ErrDecoding, "walletconnect: invalid %s response: %w", method, err)
+	}
+
+	switch res.Status {
+	case fcl.StatusApproved:
+	case fcl.StatusDeclined:
+		reason := "no reason given"
+		if res.Reason != nil {
+			reason = *res.Reason
+		}
+		return fmt.Errorf("%w: %s", ErrDeclined, reason)
+	default:
+		return flowerrors.Errorf(flowerrors.ErrUnsupported, "walletconnect: unsupported %s response status %q", method, res.Status)
+	}
+
+	if err := json.Unmarshal(res.Data, v); err != nil {
+		return flowerrors.Errorf(flowerrors.ErrDecoding, "walletconnect: invalid %s response data: %w", method, err)
+	}
+
+	return nil
+}
+
+func containsAddress(addresses []flow.Address, address flow.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,536 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package walletconnect requests signatures from a user's wallet over WalletConnect v2.
+//
+// A Client proposes sessions to wallets through a WalletConnect relay. The
+// pairing URI of a proposal is displayed to the user, typically as a QR code,
+// and the session is established once the user approves it in their wallet:
+//
+//	relay, err := walletconnect.DialRelay(walletconnect.DefaultRelayURL, projectID)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	wc := walletconnect.NewClient(relay, flow.Mainnet, walletconnect.Metadata{Name: "My App"})
+//	defer wc.Close()
+//
+//	pairing, err := wc.Pair(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	fmt.Println(pairing.URI())
+//
+//	session, err := pairing.Wait(ctx)
+//
+// Sessions send FCL requests (authn, authz and user-signature) to the wallet,
+// which holds the keys of the user. Session.Signer returns a crypto.Signer
+// that signs a transaction with the wallet of the user.
+package walletconnect
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/logging"
+)
+
+// ErrDeclined is returned when the user declines a request in their wallet.
+var ErrDeclined = errors.New("walletconnect: request declined")
+
+// ErrSessionClosed is returned for requests sent on a session the wallet has disconnected.
+var ErrSessionClosed = errors.New("walletconnect: session closed")
+
+// Tags of the messages of the WalletConnect Sign protocol. The tag of a
+// response is the tag of its request plus one.
+const (
+	tagSessionPropose = 1100
+	tagSessionSettle  = 1102
+	tagSessionUpdate  = 1104
+	tagSessionExtend  = 1106
+	tagSessionRequest = 1108
+	tagSessionEvent   = 1110
+	tagSessionDelete  = 1112
+	tagSessionPing    = 1114
+)
+
+// Methods of the Flow namespace a session is proposed for.
+const (
+	MethodAuthn    = "flow_authn"
+	MethodPreAuthz = "flow_pre_authz"
+	MethodAuthz    = "flow_authz"
+	MethodUserSign = "flow_user_sign"
+)
+
+const (
+	namespaceFlow   = "flow"
+	relayProtocol   = "irn"
+	protocolVersion = "2"
+	proposalTTL     = 5 * time.Minute
+	requestTTL      = 5 * time.Minute
+	// codeUserDisconnected is the reason code of a session deleted by the user.
+	codeUserDisconnected = 6000
+)
+
+var methods = []string{MethodAuthn, MethodPreAuthz, MethodAuthz, MethodUserSign}
+
+// Metadata describes an application or wallet to its peer.
+type Metadata struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	URL         string   `json:"url"`
+	Icons       []string `json:"icons"`
+}
+
+// A Client proposes and manages WalletConnect sessions with wallets.
+//
+// A Client is safe for concurrent use.
+type Client struct {
+	relay    Relay
+	chainID  flow.ChainID
+	metadata Metadata
+	logger   logging.Logger
+
+	mut      sync.Mutex
+	symKeys  map[string][]byte
+	pending  map[int64]chan rpcResponse
+	settles  map[string]chan settleParams
+	sessions map[string]*Session
+	closed   chan struct{}
+}
+
+// An Option configures a Client.
+type Option func(*Client)
+
+// WithLogger sets the logger the client reports dropped messages to.
+func WithLogger(logger logging.Logger) Option {
+	return func(c *Client) {
+		c.logger = logging.OrNop(logger)
+	}
+}
+
+// NewClient returns a client that proposes sessions on chainID through relay.
+//
+// The client owns the relay and closes it when it is closed.
+func NewClient(relay Relay, chainID flow.ChainID, metadata Metadata, opts ...Option) *Client {
+	c := &Client{
+		relay:    relay,
+		chainID:  chainID,
+		metadata: metadata,
+		logger:   logging.Nop(),
+		symKeys:  make(map[string][]byte),
+		pending:  make(map[int64]chan rpcResponse),
+		settles:  make(map[string]chan settleParams),
+		sessions: make(map[string]*Session),
+		closed:   make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	go c.dispatch()
+
+	return c
+}
+
+// Close closes the relay. Pending requests fail.
+func (c *Client) Close() error {
+	return c.relay.Close()
+}
+
+// chain returns the CAIP-2 identifier of the chain of the client, e.g. "flow:mainnet".
+func (c *Client) chain() string {
+	return namespaceFlow + ":" + strings.TrimPrefix(string(c.chainID), "flow-")
+}
+
+// A Pairing is a session proposal waiting for the approval of a wallet.
+type Pairing struct {
+	uri  string
+	done chan struct{}
+
+	session *Session
+	err     error
+}
+
+// URI returns the pairing URI to display to the user.
+func (p *Pairing) URI() string {
+	return p.uri
+}
+
+// Wait waits until the wallet approves or rejects the proposal.
+func (p *Pairing) Wait(ctx context.Context) (*Session, error) {
+	select {
+	case <-p.done:
+		return p.session, p.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Pair proposes a new session. The proposal expires after five minutes.
+func (c *Client) Pair(ctx context.Context) (*Pairing, error) {
+	topicBytes, err := randomBytes(keyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	symKey, err := randomBytes(keyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := generateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	topic := hex.EncodeToString(topicBytes)
+	c.setSymKey(topic, symKey)
+
+	if err := c.relay.Subscribe(ctx, topic); err != nil {
+		return nil, err
+	}
+
+	expiry := time.Now().Add(proposalTTL)
+
+	req, err := newRPCRequest("wc_sessionPropose", map[string]interface{}{
+		"requiredNamespaces": map[string]interface{}{
+			namespaceFlow: map[string]interface{}{
+				"chains":  []string{c.chain()},
+				"methods": methods,
+				"events":  []string{},
+			},
+		},
+		"optionalNamespaces": map[string]interface{}{},
+		"relays":             []map[string]string{{"protocol": relayProtocol}},
+		"proposer": map[string]interface{}{
+			"publicKey": hex.EncodeToString(keys.public[:]),
+			"metadata":  c.metadata,
+		},
+		"expiryTimestamp": expiry.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := c.register(req.ID)
+
+	if err := c.publish(ctx, topic, req, proposalTTL, tagSessionPropose); err != nil {
+		c.unregister(req.ID)
+		return nil, err
+	}
+
+	p := &Pairing{
+		uri: "wc:" + topic + "@" + protocolVersion +
+			"?relay-protocol=" + relayProtocol +
+			"&symKey=" + hex.EncodeToString(symKey) +
+			"&expiryTimestamp=" + strconv.FormatInt(expiry.Unix(), 10),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(p.done)
+		defer c.unregister(req.ID)
+
+		ctx, cancel := context.WithDeadline(context.Background(), expiry)
+		defer cancel()
+
+		p.session, p.err = c.settle(ctx, responses, keys)
+
+		_ = c.relay.Unsubscribe(context.Background(), topic)
+		c.removeSymKey(topic)
+	}()
+
+	return p, nil
+}
+
+type settleParams struct {
+	Namespaces map[string]struct {
+		Accounts []string `json:"accounts"`
+	} `json:"namespaces"`
+	Controller struct {
+		PublicKey string   `json:"publicKey"`
+		Metadata  Metadata `json:"metadata"`
+	} `json:"controller"`
+	Expiry int64 `json:"expiry"`
+}
+
+// settle waits for the response to a proposal, then for the wallet to settle the session.
+func (c *Client) settle(ctx context.Context, responses chan rpcResponse, keys *keyPair) (*Session, error) {
+	var res rpcResponse
+	select {
+	case res = <-responses:
+	case <-c.closed:
+		return nil, ErrSessionClosed
+	case <-ctx.Done():
+		return nil, flowerrors.Errorf(flowerrors.ErrTimeout, "walletconnect: proposal expired: %w", ctx.Err())
+	}
+
+	if res.Error != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDeclined, res.Error.Message)
+	}
+
+	var approval struct {
+		ResponderPublicKey string `json:"responderPublicKey"`
+	}
+	if err := json.Unmarshal(res.Result, &approval); err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "walletconnect: invalid proposal response: %w", err)
+	}
+
+	symKey, err := keys.deriveSymKey(approval.ResponderPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	topic := topicOf(symKey)
+	settles := make(chan settleParams, 1)
+
+	c.mut.Lock()
+	c.symKeys[topic] = symKey
+	c.settles[topic] = settles
+	c.mut.Unlock()
+
+	defer func() {
+		c.mut.Lock()
+		delete(c.settles, topic)
+		c.mut.Unlock()
+	}()
+
+	if err := c.relay.Subscribe(ctx, topic); err != nil {
+		c.removeSymKey(topic)
+		return nil, err
+	}
+
+	var params settleParams
+	select {
+	case params = <-settles:
+	case <-c.closed:
+		return nil, ErrSessionClosed
+	case <-ctx.Done():
+		_ = c.relay.Unsubscribe(context.Background(), topic)
+		c.removeSymKey(topic)
+		return nil, flowerrors.Errorf(flowerrors.ErrTimeout, "walletconnect: session was not settled: %w", ctx.Err())
+	}
+
+	session := &Session{
+		client: c,
+		topic:  topic,
+		peer:   params.Controller.Metadata,
+		expiry: time.Unix(params.Expiry, 0),
+		closed: make(chan struct{}),
+	}
+
+	for _, account := range params.Namespaces[namespaceFlow].Accounts {
+		// accounts are CAIP-10 identifiers, e.g. "flow:mainnet:0xf8d6e0586b0a20c7"
+		parts := strings.Split(account, ":")
+		if len(parts) != 3 || parts[0]+":"+parts[1] != c.chain() {
+			continue
+		}
+		session.accounts = append(session.accounts, flow.HexToAddress(parts[2]))
+	}
+
+	c.mut.Lock()
+	c.sessions[topic] = session
+	c.mut.Unlock()
+
+	return session, nil
+}
+
+// call sends a request on a topic and waits for its response.
+func (c *Client) call(ctx context.Context, topic string, method string, params interface{}, tag int) (json.RawMessage, error) {
+	req, err := newRPCRequest(method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := c.register(req.ID)
+	defer c.unregister(req.ID)
+
+	if err := c.publish(ctx, topic, req, requestTTL, tag); err != nil {
+		return nil, err
+	}
+
+	select {
+	case res := <-responses:
+		if res.Error != nil {
+			return nil, fmt.Errorf("%w: %s", ErrDeclined, res.Error.Message)
+		}
+		return res.Result, nil
+	case <-c.closed:
+		return nil, ErrSessionClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Client) publish(ctx context.Context, topic string, v interface{}, ttl time.Duration, tag int) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	symKey := c.symKey(topic)
+	if symKey == nil {
+		return ErrSessionClosed
+	}
+
+	message, err := encrypt(symKey, b)
+	if err != nil {
+		return err
+	}
+
+	return c.relay.Publish(ctx, topic, message, ttl, tag)
+}
+
+// dispatch routes the messages received from the relay until it is closed.
+func (c *Client) dispatch() {
+	defer close(c.closed)
+
+	for msg := range c.relay.Messages() {
+		symKey := c.symKey(msg.Topic)
+		if symKey == nil {
+			c.logger.Log(logging.DebugLevel, "walletconnect: dropped message for unknown topic", logging.String("topic", msg.Topic))
+			continue
+		}
+
+		b, err := decrypt(symKey, msg.Message)
+		if err != nil {
+			c.logger.Log(logging.WarnLevel, "walletconnect: dropped message", logging.String("topic", msg.Topic), logging.Error(err))
+			continue
+		}
+
+		var frame rpcFrame
+		if err := json.Unmarshal(b, &frame); err != nil {
+			c.logger.Log(logging.WarnLevel, "walletconnect: dropped message", logging.String("topic", msg.Topic), logging.Error(err))
+			continue
+		}
+
+		if frame.Method == "" {
+			c.mut.Lock()
+			responses, ok := c.pending[frame.ID]
+			c.mut.Unlock()
+
+			if ok {
+				responses <- rpcResponse{ID: frame.ID, Result: frame.Result, Error: frame.Error}
+			}
+			continue
+		}
+
+		go c.handleRequest(msg.Topic, frame)
+	}
+}
+
+// handleRequest handles a request sent by a wallet.
+func (c *Client) handleRequest(topic string, req rpcFrame) {
+	res := rpcResponse{ID: req.ID, JSONRPC: jsonRPCVersion, Result: json.RawMessage("true")}
+	var tag int
+
+	switch req.Method {
+	case "wc_sessionSettle":
+		tag = tagSessionSettle + 1
+
+		var params settleParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			res.Result = nil
+			res.Error = &rpcError{Code: -32602, Message: "invalid params"}
+			break
+		}
+
+		c.mut.Lock()
+		settles, ok := c.settles[topic]
+		c.mut.Unlock()
+
+		if ok {
+			settles <- params
+		}
+	case "wc_sessionDelete":
+		tag = tagSessionDelete + 1
+
+		c.mut.Lock()
+		session, ok := c.sessions[topic]
+		c.mut.Unlock()
+
+		if ok {
+			defer session.close()
+		}
+	case "wc_sessionPing":
+		tag = tagSessionPing + 1
+	case "wc_sessionUpdate":
+		tag = tagSessionUpdate + 1
+	case "wc_sessionExtend":
+		tag = tagSessionExtend + 1
+	case "wc_sessionEvent":
+		tag = tagSessionEvent + 1
+	default:
+		res.Result = nil
+		res.Error = &rpcError{Code: -32601, Message: "method not found"}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := c.publish(ctx, topic, res, requestTTL, tag); err != nil {
+		c.logger.Log(logging.WarnLevel, "walletconnect: failed to respond", logging.String("method", req.Method), logging.Error(err))
+	}
+}
+
+func (c *Client) register(id int64) chan rpcResponse {
+	responses := make(chan rpcResponse, 1)
+
+	c.mut.Lock()
+	c.pending[id] = responses
+	c.mut.Unlock()
+
+	return responses
+}
+
+func (c *Client) unregister(id int64) {
+	c.mut.Lock()
+	delete(c.pending, id)
+	c.mut.Unlock()
+}
+
+func (c *Client) symKey(topic string) []byte {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.symKeys[topic]
+}
+
+func (c *Client) setSymKey(topic string, symKey []byte) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.symKeys[topic] = symKey
+}
+
+func (c *Client) removeSymKey(topic string) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	delete(c.symKeys, topic)
+	delete(c.sessions, topic)
+}
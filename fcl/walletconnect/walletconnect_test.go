@@ -0,0 +1,470 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package walletconnect
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/fcl"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+// A memoryHub is an in-memory relay that keeps every published message, so
+// that peers subscribing late still receive them.
+type memoryHub struct {
+	mut     sync.Mutex
+	history map[string][]hubMessage
+	relays  []*memoryRelay
+}
+
+type hubMessage struct {
+	from *memoryRelay
+	msg  Message
+}
+
+func newMemoryHub() *memoryHub {
+	return &memoryHub{history: make(map[string][]hubMessage)}
+}
+
+func (h *memoryHub) connect() *memoryRelay {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	r := &memoryRelay{hub: h, topics: make(map[string]bool), messages: make(chan Message, 64)}
+	h.relays = append(h.relays, r)
+
+	return r
+}
+
+type memoryRelay struct {
+	hub      *memoryHub
+	topics   map[string]bool
+	messages chan Message
+	closed   bool
+}
+
+func (r *memoryRelay) Subscribe(_ context.Context, topic string) error {
+	r.hub.mut.Lock()
+	defer r.hub.mut.Unlock()
+
+	r.topics[topic] = true
+	for _, m := range r.hub.history[topic] {
+		if m.from != r {
+			r.messages <- m.msg
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryRelay) Unsubscribe(_ context.Context, topic string) error {
+	r.hub.mut.Lock()
+	defer r.hub.mut.Unlock()
+
+	delete(r.topics, topic)
+
+	return nil
+}
+
+func (r *memoryRelay) Publish(_ context.Context, topic string, message string, _ time.Duration, _ int) error {
+	r.hub.mut.Lock()
+	defer r.hub.mut.Unlock()
+
+	msg := Message{Topic: topic, Message: message}
+	r.hub.history[topic] = append(r.hub.history[topic], hubMessage{from: r, msg: msg})
+
+	for _, peer := range r.hub.relays {
+		if peer != r && !peer.closed && peer.topics[topic] {
+			peer.messages <- msg
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryRelay) Messages() <-chan Message {
+	return r.messages
+}
+
+func (r *memoryRelay) Close() error {
+	r.hub.mut.Lock()
+	defer r.hub.mut.Unlock()
+
+	if !r.closed {
+		r.closed = true
+		close(r.messages)
+	}
+
+	return nil
+}
+
+// A fakeWallet approves session proposals and signs FCL requests with a single key.
+type fakeWallet struct {
+	t        *testing.T
+	relay    *memoryRelay
+	address  flow.Address
+	key      *flow.AccountKey
+	signer   crypto.Signer
+	decline  bool
+	symKeys  map[string][]byte
+	requests chan string
+}
+
+func newFakeWallet(t *testing.T, hub *memoryHub) *fakeWallet {
+	key, signer := test.AccountKeyGenerator().NewWithSigner()
+
+	return &fakeWallet{
+		t:        t,
+		relay:    hub.connect(),
+		address:  test.AddressGenerator().New(),
+		key:      key,
+		signer:   signer,
+		symKeys:  make(map[string][]byte),
+		requests: make(chan string, 16),
+	}
+}
+
+// pair scans a pairing URI and serves the session until the relay is closed.
+func (w *fakeWallet) pair(uri string) {
+	// wc:{topic}@2?... parses as a URL once the topic is in the user info position
+	u, err := url.Parse(strings.Replace(uri, "wc:", "wc://", 1))
+	require.NoError(w.t, err)
+
+	topic := u.User.Username()
+
+	symKey, err := hex.DecodeString(u.Query().Get("symKey"))
+	require.NoError(w.t, err)
+
+	w.symKeys[topic] = symKey
+	require.NoError(w.t, w.relay.Subscribe(context.Background(), topic))
+
+	go w.serve()
+}
+
+func (w *fakeWallet) send(topic string, v interface{}) {
+	b, err := json.Marshal(v)
+	require.NoError(w.t, err)
+
+	message, err := encrypt(w.symKeys[topic], b)
+	require.NoError(w.t, err)
+
+	require.NoError(w.t, w.relay.Publish(context.Background(), topic, message, time.Minute, 0))
+}
+
+func (w *fakeWallet) serve() {
+	for msg := range w.relay.Messages() {
+		b, err := decrypt(w.symKeys[msg.Topic], msg.Message)
+		if err != nil {
+			continue
+		}
+
+		var frame rpcFrame
+		if err := json.Unmarshal(b, &frame); err != nil || frame.Method == "" {
+			continue
+		}
+
+		switch frame.Method {
+		case "wc_sessionPropose":
+			w.approve(msg.Topic, frame)
+		case "wc_sessionRequest":
+			w.handle(msg.Topic, frame)
+		case "wc_sessionDelete":
+			w.requests <- frame.Method
+		}
+	}
+}
+
+func (w *fakeWallet) approve(pairingTopic string, frame rpcFrame) {
+	var params struct {
+		Proposer struct {
+			PublicKey string `json:"publicKey"`
+		} `json:"proposer"`
+		RequiredNamespaces map[string]struct {
+			Chains []string `json:"chains"`
+		} `json:"requiredNamespaces"`
+	}
+	require.NoError(w.t, json.Unmarshal(frame.Params, &params))
+
+	keys, err := generateKeyPair()
+	require.NoError(w.t, err)
+
+	if w.decline {
+		w.send(pairingTopic, rpcResponse{ID: frame.ID, JSONRPC: jsonRPCVersion, Error: &rpcError{Code: 5000, Message: "User rejected."}})
+		return
+	}
+
+	w.send(pairingTopic, rpcResponse{
+		ID:      frame.ID,
+		JSONRPC: jsonRPCVersion,
+		Result:  mustMarshal(w.t, map[string]string{"responderPublicKey": hex.EncodeToString(keys.public[:])}),
+	})
+
+	symKey, err := keys.deriveSymKey(params.Proposer.PublicKey)
+	require.NoError(w.t, err)
+
+	sessionTopic := topicOf(symKey)
+	w.symKeys[sessionTopic] = symKey
+	require.NoError(w.t, w.relay.Subscribe(context.Background(), sessionTopic))
+
+	chain := params.RequiredNamespaces[namespaceFlow].Chains[0]
+
+	settle, err := newRPCRequest("wc_sessionSettle", map[string]interface{}{
+		"relay": map[string]string{"protocol": relayProtocol},
+		"namespaces": map[string]interface{}{
+			namespaceFlow: map[string]interface{}{
+				"accounts": []string{chain + ":0x" + w.address.Hex()},
+				"methods":  methods,
+				"events":   []string{},
+			},
+		},
+		"controller": map[string]interface{}{
+			"publicKey": hex.EncodeToString(keys.public[:]),
+			"metadata":  Metadata{Name: "Fake Wallet"},
+		},
+		"expiry": time.Now().Add(7 * 24 * time.Hour).Unix(),
+	})
+	require.NoError(w.t, err)
+
+	w.send(sessionTopic, settle)
+}
+
+func (w *fakeWallet) handle(topic string, frame rpcFrame) {
+	var params struct {
+		Request struct {
+			Method string   `json:"method"`
+			Params []string `json:"params"`
+		} `json:"request"`
+	}
+	require.NoError(w.t, json.Unmarshal(frame.Params, &params))
+
+	w.requests <- params.Request.Method
+
+	ctx := context.Background()
+	body := []byte(params.Request.Params[0])
+
+	var res *fcl.PollingResponse
+
+	switch params.Request.Method {
+	case MethodAuthn:
+		res = fcl.Approved(fcl.NewAuthnResponse(w.address))
+	case MethodAuthz:
+		signable, err := fcl.DecodeSignable(body)
+		require.NoError(w.t, err)
+
+		sig, err := fcl.SignerAuthz(w.address, w.key.Index, w.signer, nil)(ctx, signable)
+		if err != nil {
+			res = fcl.Declined(err.Error())
+		} else {
+			res = fcl.Approved(sig)
+		}
+	case MethodUserSign:
+		var req fcl.UserSignatureRequest
+		require.NoError(w.t, json.Unmarshal(body, &req))
+
+		sigs, err := fcl.SignerUserSignature(w.address, w.key.Index, w.signer, nil)(ctx, &req)
+		require.NoError(w.t, err)
+
+		// respond with a JSON-encoded string, as some wallets do
+		b, err := json.Marshal(fcl.Approved(sigs))
+		require.NoError(w.t, err)
+
+		w.send(topic, rpcResponse{ID: frame.ID, JSONRPC: jsonRPCVersion, Result: mustMarshal(w.t, string(b))})
+		return
+	}
+
+	w.send(topic, rpcResponse{ID: frame.ID, JSONRPC: jsonRPCVersion, Result: mustMarshal(w.t, res)})
+}
+
+func mustMarshal(t *testing.T, v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
+
+func connect(t *testing.T, wallet *fakeWallet, hub *memoryHub) (*Client, *Session) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := NewClient(hub.connect(), flow.Testnet, Metadata{Name: "Test App"})
+	t.Cleanup(func() { _ = client.Close() })
+
+	pairing, err := client.Pair(ctx)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(pairing.URI(), "wc:"))
+
+	wallet.pair(pairing.URI())
+	t.Cleanup(func() { _ = wallet.relay.Close() })
+
+	session, err := pairing.Wait(ctx)
+	require.NoError(t, err)
+
+	return client, session
+}
+
+func TestPair(t *testing.T) {
+	t.Run("Approved", func(t *testing.T) {
+		hub := newMemoryHub()
+		wallet := newFakeWallet(t, hub)
+
+		_, session := connect(t, wallet, hub)
+
+		assert.Equal(t, []flow.Address{wallet.address}, session.Accounts())
+		assert.Equal(t, "Fake Wallet", session.Peer().Name)
+		assert.True(t, session.Expiry().After(time.Now()))
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		hub := newMemoryHub()
+		wallet := newFakeWallet(t, hub)
+		wallet.decline = true
+
+		client := NewClient(hub.connect(), flow.Testnet, Metadata{Name: "Test App"})
+		defer client.Close()
+
+		pairing, err := client.Pair(ctx)
+		require.NoError(t, err)
+
+		wallet.pair(pairing.URI())
+		defer wallet.relay.Close()
+
+		_, err = pairing.Wait(ctx)
+		assert.True(t, errors.Is(err, ErrDeclined))
+	})
+}
+
+func TestSession(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("Authenticate", func(t *testing.T) {
+		hub := newMemoryHub()
+		wallet := newFakeWallet(t, hub)
+		_, session := connect(t, wallet, hub)
+
+		res, err := session.Authenticate(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "0x"+wallet.address.Hex(), res.Addr)
+		assert.Equal(t, MethodAuthn, <-wallet.requests)
+	})
+
+	t.Run("Signer", func(t *testing.T) {
+		hub := newMemoryHub()
+		wallet := newFakeWallet(t, hub)
+		_, session := connect(t, wallet, hub)
+
+		tx := flow.NewTransaction().
+			SetScript([]byte(`transaction { prepare(signer: AuthAccount) {} }`)).
+			SetReferenceBlockID(test.IdentifierGenerator().New()).
+			SetProposalKey(wallet.address, wallet.key.Index, 0).
+			SetPayer(wallet.address).
+			AddAuthorizer(wallet.address).
+			AddRawArgument([]byte(`{"type":"String","value":"hello"}`))
+
+		err := tx.SignEnvelope(wallet.address, wallet.key.Index, session.Signer(ctx, tx, wallet.address, wallet.key.Index))
+		require.NoError(t, err)
+		assert.Equal(t, MethodAuthz, <-wallet.requests)
+
+		hasher, err := crypto.NewHasher(wallet.key.HashAlgo)
+		require.NoError(t, err)
+
+		valid, err := wallet.key.PublicKey.Verify(
+			tx.EnvelopeSignatures[0].Signature,
+			append(flow.TransactionDomainTag[:], tx.EnvelopeMessage()...),
+			hasher,
+		)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Signer of another account is declined", func(t *testing.T) {
+		hub := newMemoryHub()
+		wallet := newFakeWallet(t, hub)
+		_, session := connect(t, wallet, hub)
+
+		other := test.AddressGenerator().New()
+		tx := test.TransactionGenerator().NewUnsigned()
+		tx.Arguments = nil
+		tx.SetPayer(other)
+
+		err := tx.SignEnvelope(other, 0, session.Signer(ctx, tx, other, 0))
+		assert.True(t, errors.Is(err, ErrDeclined))
+	})
+
+	t.Run("SignUserMessage", func(t *testing.T) {
+		hub := newMemoryHub()
+		wallet := newFakeWallet(t, hub)
+		_, session := connect(t, wallet, hub)
+
+		message := []byte("hello world")
+
+		sigs, err := session.SignUserMessage(ctx, message)
+		require.NoError(t, err)
+		require.Len(t, sigs, 1)
+
+		sig, err := sigs[0].SignatureBytes()
+		require.NoError(t, err)
+
+		hasher, err := crypto.NewHasher(wallet.key.HashAlgo)
+		require.NoError(t, err)
+
+		valid, err := wallet.key.PublicKey.Verify(sig, append(flow.UserDomainTag[:], message...), hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Disconnect", func(t *testing.T) {
+		hub := newMemoryHub()
+		wallet := newFakeWallet(t, hub)
+		_, session := connect(t, wallet, hub)
+
+		require.NoError(t, session.Disconnect(ctx))
+		assert.Equal(t, "wc_sessionDelete", <-wallet.requests)
+
+		select {
+		case <-session.Done():
+		default:
+			t.Fatal("session is not closed")
+		}
+
+		_, err := session.Authenticate(ctx)
+		assert.True(t, errors.Is(err, ErrSessionClosed))
+	})
+}
+
+func TestBase58Encode(t *testing.T) {
+	assert.Equal(t, "", base58Encode(nil))
+	assert.Equal(t, "1112", base58Encode([]byte{0, 0, 0, 1}))
+	assert.Equal(t, "StV1DL6CwTryKyV", base58Encode([]byte("hello world")))
+}
@@ -19,12 +19,15 @@
 package flow
 
 import (
+	"database/sql/driver"
 	"encoding/hex"
+	"strings"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
 )
 
 // An Identifier is a 32-byte unique identifier for an entity.
@@ -56,16 +59,96 @@ func BytesToID(b []byte) Identifier {
 }
 
 // HexToID constructs an identifier from a hexadecimal string.
+//
+// Invalid hex is silently decoded as far as possible; use ParseID for untrusted input.
 func HexToID(h string) Identifier {
 	b, _ := hex.DecodeString(h)
 	return BytesToID(b)
 }
 
+// ParseID parses a hexadecimal string, optionally prefixed with 0x, into an identifier.
+//
+// Unlike HexToID, an error is returned if the string is not valid hex or does not
+// encode exactly 32 bytes.
+func ParseID(h string) (Identifier, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(h, "0x"))
+	if err != nil {
+		return EmptyID, flowerrors.Errorf(flowerrors.ErrDecoding, "invalid identifier %q: %w", h, err)
+	}
+
+	if len(b) != len(EmptyID) {
+		return EmptyID, flowerrors.Errorf(flowerrors.ErrDecoding, "invalid identifier %q: expected %d bytes, got %d", h, len(EmptyID), len(b))
+	}
+
+	return BytesToID(b), nil
+}
+
 // HashToID constructs an identifier from a 32-byte hash.
 func HashToID(hash []byte) Identifier {
 	return BytesToID(hash)
 }
 
+// MarshalText returns the hexadecimal representation of this identifier.
+//
+// Identifiers are encoded as hex strings in JSON and other text formats, including
+// when used as map keys. This applies to every type holding identifiers, such as
+// blocks, transactions and results: their JSON encoding holds identifiers as
+// "0a1b..." strings rather than as arrays of 32 numbers, and JSON written in the
+// array form by earlier versions of the SDK cannot be decoded.
+func (i Identifier) MarshalText() ([]byte, error) {
+	return []byte(i.Hex()), nil
+}
+
+// UnmarshalText parses a hexadecimal identifier, as ParseID.
+func (i *Identifier) UnmarshalText(text []byte) error {
+	id, err := ParseID(string(text))
+	if err != nil {
+		return err
+	}
+
+	*i = id
+	return nil
+}
+
+// MarshalBinary returns the bytes of this identifier.
+func (i Identifier) MarshalBinary() ([]byte, error) {
+	return i.Bytes(), nil
+}
+
+// UnmarshalBinary decodes an identifier from exactly 32 bytes.
+func (i *Identifier) UnmarshalBinary(data []byte) error {
+	if len(data) != len(EmptyID) {
+		return flowerrors.Errorf(flowerrors.ErrDecoding, "invalid identifier: expected %d bytes, got %d", len(EmptyID), len(data))
+	}
+
+	*i = BytesToID(data)
+	return nil
+}
+
+// Value stores this identifier in a database as its 32 bytes, e.g. in a BYTEA or
+// BLOB column.
+func (i Identifier) Value() (driver.Value, error) {
+	return i.Bytes(), nil
+}
+
+// Scan reads an identifier from a database column. Bytes are read as the 32 bytes
+// of the identifier, as stored by Value, and strings as its hexadecimal
+// representation. NULL is read as the empty identifier.
+func (i *Identifier) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*i = EmptyID
+		return nil
+	case []byte:
+		return i.UnmarshalBinary(src)
+	case string:
+		return i.UnmarshalText([]byte(src))
+	default:
+		return flowerrors.Errorf(flowerrors.ErrDecoding, "cannot scan %T into identifier", src)
+	}
+}
+
+// A StateCommitment is the root hash of the execution state trie.
 type StateCommitment Identifier
 
 // BytesToStateCommitment constructs a state commitment from a byte slice.
@@ -83,6 +166,37 @@ func HashToStateCommitment(hash []byte) StateCommitment {
 	return StateCommitment(HashToID(hash))
 }
 
+// MarshalText returns the hexadecimal representation of this state commitment,
+// which is also its JSON encoding.
+func (sc StateCommitment) MarshalText() ([]byte, error) {
+	return Identifier(sc).MarshalText()
+}
+
+// UnmarshalText parses a hexadecimal state commitment.
+func (sc *StateCommitment) UnmarshalText(text []byte) error {
+	return (*Identifier)(sc).UnmarshalText(text)
+}
+
+// MarshalBinary returns the bytes of this state commitment.
+func (sc StateCommitment) MarshalBinary() ([]byte, error) {
+	return Identifier(sc).MarshalBinary()
+}
+
+// UnmarshalBinary decodes a state commitment from exactly 32 bytes.
+func (sc *StateCommitment) UnmarshalBinary(data []byte) error {
+	return (*Identifier)(sc).UnmarshalBinary(data)
+}
+
+// Value stores this state commitment in a database as its 32 bytes.
+func (sc StateCommitment) Value() (driver.Value, error) {
+	return Identifier(sc).Value()
+}
+
+// Scan reads a state commitment from a database column, as Identifier.Scan.
+func (sc *StateCommitment) Scan(src interface{}) error {
+	return (*Identifier)(sc).Scan(src)
+}
+
 // A ChainID is a unique identifier for a specific Flow network instance.
 //
 // Chain IDs are used used to prevent replay attacks and to support network-specific address generation.
@@ -101,6 +215,29 @@ func (id ChainID) String() string {
 	return string(id)
 }
 
+// ParseChainID returns the chain ID with the given name.
+//
+// An error is returned if the name is not one of the chain IDs known to the SDK.
+// Functions that generate or validate addresses panic on unknown chain IDs, so
+// chain IDs read from configuration or user input should be parsed first.
+func ParseChainID(s string) (ChainID, error) {
+	id := ChainID(s)
+	if err := validateChainID(id); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func validateChainID(id ChainID) error {
+	switch id {
+	case Mainnet, Testnet, Emulator:
+		return nil
+	default:
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "unknown chain ID %q", string(id))
+	}
+}
+
 // entityHasher is a thread-safe hasher used to hash Flow entities.
 type entityHasher struct {
 	mut    sync.Mutex
@@ -137,11 +274,3 @@ func mustRLPEncode(v interface{}) []byte {
 	}
 	return b
 }
-
-func mustRLPDecode(b []byte, v interface{}) {
-	err := rlpDecode(b, v)
-	if err != nil {
-		panic(err)
-	}
-	return
-}
@@ -0,0 +1,154 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestParseID(t *testing.T) {
+	h := strings.Repeat("ab", 32)
+
+	id, err := flow.ParseID(h)
+	require.NoError(t, err)
+	assert.Equal(t, flow.HexToID(h), id)
+
+	id, err = flow.ParseID("0x" + h)
+	require.NoError(t, err)
+	assert.Equal(t, flow.HexToID(h), id)
+
+	for _, literal := range []string{"", "zz", "0102", h + "00", h[:63]} {
+		_, err := flow.ParseID(literal)
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding, literal)
+	}
+}
+
+func TestParseChainID(t *testing.T) {
+	for _, chain := range []flow.ChainID{flow.Mainnet, flow.Testnet, flow.Emulator} {
+		parsed, err := flow.ParseChainID(chain.String())
+		require.NoError(t, err)
+		assert.Equal(t, chain, parsed)
+	}
+
+	_, err := flow.ParseChainID("flow-unknown")
+	assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+}
+
+func TestIdentifier_Encoding(t *testing.T) {
+	id := flow.HexToID(strings.Repeat("ab", 32))
+
+	t.Run("Text", func(t *testing.T) {
+		data, err := json.Marshal(map[flow.Identifier]flow.Identifier{id: id})
+		require.NoError(t, err)
+		assert.Equal(t, `{"`+id.Hex()+`":"`+id.Hex()+`"}`, string(data))
+
+		var decoded map[flow.Identifier]flow.Identifier
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, map[flow.Identifier]flow.Identifier{id: id}, decoded)
+
+		var invalid flow.Identifier
+		err = invalid.UnmarshalText([]byte("abcd"))
+		assert.True(t, errors.Is(err, flowerrors.ErrDecoding))
+	})
+
+	t.Run("Binary", func(t *testing.T) {
+		data, err := id.MarshalBinary()
+		require.NoError(t, err)
+
+		var decoded flow.Identifier
+		require.NoError(t, decoded.UnmarshalBinary(data))
+		assert.Equal(t, id, decoded)
+
+		err = decoded.UnmarshalBinary(data[1:])
+		assert.True(t, errors.Is(err, flowerrors.ErrDecoding))
+	})
+
+	t.Run("SQL", func(t *testing.T) {
+		value, err := id.Value()
+		require.NoError(t, err)
+		assert.Equal(t, id.Bytes(), value)
+
+		for _, src := range []interface{}{value, id.Hex(), "0x" + id.Hex()} {
+			var scanned flow.Identifier
+			require.NoError(t, scanned.Scan(src))
+			assert.Equal(t, id, scanned)
+		}
+
+		// bytes are always the raw identifier, never its hex representation
+		var scanned flow.Identifier
+		err = scanned.Scan([]byte(id.Hex()))
+		assert.True(t, errors.Is(err, flowerrors.ErrDecoding))
+
+		scanned = id
+		require.NoError(t, scanned.Scan(nil))
+		assert.Equal(t, flow.EmptyID, scanned)
+
+		err = scanned.Scan(int64(1))
+		assert.True(t, errors.Is(err, flowerrors.ErrDecoding))
+	})
+
+	t.Run("State commitment", func(t *testing.T) {
+		sc := flow.StateCommitment(id)
+
+		data, err := json.Marshal(sc)
+		require.NoError(t, err)
+		assert.Equal(t, `"`+id.Hex()+`"`, string(data))
+
+		var decoded flow.StateCommitment
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, sc, decoded)
+
+		var scanned flow.StateCommitment
+		require.NoError(t, scanned.Scan(id.Hex()))
+		assert.Equal(t, sc, scanned)
+	})
+
+	t.Run("JSON of entities", func(t *testing.T) {
+		block := test.BlockGenerator().New()
+
+		data, err := json.Marshal(block)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"ID":"`+block.ID.Hex()+`"`)
+
+		var decodedBlock flow.Block
+		require.NoError(t, json.Unmarshal(data, &decodedBlock))
+		assert.Equal(t, *block, decodedBlock)
+
+		tx := test.TransactionGenerator().New()
+
+		data, err = json.Marshal(tx)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"ReferenceBlockID":"`+tx.ReferenceBlockID.Hex()+`"`)
+
+		var decodedTx flow.Transaction
+		require.NoError(t, json.Unmarshal(data, &decodedTx))
+		assert.Equal(t, *tx, decodedTx)
+		assert.Equal(t, tx.ID(), decodedTx.ID())
+	})
+}
@@ -0,0 +1,163 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package flowerrors defines the sentinel errors shared across the Flow Go SDK.
+//
+// Errors returned by the SDK wrap one of the sentinels below together with
+// their underlying cause, so callers can branch on the kind of failure with
+// errors.Is and still reach the original error with errors.As:
+//
+//	if errors.Is(err, flowerrors.ErrNotFound) {
+//		// ...
+//	}
+package flowerrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidArgument indicates that a value passed to the SDK is invalid.
+	ErrInvalidArgument = errors.New("invalid argument")
+
+	// ErrNotFound indicates that a requested entity does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrDecoding indicates that encoded input, such as RLP, PEM, JSON-CDC or a
+	// protobuf message, is malformed.
+	ErrDecoding = errors.New("decoding failed")
+
+	// ErrUnsupported indicates that an algorithm, type or operation is not
+	// supported by the SDK or the remote node.
+	ErrUnsupported = errors.New("unsupported")
+
+	// ErrUnavailable indicates a transient failure of a remote service; the
+	// operation may succeed if retried.
+	ErrUnavailable = errors.New("unavailable")
+
+	// ErrTimeout indicates that an operation did not complete before its deadline.
+	ErrTimeout = errors.New("timeout")
+)
+
+// A Kind classifies an error by the sentinel it wraps.
+type Kind int
+
+const (
+	KindUnknown Kind = iota
+	KindInvalidArgument
+	KindNotFound
+	KindDecoding
+	KindUnsupported
+	KindUnavailable
+	KindTimeout
+)
+
+var kinds = []struct {
+	kind     Kind
+	sentinel error
+}{
+	{KindInvalidArgument, ErrInvalidArgument},
+	{KindNotFound, ErrNotFound},
+	{KindDecoding, ErrDecoding},
+	{KindUnsupported, ErrUnsupported},
+	{KindUnavailable, ErrUnavailable},
+	{KindTimeout, ErrTimeout},
+}
+
+// String returns the string representation of this kind.
+func (k Kind) String() string {
+	if s := k.Sentinel(); s != nil {
+		return s.Error()
+	}
+	return "unknown"
+}
+
+// Sentinel returns the sentinel error for this kind, or nil for KindUnknown.
+func (k Kind) Sentinel() error {
+	for _, entry := range kinds {
+		if entry.kind == k {
+			return entry.sentinel
+		}
+	}
+	return nil
+}
+
+// KindOf returns the kind of the first sentinel found in the chain of err,
+// or KindUnknown if err does not wrap any of them.
+func KindOf(err error) Kind {
+	if err == nil {
+		return KindUnknown
+	}
+	for _, entry := range kinds {
+		if errors.Is(err, entry.sentinel) {
+			return entry.kind
+		}
+	}
+	return KindUnknown
+}
+
+// IsTransient returns true if err wraps ErrUnavailable or ErrTimeout, meaning that
+// the operation may succeed if it is retried.
+func IsTransient(err error) bool {
+	return errors.Is(err, ErrUnavailable) || errors.Is(err, ErrTimeout)
+}
+
+// Error is an error of a given kind.
+//
+// Its message is unchanged by the kind, and errors.Is matches both the kind's
+// sentinel and anything in the chain of the underlying error.
+type Error struct {
+	Kind error
+	Err  error
+}
+
+// New returns an error of the given kind with the given message.
+func New(kind error, msg string) error {
+	return &Error{Kind: kind, Err: errors.New(msg)}
+}
+
+// Errorf returns an error of the given kind, formatted according to format.
+//
+// As with fmt.Errorf, an operand of the %w verb becomes the underlying cause.
+func Errorf(kind error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// Wrap returns err marked as being of the given kind, or nil if err is nil.
+//
+// Errors that already match kind are returned unchanged.
+func Wrap(kind error, err error) error {
+	if err == nil || errors.Is(err, kind) {
+		return err
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the kind of this error.
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
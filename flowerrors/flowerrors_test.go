@@ -0,0 +1,78 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowerrors_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+func TestErrorf(t *testing.T) {
+	err := flowerrors.Errorf(flowerrors.ErrDecoding, "failed to decode %s: %w", "thing", io.ErrUnexpectedEOF)
+
+	assert.EqualError(t, err, "failed to decode thing: unexpected EOF")
+	assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.NotErrorIs(t, err, flowerrors.ErrNotFound)
+
+	wrapped := fmt.Errorf("outer: %w", err)
+	assert.ErrorIs(t, wrapped, flowerrors.ErrDecoding)
+	assert.Equal(t, flowerrors.KindDecoding, flowerrors.KindOf(wrapped))
+
+	var flowErr *flowerrors.Error
+	assert.True(t, errors.As(wrapped, &flowErr))
+	assert.Equal(t, flowerrors.ErrDecoding, flowErr.Kind)
+}
+
+func TestWrap(t *testing.T) {
+	assert.NoError(t, flowerrors.Wrap(flowerrors.ErrNotFound, nil))
+
+	err := flowerrors.Wrap(flowerrors.ErrUnavailable, io.EOF)
+	assert.EqualError(t, err, io.EOF.Error())
+	assert.ErrorIs(t, err, flowerrors.ErrUnavailable)
+	assert.ErrorIs(t, err, io.EOF)
+
+	assert.Equal(t, err, flowerrors.Wrap(flowerrors.ErrUnavailable, err))
+}
+
+func TestKindOf(t *testing.T) {
+	assert.Equal(t, flowerrors.KindUnknown, flowerrors.KindOf(nil))
+	assert.Equal(t, flowerrors.KindUnknown, flowerrors.KindOf(io.EOF))
+	assert.Equal(t, flowerrors.KindNotFound, flowerrors.KindOf(flowerrors.ErrNotFound))
+	assert.Equal(t, flowerrors.KindTimeout, flowerrors.KindOf(flowerrors.New(flowerrors.ErrTimeout, "slow")))
+
+	assert.Equal(t, "not found", flowerrors.KindNotFound.String())
+	assert.Equal(t, "unknown", flowerrors.KindUnknown.String())
+	assert.Nil(t, flowerrors.KindUnknown.Sentinel())
+}
+
+func TestIsTransient(t *testing.T) {
+	assert.True(t, flowerrors.IsTransient(flowerrors.New(flowerrors.ErrUnavailable, "down")))
+	assert.True(t, flowerrors.IsTransient(fmt.Errorf("fetch: %w", flowerrors.New(flowerrors.ErrTimeout, "slow"))))
+
+	assert.False(t, flowerrors.IsTransient(nil))
+	assert.False(t, flowerrors.IsTransient(io.EOF))
+	assert.False(t, flowerrors.IsTransient(flowerrors.New(flowerrors.ErrNotFound, "missing")))
+}
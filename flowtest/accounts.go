@@ -0,0 +1,98 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowtest
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/cryptotest"
+)
+
+// DefaultBalance is the FLOW balance of accounts created by a Generator.
+const DefaultBalance = 100_000_000
+
+// An Account is a generated account together with the signer of its first key.
+type Account struct {
+	*flow.Account
+	Signer crypto.Signer
+}
+
+// Key returns the first key of the account.
+func (a *Account) Key() *flow.AccountKey {
+	return a.Keys[0]
+}
+
+// AccountKey returns a full-weight ECDSA P-256 account key and a signer for it.
+func (g *Generator) AccountKey() (*flow.AccountKey, crypto.Signer) {
+	return g.AccountKeyWithAlgorithms(crypto.ECDSA_P256, crypto.SHA3_256)
+}
+
+// AccountKeyWithAlgorithms returns a full-weight account key using the given algorithms,
+// and a signer for it.
+//
+// The signer produces reproducible signatures, see the cryptotest package.
+func (g *Generator) AccountKeyWithAlgorithms(
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (*flow.AccountKey, crypto.Signer) {
+	privateKey := g.PrivateKey(sigAlgo)
+
+	accountKey := &flow.AccountKey{
+		PublicKey: privateKey.PublicKey(),
+		SigAlgo:   sigAlgo,
+		HashAlgo:  hashAlgo,
+		Weight:    flow.AccountKeyWeightThreshold,
+	}
+
+	signer, err := cryptotest.NewSigner(privateKey, hashAlgo)
+	if err != nil {
+		panic(fmt.Errorf("flowtest: failed to create signer: %w", err))
+	}
+
+	return accountKey, signer
+}
+
+// PrivateKey returns a private key for the given signature algorithm derived from a random seed.
+func (g *Generator) PrivateKey(sigAlgo crypto.SignatureAlgorithm) crypto.PrivateKey {
+	g.keys++
+
+	privateKey, err := crypto.GeneratePrivateKey(sigAlgo, g.Bytes(crypto.MinSeedLength))
+	if err != nil {
+		panic(fmt.Errorf("flowtest: failed to generate private key: %w", err))
+	}
+
+	return privateKey
+}
+
+// Account returns an account at the next address with a single full-weight key.
+func (g *Generator) Account() *Account {
+	accountKey, signer := g.AccountKey()
+
+	return &Account{
+		Account: &flow.Account{
+			Address:   g.Address(),
+			Balance:   DefaultBalance,
+			Keys:      []*flow.AccountKey{accountKey},
+			Contracts: map[string][]byte{},
+		},
+		Signer: signer,
+	}
+}
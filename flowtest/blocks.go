@@ -0,0 +1,128 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowtest
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// BlockHeader returns the header of the next block in the generated chain.
+//
+// The first header is at height zero with timestamp GenesisTime. Each following
+// header references its predecessor as parent and is produced about a second later.
+func (g *Generator) BlockHeader() flow.BlockHeader {
+	header := flow.BlockHeader{
+		ID:        g.Identifier(),
+		Timestamp: GenesisTime,
+	}
+
+	if n := len(g.headers); n > 0 {
+		parent := g.headers[n-1]
+
+		header.ParentID = parent.ID
+		header.Height = parent.Height + 1
+		header.Timestamp = parent.Timestamp.Add(blockTime(g.rand))
+	}
+
+	g.headers = append(g.headers, header)
+
+	return header
+}
+
+// Block returns the next block in the generated chain.
+//
+// The block contains between one and three collection guarantees and, except for the
+// first block, a seal for its parent.
+func (g *Generator) Block() *flow.Block {
+	header := g.BlockHeader()
+
+	guarantees := make([]*flow.CollectionGuarantee, 1+g.rand.Intn(3))
+	for i := range guarantees {
+		guarantees[i] = g.CollectionGuarantee(header.ParentID)
+	}
+
+	seals := []*flow.BlockSeal{}
+	if header.Height > 0 {
+		seals = append(seals, g.BlockSeal(header.ParentID))
+	}
+
+	return &flow.Block{
+		BlockHeader: header,
+		BlockPayload: flow.BlockPayload{
+			CollectionGuarantees: guarantees,
+			Seals:                seals,
+		},
+	}
+}
+
+// Blocks returns the next n blocks in the generated chain.
+func (g *Generator) Blocks(n int) []*flow.Block {
+	blocks := make([]*flow.Block, n)
+	for i := range blocks {
+		blocks[i] = g.Block()
+	}
+	return blocks
+}
+
+// Collection returns a collection of the given transactions.
+func (g *Generator) Collection(txs ...*flow.Transaction) *flow.Collection {
+	ids := make([]flow.Identifier, len(txs))
+	for i, tx := range txs {
+		ids[i] = tx.ID()
+	}
+
+	return &flow.Collection{TransactionIDs: ids}
+}
+
+// CollectionGuarantee returns a guarantee for a random collection referencing the given block.
+func (g *Generator) CollectionGuarantee(referenceBlockID flow.Identifier) *flow.CollectionGuarantee {
+	return &flow.CollectionGuarantee{
+		CollectionID:     g.Identifier(),
+		ReferenceBlockID: referenceBlockID,
+		ClusterChainID:   flow.ChainID("cluster-0"),
+		SignerIndices:    g.Bytes(5),
+		Signature:        g.Bytes(48),
+	}
+}
+
+// BlockSeal returns a seal for the block with the given ID.
+func (g *Generator) BlockSeal(blockID flow.Identifier) *flow.BlockSeal {
+	return &flow.BlockSeal{
+		BlockID:                    blockID,
+		ExecutionReceiptID:         g.Identifier(),
+		ExecutionReceiptSignatures: [][]byte{},
+		ResultApprovalSignatures:   [][]byte{},
+		ResultID:                   g.Identifier(),
+		FinalState:                 flow.StateCommitment(g.Identifier()),
+		AggregatedApprovalSigs: []*flow.AggregatedSignature{
+			{
+				VerifierSignatures: [][]byte{g.Bytes(48)},
+				SignerIDs:          []flow.Identifier{g.Identifier()},
+			},
+		},
+	}
+}
+
+// blockTime returns a block production time between 0.5 and 1.5 seconds.
+func blockTime(r *rand.Rand) time.Duration {
+	return 500*time.Millisecond + time.Duration(r.Int63n(int64(time.Second)))
+}
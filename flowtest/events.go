@@ -0,0 +1,104 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowtest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/common"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/systemcontracts"
+)
+
+// An EventField is a named field of a generated event.
+type EventField struct {
+	Name  string
+	Value cadence.Value
+}
+
+// Event returns an event of the given type with the given fields.
+//
+// The event type must be a fully qualified type ID, e.g. "A.f8d6e0586b0a20c7.FlowToken.TokensDeposited".
+// Both the decoded value and the JSON-CDC payload of the event are set.
+func (g *Generator) Event(eventType string, fields ...EventField) flow.Event {
+	location, qualifiedIdentifier, err := common.DecodeTypeID(eventType)
+	if err == nil && location == nil {
+		err = errors.New("unknown location")
+	}
+	if err != nil {
+		panic(fmt.Errorf("flowtest: invalid event type %s: %w", eventType, err))
+	}
+
+	fieldTypes := make([]cadence.Field, len(fields))
+	values := make([]cadence.Value, len(fields))
+	for i, field := range fields {
+		fieldTypes[i] = cadence.Field{
+			Identifier: field.Name,
+			Type:       field.Value.Type(),
+		}
+		values[i] = field.Value
+	}
+
+	value := cadence.NewEvent(values).WithType(&cadence.EventType{
+		Location:            location,
+		QualifiedIdentifier: qualifiedIdentifier,
+		Fields:              fieldTypes,
+	})
+
+	payload, err := jsoncdc.Encode(value)
+	if err != nil {
+		panic(fmt.Errorf("flowtest: failed to encode event: %w", err))
+	}
+
+	defer func() { g.events++ }()
+
+	return flow.Event{
+		Type:             eventType,
+		TransactionID:    g.Identifier(),
+		TransactionIndex: g.events,
+		EventIndex:       g.events,
+		Value:            value,
+		Payload:          payload,
+	}
+}
+
+// TokensDepositedEvent returns a FlowToken.TokensDeposited event on the emulator chain.
+func (g *Generator) TokensDepositedEvent(amount cadence.UFix64, to flow.Address) flow.Event {
+	return g.Event(
+		flowToken.EventType("TokensDeposited"),
+		EventField{Name: "amount", Value: amount},
+		EventField{Name: "to", Value: cadence.NewOptional(cadence.Address(to))},
+	)
+}
+
+// TokensWithdrawnEvent returns a FlowToken.TokensWithdrawn event on the emulator chain.
+func (g *Generator) TokensWithdrawnEvent(amount cadence.UFix64, from flow.Address) flow.Event {
+	return g.Event(
+		flowToken.EventType("TokensWithdrawn"),
+		EventField{Name: "amount", Value: amount},
+		EventField{Name: "from", Value: cadence.NewOptional(cadence.Address(from))},
+	)
+}
+
+// flowToken is the FlowToken contract on the emulator chain.
+var flowToken = systemcontracts.MustForChain(flow.Emulator).FlowToken
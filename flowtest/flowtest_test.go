@@ -0,0 +1,156 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowtest_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client/convert"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowtest"
+)
+
+func TestGenerator_Deterministic(t *testing.T) {
+	a := flowtest.NewGenerator(42)
+	b := flowtest.NewGenerator(42)
+
+	assert.Equal(t, a.Identifier(), b.Identifier())
+	assert.Equal(t, a.Address(), b.Address())
+	assert.Equal(t, a.Account().Keys[0].PublicKey.String(), b.Account().Keys[0].PublicKey.String())
+	assert.Equal(t, a.Transaction().Encode(), b.Transaction().Encode())
+	assert.Equal(t, a.Blocks(3), b.Blocks(3))
+
+	c := flowtest.NewGenerator(43)
+	assert.NotEqual(t, a.Identifier(), c.Identifier())
+}
+
+func TestGenerator_SignedTransaction(t *testing.T) {
+	g := flowtest.NewGenerator(42)
+
+	proposer := g.Account()
+	authorizer := g.Account()
+	payer := g.Account()
+
+	tx := g.SignedTransaction(proposer, payer, proposer, authorizer)
+
+	require.Len(t, tx.PayloadSignatures, 2)
+	require.Len(t, tx.EnvelopeSignatures, 1)
+
+	accounts := map[flow.Address]*flowtest.Account{
+		proposer.Address:   proposer,
+		authorizer.Address: authorizer,
+		payer.Address:      payer,
+	}
+
+	verify := func(sig flow.TransactionSignature, message []byte) {
+		key := accounts[sig.Address].Key()
+
+		hasher, err := crypto.NewHasher(key.HashAlgo)
+		require.NoError(t, err)
+
+		valid, err := key.PublicKey.Verify(sig.Signature, append(flow.TransactionDomainTag[:], message...), hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	for _, sig := range tx.PayloadSignatures {
+		verify(sig, tx.PayloadMessage())
+	}
+	for _, sig := range tx.EnvelopeSignatures {
+		verify(sig, tx.EnvelopeMessage())
+	}
+
+	t.Run("Payer only", func(t *testing.T) {
+		tx := g.SignedTransaction(payer, payer, payer)
+
+		assert.Empty(t, tx.PayloadSignatures)
+		assert.Len(t, tx.EnvelopeSignatures, 1)
+	})
+}
+
+func TestGenerator_Blocks(t *testing.T) {
+	g := flowtest.NewGenerator(42)
+
+	blocks := g.Blocks(10)
+
+	assert.Equal(t, uint64(0), blocks[0].Height)
+	assert.Equal(t, flowtest.GenesisTime, blocks[0].Timestamp)
+	assert.Empty(t, blocks[0].Seals)
+
+	for i := 1; i < len(blocks); i++ {
+		block, parent := blocks[i], blocks[i-1]
+
+		assert.Equal(t, parent.ID, block.ParentID)
+		assert.Equal(t, parent.Height+1, block.Height)
+		assert.True(t, block.Timestamp.After(parent.Timestamp))
+
+		require.Len(t, block.Seals, 1)
+		assert.Equal(t, parent.ID, block.Seals[0].BlockID)
+		assert.NotEmpty(t, block.CollectionGuarantees)
+	}
+
+	// generated blocks survive a round trip through the Access API representation
+	msg, err := convert.BlockToMessage(*blocks[3])
+	require.NoError(t, err)
+
+	block, err := convert.MessageToBlock(msg)
+	require.NoError(t, err)
+	assert.Equal(t, *blocks[3], block)
+}
+
+func TestGenerator_Events(t *testing.T) {
+	g := flowtest.NewGenerator(42)
+
+	to := g.Address()
+	event := g.TokensDepositedEvent(cadence.UFix64(100000000), to)
+
+	assert.Equal(t, "A.0ae53cb6e3f42a79.FlowToken.TokensDeposited", event.Type)
+
+	// the payload decodes to the event value
+	msg, err := convert.EventToMessage(event)
+	require.NoError(t, err)
+
+	decoded, err := convert.MessageToEvent(msg)
+	require.NoError(t, err)
+	assert.Equal(t, event.Value.Fields, decoded.Value.Fields)
+	assert.Equal(t, event.Type, decoded.Value.EventType.ID())
+
+	t.Run("Transaction result", func(t *testing.T) {
+		tx := g.Transaction()
+		result := g.TransactionResultFor(tx, 3)
+
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+		assert.NoError(t, result.Error)
+		require.Len(t, result.Events, 3)
+
+		for i, event := range result.Events {
+			assert.Equal(t, tx.ID(), event.TransactionID)
+			assert.Equal(t, i, event.EventIndex)
+		}
+	})
+
+	t.Run("Invalid type", func(t *testing.T) {
+		assert.Panics(t, func() { g.Event("foo") })
+	})
+}
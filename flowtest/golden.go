@@ -0,0 +1,126 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowtest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// UpdateGoldenEnv is the environment variable that, when set to a non-empty value,
+// makes AssertGoldenTransaction write golden files instead of comparing against them.
+const UpdateGoldenEnv = "FLOW_UPDATE_GOLDEN"
+
+// A CanonicalEncoding holds the hex-encoded canonical encodings of a transaction.
+//
+// Payload and Envelope are the RLP messages signed by the transaction signers, before
+// the transaction domain tag is prepended. Transaction is the full RLP encoding,
+// including signatures, from which the transaction ID is computed.
+type CanonicalEncoding struct {
+	ID          string `json:"id"`
+	Payload     string `json:"payload"`
+	Envelope    string `json:"envelope"`
+	Transaction string `json:"transaction"`
+}
+
+// EncodeCanonical returns the canonical encodings of a transaction.
+func EncodeCanonical(tx *flow.Transaction) CanonicalEncoding {
+	return CanonicalEncoding{
+		ID:          tx.ID().Hex(),
+		Payload:     hex.EncodeToString(tx.PayloadMessage()),
+		Envelope:    hex.EncodeToString(tx.EnvelopeMessage()),
+		Transaction: hex.EncodeToString(tx.Encode()),
+	}
+}
+
+// AssertGoldenTransaction compares the canonical encodings of a transaction against the
+// golden file at the given path, reporting each differing encoding as a test error.
+//
+// The golden file is a JSON representation of a CanonicalEncoding. It is created or
+// overwritten when the FLOW_UPDATE_GOLDEN environment variable is set:
+//
+//	FLOW_UPDATE_GOLDEN=1 go test ./...
+//
+// Transactions should be signed with reproducible signatures, e.g. by signers from a
+// Generator or the cryptotest package, so that the envelope and full encodings are stable.
+func AssertGoldenTransaction(t testing.TB, path string, tx *flow.Transaction) bool {
+	t.Helper()
+
+	actual := EncodeCanonical(tx)
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		writeGolden(t, path, actual)
+		return true
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Errorf("flowtest: failed to read golden file, run with %s=1 to create it: %s", UpdateGoldenEnv, err)
+		return false
+	}
+
+	var expected CanonicalEncoding
+
+	err = json.Unmarshal(b, &expected)
+	if err != nil {
+		t.Errorf("flowtest: failed to decode golden file %s: %s", path, err)
+		return false
+	}
+
+	ok := true
+
+	compare := func(name, expected, actual string) {
+		if expected != actual {
+			t.Errorf("flowtest: %s encoding differs from golden file %s\nexpected: %s\nactual:   %s", name, path, expected, actual)
+			ok = false
+		}
+	}
+
+	compare("payload", expected.Payload, actual.Payload)
+	compare("envelope", expected.Envelope, actual.Envelope)
+	compare("transaction", expected.Transaction, actual.Transaction)
+	compare("ID", expected.ID, actual.ID)
+
+	return ok
+}
+
+func writeGolden(t testing.TB, path string, encoding CanonicalEncoding) {
+	t.Helper()
+
+	b, err := json.MarshalIndent(encoding, "", "  ")
+	if err != nil {
+		t.Fatalf("flowtest: failed to encode golden file: %s", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("flowtest: failed to create golden file directory: %s", err)
+	}
+
+	err = ioutil.WriteFile(path, append(b, '\n'), 0644)
+	if err != nil {
+		t.Fatalf("flowtest: failed to write golden file: %s", err)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowtest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/flowtest"
+)
+
+// errorRecorder records test errors instead of failing the test.
+type errorRecorder struct {
+	testing.TB
+	errors int
+}
+
+func (r *errorRecorder) Errorf(format string, args ...interface{}) {
+	r.errors++
+}
+
+func TestAssertGoldenTransaction(t *testing.T) {
+	g := flowtest.NewGenerator(42)
+	tx := g.Transaction()
+
+	updating := os.Getenv(flowtest.UpdateGoldenEnv) != ""
+
+	t.Run("Match", func(t *testing.T) {
+		flowtest.AssertGoldenTransaction(t, filepath.Join("testdata", "transaction.golden.json"), tx)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		if updating {
+			t.Skip("golden files are being updated")
+		}
+
+		other := flowtest.NewGenerator(43).Transaction()
+
+		recorder := &errorRecorder{TB: t}
+		ok := flowtest.AssertGoldenTransaction(recorder, filepath.Join("testdata", "transaction.golden.json"), other)
+
+		assert.False(t, ok)
+		assert.Equal(t, 4, recorder.errors)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		if updating {
+			t.Skip("golden files are being updated")
+		}
+
+		recorder := &errorRecorder{TB: t}
+		ok := flowtest.AssertGoldenTransaction(recorder, filepath.Join("testdata", "missing.golden.json"), tx)
+
+		assert.False(t, ok)
+		assert.Equal(t, 1, recorder.errors)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "flowtest")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "golden", "transaction.json")
+
+		os.Setenv(flowtest.UpdateGoldenEnv, "1")
+		assert.True(t, flowtest.AssertGoldenTransaction(t, path, tx))
+
+		if !updating {
+			os.Unsetenv(flowtest.UpdateGoldenEnv)
+		}
+
+		assert.True(t, flowtest.AssertGoldenTransaction(t, path, tx))
+	})
+}
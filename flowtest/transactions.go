@@ -0,0 +1,128 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowtest
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// DefaultGasLimit is the gas limit of transactions created by a Generator.
+const DefaultGasLimit = 9999
+
+// HelloWorldScript is the script of transactions created by a Generator.
+const HelloWorldScript = `
+transaction(greeting: String) {
+  prepare(signer: AuthAccount) {}
+  execute { log(greeting) }
+}
+`
+
+// Transaction returns a transaction signed by a new proposer, payer and authorizer.
+func (g *Generator) Transaction() *flow.Transaction {
+	proposer := g.Account()
+	payer := g.Account()
+
+	return g.SignedTransaction(proposer, payer, proposer)
+}
+
+// UnsignedTransaction returns a transaction with the given roles that has not been signed.
+//
+// The sequence number of the proposal key is taken from the proposer's first key.
+func (g *Generator) UnsignedTransaction(proposer *Account, payer *Account, authorizers ...*Account) *flow.Transaction {
+	tx := flow.NewTransaction().
+		SetScript([]byte(HelloWorldScript)).
+		SetReferenceBlockID(g.Identifier()).
+		SetGasLimit(DefaultGasLimit).
+		SetProposalKey(proposer.Address, proposer.Key().Index, proposer.Key().SequenceNumber).
+		SetPayer(payer.Address)
+
+	for _, authorizer := range authorizers {
+		tx.AddAuthorizer(authorizer.Address)
+	}
+
+	err := tx.AddArgument(cadence.String(fmt.Sprintf("Hello, %d!", g.rand.Intn(1000))))
+	if err != nil {
+		panic(fmt.Errorf("flowtest: failed to add argument: %w", err))
+	}
+
+	return tx
+}
+
+// SignedTransaction returns a transaction with the given roles carrying valid signatures
+// from the first key of each participating account.
+//
+// Following the signing rules of Flow, accounts other than the payer sign the payload
+// and the payer signs the envelope. Each account signs at most once.
+func (g *Generator) SignedTransaction(proposer *Account, payer *Account, authorizers ...*Account) *flow.Transaction {
+	tx := g.UnsignedTransaction(proposer, payer, authorizers...)
+
+	signers := append([]*Account{proposer}, authorizers...)
+	signed := map[flow.Address]bool{payer.Address: true}
+
+	for _, signer := range signers {
+		if signed[signer.Address] {
+			continue
+		}
+		signed[signer.Address] = true
+
+		err := tx.SignPayload(signer.Address, signer.Key().Index, signer.Signer)
+		if err != nil {
+			panic(fmt.Errorf("flowtest: failed to sign payload: %w", err))
+		}
+	}
+
+	err := tx.SignEnvelope(payer.Address, payer.Key().Index, payer.Signer)
+	if err != nil {
+		panic(fmt.Errorf("flowtest: failed to sign envelope: %w", err))
+	}
+
+	return tx
+}
+
+// TransactionResult returns a sealed, successful transaction result containing the given events.
+func (g *Generator) TransactionResult(events ...flow.Event) flow.TransactionResult {
+	if events == nil {
+		events = []flow.Event{}
+	}
+
+	return flow.TransactionResult{
+		Status: flow.TransactionStatusSealed,
+		Events: events,
+	}
+}
+
+// TransactionResultFor returns a sealed, successful result for the given transaction,
+// containing the given number of events emitted by it.
+func (g *Generator) TransactionResultFor(tx *flow.Transaction, events int) flow.TransactionResult {
+	txEvents := make([]flow.Event, events)
+	for i := range txEvents {
+		txEvents[i] = g.Event(fmt.Sprintf("A.%s.Greeter.Greeted", tx.Payer.Hex()), EventField{
+			Name:  "index",
+			Value: cadence.NewInt(i),
+		})
+		txEvents[i].TransactionID = tx.ID()
+		txEvents[i].EventIndex = i
+	}
+
+	return g.TransactionResult(txEvents...)
+}
@@ -0,0 +1,123 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowx
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// An Argument is a Go type that encodes to a Cadence value without loss.
+//
+// Named types are encoded by their underlying type, except for the Cadence value
+// types themselves (e.g. cadence.UFix64), which are passed through unchanged.
+type Argument interface {
+	~bool | ~string |
+		~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		flow.Address | cadence.Address
+}
+
+// Arg encodes a Go value as a Cadence script or transaction argument.
+//
+// Go integers map to the Cadence integer type of the same size and signedness,
+// int and uint to Cadence Int and UInt.
+func Arg[T Argument](v T) cadence.Value {
+	if value, ok := any(v).(cadence.Value); ok {
+		return value
+	}
+
+	switch v := any(v).(type) {
+	case flow.Address:
+		return cadence.NewAddress(v)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return cadence.NewBool(rv.Bool())
+	case reflect.String:
+		return cadence.NewString(rv.String())
+	case reflect.Int:
+		return cadence.NewInt(int(rv.Int()))
+	case reflect.Int8:
+		return cadence.NewInt8(int8(rv.Int()))
+	case reflect.Int16:
+		return cadence.NewInt16(int16(rv.Int()))
+	case reflect.Int32:
+		return cadence.NewInt32(int32(rv.Int()))
+	case reflect.Int64:
+		return cadence.NewInt64(rv.Int())
+	case reflect.Uint:
+		return cadence.NewUInt(uint(rv.Uint()))
+	case reflect.Uint8:
+		return cadence.NewUInt8(uint8(rv.Uint()))
+	case reflect.Uint16:
+		return cadence.NewUInt16(uint16(rv.Uint()))
+	case reflect.Uint32:
+		return cadence.NewUInt32(uint32(rv.Uint()))
+	default:
+		// the Argument constraint leaves uint64 as the only remaining kind
+		return cadence.NewUInt64(rv.Uint())
+	}
+}
+
+// ArrayArg encodes a slice of Go values as a Cadence array.
+func ArrayArg[T Argument](values []T) cadence.Value {
+	elements := make([]cadence.Value, len(values))
+	for i, v := range values {
+		elements[i] = Arg(v)
+	}
+
+	return cadence.NewArray(elements)
+}
+
+// OptionalArg encodes a pointer to a Go value as a Cadence optional, which is nil
+// if the pointer is nil.
+func OptionalArg[T Argument](v *T) cadence.Value {
+	if v == nil {
+		return cadence.NewOptional(nil)
+	}
+
+	return cadence.NewOptional(Arg(*v))
+}
+
+// DictionaryArg encodes a Go map as a Cadence dictionary.
+//
+// Entries are sorted by key, so that the encoded argument, and therefore the ID of a
+// transaction it is part of, does not depend on the iteration order of the map.
+func DictionaryArg[K, V Argument](m map[K]V) cadence.Value {
+	pairs := make([]cadence.KeyValuePair, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, cadence.KeyValuePair{Key: Arg(k), Value: Arg(v)})
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].Key.String() < pairs[j].Key.String()
+	})
+
+	return cadence.NewDictionary(pairs)
+}
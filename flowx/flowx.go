@@ -0,0 +1,57 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package flowx is a typed convenience layer over the Flow Go SDK.
+//
+// It uses type parameters to give compile-time types to Cadence interop: script
+// results decode into a Go type chosen by the caller, event streams deliver
+// decoded values, and arguments are built from Go values whose types are known
+// to have a Cadence counterpart.
+//
+//	type Balance struct {
+//		Address flow.Address `cadence:"address"`
+//		Amount  uint64       `cadence:"amount"`
+//	}
+//
+//	balance, err := flowx.ExecuteScript[Balance](ctx, c, script, flowx.Arg(address))
+//
+// Values are decoded with the same rules as events.DecodeValue. The package
+// requires Go 1.18 or above.
+package flowx
+
+import (
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk/events"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// Decode decodes a Cadence value into a Go value of type T.
+func Decode[T any](value cadence.Value) (T, error) {
+	var result T
+
+	err := events.DecodeValue(value, &result)
+	if err != nil {
+		return result, flowerrors.Errorf(flowerrors.ErrDecoding, "flowx: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,301 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/client/mocks"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/flowx"
+)
+
+type tokenID uint64
+
+func TestArg(t *testing.T) {
+	address := flow.HexToAddress("f8d6e0586b0a20c7")
+	amount, err := cadence.NewUFix64("1.5")
+	require.NoError(t, err)
+
+	assert.Equal(t, cadence.NewBool(true), flowx.Arg(true))
+	assert.Equal(t, cadence.String("foo"), flowx.Arg("foo"))
+	assert.Equal(t, cadence.NewInt(-1), flowx.Arg(-1))
+	assert.Equal(t, cadence.NewInt8(-8), flowx.Arg(int8(-8)))
+	assert.Equal(t, cadence.NewInt64(-64), flowx.Arg(int64(-64)))
+	assert.Equal(t, cadence.NewUInt(1), flowx.Arg(uint(1)))
+	assert.Equal(t, cadence.NewUInt8(8), flowx.Arg(uint8(8)))
+	assert.Equal(t, cadence.NewUInt32(32), flowx.Arg(uint32(32)))
+	assert.Equal(t, cadence.NewUInt64(64), flowx.Arg(uint64(64)))
+	assert.Equal(t, cadence.NewUInt64(7), flowx.Arg(tokenID(7)))
+	assert.Equal(t, cadence.NewAddress(address), flowx.Arg(address))
+
+	// Cadence values keep their own type
+	assert.Equal(t, amount, flowx.Arg(amount))
+}
+
+func TestArrayArg(t *testing.T) {
+	assert.Equal(t,
+		cadence.NewArray([]cadence.Value{cadence.NewUInt64(1), cadence.NewUInt64(2)}),
+		flowx.ArrayArg([]uint64{1, 2}),
+	)
+}
+
+func TestOptionalArg(t *testing.T) {
+	name := "foo"
+
+	assert.Equal(t, cadence.NewOptional(cadence.String("foo")), flowx.OptionalArg(&name))
+	assert.Equal(t, cadence.NewOptional(nil), flowx.OptionalArg[string](nil))
+}
+
+func TestDictionaryArg(t *testing.T) {
+	m := map[string]uint64{"c": 3, "a": 1, "b": 2}
+
+	expected := jsoncdc.MustEncode(flowx.DictionaryArg(m))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, expected, jsoncdc.MustEncode(flowx.DictionaryArg(m)))
+	}
+
+	pairs := flowx.DictionaryArg(m).(cadence.Dictionary).Pairs
+	require.Len(t, pairs, 3)
+	assert.Equal(t, cadence.String("a"), pairs[0].Key)
+	assert.Equal(t, cadence.NewUInt64(1), pairs[0].Value)
+}
+
+type balance struct {
+	Address flow.Address `cadence:"address"`
+	Amount  uint64       `cadence:"amount"`
+}
+
+var balanceType = &cadence.StructType{
+	QualifiedIdentifier: "Balance",
+	Fields: []cadence.Field{
+		{Identifier: "address", Type: cadence.AddressType{}},
+		{Identifier: "amount", Type: cadence.UInt64Type{}},
+	},
+}
+
+func newBalance(address flow.Address, amount uint64) cadence.Value {
+	return cadence.NewStruct([]cadence.Value{
+		cadence.NewAddress(address),
+		cadence.NewUInt64(amount),
+	}).WithType(balanceType)
+}
+
+func TestScript(t *testing.T) {
+	ctx := context.Background()
+	address := flow.HexToAddress("01")
+	script := []byte("pub fun main(address: Address): Balance { ... }")
+
+	t.Run("Latest block", func(t *testing.T) {
+		c := mocks.NewAccessClient(t)
+		c.On("ExecuteScriptAtLatestBlock", ctx, script, []cadence.Value{flowx.Arg(address)}).
+			Return(newBalance(address, 42), nil)
+
+		result, err := flowx.ExecuteScript[balance](ctx, c, script, flowx.Arg(address))
+		require.NoError(t, err)
+		assert.Equal(t, balance{Address: address, Amount: 42}, result)
+	})
+
+	t.Run("Block height", func(t *testing.T) {
+		c := mocks.NewAccessClient(t)
+		c.On("ExecuteScriptAtBlockHeight", ctx, uint64(7), script, []cadence.Value(nil)).
+			Return(cadence.NewArray([]cadence.Value{cadence.String("a"), cadence.String("b")}), nil)
+
+		result, err := flowx.NewScript[[]string](script).ExecuteAtBlockHeight(ctx, c, 7)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, result)
+	})
+
+	t.Run("Mismatched result", func(t *testing.T) {
+		c := mocks.NewAccessClient(t)
+		c.On("ExecuteScriptAtLatestBlock", ctx, script, mock.Anything).
+			Return(cadence.String("not a number"), nil)
+
+		_, err := flowx.ExecuteScript[uint64](ctx, c, script)
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+	})
+
+	t.Run("Client error", func(t *testing.T) {
+		failure := errors.New("unavailable")
+
+		c := mocks.NewAccessClient(t)
+		c.On("ExecuteScriptAtLatestBlock", ctx, script, mock.Anything).Return(nil, failure)
+
+		_, err := flowx.ExecuteScript[uint64](ctx, c, script)
+		assert.ErrorIs(t, err, failure)
+	})
+}
+
+type deposited struct {
+	Amount uint64 `cadence:"amount"`
+}
+
+const depositedType = "A.0000000000000001.Token.Deposited"
+
+func depositedEvent(amount cadence.Value) flow.Event {
+	return flow.Event{
+		Type: depositedType,
+		Value: cadence.NewEvent([]cadence.Value{amount}).WithType(&cadence.EventType{
+			QualifiedIdentifier: "Token.Deposited",
+			Fields:              []cadence.Field{{Identifier: "amount", Type: cadence.UInt64Type{}}},
+		}),
+	}
+}
+
+func receive[T any](t *testing.T, s *flowx.Stream[T]) (flowx.Event[T], bool) {
+	select {
+	case event, ok := <-s.C:
+		return event, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for stream")
+		return flowx.Event[T]{}, false
+	}
+}
+
+func TestStream(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Decodes events of the stream type", func(t *testing.T) {
+		blocks := make(chan client.BlockEvents, 2)
+		blocks <- client.BlockEvents{
+			Height: 10,
+			Events: []flow.Event{
+				depositedEvent(cadence.NewUInt64(1)),
+				{Type: "A.0000000000000001.Token.Withdrawn"},
+				depositedEvent(cadence.NewUInt64(2)),
+			},
+		}
+		blocks <- client.BlockEvents{Height: 11}
+		close(blocks)
+
+		s := flowx.NewStream[deposited](ctx, depositedType, blocks, nil)
+
+		event, ok := receive(t, s)
+		require.True(t, ok)
+		assert.Equal(t, deposited{Amount: 1}, event.Value)
+		assert.Equal(t, uint64(10), event.BlockHeight)
+
+		event, ok = receive(t, s)
+		require.True(t, ok)
+		assert.Equal(t, deposited{Amount: 2}, event.Value)
+
+		_, ok = receive(t, s)
+		assert.False(t, ok)
+		assert.NoError(t, s.Err())
+	})
+
+	t.Run("Ends on decoding errors", func(t *testing.T) {
+		blocks := make(chan client.BlockEvents, 1)
+		blocks <- client.BlockEvents{Events: []flow.Event{depositedEvent(cadence.String("one"))}}
+
+		s := flowx.NewStream[deposited](ctx, depositedType, blocks, nil)
+
+		_, ok := receive(t, s)
+		assert.False(t, ok)
+		assert.ErrorIs(t, s.Err(), flowerrors.ErrDecoding)
+	})
+
+	t.Run("Ends on upstream errors", func(t *testing.T) {
+		failure := errors.New("stream failed")
+
+		blocks := make(chan client.BlockEvents)
+		errs := make(chan error, 1)
+		errs <- failure
+		close(errs)
+		close(blocks)
+
+		s := flowx.NewStream[deposited](ctx, depositedType, blocks, errs)
+
+		_, ok := receive(t, s)
+		assert.False(t, ok)
+		assert.ErrorIs(t, s.Err(), failure)
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		blocks := make(chan client.BlockEvents)
+
+		s := flowx.NewStream[deposited](ctx, depositedType, blocks, nil)
+		s.Close()
+
+		_, ok := receive(t, s)
+		assert.False(t, ok)
+		assert.NoError(t, s.Err())
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		blocks := make(chan client.BlockEvents)
+
+		s := flowx.NewStream[deposited](ctx, depositedType, blocks, nil)
+		cancel()
+
+		_, ok := receive(t, s)
+		assert.False(t, ok)
+		assert.ErrorIs(t, s.Err(), context.Canceled)
+	})
+}
+
+type subscriber struct {
+	blocks chan client.BlockEvents
+	filter flow.EventFilter
+	ctx    context.Context
+}
+
+func (s *subscriber) SubscribeEventsByBlockHeight(
+	ctx context.Context,
+	_ uint64,
+	filter flow.EventFilter,
+	_ ...grpc.CallOption,
+) (<-chan client.BlockEvents, <-chan error, error) {
+	s.ctx = ctx
+	s.filter = filter
+	return s.blocks, nil, nil
+}
+
+func TestSubscribe(t *testing.T) {
+	sub := &subscriber{blocks: make(chan client.BlockEvents, 1)}
+	sub.blocks <- client.BlockEvents{Events: []flow.Event{depositedEvent(cadence.NewUInt64(5))}}
+
+	s, err := flowx.Subscribe[deposited](context.Background(), sub, depositedType, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{depositedType}, sub.filter.EventTypes)
+
+	event, ok := receive(t, s)
+	require.True(t, ok)
+	assert.Equal(t, deposited{Amount: 5}, event.Value)
+
+	s.Close()
+	<-sub.ctx.Done()
+	assert.ErrorIs(t, sub.ctx.Err(), context.Canceled)
+}
@@ -0,0 +1,86 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowx
+
+import (
+	"context"
+
+	"github.com/onflow/cadence"
+	"google.golang.org/grpc"
+)
+
+// A ScriptClient executes Cadence scripts.
+//
+// It is satisfied by *client.Client.
+type ScriptClient interface {
+	ExecuteScriptAtLatestBlock(
+		ctx context.Context,
+		script []byte,
+		arguments []cadence.Value,
+		opts ...grpc.CallOption,
+	) (cadence.Value, error)
+	ExecuteScriptAtBlockHeight(
+		ctx context.Context,
+		height uint64,
+		script []byte,
+		arguments []cadence.Value,
+		opts ...grpc.CallOption,
+	) (cadence.Value, error)
+}
+
+// A Script is a Cadence script whose result decodes into a value of type T.
+type Script[T any] struct {
+	Code []byte
+}
+
+// NewScript returns a script with the given code whose result decodes into T.
+func NewScript[T any](code []byte) Script[T] {
+	return Script[T]{Code: code}
+}
+
+// Execute executes the script against the latest sealed execution state.
+func (s Script[T]) Execute(ctx context.Context, c ScriptClient, args ...cadence.Value) (T, error) {
+	value, err := c.ExecuteScriptAtLatestBlock(ctx, s.Code, args)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return Decode[T](value)
+}
+
+// ExecuteAtBlockHeight executes the script against the execution state at the given block height.
+func (s Script[T]) ExecuteAtBlockHeight(ctx context.Context, c ScriptClient, height uint64, args ...cadence.Value) (T, error) {
+	value, err := c.ExecuteScriptAtBlockHeight(ctx, height, s.Code, args)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return Decode[T](value)
+}
+
+// ExecuteScript executes a script against the latest sealed execution state and
+// decodes its result into a value of type T.
+func ExecuteScript[T any](ctx context.Context, c ScriptClient, code []byte, args ...cadence.Value) (T, error) {
+	return NewScript[T](code).Execute(ctx, c, args...)
+}
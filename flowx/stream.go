@@ -0,0 +1,208 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowx
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// An Event is an event decoded into a value of type T.
+type Event[T any] struct {
+	Value       T
+	Event       flow.Event
+	BlockID     flow.Identifier
+	BlockHeight uint64
+}
+
+// A Stream delivers the events of a single type, decoded into values of type T.
+type Stream[T any] struct {
+	// C delivers the decoded events in chain order. It is closed when the stream ends.
+	C <-chan Event[T]
+
+	eventType string
+	ch        chan Event[T]
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+	mut       sync.Mutex
+	err       error
+}
+
+// NewStream decodes the events of the given type from an upstream of blocks, such as
+// a client subscription or an events.Subscription.
+//
+// The stream ends when the upstream ends or fails, an event cannot be decoded into T,
+// the context is cancelled or the stream is closed. The error that ended it, if any,
+// is available from Err once C is closed.
+func NewStream[T any](ctx context.Context, eventType string, blocks <-chan client.BlockEvents, errs <-chan error) *Stream[T] {
+	return newStream[T](ctx, eventType, blocks, errs, func() {})
+}
+
+// newStream starts a stream that calls release once it ends.
+func newStream[T any](
+	ctx context.Context,
+	eventType string,
+	blocks <-chan client.BlockEvents,
+	errs <-chan error,
+	release context.CancelFunc,
+) *Stream[T] {
+	streamCtx, cancel := context.WithCancel(ctx)
+
+	ch := make(chan Event[T])
+	s := &Stream[T]{
+		C:         ch,
+		eventType: eventType,
+		ch:        ch,
+		cancel: func() {
+			cancel()
+			release()
+		},
+	}
+
+	go s.run(ctx, streamCtx, blocks, errs)
+
+	return s
+}
+
+// An EventSubscriber subscribes to the events of sealed blocks.
+//
+// It is satisfied by *client.Client.
+type EventSubscriber interface {
+	SubscribeEventsByBlockHeight(
+		ctx context.Context,
+		startHeight uint64,
+		filter flow.EventFilter,
+		opts ...grpc.CallOption,
+	) (<-chan client.BlockEvents, <-chan error, error)
+}
+
+// Subscribe streams the events of the given type, decoded into values of type T,
+// starting at the given block height.
+//
+// Closing the stream ends the underlying subscription.
+func Subscribe[T any](ctx context.Context, c EventSubscriber, eventType string, startHeight uint64) (*Stream[T], error) {
+	subCtx, cancel := context.WithCancel(ctx)
+
+	blocks, errs, err := c.SubscribeEventsByBlockHeight(subCtx, startHeight, flow.EventFilter{EventTypes: []string{eventType}})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return newStream[T](ctx, eventType, blocks, errs, cancel), nil
+}
+
+// Close stops the stream. A stream that is closed ends without an error.
+func (s *Stream[T]) Close() {
+	s.closeOnce.Do(s.cancel)
+}
+
+// Err returns the error that ended the stream, if any.
+func (s *Stream[T]) Err() error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.err
+}
+
+func (s *Stream[T]) setErr(err error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.err = err
+}
+
+func (s *Stream[T]) run(parent, ctx context.Context, blocks <-chan client.BlockEvents, errs <-chan error) {
+	defer close(s.ch)
+	defer s.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.setErr(parent.Err())
+			return
+
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			s.setErr(err)
+			return
+
+		case block, ok := <-blocks:
+			if !ok {
+				if errs != nil {
+					if err, ok := <-errs; ok {
+						s.setErr(err)
+					}
+				}
+				return
+			}
+
+			err := s.deliver(ctx, block)
+			if err != nil {
+				if ctx.Err() != nil {
+					err = parent.Err()
+				}
+				s.setErr(err)
+				return
+			}
+		}
+	}
+}
+
+func (s *Stream[T]) deliver(ctx context.Context, block client.BlockEvents) error {
+	for _, event := range block.Events {
+		if event.Type != s.eventType {
+			continue
+		}
+
+		value, err := Decode[T](event.Value)
+		if err != nil {
+			return flowerrors.Errorf(
+				flowerrors.ErrDecoding,
+				"flowx: failed to decode event %d of transaction %s: %w",
+				event.EventIndex,
+				event.TransactionID,
+				err,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case s.ch <- Event[T]{
+			Value:       value,
+			Event:       event,
+			BlockID:     block.BlockID,
+			BlockHeight: block.Height,
+		}:
+		}
+	}
+
+	return nil
+}
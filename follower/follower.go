@@ -0,0 +1,423 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package follower walks the Flow chain block by block.
+//
+// A Follower delivers every block from a start height to a handler, strictly in height
+// order and without gaps, and keeps up with the chain as new blocks are sealed (or
+// finalized). It is the core loop of services that index or react to chain data.
+package follower
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/logging"
+)
+
+// A Client is the subset of the Flow Access API client used by a Follower.
+//
+// It is satisfied by *client.Client.
+type Client interface {
+	GetLatestBlockHeader(ctx context.Context, isSealed bool, opts ...grpc.CallOption) (*flow.BlockHeader, error)
+	GetBlockByHeight(ctx context.Context, height uint64, opts ...grpc.CallOption) (*flow.Block, error)
+}
+
+// DefaultPollInterval is the default time between two polls of the chain heads.
+const DefaultPollInterval = time.Second
+
+// A Mode selects the head a Follower follows.
+type Mode int
+
+const (
+	// Sealed follows the latest sealed block. Blocks are delivered once their
+	// execution results have been verified.
+	Sealed Mode = iota
+	// Finalized follows the latest finalized block. Blocks are delivered as soon as they
+	// are final, but their execution results may not be available yet.
+	Finalized
+)
+
+func (m Mode) String() string {
+	switch m {
+	case Sealed:
+		return "sealed"
+	case Finalized:
+		return "finalized"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// Heads are the latest finalized and sealed block headers known to the access node.
+type Heads struct {
+	Finalized flow.BlockHeader
+	Sealed    flow.BlockHeader
+}
+
+// A Gap is a range of heights below the followed head that could not be fetched in order.
+type Gap struct {
+	// From is the first missing height.
+	From uint64
+	// To is the height of the followed head when the gap was detected.
+	To uint64
+	// Err is the error that revealed the gap.
+	Err error
+}
+
+// Progress describes how far a follower is behind the followed head.
+type Progress struct {
+	// Height is the height of the last delivered block.
+	Height uint64
+	// Head is the height of the followed head.
+	Head uint64
+	// BlocksDelivered is the number of blocks delivered since the follower was created.
+	BlocksDelivered uint64
+	// Rate is the number of blocks delivered per second since the follower
+	// started catching up with the head.
+	Rate float64
+}
+
+// Behind returns the number of blocks left to deliver to reach the head.
+func (p Progress) Behind() uint64 {
+	if p.Height >= p.Head {
+		return 0
+	}
+	return p.Head - p.Height
+}
+
+// ETA estimates the time until the follower reaches the head at the current
+// rate. It returns zero if the follower has reached the head or the rate is
+// unknown.
+func (p Progress) ETA() time.Duration {
+	if p.Rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(p.Behind()) / p.Rate * float64(time.Second))
+}
+
+// ErrDiscontinuity is returned when a block does not reference the previously delivered
+// block as its parent, even after refetching it.
+var ErrDiscontinuity = errors.New("follower: block does not extend the previously delivered block")
+
+// A BlockHandler processes a block.
+//
+// Handlers are called once per height, in increasing height order. Returning an error
+// stops the follower.
+type BlockHandler func(ctx context.Context, block *flow.Block) error
+
+// Hooks are optional callbacks invoked while following the chain.
+type Hooks struct {
+	// OnHeads is called after every poll of the chain heads.
+	OnHeads func(heads Heads)
+	// OnGap is called when a gap is detected. The missing heights are refetched
+	// before any later block is delivered.
+	OnGap func(gap Gap)
+	// OnCaughtUp is called when the follower has delivered new blocks up to the followed head.
+	OnCaughtUp func(height uint64)
+	// OnProgress is called after every delivered block, e.g. to render how far the
+	// follower is behind the head.
+	OnProgress func(progress Progress)
+}
+
+// A Follower walks the chain block by block.
+type Follower struct {
+	client       Client
+	handler      BlockHandler
+	mode         Mode
+	interval     time.Duration
+	hooks        Hooks
+	maxRefetches int
+	logger       logging.Logger
+
+	mut        sync.Mutex
+	nextHeight uint64
+	previous   *flow.BlockHeader
+	heads      Heads
+	delivered  uint64
+}
+
+// An Option configures a Follower.
+type Option func(*Follower)
+
+// WithMode selects the head the follower follows. The default is Sealed.
+func WithMode(mode Mode) Option {
+	return func(f *Follower) {
+		f.mode = mode
+	}
+}
+
+// WithPollInterval sets the time between two polls of the chain heads.
+func WithPollInterval(interval time.Duration) Option {
+	return func(f *Follower) {
+		f.interval = interval
+	}
+}
+
+// WithHooks sets the hooks invoked while following the chain.
+func WithHooks(hooks Hooks) Option {
+	return func(f *Follower) {
+		f.hooks = hooks
+	}
+}
+
+// WithMaxRefetches sets how many times a block that does not extend the previous block
+// is refetched before the follower stops with ErrDiscontinuity.
+func WithMaxRefetches(n int) Option {
+	return func(f *Follower) {
+		f.maxRefetches = n
+	}
+}
+
+// WithLogger sets the logger the follower reports progress, gaps and transient
+// errors to.
+func WithLogger(logger logging.Logger) Option {
+	return func(f *Follower) {
+		f.logger = logging.OrNop(logger)
+	}
+}
+
+// New creates a follower that delivers blocks to the handler, starting at the given height.
+func New(client Client, startHeight uint64, handler BlockHandler, opts ...Option) *Follower {
+	f := &Follower{
+		client:       client,
+		handler:      handler,
+		mode:         Sealed,
+		interval:     DefaultPollInterval,
+		maxRefetches: 3,
+		nextHeight:   startHeight,
+		logger:       logging.Nop(),
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// NextHeight returns the height of the next block to be delivered.
+//
+// It can be persisted to resume following after a restart.
+func (f *Follower) NextHeight() uint64 {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	return f.nextHeight
+}
+
+// Heads returns the chain heads observed by the last poll.
+func (f *Follower) Heads() Heads {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	return f.heads
+}
+
+// Run follows the chain until the context is cancelled or the handler returns an error.
+func (f *Follower) Run(ctx context.Context) error {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		head, err := f.pollHeads(ctx)
+		if err != nil && !isTransient(err) {
+			return err
+		}
+
+		if err != nil {
+			f.logger.Log(logging.WarnLevel, "failed to poll chain heads, retrying", logging.Error(err))
+		}
+
+		if err == nil {
+			err = f.catchUp(ctx, head)
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollHeads fetches the chain heads and returns the height of the followed head.
+func (f *Follower) pollHeads(ctx context.Context) (uint64, error) {
+	sealed, err := f.client.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+
+	finalized, err := f.client.GetLatestBlockHeader(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+
+	heads := Heads{
+		Finalized: *finalized,
+		Sealed:    *sealed,
+	}
+
+	f.mut.Lock()
+	f.heads = heads
+	f.mut.Unlock()
+
+	f.logger.Log(logging.DebugLevel, "polled chain heads",
+		logging.Uint64("sealed_height", sealed.Height),
+		logging.Uint64("finalized_height", finalized.Height),
+	)
+
+	if f.hooks.OnHeads != nil {
+		f.hooks.OnHeads(heads)
+	}
+
+	if f.mode == Finalized {
+		return finalized.Height, nil
+	}
+
+	return sealed.Height, nil
+}
+
+// catchUp delivers all blocks up to the given head height.
+//
+// A block that cannot be fetched is reported as a gap and refetched on the next poll,
+// so blocks are never delivered out of order.
+func (f *Follower) catchUp(ctx context.Context, head uint64) error {
+	if f.NextHeight() > head {
+		return nil
+	}
+
+	started := time.Now()
+	var caughtUp uint64
+
+	for f.NextHeight() <= head {
+		next := f.NextHeight()
+
+		block, err := f.fetch(ctx, next)
+		if err != nil {
+			if isTransient(err) || isNotFound(err) {
+				f.reportGap(Gap{From: next, To: head, Err: err})
+				return nil
+			}
+			return err
+		}
+
+		err = f.handler(ctx, block)
+		if err != nil {
+			return err
+		}
+
+		f.mut.Lock()
+		f.previous = &block.BlockHeader
+		f.nextHeight = next + 1
+		f.delivered++
+		delivered := f.delivered
+		f.mut.Unlock()
+
+		caughtUp++
+
+		if f.hooks.OnProgress != nil {
+			f.hooks.OnProgress(Progress{
+				Height:          next,
+				Head:            head,
+				BlocksDelivered: delivered,
+				Rate:            float64(caughtUp) / time.Since(started).Seconds(),
+			})
+		}
+	}
+
+	f.logger.Log(logging.DebugLevel, "caught up with chain head", logging.Height(head))
+
+	if f.hooks.OnCaughtUp != nil {
+		f.hooks.OnCaughtUp(head)
+	}
+
+	return nil
+}
+
+// fetch fetches the block at the given height and checks that it extends the
+// previously delivered block, refetching it if it does not.
+func (f *Follower) fetch(ctx context.Context, height uint64) (*flow.Block, error) {
+	f.mut.Lock()
+	previous := f.previous
+	f.mut.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		block, err := f.client.GetBlockByHeight(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+
+		if block.Height != height {
+			err = fmt.Errorf("follower: requested block at height %d, got height %d", height, block.Height)
+		} else if previous != nil && block.ParentID != previous.ID {
+			err = fmt.Errorf("%w: block %s at height %d has parent %s, expected %s",
+				ErrDiscontinuity, block.ID, height, block.ParentID, previous.ID)
+		}
+
+		if err == nil {
+			return block, nil
+		}
+
+		if attempt >= f.maxRefetches {
+			return nil, err
+		}
+
+		f.reportGap(Gap{From: height, To: height, Err: err})
+	}
+}
+
+func (f *Follower) reportGap(gap Gap) {
+	f.logger.Log(logging.WarnLevel, "gap in followed chain, refetching",
+		logging.Uint64("from_height", gap.From),
+		logging.Uint64("to_height", gap.To),
+		logging.Error(gap.Err),
+	)
+
+	if f.hooks.OnGap != nil {
+		f.hooks.OnGap(gap)
+	}
+}
+
+// isTransient returns true if the error is likely to succeed when retried.
+func isTransient(err error) bool {
+	return flowerrors.IsTransient(err)
+}
+
+// isNotFound returns true if the access node does not know the requested block yet.
+func isNotFound(err error) bool {
+	return grpcCode(err) == codes.NotFound
+}
+
+func grpcCode(err error) codes.Code {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return codes.Unknown
+	}
+	return grpcErr.GRPCStatus().Code()
+}
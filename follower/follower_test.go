@@ -0,0 +1,232 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package follower_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/follower"
+)
+
+// fakeChain serves a linear chain in which the block at height h has ID h.
+type fakeChain struct {
+	mut       sync.Mutex
+	sealed    uint64
+	finalized uint64
+	// missing heights return NotFound the given number of times
+	missing map[uint64]int
+	// forked heights return a block with the wrong parent the given number of times
+	forked map[uint64]int
+	polls  int
+	onPoll func(polls int)
+}
+
+func idAt(height uint64) flow.Identifier {
+	var id flow.Identifier
+	binary.BigEndian.PutUint64(id[:], height)
+	return id
+}
+
+func headerAt(height uint64) flow.BlockHeader {
+	return flow.BlockHeader{
+		ID:       idAt(height),
+		ParentID: idAt(height - 1),
+		Height:   height,
+	}
+}
+
+func (c *fakeChain) GetLatestBlockHeader(_ context.Context, isSealed bool, _ ...grpc.CallOption) (*flow.BlockHeader, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	header := headerAt(c.finalized)
+	if isSealed {
+		header = headerAt(c.sealed)
+
+		c.polls++
+		if c.onPoll != nil {
+			c.onPoll(c.polls)
+		}
+	}
+
+	return &header, nil
+}
+
+func (c *fakeChain) GetBlockByHeight(_ context.Context, height uint64, _ ...grpc.CallOption) (*flow.Block, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.missing[height] > 0 {
+		c.missing[height]--
+		return nil, status.Error(codes.NotFound, "block not found")
+	}
+
+	header := headerAt(height)
+	if c.forked[height] > 0 {
+		c.forked[height]--
+		header.ParentID = flow.HexToID("ff")
+	}
+
+	return &flow.Block{BlockHeader: header}, nil
+}
+
+// follow runs a follower until it has polled the chain the given number of times.
+func follow(t *testing.T, c *fakeChain, polls int, opts ...follower.Option) ([]uint64, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c.onPoll = func(n int) {
+		if n > polls {
+			cancel()
+		}
+	}
+
+	var heights []uint64
+	f := follower.New(c, 1, func(_ context.Context, block *flow.Block) error {
+		heights = append(heights, block.Height)
+		return nil
+	}, append([]follower.Option{follower.WithPollInterval(time.Millisecond)}, opts...)...)
+
+	err := f.Run(ctx)
+
+	return heights, err
+}
+
+func TestFollower(t *testing.T) {
+	t.Run("Follows sealed head", func(t *testing.T) {
+		c := &fakeChain{sealed: 5, finalized: 8}
+
+		var heads follower.Heads
+		var caughtUp []uint64
+
+		heights, err := follow(t, c, 1, follower.WithHooks(follower.Hooks{
+			OnHeads:    func(h follower.Heads) { heads = h },
+			OnCaughtUp: func(height uint64) { caughtUp = append(caughtUp, height) },
+		}))
+		assert.Equal(t, context.Canceled, err)
+
+		assert.Equal(t, []uint64{1, 2, 3, 4, 5}, heights)
+		assert.Equal(t, uint64(5), heads.Sealed.Height)
+		assert.Equal(t, uint64(8), heads.Finalized.Height)
+		assert.Equal(t, []uint64{5}, caughtUp)
+	})
+
+	t.Run("Reports progress", func(t *testing.T) {
+		c := &fakeChain{sealed: 3, finalized: 3}
+
+		var progress []follower.Progress
+		_, err := follow(t, c, 1, follower.WithHooks(follower.Hooks{
+			OnProgress: func(p follower.Progress) { progress = append(progress, p) },
+		}))
+		assert.Equal(t, context.Canceled, err)
+
+		require.Len(t, progress, 3)
+		assert.Equal(t, uint64(1), progress[0].Height)
+		assert.Equal(t, uint64(3), progress[0].Head)
+		assert.Equal(t, uint64(2), progress[0].Behind())
+		assert.Positive(t, progress[0].Rate)
+		assert.Equal(t, uint64(3), progress[2].BlocksDelivered)
+		assert.Zero(t, progress[2].ETA())
+	})
+
+	t.Run("Follows finalized head", func(t *testing.T) {
+		c := &fakeChain{sealed: 5, finalized: 8}
+
+		heights, err := follow(t, c, 1, follower.WithMode(follower.Finalized))
+		assert.Equal(t, context.Canceled, err)
+
+		assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8}, heights)
+	})
+
+	t.Run("Refetches gaps", func(t *testing.T) {
+		c := &fakeChain{sealed: 5, finalized: 5, missing: map[uint64]int{3: 1}}
+
+		var gaps []follower.Gap
+		heights, err := follow(t, c, 2, follower.WithHooks(follower.Hooks{
+			OnGap: func(gap follower.Gap) { gaps = append(gaps, gap) },
+		}))
+		assert.Equal(t, context.Canceled, err)
+
+		assert.Equal(t, []uint64{1, 2, 3, 4, 5}, heights)
+		require.Len(t, gaps, 1)
+		assert.Equal(t, uint64(3), gaps[0].From)
+		assert.Equal(t, uint64(5), gaps[0].To)
+	})
+
+	t.Run("Refetches discontinuous blocks", func(t *testing.T) {
+		c := &fakeChain{sealed: 5, finalized: 5, forked: map[uint64]int{4: 2}}
+
+		var gaps []follower.Gap
+		heights, err := follow(t, c, 1, follower.WithHooks(follower.Hooks{
+			OnGap: func(gap follower.Gap) { gaps = append(gaps, gap) },
+		}))
+		assert.Equal(t, context.Canceled, err)
+
+		assert.Equal(t, []uint64{1, 2, 3, 4, 5}, heights)
+		require.Len(t, gaps, 2)
+		assert.True(t, errors.Is(gaps[0].Err, follower.ErrDiscontinuity))
+	})
+
+	t.Run("Stops on persistent discontinuity", func(t *testing.T) {
+		c := &fakeChain{sealed: 5, finalized: 5, forked: map[uint64]int{4: 10}}
+
+		heights, err := follow(t, c, 1, follower.WithMaxRefetches(2))
+		assert.True(t, errors.Is(err, follower.ErrDiscontinuity))
+
+		assert.Equal(t, []uint64{1, 2, 3}, heights)
+	})
+
+	t.Run("Handler error", func(t *testing.T) {
+		c := &fakeChain{sealed: 5, finalized: 5}
+		handlerErr := errors.New("handler failed")
+
+		f := follower.New(c, 1, func(_ context.Context, block *flow.Block) error {
+			if block.Height == 2 {
+				return handlerErr
+			}
+			return nil
+		})
+
+		err := f.Run(context.Background())
+		assert.Equal(t, handlerErr, err)
+		assert.Equal(t, uint64(2), f.NextHeight())
+	})
+}
+
+func TestProgress_ETA(t *testing.T) {
+	p := follower.Progress{Height: 10, Head: 30, Rate: 4}
+
+	assert.Equal(t, uint64(20), p.Behind())
+	assert.Equal(t, 5*time.Second, p.ETA())
+
+	p.Rate = 0
+	assert.Zero(t, p.ETA())
+}
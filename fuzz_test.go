@@ -0,0 +1,129 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func FuzzDecodeTransaction(f *testing.F) {
+	tx := test.TransactionGenerator().New()
+
+	f.Add(tx.Encode())
+	f.Add(tx.PayloadMessage())
+	f.Add(tx.EnvelopeMessage())
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		decoded, err := flow.DecodeTransaction(b)
+		if err != nil {
+			return
+		}
+
+		// a decoded transaction can always be encoded again
+		_ = decoded.Encode()
+		_ = decoded.ID()
+	})
+}
+
+func FuzzDecodeAccountKey(f *testing.F) {
+	f.Add(test.AccountKeyGenerator().New().Encode())
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		key, err := flow.DecodeAccountKey(b)
+		if err != nil {
+			return
+		}
+
+		_ = key.Encode()
+	})
+}
+
+func FuzzParseAddress(f *testing.F) {
+	f.Add("0xf8d6e0586b0a20c7")
+	f.Add("f8d6e0586b0a20c7")
+	f.Add("1")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		// HexToAddress accepts any input
+		_ = flow.HexToAddress(s)
+
+		address, err := flow.ParseAddress(s)
+		if err != nil {
+			return
+		}
+
+		roundTrip, err := flow.ParseAddress(address.Hex())
+		if err != nil {
+			t.Fatalf("failed to parse hex of parsed address %s: %s", address, err)
+		}
+
+		if roundTrip != address {
+			t.Fatalf("address changed in round trip: %s != %s", roundTrip, address)
+		}
+	})
+}
+
+func FuzzDecodeSignerIndices(f *testing.F) {
+	ids := test.IdentifierGenerator()
+	committee := []flow.Identifier{ids.New(), ids.New(), ids.New()}
+
+	indices, err := flow.EncodeSignerIndices(committee, committee[1:])
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(indices)
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		signers, err := flow.DecodeSignerIndices(committee, b)
+		if err != nil {
+			return
+		}
+
+		encoded, err := flow.EncodeSignerIndices(committee, signers)
+		if err != nil {
+			t.Fatalf("failed to encode decoded signers: %s", err)
+		}
+
+		if !bytes.Equal(encoded, b) {
+			t.Fatalf("signer indices changed in round trip: %x != %x", encoded, b)
+		}
+	})
+}
+
+func FuzzDecodeServiceEvent(f *testing.F) {
+	f.Add(flow.ServiceEventEpochSetup, []byte(`{"Counter": 1, "Participants": [{"NodeID": "01"}]}`))
+	f.Add(flow.ServiceEventEpochCommit, []byte(`{"ClusterQCs": [{"VoterIDs": ["01"]}]}`))
+	f.Add(flow.ServiceEventVersionBeacon, []byte(`{"VersionBoundaries": [{"BlockHeight": 1, "Version": "1.0.0"}]}`))
+
+	f.Fuzz(func(t *testing.T, eventType string, payload []byte) {
+		_, _ = flow.ServiceEvent{Type: eventType, Payload: payload}.Decode()
+	})
+}
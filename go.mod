@@ -1,17 +1,19 @@
 module github.com/onflow/flow-go-sdk
 
-go 1.13
+go 1.16
 
 require (
-	cloud.google.com/go v0.65.0
+	github.com/btcsuite/btcd v0.20.1-beta
 	github.com/ethereum/go-ethereum v1.9.9
+	github.com/golang/protobuf v1.5.2
+	github.com/mattn/go-sqlite3 v1.14.16
 	github.com/onflow/cadence v0.18.0
 	github.com/onflow/flow-go/crypto v0.12.0
-	github.com/onflow/flow/protobuf/go/flow v0.2.2
-	github.com/pkg/errors v0.8.1
-	github.com/stretchr/testify v1.7.0
-	google.golang.org/api v0.31.0
-	google.golang.org/genproto v0.0.0-20200831141814-d751682dd103
-	google.golang.org/grpc v1.39.0
-	google.golang.org/protobuf v1.27.1
+	github.com/onflow/flow/protobuf/go/flow v0.4.20
+	github.com/stretchr/testify v1.8.1
+	go.uber.org/goleak v1.1.11 // indirect
+	golang.org/x/crypto v0.0.0-20210921155107-089bfa567519
+	golang.org/x/net v0.4.0
+	google.golang.org/grpc v1.51.0
+	google.golang.org/protobuf v1.28.1
 )
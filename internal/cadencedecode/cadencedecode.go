@@ -0,0 +1,211 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cadencedecode converts Cadence values into Go values.
+//
+// It backs the decoders of the events package and the script results of the
+// query packages.
+package cadencedecode
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// FieldTag is the struct tag that maps a Go struct field to a Cadence field.
+const FieldTag = "cadence"
+
+var (
+	addressType = reflect.TypeOf(flow.Address{})
+	bigIntType  = reflect.TypeOf((*big.Int)(nil))
+)
+
+// Decode converts a Cadence value into the Go value pointed to by target, as
+// described for Assign.
+func Decode(value cadence.Value, target interface{}) error {
+	ptr := reflect.ValueOf(target)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "decode target must be a non-nil pointer, got %T", target)
+	}
+
+	if value == nil {
+		return fmt.Errorf("cannot decode a nil value into %T", target)
+	}
+
+	return Assign(ptr.Elem(), value)
+}
+
+// assign converts a Cadence value into dst.
+func Assign(dst reflect.Value, value cadence.Value) error {
+	if optional, ok := value.(cadence.Optional); ok {
+		if optional.Value == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+
+		if dst.Kind() == reflect.Ptr && dst.Type() != bigIntType {
+			elem := reflect.New(dst.Type().Elem())
+			err := Assign(elem.Elem(), optional.Value)
+			if err != nil {
+				return err
+			}
+			dst.Set(elem)
+			return nil
+		}
+
+		return Assign(dst, optional.Value)
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Type().AssignableTo(dst.Type()) {
+		dst.Set(v)
+		return nil
+	}
+
+	switch value := value.(type) {
+	case cadence.Address:
+		if dst.Type() == addressType {
+			dst.Set(reflect.ValueOf(flow.BytesToAddress(value.Bytes())))
+			return nil
+		}
+
+	case cadence.Array:
+		if dst.Kind() == reflect.Slice {
+			slice := reflect.MakeSlice(dst.Type(), len(value.Values), len(value.Values))
+			for i, elem := range value.Values {
+				err := Assign(slice.Index(i), elem)
+				if err != nil {
+					return fmt.Errorf("index %d: %w", i, err)
+				}
+			}
+			dst.Set(slice)
+			return nil
+		}
+
+	case cadence.Dictionary:
+		if dst.Kind() == reflect.Map {
+			m := reflect.MakeMapWithSize(dst.Type(), len(value.Pairs))
+			for _, pair := range value.Pairs {
+				k := reflect.New(dst.Type().Key()).Elem()
+				err := Assign(k, pair.Key)
+				if err != nil {
+					return fmt.Errorf("key %s: %w", pair.Key, err)
+				}
+
+				v := reflect.New(dst.Type().Elem()).Elem()
+				err = Assign(v, pair.Value)
+				if err != nil {
+					return fmt.Errorf("value for key %s: %w", pair.Key, err)
+				}
+
+				m.SetMapIndex(k, v)
+			}
+			dst.Set(m)
+			return nil
+		}
+	}
+
+	if dst.Kind() == reflect.Struct {
+		if fields, ok := compositeFields(value); ok {
+			return assignFields(dst, fields)
+		}
+	}
+
+	goValue := value.ToGoValue()
+	if goValue != nil {
+		gv := reflect.ValueOf(goValue)
+		if gv.Type().AssignableTo(dst.Type()) {
+			dst.Set(gv)
+			return nil
+		}
+
+		if gv.Kind() == dst.Kind() && gv.Type().ConvertibleTo(dst.Type()) {
+			dst.Set(gv.Convert(dst.Type()))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cannot decode %T into %s", value, dst.Type())
+}
+
+// compositeFields returns the fields of a struct, resource or event value by name.
+func compositeFields(value cadence.Value) (map[string]cadence.Value, bool) {
+	var (
+		types  []cadence.Field
+		values []cadence.Value
+	)
+
+	switch value := value.(type) {
+	case cadence.Struct:
+		if value.StructType == nil {
+			return nil, false
+		}
+		types, values = value.StructType.Fields, value.Fields
+	case cadence.Resource:
+		if value.ResourceType == nil {
+			return nil, false
+		}
+		types, values = value.ResourceType.Fields, value.Fields
+	case cadence.Event:
+		if value.EventType == nil {
+			return nil, false
+		}
+		types, values = value.EventType.Fields, value.Fields
+	default:
+		return nil, false
+	}
+
+	fields := make(map[string]cadence.Value, len(values))
+	for i, field := range types {
+		if i < len(values) {
+			fields[field.Identifier] = values[i]
+		}
+	}
+
+	return fields, true
+}
+
+// assignFields sets the tagged fields of the struct dst from the composite fields.
+func assignFields(dst reflect.Value, fields map[string]cadence.Value) error {
+	dstType := dst.Type()
+
+	for i := 0; i < dstType.NumField(); i++ {
+		name, ok := dstType.Field(i).Tag.Lookup(FieldTag)
+		if !ok || name == "" || name == "-" {
+			continue
+		}
+
+		value, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("no field %s", name)
+		}
+
+		err := Assign(dst.Field(i), value)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package protov2 adapts the Flow protobuf messages to google.golang.org/protobuf.
+//
+// The Flow messages are generated with the legacy API of github.com/golang/protobuf,
+// so they are wrapped before being encoded or inspected with the current API.
+package protov2
+
+import (
+	protov1 "github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// Message returns m as a message of google.golang.org/protobuf.
+func Message(m protoiface.MessageV1) proto.Message {
+	return protov1.MessageV2(m)
+}
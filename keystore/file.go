@@ -0,0 +1,350 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keystore
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// DefaultScryptN is the default scrypt cost parameter used to derive the
+// encryption key of a file from the passphrase.
+const DefaultScryptN = 1 << 15
+
+const (
+	fileExt     = ".json"
+	scryptR     = 8
+	scryptP     = 1
+	derivedSize = 32
+	saltSize    = 16
+)
+
+// fileRecord is the content of a key file.
+type fileRecord struct {
+	record
+	Crypto *fileCrypto `json:"crypto,omitempty"`
+}
+
+// fileCrypto is an encrypted private key. The key is encrypted with
+// AES-256-GCM under a key derived from the passphrase with scrypt. The
+// metadata of the key is authenticated as additional data.
+type fileCrypto struct {
+	KDF        string `json:"kdf"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// A FileStore is a keystore that stores each key in a JSON file in a
+// directory, with private keys encrypted under a passphrase.
+//
+// Keys held by Cloud KMS are stored as unencrypted references. A FileStore is
+// safe for concurrent use within a process.
+type FileStore struct {
+	dir        string
+	passphrase []byte
+	scryptN    int
+
+	mut sync.RWMutex
+}
+
+var _ Keystore = (*FileStore)(nil)
+
+// A FileOption configures a FileStore.
+type FileOption func(*FileStore)
+
+// WithScryptN sets the scrypt cost parameter used to encrypt private keys.
+// It must be a power of two greater than 1. The default is DefaultScryptN.
+//
+// Files encrypted with any cost can be decrypted, as the cost is stored with the key.
+func WithScryptN(n int) FileOption {
+	return func(s *FileStore) {
+		s.scryptN = n
+	}
+}
+
+// NewFileStore returns a keystore that stores keys in dir, which is created
+// if it does not exist.
+func NewFileStore(dir string, passphrase []byte, opts ...FileOption) (*FileStore, error) {
+	if len(passphrase) == 0 {
+		return nil, flowerrors.New(flowerrors.ErrInvalidArgument, "keystore: passphrase is empty")
+	}
+
+	s := &FileStore{
+		dir:        dir,
+		passphrase: passphrase,
+		scryptN:    DefaultScryptN,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+
+	return s, nil
+}
+
+// Get returns the key with the given index of an account.
+func (s *FileStore) Get(_ context.Context, address flow.Address, index int) (*Key, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	return s.read(s.path(address, index), address, index)
+}
+
+// Put stores a key, replacing any key with the same address and index.
+func (s *FileStore) Put(_ context.Context, key *Key) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	file := fileRecord{record: newRecord(key)}
+
+	if key.PrivateKey != nil {
+		encrypted, err := s.encrypt(file.record, key.PrivateKey.Encode())
+		if err != nil {
+			return err
+		}
+		file.Crypto = encrypted
+	}
+
+	b, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("keystore: %w", err)
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	return writeFileAtomic(s.path(key.Address, key.Index), b)
+}
+
+// List returns the keys of an account, sorted by index.
+func (s *FileStore) List(_ context.Context, address flow.Address) ([]*Key, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	paths, err := filepath.Glob(filepath.Join(s.dir, address.Hex()+"-*"+fileExt))
+	if err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+
+	keys := make([]*Key, 0, len(paths))
+
+	for _, path := range paths {
+		suffix := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), address.Hex()+"-"), fileExt)
+
+		index, err := strconv.Atoi(suffix)
+		if err != nil {
+			// not a key file
+			continue
+		}
+
+		key, err := s.read(path, address, index)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	sortByIndex(keys)
+
+	return keys, nil
+}
+
+// Delete removes the key with the given index of an account.
+func (s *FileStore) Delete(_ context.Context, address flow.Address, index int) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	err := os.Remove(s.path(address, index))
+	if errors.Is(err, os.ErrNotExist) {
+		return notFound(address, index)
+	}
+	if err != nil {
+		return fmt.Errorf("keystore: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileStore) path(address flow.Address, index int) string {
+	return filepath.Join(s.dir, keyName(address, index)+fileExt)
+}
+
+func (s *FileStore) read(path string, address flow.Address, index int) (*Key, error) {
+	b, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, notFound(address, index)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+
+	var file fileRecord
+	if err := json.Unmarshal(b, &file); err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "keystore: failed to decode %s: %w", path, err)
+	}
+
+	var privateKey []byte
+	if file.Crypto != nil {
+		privateKey, err = s.decrypt(file.record, file.Crypto)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key, err := file.key(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.Address != address || key.Index != index {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "keystore: %s contains key %d of account %s", path, key.Index, key.Address)
+	}
+
+	return key, nil
+}
+
+func (s *FileStore) encrypt(metadata record, plaintext []byte) (*fileCrypto, error) {
+	encrypted := &fileCrypto{
+		KDF:  "scrypt",
+		N:    s.scryptN,
+		R:    scryptR,
+		P:    scryptP,
+		Salt: make([]byte, saltSize),
+	}
+
+	if _, err := rand.Read(encrypted.Salt); err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+
+	aead, err := s.cipher(encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(encrypted.Nonce); err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+
+	additionalData, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+
+	encrypted.Ciphertext = aead.Seal(nil, encrypted.Nonce, plaintext, additionalData)
+
+	return encrypted, nil
+}
+
+func (s *FileStore) decrypt(metadata record, encrypted *fileCrypto) ([]byte, error) {
+	if encrypted.KDF != "scrypt" {
+		return nil, flowerrors.Errorf(flowerrors.ErrUnsupported, "keystore: unsupported key derivation function %q", encrypted.KDF)
+	}
+
+	aead, err := s.cipher(encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	additionalData, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+
+	plaintext, err := aead.Open(nil, encrypted.Nonce, encrypted.Ciphertext, additionalData)
+	if err != nil {
+		return nil, flowerrors.New(flowerrors.ErrDecoding, "keystore: failed to decrypt private key: wrong passphrase or corrupted file")
+	}
+
+	return plaintext, nil
+}
+
+func (s *FileStore) cipher(encrypted *fileCrypto) (cipher.AEAD, error) {
+	derived, err := scrypt.Key(s.passphrase, encrypted.Salt, encrypted.N, encrypted.R, encrypted.P, derivedSize)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "keystore: %w", err)
+	}
+
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+
+	if encrypted.Nonce != nil && len(encrypted.Nonce) != aead.NonceSize() {
+		return nil, flowerrors.New(flowerrors.ErrDecoding, "keystore: invalid nonce size")
+	}
+
+	return aead, nil
+}
+
+// writeFileAtomic writes a file readable only by its owner, replacing any
+// existing file without leaving a partially written file behind.
+func writeFileAtomic(path string, b []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("keystore: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("keystore: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("keystore: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("keystore: %w", err)
+	}
+
+	return nil
+}
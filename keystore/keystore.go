@@ -0,0 +1,190 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package keystore stores the keys of Flow accounts.
+//
+// A Keystore saves and retrieves keys by account address and key index. The
+// package provides keystores backed by memory, encrypted files and HashiCorp
+// Vault. A key either holds its private key material or references a key held
+// by Google Cloud KMS, so the same keystore can manage both kinds of keys.
+//
+// A Resolver turns the keys of a keystore into signers, which makes the
+// wallet and signing layers independent of where keys are stored:
+//
+//	store, err := keystore.NewFileStore("keys", passphrase)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	resolver := keystore.NewResolver(store, nil)
+//
+//	account, err := wallet.NewAccount(ctx, flowClient, address, 0, signer,
+//	    wallet.WithSignerResolver(resolver),
+//	)
+package keystore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/wallet"
+)
+
+// A Key is an account key stored in a keystore.
+//
+// Exactly one of PrivateKey and KMSResourceID is set.
+type Key struct {
+	Address  flow.Address
+	Index    int
+	SigAlgo  crypto.SignatureAlgorithm
+	HashAlgo crypto.HashAlgorithm
+	// PrivateKey is the private key, for keys whose material is held by the keystore.
+	PrivateKey crypto.PrivateKey
+	// KMSResourceID is the resource ID of a Google Cloud KMS key version, for
+	// keys held by Cloud KMS. Only the reference is stored in the keystore.
+	KMSResourceID string
+}
+
+// Validate returns an error if the key is incomplete.
+func (k *Key) Validate() error {
+	if k.Address == flow.EmptyAddress {
+		return flowerrors.New(flowerrors.ErrInvalidArgument, "keystore: key has no address")
+	}
+
+	if k.Index < 0 {
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "keystore: invalid key index %d", k.Index)
+	}
+
+	if (k.PrivateKey == nil) == (k.KMSResourceID == "") {
+		return flowerrors.New(flowerrors.ErrInvalidArgument, "keystore: key must have either a private key or a KMS resource ID")
+	}
+
+	if k.PrivateKey != nil && k.PrivateKey.Algorithm() != k.SigAlgo {
+		return flowerrors.Errorf(
+			flowerrors.ErrInvalidArgument,
+			"keystore: private key algorithm %s does not match signature algorithm %s",
+			k.PrivateKey.Algorithm(), k.SigAlgo,
+		)
+	}
+
+	return nil
+}
+
+// A Keystore stores account keys by account address and key index.
+//
+// Implementations return errors wrapping flowerrors.ErrNotFound for keys that
+// do not exist.
+type Keystore interface {
+	// Get returns the key with the given index of an account.
+	Get(ctx context.Context, address flow.Address, index int) (*Key, error)
+	// Put stores a key, replacing any key with the same address and index.
+	Put(ctx context.Context, key *Key) error
+	// List returns the keys of an account, sorted by index.
+	List(ctx context.Context, address flow.Address) ([]*Key, error)
+	// Delete removes the key with the given index of an account.
+	Delete(ctx context.Context, address flow.Address, index int) error
+}
+
+// A SignerFactory creates a signer for a key.
+type SignerFactory func(ctx context.Context, key *Key) (crypto.Signer, error)
+
+// InMemorySigner is a SignerFactory for keys that hold their private key.
+//
+// An error wrapping flowerrors.ErrUnsupported is returned for keys held by
+// Cloud KMS; the SignerFactory of the github.com/onflow/flow-go-sdk/crypto/cloudkms
+// module supports both kinds of keys.
+func InMemorySigner(_ context.Context, key *Key) (crypto.Signer, error) {
+	if key.PrivateKey == nil {
+		return nil, flowerrors.Errorf(
+			flowerrors.ErrUnsupported,
+			"keystore: key %d of account %s is held by Cloud KMS",
+			key.Index, key.Address,
+		)
+	}
+
+	return crypto.NewInMemorySigner(key.PrivateKey, key.HashAlgo), nil
+}
+
+// A Resolver creates signers for the keys of a keystore.
+//
+// It implements wallet.SignerResolver.
+type Resolver struct {
+	store   Keystore
+	signers SignerFactory
+}
+
+var _ wallet.SignerResolver = (*Resolver)(nil)
+
+// NewResolver returns a resolver for the keys of a keystore. If signers is
+// nil, InMemorySigner is used.
+func NewResolver(store Keystore, signers SignerFactory) *Resolver {
+	if signers == nil {
+		signers = InMemorySigner
+	}
+
+	return &Resolver{
+		store:   store,
+		signers: signers,
+	}
+}
+
+// Signer returns a signer for the key with the given index of an account.
+func (r *Resolver) Signer(ctx context.Context, address flow.Address, index int) (crypto.Signer, error) {
+	key, err := r.store.Get(ctx, address, index)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.signers(ctx, key)
+}
+
+// ResolveSigner returns a signer for the key of an account with the lowest index.
+func (r *Resolver) ResolveSigner(ctx context.Context, address flow.Address) (wallet.KeySigner, error) {
+	keys, err := r.store.List(ctx, address)
+	if err != nil {
+		return wallet.KeySigner{}, err
+	}
+
+	if len(keys) == 0 {
+		return wallet.KeySigner{}, flowerrors.Errorf(flowerrors.ErrNotFound, "keystore: no keys for account %s", address)
+	}
+
+	signer, err := r.signers(ctx, keys[0])
+	if err != nil {
+		return wallet.KeySigner{}, err
+	}
+
+	return wallet.KeySigner{
+		Address:  address,
+		KeyIndex: keys[0].Index,
+		Signer:   signer,
+	}, nil
+}
+
+func notFound(address flow.Address, index int) error {
+	return flowerrors.Errorf(flowerrors.ErrNotFound, "keystore: key %d of account %s not found", index, address)
+}
+
+func sortByIndex(keys []*Key) {
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Index < keys[j].Index
+	})
+}
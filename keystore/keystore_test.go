@@ -0,0 +1,334 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keystore_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/keystore"
+)
+
+var (
+	addressA = flow.HexToAddress("f8d6e0586b0a20c7")
+	addressB = flow.HexToAddress("01cf0e2f2f715450")
+)
+
+func newPrivateKey(t *testing.T, seed byte) crypto.PrivateKey {
+	b := make([]byte, crypto.MinSeedLength)
+	for i := range b {
+		b[i] = seed
+	}
+
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, b)
+	require.NoError(t, err)
+
+	return privateKey
+}
+
+func newKey(t *testing.T, address flow.Address, index int) *keystore.Key {
+	return &keystore.Key{
+		Address:    address,
+		Index:      index,
+		SigAlgo:    crypto.ECDSA_P256,
+		HashAlgo:   crypto.SHA3_256,
+		PrivateKey: newPrivateKey(t, byte(index+1)),
+	}
+}
+
+func assertKeyEqual(t *testing.T, expected, actual *keystore.Key) {
+	assert.Equal(t, expected.Address, actual.Address)
+	assert.Equal(t, expected.Index, actual.Index)
+	assert.Equal(t, expected.SigAlgo, actual.SigAlgo)
+	assert.Equal(t, expected.HashAlgo, actual.HashAlgo)
+	assert.Equal(t, expected.KMSResourceID, actual.KMSResourceID)
+
+	if expected.PrivateKey == nil {
+		assert.Nil(t, actual.PrivateKey)
+	} else {
+		require.NotNil(t, actual.PrivateKey)
+		assert.True(t, expected.PrivateKey.Equals(actual.PrivateKey))
+	}
+}
+
+// testKeystore checks the behaviour shared by all keystores.
+func testKeystore(t *testing.T, store keystore.Keystore) {
+	ctx := context.Background()
+
+	_, err := store.Get(ctx, addressA, 0)
+	assert.ErrorIs(t, err, flowerrors.ErrNotFound)
+
+	keys, err := store.List(ctx, addressA)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	key0 := newKey(t, addressA, 0)
+	key2 := newKey(t, addressA, 2)
+	kmsKey := &keystore.Key{
+		Address:       addressA,
+		Index:         10,
+		SigAlgo:       crypto.ECDSA_P256,
+		HashAlgo:      crypto.SHA2_256,
+		KMSResourceID: "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1",
+	}
+	other := newKey(t, addressB, 1)
+
+	for _, key := range []*keystore.Key{key2, kmsKey, key0, other} {
+		require.NoError(t, store.Put(ctx, key))
+	}
+
+	key, err := store.Get(ctx, addressA, 0)
+	require.NoError(t, err)
+	assertKeyEqual(t, key0, key)
+
+	key, err = store.Get(ctx, addressA, 10)
+	require.NoError(t, err)
+	assertKeyEqual(t, kmsKey, key)
+
+	keys, err = store.List(ctx, addressA)
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+	assertKeyEqual(t, key0, keys[0])
+	assertKeyEqual(t, key2, keys[1])
+	assertKeyEqual(t, kmsKey, keys[2])
+
+	// Put replaces the key
+	replacement := newKey(t, addressA, 0)
+	replacement.PrivateKey = newPrivateKey(t, 42)
+	require.NoError(t, store.Put(ctx, replacement))
+
+	key, err = store.Get(ctx, addressA, 0)
+	require.NoError(t, err)
+	assertKeyEqual(t, replacement, key)
+
+	require.NoError(t, store.Delete(ctx, addressA, 2))
+
+	_, err = store.Get(ctx, addressA, 2)
+	assert.ErrorIs(t, err, flowerrors.ErrNotFound)
+
+	err = store.Delete(ctx, addressA, 2)
+	assert.ErrorIs(t, err, flowerrors.ErrNotFound)
+
+	keys, err = store.List(ctx, addressA)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	keys, err = store.List(ctx, addressB)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assertKeyEqual(t, other, keys[0])
+
+	err = store.Put(ctx, &keystore.Key{Address: addressA, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256})
+	assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+}
+
+func TestMemory(t *testing.T) {
+	testKeystore(t, keystore.NewMemory())
+}
+
+func TestFileStore(t *testing.T) {
+	passphrase := []byte("correct horse battery staple")
+
+	t.Run("Keystore", func(t *testing.T) {
+		store, err := keystore.NewFileStore(t.TempDir(), passphrase, keystore.WithScryptN(1<<4))
+		require.NoError(t, err)
+
+		testKeystore(t, store)
+	})
+
+	t.Run("Encrypts private keys", func(t *testing.T) {
+		ctx := context.Background()
+		dir := filepath.Join(t.TempDir(), "keys")
+
+		store, err := keystore.NewFileStore(dir, passphrase, keystore.WithScryptN(1<<4))
+		require.NoError(t, err)
+
+		key := newKey(t, addressA, 0)
+		require.NoError(t, store.Put(ctx, key))
+
+		b, err := ioutil.ReadFile(filepath.Join(dir, "f8d6e0586b0a20c7-0.json"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(b), hex.EncodeToString(key.PrivateKey.Encode()))
+
+		// a store opened with the same passphrase can read the key
+		reopened, err := keystore.NewFileStore(dir, passphrase)
+		require.NoError(t, err)
+
+		read, err := reopened.Get(ctx, addressA, 0)
+		require.NoError(t, err)
+		assertKeyEqual(t, key, read)
+
+		wrong, err := keystore.NewFileStore(dir, []byte("wrong"))
+		require.NoError(t, err)
+
+		_, err = wrong.Get(ctx, addressA, 0)
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+
+		// the metadata is authenticated
+		tampered := strings.Replace(string(b), `"SHA3_256"`, `"SHA2_256"`, 1)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "f8d6e0586b0a20c7-0.json"), []byte(tampered), 0600))
+
+		_, err = store.Get(ctx, addressA, 0)
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+	})
+
+	t.Run("Empty passphrase", func(t *testing.T) {
+		_, err := keystore.NewFileStore(t.TempDir(), nil)
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+	})
+}
+
+// fakeVault implements the subset of the KV version 2 API used by a VaultStore.
+type fakeVault struct {
+	t       *testing.T
+	mut     sync.Mutex
+	secrets map[string]json.RawMessage
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mut.Lock()
+	defer v.mut.Unlock()
+
+	if r.Header.Get("X-Vault-Token") != "token" {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		return
+	}
+
+	const prefix = "/v1/kv/"
+
+	path := strings.TrimPrefix(r.URL.Path, prefix)
+
+	switch {
+	case r.Method == "LIST" && strings.HasPrefix(path, "metadata/"):
+		dir := strings.TrimPrefix(path, "metadata/") + "/"
+
+		var keys []string
+		for name := range v.secrets {
+			if strings.HasPrefix(name, dir) {
+				keys = append(keys, strings.TrimPrefix(name, dir))
+			}
+		}
+		if len(keys) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		sort.Strings(keys)
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "data/"):
+		secret, ok := v.secrets[strings.TrimPrefix(path, "data/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"data": secret}})
+
+	case r.Method == http.MethodPost && strings.HasPrefix(path, "data/"):
+		var request struct {
+			Data json.RawMessage `json:"data"`
+		}
+		require.NoError(v.t, json.NewDecoder(r.Body).Decode(&request))
+
+		v.secrets[strings.TrimPrefix(path, "data/")] = request.Data
+
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "metadata/"):
+		delete(v.secrets, strings.TrimPrefix(path, "metadata/"))
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestVaultStore(t *testing.T) {
+	vault := &fakeVault{t: t, secrets: make(map[string]json.RawMessage)}
+
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	t.Run("Keystore", func(t *testing.T) {
+		store := keystore.NewVaultStore(server.URL, "token", keystore.WithVaultMount("kv"), keystore.WithVaultPrefix("/app/keys/"))
+
+		testKeystore(t, store)
+
+		assert.Contains(t, vault.secrets, "app/keys/f8d6e0586b0a20c7/0")
+	})
+
+	t.Run("Permission denied", func(t *testing.T) {
+		store := keystore.NewVaultStore(server.URL, "wrong", keystore.WithVaultMount("kv"))
+
+		_, err := store.Get(context.Background(), addressA, 0)
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+		assert.Contains(t, err.Error(), "permission denied")
+	})
+}
+
+func TestResolver(t *testing.T) {
+	ctx := context.Background()
+	store := keystore.NewMemory()
+
+	key := newKey(t, addressA, 3)
+	require.NoError(t, store.Put(ctx, key))
+	require.NoError(t, store.Put(ctx, newKey(t, addressA, 5)))
+	require.NoError(t, store.Put(ctx, &keystore.Key{
+		Address:       addressB,
+		SigAlgo:       crypto.ECDSA_P256,
+		HashAlgo:      crypto.SHA2_256,
+		KMSResourceID: "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1",
+	}))
+
+	resolver := keystore.NewResolver(store, nil)
+
+	signer, err := resolver.ResolveSigner(ctx, addressA)
+	require.NoError(t, err)
+	assert.Equal(t, addressA, signer.Address)
+	assert.Equal(t, 3, signer.KeyIndex)
+
+	assert.Equal(t, crypto.NewInMemorySigner(key.PrivateKey, key.HashAlgo), signer.Signer)
+
+	_, err = resolver.Signer(ctx, addressA, 5)
+	assert.NoError(t, err)
+
+	_, err = resolver.Signer(ctx, addressA, 4)
+	assert.ErrorIs(t, err, flowerrors.ErrNotFound)
+
+	_, err = resolver.ResolveSigner(ctx, addressB)
+	assert.ErrorIs(t, err, flowerrors.ErrUnsupported)
+
+	_, err = resolver.ResolveSigner(ctx, flow.HexToAddress("179b6b1cb6755e31"))
+	assert.ErrorIs(t, err, flowerrors.ErrNotFound)
+}
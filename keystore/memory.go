@@ -0,0 +1,111 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keystore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+type keyID struct {
+	address flow.Address
+	index   int
+}
+
+// Memory is a keystore that holds keys in memory.
+//
+// It is intended for tests and short-lived processes. A Memory keystore is
+// safe for concurrent use.
+type Memory struct {
+	mut  sync.RWMutex
+	keys map[keyID]*Key
+}
+
+var _ Keystore = (*Memory)(nil)
+
+// NewMemory returns an empty in-memory keystore.
+func NewMemory() *Memory {
+	return &Memory{
+		keys: make(map[keyID]*Key),
+	}
+}
+
+// Get returns the key with the given index of an account.
+func (m *Memory) Get(_ context.Context, address flow.Address, index int) (*Key, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	key, ok := m.keys[keyID{address, index}]
+	if !ok {
+		return nil, notFound(address, index)
+	}
+
+	copied := *key
+	return &copied, nil
+}
+
+// Put stores a key, replacing any key with the same address and index.
+func (m *Memory) Put(_ context.Context, key *Key) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	copied := *key
+	m.keys[keyID{key.Address, key.Index}] = &copied
+
+	return nil
+}
+
+// List returns the keys of an account, sorted by index.
+func (m *Memory) List(_ context.Context, address flow.Address) ([]*Key, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	var keys []*Key
+	for id, key := range m.keys {
+		if id.address == address {
+			copied := *key
+			keys = append(keys, &copied)
+		}
+	}
+
+	sortByIndex(keys)
+
+	return keys, nil
+}
+
+// Delete removes the key with the given index of an account.
+func (m *Memory) Delete(_ context.Context, address flow.Address, index int) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	id := keyID{address, index}
+	if _, ok := m.keys[id]; !ok {
+		return notFound(address, index)
+	}
+
+	delete(m.keys, id)
+
+	return nil
+}
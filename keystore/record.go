@@ -0,0 +1,117 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keystore
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// record is the serialized form of a key's metadata, shared by the persistent
+// keystores. How the private key is stored depends on the keystore.
+type record struct {
+	Address       string `json:"address"`
+	Index         int    `json:"index"`
+	SigAlgo       string `json:"signatureAlgorithm"`
+	HashAlgo      string `json:"hashAlgorithm"`
+	KMSResourceID string `json:"kmsResourceID,omitempty"`
+}
+
+func newRecord(key *Key) record {
+	return record{
+		Address:       key.Address.Hex(),
+		Index:         key.Index,
+		SigAlgo:       key.SigAlgo.String(),
+		HashAlgo:      key.HashAlgo.String(),
+		KMSResourceID: key.KMSResourceID,
+	}
+}
+
+// key returns the key described by the record, with the given encoded private
+// key. encodedPrivateKey is nil for keys held by Cloud KMS.
+func (r record) key(encodedPrivateKey []byte) (*Key, error) {
+	address, err := flow.ParseAddress(r.Address)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+
+	key := &Key{
+		Address:       address,
+		Index:         r.Index,
+		SigAlgo:       crypto.StringToSignatureAlgorithm(r.SigAlgo),
+		HashAlgo:      crypto.StringToHashAlgorithm(r.HashAlgo),
+		KMSResourceID: r.KMSResourceID,
+	}
+
+	if key.SigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "keystore: unknown signature algorithm %q", r.SigAlgo)
+	}
+
+	if key.HashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "keystore: unknown hash algorithm %q", r.HashAlgo)
+	}
+
+	if encodedPrivateKey != nil {
+		key.PrivateKey, err = crypto.DecodePrivateKey(key.SigAlgo, encodedPrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("keystore: %w", err)
+		}
+	}
+
+	if err := key.Validate(); err != nil {
+		return nil, flowerrors.Wrap(flowerrors.ErrDecoding, err)
+	}
+
+	return key, nil
+}
+
+// keyName returns the name a key is stored under, e.g. "f8d6e0586b0a20c7-0".
+func keyName(address flow.Address, index int) string {
+	return fmt.Sprintf("%s-%d", address.Hex(), index)
+}
+
+// encodePrivateKeyHex returns the hex encoding of a private key, or an empty
+// string if there is none.
+func encodePrivateKeyHex(privateKey crypto.PrivateKey) string {
+	if privateKey == nil {
+		return ""
+	}
+
+	return hex.EncodeToString(privateKey.Encode())
+}
+
+// decodePrivateKeyHex returns the bytes of a hex-encoded private key, or nil
+// if s is empty.
+func decodePrivateKeyHex(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "keystore: invalid private key: %w", err)
+	}
+
+	return b, nil
+}
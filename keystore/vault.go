@@ -0,0 +1,313 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keystore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// Defaults of a VaultStore.
+const (
+	DefaultVaultMount  = "secret"
+	DefaultVaultPrefix = "flow/keys"
+)
+
+// vaultRecord is the secret stored for a key. Vault encrypts secrets at rest,
+// so private keys are stored hex-encoded.
+type vaultRecord struct {
+	record
+	PrivateKey string `json:"privateKey,omitempty"`
+}
+
+// A VaultStore is a keystore backed by the KV version 2 secrets engine of
+// HashiCorp Vault.
+//
+// Each key is stored as a secret at <prefix>/<address>/<index> in the mount,
+// e.g. secret/flow/keys/f8d6e0586b0a20c7/0. The store talks to the Vault HTTP
+// API directly and does not depend on the Vault client library.
+type VaultStore struct {
+	address    string
+	token      string
+	mount      string
+	prefix     string
+	namespace  string
+	httpClient *http.Client
+}
+
+var _ Keystore = (*VaultStore)(nil)
+
+// A VaultOption configures a VaultStore.
+type VaultOption func(*VaultStore)
+
+// WithVaultMount sets the path the KV secrets engine is mounted at. The
+// default is DefaultVaultMount.
+func WithVaultMount(mount string) VaultOption {
+	return func(s *VaultStore) {
+		s.mount = strings.Trim(mount, "/")
+	}
+}
+
+// WithVaultPrefix sets the path under the mount that keys are stored at. The
+// default is DefaultVaultPrefix.
+func WithVaultPrefix(prefix string) VaultOption {
+	return func(s *VaultStore) {
+		s.prefix = strings.Trim(prefix, "/")
+	}
+}
+
+// WithVaultNamespace sets the Vault Enterprise namespace of requests.
+func WithVaultNamespace(namespace string) VaultOption {
+	return func(s *VaultStore) {
+		s.namespace = namespace
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to talk to Vault. The default is
+// http.DefaultClient.
+func WithHTTPClient(client *http.Client) VaultOption {
+	return func(s *VaultStore) {
+		s.httpClient = client
+	}
+}
+
+// NewVaultStore returns a keystore backed by the Vault server at address, e.g.
+// "https://vault.example.com:8200", authenticated with token.
+func NewVaultStore(address, token string, opts ...VaultOption) *VaultStore {
+	s := &VaultStore{
+		address:    strings.TrimSuffix(address, "/"),
+		token:      token,
+		mount:      DefaultVaultMount,
+		prefix:     DefaultVaultPrefix,
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Get returns the key with the given index of an account.
+func (s *VaultStore) Get(ctx context.Context, address flow.Address, index int) (*Key, error) {
+	var response struct {
+		Data struct {
+			Data vaultRecord `json:"data"`
+		} `json:"data"`
+	}
+
+	found, err := s.do(ctx, http.MethodGet, s.url("data", address, index), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, notFound(address, index)
+	}
+
+	secret := response.Data.Data
+
+	privateKey, err := decodePrivateKeyHex(secret.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := secret.key(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.Address != address || key.Index != index {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "keystore: Vault secret of key %d of account %s contains key %d of account %s", index, address, key.Index, key.Address)
+	}
+
+	return key, nil
+}
+
+// Put stores a key, replacing any key with the same address and index.
+func (s *VaultStore) Put(ctx context.Context, key *Key) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	request := struct {
+		Data vaultRecord `json:"data"`
+	}{
+		Data: vaultRecord{
+			record:     newRecord(key),
+			PrivateKey: encodePrivateKeyHex(key.PrivateKey),
+		},
+	}
+
+	_, err := s.do(ctx, http.MethodPost, s.url("data", key.Address, key.Index), request, nil)
+
+	return err
+}
+
+// List returns the keys of an account, sorted by index.
+func (s *VaultStore) List(ctx context.Context, address flow.Address) ([]*Key, error) {
+	var response struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+
+	found, err := s.do(ctx, "LIST", s.url("metadata", address, -1), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+
+	keys := make([]*Key, 0, len(response.Data.Keys))
+
+	for _, name := range response.Data.Keys {
+		index, err := strconv.Atoi(name)
+		if err != nil {
+			// not a key secret, e.g. a nested path
+			continue
+		}
+
+		key, err := s.Get(ctx, address, index)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	sortByIndex(keys)
+
+	return keys, nil
+}
+
+// Delete removes the key with the given index of an account, including all
+// versions of its secret.
+func (s *VaultStore) Delete(ctx context.Context, address flow.Address, index int) error {
+	// Vault reports success when deleting a missing secret
+	if _, err := s.Get(ctx, address, index); err != nil {
+		return err
+	}
+
+	_, err := s.do(ctx, http.MethodDelete, s.url("metadata", address, index), nil, nil)
+
+	return err
+}
+
+// url returns the API URL of a key secret, or of the directory of an
+// account's keys if index is negative.
+func (s *VaultStore) url(kind string, address flow.Address, index int) string {
+	parts := []string{s.address, "v1", s.mount, kind}
+	if s.prefix != "" {
+		parts = append(parts, s.prefix)
+	}
+	parts = append(parts, address.Hex())
+	if index >= 0 {
+		parts = append(parts, strconv.Itoa(index))
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// do sends a request to Vault and decodes the response into result. It
+// returns false if Vault responded with 404 Not Found.
+func (s *VaultStore) do(ctx context.Context, method, url string, body interface{}, result interface{}) (bool, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return false, fmt.Errorf("keystore: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return false, fmt.Errorf("keystore: %w", err)
+	}
+	req = req.WithContext(ctx)
+
+	req.Header.Set("X-Vault-Token", s.token)
+	req.Header.Set("X-Vault-Request", "true")
+	if s.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return false, flowerrors.Errorf(flowerrors.ErrUnavailable, "keystore: Vault request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return false, flowerrors.Errorf(flowerrors.ErrUnavailable, "keystore: failed to read Vault response: %w", err)
+	}
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return false, nil
+	case res.StatusCode >= 300:
+		return false, vaultError(res.StatusCode, b)
+	}
+
+	if result != nil && len(b) > 0 {
+		if err := json.Unmarshal(b, result); err != nil {
+			return false, flowerrors.Errorf(flowerrors.ErrDecoding, "keystore: failed to decode Vault response: %w", err)
+		}
+	}
+
+	return true, nil
+}
+
+// vaultError returns an error for a failed Vault request, including the
+// messages of the response.
+func vaultError(status int, body []byte) error {
+	var response struct {
+		Errors []string `json:"errors"`
+	}
+	_ = json.Unmarshal(body, &response)
+
+	message := http.StatusText(status)
+	if len(response.Errors) > 0 {
+		message = strings.Join(response.Errors, "; ")
+	}
+
+	kind := flowerrors.ErrUnavailable
+	switch status {
+	case http.StatusBadRequest, http.StatusForbidden, http.StatusUnauthorized:
+		kind = flowerrors.ErrInvalidArgument
+	}
+
+	return flowerrors.Errorf(kind, "keystore: Vault responded with status %d: %s", status, message)
+}
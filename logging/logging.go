@@ -0,0 +1,171 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package logging defines the Logger interface accepted by the components of the SDK
+// that log, such as the client, event pollers and chain followers.
+//
+// Components log nothing unless they are given a Logger. Adapters for log/slog, zap
+// and zerolog are provided in the subpackages of this package, and NewTextLogger
+// writes logfmt lines without any dependencies. Fields identifying transactions and
+// blocks use the same keys everywhere, so logs can be correlated across components.
+package logging
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// A Level is the severity of a log entry.
+type Level int
+
+// Levels in increasing order of severity.
+const (
+	DebugLevel Level = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+// String returns the lower case name of the level.
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	default:
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+}
+
+// A Field is a key-value pair attached to a log entry.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Keys of the fields logged by the SDK.
+const (
+	TransactionIDKey = "tx_id"
+	BlockIDKey       = "block_id"
+	HeightKey        = "height"
+	AddressKey       = "address"
+	ErrorKey         = "error"
+)
+
+// Any returns a field with an arbitrary value.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// String returns a field with a string value.
+func String(key string, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Uint64 returns a field with an unsigned integer value.
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Duration returns a field with a duration value.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+// TransactionID returns the field identifying a transaction.
+func TransactionID(id flow.Identifier) Field {
+	return Field{Key: TransactionIDKey, Value: id.String()}
+}
+
+// BlockID returns the field identifying a block.
+func BlockID(id flow.Identifier) Field {
+	return Field{Key: BlockIDKey, Value: id.String()}
+}
+
+// Height returns the field holding a block height.
+func Height(height uint64) Field {
+	return Field{Key: HeightKey, Value: height}
+}
+
+// Address returns the field holding an account address.
+func Address(address flow.Address) Field {
+	return Field{Key: AddressKey, Value: address.Hex()}
+}
+
+// Error returns the field holding an error, or the message "<nil>" for a nil error.
+func Error(err error) Field {
+	if err == nil {
+		return Field{Key: ErrorKey, Value: "<nil>"}
+	}
+
+	return Field{Key: ErrorKey, Value: err.Error()}
+}
+
+// A Logger writes structured, leveled log entries.
+//
+// Implementations decide which levels are written, and must be safe for concurrent use.
+type Logger interface {
+	Log(level Level, msg string, fields ...Field)
+}
+
+// Nop returns a logger that discards all entries.
+func Nop() Logger {
+	return nopLogger{}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Log(Level, string, ...Field) {}
+
+// OrNop returns the logger, or a logger that discards all entries if it is nil.
+func OrNop(logger Logger) Logger {
+	if logger == nil {
+		return Nop()
+	}
+
+	return logger
+}
+
+// With returns a logger that adds fields to every entry logged with it.
+func With(logger Logger, fields ...Field) Logger {
+	if len(fields) == 0 {
+		return logger
+	}
+
+	return withLogger{logger: logger, fields: fields}
+}
+
+type withLogger struct {
+	logger Logger
+	fields []Field
+}
+
+func (l withLogger) Log(level Level, msg string, fields ...Field) {
+	all := make([]Field, 0, len(l.fields)+len(fields))
+	all = append(all, l.fields...)
+	all = append(all, fields...)
+
+	l.logger.Log(level, msg, all...)
+}
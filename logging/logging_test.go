@@ -0,0 +1,103 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/logging"
+)
+
+type entry struct {
+	level  logging.Level
+	msg    string
+	fields []logging.Field
+}
+
+type recorder struct {
+	mut     sync.Mutex
+	entries []entry
+}
+
+func (r *recorder) Log(level logging.Level, msg string, fields ...logging.Field) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.entries = append(r.entries, entry{level: level, msg: msg, fields: fields})
+}
+
+func TestTextLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := logging.NewTextLogger(&buf, logging.InfoLevel)
+
+	logger.Log(logging.DebugLevel, "dropped")
+	logger.Log(logging.InfoLevel, "transaction sealed",
+		logging.TransactionID(flow.HexToID("01")),
+		logging.Height(42),
+		logging.Address(flow.HexToAddress("02")),
+		logging.String("status", ""),
+	)
+	logger.Log(logging.ErrorLevel, "failed", logging.Error(errors.New("key=value")))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	assert.Regexp(t, `^time=\S+ level=info msg="transaction sealed" `+
+		`tx_id=0100000000000000000000000000000000000000000000000000000000000000 `+
+		`height=42 address=0000000000000002 status=""$`, lines[0])
+	assert.Regexp(t, `^time=\S+ level=error msg=failed error="key=value"$`, lines[1])
+}
+
+func TestWith(t *testing.T) {
+	r := &recorder{}
+
+	logger := logging.With(r, logging.String("component", "poller"))
+	logger.Log(logging.WarnLevel, "retrying", logging.Error(nil))
+
+	require.Len(t, r.entries, 1)
+	assert.Equal(t, logging.WarnLevel, r.entries[0].level)
+	assert.Equal(t, []logging.Field{
+		logging.String("component", "poller"),
+		logging.Any(logging.ErrorKey, "<nil>"),
+	}, r.entries[0].fields)
+
+	assert.Equal(t, logging.Logger(r), logging.With(r))
+}
+
+func TestOrNop(t *testing.T) {
+	assert.Equal(t, logging.Nop(), logging.OrNop(nil))
+
+	r := &recorder{}
+	assert.Equal(t, logging.Logger(r), logging.OrNop(r))
+}
+
+func TestLevel_String(t *testing.T) {
+	assert.Equal(t, "debug", logging.DebugLevel.String())
+	assert.Equal(t, "warn", logging.WarnLevel.String())
+	assert.Equal(t, "level(7)", logging.Level(7).String())
+}
@@ -0,0 +1,69 @@
+//go:build go1.21
+// +build go1.21
+
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package slogadapter adapts a log/slog logger to the logging.Logger interface.
+package slogadapter
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/onflow/flow-go-sdk/logging"
+)
+
+// New returns a logging.Logger that writes to an slog logger.
+func New(logger *slog.Logger) logging.Logger {
+	return &adapter{logger: logger}
+}
+
+type adapter struct {
+	logger *slog.Logger
+}
+
+func (a *adapter) Log(level logging.Level, msg string, fields ...logging.Field) {
+	ctx := context.Background()
+	slogLevel := Level(level)
+
+	if !a.logger.Enabled(ctx, slogLevel) {
+		return
+	}
+
+	attrs := make([]slog.Attr, len(fields))
+	for i, field := range fields {
+		attrs[i] = slog.Any(field.Key, field.Value)
+	}
+
+	a.logger.LogAttrs(ctx, slogLevel, msg, attrs...)
+}
+
+// Level returns the slog level corresponding to a logging level.
+func Level(level logging.Level) slog.Level {
+	switch level {
+	case logging.DebugLevel:
+		return slog.LevelDebug
+	case logging.InfoLevel:
+		return slog.LevelInfo
+	case logging.WarnLevel:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
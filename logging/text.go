@@ -0,0 +1,81 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NewTextLogger returns a logger that writes entries at or above a level as logfmt
+// lines to w:
+//
+//	time=2021-01-01T00:00:00Z level=info msg="transaction sealed" tx_id=7b...
+func NewTextLogger(w io.Writer, level Level) Logger {
+	return &textLogger{w: w, level: level, now: time.Now}
+}
+
+type textLogger struct {
+	mut   sync.Mutex
+	w     io.Writer
+	level Level
+	now   func() time.Time
+}
+
+func (l *textLogger) Log(level Level, msg string, fields ...Field) {
+	if level < l.level {
+		return
+	}
+
+	var b strings.Builder
+
+	b.WriteString("time=")
+	b.WriteString(l.now().UTC().Format(time.RFC3339))
+	b.WriteString(" level=")
+	b.WriteString(level.String())
+	b.WriteString(" msg=")
+	b.WriteString(quote(msg))
+
+	for _, field := range fields {
+		b.WriteByte(' ')
+		b.WriteString(field.Key)
+		b.WriteByte('=')
+		b.WriteString(quote(fmt.Sprint(field.Value)))
+	}
+
+	b.WriteByte('\n')
+
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	_, _ = io.WriteString(l.w, b.String())
+}
+
+// quote quotes a logfmt value if it is empty or contains spaces, quotes or '='.
+func quote(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\r\n\"=") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
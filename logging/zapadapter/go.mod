@@ -0,0 +1,11 @@
+module github.com/onflow/flow-go-sdk/logging/zapadapter
+
+go 1.13
+
+require (
+	github.com/onflow/flow-go-sdk v0.21.0
+	github.com/stretchr/testify v1.8.1
+	go.uber.org/zap v1.24.0
+)
+
+replace github.com/onflow/flow-go-sdk => ../..
@@ -0,0 +1,64 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package zapadapter adapts a zap logger to the logging.Logger interface.
+package zapadapter
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/onflow/flow-go-sdk/logging"
+)
+
+// New returns a logging.Logger that writes to a zap logger.
+func New(logger *zap.Logger) logging.Logger {
+	return &adapter{logger: logger}
+}
+
+type adapter struct {
+	logger *zap.Logger
+}
+
+func (a *adapter) Log(level logging.Level, msg string, fields ...logging.Field) {
+	entry := a.logger.Check(Level(level), msg)
+	if entry == nil {
+		return
+	}
+
+	zapFields := make([]zap.Field, len(fields))
+	for i, field := range fields {
+		zapFields[i] = zap.Any(field.Key, field.Value)
+	}
+
+	entry.Write(zapFields...)
+}
+
+// Level returns the zap level corresponding to a logging level.
+func Level(level logging.Level) zapcore.Level {
+	switch level {
+	case logging.DebugLevel:
+		return zapcore.DebugLevel
+	case logging.InfoLevel:
+		return zapcore.InfoLevel
+	case logging.WarnLevel:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
@@ -0,0 +1,48 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zapadapter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/onflow/flow-go-sdk/logging"
+	"github.com/onflow/flow-go-sdk/logging/zapadapter"
+)
+
+func TestAdapter(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	logger := zapadapter.New(zap.New(core))
+
+	logger.Log(logging.DebugLevel, "dropped")
+	logger.Log(logging.WarnLevel, "retrying", logging.Height(42))
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+
+	assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+	assert.Equal(t, "retrying", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{"height": uint64(42)}, entries[0].ContextMap())
+}
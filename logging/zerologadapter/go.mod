@@ -0,0 +1,11 @@
+module github.com/onflow/flow-go-sdk/logging/zerologadapter
+
+go 1.13
+
+require (
+	github.com/onflow/flow-go-sdk v0.21.0
+	github.com/rs/zerolog v1.29.1
+	github.com/stretchr/testify v1.8.1
+)
+
+replace github.com/onflow/flow-go-sdk => ../..
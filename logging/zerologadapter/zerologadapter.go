@@ -0,0 +1,62 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package zerologadapter adapts a zerolog logger to the logging.Logger interface.
+package zerologadapter
+
+import (
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go-sdk/logging"
+)
+
+// New returns a logging.Logger that writes to a zerolog logger.
+func New(logger zerolog.Logger) logging.Logger {
+	return &adapter{logger: logger}
+}
+
+type adapter struct {
+	logger zerolog.Logger
+}
+
+func (a *adapter) Log(level logging.Level, msg string, fields ...logging.Field) {
+	event := a.logger.WithLevel(Level(level))
+	if event == nil {
+		return
+	}
+
+	for _, field := range fields {
+		event = event.Interface(field.Key, field.Value)
+	}
+
+	event.Msg(msg)
+}
+
+// Level returns the zerolog level corresponding to a logging level.
+func Level(level logging.Level) zerolog.Level {
+	switch level {
+	case logging.DebugLevel:
+		return zerolog.DebugLevel
+	case logging.InfoLevel:
+		return zerolog.InfoLevel
+	case logging.WarnLevel:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.ErrorLevel
+	}
+}
@@ -0,0 +1,54 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zerologadapter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/logging"
+	"github.com/onflow/flow-go-sdk/logging/zerologadapter"
+)
+
+func TestAdapter(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := zerologadapter.New(zerolog.New(&buf).Level(zerolog.InfoLevel))
+
+	logger.Log(logging.DebugLevel, "dropped")
+	logger.Log(logging.WarnLevel, "retrying", logging.Height(42))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+
+	assert.Equal(t, map[string]interface{}{
+		"level":   "warn",
+		"message": "retrying",
+		"height":  float64(42),
+	}, entry)
+}
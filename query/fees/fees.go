@@ -0,0 +1,170 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fees estimates transaction fees from the fee parameters of the
+// FlowFees contract.
+//
+// The fee of a transaction is
+//
+//	surgeFactor * (inclusionEffort * inclusionEffortCost + executionEffort * executionEffortCost)
+//
+// computed with the fixed-point arithmetic of UFix64, as FlowFees.computeFees
+// does when the fee is deducted:
+//
+//	c, err := fees.NewClient(flowClient, flow.Mainnet)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	fee, err := c.EstimateFee(ctx, fees.DefaultInclusionEffort, executionEffort)
+package fees
+
+import (
+	"context"
+	"embed"
+	"math/bits"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/query/internal/scripts"
+)
+
+// cadenceScripts holds the Cadence scripts of this package.
+//
+//go:embed cadence/*.cdc
+var cadenceScripts embed.FS
+
+// A ScriptClient executes Cadence scripts.
+//
+// It is satisfied by *client.Client. If the client reports the version of
+// Cadence of its network, the scripts are only run on Cadence 1.0.
+type ScriptClient = scripts.Client
+
+// DefaultInclusionEffort is the inclusion effort of a transaction, 1.0. The
+// network charges the same inclusion effort for every transaction.
+const DefaultInclusionEffort = cadence.UFix64(100_000_000)
+
+// ufix64Factor is the scale of UFix64: the raw value of 1.0.
+const ufix64Factor = 100_000_000
+
+// Parameters are the fee parameters of a network: a FlowFees.FeeParameters value.
+type Parameters struct {
+	// SurgeFactor multiplies the fee of every transaction. It is 1.0 unless
+	// the network is congested.
+	SurgeFactor cadence.UFix64 `cadence:"surgeFactor"`
+	// InclusionEffortCost is the cost of one unit of inclusion effort.
+	InclusionEffortCost cadence.UFix64 `cadence:"inclusionEffortCost"`
+	// ExecutionEffortCost is the cost of one unit of execution effort.
+	ExecutionEffortCost cadence.UFix64 `cadence:"executionEffortCost"`
+}
+
+// Fee returns the fee of a transaction with the given inclusion and execution
+// effort, as reported by the FlowFees.FeesDeducted event of a transaction.
+//
+// An error wrapping flowerrors.ErrInvalidArgument is returned if the fee
+// overflows UFix64.
+func (p *Parameters) Fee(inclusionEffort, executionEffort cadence.UFix64) (cadence.UFix64, error) {
+	inclusion, ok := mul(inclusionEffort, p.InclusionEffortCost)
+	if !ok {
+		return 0, overflow(inclusionEffort, executionEffort)
+	}
+
+	execution, ok := mul(executionEffort, p.ExecutionEffortCost)
+	if !ok {
+		return 0, overflow(inclusionEffort, executionEffort)
+	}
+
+	sum, carry := bits.Add64(uint64(inclusion), uint64(execution), 0)
+	if carry != 0 {
+		return 0, overflow(inclusionEffort, executionEffort)
+	}
+
+	fee, ok := mul(p.SurgeFactor, cadence.UFix64(sum))
+	if !ok {
+		return 0, overflow(inclusionEffort, executionEffort)
+	}
+
+	return fee, nil
+}
+
+// mul multiplies two UFix64 values, truncating the result as Cadence does.
+func mul(a, b cadence.UFix64) (cadence.UFix64, bool) {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	if hi >= ufix64Factor {
+		return 0, false
+	}
+
+	quo, _ := bits.Div64(hi, lo, ufix64Factor)
+
+	return cadence.UFix64(quo), true
+}
+
+func overflow(inclusionEffort, executionEffort cadence.UFix64) error {
+	return flowerrors.Errorf(
+		flowerrors.ErrInvalidArgument,
+		"fees: fee for inclusion effort %s and execution effort %s overflows UFix64",
+		inclusionEffort,
+		executionEffort,
+	)
+}
+
+// A Client reads the fee parameters of a network.
+type Client struct {
+	scripts *scripts.Runner
+}
+
+// NewClient returns a client running scripts with c against the network with
+// the given chain ID.
+func NewClient(c ScriptClient, chainID flow.ChainID) (*Client, error) {
+	r, err := scripts.NewRunner("fees", c, cadenceScripts, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		scripts: r,
+	}, nil
+}
+
+// Parameters returns the current fee parameters of the network, including
+// the surge factor.
+func (c *Client) Parameters(ctx context.Context) (*Parameters, error) {
+	var params Parameters
+	err := c.scripts.Execute(ctx, "get_fee_parameters", &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &params, nil
+}
+
+// EstimateFee returns the fee of a transaction with the given inclusion and
+// execution effort under the current fee parameters of the network.
+//
+// The parameters are read on every call; use Parameters and Parameters.Fee to
+// estimate the fees of several transactions at once.
+func (c *Client) EstimateFee(ctx context.Context, inclusionEffort, executionEffort cadence.UFix64) (cadence.UFix64, error) {
+	params, err := c.Parameters(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return params.Fee(inclusionEffort, executionEffort)
+}
@@ -0,0 +1,78 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fees_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/query/fees"
+	"github.com/onflow/flow-go-sdk/query/internal/scriptstest"
+)
+
+func TestParameters_Fee(t *testing.T) {
+	params := &fees.Parameters{
+		SurgeFactor:         scriptstest.UFix64(t, "2.0"),
+		InclusionEffortCost: scriptstest.UFix64(t, "0.000001"),
+		ExecutionEffortCost: scriptstest.UFix64(t, "0.00004"),
+	}
+
+	fee, err := params.Fee(fees.DefaultInclusionEffort, scriptstest.UFix64(t, "0.5"))
+	require.NoError(t, err)
+	assert.Equal(t, "0.00004200", fee.String())
+
+	// Products below the precision of UFix64 are truncated.
+	fee, err = params.Fee(0, scriptstest.UFix64(t, "0.00000001"))
+	require.NoError(t, err)
+	assert.Equal(t, cadence.UFix64(0), fee)
+
+	_, err = (&fees.Parameters{SurgeFactor: math.MaxUint64, ExecutionEffortCost: scriptstest.UFix64(t, "1.0")}).
+		Fee(0, scriptstest.UFix64(t, "2.0"))
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+}
+
+func TestClient_EstimateFee(t *testing.T) {
+	ctx := context.Background()
+	c := scriptstest.NewClient(t)
+
+	c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("import FlowFees from 0xf919ee77447b7497"), []cadence.Value(nil)).
+		Return(scriptstest.NewStruct(
+			"FlowFees.FeeParameters",
+			[]string{"surgeFactor", "inclusionEffortCost", "executionEffortCost"},
+			scriptstest.UFix64(t, "1.0"),
+			scriptstest.UFix64(t, "0.000001"),
+			scriptstest.UFix64(t, "0.00004"),
+		), nil).
+		Once()
+
+	q, err := fees.NewClient(c, flow.Mainnet)
+	require.NoError(t, err)
+
+	fee, err := q.EstimateFee(ctx, fees.DefaultInclusionEffort, scriptstest.UFix64(t, "0.25"))
+	require.NoError(t, err)
+	assert.Equal(t, scriptstest.UFix64(t, "0.000011"), fee)
+}
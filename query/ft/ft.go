@@ -0,0 +1,176 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ft reads the balances of fungible token vaults.
+//
+// Any token implementing the FungibleToken standard is supported. The public
+// path of the balance of a vault is either given explicitly or resolved from
+// the FTVaultData metadata view of the token contract:
+//
+//	c, err := ft.NewClient(flowClient, flow.Mainnet)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	token, err := ft.ParseToken("A.b19436aae4d94622.FiatToken")
+//	if err != nil {
+//	    return err
+//	}
+//
+//	balance, err := c.Balance(ctx, address, token)
+package ft
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/query/internal/scripts"
+	"github.com/onflow/flow-go-sdk/systemcontracts"
+)
+
+// cadenceScripts holds the Cadence scripts of this package.
+//
+//go:embed cadence/*.cdc
+var cadenceScripts embed.FS
+
+// A ScriptClient executes Cadence scripts.
+//
+// It is satisfied by *client.Client. If the client reports the version of
+// Cadence of its network, the scripts are only run on Cadence 1.0.
+type ScriptClient = scripts.Client
+
+// A Token identifies a fungible token by the contract that defines it.
+type Token struct {
+	// Address is the address of the account the token contract is deployed to.
+	Address flow.Address
+	// Name is the name of the token contract, e.g. "FlowToken".
+	Name string
+	// BalancePath is the identifier of the public path at which accounts
+	// publish the balance of their vault, e.g. "flowTokenBalance".
+	//
+	// If empty, the path is resolved from the FTVaultData view of the
+	// token contract.
+	BalancePath string
+}
+
+// String returns the location of the token contract, e.g. "A.1654653399040a61.FlowToken".
+func (t Token) String() string {
+	return fmt.Sprintf("A.%s.%s", t.Address.Hex(), t.Name)
+}
+
+// ParseToken returns the token defined by the contract at a location such as
+// "A.1654653399040a61.FlowToken". A vault type such as
+// "A.1654653399040a61.FlowToken.Vault" is accepted as well.
+func ParseToken(location string) (Token, error) {
+	parts := strings.Split(location, ".")
+	if len(parts) == 4 && parts[3] == "Vault" {
+		parts = parts[:3]
+	}
+
+	if len(parts) != 3 || parts[0] != "A" || parts[2] == "" {
+		return Token{}, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "ft: invalid token location %q", location)
+	}
+
+	address, err := flow.ParseAddress(parts[1])
+	if err != nil {
+		return Token{}, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "ft: invalid token location %q: %w", location, err)
+	}
+
+	return Token{Address: address, Name: parts[2]}, nil
+}
+
+// FlowToken returns the FLOW token of the network with the given chain ID.
+func FlowToken(chainID flow.ChainID) (Token, error) {
+	contracts, err := systemcontracts.ForChain(chainID)
+	if err != nil {
+		return Token{}, fmt.Errorf("ft: %w", err)
+	}
+
+	return Token{
+		Address:     contracts.FlowToken.Address,
+		Name:        contracts.FlowToken.Name,
+		BalancePath: "flowTokenBalance",
+	}, nil
+}
+
+// A Client reads fungible token balances from a network.
+type Client struct {
+	scripts *scripts.Runner
+}
+
+// NewClient returns a client running scripts with c against the network with
+// the given chain ID.
+func NewClient(c ScriptClient, chainID flow.ChainID) (*Client, error) {
+	r, err := scripts.NewRunner("ft", c, cadenceScripts, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		scripts: r,
+	}, nil
+}
+
+// Balance returns the balance of the vault of a token in an account.
+//
+// An error wrapping flowerrors.ErrNotFound is returned if the account does not
+// publish a balance for the token at its balance path.
+func (c *Client) Balance(ctx context.Context, account flow.Address, token Token) (cadence.UFix64, error) {
+	if token.Name == "" || token.Address == flow.EmptyAddress {
+		return 0, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "ft: token %s is incomplete", token)
+	}
+
+	var balancePath cadence.Value = cadence.NewOptional(nil)
+	if token.BalancePath != "" {
+		balancePath = cadence.NewOptional(cadence.String(token.BalancePath))
+	}
+
+	value, err := c.scripts.Run(
+		ctx,
+		"get_balance",
+		cadence.BytesToAddress(account.Bytes()),
+		cadence.BytesToAddress(token.Address.Bytes()),
+		cadence.String(token.Name),
+		balancePath,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	optional, ok := value.(cadence.Optional)
+	if !ok {
+		return 0, flowerrors.Errorf(flowerrors.ErrDecoding, "ft: expected an optional UFix64, got %T", value)
+	}
+
+	if optional.Value == nil {
+		return 0, flowerrors.Errorf(flowerrors.ErrNotFound, "ft: account %s has no %s vault", account, token)
+	}
+
+	balance, ok := optional.Value.(cadence.UFix64)
+	if !ok {
+		return 0, flowerrors.Errorf(flowerrors.ErrDecoding, "ft: expected a UFix64 balance, got %T", optional.Value)
+	}
+
+	return balance, nil
+}
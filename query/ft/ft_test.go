@@ -0,0 +1,105 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ft_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/query/ft"
+	"github.com/onflow/flow-go-sdk/query/internal/scriptstest"
+)
+
+func TestParseToken(t *testing.T) {
+	token, err := ft.ParseToken("A.b19436aae4d94622.FiatToken.Vault")
+	require.NoError(t, err)
+	assert.Equal(t, flow.HexToAddress("b19436aae4d94622"), token.Address)
+	assert.Equal(t, "FiatToken", token.Name)
+	assert.Equal(t, "A.b19436aae4d94622.FiatToken", token.String())
+
+	for _, location := range []string{"FiatToken", "A.zz.FiatToken", "A.b19436aae4d94622.", "S.b19436aae4d94622.FiatToken"} {
+		_, err := ft.ParseToken(location)
+		assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument), location)
+	}
+}
+
+func TestClient_Balance(t *testing.T) {
+	ctx := context.Background()
+	account := flow.HexToAddress("01")
+
+	balance, err := cadence.NewUFix64("12.34567891")
+	require.NoError(t, err)
+
+	isImported := scriptstest.ScriptContains("import FungibleTokenMetadataViews from 0xf233dcee88fe0abe")
+
+	t.Run("Explicit path", func(t *testing.T) {
+		c := scriptstest.NewClient(t)
+
+		flowToken, err := ft.FlowToken(flow.Mainnet)
+		require.NoError(t, err)
+
+		c.On("ExecuteScriptAtLatestBlock", ctx, isImported, []cadence.Value{
+			cadence.BytesToAddress(account.Bytes()),
+			cadence.BytesToAddress(flow.HexToAddress("1654653399040a61").Bytes()),
+			cadence.String("FlowToken"),
+			cadence.NewOptional(cadence.String("flowTokenBalance")),
+		}).Return(cadence.NewOptional(balance), nil).Once()
+
+		q, err := ft.NewClient(c, flow.Mainnet)
+		require.NoError(t, err)
+
+		amount, err := q.Balance(ctx, account, flowToken)
+		require.NoError(t, err)
+		assert.Equal(t, balance, amount)
+		assert.Equal(t, "12.34567891", amount.String())
+	})
+
+	t.Run("Path from metadata views", func(t *testing.T) {
+		c := scriptstest.NewClient(t)
+
+		token, err := ft.ParseToken("A.b19436aae4d94622.FiatToken")
+		require.NoError(t, err)
+
+		c.On("ExecuteScriptAtLatestBlock", ctx, isImported, mock.MatchedBy(func(args []cadence.Value) bool {
+			return len(args) == 4 && args[3] == cadence.NewOptional(nil)
+		})).Return(cadence.NewOptional(nil), nil).Once()
+
+		q, err := ft.NewClient(c, flow.Mainnet)
+		require.NoError(t, err)
+
+		_, err = q.Balance(ctx, account, token)
+		assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+	})
+
+	t.Run("Incomplete token", func(t *testing.T) {
+		q, err := ft.NewClient(scriptstest.NewClient(t), flow.Mainnet)
+		require.NoError(t, err)
+
+		_, err = q.Balance(ctx, account, ft.Token{Name: "FiatToken"})
+		assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+	})
+}
@@ -0,0 +1,131 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package scripts runs the embedded Cadence scripts of the query packages.
+package scripts
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/onflow/cadence"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/internal/cadencedecode"
+	"github.com/onflow/flow-go-sdk/systemcontracts"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+// A Client executes Cadence scripts.
+type Client interface {
+	ExecuteScriptAtLatestBlock(
+		ctx context.Context,
+		script []byte,
+		arguments []cadence.Value,
+		opts ...grpc.CallOption,
+	) (cadence.Value, error)
+}
+
+// cadenceVersioner is implemented by clients that know the version of Cadence
+// run by their network, such as *client.Client.
+type cadenceVersioner interface {
+	CadenceVersion() flow.CadenceVersion
+}
+
+// A Runner runs the scripts of a package against a network.
+//
+// The scripts are read from the cadence directory of an embedded file system
+// and are written in Cadence 1.0.
+type Runner struct {
+	pkg     string
+	client  Client
+	scripts fs.FS
+	chainID flow.ChainID
+}
+
+// NewRunner returns a runner executing the scripts with c against the network
+// with the given chain ID. Errors are prefixed with the package name pkg.
+func NewRunner(pkg string, c Client, scripts fs.FS, chainID flow.ChainID) (*Runner, error) {
+	if _, err := systemcontracts.ForCadence(chainID, flow.CadenceV1); err != nil {
+		return nil, fmt.Errorf("%s: %w", pkg, err)
+	}
+
+	return &Runner{
+		pkg:     pkg,
+		client:  c,
+		scripts: scripts,
+		chainID: chainID,
+	}, nil
+}
+
+// Run runs the script with the given name and returns its result.
+//
+// The imports of the script are resolved for the version of Cadence of the
+// client, if it reports one. An error wrapping flowerrors.ErrUnsupported is
+// returned if the client targets Cadence before 1.0.
+func (r *Runner) Run(ctx context.Context, name string, args ...cadence.Value) (cadence.Value, error) {
+	code, err := fs.ReadFile(r.scripts, path.Join("cadence", name+".cdc"))
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrNotFound, "%s: missing script %s: %w", r.pkg, name, err)
+	}
+
+	version := flow.CadenceV1
+	if v, ok := r.client.(cadenceVersioner); ok {
+		version = v.CadenceVersion()
+	}
+
+	if version != flow.CadenceV1 {
+		return nil, flowerrors.Errorf(
+			flowerrors.ErrUnsupported,
+			"%s: script %s requires Cadence 1.0, the client targets Cadence %s",
+			r.pkg,
+			name,
+			version,
+		)
+	}
+
+	contracts, err := systemcontracts.ForCadence(r.chainID, version)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", r.pkg, err)
+	}
+
+	code = []byte(templates.ResolveImports(string(code), contracts.Addresses()))
+
+	return r.client.ExecuteScriptAtLatestBlock(ctx, code, args)
+}
+
+// Execute runs the script with the given name and decodes its result into the
+// Go value pointed to by target, matching the fields of structs by their
+// `cadence:"<name>"` tag.
+func (r *Runner) Execute(ctx context.Context, name string, target interface{}, args ...cadence.Value) error {
+	value, err := r.Run(ctx, name, args...)
+	if err != nil {
+		return err
+	}
+
+	err = cadencedecode.Decode(value, target)
+	if err != nil {
+		return flowerrors.Errorf(flowerrors.ErrDecoding, "%s: %s: %w", r.pkg, name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client/mocks"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/query/internal/scripts"
+	"github.com/onflow/flow-go-sdk/query/internal/scriptstest"
+)
+
+var testScripts = fstest.MapFS{
+	"cadence/get_supply.cdc": {Data: []byte("import FlowToken from \"FlowToken\"\n")},
+}
+
+func TestRunner(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Resolves imports", func(t *testing.T) {
+		c := scriptstest.NewClient(t)
+		c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("import FlowToken from 0x7e60df042a9c0868"), []cadence.Value(nil)).
+			Return(scriptstest.UFix64(t, "1.5"), nil).
+			Once()
+
+		r, err := scripts.NewRunner("test", c, testScripts, flow.Testnet)
+		require.NoError(t, err)
+
+		var supply cadence.UFix64
+		err = r.Execute(ctx, "get_supply", &supply)
+		require.NoError(t, err)
+		assert.Equal(t, scriptstest.UFix64(t, "1.5"), supply)
+	})
+
+	t.Run("Missing script", func(t *testing.T) {
+		r, err := scripts.NewRunner("test", scriptstest.NewClient(t), testScripts, flow.Testnet)
+		require.NoError(t, err)
+
+		_, err = r.Run(ctx, "get_balance")
+		assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+	})
+
+	t.Run("Cadence pre-1.0", func(t *testing.T) {
+		c := mocks.NewAccessClient(t)
+		c.On("CadenceVersion").Return(flow.CadenceV0)
+
+		r, err := scripts.NewRunner("test", c, testScripts, flow.Testnet)
+		require.NoError(t, err)
+
+		_, err = r.Run(ctx, "get_supply")
+		assert.True(t, errors.Is(err, flowerrors.ErrUnsupported))
+	})
+
+	t.Run("Decoding error", func(t *testing.T) {
+		c := scriptstest.NewClient(t)
+		c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("FlowToken"), []cadence.Value(nil)).
+			Return(cadence.String("1.5"), nil).
+			Once()
+
+		r, err := scripts.NewRunner("test", c, testScripts, flow.Testnet)
+		require.NoError(t, err)
+
+		var supply cadence.UFix64
+		err = r.Execute(ctx, "get_supply", &supply)
+		assert.True(t, errors.Is(err, flowerrors.ErrDecoding))
+	})
+
+	t.Run("Unknown chain", func(t *testing.T) {
+		_, err := scripts.NewRunner("test", scriptstest.NewClient(t), testScripts, "flow-unknown")
+		assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+	})
+}
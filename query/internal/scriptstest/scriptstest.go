@@ -0,0 +1,63 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package scriptstest provides fixtures for the tests of the query packages.
+package scriptstest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client/mocks"
+)
+
+// NewClient returns a mock access client targeting Cadence 1.0.
+func NewClient(t *testing.T) *mocks.AccessClient {
+	c := mocks.NewAccessClient(t)
+	c.On("CadenceVersion").Return(flow.CadenceV1).Maybe()
+	return c
+}
+
+// UFix64 parses a UFix64 value such as "1.5".
+func UFix64(t *testing.T, s string) cadence.UFix64 {
+	v, err := cadence.NewUFix64(s)
+	require.NoError(t, err)
+	return v
+}
+
+// NewStruct returns a struct of the type with the given identifier and fields.
+func NewStruct(id string, names []string, values ...cadence.Value) cadence.Struct {
+	fields := make([]cadence.Field, len(names))
+	for i, name := range names {
+		fields[i] = cadence.Field{Identifier: name}
+	}
+
+	return cadence.NewStruct(values).WithType(&cadence.StructType{QualifiedIdentifier: id, Fields: fields})
+}
+
+// ScriptContains matches a script whose code contains s.
+func ScriptContains(s string) interface{} {
+	return mock.MatchedBy(func(code []byte) bool {
+		return bytes.Contains(code, []byte(s))
+	})
+}
@@ -0,0 +1,163 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package nft reads NFT collections and the standard metadata views of their
+// NFTs.
+//
+// Collections are identified by the account holding them and the identifier of
+// the public path they are published at. Any collection implementing the
+// NonFungibleToken standard is supported:
+//
+//	c, err := nft.NewClient(flowClient, flow.Mainnet)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	ids, err := c.IDs(ctx, address, "exampleNFTCollection")
+//	if err != nil {
+//	    return err
+//	}
+//
+//	metadata, err := c.Metadata(ctx, address, "exampleNFTCollection", ids[0])
+package nft
+
+import (
+	"context"
+	"embed"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/query/internal/scripts"
+)
+
+// cadenceScripts holds the Cadence scripts of this package.
+//
+//go:embed cadence/*.cdc
+var cadenceScripts embed.FS
+
+// A ScriptClient executes Cadence scripts.
+//
+// It is satisfied by *client.Client. If the client reports the version of
+// Cadence of its network, the scripts are only run on Cadence 1.0.
+type ScriptClient = scripts.Client
+
+// Display is the MetadataViews.Display view of an NFT.
+type Display struct {
+	Name        string `cadence:"name"`
+	Description string `cadence:"description"`
+	// Thumbnail is the URI of the thumbnail image, e.g. an HTTP URL or an
+	// ipfs:// URI.
+	Thumbnail string `cadence:"thumbnail"`
+}
+
+// A Royalty is an entry of the MetadataViews.Royalties view of an NFT.
+type Royalty struct {
+	// Receiver is the account whose receiver capability gets paid the royalty.
+	Receiver flow.Address `cadence:"receiver"`
+	// Cut is the share of the sale price paid, between 0.0 and 1.0.
+	Cut         cadence.UFix64 `cadence:"cut"`
+	Description string         `cadence:"description"`
+}
+
+// CollectionData is the MetadataViews.NFTCollectionData view of an NFT.
+type CollectionData struct {
+	// StoragePath is the path the collection is stored at, e.g. "/storage/exampleNFTCollection".
+	StoragePath string `cadence:"storagePath"`
+	// PublicPath is the path the collection is published at, e.g. "/public/exampleNFTCollection".
+	PublicPath string `cadence:"publicPath"`
+	// PublicCollection is the type identifier of the published collection.
+	PublicCollection string `cadence:"publicCollection"`
+	// PublicLinkedType is the type identifier of the published capability.
+	PublicLinkedType string `cadence:"publicLinkedType"`
+}
+
+// Metadata are the standard metadata views of an NFT. Views the NFT does not
+// resolve are nil or empty.
+type Metadata struct {
+	ID             uint64          `cadence:"id"`
+	Display        *Display        `cadence:"display"`
+	Royalties      []Royalty       `cadence:"royalties"`
+	ExternalURL    string          `cadence:"externalURL"`
+	CollectionData *CollectionData `cadence:"collectionData"`
+}
+
+// A Client reads NFT collections from a network.
+type Client struct {
+	scripts *scripts.Runner
+}
+
+// NewClient returns a client running scripts with c against the network with
+// the given chain ID.
+func NewClient(c ScriptClient, chainID flow.ChainID) (*Client, error) {
+	r, err := scripts.NewRunner("nft", c, cadenceScripts, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		scripts: r,
+	}, nil
+}
+
+// IDs returns the IDs of the NFTs in the collection an account publishes at
+// the public path with the given identifier.
+//
+// An error wrapping flowerrors.ErrNotFound is returned if the account
+// publishes no collection at the path.
+func (c *Client) IDs(ctx context.Context, account flow.Address, collectionPath string) ([]uint64, error) {
+	var ids *[]uint64
+	err := c.scripts.Execute(ctx, "get_ids", &ids,
+		cadence.BytesToAddress(account.Bytes()),
+		cadence.String(collectionPath),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if ids == nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrNotFound, "nft: account %s has no collection at /public/%s", account, collectionPath)
+	}
+
+	return *ids, nil
+}
+
+// Metadata returns the Display, Royalties, ExternalURL and NFTCollectionData
+// views of an NFT in the collection an account publishes at the public path
+// with the given identifier.
+//
+// An error wrapping flowerrors.ErrNotFound is returned if the account
+// publishes no collection at the path or the collection has no NFT with the ID.
+func (c *Client) Metadata(ctx context.Context, account flow.Address, collectionPath string, id uint64) (*Metadata, error) {
+	var metadata *Metadata
+	err := c.scripts.Execute(ctx, "get_metadata", &metadata,
+		cadence.BytesToAddress(account.Bytes()),
+		cadence.String(collectionPath),
+		cadence.NewUInt64(id),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata == nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrNotFound, "nft: account %s has no NFT %d at /public/%s", account, id, collectionPath)
+	}
+
+	return metadata, nil
+}
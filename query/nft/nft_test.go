@@ -0,0 +1,119 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package nft_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/query/internal/scriptstest"
+	"github.com/onflow/flow-go-sdk/query/nft"
+)
+
+func TestClient_IDs(t *testing.T) {
+	ctx := context.Background()
+	account := flow.HexToAddress("01")
+	c := scriptstest.NewClient(t)
+
+	c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("?.getIDs()"), []cadence.Value{
+		cadence.BytesToAddress(account.Bytes()),
+		cadence.String("exampleNFTCollection"),
+	}).Return(cadence.NewOptional(cadence.NewArray([]cadence.Value{
+		cadence.NewUInt64(7),
+		cadence.NewUInt64(9),
+	})), nil).Once()
+
+	c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("?.getIDs()"), mock.Anything).
+		Return(cadence.NewOptional(nil), nil).
+		Once()
+
+	q, err := nft.NewClient(c, flow.Testnet)
+	require.NoError(t, err)
+
+	ids, err := q.IDs(ctx, account, "exampleNFTCollection")
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{7, 9}, ids)
+
+	_, err = q.IDs(ctx, account, "missing")
+	assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+}
+
+func TestClient_Metadata(t *testing.T) {
+	ctx := context.Background()
+	account := flow.HexToAddress("01")
+	receiver := flow.HexToAddress("02")
+	c := scriptstest.NewClient(t)
+
+	cut, err := cadence.NewUFix64("0.05")
+	require.NoError(t, err)
+
+	metadata := scriptstest.NewStruct("s.Metadata",
+		[]string{"id", "display", "royalties", "externalURL", "collectionData"},
+		cadence.NewUInt64(7),
+		cadence.NewOptional(scriptstest.NewStruct("s.Display",
+			[]string{"name", "description", "thumbnail"},
+			cadence.String("Example #7"),
+			cadence.String("An example NFT"),
+			cadence.String("ipfs://bafy/7.png"),
+		)),
+		cadence.NewArray([]cadence.Value{
+			scriptstest.NewStruct("s.Royalty",
+				[]string{"receiver", "cut", "description"},
+				cadence.BytesToAddress(receiver.Bytes()),
+				cut,
+				cadence.String("creator"),
+			),
+		}),
+		cadence.NewOptional(cadence.String("https://example.com/7")),
+		cadence.NewOptional(nil),
+	)
+
+	c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("import MetadataViews from 0x631e88ae7f1d7c20"), []cadence.Value{
+		cadence.BytesToAddress(account.Bytes()),
+		cadence.String("exampleNFTCollection"),
+		cadence.NewUInt64(7),
+	}).Return(cadence.NewOptional(metadata), nil).Once()
+
+	c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("borrowViewResolver"), mock.Anything).
+		Return(cadence.NewOptional(nil), nil).
+		Once()
+
+	q, err := nft.NewClient(c, flow.Testnet)
+	require.NoError(t, err)
+
+	m, err := q.Metadata(ctx, account, "exampleNFTCollection", 7)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(7), m.ID)
+	assert.Equal(t, &nft.Display{Name: "Example #7", Description: "An example NFT", Thumbnail: "ipfs://bafy/7.png"}, m.Display)
+	assert.Equal(t, []nft.Royalty{{Receiver: receiver, Cut: cut, Description: "creator"}}, m.Royalties)
+	assert.Equal(t, "https://example.com/7", m.ExternalURL)
+	assert.Nil(t, m.CollectionData)
+
+	_, err = q.Metadata(ctx, account, "exampleNFTCollection", 8)
+	assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+}
@@ -0,0 +1,202 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package query runs common read-only Cadence scripts and returns their results
+// as Go values.
+//
+// The scripts are maintained with the SDK and their imports are resolved for
+// the network of the client, so the same code reads Mainnet, Testnet and the
+// emulator:
+//
+//	q, err := query.NewClient(flowClient, flow.Mainnet)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	balance, err := q.FlowBalance(ctx, address)
+//
+// The subpackages cover specific domains: staking for staking and epochs, ft
+// for fungible token balances, nft for NFT collections and fees for transaction
+// fees.
+package query
+
+import (
+	"context"
+	"embed"
+	"fmt"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/query/internal/scripts"
+	"github.com/onflow/flow-go-sdk/query/staking"
+)
+
+// cadenceScripts holds the Cadence scripts of this package.
+//
+//go:embed cadence/*.cdc
+var cadenceScripts embed.FS
+
+// A ScriptClient executes Cadence scripts.
+//
+// It is satisfied by *client.Client. If the client reports the version of
+// Cadence of its network, the scripts are only run on Cadence 1.0.
+type ScriptClient = scripts.Client
+
+// Balance is the FLOW balance of an account.
+type Balance struct {
+	// Balance is the total balance of the account.
+	Balance cadence.UFix64 `cadence:"balance"`
+	// AvailableBalance is the part of the balance not reserved for the
+	// storage used by the account.
+	AvailableBalance cadence.UFix64 `cadence:"availableBalance"`
+}
+
+// A Client runs the scripts of this package against a network.
+type Client struct {
+	scripts *scripts.Runner
+	staking *staking.Client
+}
+
+// NewClient returns a client running scripts with c against the network with
+// the given chain ID.
+func NewClient(c ScriptClient, chainID flow.ChainID) (*Client, error) {
+	r, err := scripts.NewRunner("query", c, cadenceScripts, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := staking.NewClient(c, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return &Client{
+		scripts: r,
+		staking: s,
+	}, nil
+}
+
+// FlowBalance returns the FLOW balance of an account.
+func (c *Client) FlowBalance(ctx context.Context, address flow.Address) (*Balance, error) {
+	var balance Balance
+	err := c.scripts.Execute(ctx, "get_flow_balance", &balance, cadence.BytesToAddress(address.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &balance, nil
+}
+
+// scriptKey is an account key as returned by the get_account_keys script.
+type scriptKey struct {
+	Index              uint32         `cadence:"index"`
+	PublicKey          []byte         `cadence:"publicKey"`
+	SignatureAlgorithm uint8          `cadence:"signatureAlgorithm"`
+	HashAlgorithm      uint8          `cadence:"hashAlgorithm"`
+	Weight             cadence.UFix64 `cadence:"weight"`
+	IsRevoked          bool           `cadence:"isRevoked"`
+}
+
+// Raw values of the Cadence SignatureAlgorithm and HashAlgorithm enums.
+var (
+	signatureAlgorithms = map[uint8]crypto.SignatureAlgorithm{
+		1: crypto.ECDSA_P256,
+		2: crypto.ECDSA_secp256k1,
+	}
+
+	hashAlgorithms = map[uint8]crypto.HashAlgorithm{
+		1: crypto.SHA2_256,
+		2: crypto.SHA2_384,
+		3: crypto.SHA3_256,
+		4: crypto.SHA3_384,
+	}
+)
+
+// AccountKeys returns the keys of an account, including revoked keys.
+//
+// Sequence numbers are not available to scripts, so the SequenceNumber of the
+// returned keys is always zero; use the GetAccount method of the access client
+// to read them.
+func (c *Client) AccountKeys(ctx context.Context, address flow.Address) ([]*flow.AccountKey, error) {
+	var keys []scriptKey
+	err := c.scripts.Execute(ctx, "get_account_keys", &keys, cadence.BytesToAddress(address.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	accountKeys := make([]*flow.AccountKey, len(keys))
+	for i, key := range keys {
+		sigAlgo, ok := signatureAlgorithms[key.SignatureAlgorithm]
+		if !ok {
+			return nil, flowerrors.Errorf(flowerrors.ErrUnsupported, "query: key %d uses unsupported signature algorithm %d", key.Index, key.SignatureAlgorithm)
+		}
+
+		hashAlgo, ok := hashAlgorithms[key.HashAlgorithm]
+		if !ok {
+			return nil, flowerrors.Errorf(flowerrors.ErrUnsupported, "query: key %d uses unsupported hash algorithm %d", key.Index, key.HashAlgorithm)
+		}
+
+		publicKey, err := crypto.DecodePublicKey(sigAlgo, key.PublicKey)
+		if err != nil {
+			return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "query: invalid public key %d: %w", key.Index, err)
+		}
+
+		accountKeys[i] = &flow.AccountKey{
+			Index:     int(key.Index),
+			PublicKey: publicKey,
+			SigAlgo:   sigAlgo,
+			HashAlgo:  hashAlgo,
+			Weight:    int(key.Weight / 100_000_000),
+			Revoked:   key.IsRevoked,
+		}
+	}
+
+	return accountKeys, nil
+}
+
+// ContractNames returns the names of the contracts deployed to an account.
+func (c *Client) ContractNames(ctx context.Context, address flow.Address) ([]string, error) {
+	var names []string
+	err := c.scripts.Execute(ctx, "get_contract_names", &names, cadence.BytesToAddress(address.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// FlowTotalSupply returns the total supply of FLOW.
+func (c *Client) FlowTotalSupply(ctx context.Context) (cadence.UFix64, error) {
+	var supply cadence.UFix64
+	err := c.scripts.Execute(ctx, "get_flow_total_supply", &supply)
+	if err != nil {
+		return 0, err
+	}
+
+	return supply, nil
+}
+
+// CurrentEpoch returns the counter, phase and metadata of the current epoch.
+//
+// See the staking package for other staking and epoch queries.
+func (c *Client) CurrentEpoch(ctx context.Context) (*staking.Epoch, error) {
+	return c.staking.CurrentEpoch(ctx)
+}
@@ -0,0 +1,126 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/cryptotest"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/query"
+	"github.com/onflow/flow-go-sdk/query/internal/scriptstest"
+)
+
+func bytesValue(b []byte) cadence.Array {
+	values := make([]cadence.Value, len(b))
+	for i, c := range b {
+		values[i] = cadence.NewUInt8(c)
+	}
+
+	return cadence.NewArray(values)
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	address := flow.HexToAddress("01")
+	addressArg := []cadence.Value{cadence.BytesToAddress(address.Bytes())}
+
+	c := scriptstest.NewClient(t)
+
+	c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("account.availableBalance"), addressArg).
+		Return(scriptstest.NewStruct("s.Result", []string{"balance", "availableBalance"}, scriptstest.UFix64(t, "10.0"), scriptstest.UFix64(t, "9.999")), nil).
+		Once()
+
+	c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains(".contracts.names"), addressArg).
+		Return(cadence.NewArray([]cadence.Value{cadence.String("FlowToken")}), nil).
+		Once()
+
+	c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("import FlowToken from 0x1654653399040a61"), []cadence.Value(nil)).
+		Return(scriptstest.UFix64(t, "1500000000.0"), nil).
+		Once()
+
+	q, err := query.NewClient(c, flow.Mainnet)
+	require.NoError(t, err)
+
+	balance, err := q.FlowBalance(ctx, address)
+	require.NoError(t, err)
+	assert.Equal(t, &query.Balance{Balance: scriptstest.UFix64(t, "10.0"), AvailableBalance: scriptstest.UFix64(t, "9.999")}, balance)
+
+	names, err := q.ContractNames(ctx, address)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"FlowToken"}, names)
+
+	supply, err := q.FlowTotalSupply(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, scriptstest.UFix64(t, "1500000000.0"), supply)
+}
+
+func TestClient_AccountKeys(t *testing.T) {
+	ctx := context.Background()
+	address := flow.HexToAddress("01")
+	privateKey := cryptotest.PrivateKey(crypto.ECDSA_P256, 0)
+
+	key := func(sigAlgo uint8) cadence.Value {
+		return scriptstest.NewStruct(
+			"s.Result",
+			[]string{"index", "publicKey", "signatureAlgorithm", "hashAlgorithm", "weight", "isRevoked"},
+			cadence.NewUInt32(2),
+			bytesValue(privateKey.PublicKey().Encode()),
+			cadence.NewUInt8(sigAlgo),
+			cadence.NewUInt8(3),
+			scriptstest.UFix64(t, "1000.0"),
+			cadence.NewBool(true),
+		)
+	}
+
+	c := scriptstest.NewClient(t)
+	c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("keys.forEach"), mock.Anything).
+		Return(cadence.NewArray([]cadence.Value{key(1)}), nil).
+		Once()
+	c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("keys.forEach"), mock.Anything).
+		Return(cadence.NewArray([]cadence.Value{key(3)}), nil).
+		Once()
+
+	q, err := query.NewClient(c, flow.Testnet)
+	require.NoError(t, err)
+
+	keys, err := q.AccountKeys(ctx, address)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+
+	assert.Equal(t, 2, keys[0].Index)
+	assert.True(t, privateKey.PublicKey().Equals(keys[0].PublicKey))
+	assert.Equal(t, crypto.ECDSA_P256, keys[0].SigAlgo)
+	assert.Equal(t, crypto.SHA3_256, keys[0].HashAlgo)
+	assert.Equal(t, 1000, keys[0].Weight)
+	assert.True(t, keys[0].Revoked)
+
+	// BLS keys cannot be used as Flow account keys by the SDK.
+	_, err = q.AccountKeys(ctx, address)
+	assert.True(t, errors.Is(err, flowerrors.ErrUnsupported))
+}
@@ -0,0 +1,297 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package staking reads the staking and epoch state of a Flow network from the
+// FlowIDTableStaking and FlowEpoch contracts.
+//
+// It runs the standard read scripts of the core contracts, with their imports
+// resolved for the network, and decodes the results into Go structs:
+//
+//	c, err := staking.NewClient(flowClient, flow.Mainnet)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	epoch, err := c.CurrentEpoch(ctx)
+package staking
+
+import (
+	"context"
+	"embed"
+	"fmt"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/query/internal/scripts"
+)
+
+// cadenceScripts holds the Cadence scripts of this package.
+//
+//go:embed cadence/*.cdc
+var cadenceScripts embed.FS
+
+// A ScriptClient executes Cadence scripts.
+//
+// It is satisfied by *client.Client. If the client reports the version of
+// Cadence of its network, the scripts are only run on Cadence 1.0.
+type ScriptClient = scripts.Client
+
+// A Role is the role of a staked node, as numbered by FlowIDTableStaking.
+type Role uint8
+
+// Roles of the nodes of a Flow network.
+const (
+	RoleCollection Role = iota + 1
+	RoleConsensus
+	RoleExecution
+	RoleVerification
+	RoleAccess
+)
+
+// String returns the name of the role, e.g. "collection".
+func (r Role) String() string {
+	switch r {
+	case RoleCollection:
+		return "collection"
+	case RoleConsensus:
+		return "consensus"
+	case RoleExecution:
+		return "execution"
+	case RoleVerification:
+		return "verification"
+	case RoleAccess:
+		return "access"
+	default:
+		return fmt.Sprintf("Role(%d)", uint8(r))
+	}
+}
+
+// An EpochPhase is a phase of an epoch, as numbered by FlowEpoch.
+type EpochPhase uint8
+
+// Phases of an epoch.
+const (
+	EpochPhaseStakingAuction EpochPhase = iota
+	EpochPhaseSetup
+	EpochPhaseCommitted
+)
+
+// String returns the name of the phase, e.g. "staking auction".
+func (p EpochPhase) String() string {
+	switch p {
+	case EpochPhaseStakingAuction:
+		return "staking auction"
+	case EpochPhaseSetup:
+		return "setup"
+	case EpochPhaseCommitted:
+		return "committed"
+	default:
+		return fmt.Sprintf("EpochPhase(%d)", uint8(p))
+	}
+}
+
+// NodeInfo is the staking record of a node: a FlowIDTableStaking.NodeInfo value.
+type NodeInfo struct {
+	ID                       string         `cadence:"id"`
+	Role                     Role           `cadence:"role"`
+	NetworkingAddress        string         `cadence:"networkingAddress"`
+	NetworkingKey            string         `cadence:"networkingKey"`
+	StakingKey               string         `cadence:"stakingKey"`
+	TokensStaked             cadence.UFix64 `cadence:"tokensStaked"`
+	TokensCommitted          cadence.UFix64 `cadence:"tokensCommitted"`
+	TokensUnstaking          cadence.UFix64 `cadence:"tokensUnstaking"`
+	TokensUnstaked           cadence.UFix64 `cadence:"tokensUnstaked"`
+	TokensRewarded           cadence.UFix64 `cadence:"tokensRewarded"`
+	TokensRequestedToUnstake cadence.UFix64 `cadence:"tokensRequestedToUnstake"`
+	Delegators               []uint32       `cadence:"delegators"`
+	DelegatorIDCounter       uint32         `cadence:"delegatorIDCounter"`
+	InitialWeight            uint64         `cadence:"initialWeight"`
+}
+
+// DelegatorInfo is the staking record of a delegator: a
+// FlowIDTableStaking.DelegatorInfo value.
+type DelegatorInfo struct {
+	ID                       uint32         `cadence:"id"`
+	NodeID                   string         `cadence:"nodeID"`
+	TokensCommitted          cadence.UFix64 `cadence:"tokensCommitted"`
+	TokensStaked             cadence.UFix64 `cadence:"tokensStaked"`
+	TokensUnstaking          cadence.UFix64 `cadence:"tokensUnstaking"`
+	TokensRewarded           cadence.UFix64 `cadence:"tokensRewarded"`
+	TokensUnstaked           cadence.UFix64 `cadence:"tokensUnstaked"`
+	TokensRequestedToUnstake cadence.UFix64 `cadence:"tokensRequestedToUnstake"`
+}
+
+// Balances are the committed and staked balances of a node, with and without
+// the tokens of its delegators.
+type Balances struct {
+	Committed               cadence.UFix64 `cadence:"committed"`
+	CommittedWithDelegators cadence.UFix64 `cadence:"committedWithDelegators"`
+	Staked                  cadence.UFix64 `cadence:"staked"`
+	StakedWithDelegators    cadence.UFix64 `cadence:"stakedWithDelegators"`
+}
+
+// EpochMetadata is the metadata of an epoch: a FlowEpoch.EpochMetadata value.
+type EpochMetadata struct {
+	Counter        uint64         `cadence:"counter"`
+	Seed           string         `cadence:"seed"`
+	StartView      uint64         `cadence:"startView"`
+	EndView        uint64         `cadence:"endView"`
+	StakingEndView uint64         `cadence:"stakingEndView"`
+	TotalRewards   cadence.UFix64 `cadence:"totalRewards"`
+	RewardsPaid    bool           `cadence:"rewardsPaid"`
+	DKGKeys        []string       `cadence:"dkgKeys"`
+}
+
+// Epoch is the state of the current epoch.
+type Epoch struct {
+	Counter  uint64        `cadence:"counter"`
+	Phase    EpochPhase    `cadence:"phase"`
+	Metadata EpochMetadata `cadence:"metadata"`
+}
+
+// A Client reads staking and epoch state from a network.
+type Client struct {
+	scripts *scripts.Runner
+}
+
+// NewClient returns a client running scripts with c against the network with
+// the given chain ID.
+func NewClient(c ScriptClient, chainID flow.ChainID) (*Client, error) {
+	r, err := scripts.NewRunner("staking", c, cadenceScripts, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		scripts: r,
+	}, nil
+}
+
+// NodeInfo returns the staking record of a node.
+func (c *Client) NodeInfo(ctx context.Context, nodeID string) (*NodeInfo, error) {
+	var info NodeInfo
+	err := c.scripts.Execute(ctx, "get_node_info", &info, cadence.String(nodeID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// DelegatorInfo returns the staking record of a delegator of a node.
+func (c *Client) DelegatorInfo(ctx context.Context, nodeID string, delegatorID uint32) (*DelegatorInfo, error) {
+	var info DelegatorInfo
+	err := c.scripts.Execute(ctx, "get_delegator_info", &info, cadence.String(nodeID), cadence.NewUInt32(delegatorID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// NodeBalances returns the committed and staked balances of a node.
+func (c *Client) NodeBalances(ctx context.Context, nodeID string) (*Balances, error) {
+	var balances Balances
+	err := c.scripts.Execute(ctx, "get_node_balances", &balances, cadence.String(nodeID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &balances, nil
+}
+
+// StakedNodeIDs returns the IDs of the nodes staked for the current epoch.
+func (c *Client) StakedNodeIDs(ctx context.Context) ([]string, error) {
+	var ids []string
+	err := c.scripts.Execute(ctx, "get_staked_node_ids", &ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// ProposedNodeIDs returns the IDs of the nodes proposed for the next epoch.
+func (c *Client) ProposedNodeIDs(ctx context.Context) ([]string, error) {
+	var ids []string
+	err := c.scripts.Execute(ctx, "get_proposed_node_ids", &ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// TotalStakedByRole returns the tokens staked for the current epoch by the
+// nodes of each role and their delegators.
+func (c *Client) TotalStakedByRole(ctx context.Context) (map[Role]cadence.UFix64, error) {
+	var totals map[Role]cadence.UFix64
+	err := c.scripts.Execute(ctx, "get_total_staked_by_role", &totals)
+	if err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+// TotalStaked returns the tokens staked for the current epoch by all nodes
+// and their delegators.
+func (c *Client) TotalStaked(ctx context.Context) (cadence.UFix64, error) {
+	totals, err := c.TotalStakedByRole(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var total cadence.UFix64
+	for _, amount := range totals {
+		total += amount
+	}
+
+	return total, nil
+}
+
+// CurrentEpoch returns the counter, phase and metadata of the current epoch.
+func (c *Client) CurrentEpoch(ctx context.Context) (*Epoch, error) {
+	var epoch Epoch
+	err := c.scripts.Execute(ctx, "get_current_epoch", &epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &epoch, nil
+}
+
+// EpochMetadata returns the metadata of the epoch with the given counter.
+//
+// An error wrapping flowerrors.ErrNotFound is returned if the epoch has no
+// metadata, e.g. because it has not been proposed yet.
+func (c *Client) EpochMetadata(ctx context.Context, counter uint64) (*EpochMetadata, error) {
+	var metadata *EpochMetadata
+	err := c.scripts.Execute(ctx, "get_epoch_metadata", &metadata, cadence.NewUInt64(counter))
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata == nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrNotFound, "staking: epoch %d has no metadata", counter)
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,150 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package staking_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/query/internal/scriptstest"
+	"github.com/onflow/flow-go-sdk/query/staking"
+)
+
+func TestClient_NodeInfo(t *testing.T) {
+	ctx := context.Background()
+	c := scriptstest.NewClient(t)
+
+	staked := scriptstest.UFix64(t, "1250000.0")
+
+	c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("import FlowIDTableStaking from 0x8624b52f9ddcd04a"), []cadence.Value{cadence.String("abcd")}).
+		Return(scriptstest.NewStruct("FlowIDTableStaking.NodeInfo",
+			[]string{
+				"id", "role", "networkingAddress", "networkingKey", "stakingKey",
+				"tokensStaked", "tokensCommitted", "tokensUnstaking", "tokensUnstaked", "tokensRewarded",
+				"delegators", "delegatorIDCounter", "tokensRequestedToUnstake", "initialWeight",
+			},
+			cadence.String("abcd"),
+			cadence.NewUInt8(uint8(staking.RoleExecution)),
+			cadence.String("execution-001.mainnet.nodes.onflow.org:3569"),
+			cadence.String("0a"),
+			cadence.String("0b"),
+			staked,
+			cadence.UFix64(0),
+			cadence.UFix64(0),
+			cadence.UFix64(0),
+			cadence.UFix64(0),
+			cadence.NewArray([]cadence.Value{cadence.NewUInt32(1), cadence.NewUInt32(2)}),
+			cadence.NewUInt32(2),
+			cadence.UFix64(0),
+			cadence.NewUInt64(100),
+		), nil).
+		Once()
+
+	s, err := staking.NewClient(c, flow.Mainnet)
+	require.NoError(t, err)
+
+	info, err := s.NodeInfo(ctx, "abcd")
+	require.NoError(t, err)
+
+	assert.Equal(t, "abcd", info.ID)
+	assert.Equal(t, staking.RoleExecution, info.Role)
+	assert.Equal(t, "execution", info.Role.String())
+	assert.Equal(t, staked, info.TokensStaked)
+	assert.Equal(t, []uint32{1, 2}, info.Delegators)
+	assert.Equal(t, uint64(100), info.InitialWeight)
+}
+
+func TestClient_TotalStaked(t *testing.T) {
+	ctx := context.Background()
+	c := scriptstest.NewClient(t)
+
+	c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("getTotalTokensStakedByNodeType()"), []cadence.Value(nil)).
+		Return(cadence.NewDictionary([]cadence.KeyValuePair{
+			{Key: cadence.NewUInt8(1), Value: scriptstest.UFix64(t, "10.5")},
+			{Key: cadence.NewUInt8(2), Value: scriptstest.UFix64(t, "20.25")},
+		}), nil).
+		Twice()
+
+	s, err := staking.NewClient(c, flow.Testnet)
+	require.NoError(t, err)
+
+	byRole, err := s.TotalStakedByRole(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, scriptstest.UFix64(t, "20.25"), byRole[staking.RoleConsensus])
+
+	total, err := s.TotalStaked(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, scriptstest.UFix64(t, "30.75"), total)
+}
+
+func TestClient_Epoch(t *testing.T) {
+	ctx := context.Background()
+	c := scriptstest.NewClient(t)
+
+	metadata := scriptstest.NewStruct("FlowEpoch.EpochMetadata",
+		[]string{"counter", "seed", "startView", "endView", "stakingEndView", "totalRewards", "rewardsPaid", "dkgKeys"},
+		cadence.NewUInt64(120),
+		cadence.String("5eed"),
+		cadence.NewUInt64(1000),
+		cadence.NewUInt64(2000),
+		cadence.NewUInt64(1800),
+		scriptstest.UFix64(t, "1000.0"),
+		cadence.NewBool(false),
+		cadence.NewArray([]cadence.Value{cadence.String("k1")}),
+	)
+
+	c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("FlowEpoch.currentEpochPhase"), mock.Anything).
+		Return(scriptstest.NewStruct("s.Epoch",
+			[]string{"counter", "phase", "metadata"},
+			cadence.NewUInt64(120),
+			cadence.NewUInt8(uint8(staking.EpochPhaseSetup)),
+			metadata,
+		), nil).
+		Once()
+
+	c.On("ExecuteScriptAtLatestBlock", ctx, scriptstest.ScriptContains("getEpochMetadata(counter)"), []cadence.Value{cadence.NewUInt64(121)}).
+		Return(cadence.NewOptional(nil), nil).
+		Once()
+
+	s, err := staking.NewClient(c, flow.Emulator)
+	require.NoError(t, err)
+
+	epoch, err := s.CurrentEpoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(120), epoch.Counter)
+	assert.Equal(t, staking.EpochPhaseSetup, epoch.Phase)
+	assert.Equal(t, uint64(1800), epoch.Metadata.StakingEndView)
+	assert.Equal(t, []string{"k1"}, epoch.Metadata.DKGKeys)
+
+	_, err = s.EpochMetadata(ctx, 121)
+	assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+}
+
+func TestNewClient_UnknownChain(t *testing.T) {
+	_, err := staking.NewClient(scriptstest.NewClient(t), "flow-unknown")
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+}
@@ -0,0 +1,71 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// A RegisterID identifies a register of the execution state.
+//
+// The owner of the registers of an account is the account address; global
+// registers have an empty owner. Owner and Key hold raw bytes.
+type RegisterID struct {
+	Owner string
+	Key   string
+}
+
+// AccountStatusRegisterKey is the key of the register holding the status of an
+// account, such as its storage usage and number of keys.
+const AccountStatusRegisterKey = "account_status"
+
+// NewRegisterID returns the ID of the register of an account with the given key.
+func NewRegisterID(owner Address, key string) RegisterID {
+	return RegisterID{
+		Owner: string(owner.Bytes()),
+		Key:   key,
+	}
+}
+
+// GlobalRegisterID returns the ID of the global register with the given key.
+func GlobalRegisterID(key string) RegisterID {
+	return RegisterID{Key: key}
+}
+
+// AccountStatusRegisterID returns the ID of the register holding the status of an account.
+func AccountStatusRegisterID(address Address) RegisterID {
+	return NewRegisterID(address, AccountStatusRegisterKey)
+}
+
+// IsGlobal returns true if the register is not owned by an account.
+func (id RegisterID) IsGlobal() bool {
+	return id.Owner == ""
+}
+
+// Address returns the address of the account owning the register, or
+// EmptyAddress for global registers.
+func (id RegisterID) Address() Address {
+	return BytesToAddress([]byte(id.Owner))
+}
+
+// String returns the hex-encoded owner and the key of the register.
+func (id RegisterID) String() string {
+	return fmt.Sprintf("%s/%q", hex.EncodeToString([]byte(id.Owner)), id.Key)
+}
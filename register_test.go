@@ -0,0 +1,43 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func TestRegisterID(t *testing.T) {
+	address := flow.HexToAddress("01")
+
+	id := flow.AccountStatusRegisterID(address)
+	assert.Equal(t, string(address.Bytes()), id.Owner)
+	assert.Equal(t, flow.AccountStatusRegisterKey, id.Key)
+	assert.Equal(t, address, id.Address())
+	assert.False(t, id.IsGlobal())
+	assert.Equal(t, `0000000000000001/"account_status"`, id.String())
+
+	global := flow.GlobalRegisterID("uuid")
+	assert.True(t, global.IsGlobal())
+	assert.Equal(t, flow.EmptyAddress, global.Address())
+	assert.Equal(t, `/"uuid"`, global.String())
+}
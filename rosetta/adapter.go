@@ -0,0 +1,279 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rosetta
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/onflow/cadence"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/events"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/systemcontracts"
+)
+
+// A BlockClient reads blocks and their events.
+//
+// It is satisfied by *client.Client.
+type BlockClient interface {
+	GetBlockByHeight(ctx context.Context, height uint64, opts ...grpc.CallOption) (*flow.Block, error)
+	GetEventsForHeightRange(
+		ctx context.Context,
+		query client.EventRangeQuery,
+		opts ...grpc.CallOption,
+	) ([]client.BlockEvents, error)
+}
+
+// An Adapter converts the data of a Flow network to Rosetta models.
+type Adapter struct {
+	network       *NetworkIdentifier
+	withdrawnType string
+	depositedType string
+}
+
+// NewAdapter returns an adapter for the network with the given chain ID.
+func NewAdapter(chainID flow.ChainID) (*Adapter, error) {
+	contracts, err := systemcontracts.ForChain(chainID)
+	if err != nil {
+		return nil, fmt.Errorf("rosetta: %w", err)
+	}
+
+	return &Adapter{
+		network:       &NetworkIdentifier{Blockchain: Blockchain, Network: string(chainID)},
+		withdrawnType: contracts.FlowToken.EventType("TokensWithdrawn"),
+		depositedType: contracts.FlowToken.EventType("TokensDeposited"),
+	}, nil
+}
+
+// Network returns the identifier of the network of the adapter.
+func (a *Adapter) Network() *NetworkIdentifier {
+	return a.network
+}
+
+// EventTypes returns the types of the events the adapter converts to operations.
+func (a *Adapter) EventTypes() []string {
+	return []string{a.withdrawnType, a.depositedType}
+}
+
+// FetchBlock reads the block at the given height and its FLOW transfer events,
+// and converts them to a Rosetta block.
+func (a *Adapter) FetchBlock(ctx context.Context, c BlockClient, height uint64) (*Block, error) {
+	block, err := c.GetBlockByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	var blockEvents []flow.Event
+	for _, eventType := range a.EventTypes() {
+		results, err := c.GetEventsForHeightRange(ctx, client.EventRangeQuery{
+			Type:        eventType,
+			StartHeight: height,
+			EndHeight:   height,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results {
+			blockEvents = append(blockEvents, result.Events...)
+		}
+	}
+
+	return a.Block(block, blockEvents)
+}
+
+// Block converts a block and the events emitted by its transactions to a
+// Rosetta block.
+//
+// Events other than FLOW withdrawals and deposits are ignored, so events can
+// be the unfiltered events of the block. Transactions that did not move FLOW
+// are omitted from the block.
+func (a *Adapter) Block(block *flow.Block, blockEvents []flow.Event) (*Block, error) {
+	transactions, err := a.Transactions(blockEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := &BlockIdentifier{
+		Index: int64(block.Height) - 1,
+		Hash:  block.ParentID.Hex(),
+	}
+
+	// The parent of the genesis block is the genesis block itself.
+	if block.Height == 0 {
+		parent = blockIdentifier(block.BlockHeader)
+	}
+
+	return &Block{
+		BlockIdentifier:       blockIdentifier(block.BlockHeader),
+		ParentBlockIdentifier: parent,
+		Timestamp:             block.Timestamp.UnixNano() / 1e6,
+		Transactions:          transactions,
+	}, nil
+}
+
+// tokensWithdrawn and tokensDeposited are the FLOW transfer events. Events of
+// vaults that are not stored in an account have no address.
+type tokensWithdrawn struct {
+	Amount cadence.UFix64 `cadence:"amount"`
+	From   *flow.Address  `cadence:"from"`
+}
+
+type tokensDeposited struct {
+	Amount cadence.UFix64 `cadence:"amount"`
+	To     *flow.Address  `cadence:"to"`
+}
+
+// transfer is a decoded FLOW withdrawal or deposit.
+type transfer struct {
+	amount  cadence.UFix64
+	address *flow.Address
+	deposit bool
+}
+
+// Transactions converts FLOW withdrawal and deposit events to Rosetta
+// transactions, in the order of execution.
+//
+// Each event becomes a transfer operation of the account it names; events of
+// vaults not stored in an account, such as minted or burned tokens, are
+// skipped. A deposit is related to the withdrawal of the same amount directly
+// preceding it.
+func (a *Adapter) Transactions(blockEvents []flow.Event) ([]*Transaction, error) {
+	sorted := make([]flow.Event, 0, len(blockEvents))
+	for _, event := range blockEvents {
+		if event.Type == a.withdrawnType || event.Type == a.depositedType {
+			sorted = append(sorted, event)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].TransactionIndex != sorted[j].TransactionIndex {
+			return sorted[i].TransactionIndex < sorted[j].TransactionIndex
+		}
+		return sorted[i].EventIndex < sorted[j].EventIndex
+	})
+
+	var (
+		transactions []*Transaction
+		current      *Transaction
+		withdrawal   *Operation
+	)
+
+	for _, event := range sorted {
+		t, err := a.decodeTransfer(event)
+		if err != nil {
+			return nil, err
+		}
+
+		if current == nil || current.TransactionIdentifier.Hash != event.TransactionID.Hex() {
+			current = &Transaction{
+				TransactionIdentifier: &TransactionIdentifier{Hash: event.TransactionID.Hex()},
+				Operations:            []*Operation{},
+			}
+			transactions = append(transactions, current)
+			withdrawal = nil
+		}
+
+		if t.address == nil {
+			continue
+		}
+
+		status := OperationStatusSuccess
+		op := &Operation{
+			OperationIdentifier: &OperationIdentifier{Index: int64(len(current.Operations))},
+			Type:                OperationTypeTransfer,
+			Status:              &status,
+			Account:             &AccountIdentifier{Address: "0x" + t.address.Hex()},
+			Amount:              NewAmount(t.amount, !t.deposit),
+		}
+
+		if !t.deposit {
+			withdrawal = op
+		} else if withdrawal != nil && withdrawal.Amount.Value == "-"+op.Amount.Value {
+			op.RelatedOperations = []*OperationIdentifier{withdrawal.OperationIdentifier}
+			withdrawal = nil
+		}
+
+		current.Operations = append(current.Operations, op)
+	}
+
+	// Drop transactions whose events were all skipped.
+	result := transactions[:0]
+	for _, tx := range transactions {
+		if len(tx.Operations) > 0 {
+			result = append(result, tx)
+		}
+	}
+
+	return result, nil
+}
+
+// decodeTransfer decodes a FLOW withdrawal or deposit event.
+func (a *Adapter) decodeTransfer(event flow.Event) (*transfer, error) {
+	if event.Type == a.depositedType {
+		var deposited tokensDeposited
+		err := events.DecodeEvent(event, &deposited)
+		if err != nil {
+			return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "rosetta: %w", err)
+		}
+
+		return &transfer{amount: deposited.Amount, address: deposited.To, deposit: true}, nil
+	}
+
+	var withdrawn tokensWithdrawn
+	err := events.DecodeEvent(event, &withdrawn)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "rosetta: %w", err)
+	}
+
+	return &transfer{amount: withdrawn.Amount, address: withdrawn.From}, nil
+}
+
+// AccountBalance returns the FLOW balance of an account at the block with the
+// given header as a Rosetta balance response.
+func AccountBalance(header flow.BlockHeader, balance cadence.UFix64) *AccountBalanceResponse {
+	return &AccountBalanceResponse{
+		BlockIdentifier: blockIdentifier(header),
+		Balances:        []*Amount{NewAmount(balance, false)},
+	}
+}
+
+// NewAmount returns a FLOW amount in the smallest unit of FLOW, negated if
+// negative is true.
+func NewAmount(value cadence.UFix64, negative bool) *Amount {
+	s := strconv.FormatUint(uint64(value), 10)
+	if negative && value != 0 {
+		s = "-" + s
+	}
+
+	return &Amount{Value: s, Currency: FLOW}
+}
+
+func blockIdentifier(header flow.BlockHeader) *BlockIdentifier {
+	return &BlockIdentifier{
+		Index: int64(header.Height),
+		Hash:  header.ID.Hex(),
+	}
+}
@@ -0,0 +1,205 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rosetta_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/client/mocks"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/rosetta"
+	"github.com/onflow/flow-go-sdk/systemcontracts"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+var flowToken = systemcontracts.MustForChain(flow.Emulator).FlowToken
+
+func ufix64(t *testing.T, s string) cadence.UFix64 {
+	v, err := cadence.NewUFix64(s)
+	require.NoError(t, err)
+	return v
+}
+
+// transferEvent creates a FlowToken event with an amount and an optional
+// address field.
+func transferEvent(
+	name string,
+	field string,
+	amount cadence.UFix64,
+	address *flow.Address,
+	txID flow.Identifier,
+	txIndex int,
+	eventIndex int,
+) flow.Event {
+	addressValue := cadence.NewOptional(nil)
+	if address != nil {
+		addressValue = cadence.NewOptional(cadence.BytesToAddress(address.Bytes()))
+	}
+
+	value := cadence.NewEvent([]cadence.Value{amount, addressValue}).WithType(&cadence.EventType{
+		QualifiedIdentifier: "FlowToken." + name,
+		Fields: []cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: field, Type: cadence.OptionalType{Type: cadence.AddressType{}}},
+		},
+	})
+
+	return flow.Event{
+		Type:             flowToken.EventType(name),
+		TransactionID:    txID,
+		TransactionIndex: txIndex,
+		EventIndex:       eventIndex,
+		Value:            value,
+	}
+}
+
+func withdrawn(amount cadence.UFix64, from *flow.Address, txID flow.Identifier, txIndex, eventIndex int) flow.Event {
+	return transferEvent("TokensWithdrawn", "from", amount, from, txID, txIndex, eventIndex)
+}
+
+func deposited(amount cadence.UFix64, to *flow.Address, txID flow.Identifier, txIndex, eventIndex int) flow.Event {
+	return transferEvent("TokensDeposited", "to", amount, to, txID, txIndex, eventIndex)
+}
+
+func TestAdapter_Block(t *testing.T) {
+	ids := test.IdentifierGenerator()
+	tx1, tx2 := ids.New(), ids.New()
+
+	alice := flow.HexToAddress("01")
+	bob := flow.HexToAddress("02")
+
+	blockEvents := []flow.Event{
+		// Minted tokens have no owner and are skipped.
+		withdrawn(ufix64(t, "5.0"), nil, tx2, 1, 0),
+		deposited(ufix64(t, "5.0"), &bob, tx2, 1, 1),
+		deposited(ufix64(t, "10.0"), &bob, tx1, 0, 1),
+		{Type: "A.01.Other.Event", TransactionID: tx1},
+		withdrawn(ufix64(t, "10.0"), &alice, tx1, 0, 0),
+	}
+
+	block := &flow.Block{
+		BlockHeader: flow.BlockHeader{
+			ID:        ids.New(),
+			ParentID:  ids.New(),
+			Height:    42,
+			Timestamp: time.Unix(1700000000, 5e6),
+		},
+	}
+
+	adapter, err := rosetta.NewAdapter(flow.Emulator)
+	require.NoError(t, err)
+
+	result, err := adapter.Block(block, blockEvents)
+	require.NoError(t, err)
+
+	assert.Equal(t, &rosetta.BlockIdentifier{Index: 42, Hash: block.ID.Hex()}, result.BlockIdentifier)
+	assert.Equal(t, &rosetta.BlockIdentifier{Index: 41, Hash: block.ParentID.Hex()}, result.ParentBlockIdentifier)
+	assert.Equal(t, int64(1700000000005), result.Timestamp)
+
+	require.Len(t, result.Transactions, 2)
+
+	first := result.Transactions[0]
+	assert.Equal(t, tx1.Hex(), first.TransactionIdentifier.Hash)
+	require.Len(t, first.Operations, 2)
+	assert.Equal(t, "0x0000000000000001", first.Operations[0].Account.Address)
+	assert.Equal(t, "-1000000000", first.Operations[0].Amount.Value)
+	assert.Equal(t, "0x0000000000000002", first.Operations[1].Account.Address)
+	assert.Equal(t, "1000000000", first.Operations[1].Amount.Value)
+	assert.Equal(t, []*rosetta.OperationIdentifier{{Index: 0}}, first.Operations[1].RelatedOperations)
+
+	second := result.Transactions[1]
+	assert.Equal(t, tx2.Hex(), second.TransactionIdentifier.Hash)
+	require.Len(t, second.Operations, 1)
+	assert.Equal(t, int64(0), second.Operations[0].OperationIdentifier.Index)
+	assert.Nil(t, second.Operations[0].RelatedOperations)
+
+	encoded, err := json.Marshal(first.Operations[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"operation_identifier": {"index": 1},
+		"related_operations": [{"index": 0}],
+		"type": "transfer",
+		"status": "SUCCESS",
+		"account": {"address": "0x0000000000000002"},
+		"amount": {"value": "1000000000", "currency": {"symbol": "FLOW", "decimals": 8}}
+	}`, string(encoded))
+}
+
+func TestAdapter_Block_InvalidEvent(t *testing.T) {
+	adapter, err := rosetta.NewAdapter(flow.Emulator)
+	require.NoError(t, err)
+
+	_, err = adapter.Block(&flow.Block{}, []flow.Event{{Type: flowToken.EventType("TokensDeposited")}})
+	assert.True(t, errors.Is(err, flowerrors.ErrDecoding))
+}
+
+func TestAdapter_FetchBlock(t *testing.T) {
+	ctx := context.Background()
+	ids := test.IdentifierGenerator()
+	txID := ids.New()
+	account := flow.HexToAddress("01")
+
+	block := &flow.Block{BlockHeader: flow.BlockHeader{ID: ids.New(), Height: 0}}
+
+	c := mocks.NewAccessClient(t)
+	c.On("GetBlockByHeight", ctx, uint64(0)).Return(block, nil).Once()
+	c.On("GetEventsForHeightRange", ctx, client.EventRangeQuery{Type: flowToken.EventType("TokensWithdrawn")}).
+		Return([]client.BlockEvents{}, nil).
+		Once()
+	c.On("GetEventsForHeightRange", ctx, mock.MatchedBy(func(q client.EventRangeQuery) bool {
+		return q.Type == flowToken.EventType("TokensDeposited")
+	})).
+		Return([]client.BlockEvents{{Events: []flow.Event{deposited(ufix64(t, "1.0"), &account, txID, 0, 0)}}}, nil).
+		Once()
+
+	adapter, err := rosetta.NewAdapter(flow.Emulator)
+	require.NoError(t, err)
+
+	result, err := adapter.FetchBlock(ctx, c, 0)
+	require.NoError(t, err)
+
+	// The parent of the genesis block is the block itself.
+	assert.Equal(t, result.BlockIdentifier, result.ParentBlockIdentifier)
+	require.Len(t, result.Transactions, 1)
+	assert.Equal(t, "100000000", result.Transactions[0].Operations[0].Amount.Value)
+}
+
+func TestAccountBalance(t *testing.T) {
+	header := flow.BlockHeader{ID: test.IdentifierGenerator().New(), Height: 7}
+
+	balance := rosetta.AccountBalance(header, ufix64(t, "0.5"))
+
+	assert.Equal(t, &rosetta.AccountBalanceResponse{
+		BlockIdentifier: &rosetta.BlockIdentifier{Index: 7, Hash: header.ID.Hex()},
+		Balances:        []*rosetta.Amount{{Value: "50000000", Currency: rosetta.FLOW}},
+	}, balance)
+
+	assert.Equal(t, "0", rosetta.NewAmount(0, true).Value)
+}
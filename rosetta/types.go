@@ -0,0 +1,120 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package rosetta maps Flow data to the models of the Rosetta Data API, so that
+// integrations built on Rosetta, such as exchanges, can read Flow through the SDK.
+//
+// An Adapter converts blocks and the FLOW transfer events of their transactions
+// into Rosetta blocks, transactions and operations:
+//
+//	adapter, err := rosetta.NewAdapter(flow.Mainnet)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	block, err := adapter.FetchBlock(ctx, flowClient, height)
+//
+// The types of this package follow the Rosetta specification and marshal to its
+// JSON representation.
+package rosetta
+
+// Blockchain is the name of the blockchain in Rosetta network identifiers.
+const Blockchain = "flow"
+
+// Operation types and statuses.
+const (
+	// OperationTypeTransfer is the type of operations moving FLOW. Withdrawals
+	// have a negative amount, deposits a positive one.
+	OperationTypeTransfer = "transfer"
+	// OperationStatusSuccess is the status of operations of executed
+	// transactions.
+	OperationStatusSuccess = "SUCCESS"
+)
+
+// FLOW is the currency of the amounts returned by the adapter.
+var FLOW = &Currency{Symbol: "FLOW", Decimals: 8}
+
+// A NetworkIdentifier identifies a Flow network.
+type NetworkIdentifier struct {
+	Blockchain string `json:"blockchain"`
+	Network    string `json:"network"`
+}
+
+// A BlockIdentifier identifies a block by height and ID.
+type BlockIdentifier struct {
+	Index int64  `json:"index"`
+	Hash  string `json:"hash"`
+}
+
+// A Block is a block with the transactions that moved FLOW.
+type Block struct {
+	BlockIdentifier       *BlockIdentifier `json:"block_identifier"`
+	ParentBlockIdentifier *BlockIdentifier `json:"parent_block_identifier"`
+	// Timestamp is the time of the block in milliseconds since the Unix epoch.
+	Timestamp    int64          `json:"timestamp"`
+	Transactions []*Transaction `json:"transactions"`
+}
+
+// A TransactionIdentifier identifies a transaction by ID.
+type TransactionIdentifier struct {
+	Hash string `json:"hash"`
+}
+
+// A Transaction is a transaction with the operations it executed.
+type Transaction struct {
+	TransactionIdentifier *TransactionIdentifier `json:"transaction_identifier"`
+	Operations            []*Operation           `json:"operations"`
+}
+
+// An OperationIdentifier identifies an operation by its index in a transaction.
+type OperationIdentifier struct {
+	Index int64 `json:"index"`
+}
+
+// An Operation is a change to the balance of an account.
+type Operation struct {
+	OperationIdentifier *OperationIdentifier   `json:"operation_identifier"`
+	RelatedOperations   []*OperationIdentifier `json:"related_operations,omitempty"`
+	Type                string                 `json:"type"`
+	Status              *string                `json:"status,omitempty"`
+	Account             *AccountIdentifier     `json:"account,omitempty"`
+	Amount              *Amount                `json:"amount,omitempty"`
+}
+
+// An AccountIdentifier identifies an account by its 0x-prefixed address.
+type AccountIdentifier struct {
+	Address string `json:"address"`
+}
+
+// An Amount is a signed value in the smallest unit of its currency.
+type Amount struct {
+	Value    string    `json:"value"`
+	Currency *Currency `json:"currency"`
+}
+
+// A Currency is a token and the number of decimals of its amounts.
+type Currency struct {
+	Symbol   string `json:"symbol"`
+	Decimals int32  `json:"decimals"`
+}
+
+// An AccountBalanceResponse is the balance of an account at a block.
+type AccountBalanceResponse struct {
+	BlockIdentifier *BlockIdentifier `json:"block_identifier"`
+	Balances        []*Amount        `json:"balances"`
+}
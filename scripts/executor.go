@@ -0,0 +1,303 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package scripts limits the load Cadence scripts put on an access node.
+//
+// An Executor runs at most a fixed number of scripts at a time and queues the
+// others, so that a burst of reads neither overwhelms the access node nor
+// starves other requests, such as transaction submissions, sent on the same
+// connection. Queued scripts are started in turn for each caller, so a caller
+// sending many scripts does not delay the scripts of other callers:
+//
+//	executor := scripts.NewExecutor(flowClient, scripts.WithWorkers(4))
+//
+//	ctx = scripts.WithCaller(ctx, "dashboard")
+//	value, err := executor.ExecuteScriptAtLatestBlock(ctx, script, args)
+//
+// An Executor can be used wherever a script client is expected, e.g. by the
+// query package.
+package scripts
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// A Client executes Cadence scripts.
+//
+// It is satisfied by *client.Client.
+type Client interface {
+	ExecuteScriptAtLatestBlock(
+		ctx context.Context,
+		script []byte,
+		arguments []cadence.Value,
+		opts ...grpc.CallOption,
+	) (cadence.Value, error)
+}
+
+// DefaultWorkers is the default number of scripts an Executor runs at a time.
+const DefaultWorkers = 8
+
+// DefaultTimeout is the default time a script may run before it is cancelled.
+const DefaultTimeout = 30 * time.Second
+
+// ErrQueueFull is returned when a caller has reached its limit of queued scripts.
+var ErrQueueFull = flowerrors.New(flowerrors.ErrUnavailable, "scripts: queue is full")
+
+// An Executor executes scripts with a bounded number of workers.
+//
+// An Executor is safe for concurrent use.
+type Executor struct {
+	client    Client
+	workers   int
+	timeout   time.Duration
+	maxQueued int
+
+	mut     sync.Mutex
+	running int
+	queues  map[string][]*waiter
+	// callers are the callers with queued scripts, in the order they are served
+	callers []string
+}
+
+// waiter is a queued script waiting for a worker.
+type waiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// An Option configures an Executor.
+type Option func(*Executor)
+
+// WithWorkers sets the number of scripts run at a time. The default is DefaultWorkers.
+func WithWorkers(workers int) Option {
+	return func(e *Executor) {
+		e.workers = workers
+	}
+}
+
+// WithTimeout sets the time a script may run before it is cancelled, not
+// counting the time it is queued. Zero means no timeout. The default is
+// DefaultTimeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(e *Executor) {
+		e.timeout = timeout
+	}
+}
+
+// WithMaxQueued limits the number of scripts each caller can have queued.
+// Scripts over the limit fail with ErrQueueFull. Zero, the default, means no
+// limit.
+func WithMaxQueued(n int) Option {
+	return func(e *Executor) {
+		e.maxQueued = n
+	}
+}
+
+// NewExecutor returns an executor running scripts with the client.
+func NewExecutor(client Client, opts ...Option) *Executor {
+	e := &Executor{
+		client:  client,
+		workers: DefaultWorkers,
+		timeout: DefaultTimeout,
+		queues:  make(map[string][]*waiter),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	if e.workers < 1 {
+		e.workers = 1
+	}
+
+	return e
+}
+
+type callerKey struct{}
+
+// WithCaller returns a context identifying the caller of the scripts executed
+// with it. Queued scripts are started in turn for each caller; scripts
+// executed without a caller share the same turn.
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+func callerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+// ExecuteScriptAtLatestBlock executes a script against the latest sealed
+// block once a worker is available.
+//
+// If the context is done while the script is queued, the script is not
+// executed and the error of the context is returned.
+func (e *Executor) ExecuteScriptAtLatestBlock(
+	ctx context.Context,
+	script []byte,
+	arguments []cadence.Value,
+	opts ...grpc.CallOption,
+) (cadence.Value, error) {
+	err := e.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer e.release()
+
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+
+	return e.client.ExecuteScriptAtLatestBlock(ctx, script, arguments, opts...)
+}
+
+// Stats are the number of running and queued scripts of an Executor.
+type Stats struct {
+	Running int
+	Queued  int
+}
+
+// Stats returns the number of running and queued scripts.
+func (e *Executor) Stats() Stats {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+
+	queued := 0
+	for _, queue := range e.queues {
+		queued += len(queue)
+	}
+
+	return Stats{
+		Running: e.running,
+		Queued:  queued,
+	}
+}
+
+// acquire waits for a worker.
+func (e *Executor) acquire(ctx context.Context) error {
+	caller := callerFromContext(ctx)
+
+	e.mut.Lock()
+
+	if e.running < e.workers && len(e.callers) == 0 {
+		e.running++
+		e.mut.Unlock()
+		return nil
+	}
+
+	queue := e.queues[caller]
+	if e.maxQueued > 0 && len(queue) >= e.maxQueued {
+		e.mut.Unlock()
+		return ErrQueueFull
+	}
+
+	w := &waiter{ready: make(chan struct{})}
+	if len(queue) == 0 {
+		e.callers = append(e.callers, caller)
+	}
+	e.queues[caller] = append(queue, w)
+
+	e.mut.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	e.mut.Lock()
+	defer e.mut.Unlock()
+
+	if w.granted {
+		// the worker was handed over while the context was done
+		e.next()
+		return ctx.Err()
+	}
+
+	e.remove(caller, w)
+
+	return ctx.Err()
+}
+
+// release returns a worker, handing it over to the next queued script.
+func (e *Executor) release() {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+
+	e.next()
+}
+
+// next hands the worker of a finished script over to the script of the next
+// caller in turn, or frees it. It must be called with the lock held.
+func (e *Executor) next() {
+	if len(e.callers) == 0 {
+		e.running--
+		return
+	}
+
+	caller := e.callers[0]
+	e.callers = e.callers[1:]
+
+	queue := e.queues[caller]
+	w := queue[0]
+
+	if len(queue) > 1 {
+		e.queues[caller] = queue[1:]
+		e.callers = append(e.callers, caller)
+	} else {
+		delete(e.queues, caller)
+	}
+
+	w.granted = true
+	close(w.ready)
+}
+
+// remove removes a waiter from the queue of its caller. It must be called with the lock held.
+func (e *Executor) remove(caller string, w *waiter) {
+	queue := e.queues[caller]
+
+	for i, queued := range queue {
+		if queued == w {
+			queue = append(queue[:i:i], queue[i+1:]...)
+			break
+		}
+	}
+
+	if len(queue) > 0 {
+		e.queues[caller] = queue
+		return
+	}
+
+	delete(e.queues, caller)
+
+	for i, c := range e.callers {
+		if c == caller {
+			e.callers = append(e.callers[:i:i], e.callers[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,154 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/scripts"
+)
+
+// blockingClient executes scripts once they are unblocked, recording the
+// order in which they started.
+type blockingClient struct {
+	mut     sync.Mutex
+	started []string
+	unblock chan struct{}
+}
+
+func newBlockingClient() *blockingClient {
+	return &blockingClient{unblock: make(chan struct{})}
+}
+
+func (c *blockingClient) ExecuteScriptAtLatestBlock(
+	ctx context.Context,
+	script []byte,
+	_ []cadence.Value,
+	_ ...grpc.CallOption,
+) (cadence.Value, error) {
+	c.mut.Lock()
+	c.started = append(c.started, string(script))
+	c.mut.Unlock()
+
+	select {
+	case <-c.unblock:
+		return cadence.String(script), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *blockingClient) Started() []string {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return append([]string(nil), c.started...)
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	require.Eventually(t, condition, 5*time.Second, time.Millisecond)
+}
+
+func TestExecutor_Fairness(t *testing.T) {
+	client := newBlockingClient()
+	executor := scripts.NewExecutor(client, scripts.WithWorkers(1))
+
+	var wg sync.WaitGroup
+	execute := func(caller, script string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := executor.ExecuteScriptAtLatestBlock(scripts.WithCaller(context.Background(), caller), []byte(script), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, cadence.String(script), value)
+		}()
+	}
+
+	execute("a", "a0")
+	waitFor(t, func() bool { return executor.Stats().Running == 1 })
+
+	// a burst of scripts of caller a does not delay the script of caller b
+	execute("a", "a1")
+	waitFor(t, func() bool { return executor.Stats().Queued == 1 })
+	execute("a", "a2")
+	waitFor(t, func() bool { return executor.Stats().Queued == 2 })
+	execute("b", "b1")
+	waitFor(t, func() bool { return executor.Stats().Queued == 3 })
+
+	close(client.unblock)
+	wg.Wait()
+
+	assert.Equal(t, []string{"a0", "a1", "b1", "a2"}, client.Started())
+	assert.Equal(t, scripts.Stats{}, executor.Stats())
+}
+
+func TestExecutor_Timeout(t *testing.T) {
+	client := newBlockingClient()
+	executor := scripts.NewExecutor(client, scripts.WithTimeout(10*time.Millisecond))
+
+	_, err := executor.ExecuteScriptAtLatestBlock(context.Background(), []byte("slow"), nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, scripts.Stats{}, executor.Stats())
+}
+
+func TestExecutor_Queue(t *testing.T) {
+	client := newBlockingClient()
+	executor := scripts.NewExecutor(client, scripts.WithWorkers(1), scripts.WithMaxQueued(1))
+
+	done := make(chan error, 2)
+	go func() {
+		_, err := executor.ExecuteScriptAtLatestBlock(context.Background(), []byte("running"), nil)
+		done <- err
+	}()
+	waitFor(t, func() bool { return executor.Stats().Running == 1 })
+
+	go func() {
+		_, err := executor.ExecuteScriptAtLatestBlock(context.Background(), []byte("queued"), nil)
+		done <- err
+	}()
+	waitFor(t, func() bool { return executor.Stats().Queued == 1 })
+
+	_, err := executor.ExecuteScriptAtLatestBlock(context.Background(), []byte("rejected"), nil)
+	assert.True(t, errors.Is(err, scripts.ErrQueueFull))
+	assert.True(t, errors.Is(err, flowerrors.ErrUnavailable))
+
+	// a queued script is abandoned when its context is done
+	ctx, cancel := context.WithTimeout(scripts.WithCaller(context.Background(), "other"), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = executor.ExecuteScriptAtLatestBlock(ctx, []byte("cancelled"), nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, scripts.Stats{Running: 1, Queued: 1}, executor.Stats())
+
+	close(client.unblock)
+	require.NoError(t, <-done)
+	require.NoError(t, <-done)
+
+	assert.Equal(t, []string{"running", "queued"}, client.Started())
+	assert.Equal(t, scripts.Stats{}, executor.Stats())
+}
@@ -0,0 +1,320 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/json"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// List of protocol service event types.
+//
+// Service events are emitted by the system chunk of a block and are included in
+// the execution result for that block.
+const (
+	ServiceEventEpochSetup    string = "setup"
+	ServiceEventEpochCommit   string = "commit"
+	ServiceEventVersionBeacon string = "version-beacon"
+
+	ServiceEventProtocolStateVersionUpgrade string = "protocol-state-version-upgrade"
+	ServiceEventSetEpochExtensionViewCount  string = "set-epoch-extension-view-count"
+	ServiceEventEjectNode                   string = "eject-node"
+)
+
+// An EpochSetup service event is emitted when the epoch setup phase begins.
+//
+// It contains the participants and the schedule of the upcoming epoch.
+type EpochSetup struct {
+	Counter            uint64
+	FirstView          uint64
+	DKGPhase1FinalView uint64
+	DKGPhase2FinalView uint64
+	DKGPhase3FinalView uint64
+	FinalView          uint64
+	Participants       []*EpochParticipant
+	// Assignments lists the node IDs of the members of each collection cluster.
+	Assignments    [][]Identifier
+	RandomSource   []byte
+	TargetDuration uint64
+	TargetEndTime  uint64
+}
+
+// An EpochParticipant is a node taking part in an epoch.
+type EpochParticipant struct {
+	NodeID        Identifier
+	Address       string
+	Role          string
+	InitialWeight uint64
+	StakingPubKey []byte
+	NetworkPubKey []byte
+}
+
+// An EpochCommit service event is emitted when the epoch setup phase completes.
+//
+// It contains the results of the cluster quorum certificate and DKG processes.
+type EpochCommit struct {
+	Counter    uint64
+	ClusterQCs []*ClusterQCVoteData
+	// DKGGroupKey is the hex-encoded group public key of the random beacon.
+	DKGGroupKey string
+	// DKGParticipantKeys are the hex-encoded public key shares of the random beacon participants.
+	DKGParticipantKeys []string
+}
+
+// ClusterQCVoteData is the aggregated vote of a collection cluster on its root block.
+type ClusterQCVoteData struct {
+	SigData  []byte
+	VoterIDs []Identifier
+}
+
+// A VersionBeacon service event specifies the node software versions required
+// from given block heights onwards.
+type VersionBeacon struct {
+	VersionBoundaries []VersionBoundary
+	Sequence          uint64
+}
+
+// A VersionBoundary is the minimum node software version required from a block height.
+type VersionBoundary struct {
+	BlockHeight uint64
+	Version     string
+}
+
+// A ProtocolStateVersionUpgrade service event schedules an upgrade of the protocol state
+// to a new version, taking effect at the given view.
+type ProtocolStateVersionUpgrade struct {
+	NewProtocolStateVersion uint64
+	ActiveView              uint64
+}
+
+// A SetEpochExtensionViewCount service event changes the number of views by which
+// an epoch is extended if the epoch transition fails.
+type SetEpochExtensionViewCount struct {
+	Value uint64
+}
+
+// An EjectNode service event ejects a node from the network.
+type EjectNode struct {
+	NodeID Identifier
+}
+
+// Decode decodes the payload of this service event into its typed representation.
+//
+// The returned value is one of *EpochSetup, *EpochCommit, *VersionBeacon,
+// *ProtocolStateVersionUpgrade, *SetEpochExtensionViewCount or *EjectNode. An error
+// is returned if the service event type is not supported.
+func (e ServiceEvent) Decode() (interface{}, error) {
+	switch e.Type {
+	case ServiceEventEpochSetup:
+		return DecodeEpochSetup(e.Payload)
+	case ServiceEventEpochCommit:
+		return DecodeEpochCommit(e.Payload)
+	case ServiceEventVersionBeacon:
+		return DecodeVersionBeacon(e.Payload)
+	case ServiceEventProtocolStateVersionUpgrade:
+		return DecodeProtocolStateVersionUpgrade(e.Payload)
+	case ServiceEventSetEpochExtensionViewCount:
+		return DecodeSetEpochExtensionViewCount(e.Payload)
+	case ServiceEventEjectNode:
+		return DecodeEjectNode(e.Payload)
+	default:
+		return nil, flowerrors.Errorf(flowerrors.ErrUnsupported, "unsupported service event type: %s", e.Type)
+	}
+}
+
+// DecodeEpochSetup decodes the JSON payload of an EpochSetup service event.
+func DecodeEpochSetup(payload []byte) (*EpochSetup, error) {
+	var temp struct {
+		Counter            uint64
+		FirstView          uint64
+		DKGPhase1FinalView uint64
+		DKGPhase2FinalView uint64
+		DKGPhase3FinalView uint64
+		FinalView          uint64
+		Participants       []struct {
+			NodeID        string
+			Address       string
+			Role          string
+			InitialWeight uint64
+			Weight        uint64
+			StakingPubKey []byte
+			NetworkPubKey []byte
+		}
+		Assignments    [][]string
+		RandomSource   []byte
+		TargetDuration uint64
+		TargetEndTime  uint64
+	}
+
+	err := json.Unmarshal(payload, &temp)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "failed to decode %s service event: %w", ServiceEventEpochSetup, err)
+	}
+
+	participants := make([]*EpochParticipant, len(temp.Participants))
+	for i, p := range temp.Participants {
+		nodeID, err := ParseID(p.NodeID)
+		if err != nil {
+			return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "failed to decode %s service event: %w", ServiceEventEpochSetup, err)
+		}
+
+		weight := p.InitialWeight
+		if weight == 0 {
+			// older protocol versions report the weight under a different name
+			weight = p.Weight
+		}
+
+		participants[i] = &EpochParticipant{
+			NodeID:        nodeID,
+			Address:       p.Address,
+			Role:          p.Role,
+			InitialWeight: weight,
+			StakingPubKey: p.StakingPubKey,
+			NetworkPubKey: p.NetworkPubKey,
+		}
+	}
+
+	assignments := make([][]Identifier, len(temp.Assignments))
+	for i, cluster := range temp.Assignments {
+		assignments[i], err = parseIDs(cluster)
+		if err != nil {
+			return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "failed to decode %s service event: %w", ServiceEventEpochSetup, err)
+		}
+	}
+
+	return &EpochSetup{
+		Counter:            temp.Counter,
+		FirstView:          temp.FirstView,
+		DKGPhase1FinalView: temp.DKGPhase1FinalView,
+		DKGPhase2FinalView: temp.DKGPhase2FinalView,
+		DKGPhase3FinalView: temp.DKGPhase3FinalView,
+		FinalView:          temp.FinalView,
+		Participants:       participants,
+		Assignments:        assignments,
+		RandomSource:       temp.RandomSource,
+		TargetDuration:     temp.TargetDuration,
+		TargetEndTime:      temp.TargetEndTime,
+	}, nil
+}
+
+// DecodeEpochCommit decodes the JSON payload of an EpochCommit service event.
+func DecodeEpochCommit(payload []byte) (*EpochCommit, error) {
+	var temp struct {
+		Counter    uint64
+		ClusterQCs []struct {
+			SigData  []byte
+			VoterIDs []string
+		}
+		DKGGroupKey        string
+		DKGParticipantKeys []string
+	}
+
+	err := json.Unmarshal(payload, &temp)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "failed to decode %s service event: %w", ServiceEventEpochCommit, err)
+	}
+
+	qcs := make([]*ClusterQCVoteData, len(temp.ClusterQCs))
+	for i, qc := range temp.ClusterQCs {
+		voterIDs, err := parseIDs(qc.VoterIDs)
+		if err != nil {
+			return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "failed to decode %s service event: %w", ServiceEventEpochCommit, err)
+		}
+
+		qcs[i] = &ClusterQCVoteData{
+			SigData:  qc.SigData,
+			VoterIDs: voterIDs,
+		}
+	}
+
+	return &EpochCommit{
+		Counter:            temp.Counter,
+		ClusterQCs:         qcs,
+		DKGGroupKey:        temp.DKGGroupKey,
+		DKGParticipantKeys: temp.DKGParticipantKeys,
+	}, nil
+}
+
+// DecodeVersionBeacon decodes the JSON payload of a VersionBeacon service event.
+func DecodeVersionBeacon(payload []byte) (*VersionBeacon, error) {
+	var beacon VersionBeacon
+
+	err := json.Unmarshal(payload, &beacon)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "failed to decode %s service event: %w", ServiceEventVersionBeacon, err)
+	}
+
+	return &beacon, nil
+}
+
+// DecodeProtocolStateVersionUpgrade decodes the JSON payload of a ProtocolStateVersionUpgrade service event.
+func DecodeProtocolStateVersionUpgrade(payload []byte) (*ProtocolStateVersionUpgrade, error) {
+	var upgrade ProtocolStateVersionUpgrade
+
+	err := json.Unmarshal(payload, &upgrade)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "failed to decode %s service event: %w", ServiceEventProtocolStateVersionUpgrade, err)
+	}
+
+	return &upgrade, nil
+}
+
+// DecodeSetEpochExtensionViewCount decodes the JSON payload of a SetEpochExtensionViewCount service event.
+func DecodeSetEpochExtensionViewCount(payload []byte) (*SetEpochExtensionViewCount, error) {
+	var count SetEpochExtensionViewCount
+
+	err := json.Unmarshal(payload, &count)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "failed to decode %s service event: %w", ServiceEventSetEpochExtensionViewCount, err)
+	}
+
+	return &count, nil
+}
+
+// DecodeEjectNode decodes the JSON payload of an EjectNode service event.
+func DecodeEjectNode(payload []byte) (*EjectNode, error) {
+	var temp struct {
+		NodeID string
+	}
+
+	err := json.Unmarshal(payload, &temp)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "failed to decode %s service event: %w", ServiceEventEjectNode, err)
+	}
+
+	nodeID, err := ParseID(temp.NodeID)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "failed to decode %s service event: %w", ServiceEventEjectNode, err)
+	}
+
+	return &EjectNode{NodeID: nodeID}, nil
+}
+
+func parseIDs(l []string) ([]Identifier, error) {
+	ids := make([]Identifier, len(l))
+	for i, h := range l {
+		id, err := ParseID(h)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
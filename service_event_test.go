@@ -0,0 +1,176 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+const nodeIDHex = "0000000000000000000000000000000000000000000000000000000000000001"
+
+func TestServiceEvent_Decode(t *testing.T) {
+	t.Run("EpochSetup", func(t *testing.T) {
+		event := flow.ServiceEvent{
+			Type: flow.ServiceEventEpochSetup,
+			Payload: []byte(`{
+				"Counter": 2,
+				"FirstView": 100,
+				"FinalView": 200,
+				"Participants": [
+					{"NodeID": "` + nodeIDHex + `", "Address": "node:3569", "Role": "collection", "InitialWeight": 1000}
+				],
+				"Assignments": [["` + nodeIDHex + `"]],
+				"RandomSource": "AQID"
+			}`),
+		}
+
+		value, err := event.Decode()
+		require.NoError(t, err)
+
+		setup, ok := value.(*flow.EpochSetup)
+		require.True(t, ok)
+
+		assert.Equal(t, uint64(2), setup.Counter)
+		assert.Equal(t, uint64(100), setup.FirstView)
+		assert.Equal(t, uint64(200), setup.FinalView)
+		require.Len(t, setup.Participants, 1)
+		assert.Equal(t, flow.HexToID(nodeIDHex), setup.Participants[0].NodeID)
+		assert.Equal(t, "collection", setup.Participants[0].Role)
+		assert.Equal(t, uint64(1000), setup.Participants[0].InitialWeight)
+		assert.Equal(t, [][]flow.Identifier{{flow.HexToID(nodeIDHex)}}, setup.Assignments)
+		assert.Equal(t, []byte{1, 2, 3}, setup.RandomSource)
+	})
+
+	t.Run("EpochCommit", func(t *testing.T) {
+		event := flow.ServiceEvent{
+			Type: flow.ServiceEventEpochCommit,
+			Payload: []byte(`{
+				"Counter": 2,
+				"ClusterQCs": [{"SigData": "AQID", "VoterIDs": ["` + nodeIDHex + `"]}],
+				"DKGGroupKey": "abcd",
+				"DKGParticipantKeys": ["ef01"]
+			}`),
+		}
+
+		value, err := event.Decode()
+		require.NoError(t, err)
+
+		commit, ok := value.(*flow.EpochCommit)
+		require.True(t, ok)
+
+		assert.Equal(t, uint64(2), commit.Counter)
+		require.Len(t, commit.ClusterQCs, 1)
+		assert.Equal(t, []flow.Identifier{flow.HexToID(nodeIDHex)}, commit.ClusterQCs[0].VoterIDs)
+		assert.Equal(t, "abcd", commit.DKGGroupKey)
+		assert.Equal(t, []string{"ef01"}, commit.DKGParticipantKeys)
+	})
+
+	t.Run("VersionBeacon", func(t *testing.T) {
+		event := flow.ServiceEvent{
+			Type:    flow.ServiceEventVersionBeacon,
+			Payload: []byte(`{"VersionBoundaries": [{"BlockHeight": 42, "Version": "0.33.0"}], "Sequence": 7}`),
+		}
+
+		value, err := event.Decode()
+		require.NoError(t, err)
+
+		assert.Equal(t, &flow.VersionBeacon{
+			VersionBoundaries: []flow.VersionBoundary{{BlockHeight: 42, Version: "0.33.0"}},
+			Sequence:          7,
+		}, value)
+	})
+
+	t.Run("ProtocolStateVersionUpgrade", func(t *testing.T) {
+		event := flow.ServiceEvent{
+			Type:    flow.ServiceEventProtocolStateVersionUpgrade,
+			Payload: []byte(`{"NewProtocolStateVersion": 2, "ActiveView": 1000}`),
+		}
+
+		value, err := event.Decode()
+		require.NoError(t, err)
+
+		assert.Equal(t, &flow.ProtocolStateVersionUpgrade{NewProtocolStateVersion: 2, ActiveView: 1000}, value)
+	})
+
+	t.Run("SetEpochExtensionViewCount", func(t *testing.T) {
+		event := flow.ServiceEvent{
+			Type:    flow.ServiceEventSetEpochExtensionViewCount,
+			Payload: []byte(`{"Value": 100000}`),
+		}
+
+		value, err := event.Decode()
+		require.NoError(t, err)
+
+		assert.Equal(t, &flow.SetEpochExtensionViewCount{Value: 100000}, value)
+	})
+
+	t.Run("EjectNode", func(t *testing.T) {
+		event := flow.ServiceEvent{
+			Type:    flow.ServiceEventEjectNode,
+			Payload: []byte(`{"NodeID": "` + nodeIDHex + `"}`),
+		}
+
+		value, err := event.Decode()
+		require.NoError(t, err)
+
+		assert.Equal(t, &flow.EjectNode{NodeID: flow.HexToID(nodeIDHex)}, value)
+	})
+
+	t.Run("Unsupported type", func(t *testing.T) {
+		_, err := flow.ServiceEvent{Type: "foo"}.Decode()
+		assert.Error(t, err)
+	})
+
+	t.Run("Malformed payload", func(t *testing.T) {
+		_, err := flow.ServiceEvent{Type: flow.ServiceEventEpochSetup, Payload: []byte("{")}.Decode()
+		assert.Error(t, err)
+	})
+
+	t.Run("Malformed identifiers", func(t *testing.T) {
+		events := []flow.ServiceEvent{
+			{
+				Type:    flow.ServiceEventEpochSetup,
+				Payload: []byte(`{"Participants": [{"NodeID": "0102"}]}`),
+			},
+			{
+				Type:    flow.ServiceEventEpochSetup,
+				Payload: []byte(`{"Assignments": [["` + nodeIDHex + `", "zz"]]}`),
+			},
+			{
+				Type:    flow.ServiceEventEpochCommit,
+				Payload: []byte(`{"ClusterQCs": [{"VoterIDs": ["0102"]}]}`),
+			},
+			{
+				Type:    flow.ServiceEventEjectNode,
+				Payload: []byte(`{"NodeID": "not hex"}`),
+			},
+		}
+
+		for _, event := range events {
+			_, err := event.Decode()
+			assert.ErrorIs(t, err, flowerrors.ErrDecoding, event.Type)
+		}
+	})
+}
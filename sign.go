@@ -19,40 +19,144 @@
 package flow
 
 import (
-	"fmt"
+	"bytes"
+	"sync"
 
 	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
 )
 
 const domainTagLength = 32
 
-// TransactionDomainTag is the prefix of all signed transaction payloads.
+// A DomainTag is the prefix of a signed message that identifies the domain the
+// message belongs to, so that a signature in one domain cannot be replayed in
+// another.
 //
 // A domain tag is encoded as UTF-8 bytes, right padded to a total length of 32 bytes.
+type DomainTag [domainTagLength]byte
+
+// TransactionDomainTag is the prefix of all signed transaction payloads.
 var TransactionDomainTag = paddedDomainTag("FLOW-V0.0-transaction")
 
 // UserDomainTag is the prefix of all signed user space payloads.
-//
-// A domain tag is encoded as UTF-8 bytes, right padded to a total length of 32 bytes.
 var UserDomainTag = paddedDomainTag("FLOW-V0.0-user")
 
-func paddedDomainTag(s string) [domainTagLength]byte {
-	var tag [domainTagLength]byte
-
-	if len(s) > domainTagLength {
-		panic(fmt.Sprintf("domain tag %s cannot be longer than %d characters", s, domainTagLength))
+// NewDomainTag returns the domain tag with the given name.
+//
+// An error is returned if the name is empty or longer than 32 bytes.
+func NewDomainTag(name string) (DomainTag, error) {
+	if name == "" || len(name) > domainTagLength {
+		return DomainTag{}, flowerrors.Errorf(
+			flowerrors.ErrInvalidArgument,
+			"domain tag %q must be between 1 and %d bytes long",
+			name,
+			domainTagLength,
+		)
 	}
 
-	copy(tag[:], s)
+	var tag DomainTag
+	copy(tag[:], name)
+
+	return tag, nil
+}
+
+func paddedDomainTag(s string) DomainTag {
+	tag, err := NewDomainTag(s)
+	if err != nil {
+		panic(err)
+	}
 
 	return tag
 }
 
+// String returns the name of the domain tag, without padding.
+func (t DomainTag) String() string {
+	return string(bytes.TrimRight(t[:], "\x00"))
+}
+
+// Bytes returns the padded domain tag.
+func (t DomainTag) Bytes() []byte {
+	return t[:]
+}
+
+// Tag returns the message prefixed with the domain tag. The message is not modified.
+func (t DomainTag) Tag(message []byte) []byte {
+	tagged := make([]byte, 0, domainTagLength+len(message))
+	tagged = append(tagged, t[:]...)
+
+	return append(tagged, message...)
+}
+
+// Untag returns the message without its domain tag prefix.
+//
+// An error is returned if the message is not prefixed with the domain tag.
+func (t DomainTag) Untag(message []byte) ([]byte, error) {
+	if !t.Tags(message) {
+		return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "message is not tagged with domain tag %s", t)
+	}
+
+	return message[domainTagLength:], nil
+}
+
+// Tags returns true if the message is prefixed with the domain tag.
+func (t DomainTag) Tags(message []byte) bool {
+	return bytes.HasPrefix(message, t[:])
+}
+
+var domainTags = struct {
+	sync.RWMutex
+	tags []DomainTag
+}{
+	tags: []DomainTag{TransactionDomainTag, UserDomainTag},
+}
+
+// RegisterDomainTag adds a domain tag to the tags recognized by UntagMessage,
+// e.g. the tag of an application-specific signing domain.
+//
+// An error is returned if the tag is already registered.
+func RegisterDomainTag(tag DomainTag) error {
+	domainTags.Lock()
+	defer domainTags.Unlock()
+
+	for _, t := range domainTags.tags {
+		if t == tag {
+			return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "domain tag %s is already registered", tag)
+		}
+	}
+
+	domainTags.tags = append(domainTags.tags, tag)
+
+	return nil
+}
+
+// DomainTags returns the registered domain tags, starting with
+// TransactionDomainTag and UserDomainTag.
+func DomainTags() []DomainTag {
+	domainTags.RLock()
+	defer domainTags.RUnlock()
+
+	return append([]DomainTag(nil), domainTags.tags...)
+}
+
+// UntagMessage returns the registered domain tag a message is prefixed with,
+// and the message without it.
+//
+// An error wrapping flowerrors.ErrNotFound is returned if the message is not
+// prefixed with any registered domain tag.
+func UntagMessage(message []byte) (DomainTag, []byte, error) {
+	for _, tag := range DomainTags() {
+		if tag.Tags(message) {
+			return tag, message[domainTagLength:], nil
+		}
+	}
+
+	return DomainTag{}, nil, flowerrors.New(flowerrors.ErrNotFound, "message is not tagged with a registered domain tag")
+}
+
 // SignUserMessage signs a message in the user domain.
 //
 // User messages are distinct from other signed messages (i.e. transactions), and can be
 // verified directly in on-chain Cadence code.
 func SignUserMessage(signer crypto.Signer, message []byte) ([]byte, error) {
-	message = append(UserDomainTag[:], message...)
-	return signer.Sign(message)
+	return SignAndAudit(signer, UserDomainTag.Tag(message), SignatureRecord{KeyIndex: -1})
 }
@@ -0,0 +1,93 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+func TestDomainTag(t *testing.T) {
+	assert.Equal(t, "FLOW-V0.0-transaction", flow.TransactionDomainTag.String())
+	assert.Equal(t, "FLOW-V0.0-user", flow.UserDomainTag.String())
+	assert.Equal(
+		t,
+		"464c4f572d56302e302d7472616e73616374696f6e0000000000000000000000",
+		hex.EncodeToString(flow.TransactionDomainTag.Bytes()),
+	)
+
+	message := []byte("hello")
+
+	tagged := flow.UserDomainTag.Tag(message)
+	assert.Len(t, tagged, 32+len(message))
+	assert.True(t, flow.UserDomainTag.Tags(tagged))
+	assert.False(t, flow.TransactionDomainTag.Tags(tagged))
+
+	untagged, err := flow.UserDomainTag.Untag(tagged)
+	require.NoError(t, err)
+	assert.Equal(t, message, untagged)
+
+	_, err = flow.TransactionDomainTag.Untag(tagged)
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+}
+
+func TestNewDomainTag(t *testing.T) {
+	tag, err := flow.NewDomainTag("ACME-V1-order")
+	require.NoError(t, err)
+	assert.Equal(t, "ACME-V1-order", tag.String())
+
+	_, err = flow.NewDomainTag("")
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+
+	_, err = flow.NewDomainTag(strings.Repeat("x", 33))
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+}
+
+func TestRegisterDomainTag(t *testing.T) {
+	tag, err := flow.NewDomainTag("TEST-V0-register")
+	require.NoError(t, err)
+
+	message := tag.Tag([]byte{1, 2, 3})
+
+	_, _, err = flow.UntagMessage(message)
+	assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+
+	require.NoError(t, flow.RegisterDomainTag(tag))
+	assert.Contains(t, flow.DomainTags(), tag)
+
+	err = flow.RegisterDomainTag(tag)
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+
+	found, untagged, err := flow.UntagMessage(message)
+	require.NoError(t, err)
+	assert.Equal(t, tag, found)
+	assert.Equal(t, []byte{1, 2, 3}, untagged)
+
+	found, _, err = flow.UntagMessage(flow.TransactionDomainTag.Tag(nil))
+	require.NoError(t, err)
+	assert.Equal(t, flow.TransactionDomainTag, found)
+}
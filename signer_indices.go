@@ -0,0 +1,107 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// signerIndicesChecksumLength is the length of the committee checksum prefixing encoded signer indices.
+const signerIndicesChecksumLength = 4
+
+// DecodeSignerIndices decodes signer indices into the IDs of the signing nodes.
+//
+// Signer indices are a bit vector over the committee, canonically ordered as on chain,
+// prefixed with a CRC-32 checksum of the committee's node IDs. The checksum guards
+// against decoding the indices against the wrong committee, e.g. the members of
+// another cluster or epoch.
+//
+// An error is returned if the checksum does not match the committee or if the
+// bit vector is malformed.
+func DecodeSignerIndices(committee []Identifier, signerIndices []byte) ([]Identifier, error) {
+	vectorLength := (len(committee) + 7) / 8
+
+	if len(signerIndices) != signerIndicesChecksumLength+vectorLength {
+		return nil, flowerrors.Errorf(
+			flowerrors.ErrDecoding,
+			"signer indices have length %d, expected %d for a committee of %d",
+			len(signerIndices),
+			signerIndicesChecksumLength+vectorLength,
+			len(committee),
+		)
+	}
+
+	checksum := binary.BigEndian.Uint32(signerIndices[:signerIndicesChecksumLength])
+	if checksum != committeeChecksum(committee) {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "signer indices checksum %08x does not match committee", checksum)
+	}
+
+	vector := signerIndices[signerIndicesChecksumLength:]
+
+	signers := make([]Identifier, 0, len(committee))
+	for i, nodeID := range committee {
+		if vector[i/8]&(1<<(7-uint(i%8))) != 0 {
+			signers = append(signers, nodeID)
+		}
+	}
+
+	// bits beyond the committee size must be zero
+	for i := len(committee); i < vectorLength*8; i++ {
+		if vector[i/8]&(1<<(7-uint(i%8))) != 0 {
+			return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "signer indices have non-zero padding bit %d", i)
+		}
+	}
+
+	return signers, nil
+}
+
+// EncodeSignerIndices encodes the IDs of the signing nodes as signer indices over the committee.
+//
+// An error is returned if a signer is not a member of the committee.
+func EncodeSignerIndices(committee []Identifier, signers []Identifier) ([]byte, error) {
+	positions := make(map[Identifier]int, len(committee))
+	for i, nodeID := range committee {
+		positions[nodeID] = i
+	}
+
+	signerIndices := make([]byte, signerIndicesChecksumLength+(len(committee)+7)/8)
+	binary.BigEndian.PutUint32(signerIndices, committeeChecksum(committee))
+
+	vector := signerIndices[signerIndicesChecksumLength:]
+	for _, signer := range signers {
+		i, ok := positions[signer]
+		if !ok {
+			return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "signer %s is not a member of the committee", signer)
+		}
+		vector[i/8] |= 1 << (7 - uint(i%8))
+	}
+
+	return signerIndices, nil
+}
+
+func committeeChecksum(committee []Identifier) uint32 {
+	data := make([]byte, 0, len(committee)*len(Identifier{}))
+	for _, nodeID := range committee {
+		data = append(data, nodeID[:]...)
+	}
+	return crc32.ChecksumIEEE(data)
+}
@@ -0,0 +1,95 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestSignerIndices(t *testing.T) {
+	ids := test.IdentifierGenerator()
+
+	committee := make([]flow.Identifier, 10)
+	for i := range committee {
+		committee[i] = ids.New()
+	}
+
+	t.Run("Round trip", func(t *testing.T) {
+		signers := []flow.Identifier{committee[0], committee[3], committee[9]}
+
+		indices, err := flow.EncodeSignerIndices(committee, signers)
+		require.NoError(t, err)
+
+		// 4 byte checksum followed by 2 bytes for 10 committee members
+		require.Len(t, indices, 6)
+		assert.Equal(t, []byte{0b10010000, 0b01000000}, indices[4:])
+
+		decoded, err := flow.DecodeSignerIndices(committee, indices)
+		require.NoError(t, err)
+		assert.Equal(t, signers, decoded)
+	})
+
+	t.Run("Committee order", func(t *testing.T) {
+		indices, err := flow.EncodeSignerIndices(committee, []flow.Identifier{committee[5], committee[1]})
+		require.NoError(t, err)
+
+		decoded, err := flow.DecodeSignerIndices(committee, indices)
+		require.NoError(t, err)
+		assert.Equal(t, []flow.Identifier{committee[1], committee[5]}, decoded)
+	})
+
+	t.Run("Wrong committee", func(t *testing.T) {
+		indices, err := flow.EncodeSignerIndices(committee, committee[:2])
+		require.NoError(t, err)
+
+		reordered := append([]flow.Identifier{committee[1], committee[0]}, committee[2:]...)
+
+		_, err = flow.DecodeSignerIndices(reordered, indices)
+		assert.Error(t, err)
+	})
+
+	t.Run("Wrong length", func(t *testing.T) {
+		indices, err := flow.EncodeSignerIndices(committee, committee[:2])
+		require.NoError(t, err)
+
+		_, err = flow.DecodeSignerIndices(committee, indices[:5])
+		assert.Error(t, err)
+	})
+
+	t.Run("Non-zero padding", func(t *testing.T) {
+		indices, err := flow.EncodeSignerIndices(committee, committee[:2])
+		require.NoError(t, err)
+
+		indices[5] |= 1
+
+		_, err = flow.DecodeSignerIndices(committee, indices)
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown signer", func(t *testing.T) {
+		_, err := flow.EncodeSignerIndices(committee, []flow.Identifier{ids.New()})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,313 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package systemcontracts provides the canonical addresses of the core
+// contracts deployed to each Flow network.
+//
+// Use it to build imports and event types instead of hardcoding addresses, so
+// that the same code works on the emulator, Testnet and Mainnet:
+//
+//	contracts, err := systemcontracts.ForChain(flow.Testnet)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	script := contracts.FungibleToken.Import() + "\n" + contracts.FlowToken.Import() + "\n..."
+package systemcontracts
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// Names of the core contracts.
+const (
+	ContractNameFungibleToken              = "FungibleToken"
+	ContractNameFungibleTokenMetadataViews = "FungibleTokenMetadataViews"
+	ContractNameFlowToken                  = "FlowToken"
+	ContractNameNonFungibleToken           = "NonFungibleToken"
+	ContractNameMetadataViews              = "MetadataViews"
+	ContractNameViewResolver               = "ViewResolver"
+	ContractNameBurner                     = "Burner"
+	ContractNameFlowFees                   = "FlowFees"
+	ContractNameFlowServiceAccount         = "FlowServiceAccount"
+	ContractNameFlowStorageFees            = "FlowStorageFees"
+	ContractNameFlowIDTableStaking         = "FlowIDTableStaking"
+	ContractNameFlowEpoch                  = "FlowEpoch"
+	ContractNameFlowClusterQC              = "FlowClusterQC"
+	ContractNameFlowDKG                    = "FlowDKG"
+	ContractNameLockedTokens               = "LockedTokens"
+	ContractNameStakingProxy               = "StakingProxy"
+	ContractNameFlowStakingCollection      = "FlowStakingCollection"
+	ContractNameNodeVersionBeacon          = "NodeVersionBeacon"
+	ContractNameRandomBeaconHistory        = "RandomBeaconHistory"
+	ContractNameEVM                        = "EVM"
+)
+
+// A Contract is a contract deployed to an account.
+type Contract struct {
+	Name    string
+	Address flow.Address
+}
+
+// Location returns the location of the contract, e.g. "A.f233dcee88fe0abe.FungibleToken".
+func (c Contract) Location() string {
+	return fmt.Sprintf("A.%s.%s", c.Address.Hex(), c.Name)
+}
+
+// EventType returns the fully-qualified type of an event defined by the contract,
+// e.g. "A.f233dcee88fe0abe.FungibleToken.TokensDeposited".
+func (c Contract) EventType(event string) string {
+	return c.Location() + "." + event
+}
+
+// Import returns a Cadence import statement for the contract,
+// e.g. "import FungibleToken from 0xf233dcee88fe0abe".
+func (c Contract) Import() string {
+	return fmt.Sprintf("import %s from 0x%s", c.Name, c.Address.Hex())
+}
+
+// Contracts are the core contracts of a network.
+//
+// Contracts introduced by the Crescendo upgrade, Burner and EVM, are zero on
+// networks that run Cadence before version 1.0, and are omitted from All.
+type Contracts struct {
+	ChainID        flow.ChainID
+	CadenceVersion flow.CadenceVersion
+
+	FungibleToken              Contract
+	FungibleTokenMetadataViews Contract
+	FlowToken                  Contract
+	NonFungibleToken           Contract
+	MetadataViews              Contract
+	ViewResolver               Contract
+	Burner                     Contract
+	FlowFees                   Contract
+	FlowServiceAccount         Contract
+	FlowStorageFees            Contract
+	FlowIDTableStaking         Contract
+	FlowEpoch                  Contract
+	FlowClusterQC              Contract
+	FlowDKG                    Contract
+	LockedTokens               Contract
+	StakingProxy               Contract
+	FlowStakingCollection      Contract
+	NodeVersionBeacon          Contract
+	RandomBeaconHistory        Contract
+	EVM                        Contract
+}
+
+// networkAddresses are the accounts the core contracts are deployed to on a network.
+type networkAddresses struct {
+	serviceAccount   string
+	fungibleToken    string
+	flowToken        string
+	nonFungibleToken string
+	flowFees         string
+	staking          string
+	lockedTokens     string
+	stakingProxy     string
+}
+
+var networks = map[flow.ChainID]networkAddresses{
+	flow.Mainnet: {
+		serviceAccount:   "e467b9dd11fa00df",
+		fungibleToken:    "f233dcee88fe0abe",
+		flowToken:        "1654653399040a61",
+		nonFungibleToken: "1d7e57aa55817448",
+		flowFees:         "f919ee77447b7497",
+		staking:          "8624b52f9ddcd04a",
+		lockedTokens:     "8d0e87b65159ae63",
+		stakingProxy:     "62430cf28c26d095",
+	},
+	flow.Testnet: {
+		serviceAccount:   "8c5303eaa26202d6",
+		fungibleToken:    "9a0766d93b6608b7",
+		flowToken:        "7e60df042a9c0868",
+		nonFungibleToken: "631e88ae7f1d7c20",
+		flowFees:         "912d5440f7e3769e",
+		staking:          "9eca2b38b18b5dfe",
+		lockedTokens:     "95e019a17d0e23d7",
+		stakingProxy:     "7aad92e5a0715d21",
+	},
+	flow.Emulator: {
+		serviceAccount:   "f8d6e0586b0a20c7",
+		fungibleToken:    "ee82856bf20e2aa6",
+		flowToken:        "0ae53cb6e3f42a79",
+		nonFungibleToken: "f8d6e0586b0a20c7",
+		flowFees:         "e5a8b7f23e8b548f",
+		staking:          "f8d6e0586b0a20c7",
+		lockedTokens:     "f8d6e0586b0a20c7",
+		stakingProxy:     "f8d6e0586b0a20c7",
+	},
+}
+
+// ForChain returns the core contracts of the network with the given chain ID,
+// as deployed since the Crescendo upgrade to Cadence 1.0.
+//
+// An error is returned if the chain ID is not one of the chain IDs known to the SDK.
+func ForChain(chainID flow.ChainID) (*Contracts, error) {
+	return ForCadence(chainID, flow.CadenceV1)
+}
+
+// ForCadence returns the core contracts of the network with the given chain ID
+// when it runs the given version of Cadence, e.g. an emulator snapshot taken
+// before the Crescendo upgrade.
+//
+// An error is returned if the chain ID or the Cadence version is unknown.
+func ForCadence(chainID flow.ChainID, version flow.CadenceVersion) (*Contracts, error) {
+	if _, err := flow.ParseChainID(string(chainID)); err != nil {
+		return nil, fmt.Errorf("systemcontracts: %w", err)
+	}
+
+	if err := version.Validate(); err != nil {
+		return nil, fmt.Errorf("systemcontracts: %w", err)
+	}
+
+	n := networks[chainID]
+
+	contract := func(name, address string) Contract {
+		return Contract{Name: name, Address: flow.HexToAddress(address)}
+	}
+
+	burner := n.fungibleToken
+	if chainID == flow.Emulator {
+		burner = n.serviceAccount
+	}
+
+	contracts := &Contracts{
+		ChainID:                    chainID,
+		CadenceVersion:             version,
+		FungibleToken:              contract(ContractNameFungibleToken, n.fungibleToken),
+		FungibleTokenMetadataViews: contract(ContractNameFungibleTokenMetadataViews, n.fungibleToken),
+		FlowToken:                  contract(ContractNameFlowToken, n.flowToken),
+		NonFungibleToken:           contract(ContractNameNonFungibleToken, n.nonFungibleToken),
+		MetadataViews:              contract(ContractNameMetadataViews, n.nonFungibleToken),
+		ViewResolver:               contract(ContractNameViewResolver, n.nonFungibleToken),
+		Burner:                     contract(ContractNameBurner, burner),
+		FlowFees:                   contract(ContractNameFlowFees, n.flowFees),
+		FlowServiceAccount:         contract(ContractNameFlowServiceAccount, n.serviceAccount),
+		FlowStorageFees:            contract(ContractNameFlowStorageFees, n.serviceAccount),
+		FlowIDTableStaking:         contract(ContractNameFlowIDTableStaking, n.staking),
+		FlowEpoch:                  contract(ContractNameFlowEpoch, n.staking),
+		FlowClusterQC:              contract(ContractNameFlowClusterQC, n.staking),
+		FlowDKG:                    contract(ContractNameFlowDKG, n.staking),
+		LockedTokens:               contract(ContractNameLockedTokens, n.lockedTokens),
+		StakingProxy:               contract(ContractNameStakingProxy, n.stakingProxy),
+		FlowStakingCollection:      contract(ContractNameFlowStakingCollection, n.lockedTokens),
+		NodeVersionBeacon:          contract(ContractNameNodeVersionBeacon, n.serviceAccount),
+		RandomBeaconHistory:        contract(ContractNameRandomBeaconHistory, n.serviceAccount),
+		EVM:                        contract(ContractNameEVM, n.serviceAccount),
+	}
+
+	if version == flow.CadenceV0 {
+		contracts.Burner = Contract{}
+		contracts.EVM = Contract{}
+	}
+
+	return contracts, nil
+}
+
+// MustForChain is like ForChain but panics if the chain ID is unknown.
+//
+// It is intended for chain IDs that are constants, such as flow.Mainnet.
+func MustForChain(chainID flow.ChainID) *Contracts {
+	contracts, err := ForChain(chainID)
+	if err != nil {
+		panic(err)
+	}
+
+	return contracts
+}
+
+// All returns all core contracts deployed to the network.
+func (c *Contracts) All() []Contract {
+	all := []Contract{
+		c.FungibleToken,
+		c.FungibleTokenMetadataViews,
+		c.FlowToken,
+		c.NonFungibleToken,
+		c.MetadataViews,
+		c.ViewResolver,
+		c.Burner,
+		c.FlowFees,
+		c.FlowServiceAccount,
+		c.FlowStorageFees,
+		c.FlowIDTableStaking,
+		c.FlowEpoch,
+		c.FlowClusterQC,
+		c.FlowDKG,
+		c.LockedTokens,
+		c.StakingProxy,
+		c.FlowStakingCollection,
+		c.NodeVersionBeacon,
+		c.RandomBeaconHistory,
+		c.EVM,
+	}
+
+	deployed := all[:0]
+	for _, contract := range all {
+		if contract.Name != "" {
+			deployed = append(deployed, contract)
+		}
+	}
+
+	return deployed
+}
+
+// ByName returns the core contract with the given name.
+func (c *Contracts) ByName(name string) (Contract, bool) {
+	for _, contract := range c.All() {
+		if contract.Name == name {
+			return contract, true
+		}
+	}
+
+	return Contract{}, false
+}
+
+// Addresses returns the addresses of the core contracts by contract name.
+func (c *Contracts) Addresses() map[string]flow.Address {
+	all := c.All()
+
+	addresses := make(map[string]flow.Address, len(all))
+	for _, contract := range all {
+		addresses[contract.Name] = contract.Address
+	}
+
+	return addresses
+}
+
+// Address returns the address of a core contract on the network with the given chain ID.
+//
+// An error is returned if the chain ID is unknown or the contract is not a core contract.
+func Address(chainID flow.ChainID, name string) (flow.Address, error) {
+	contracts, err := ForChain(chainID)
+	if err != nil {
+		return flow.EmptyAddress, err
+	}
+
+	contract, ok := contracts.ByName(name)
+	if !ok {
+		return flow.EmptyAddress, flowerrors.Errorf(flowerrors.ErrNotFound, "systemcontracts: %s is not a core contract", name)
+	}
+
+	return contract.Address, nil
+}
@@ -0,0 +1,114 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package systemcontracts_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/systemcontracts"
+)
+
+func TestForChain(t *testing.T) {
+	for _, chainID := range []flow.ChainID{flow.Mainnet, flow.Testnet, flow.Emulator} {
+		t.Run(chainID.String(), func(t *testing.T) {
+			contracts, err := systemcontracts.ForChain(chainID)
+			require.NoError(t, err)
+
+			assert.Equal(t, chainID, contracts.ChainID)
+
+			names := make(map[string]bool)
+			for _, contract := range contracts.All() {
+				assert.NotEmpty(t, contract.Name)
+				assert.False(t, names[contract.Name], "duplicate contract %s", contract.Name)
+				names[contract.Name] = true
+
+				assert.True(t, contract.Address.IsValid(chainID), "%s address %s", contract.Name, contract.Address)
+			}
+
+			assert.Len(t, contracts.Addresses(), len(names))
+		})
+	}
+
+	_, err := systemcontracts.ForChain("flow-unknown")
+	assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+
+	assert.Panics(t, func() { systemcontracts.MustForChain("flow-unknown") })
+}
+
+func TestAddress(t *testing.T) {
+	tests := []struct {
+		chainID flow.ChainID
+		name    string
+		address string
+	}{
+		{flow.Mainnet, systemcontracts.ContractNameFungibleToken, "f233dcee88fe0abe"},
+		{flow.Mainnet, systemcontracts.ContractNameFlowToken, "1654653399040a61"},
+		{flow.Mainnet, systemcontracts.ContractNameNonFungibleToken, "1d7e57aa55817448"},
+		{flow.Testnet, systemcontracts.ContractNameFungibleToken, "9a0766d93b6608b7"},
+		{flow.Testnet, systemcontracts.ContractNameFlowToken, "7e60df042a9c0868"},
+		{flow.Testnet, systemcontracts.ContractNameMetadataViews, "631e88ae7f1d7c20"},
+		{flow.Emulator, systemcontracts.ContractNameFungibleToken, "ee82856bf20e2aa6"},
+		{flow.Emulator, systemcontracts.ContractNameFlowToken, "0ae53cb6e3f42a79"},
+		{flow.Emulator, systemcontracts.ContractNameFlowFees, "e5a8b7f23e8b548f"},
+		{flow.Emulator, systemcontracts.ContractNameFlowServiceAccount, "f8d6e0586b0a20c7"},
+	}
+
+	for _, tt := range tests {
+		address, err := systemcontracts.Address(tt.chainID, tt.name)
+		require.NoError(t, err)
+		assert.Equal(t, flow.HexToAddress(tt.address), address, "%s on %s", tt.name, tt.chainID)
+	}
+
+	_, err := systemcontracts.Address(flow.Mainnet, "NotACoreContract")
+	assert.ErrorIs(t, err, flowerrors.ErrNotFound)
+}
+
+func TestContract(t *testing.T) {
+	contract := systemcontracts.MustForChain(flow.Mainnet).FungibleToken
+
+	assert.Equal(t, "A.f233dcee88fe0abe.FungibleToken", contract.Location())
+	assert.Equal(t, "A.f233dcee88fe0abe.FungibleToken.TokensDeposited", contract.EventType("TokensDeposited"))
+	assert.Equal(t, "import FungibleToken from 0xf233dcee88fe0abe", contract.Import())
+}
+
+func TestForCadence(t *testing.T) {
+	v1, err := systemcontracts.ForCadence(flow.Emulator, flow.CadenceV1)
+	require.NoError(t, err)
+	assert.Equal(t, systemcontracts.MustForChain(flow.Emulator), v1)
+
+	legacy, err := systemcontracts.ForCadence(flow.Emulator, flow.CadenceV0)
+	require.NoError(t, err)
+	assert.Equal(t, flow.CadenceV0, legacy.CadenceVersion)
+	assert.Equal(t, v1.FungibleToken, legacy.FungibleToken)
+	assert.Len(t, legacy.All(), len(v1.All())-2)
+
+	_, ok := legacy.ByName(systemcontracts.ContractNameEVM)
+	assert.False(t, ok)
+	_, ok = legacy.ByName(systemcontracts.ContractNameBurner)
+	assert.False(t, ok)
+	assert.NotContains(t, legacy.Addresses(), "")
+
+	_, err = systemcontracts.ForCadence(flow.Emulator, flow.CadenceVersion(42))
+	assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+}
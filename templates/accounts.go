@@ -20,11 +20,14 @@ package templates
 
 import (
 	"encoding/hex"
+	"fmt"
 
 	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
 )
 
 // Contract is a Cadence contract deployed to a Flow account.
@@ -47,21 +50,105 @@ func (c Contract) SourceHex() string {
 	return hex.EncodeToString(c.SourceBytes())
 }
 
-const createAccountTemplate = `
-transaction(publicKeys: [String], contracts: {String: String}) {
-	prepare(signer: AuthAccount) {
-		let acct = AuthAccount(payer: signer)
+// Templates generates the transactions of the templates shipped with the SDK
+// for a version of Cadence.
+//
+// The package functions generate the transactions for the version of Cadence of
+// the default catalog, Cadence before version 1.0 unless switched with
+// SetCadenceVersion; use ForCadence to target a version independently.
+type Templates struct {
+	version flow.CadenceVersion
+}
+
+// ForCadence returns the templates for a version of Cadence, e.g. the version
+// run by the node a client is connected to.
+//
+// An error matching flowerrors.ErrInvalidArgument is returned for an unknown version.
+func ForCadence(version flow.CadenceVersion) (Templates, error) {
+	if err := version.Validate(); err != nil {
+		return Templates{}, fmt.Errorf("templates: %w", err)
+	}
+
+	return Templates{version: version}, nil
+}
+
+// defaultTemplates returns the templates used by the package functions. The
+// version of the default catalog is always known.
+func defaultTemplates() Templates {
+	return Templates{version: DefaultCatalog.CadenceVersion()}
+}
+
+// Version returns the version of Cadence targeted by the templates.
+func (t Templates) Version() flow.CadenceVersion {
+	return t.version
+}
+
+func (t Templates) script(name string) []byte {
+	return []byte(builtinSources[t.version][name])
+}
+
+// Raw values of the Cadence 1.0 SignatureAlgorithm and HashAlgorithm enums.
+// Keys with other algorithms are rejected by the Cadence 1.0 templates.
+var (
+	cadenceSignatureAlgorithms = map[crypto.SignatureAlgorithm]uint8{
+		crypto.ECDSA_P256:      1,
+		crypto.ECDSA_secp256k1: 2,
+	}
+
+	cadenceHashAlgorithms = map[crypto.HashAlgorithm]uint8{
+		crypto.SHA2_256: 1,
+		crypto.SHA2_384: 2,
+		crypto.SHA3_256: 3,
+		crypto.SHA3_384: 4,
+	}
+)
+
+// keyArguments returns the arguments describing a list of account keys.
+//
+// Templates before Cadence 1.0 take the keys as a list of hex-encoded RLP
+// account keys. Cadence 1.0 templates take lists of the raw public keys, of
+// their signature and hash algorithms and of their weights; an error matching
+// flowerrors.ErrUnsupported is returned for a key with an algorithm they do
+// not support.
+func (t Templates) keyArguments(accountKeys []*flow.AccountKey) ([]cadence.Value, error) {
+	publicKeys := make([]cadence.Value, len(accountKeys))
+
+	if t.version == flow.CadenceV0 {
+		for i, accountKey := range accountKeys {
+			publicKeys[i] = cadence.String(hex.EncodeToString(accountKey.Encode()))
+		}
 
-		for key in publicKeys {
-			acct.addPublicKey(key.decodeHex())
+		return []cadence.Value{cadence.NewArray(publicKeys)}, nil
+	}
+
+	sigAlgos := make([]cadence.Value, len(accountKeys))
+	hashAlgos := make([]cadence.Value, len(accountKeys))
+	weights := make([]cadence.Value, len(accountKeys))
+
+	for i, accountKey := range accountKeys {
+		sigAlgo, ok := cadenceSignatureAlgorithms[accountKey.SigAlgo]
+		if !ok {
+			return nil, flowerrors.Errorf(flowerrors.ErrUnsupported, "templates: unsupported signature algorithm %s", accountKey.SigAlgo)
 		}
 
-		for contract in contracts.keys {
-			acct.contracts.add(name: contract, code: contracts[contract]!.decodeHex())
+		hashAlgo, ok := cadenceHashAlgorithms[accountKey.HashAlgo]
+		if !ok {
+			return nil, flowerrors.Errorf(flowerrors.ErrUnsupported, "templates: unsupported hash algorithm %s", accountKey.HashAlgo)
 		}
+
+		publicKeys[i] = cadence.String(hex.EncodeToString(accountKey.PublicKey.Encode()))
+		sigAlgos[i] = cadence.NewUInt8(sigAlgo)
+		hashAlgos[i] = cadence.NewUInt8(hashAlgo)
+		weights[i] = cadence.UFix64(uint64(accountKey.Weight) * 100_000_000)
 	}
+
+	return []cadence.Value{
+		cadence.NewArray(publicKeys),
+		cadence.NewArray(sigAlgos),
+		cadence.NewArray(hashAlgos),
+		cadence.NewArray(weights),
+	}, nil
 }
-`
 
 // CreateAccount generates a transactions that creates a new account.
 //
@@ -72,12 +159,17 @@ transaction(publicKeys: [String], contracts: {String: String}) {
 //
 // The final argument is the address of the account that will pay the account creation fee.
 // This account is added as a transaction authorizer and therefore must sign the resulting transaction.
-func CreateAccount(accountKeys []*flow.AccountKey, contracts []Contract, payer flow.Address) *flow.Transaction {
-	publicKeys := make([]cadence.Value, len(accountKeys))
-
-	for i, accountKey := range accountKeys {
-		keyHex := hex.EncodeToString(accountKey.Encode())
-		publicKeys[i] = cadence.String(keyHex)
+//
+// An error matching flowerrors.ErrUnsupported is returned for a key with an algorithm
+// the version of Cadence does not support.
+func (t Templates) CreateAccount(
+	accountKeys []*flow.AccountKey,
+	contracts []Contract,
+	payer flow.Address,
+) (*flow.Transaction, error) {
+	keyArguments, err := t.keyArguments(accountKeys)
+	if err != nil {
+		return nil, err
 	}
 
 	contractKeyPairs := make([]cadence.KeyValuePair, len(contracts))
@@ -89,107 +181,172 @@ func CreateAccount(accountKeys []*flow.AccountKey, contracts []Contract, payer f
 		}
 	}
 
-	cadencePublicKeys := cadence.NewArray(publicKeys)
-	cadenceContracts := cadence.NewDictionary(contractKeyPairs)
+	tx := flow.NewTransaction().
+		SetScript(t.script("create_account")).
+		AddAuthorizer(payer)
 
-	return flow.NewTransaction().
-		SetScript([]byte(createAccountTemplate)).
-		AddAuthorizer(payer).
-		AddRawArgument(jsoncdc.MustEncode(cadencePublicKeys)).
-		AddRawArgument(jsoncdc.MustEncode(cadenceContracts))
-}
-
-const updateAccountContractTemplate = `
-transaction(name: String, code: String) {
-	prepare(signer: AuthAccount) {
-		signer.contracts.update__experimental(name: name, code: code.decodeHex())
+	for _, arg := range keyArguments {
+		tx.AddRawArgument(jsoncdc.MustEncode(arg))
 	}
+
+	return tx.AddRawArgument(jsoncdc.MustEncode(cadence.NewDictionary(contractKeyPairs))), nil
 }
-`
 
 // UpdateAccountContract generates a transaction that updates a contract deployed at an account.
-func UpdateAccountContract(address flow.Address, contract Contract) *flow.Transaction {
+func (t Templates) UpdateAccountContract(address flow.Address, contract Contract) *flow.Transaction {
 	cadenceName := cadence.String(contract.Name)
 	cadenceCode := cadence.String(contract.SourceHex())
 
 	return flow.NewTransaction().
-		SetScript([]byte(updateAccountContractTemplate)).
+		SetScript(t.script("update_account_contract")).
 		AddRawArgument(jsoncdc.MustEncode(cadenceName)).
 		AddRawArgument(jsoncdc.MustEncode(cadenceCode)).
 		AddAuthorizer(address)
 }
 
-const addAccountContractTemplate = `
-transaction(name: String, code: String) {
-	prepare(signer: AuthAccount) {
-		signer.contracts.add(name: name, code: code.decodeHex())
-	}
-}
-`
-
 // AddAccountContract generates a transaction that deploys a contract to an account.
-func AddAccountContract(address flow.Address, contract Contract) *flow.Transaction {
+func (t Templates) AddAccountContract(address flow.Address, contract Contract) *flow.Transaction {
 	cadenceName := cadence.String(contract.Name)
 	cadenceCode := cadence.String(contract.SourceHex())
 
 	return flow.NewTransaction().
-		SetScript([]byte(addAccountContractTemplate)).
+		SetScript(t.script("add_account_contract")).
 		AddRawArgument(jsoncdc.MustEncode(cadenceName)).
 		AddRawArgument(jsoncdc.MustEncode(cadenceCode)).
 		AddAuthorizer(address)
 }
 
-const addAccountKeyTemplate = `
-transaction(publicKey: String) {
-	prepare(signer: AuthAccount) {
-		signer.addPublicKey(publicKey.decodeHex())
+// AddAccountKey generates a transaction that adds a public key to an account.
+//
+// An error matching flowerrors.ErrUnsupported is returned for a key with an algorithm
+// the version of Cadence does not support.
+func (t Templates) AddAccountKey(address flow.Address, accountKey *flow.AccountKey) (*flow.Transaction, error) {
+	keyArguments, err := t.keyArguments([]*flow.AccountKey{accountKey})
+	if err != nil {
+		return nil, err
+	}
+
+	tx := flow.NewTransaction().
+		SetScript(t.script("add_account_key")).
+		AddAuthorizer(address)
+
+	// the single key template takes the elements of the key lists
+	for _, arg := range keyArguments {
+		tx.AddRawArgument(jsoncdc.MustEncode(arg.(cadence.Array).Values[0]))
 	}
+
+	return tx, nil
 }
-`
 
-// AddAccountKey generates a transaction that adds a public key to an account.
-func AddAccountKey(address flow.Address, accountKey *flow.AccountKey) *flow.Transaction {
-	keyHex := hex.EncodeToString(accountKey.Encode())
-	cadencePublicKey := cadence.String(keyHex)
+// AddAccountKeys generates a transaction that adds several public keys to an account.
+//
+// An error matching flowerrors.ErrUnsupported is returned for a key with an algorithm
+// the version of Cadence does not support.
+func (t Templates) AddAccountKeys(address flow.Address, accountKeys []*flow.AccountKey) (*flow.Transaction, error) {
+	keyArguments, err := t.keyArguments(accountKeys)
+	if err != nil {
+		return nil, err
+	}
 
-	return flow.NewTransaction().
-		SetScript([]byte(addAccountKeyTemplate)).
-		AddRawArgument(jsoncdc.MustEncode(cadencePublicKey)).
+	tx := flow.NewTransaction().
+		SetScript(t.script("add_account_keys")).
 		AddAuthorizer(address)
-}
 
-const removeAccountKeyTemplate = `
-transaction(keyIndex: Int) {
-	prepare(signer: AuthAccount) {
-		signer.removePublicKey(keyIndex)
+	for _, arg := range keyArguments {
+		tx.AddRawArgument(jsoncdc.MustEncode(arg))
 	}
+
+	return tx, nil
 }
-`
 
 // RemoveAccountKey generates a transaction that removes a key from an account.
-func RemoveAccountKey(address flow.Address, keyIndex int) *flow.Transaction {
+//
+// Cadence 1.0 revokes the key instead: it keeps its index but can no longer sign.
+func (t Templates) RemoveAccountKey(address flow.Address, keyIndex int) *flow.Transaction {
 	cadenceKeyIndex := cadence.NewInt(keyIndex)
 
 	return flow.NewTransaction().
-		SetScript([]byte(removeAccountKeyTemplate)).
+		SetScript(t.script("remove_account_key")).
 		AddRawArgument(jsoncdc.MustEncode(cadenceKeyIndex)).
 		AddAuthorizer(address)
 }
 
 // RemoveAccountContract generates a transaction that removes a contract with the given name
-func RemoveAccountContract(address flow.Address, contractName string) *flow.Transaction {
+func (t Templates) RemoveAccountContract(address flow.Address, contractName string) *flow.Transaction {
 	cadenceName := cadence.String(contractName)
 
 	return flow.NewTransaction().
-		SetScript([]byte(removeAccountContractTemplate)).
+		SetScript(t.script("remove_account_contract")).
 		AddRawArgument(jsoncdc.MustEncode(cadenceName)).
 		AddAuthorizer(address)
 }
 
-const removeAccountContractTemplate = `
-transaction(name: String) {
-	prepare(signer: AuthAccount) {
-		signer.contracts.remove(name: name)
+// CreateAccount generates a transactions that creates a new account, for
+// the version of Cadence of the default catalog.
+//
+// This template accepts a list of public keys and a contracts argument, both of which are optional.
+//
+// The contracts argument is a dictionary of *contract name*: *contract code (in bytes)*.
+// All of the contracts will be deployed to the account.
+//
+// The final argument is the address of the account that will pay the account creation fee.
+// This account is added as a transaction authorizer and therefore must sign the resulting transaction.
+//
+// It panics for a key with an algorithm Cadence 1.0 does not support once the default
+// catalog targets Cadence 1.0; use Templates.CreateAccount to handle the error.
+func CreateAccount(accountKeys []*flow.AccountKey, contracts []Contract, payer flow.Address) *flow.Transaction {
+	return mustTransaction(defaultTemplates().CreateAccount(accountKeys, contracts, payer))
+}
+
+// UpdateAccountContract generates a transaction that updates a contract deployed at an account,
+// for the version of Cadence of the default catalog.
+func UpdateAccountContract(address flow.Address, contract Contract) *flow.Transaction {
+	return defaultTemplates().UpdateAccountContract(address, contract)
+}
+
+// AddAccountContract generates a transaction that deploys a contract to an account, for the
+// version of Cadence of the default catalog.
+func AddAccountContract(address flow.Address, contract Contract) *flow.Transaction {
+	return defaultTemplates().AddAccountContract(address, contract)
+}
+
+// AddAccountKey generates a transaction that adds a public key to an account, for the version of
+// Cadence of the default catalog.
+//
+// It panics for a key with an algorithm Cadence 1.0 does not support once the default
+// catalog targets Cadence 1.0; use Templates.AddAccountKey to handle the error.
+func AddAccountKey(address flow.Address, accountKey *flow.AccountKey) *flow.Transaction {
+	return mustTransaction(defaultTemplates().AddAccountKey(address, accountKey))
+}
+
+// AddAccountKeys generates a transaction that adds several public keys to an account, for the
+// version of Cadence of the default catalog.
+//
+// It panics for a key with an algorithm Cadence 1.0 does not support once the default
+// catalog targets Cadence 1.0; use Templates.AddAccountKeys to handle the error.
+func AddAccountKeys(address flow.Address, accountKeys []*flow.AccountKey) *flow.Transaction {
+	return mustTransaction(defaultTemplates().AddAccountKeys(address, accountKeys))
+}
+
+// RemoveAccountKey generates a transaction that removes a key from an account, for the version of
+// Cadence of the default catalog.
+func RemoveAccountKey(address flow.Address, keyIndex int) *flow.Transaction {
+	return defaultTemplates().RemoveAccountKey(address, keyIndex)
+}
+
+// RemoveAccountContract generates a transaction that removes a contract with the given name, for
+// the version of Cadence of the default catalog.
+func RemoveAccountContract(address flow.Address, contractName string) *flow.Transaction {
+	return defaultTemplates().RemoveAccountContract(address, contractName)
+}
+
+// mustTransaction returns tx, and panics if err is not nil. Like
+// jsoncdc.MustEncode, it lets the package functions, which predate the
+// Templates methods, keep their signatures.
+func mustTransaction(tx *flow.Transaction, err error) *flow.Transaction {
+	if err != nil {
+		panic(err)
 	}
+
+	return tx
 }
-`
@@ -19,10 +19,18 @@
 package templates_test
 
 import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/cryptotest"
+	"github.com/onflow/flow-go-sdk/flowerrors"
 	"github.com/onflow/flow-go-sdk/templates"
-	"github.com/stretchr/testify/require"
-	"testing"
 )
 
 func TestCreateAccount(t *testing.T) {
@@ -51,3 +59,168 @@ func TestCreateAccount(t *testing.T) {
 				"2 times the contract code (converted to hex) + 500 bytes of extra data.")
 	})
 }
+
+// The scripts of the templates before Cadence 1.0 must not change: existing
+// callers rely on the transaction IDs and payloads they produce.
+func TestLegacyScripts(t *testing.T) {
+	const removeAccountKeyTemplate = `
+transaction(keyIndex: Int) {
+	prepare(signer: AuthAccount) {
+		signer.removePublicKey(keyIndex)
+	}
+}
+`
+
+	tx := templates.RemoveAccountKey(flow.HexToAddress("01"), 0)
+	assert.Equal(t, removeAccountKeyTemplate, string(tx.Script))
+}
+
+func TestForCadence(t *testing.T) {
+	address := flow.HexToAddress("01")
+	privateKey := cryptotest.PrivateKey(crypto.ECDSA_P256, 0)
+	accountKey := flow.NewAccountKey().
+		FromPrivateKey(privateKey).
+		SetHashAlgo(crypto.SHA3_256).
+		SetWeight(flow.AccountKeyWeightThreshold)
+
+	legacy, err := templates.ForCadence(flow.CadenceV0)
+	require.NoError(t, err)
+	assert.Equal(t, flow.CadenceV0, legacy.Version())
+	legacyTx, err := legacy.AddAccountKey(address, accountKey)
+	require.NoError(t, err)
+	assert.Equal(t, templates.AddAccountKey(address, accountKey), legacyTx)
+	assert.Equal(t, templates.RemoveAccountKey(address, 1), legacy.RemoveAccountKey(address, 1))
+
+	v1, err := templates.ForCadence(flow.CadenceV1)
+	require.NoError(t, err)
+	assert.Equal(t, flow.CadenceV1, v1.Version())
+
+	t.Run("Add account key", func(t *testing.T) {
+		tx, err := v1.AddAccountKey(address, accountKey)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(tx.Script), "signer.keys.add(")
+		assert.Equal(t, []flow.Address{address}, tx.Authorizers)
+		require.Len(t, tx.Arguments, 4)
+
+		publicKey, err := tx.Argument(0)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.String(hex.EncodeToString(privateKey.PublicKey().Encode())), publicKey)
+
+		sigAlgo, err := tx.Argument(1)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewUInt8(1), sigAlgo)
+
+		hashAlgo, err := tx.Argument(2)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewUInt8(3), hashAlgo)
+
+		weight, err := tx.Argument(3)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.UFix64(1000_00000000), weight)
+	})
+
+	t.Run("Create account", func(t *testing.T) {
+		tx, err := v1.CreateAccount(
+			[]*flow.AccountKey{accountKey, accountKey},
+			[]templates.Contract{{Name: "Foo", Source: "access(all) contract Foo {}"}},
+			address,
+		)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(tx.Script), "Account(payer: signer)")
+		require.Len(t, tx.Arguments, 5)
+
+		for i := 0; i < 4; i++ {
+			arg, err := tx.Argument(i)
+			require.NoError(t, err)
+			assert.Len(t, arg.(cadence.Array).Values, 2)
+		}
+	})
+
+	t.Run("Unsupported algorithms", func(t *testing.T) {
+		unknownSigAlgo := flow.NewAccountKey().
+			FromPrivateKey(privateKey).
+			SetSigAlgo(crypto.UnknownSignatureAlgorithm).
+			SetHashAlgo(crypto.SHA3_256).
+			SetWeight(flow.AccountKeyWeightThreshold)
+
+		unknownHashAlgo := flow.NewAccountKey().
+			FromPrivateKey(privateKey).
+			SetHashAlgo(crypto.UnknownHashAlgorithm).
+			SetWeight(flow.AccountKeyWeightThreshold)
+
+		for _, key := range []*flow.AccountKey{unknownSigAlgo, unknownHashAlgo} {
+			tx, err := v1.AddAccountKey(address, key)
+			assert.ErrorIs(t, err, flowerrors.ErrUnsupported)
+			assert.Nil(t, tx)
+
+			tx, err = v1.AddAccountKeys(address, []*flow.AccountKey{accountKey, key})
+			assert.ErrorIs(t, err, flowerrors.ErrUnsupported)
+			assert.Nil(t, tx)
+
+			tx, err = v1.CreateAccount([]*flow.AccountKey{key}, nil, address)
+			assert.ErrorIs(t, err, flowerrors.ErrUnsupported)
+			assert.Nil(t, tx)
+		}
+
+		// keys are RLP encoded as a whole before Cadence 1.0
+		_, err := legacy.AddAccountKey(address, unknownHashAlgo)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Update account contract", func(t *testing.T) {
+		tx := v1.UpdateAccountContract(address, templates.Contract{Name: "Foo"})
+		assert.Contains(t, string(tx.Script), "signer.contracts.update(")
+		assert.Empty(t, templates.FindLegacySyntax(string(tx.Script)))
+	})
+
+	_, err = templates.ForCadence(flow.CadenceVersion(42))
+	assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+}
+
+// Every template function must find its template for every version of Cadence.
+func TestTemplates_Scripts(t *testing.T) {
+	address := flow.HexToAddress("01")
+	contract := templates.Contract{Name: "Foo"}
+	accountKey := flow.NewAccountKey().
+		FromPrivateKey(cryptotest.PrivateKey(crypto.ECDSA_P256, 0)).
+		SetHashAlgo(crypto.SHA3_256).
+		SetWeight(flow.AccountKeyWeightThreshold)
+
+	for _, version := range []flow.CadenceVersion{flow.CadenceV0, flow.CadenceV1} {
+		tmpl, err := templates.ForCadence(version)
+		require.NoError(t, err)
+
+		catalog, err := templates.NewBuiltinCatalog(version)
+		require.NoError(t, err)
+
+		createAccount, err := tmpl.CreateAccount(nil, nil, address)
+		require.NoError(t, err)
+
+		addAccountKey, err := tmpl.AddAccountKey(address, accountKey)
+		require.NoError(t, err)
+
+		addAccountKeys, err := tmpl.AddAccountKeys(address, nil)
+		require.NoError(t, err)
+
+		scripts := map[string]*flow.Transaction{
+			"create_account":          createAccount,
+			"update_account_contract": tmpl.UpdateAccountContract(address, contract),
+			"add_account_contract":    tmpl.AddAccountContract(address, contract),
+			"add_account_key":         addAccountKey,
+			"add_account_keys":        addAccountKeys,
+			"remove_account_key":      tmpl.RemoveAccountKey(address, 0),
+			"remove_account_contract": tmpl.RemoveAccountContract(address, contract.Name),
+			"stage_contract_chunk":    tmpl.StageContractChunk(address, contract.Name, "", true),
+			"deploy_staged_contract":  tmpl.DeployStagedContract(address, contract.Name, "", false),
+		}
+
+		for name, tx := range scripts {
+			source, err := catalog.Source(name)
+			require.NoError(t, err)
+			assert.NotEmpty(t, tx.Script, name)
+			assert.Equal(t, source, string(tx.Script), name)
+		}
+	}
+}
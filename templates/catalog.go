@@ -0,0 +1,310 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/systemcontracts"
+)
+
+// builtin holds the Cadence templates shipped with the SDK, in one directory
+// per version of Cadence.
+//
+//go:embed cadence/*.cdc cadence1/*.cdc
+var builtin embed.FS
+
+// builtinDirs are the directories of the templates in builtin, by version of Cadence.
+var builtinDirs = map[flow.CadenceVersion]string{
+	flow.CadenceV0: "cadence",
+	flow.CadenceV1: "cadence1",
+}
+
+// templateExt is the file extension of a template.
+const templateExt = ".cdc"
+
+// A Catalog looks up Cadence templates by name in a set of directories.
+//
+// The name of a template is the name of its file without the .cdc extension,
+// e.g. "transfer_flow" for transfer_flow.cdc. Directories registered later take
+// precedence, so a registered directory can override the templates of the SDK.
+//
+// A catalog targets a version of Cadence, which selects the built-in templates
+// of a built-in catalog and the core contracts its imports resolve to.
+type Catalog struct {
+	mut     sync.RWMutex
+	builtin bool
+	version flow.CadenceVersion
+	dirs    []fs.FS
+}
+
+// NewCatalog returns a catalog of the templates in the given directories,
+// targeting Cadence 1.0.
+func NewCatalog(dirs ...fs.FS) *Catalog {
+	return &Catalog{version: flow.CadenceV1, dirs: dirs}
+}
+
+// NewBuiltinCatalog returns a catalog of the templates shipped with the SDK
+// for the given version of Cadence.
+//
+// Every built-in template has a version for each version of Cadence, under the
+// same name and with the same imports. An error matching
+// flowerrors.ErrInvalidArgument is returned for an unknown version.
+func NewBuiltinCatalog(version flow.CadenceVersion) (*Catalog, error) {
+	if err := version.Validate(); err != nil {
+		return nil, fmt.Errorf("templates: %w", err)
+	}
+
+	return &Catalog{builtin: true, version: version}, nil
+}
+
+// DefaultCatalog is the catalog used by Get and Register. It contains the
+// templates shipped with the SDK for Cadence before version 1.0, unless
+// switched with SetCadenceVersion.
+var DefaultCatalog = &Catalog{builtin: true, version: flow.CadenceV0}
+
+// CadenceVersion returns the version of Cadence targeted by the catalog.
+func (c *Catalog) CadenceVersion() flow.CadenceVersion {
+	c.mut.RLock()
+	defer c.mut.RUnlock()
+
+	return c.version
+}
+
+// SetCadenceVersion switches the version of Cadence targeted by the catalog,
+// e.g. once the network it is used with was upgraded to Cadence 1.0.
+//
+// The built-in templates of a built-in catalog are switched to the templates
+// for the version; registered directories are kept. An error matching
+// flowerrors.ErrInvalidArgument is returned, and the version is kept, for an
+// unknown version.
+func (c *Catalog) SetCadenceVersion(version flow.CadenceVersion) error {
+	if err := version.Validate(); err != nil {
+		return fmt.Errorf("templates: %w", err)
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.version = version
+
+	return nil
+}
+
+// Register adds a directory of templates to the catalog.
+func (c *Catalog) Register(dir fs.FS) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.dirs = append(c.dirs, dir)
+}
+
+// RegisterDir adds the templates in a directory of the file system to the catalog.
+func (c *Catalog) RegisterDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("templates: %w", err)
+	}
+
+	if !info.IsDir() {
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "templates: %s is not a directory", dir)
+	}
+
+	c.Register(os.DirFS(dir))
+
+	return nil
+}
+
+// Source returns the Cadence code of a template as it is stored, with its
+// imports unresolved.
+func (c *Catalog) Source(name string) (string, error) {
+	if !fs.ValidPath(name) || strings.Contains(name, "/") {
+		return "", flowerrors.Errorf(flowerrors.ErrInvalidArgument, "templates: invalid template name %q", name)
+	}
+
+	c.mut.RLock()
+	defer c.mut.RUnlock()
+
+	dirs, err := c.lookupDirs()
+	if err != nil {
+		return "", err
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		code, err := fs.ReadFile(dirs[i], name+templateExt)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("templates: failed to read template %s: %w", name, err)
+		}
+
+		return string(code), nil
+	}
+
+	return "", flowerrors.Errorf(flowerrors.ErrNotFound, "templates: template %s not found", name)
+}
+
+// Get returns the Cadence code of a template with the imports of core
+// contracts resolved to their addresses on the network with the given chain ID,
+// when it runs the version of Cadence of the catalog.
+//
+// Templates import core contracts by name, e.g. import "FungibleToken"; see
+// ResolveImports for the supported import forms.
+func (c *Catalog) Get(name string, chainID flow.ChainID) (string, error) {
+	code, err := c.Source(name)
+	if err != nil {
+		return "", err
+	}
+
+	contracts, err := systemcontracts.ForCadence(chainID, c.CadenceVersion())
+	if err != nil {
+		return "", fmt.Errorf("templates: %w", err)
+	}
+
+	return ResolveImports(code, contracts.Addresses()), nil
+}
+
+// Names returns the names of all templates in the catalog, sorted.
+func (c *Catalog) Names() ([]string, error) {
+	c.mut.RLock()
+	defer c.mut.RUnlock()
+
+	dirs, err := c.lookupDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+
+	for _, dir := range dirs {
+		paths, err := fs.Glob(dir, "*"+templateExt)
+		if err != nil {
+			return nil, fmt.Errorf("templates: %w", err)
+		}
+
+		for _, p := range paths {
+			seen[strings.TrimSuffix(path.Base(p), templateExt)] = true
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// lookupDirs returns the directories of the catalog in increasing order of
+// precedence. The built-in templates have the lowest precedence.
+func (c *Catalog) lookupDirs() ([]fs.FS, error) {
+	if !c.builtin {
+		return c.dirs, nil
+	}
+
+	dir, err := builtinFS(c.version)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]fs.FS{dir}, c.dirs...), nil
+}
+
+// Get returns a template from the default catalog with the imports of core
+// contracts resolved for the network with the given chain ID.
+func Get(name string, chainID flow.ChainID) (string, error) {
+	return DefaultCatalog.Get(name, chainID)
+}
+
+// SetCadenceVersion switches the version of Cadence targeted by the default
+// catalog and by the template functions of this package, e.g. to target a
+// network upgraded to Cadence 1.0 from a program built for older networks.
+// An error is returned, and the version is kept, for an unknown version.
+func SetCadenceVersion(version flow.CadenceVersion) error {
+	return DefaultCatalog.SetCadenceVersion(version)
+}
+
+// Register adds a directory of templates to the default catalog.
+func Register(dir fs.FS) {
+	DefaultCatalog.Register(dir)
+}
+
+// RegisterDir adds the templates in a directory of the file system to the default catalog.
+func RegisterDir(dir string) error {
+	return DefaultCatalog.RegisterDir(dir)
+}
+
+// builtinFS returns the directory of the templates shipped with the SDK for a
+// version of Cadence.
+func builtinFS(version flow.CadenceVersion) (fs.FS, error) {
+	dir, ok := builtinDirs[version]
+	if !ok {
+		return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "templates: unknown Cadence version %d", int(version))
+	}
+
+	sub, err := fs.Sub(builtin, dir)
+	if err != nil {
+		return nil, fmt.Errorf("templates: %w", err)
+	}
+
+	return sub, nil
+}
+
+// builtinSources holds the code of the templates shipped with the SDK, by
+// version of Cadence and name. It is used by the template functions of this
+// package, which are not affected by registered directories.
+var builtinSources = make(map[flow.CadenceVersion]map[string]string)
+
+// init loads builtinSources. The templates are embedded in the binary, so an
+// error is a bug of the SDK.
+func init() {
+	for version := range builtinDirs {
+		catalog := &Catalog{builtin: true, version: version}
+
+		names, err := catalog.Names()
+		if err != nil {
+			panic(err)
+		}
+
+		sources := make(map[string]string, len(names))
+		for _, name := range names {
+			code, err := catalog.Source(name)
+			if err != nil {
+				panic(err)
+			}
+
+			sources[name] = code
+		}
+
+		builtinSources[version] = sources
+	}
+}
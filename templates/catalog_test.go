@@ -0,0 +1,209 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/cryptotest"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+func TestGet(t *testing.T) {
+	code, err := templates.Get("transfer_flow", flow.Mainnet)
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "import FungibleToken from 0xf233dcee88fe0abe\n")
+	assert.Contains(t, code, "import FlowToken from 0x1654653399040a61\n")
+
+	code, err = templates.Get("transfer_flow", flow.Emulator)
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "import FungibleToken from 0xee82856bf20e2aa6\n")
+	assert.Contains(t, code, "import FlowToken from 0x0ae53cb6e3f42a79\n")
+
+	_, err = templates.Get("transfer_flow", "flow-unknown")
+	assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+
+	_, err = templates.Get("no_such_template", flow.Mainnet)
+	assert.ErrorIs(t, err, flowerrors.ErrNotFound)
+
+	_, err = templates.Get("../templates/cadence/transfer_flow", flow.Mainnet)
+	assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+}
+
+func TestCatalog(t *testing.T) {
+	catalog := templates.NewCatalog(fstest.MapFS{
+		"hello.cdc":    {Data: []byte(`pub fun main(): String { return "hello" }`)},
+		"transfer.cdc": {Data: []byte("import \"FungibleToken\"\nimport Foo from \"./Foo.cdc\"\n")},
+		"README.md":    {Data: []byte("not a template")},
+	})
+
+	names, err := catalog.Names()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello", "transfer"}, names)
+
+	code, err := catalog.Get("transfer", flow.Testnet)
+	require.NoError(t, err)
+	assert.Equal(t, "import FungibleToken from 0x9a0766d93b6608b7\nimport Foo from \"./Foo.cdc\"\n", code)
+
+	source, err := catalog.Source("transfer")
+	require.NoError(t, err)
+	assert.Equal(t, "import \"FungibleToken\"\nimport Foo from \"./Foo.cdc\"\n", source)
+
+	// directories registered later take precedence
+	catalog.Register(fstest.MapFS{
+		"hello.cdc": {Data: []byte(`pub fun main(): String { return "hi" }`)},
+	})
+
+	code, err = catalog.Get("hello", flow.Emulator)
+	require.NoError(t, err)
+	assert.Equal(t, `pub fun main(): String { return "hi" }`, code)
+
+	err = catalog.RegisterDir(t.TempDir() + "/missing")
+	assert.Error(t, err)
+}
+
+func TestDefaultCatalog(t *testing.T) {
+	names, err := templates.DefaultCatalog.Names()
+	require.NoError(t, err)
+
+	for _, name := range []string{
+		"add_account_contract",
+		"add_account_key",
+		"add_account_keys",
+		"create_account",
+		"mint_flow",
+		"remove_account_contract",
+		"remove_account_key",
+		"transfer_flow",
+		"update_account_contract",
+	} {
+		assert.Contains(t, names, name)
+	}
+}
+
+func TestNewBuiltinCatalog(t *testing.T) {
+	legacy, err := templates.NewBuiltinCatalog(flow.CadenceV0)
+	require.NoError(t, err)
+
+	v1, err := templates.NewBuiltinCatalog(flow.CadenceV1)
+	require.NoError(t, err)
+
+	legacyNames, err := legacy.Names()
+	require.NoError(t, err)
+
+	names, err := v1.Names()
+	require.NoError(t, err)
+	assert.Equal(t, legacyNames, names)
+
+	for _, name := range names {
+		legacySource, err := legacy.Source(name)
+		require.NoError(t, err)
+
+		source, err := v1.Source(name)
+		require.NoError(t, err)
+
+		assert.Equal(t, templates.Imports(legacySource), templates.Imports(source), name)
+		assert.NotEqual(t, legacySource, source, name)
+		assert.Empty(t, templates.FindLegacySyntax(source), name)
+	}
+
+	code, err := v1.Get("transfer_flow", flow.Mainnet)
+	require.NoError(t, err)
+	assert.Contains(t, code, "import FungibleToken from 0xf233dcee88fe0abe\n")
+	assert.Contains(t, code, "&Account")
+
+	_, err = templates.NewBuiltinCatalog(flow.CadenceVersion(42))
+	assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+}
+
+func TestSetCadenceVersion(t *testing.T) {
+	require.NoError(t, templates.SetCadenceVersion(flow.CadenceV1))
+	t.Cleanup(func() { _ = templates.SetCadenceVersion(flow.CadenceV0) })
+
+	assert.Equal(t, flow.CadenceV1, templates.DefaultCatalog.CadenceVersion())
+
+	code, err := templates.Get("transfer_flow", flow.Emulator)
+	require.NoError(t, err)
+	assert.Empty(t, templates.FindLegacySyntax(code))
+
+	tx := templates.AddAccountKey(flow.HexToAddress("01"), flow.NewAccountKey().
+		FromPrivateKey(cryptotest.PrivateKey(crypto.ECDSA_P256, 0)).
+		SetHashAlgo(crypto.SHA3_256).
+		SetWeight(flow.AccountKeyWeightThreshold))
+	assert.Len(t, tx.Arguments, 4)
+
+	t.Run("Registered directories", func(t *testing.T) {
+		catalog, err := templates.NewBuiltinCatalog(flow.CadenceV0)
+		require.NoError(t, err)
+
+		catalog.Register(fstest.MapFS{
+			"transfer_flow.cdc": {Data: []byte("import \"EVM\"\n")},
+		})
+
+		code, err := catalog.Get("transfer_flow", flow.Emulator)
+		require.NoError(t, err)
+		assert.Equal(t, "import \"EVM\"\n", code, "EVM is not deployed before Cadence 1.0")
+
+		require.NoError(t, catalog.SetCadenceVersion(flow.CadenceV1))
+
+		code, err = catalog.Get("transfer_flow", flow.Emulator)
+		require.NoError(t, err)
+		assert.Equal(t, "import EVM from 0xf8d6e0586b0a20c7\n", code)
+
+		code, err = catalog.Get("mint_flow", flow.Emulator)
+		require.NoError(t, err)
+		assert.Contains(t, code, "signer.storage")
+	})
+
+	err = templates.SetCadenceVersion(flow.CadenceVersion(42))
+	assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+	assert.Equal(t, flow.CadenceV1, templates.DefaultCatalog.CadenceVersion())
+}
+
+func TestImports(t *testing.T) {
+	code := `
+import FungibleToken from 0xf233dcee88fe0abe
+import Foo, Bar from "./Foo.cdc"
+	import "Baz"
+`
+
+	assert.Equal(t, []string{"Bar", "Baz", "Foo", "FungibleToken"}, templates.Imports(code))
+
+	resolved := templates.ResolveImports(code, map[string]flow.Address{
+		"Foo": flow.HexToAddress("01"),
+		"Bar": flow.HexToAddress("02"),
+		"Baz": flow.HexToAddress("03"),
+	})
+
+	assert.Equal(t, `
+import FungibleToken from 0xf233dcee88fe0abe
+import Foo from 0x0000000000000001
+import Bar from 0x0000000000000002
+	import Baz from 0x0000000000000003
+`, resolved)
+}
@@ -0,0 +1,116 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"encoding/hex"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// DefaultContractChunkSize is the default number of bytes of contract code
+// staged by each transaction of a chunked deployment. Code is sent hex-encoded,
+// so a chunk takes twice its size in a transaction, well within the maximum
+// transaction size of the network.
+const DefaultContractChunkSize = 500_000
+
+// stagingPathPrefix is the prefix of the storage path the code of a contract
+// is staged at during a chunked deployment, followed by the contract name.
+const stagingPathPrefix = "flowSDKStagedContract_"
+
+// ContractStagingPath returns the identifier of the storage path the code of a
+// contract is staged at during a chunked deployment.
+func ContractStagingPath(contractName string) string {
+	return stagingPathPrefix + contractName
+}
+
+// ContractCodeHash returns the hex-encoded SHA3-256 hash of the code of a
+// contract, which a chunked deployment checks the staged code against.
+func ContractCodeHash(contract Contract) string {
+	return hex.EncodeToString(crypto.NewSHA3_256().ComputeHash(contract.SourceBytes()))
+}
+
+// StageContractChunk generates a transaction that appends a chunk of hex-encoded
+// contract code to the code staged in the storage of an account. The first
+// chunk replaces any code left staged by an earlier deployment.
+func (t Templates) StageContractChunk(address flow.Address, contractName string, chunkHex string, first bool) *flow.Transaction {
+	return flow.NewTransaction().
+		SetScript(t.script("stage_contract_chunk")).
+		AddRawArgument(jsoncdc.MustEncode(cadence.String(ContractStagingPath(contractName)))).
+		AddRawArgument(jsoncdc.MustEncode(cadence.String(chunkHex))).
+		AddRawArgument(jsoncdc.MustEncode(cadence.NewBool(first))).
+		AddAuthorizer(address)
+}
+
+// DeployStagedContract generates a transaction that deploys, or updates, a
+// contract from the code staged in the storage of an account.
+//
+// The transaction fails unless the staged code hashes to codeHash, as returned
+// by ContractCodeHash. The staged code is removed from storage.
+func (t Templates) DeployStagedContract(address flow.Address, contractName string, codeHash string, update bool) *flow.Transaction {
+	return flow.NewTransaction().
+		SetScript(t.script("deploy_staged_contract")).
+		AddRawArgument(jsoncdc.MustEncode(cadence.String(ContractStagingPath(contractName)))).
+		AddRawArgument(jsoncdc.MustEncode(cadence.String(contractName))).
+		AddRawArgument(jsoncdc.MustEncode(cadence.String(codeHash))).
+		AddRawArgument(jsoncdc.MustEncode(cadence.NewBool(update))).
+		AddAuthorizer(address)
+}
+
+// ChunkedContractDeployment generates the transactions that deploy, or update, a
+// contract too large to be sent in a single transaction.
+//
+// The code is split into chunks of at most chunkSize bytes, or
+// DefaultContractChunkSize if chunkSize is not positive. The returned
+// transactions stage the chunks in the storage of the account, in order, and
+// the last one deploys the staged code after checking its hash. The
+// transactions must be executed in order, e.g. by sending each of them once the
+// previous one is sealed.
+func (t Templates) ChunkedContractDeployment(address flow.Address, contract Contract, chunkSize int, update bool) []*flow.Transaction {
+	if chunkSize <= 0 {
+		chunkSize = DefaultContractChunkSize
+	}
+
+	code := contract.SourceBytes()
+
+	var txs []*flow.Transaction
+
+	// an empty contract is staged as a single empty chunk
+	for start := 0; start == 0 || start < len(code); start += chunkSize {
+		end := start + chunkSize
+		if end > len(code) {
+			end = len(code)
+		}
+
+		txs = append(txs, t.StageContractChunk(address, contract.Name, hex.EncodeToString(code[start:end]), start == 0))
+	}
+
+	return append(txs, t.DeployStagedContract(address, contract.Name, ContractCodeHash(contract), update))
+}
+
+// ChunkedContractDeployment generates the transactions that deploy, or update, a
+// contract too large to be sent in a single transaction, for the version of
+// Cadence of the default catalog. See Templates.ChunkedContractDeployment.
+func ChunkedContractDeployment(address flow.Address, contract Contract, chunkSize int, update bool) []*flow.Transaction {
+	return defaultTemplates().ChunkedContractDeployment(address, contract, chunkSize, update)
+}
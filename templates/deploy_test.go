@@ -0,0 +1,88 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+func TestChunkedContractDeployment(t *testing.T) {
+	address := flow.HexToAddress("01")
+	contract := templates.Contract{
+		Name:   "Large",
+		Source: "access(all) contract Large {" + strings.Repeat(" ", 20) + "}",
+	}
+
+	v1, err := templates.ForCadence(flow.CadenceV1)
+	require.NoError(t, err)
+
+	txs := v1.ChunkedContractDeployment(address, contract, 20, true)
+	require.Len(t, txs, 4)
+
+	var staged string
+	for i, tx := range txs[:3] {
+		assert.Contains(t, string(tx.Script), "signer.storage.save(")
+		assert.Equal(t, []flow.Address{address}, tx.Authorizers)
+
+		path, err := tx.Argument(0)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.String(templates.ContractStagingPath("Large")), path)
+
+		chunk, err := tx.Argument(1)
+		require.NoError(t, err)
+		staged += string(chunk.(cadence.String))
+
+		first, err := tx.Argument(2)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewBool(i == 0), first)
+	}
+
+	code, err := hex.DecodeString(staged)
+	require.NoError(t, err)
+	assert.Equal(t, contract.Source, string(code))
+
+	deploy := txs[3]
+	assert.Contains(t, string(deploy.Script), "signer.contracts.update(")
+
+	codeHash, err := deploy.Argument(2)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.String(hex.EncodeToString(crypto.NewSHA3_256().ComputeHash(code))), codeHash)
+
+	update, err := deploy.Argument(3)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewBool(true), update)
+
+	// the default chunk size stages small contracts in a single chunk
+	txs = templates.ChunkedContractDeployment(address, contract, 0, false)
+	require.Len(t, txs, 2)
+	assert.Contains(t, string(txs[1].Script), "signer.contracts.add(")
+
+	txs = templates.ChunkedContractDeployment(address, templates.Contract{Name: "Empty"}, 0, false)
+	assert.Len(t, txs, 2)
+}
@@ -0,0 +1,86 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// importPattern matches an import statement in any of the forms
+//
+//	import Foo from 0x01
+//	import Foo, Bar from "./Foo.cdc"
+//	import "Foo"
+var importPattern = regexp.MustCompile(`(?m)^([ \t]*)import[ \t]+(?:([A-Za-z_]\w*(?:[ \t]*,[ \t]*[A-Za-z_]\w*)*)[ \t]+from[ \t]+\S+|"([A-Za-z_]\w*)")`)
+
+// Imports returns the names of the contracts imported by code, sorted.
+func Imports(code string) []string {
+	var names []string
+
+	for _, match := range importPattern.FindAllStringSubmatch(code, -1) {
+		names = append(names, importNames(match)...)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func importNames(match []string) []string {
+	if match[3] != "" {
+		return []string{match[3]}
+	}
+
+	names := strings.Split(match[2], ",")
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+	}
+
+	return names
+}
+
+// ResolveImports rewrites the imports of the named contracts in code to import
+// them from the given addresses.
+//
+// File imports, placeholder addresses and string imports are all rewritten to the
+// address form "import Foo from 0x...". Statements importing a contract that has
+// no address are left unchanged.
+func ResolveImports(code string, addresses map[string]flow.Address) string {
+	return importPattern.ReplaceAllStringFunc(code, func(statement string) string {
+		match := importPattern.FindStringSubmatch(statement)
+		indent, names := match[1], importNames(match)
+
+		lines := make([]string, len(names))
+		for i, name := range names {
+			address, ok := addresses[name]
+			if !ok {
+				return statement
+			}
+
+			lines[i] = fmt.Sprintf("%simport %s from 0x%s", indent, name, address.Hex())
+		}
+
+		return strings.Join(lines, "\n")
+	})
+}
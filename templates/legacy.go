@@ -0,0 +1,191 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A LegacySyntax is a use of Cadence syntax that was removed or replaced in
+// Cadence 1.0.
+type LegacySyntax struct {
+	// Line is the line of the syntax, starting from 1.
+	Line int
+	// Column is the byte offset of the syntax in its line, starting from 1.
+	Column int
+	// Text is the legacy syntax as written in the code.
+	Text string
+	// Message describes the replacement of the syntax in Cadence 1.0.
+	Message string
+}
+
+// legacyRules match the legacy syntax that can be recognized without parsing
+// the code. Account members are only matched when they are accessed on a value,
+// to tell them apart from declarations of the same name.
+var legacyRules = []struct {
+	pattern *regexp.Regexp
+	message string
+}{
+	{regexp.MustCompile(`\bpub\s*\(\s*set\s*\)`), "pub(set) was removed, use a setter function"},
+	{regexp.MustCompile(`\bpub\b`), "pub is replaced by access(all)"},
+	{regexp.MustCompile(`\bpriv\b`), "priv is replaced by access(self)"},
+	{regexp.MustCompile(`\bAuthAccount\b`), "AuthAccount is replaced by an authorized reference, e.g. auth(Storage) &Account"},
+	{regexp.MustCompile(`\bPublicAccount\b`), "PublicAccount is replaced by &Account"},
+	{regexp.MustCompile(`\.getCapability\b`), "getCapability is replaced by capabilities.get and capabilities.borrow"},
+	{regexp.MustCompile(`\.link\s*<`), "link is replaced by capabilities.storage.issue and capabilities.publish"},
+	{regexp.MustCompile(`\.unlink\s*\(`), "unlink is replaced by capabilities.unpublish"},
+	{regexp.MustCompile(`\.addPublicKey\s*\(`), "addPublicKey is replaced by keys.add"},
+	{regexp.MustCompile(`\.removePublicKey\s*\(`), "removePublicKey is replaced by keys.revoke"},
+	{regexp.MustCompile(`\.update__experimental\s*\(`), "contracts.update__experimental is replaced by contracts.update"},
+	{regexp.MustCompile(`\bAny(Struct|Resource)\s*\{`), "restricted types are replaced by intersection types, e.g. {I}"},
+	{regexp.MustCompile(`\bdestroy\s*\(\s*\)\s*\{`), "custom destructors were removed, emit a ResourceDestroyed event instead"},
+}
+
+// FindLegacySyntax returns the uses of syntax removed or replaced in Cadence
+// 1.0 in Cadence code, sorted by position, or nil if there is none.
+//
+// The code is not parsed: comments and string literals are skipped, and the
+// rest is matched against known patterns. The result helps migrating code
+// before the Crescendo upgrade, but an empty result does not guarantee that
+// the code is valid Cadence 1.0.
+func FindLegacySyntax(code string) []LegacySyntax {
+	lines := strings.Split(maskCommentsAndStrings(code), "\n")
+
+	var found []LegacySyntax
+
+	for i, line := range lines {
+		matched := make(map[int]bool)
+
+		for _, rule := range legacyRules {
+			for _, loc := range rule.pattern.FindAllStringIndex(line, -1) {
+				// a pub(set) is not reported again as a pub
+				if matched[loc[0]] {
+					continue
+				}
+				matched[loc[0]] = true
+
+				found = append(found, LegacySyntax{
+					Line:    i + 1,
+					Column:  loc[0] + 1,
+					Text:    line[loc[0]:loc[1]],
+					Message: rule.message,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].Line != found[j].Line {
+			return found[i].Line < found[j].Line
+		}
+		return found[i].Column < found[j].Column
+	})
+
+	return found
+}
+
+// maskCommentsAndStrings replaces the comments and the contents of the string
+// literals of Cadence code with spaces, keeping the line breaks and the offsets
+// of the rest of the code.
+func maskCommentsAndStrings(code string) string {
+	masked := []byte(code)
+
+	const (
+		stateCode = iota
+		stateLineComment
+		stateBlockComment
+		stateString
+	)
+
+	state := stateCode
+	depth := 0
+
+	mask := func(i int) {
+		if masked[i] != '\n' {
+			masked[i] = ' '
+		}
+	}
+
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		var next byte
+		if i+1 < len(code) {
+			next = code[i+1]
+		}
+
+		switch state {
+		case stateCode:
+			switch {
+			case c == '/' && next == '/':
+				state = stateLineComment
+				mask(i)
+			case c == '/' && next == '*':
+				state = stateBlockComment
+				depth = 1
+				mask(i)
+				mask(i + 1)
+				i++
+			case c == '"':
+				state = stateString
+			}
+
+		case stateLineComment:
+			if c == '\n' {
+				state = stateCode
+			}
+			mask(i)
+
+		case stateBlockComment:
+			// block comments nest in Cadence
+			switch {
+			case c == '/' && next == '*':
+				depth++
+				mask(i)
+				mask(i + 1)
+				i++
+			case c == '*' && next == '/':
+				depth--
+				mask(i)
+				mask(i + 1)
+				i++
+				if depth == 0 {
+					state = stateCode
+				}
+			default:
+				mask(i)
+			}
+
+		case stateString:
+			switch {
+			case c == '\\' && next != 0:
+				mask(i)
+				mask(i + 1)
+				i++
+			case c == '"' || c == '\n':
+				state = stateCode
+			default:
+				mask(i)
+			}
+		}
+	}
+
+	return string(masked)
+}
@@ -0,0 +1,65 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+func TestFindLegacySyntax(t *testing.T) {
+	code := `pub contract Foo {
+	pub(set) var bar: Int
+	// pub fun commented() {}
+	/* nested /* AuthAccount */ PublicAccount */
+	priv let baz: String
+
+	pub fun link(signer: AuthAccount) {
+		signer.link<&Foo>(/public/foo, target: /storage/foo)
+		let message = "signer.addPublicKey(key)"
+	}
+
+	destroy() {}
+}`
+
+	assert.Equal(t, []templates.LegacySyntax{
+		{Line: 1, Column: 1, Text: "pub", Message: "pub is replaced by access(all)"},
+		{Line: 2, Column: 2, Text: "pub(set)", Message: "pub(set) was removed, use a setter function"},
+		{Line: 5, Column: 2, Text: "priv", Message: "priv is replaced by access(self)"},
+		{Line: 7, Column: 2, Text: "pub", Message: "pub is replaced by access(all)"},
+		{Line: 7, Column: 23, Text: "AuthAccount", Message: "AuthAccount is replaced by an authorized reference, e.g. auth(Storage) &Account"},
+		{Line: 8, Column: 9, Text: ".link<", Message: "link is replaced by capabilities.storage.issue and capabilities.publish"},
+		{Line: 12, Column: 2, Text: "destroy() {", Message: "custom destructors were removed, emit a ResourceDestroyed event instead"},
+	}, templates.FindLegacySyntax(code))
+
+	assert.Nil(t, templates.FindLegacySyntax(`access(all) fun main(): String { return "pub" }`))
+
+	// the legacy templates are detected as legacy code
+	legacy, err := templates.NewBuiltinCatalog(flow.CadenceV0)
+	require.NoError(t, err)
+
+	source, err := legacy.Source("transfer_flow")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, templates.FindLegacySyntax(source))
+}
@@ -211,7 +211,11 @@ func CollectionGuaranteeGenerator() *CollectionGuarantees {
 
 func (g *CollectionGuarantees) New() *flow.CollectionGuarantee {
 	return &flow.CollectionGuarantee{
-		CollectionID: g.ids.New(),
+		CollectionID:     g.ids.New(),
+		ReferenceBlockID: g.ids.New(),
+		ClusterChainID:   flow.ChainID("cluster-1"),
+		SignerIndices:    []byte{0, 0, 0, 0, 0b10100000},
+		Signature:        []byte("signature"),
 	}
 }
 
@@ -227,6 +231,14 @@ func (g *BlockSeals) New() *flow.BlockSeal {
 		ExecutionReceiptID:         g.ids.New(),
 		ExecutionReceiptSignatures: [][]byte{},
 		ResultApprovalSignatures:   [][]byte{},
+		ResultID:                   g.ids.New(),
+		FinalState:                 flow.StateCommitment(g.ids.New()),
+		AggregatedApprovalSigs: []*flow.AggregatedSignature{
+			{
+				VerifierSignatures: [][]byte{[]byte("signature")},
+				SignerIDs:          []flow.Identifier{g.ids.New()},
+			},
+		},
 	}
 }
 
@@ -21,7 +21,6 @@ package flow
 import (
 	"bytes"
 	"errors"
-	"fmt"
 	"sort"
 
 	"github.com/ethereum/go-ethereum/rlp"
@@ -29,6 +28,7 @@ import (
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 
 	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
 )
 
 // A Transaction is a full transaction object containing a payload and signatures.
@@ -137,7 +137,7 @@ func (t *Transaction) SetScript(script []byte) *Transaction {
 func (t *Transaction) AddArgument(arg cadence.Value) error {
 	encodedArg, err := jsoncdc.Encode(arg)
 	if err != nil {
-		return fmt.Errorf("failed to encode argument: %w", err)
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "failed to encode argument: %w", err)
 	}
 
 	t.Arguments = append(t.Arguments, encodedArg)
@@ -153,18 +153,18 @@ func (t *Transaction) AddRawArgument(arg []byte) *Transaction {
 // Argument returns the decoded argument at the given index.
 func (t *Transaction) Argument(i int) (cadence.Value, error) {
 	if i < 0 {
-		return nil, fmt.Errorf("argument index must be positive")
+		return nil, flowerrors.New(flowerrors.ErrInvalidArgument, "argument index must be positive")
 	}
 
 	if i >= len(t.Arguments) {
-		return nil, fmt.Errorf("no argument at index %d", i)
+		return nil, flowerrors.Errorf(flowerrors.ErrNotFound, "no argument at index %d", i)
 	}
 
 	encodedArg := t.Arguments[i]
 
 	arg, err := jsoncdc.Decode(encodedArg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode argument at index %d: %w", i, err)
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "failed to decode argument at index %d: %w", i, err)
 	}
 
 	return arg, nil
@@ -291,9 +291,11 @@ func (t *Transaction) refreshSignerIndex() {
 //
 // This function returns an error if the signature cannot be generated.
 func (t *Transaction) SignPayload(address Address, keyIndex int, signer crypto.Signer) error {
-	message := t.PayloadMessage()
-	message = append(TransactionDomainTag[:], message...)
-	sig, err := signer.Sign(message)
+	sig, err := SignAndAudit(signer, TransactionDomainTag.Tag(t.PayloadMessage()), SignatureRecord{
+		Address:  address,
+		KeyIndex: keyIndex,
+		Roles:    t.signatureRoles(address),
+	})
 	if err != nil {
 		// TODO: wrap error
 		return err
@@ -311,9 +313,12 @@ func (t *Transaction) SignPayload(address Address, keyIndex int, signer crypto.S
 //
 // This function returns an error if the signature cannot be generated.
 func (t *Transaction) SignEnvelope(address Address, keyIndex int, signer crypto.Signer) error {
-	message := t.EnvelopeMessage()
-	message = append(TransactionDomainTag[:], message...)
-	sig, err := signer.Sign(message)
+	sig, err := SignAndAudit(signer, TransactionDomainTag.Tag(t.EnvelopeMessage()), SignatureRecord{
+		Address:  address,
+		KeyIndex: keyIndex,
+		Envelope: true,
+		Roles:    t.signatureRoles(address),
+	})
 	if err != nil {
 		// TODO: wrap error
 		return err
@@ -418,7 +423,7 @@ func (t *Transaction) Encode() []byte {
 func DecodeTransaction(transactionMessage []byte) (*Transaction, error) {
 	temp, err := decodeTransaction(transactionMessage)
 	if err != nil {
-		return nil, err
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "failed to decode transaction: %w", err)
 	}
 
 	authorizers := make([]Address, len(temp.Payload.Authorizers))
@@ -443,7 +448,11 @@ func DecodeTransaction(transactionMessage []byte) (*Transaction, error) {
 		payloadSignatures := make([]TransactionSignature, len(temp.PayloadSignatures))
 		for i, sig := range temp.PayloadSignatures {
 			payloadSignatures[i] = transactionSignatureFromCanonicalForm(sig)
-			payloadSignatures[i].Address = signers[payloadSignatures[i].SignerIndex]
+			signerIndex := payloadSignatures[i].SignerIndex
+			if signerIndex < 0 || signerIndex >= len(signers) {
+				return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "invalid payload signature signer index %d", signerIndex)
+			}
+			payloadSignatures[i].Address = signers[signerIndex]
 		}
 		t.PayloadSignatures = payloadSignatures
 	}
@@ -452,7 +461,11 @@ func DecodeTransaction(transactionMessage []byte) (*Transaction, error) {
 		envelopeSignatures := make([]TransactionSignature, len(temp.EnvelopeSignatures))
 		for i, sig := range temp.EnvelopeSignatures {
 			envelopeSignatures[i] = transactionSignatureFromCanonicalForm(sig)
-			envelopeSignatures[i].Address = signers[envelopeSignatures[i].SignerIndex]
+			signerIndex := envelopeSignatures[i].SignerIndex
+			if signerIndex < 0 || signerIndex >= len(signers) {
+				return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "invalid envelope signature signer index %d", signerIndex)
+			}
+			envelopeSignatures[i].Address = signers[signerIndex]
 		}
 		t.EnvelopeSignatures = envelopeSignatures
 	}
@@ -477,7 +490,7 @@ func decodeTransaction(transactionMessage []byte) (*transactionCanonicalForm, er
 
 	// First kind should always be a list
 	if kind != rlp.List {
-		return nil, errors.New("unexpected rlp decoding type")
+		return nil, flowerrors.New(flowerrors.ErrDecoding, "unexpected rlp decoding type")
 	}
 
 	_, err = s.List()
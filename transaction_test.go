@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
 	"github.com/onflow/flow-go-sdk/test"
 )
 
@@ -692,3 +693,92 @@ func TestTransaction_RLPMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeTransaction_InvalidSignerIndex(t *testing.T) {
+	addresses := test.AddressGenerator()
+	address := addresses.New()
+
+	newTx := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetProposalKey(address, 0, 0).
+			SetPayer(address).
+			AddPayloadSignature(address, 0, []byte{42}).
+			AddEnvelopeSignature(address, 0, []byte{42})
+	}
+
+	t.Run("Payload signature", func(t *testing.T) {
+		tx := newTx()
+		tx.PayloadSignatures[0].SignerIndex = 5
+
+		_, err := flow.DecodeTransaction(tx.Encode())
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+	})
+
+	t.Run("Envelope signature", func(t *testing.T) {
+		tx := newTx()
+		tx.EnvelopeSignatures[0].SignerIndex = 5
+
+		_, err := flow.DecodeTransaction(tx.Encode())
+		assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+	})
+}
+
+func TestDecodeTransaction_NotAList(t *testing.T) {
+	// RLP encoding of the string "abc"
+	_, err := flow.DecodeTransaction([]byte{0x83, 'a', 'b', 'c'})
+	assert.ErrorIs(t, err, flowerrors.ErrDecoding)
+}
+
+func BenchmarkTransaction_PayloadMessage(b *testing.B) {
+	tx := test.TransactionGenerator().New()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = tx.PayloadMessage()
+	}
+}
+
+func BenchmarkTransaction_EnvelopeMessage(b *testing.B) {
+	tx := test.TransactionGenerator().New()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = tx.EnvelopeMessage()
+	}
+}
+
+func BenchmarkTransaction_Encode(b *testing.B) {
+	tx := test.TransactionGenerator().New()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = tx.Encode()
+	}
+}
+
+func BenchmarkTransaction_ID(b *testing.B) {
+	tx := test.TransactionGenerator().New()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = tx.ID()
+	}
+}
+
+func BenchmarkDecodeTransaction(b *testing.B) {
+	encoded := test.TransactionGenerator().New().Encode()
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(encoded)))
+
+	for i := 0; i < b.N; i++ {
+		_, err := flow.DecodeTransaction(encoded)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
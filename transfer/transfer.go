@@ -0,0 +1,280 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package transfer sends FLOW between accounts in a single call.
+//
+// Flow builds the transfer transaction from the transfer_flow template, reads
+// the sequence number of the sender's key, sets the reference block, signs,
+// submits and waits for the transaction to be sealed, and returns the decoded
+// FLOW and fee events of the transaction:
+//
+//	result, err := transfer.Flow(ctx, flowClient, signer, sender, recipient, amount)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	fmt.Printf("sent in transaction %s\n", result.TransactionID)
+//
+// Use a wallet.Account to send several transactions with the same key without
+// waiting for each of them to be sealed.
+package transfer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/events"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/systemcontracts"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go-sdk/wallet"
+)
+
+// A Client is the subset of the Flow Access API client used to transfer FLOW.
+//
+// It is satisfied by *client.Client. If the client reports the version of
+// Cadence of its network, like *client.Client does, the transfer transaction
+// is built for that version.
+type Client interface {
+	wallet.Client
+}
+
+// cadenceVersioner is implemented by clients that know the version of Cadence
+// of their network.
+type cadenceVersioner interface {
+	CadenceVersion() flow.CadenceVersion
+}
+
+// TokensWithdrawn is a FlowToken.TokensWithdrawn event. From is nil for
+// vaults that are not stored in an account.
+type TokensWithdrawn struct {
+	Amount cadence.UFix64 `cadence:"amount"`
+	From   *flow.Address  `cadence:"from"`
+}
+
+// TokensDeposited is a FlowToken.TokensDeposited event. To is nil for vaults
+// that are not stored in an account.
+type TokensDeposited struct {
+	Amount cadence.UFix64 `cadence:"amount"`
+	To     *flow.Address  `cadence:"to"`
+}
+
+// FeesDeducted is a FlowFees.FeesDeducted event.
+type FeesDeducted struct {
+	Amount          cadence.UFix64 `cadence:"amount"`
+	InclusionEffort cadence.UFix64 `cadence:"inclusionEffort"`
+	ExecutionEffort cadence.UFix64 `cadence:"executionEffort"`
+}
+
+// A Result is the outcome of a sealed FLOW transfer.
+type Result struct {
+	TransactionID flow.Identifier
+	// TransactionResult is the sealed result of the transaction.
+	TransactionResult *flow.TransactionResult
+	// Withdrawals are the FLOW withdrawals of the transaction, in the order
+	// they were emitted, including the withdrawal of the fee from the payer.
+	Withdrawals []TokensWithdrawn
+	// Deposits are the FLOW deposits of the transaction, in the order they
+	// were emitted, including the deposit of the fee.
+	Deposits []TokensDeposited
+	// Fee is the fee paid for the transaction, or nil if the network charges
+	// no fees, e.g. an emulator with fees disabled.
+	Fee *FeesDeducted
+}
+
+type options struct {
+	keyIndex       int
+	chainID        flow.ChainID
+	cadenceVersion *flow.CadenceVersion
+	accountOptions []wallet.Option
+}
+
+// An Option configures a transfer.
+type Option func(*options)
+
+// WithKeyIndex sets the index of the key of the sender that signs the transfer.
+// The default is 0.
+func WithKeyIndex(keyIndex int) Option {
+	return func(o *options) {
+		o.keyIndex = keyIndex
+	}
+}
+
+// WithChainID sets the chain ID of the network. By default, the network is the
+// network the address of the sender is valid on.
+func WithChainID(chainID flow.ChainID) Option {
+	return func(o *options) {
+		o.chainID = chainID
+	}
+}
+
+// WithCadenceVersion sets the version of Cadence the transfer transaction is
+// built for. By default, it is the version of the client if it reports one,
+// and the version of the default template catalog otherwise.
+func WithCadenceVersion(version flow.CadenceVersion) Option {
+	return func(o *options) {
+		o.cadenceVersion = &version
+	}
+}
+
+// WithAccountOptions sets the options of the wallet.Account that sends the
+// transfer, e.g. its gas limit or poll interval.
+func WithAccountOptions(opts ...wallet.Option) Option {
+	return func(o *options) {
+		o.accountOptions = append(o.accountOptions, opts...)
+	}
+}
+
+// Flow transfers an amount of FLOW from the account at from to the account at
+// to, signed with the given signer of a key of the sender, and waits for the
+// transfer to be sealed.
+//
+// If the transaction is sealed with an error, the result is returned together
+// with an error wrapping the error of the transaction.
+func Flow(
+	ctx context.Context,
+	client Client,
+	fromSigner crypto.Signer,
+	from flow.Address,
+	to flow.Address,
+	amount cadence.UFix64,
+	opts ...Option,
+) (*Result, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if amount == 0 {
+		return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "transfer: amount must be positive")
+	}
+
+	if o.chainID == "" {
+		chainID, err := chainOf(from)
+		if err != nil {
+			return nil, err
+		}
+		o.chainID = chainID
+	}
+
+	if !to.IsValid(o.chainID) {
+		return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "transfer: %s is not a valid address on %s", to, o.chainID)
+	}
+
+	version := templates.DefaultCatalog.CadenceVersion()
+	if o.cadenceVersion != nil {
+		version = *o.cadenceVersion
+	} else if v, ok := client.(cadenceVersioner); ok {
+		version = v.CadenceVersion()
+	}
+
+	catalog, err := templates.NewBuiltinCatalog(version)
+	if err != nil {
+		return nil, fmt.Errorf("transfer: %w", err)
+	}
+
+	code, err := catalog.Get("transfer_flow", o.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("transfer: %w", err)
+	}
+
+	account, err := wallet.NewAccount(ctx, client, from, o.keyIndex, fromSigner, o.accountOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(code)).
+		AddRawArgument(jsoncdc.MustEncode(amount)).
+		AddRawArgument(jsoncdc.MustEncode(cadence.BytesToAddress(to.Bytes()))).
+		AddAuthorizer(from)
+
+	txResult, sendErr := account.SendAndWait(ctx, tx)
+	if txResult == nil {
+		return nil, sendErr
+	}
+
+	result, err := DecodeResult(o.chainID, txResult)
+	if err != nil {
+		return nil, err
+	}
+	result.TransactionID = tx.ID()
+
+	return result, sendErr
+}
+
+// DecodeResult decodes the FLOW and fee events of the result of a transaction
+// on the network with the given chain ID. The TransactionID of the returned
+// result is not set.
+func DecodeResult(chainID flow.ChainID, txResult *flow.TransactionResult) (*Result, error) {
+	contracts, err := systemcontracts.ForChain(chainID)
+	if err != nil {
+		return nil, fmt.Errorf("transfer: %w", err)
+	}
+
+	var (
+		withdrawnType = contracts.FlowToken.EventType("TokensWithdrawn")
+		depositedType = contracts.FlowToken.EventType("TokensDeposited")
+		feesType      = contracts.FlowFees.EventType("FeesDeducted")
+	)
+
+	result := &Result{TransactionResult: txResult}
+
+	for _, event := range txResult.Events {
+		switch event.Type {
+		case withdrawnType:
+			var withdrawn TokensWithdrawn
+			if err := events.DecodeEvent(event, &withdrawn); err != nil {
+				return nil, fmt.Errorf("transfer: %w", err)
+			}
+			result.Withdrawals = append(result.Withdrawals, withdrawn)
+
+		case depositedType:
+			var deposited TokensDeposited
+			if err := events.DecodeEvent(event, &deposited); err != nil {
+				return nil, fmt.Errorf("transfer: %w", err)
+			}
+			result.Deposits = append(result.Deposits, deposited)
+
+		case feesType:
+			var fee FeesDeducted
+			if err := events.DecodeEvent(event, &fee); err != nil {
+				return nil, fmt.Errorf("transfer: %w", err)
+			}
+			result.Fee = &fee
+		}
+	}
+
+	return result, nil
+}
+
+// chainOf returns the chain ID of the known network the address is valid on.
+func chainOf(address flow.Address) (flow.ChainID, error) {
+	for _, chainID := range []flow.ChainID{flow.Mainnet, flow.Testnet, flow.Emulator} {
+		if address.IsValid(chainID) {
+			return chainID, nil
+		}
+	}
+
+	return "", flowerrors.Errorf(flowerrors.ErrInvalidArgument, "transfer: %s is not a valid address on a known network", address)
+}
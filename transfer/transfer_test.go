@@ -0,0 +1,153 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transfer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client/mocks"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/flowtest"
+	"github.com/onflow/flow-go-sdk/systemcontracts"
+	"github.com/onflow/flow-go-sdk/test"
+	"github.com/onflow/flow-go-sdk/transfer"
+	"github.com/onflow/flow-go-sdk/wallet"
+)
+
+func TestFlow(t *testing.T) {
+	ctx := context.Background()
+	gen := flowtest.NewGenerator(0)
+
+	key, signer := test.AccountKeyGenerator().NewWithSigner()
+	key.Index = 0
+
+	from := flow.NewAddressGenerator(flow.Emulator).NextAddress()
+	to := flow.NewAddressGenerator(flow.Emulator).SetIndex(5).Address()
+	amount := cadence.UFix64(10_00000000)
+	fee := cadence.UFix64(1000)
+
+	feesDeducted := gen.Event(
+		systemcontracts.MustForChain(flow.Emulator).FlowFees.EventType("FeesDeducted"),
+		flowtest.EventField{Name: "amount", Value: fee},
+		flowtest.EventField{Name: "inclusionEffort", Value: cadence.UFix64(1_00000000)},
+		flowtest.EventField{Name: "executionEffort", Value: cadence.UFix64(2)},
+	)
+
+	t.Run("Sends and decodes the transfer", func(t *testing.T) {
+		client := mocks.NewAccessClient(t)
+		client.On("CadenceVersion").Return(flow.CadenceV0)
+		header := test.BlockHeaderGenerator().New()
+
+		client.On("GetAccountAtLatestBlock", ctx, from).
+			Return(&flow.Account{Address: from, Keys: []*flow.AccountKey{key}}, nil).Once()
+		client.On("GetLatestBlockHeader", ctx, false).Return(&header, nil).Once()
+
+		var sent flow.Transaction
+		client.On("SendTransaction", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { sent = args.Get(1).(flow.Transaction) }).
+			Return(nil).Once()
+
+		client.On("GetTransactionResult", ctx, mock.Anything).
+			Return(&flow.TransactionResult{
+				Status: flow.TransactionStatusSealed,
+				Events: []flow.Event{
+					gen.TokensWithdrawnEvent(amount, from),
+					gen.TokensDepositedEvent(amount, to),
+					gen.TokensWithdrawnEvent(fee, from),
+					feesDeducted,
+				},
+			}, nil).Once()
+
+		result, err := transfer.Flow(ctx, client, signer, from, to, amount)
+		require.NoError(t, err)
+
+		assert.Equal(t, sent.ID(), result.TransactionID)
+		assert.Equal(t, header.ID, sent.ReferenceBlockID)
+		assert.Equal(t, key.SequenceNumber, sent.ProposalKey.SequenceNumber)
+		assert.Equal(t, []flow.Address{from}, sent.Authorizers)
+		assert.Contains(t, string(sent.Script), "import FlowToken from 0x0ae53cb6e3f42a79")
+
+		recipient, err := sent.Argument(1)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.BytesToAddress(to.Bytes()), recipient)
+
+		assert.Equal(t, []transfer.TokensWithdrawn{
+			{Amount: amount, From: &from},
+			{Amount: fee, From: &from},
+		}, result.Withdrawals)
+		assert.Equal(t, []transfer.TokensDeposited{{Amount: amount, To: &to}}, result.Deposits)
+		assert.Equal(t, &transfer.FeesDeducted{
+			Amount:          fee,
+			InclusionEffort: 1_00000000,
+			ExecutionEffort: 2,
+		}, result.Fee)
+	})
+
+	t.Run("Failed transfer", func(t *testing.T) {
+		client := mocks.NewAccessClient(t)
+		header := test.BlockHeaderGenerator().New()
+
+		client.On("GetAccountAtLatestBlock", ctx, from).
+			Return(&flow.Account{Address: from, Keys: []*flow.AccountKey{key}}, nil).Once()
+		client.On("GetLatestBlockHeader", ctx, false).Return(&header, nil).Once()
+		client.On("SendTransaction", ctx, mock.Anything).Return(nil).Once()
+		client.On("GetTransactionResult", ctx, mock.Anything).
+			Return(&flow.TransactionResult{
+				Status: flow.TransactionStatusSealed,
+				Error:  assert.AnError,
+				Events: []flow.Event{feesDeducted},
+			}, nil).Once()
+
+		result, err := transfer.Flow(ctx, client, signer, from, to, amount, transfer.WithCadenceVersion(flow.CadenceV1))
+		assert.ErrorIs(t, err, assert.AnError)
+		require.NotNil(t, result)
+		assert.Equal(t, fee, result.Fee.Amount)
+		assert.Empty(t, result.Deposits)
+	})
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		client := mocks.NewAccessClient(t)
+		client.On("CadenceVersion").Return(flow.CadenceV0)
+
+		_, err := transfer.Flow(ctx, client, signer, from, to, 0)
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+
+		_, err = transfer.Flow(ctx, client, signer, flow.HexToAddress("01"), to, amount)
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+
+		_, err = transfer.Flow(ctx, client, signer, from, flow.HexToAddress("01"), amount)
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+
+		client.On("GetAccountAtLatestBlock", ctx, from).
+			Return(&flow.Account{Address: from, Keys: []*flow.AccountKey{key}}, nil).Once()
+
+		_, err = transfer.Flow(ctx, client, signer, from, to, amount,
+			transfer.WithKeyIndex(1),
+			transfer.WithAccountOptions(wallet.WithGasLimit(100)),
+		)
+		assert.ErrorIs(t, err, flowerrors.ErrNotFound)
+	})
+}
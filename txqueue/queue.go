@@ -0,0 +1,444 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package txqueue submits signed transactions to an access node and retries
+// failed submissions until they are accepted.
+//
+// Transactions are persisted to a Store before they are sent, so that queued
+// submissions survive a restart. Submissions failing with a transient error are
+// retried with exponential backoff; submissions that fail permanently, or that
+// exhaust their attempts, are parked as dead letters together with the error
+// that caused the failure, where they can be inspected, requeued or discarded:
+//
+//	store, err := txqueue.NewFileStore("queue.json")
+//	if err != nil {
+//	    return err
+//	}
+//
+//	queue := txqueue.New(flowClient, store)
+//	go queue.Run(ctx)
+//
+//	txID, err := queue.Submit(tx)
+//
+// The queue only submits transactions: use the GetTransactionResult method of
+// the access client, or a wallet.Account, to wait for their results.
+package txqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/logging"
+)
+
+// A Client sends transactions to an access node.
+//
+// It is satisfied by *client.Client.
+type Client interface {
+	SendTransaction(ctx context.Context, tx flow.Transaction, opts ...grpc.CallOption) error
+}
+
+// State is the state of a queued submission.
+type State string
+
+const (
+	// StatePending is the state of submissions waiting to be sent.
+	StatePending State = "pending"
+	// StateDead is the state of submissions that failed permanently.
+	StateDead State = "dead"
+)
+
+// An Entry is a transaction in the queue.
+type Entry struct {
+	// ID is the ID of the transaction.
+	ID flow.Identifier
+	// Transaction is the signed transaction to submit.
+	Transaction *flow.Transaction
+	// State is the state of the submission.
+	State State
+	// Attempts is the number of times the transaction was sent.
+	Attempts int
+	// EnqueuedAt is the time the transaction was submitted to the queue.
+	EnqueuedAt time.Time
+	// NextAttemptAt is the earliest time the transaction is sent again.
+	NextAttemptAt time.Time
+	// LastError is the error of the last failed attempt, if any.
+	LastError string
+	// LastErrorCode is the gRPC status code of the last failed attempt, or
+	// codes.Unknown if the error had no status.
+	LastErrorCode codes.Code
+	// FailedAt is the time the submission was parked as a dead letter.
+	FailedAt time.Time
+}
+
+// A Policy decides which failed submissions are retried and when.
+type Policy struct {
+	// MaxAttempts is the number of times a transaction is sent before it is
+	// parked as a dead letter. Zero means no limit.
+	MaxAttempts int
+	// InitialBackoff is the time between the first and the second attempt.
+	// The time doubles with every further attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the time between two attempts.
+	MaxBackoff time.Duration
+	// Retryable returns true if a submission failing with err should be
+	// retried.
+	Retryable func(err error) bool
+}
+
+// DefaultPolicy returns the policy used by a queue unless WithPolicy is given.
+//
+// It makes up to 10 attempts, starting with one second between them and
+// backing off up to one minute, and retries errors with an Unavailable,
+// ResourceExhausted, DeadlineExceeded or Aborted status.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:    10,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		Retryable:      IsTransient,
+	}
+}
+
+// IsTransient returns true if err may succeed when the request is repeated, i.e.
+// it matches flowerrors.ErrUnavailable or flowerrors.ErrTimeout.
+func IsTransient(err error) bool {
+	return flowerrors.IsTransient(err)
+}
+
+// backoff returns the time to wait after the given number of attempts.
+func (p Policy) backoff(attempts int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+
+	return backoff
+}
+
+// DefaultPollInterval is the default time between two checks for submissions
+// that are due.
+const DefaultPollInterval = time.Second
+
+// A Queue submits transactions and retries failed submissions.
+//
+// A Queue is safe for concurrent use, but only one Queue should process a
+// given store at a time.
+type Queue struct {
+	client       Client
+	store        Store
+	policy       Policy
+	pollInterval time.Duration
+	logger       logging.Logger
+	wake         chan struct{}
+}
+
+// An Option configures a Queue.
+type Option func(*Queue)
+
+// WithPolicy sets the retry policy of the queue.
+func WithPolicy(policy Policy) Option {
+	return func(q *Queue) {
+		q.policy = policy
+	}
+}
+
+// WithPollInterval sets the time between two checks for submissions that are
+// due. New submissions are sent without waiting for the next check.
+func WithPollInterval(interval time.Duration) Option {
+	return func(q *Queue) {
+		q.pollInterval = interval
+	}
+}
+
+// WithLogger sets the logger the queue reports retries and dead letters to.
+func WithLogger(logger logging.Logger) Option {
+	return func(q *Queue) {
+		q.logger = logging.OrNop(logger)
+	}
+}
+
+// New creates a queue sending transactions with the client and persisting
+// them to the store.
+func New(client Client, store Store, opts ...Option) *Queue {
+	q := &Queue{
+		client:       client,
+		store:        store,
+		policy:       DefaultPolicy(),
+		pollInterval: DefaultPollInterval,
+		logger:       logging.Nop(),
+		wake:         make(chan struct{}, 1),
+	}
+
+	for _, opt := range opts {
+		opt(q)
+	}
+
+	if q.policy.Retryable == nil {
+		q.policy.Retryable = IsTransient
+	}
+
+	return q
+}
+
+// Submit persists a signed transaction to the queue and returns its ID.
+//
+// The transaction is sent by Run, or by the next call to ProcessDue. Submitting
+// a transaction that is already queued fails with flowerrors.ErrInvalidArgument.
+func (q *Queue) Submit(tx *flow.Transaction) (flow.Identifier, error) {
+	txID := tx.ID()
+
+	_, err := q.store.Get(txID)
+	switch {
+	case err == nil:
+		return flow.EmptyID, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "txqueue: transaction %s is already queued", txID)
+	case !errors.Is(err, flowerrors.ErrNotFound):
+		return flow.EmptyID, err
+	}
+
+	now := time.Now()
+
+	err = q.store.Save(&Entry{
+		ID:            txID,
+		Transaction:   tx,
+		State:         StatePending,
+		EnqueuedAt:    now,
+		NextAttemptAt: now,
+	})
+	if err != nil {
+		return flow.EmptyID, fmt.Errorf("txqueue: failed to save transaction %s: %w", txID, err)
+	}
+
+	q.notify()
+
+	return txID, nil
+}
+
+// Run sends the pending transactions that are due until the context is
+// cancelled.
+//
+// Run returns the first error of the store; failed submissions are retried or
+// parked as dead letters and do not stop it.
+func (q *Queue) Run(ctx context.Context) error {
+	ticker := time.NewTicker(q.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		err := q.ProcessDue(ctx)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-q.wake:
+		}
+	}
+}
+
+// ProcessDue sends the pending transactions whose next attempt is due.
+//
+// Accepted transactions are removed from the queue. A transaction failing with
+// an AlreadyExists status was accepted by an earlier attempt and is removed too.
+func (q *Queue) ProcessDue(ctx context.Context) error {
+	entries, err := q.store.List()
+	if err != nil {
+		return fmt.Errorf("txqueue: failed to list entries: %w", err)
+	}
+
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if entry.State != StatePending || time.Now().Before(entry.NextAttemptAt) {
+			continue
+		}
+
+		err := q.send(ctx, entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// send makes an attempt to submit the transaction of the entry and records
+// its outcome in the store.
+func (q *Queue) send(ctx context.Context, entry *Entry) error {
+	entry.Attempts++
+
+	sendErr := q.client.SendTransaction(ctx, *entry.Transaction)
+	if sendErr == nil || grpcCode(sendErr) == codes.AlreadyExists {
+		q.logger.Log(logging.DebugLevel, "sent queued transaction",
+			logging.TransactionID(entry.ID),
+			logging.Uint64("attempts", uint64(entry.Attempts)),
+		)
+
+		err := q.store.Delete(entry.ID)
+		if err != nil && !errors.Is(err, flowerrors.ErrNotFound) {
+			return fmt.Errorf("txqueue: failed to delete transaction %s: %w", entry.ID, err)
+		}
+
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		// the attempt was interrupted, not rejected
+		return nil
+	}
+
+	now := time.Now()
+
+	entry.LastError = sendErr.Error()
+	entry.LastErrorCode = grpcCode(sendErr)
+
+	exhausted := q.policy.MaxAttempts > 0 && entry.Attempts >= q.policy.MaxAttempts
+
+	if q.policy.Retryable(sendErr) && !exhausted {
+		entry.NextAttemptAt = now.Add(q.policy.backoff(entry.Attempts))
+
+		q.logger.Log(logging.WarnLevel, "failed to send queued transaction, retrying",
+			logging.TransactionID(entry.ID),
+			logging.Uint64("attempts", uint64(entry.Attempts)),
+			logging.Duration("backoff", entry.NextAttemptAt.Sub(now)),
+			logging.Error(sendErr),
+		)
+	} else {
+		entry.State = StateDead
+		entry.FailedAt = now
+
+		q.logger.Log(logging.ErrorLevel, "failed to send queued transaction, parked as dead letter",
+			logging.TransactionID(entry.ID),
+			logging.Uint64("attempts", uint64(entry.Attempts)),
+			logging.Error(sendErr),
+		)
+	}
+
+	err := q.store.Save(entry)
+	if err != nil {
+		return fmt.Errorf("txqueue: failed to save transaction %s: %w", entry.ID, err)
+	}
+
+	return nil
+}
+
+// Get returns the queued transaction with the given ID.
+//
+// It fails with flowerrors.ErrNotFound if the transaction is not in the queue,
+// e.g. because it was accepted.
+func (q *Queue) Get(txID flow.Identifier) (*Entry, error) {
+	return q.store.Get(txID)
+}
+
+// Pending returns the transactions waiting to be sent.
+func (q *Queue) Pending() ([]*Entry, error) {
+	return q.list(StatePending)
+}
+
+// DeadLetters returns the transactions that failed permanently.
+func (q *Queue) DeadLetters() ([]*Entry, error) {
+	return q.list(StateDead)
+}
+
+func (q *Queue) list(state State) ([]*Entry, error) {
+	entries, err := q.store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []*Entry
+	for _, entry := range entries {
+		if entry.State == state {
+			filtered = append(filtered, entry)
+		}
+	}
+
+	return filtered, nil
+}
+
+// Requeue moves a dead letter back to the pending transactions and resets its
+// attempts, e.g. once the cause of its failure has been fixed.
+//
+// It fails with flowerrors.ErrNotFound if the transaction is not in the queue,
+// and with flowerrors.ErrInvalidArgument if it is not a dead letter.
+func (q *Queue) Requeue(txID flow.Identifier) error {
+	entry, err := q.store.Get(txID)
+	if err != nil {
+		return err
+	}
+
+	if entry.State != StateDead {
+		return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "txqueue: transaction %s is not a dead letter", txID)
+	}
+
+	entry.State = StatePending
+	entry.Attempts = 0
+	entry.NextAttemptAt = time.Now()
+	entry.FailedAt = time.Time{}
+
+	err = q.store.Save(entry)
+	if err != nil {
+		return fmt.Errorf("txqueue: failed to save transaction %s: %w", txID, err)
+	}
+
+	q.notify()
+
+	return nil
+}
+
+// Discard removes a transaction from the queue, whatever its state.
+//
+// It fails with flowerrors.ErrNotFound if the transaction is not in the queue.
+func (q *Queue) Discard(txID flow.Identifier) error {
+	return q.store.Delete(txID)
+}
+
+// notify wakes up Run without blocking.
+func (q *Queue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func grpcCode(err error) codes.Code {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return codes.Unknown
+	}
+	return grpcErr.GRPCStatus().Code()
+}
@@ -0,0 +1,300 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package txqueue_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/client/mocks"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/test"
+	"github.com/onflow/flow-go-sdk/txqueue"
+)
+
+func noBackoff(maxAttempts int) txqueue.Option {
+	return txqueue.WithPolicy(txqueue.Policy{MaxAttempts: maxAttempts})
+}
+
+func TestQueue_ProcessDue(t *testing.T) {
+	ctx := context.Background()
+	txs := test.TransactionGenerator()
+
+	t.Run("Accepted", func(t *testing.T) {
+		tx := txs.New()
+
+		c := mocks.NewAccessClient(t)
+		c.On("SendTransaction", ctx, *tx).Return(nil).Once()
+
+		q := txqueue.New(c, txqueue.NewMemoryStore(), noBackoff(3))
+
+		txID, err := q.Submit(tx)
+		require.NoError(t, err)
+		assert.Equal(t, tx.ID(), txID)
+
+		_, err = q.Submit(tx)
+		assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+
+		require.NoError(t, q.ProcessDue(ctx))
+
+		_, err = q.Get(txID)
+		assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+	})
+
+	t.Run("Retried", func(t *testing.T) {
+		tx := txs.New()
+
+		c := mocks.NewAccessClient(t)
+		c.On("SendTransaction", ctx, *tx).Return(client.RPCError{GRPCErr: status.Error(codes.Unavailable, "unavailable")}).Once()
+		c.On("SendTransaction", ctx, *tx).Return(client.RPCError{GRPCErr: status.Error(codes.AlreadyExists, "known")}).Once()
+
+		q := txqueue.New(c, txqueue.NewMemoryStore(), noBackoff(3))
+
+		txID, err := q.Submit(tx)
+		require.NoError(t, err)
+
+		require.NoError(t, q.ProcessDue(ctx))
+
+		pending, err := q.Pending()
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, txID, pending[0].ID)
+		assert.Equal(t, 1, pending[0].Attempts)
+		assert.Equal(t, codes.Unavailable, pending[0].LastErrorCode)
+		assert.Contains(t, pending[0].LastError, "unavailable")
+
+		// AlreadyExists means an earlier attempt was accepted.
+		require.NoError(t, q.ProcessDue(ctx))
+
+		pending, err = q.Pending()
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("Backoff", func(t *testing.T) {
+		tx := txs.New()
+
+		c := mocks.NewAccessClient(t)
+		c.On("SendTransaction", ctx, *tx).Return(client.RPCError{GRPCErr: status.Error(codes.Unavailable, "unavailable")}).Once()
+
+		q := txqueue.New(c, txqueue.NewMemoryStore(), txqueue.WithPolicy(txqueue.Policy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Hour,
+		}))
+
+		txID, err := q.Submit(tx)
+		require.NoError(t, err)
+
+		require.NoError(t, q.ProcessDue(ctx))
+		// the second attempt is not due yet
+		require.NoError(t, q.ProcessDue(ctx))
+
+		entry, err := q.Get(txID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, entry.Attempts)
+		assert.True(t, entry.NextAttemptAt.After(time.Now().Add(59*time.Minute)))
+	})
+
+	t.Run("Exhausted", func(t *testing.T) {
+		tx := txs.New()
+
+		c := mocks.NewAccessClient(t)
+		c.On("SendTransaction", ctx, *tx).Return(client.RPCError{GRPCErr: status.Error(codes.Unavailable, "unavailable")}).Twice()
+
+		q := txqueue.New(c, txqueue.NewMemoryStore(), noBackoff(2))
+
+		txID, err := q.Submit(tx)
+		require.NoError(t, err)
+
+		require.NoError(t, q.ProcessDue(ctx))
+		require.NoError(t, q.ProcessDue(ctx))
+		require.NoError(t, q.ProcessDue(ctx))
+
+		dead, err := q.DeadLetters()
+		require.NoError(t, err)
+		require.Len(t, dead, 1)
+		assert.Equal(t, txID, dead[0].ID)
+		assert.Equal(t, txqueue.StateDead, dead[0].State)
+		assert.Equal(t, 2, dead[0].Attempts)
+		assert.False(t, dead[0].FailedAt.IsZero())
+	})
+
+	t.Run("Permanent", func(t *testing.T) {
+		tx := txs.New()
+
+		c := mocks.NewAccessClient(t)
+		c.On("SendTransaction", ctx, *tx).Return(client.RPCError{GRPCErr: status.Error(codes.InvalidArgument, "expired")}).Once()
+
+		q := txqueue.New(c, txqueue.NewMemoryStore(), noBackoff(0))
+
+		txID, err := q.Submit(tx)
+		require.NoError(t, err)
+
+		require.NoError(t, q.ProcessDue(ctx))
+
+		entry, err := q.Get(txID)
+		require.NoError(t, err)
+		assert.Equal(t, txqueue.StateDead, entry.State)
+		assert.Equal(t, codes.InvalidArgument, entry.LastErrorCode)
+		assert.Equal(t, 1, entry.Attempts)
+	})
+}
+
+func TestQueue_DeadLetters(t *testing.T) {
+	ctx := context.Background()
+	txs := test.TransactionGenerator()
+
+	first, second := txs.New(), txs.New()
+	second.SetGasLimit(first.GasLimit + 1)
+
+	c := mocks.NewAccessClient(t)
+	c.On("SendTransaction", ctx, mock.Anything).Return(client.RPCError{GRPCErr: status.Error(codes.InvalidArgument, "invalid")}).Twice()
+
+	q := txqueue.New(c, txqueue.NewMemoryStore(), noBackoff(1))
+
+	firstID, err := q.Submit(first)
+	require.NoError(t, err)
+	secondID, err := q.Submit(second)
+	require.NoError(t, err)
+
+	require.NoError(t, q.ProcessDue(ctx))
+
+	dead, err := q.DeadLetters()
+	require.NoError(t, err)
+	assert.Len(t, dead, 2)
+
+	err = q.Requeue(firstID)
+	require.NoError(t, err)
+
+	err = q.Requeue(firstID)
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+
+	pending, err := q.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, firstID, pending[0].ID)
+	assert.Equal(t, 0, pending[0].Attempts)
+	assert.Equal(t, codes.InvalidArgument, pending[0].LastErrorCode)
+
+	require.NoError(t, q.Discard(secondID))
+
+	err = q.Discard(secondID)
+	assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+
+	err = q.Requeue(secondID)
+	assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+}
+
+func TestQueue_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tx := test.TransactionGenerator().New()
+	sent := make(chan struct{})
+
+	c := mocks.NewAccessClient(t)
+	c.On("SendTransaction", mock.Anything, *tx).Return(nil).Once().Run(func(mock.Arguments) {
+		close(sent)
+	})
+
+	q := txqueue.New(c, txqueue.NewMemoryStore(), txqueue.WithPollInterval(time.Hour))
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- q.Run(ctx)
+	}()
+
+	_, err := q.Submit(tx)
+	require.NoError(t, err)
+
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("transaction was not sent")
+	}
+
+	cancel()
+	assert.True(t, errors.Is(<-errs, context.Canceled))
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.json")
+	tx := test.TransactionGenerator().New()
+
+	store, err := txqueue.NewFileStore(path)
+	require.NoError(t, err)
+
+	entries, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	entry := &txqueue.Entry{
+		ID:            tx.ID(),
+		Transaction:   tx,
+		State:         txqueue.StateDead,
+		Attempts:      3,
+		EnqueuedAt:    now,
+		NextAttemptAt: now,
+		LastError:     "rpc error: code = Unavailable",
+		LastErrorCode: codes.Unavailable,
+		FailedAt:      now,
+	}
+	require.NoError(t, store.Save(entry))
+
+	reopened, err := txqueue.NewFileStore(path)
+	require.NoError(t, err)
+
+	found, err := reopened.Get(tx.ID())
+	require.NoError(t, err)
+	assert.Equal(t, tx.ID(), found.Transaction.ID())
+	found.Transaction = tx
+	assert.Equal(t, entry, found)
+
+	entries, err = reopened.List()
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	require.NoError(t, reopened.Delete(tx.ID()))
+
+	_, err = reopened.Get(tx.ID())
+	assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+
+	err = reopened.Delete(flow.EmptyID)
+	assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+
+	reopened, err = txqueue.NewFileStore(path)
+	require.NoError(t, err)
+
+	entries, err = reopened.List()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
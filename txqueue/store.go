@@ -0,0 +1,277 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package txqueue
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// A Store persists the entries of a queue.
+//
+// Implementations must be safe for concurrent use, and must not retain or
+// return entries that are modified by the caller afterwards.
+type Store interface {
+	// Save inserts or replaces the entry with the ID of the given entry.
+	Save(entry *Entry) error
+	// Get returns the entry with the given ID, or fails with
+	// flowerrors.ErrNotFound if there is none.
+	Get(txID flow.Identifier) (*Entry, error)
+	// Delete removes the entry with the given ID, or fails with
+	// flowerrors.ErrNotFound if there is none.
+	Delete(txID flow.Identifier) error
+	// List returns all entries, in the order they were enqueued.
+	List() ([]*Entry, error)
+}
+
+// A MemoryStore is a Store that keeps entries in memory.
+//
+// It is useful for testing, or for applications that do not need queued
+// submissions to survive a restart.
+type MemoryStore struct {
+	mut     sync.Mutex
+	entries map[flow.Identifier]Entry
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[flow.Identifier]Entry),
+	}
+}
+
+func (s *MemoryStore) Save(entry *Entry) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.entries[entry.ID] = *entry
+	return nil
+}
+
+func (s *MemoryStore) Get(txID flow.Identifier) (*Entry, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	entry, ok := s.entries[txID]
+	if !ok {
+		return nil, notFound(txID)
+	}
+
+	return &entry, nil
+}
+
+func (s *MemoryStore) Delete(txID flow.Identifier) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if _, ok := s.entries[txID]; !ok {
+		return notFound(txID)
+	}
+
+	delete(s.entries, txID)
+	return nil
+}
+
+func (s *MemoryStore) List() ([]*Entry, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	entries := make([]*Entry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		entry := entry
+		entries = append(entries, &entry)
+	}
+
+	sortEntries(entries)
+
+	return entries, nil
+}
+
+// A FileStore is a Store that persists entries to a JSON file.
+//
+// The file is rewritten on every change, which makes it suitable for queues
+// holding up to a few thousand transactions. Larger queues should implement
+// Store on top of their database.
+type FileStore struct {
+	path  string
+	mut   sync.Mutex
+	state map[string]fileEntry
+}
+
+// fileEntry is the JSON representation of an entry. The transaction is stored
+// in its canonical encoding.
+type fileEntry struct {
+	Transaction   []byte     `json:"transaction"`
+	State         State      `json:"state"`
+	Attempts      int        `json:"attempts"`
+	EnqueuedAt    time.Time  `json:"enqueuedAt"`
+	NextAttemptAt time.Time  `json:"nextAttemptAt"`
+	LastError     string     `json:"lastError,omitempty"`
+	LastErrorCode codes.Code `json:"lastErrorCode,omitempty"`
+	FailedAt      time.Time  `json:"failedAt,omitempty"`
+}
+
+// NewFileStore opens the store at the given path, creating it if it does not exist.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{
+		path:  path,
+		state: make(map[string]fileEntry),
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(b, &s.state)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "txqueue: failed to decode store %s: %w", path, err)
+	}
+
+	if s.state == nil {
+		s.state = make(map[string]fileEntry)
+	}
+
+	return s, nil
+}
+
+func (s *FileStore) Save(entry *Entry) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.state[entry.ID.String()] = fileEntry{
+		Transaction:   entry.Transaction.Encode(),
+		State:         entry.State,
+		Attempts:      entry.Attempts,
+		EnqueuedAt:    entry.EnqueuedAt,
+		NextAttemptAt: entry.NextAttemptAt,
+		LastError:     entry.LastError,
+		LastErrorCode: entry.LastErrorCode,
+		FailedAt:      entry.FailedAt,
+	}
+
+	return s.save()
+}
+
+func (s *FileStore) Get(txID flow.Identifier) (*Entry, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	f, ok := s.state[txID.String()]
+	if !ok {
+		return nil, notFound(txID)
+	}
+
+	return fromFileEntry(txID, f)
+}
+
+func (s *FileStore) Delete(txID flow.Identifier) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	key := txID.String()
+	if _, ok := s.state[key]; !ok {
+		return notFound(txID)
+	}
+
+	delete(s.state, key)
+	return s.save()
+}
+
+func (s *FileStore) List() ([]*Entry, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	entries := make([]*Entry, 0, len(s.state))
+	for key, f := range s.state {
+		entry, err := fromFileEntry(flow.HexToID(key), f)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sortEntries(entries)
+
+	return entries, nil
+}
+
+// save atomically writes the state to disk. It must be called with the lock held.
+func (s *FileStore) save() error {
+	b, err := json.Marshal(s.state)
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+
+	err = ioutil.WriteFile(tmp, b, 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.path)
+}
+
+func fromFileEntry(txID flow.Identifier, f fileEntry) (*Entry, error) {
+	tx, err := flow.DecodeTransaction(f.Transaction)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "txqueue: failed to decode transaction %s: %w", txID, err)
+	}
+
+	return &Entry{
+		ID:            txID,
+		Transaction:   tx,
+		State:         f.State,
+		Attempts:      f.Attempts,
+		EnqueuedAt:    f.EnqueuedAt,
+		NextAttemptAt: f.NextAttemptAt,
+		LastError:     f.LastError,
+		LastErrorCode: f.LastErrorCode,
+		FailedAt:      f.FailedAt,
+	}, nil
+}
+
+// sortEntries sorts entries by the time they were enqueued, then by ID.
+func sortEntries(entries []*Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if !a.EnqueuedAt.Equal(b.EnqueuedAt) {
+			return a.EnqueuedAt.Before(b.EnqueuedAt)
+		}
+		return a.ID.String() < b.ID.String()
+	})
+}
+
+func notFound(txID flow.Identifier) error {
+	return flowerrors.Errorf(flowerrors.ErrNotFound, "txqueue: transaction %s is not queued", txID)
+}
@@ -0,0 +1,167 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package verification
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// ErrInvalidExecutionData is wrapped by all errors returned for execution data that fails verification.
+var ErrInvalidExecutionData = errors.New("verification: invalid execution data")
+
+// VerifyExecutionData checks that the execution data of a block matches the
+// execution result of the block.
+//
+// The result should be sealed, and its seal verified with a Verifier. The
+// following properties are verified:
+//
+//   - the block, the result and the execution data refer to the same block
+//   - the execution data has one chunk for every chunk of the result
+//   - the collection of every chunk is the collection guaranteed in the block at the
+//     index of the chunk, and the system chunk executes no guaranteed collection
+//   - the number of transactions of every chunk matches the result
+//   - the events of every chunk hash to the event collection of the chunk in the result
+//   - the register updates of every chunk apply to the start state of the chunk in the result
+func VerifyExecutionData(block *flow.Block, result *flow.ExecutionResult, data *flow.BlockExecutionData) error {
+	if result.BlockID != block.ID {
+		return invalidExecutionData("result is for block %s, but block is %s", result.BlockID, block.ID)
+	}
+
+	if data.BlockID != block.ID {
+		return invalidExecutionData("execution data is for block %s, but block is %s", data.BlockID, block.ID)
+	}
+
+	if len(data.ChunkExecutionData) != len(result.Chunks) {
+		return invalidExecutionData(
+			"execution data has %d chunks, but result has %d chunks",
+			len(data.ChunkExecutionData),
+			len(result.Chunks),
+		)
+	}
+
+	if len(result.Chunks) != len(block.CollectionGuarantees)+1 {
+		return invalidExecutionData(
+			"result has %d chunks, but block has %d collections",
+			len(result.Chunks),
+			len(block.CollectionGuarantees),
+		)
+	}
+
+	for i, chunk := range result.Chunks {
+		err := verifyChunkExecutionData(block, chunk, i, data.ChunkExecutionData[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func verifyChunkExecutionData(block *flow.Block, chunk *flow.Chunk, index int, data *flow.ChunkExecutionData) error {
+	if data == nil {
+		return invalidExecutionData("chunk %d has no execution data", index)
+	}
+
+	if len(data.Transactions) != int(chunk.NumberOfTransactions) {
+		return invalidExecutionData(
+			"chunk %d has %d transactions, but result has %d",
+			index,
+			len(data.Transactions),
+			chunk.NumberOfTransactions,
+		)
+	}
+
+	// the system chunk is the last chunk, and executes the system transaction only
+	if index < len(block.CollectionGuarantees) {
+		collectionID := data.Collection().ID()
+		guaranteed := block.CollectionGuarantees[index].CollectionID
+
+		if collectionID != guaranteed {
+			return invalidExecutionData("chunk %d executes collection %s, but block guarantees %s", index, collectionID, guaranteed)
+		}
+	}
+
+	eventsHash, err := flow.CalculateEventsHash(data.Events)
+	if err != nil {
+		return fmt.Errorf("verification: failed to hash events of chunk %d: %w", index, err)
+	}
+
+	if !bytes.Equal(eventsHash, chunk.EventCollection) {
+		return invalidExecutionData(
+			"events of chunk %d hash to %x, but result has %x",
+			index,
+			[]byte(eventsHash),
+			[]byte(chunk.EventCollection),
+		)
+	}
+
+	if data.TrieUpdate != nil && data.TrieUpdate.RootHash != chunk.StartState {
+		return invalidExecutionData(
+			"register updates of chunk %d apply to state %x, but chunk starts at %x",
+			index,
+			data.TrieUpdate.RootHash[:],
+			chunk.StartState[:],
+		)
+	}
+
+	return nil
+}
+
+// An ExecutionDataClient is the subset of the Flow Access API client used to fetch
+// the execution data of a block.
+//
+// It is satisfied by *client.Client.
+type ExecutionDataClient interface {
+	GetBlockByID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) (*flow.Block, error)
+	GetExecutionDataByBlockID(ctx context.Context, blockID flow.Identifier, opts ...grpc.CallOption) (*flow.BlockExecutionData, error)
+}
+
+// FetchExecutionData fetches the execution data of the block of a result, and
+// verifies it against the result with VerifyExecutionData.
+//
+// The result should be sealed, and its seal verified with a Verifier.
+func FetchExecutionData(ctx context.Context, client ExecutionDataClient, result *flow.ExecutionResult) (*flow.BlockExecutionData, error) {
+	block, err := client.GetBlockByID(ctx, result.BlockID)
+	if err != nil {
+		return nil, fmt.Errorf("verification: failed to fetch block %s: %w", result.BlockID, err)
+	}
+
+	data, err := client.GetExecutionDataByBlockID(ctx, result.BlockID)
+	if err != nil {
+		return nil, fmt.Errorf("verification: failed to fetch execution data of block %s: %w", result.BlockID, err)
+	}
+
+	err = VerifyExecutionData(block, result, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func invalidExecutionData(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidExecutionData, fmt.Sprintf(format, args...))
+}
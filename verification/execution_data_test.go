@@ -0,0 +1,184 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package verification_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+	"github.com/onflow/flow-go-sdk/verification"
+)
+
+func executionDataFixtures(t *testing.T) (*flow.Block, *flow.ExecutionResult, *flow.BlockExecutionData) {
+	events := test.EventGenerator()
+
+	txA := flow.NewTransaction().SetScript([]byte("transaction { execute { log(1) } }"))
+	txB := flow.NewTransaction().SetScript([]byte("transaction { execute { log(2) } }"))
+	systemTx := flow.NewTransaction().SetScript([]byte("transaction { execute { log(0) } }"))
+
+	collection := &flow.ChunkExecutionData{
+		Transactions: []*flow.Transaction{txA, txB},
+		Events:       []flow.Event{events.New(), events.New()},
+		TrieUpdate:   &flow.TrieUpdate{RootHash: flow.StateCommitment(flow.HexToID("d0"))},
+	}
+
+	system := &flow.ChunkExecutionData{
+		Transactions: []*flow.Transaction{systemTx},
+		Events:       []flow.Event{events.New()},
+	}
+
+	collectionEvents, err := flow.CalculateEventsHash(collection.Events)
+	require.NoError(t, err)
+
+	systemEvents, err := flow.CalculateEventsHash(system.Events)
+	require.NoError(t, err)
+
+	block := &flow.Block{
+		BlockHeader: flow.BlockHeader{ID: blockID},
+		BlockPayload: flow.BlockPayload{
+			CollectionGuarantees: []*flow.CollectionGuarantee{
+				{CollectionID: flow.Collection{TransactionIDs: []flow.Identifier{txA.ID(), txB.ID()}}.ID()},
+			},
+		},
+	}
+
+	result := &flow.ExecutionResult{
+		BlockID: blockID,
+		Chunks: []*flow.Chunk{
+			{
+				Index:                0,
+				StartState:           flow.StateCommitment(flow.HexToID("d0")),
+				EndState:             flow.StateCommitment(flow.HexToID("d1")),
+				EventCollection:      collectionEvents,
+				NumberOfTransactions: 2,
+			},
+			{
+				Index:                1,
+				CollectionIndex:      1,
+				StartState:           flow.StateCommitment(flow.HexToID("d1")),
+				EndState:             flow.StateCommitment(flow.HexToID("d2")),
+				EventCollection:      systemEvents,
+				NumberOfTransactions: 1,
+			},
+		},
+	}
+
+	data := &flow.BlockExecutionData{
+		BlockID:            blockID,
+		ChunkExecutionData: []*flow.ChunkExecutionData{collection, system},
+	}
+
+	return block, result, data
+}
+
+func TestVerifyExecutionData(t *testing.T) {
+	block, result, data := executionDataFixtures(t)
+	require.NoError(t, verification.VerifyExecutionData(block, result, data))
+
+	invalid := []struct {
+		name   string
+		modify func(block *flow.Block, result *flow.ExecutionResult, data *flow.BlockExecutionData)
+	}{
+		{"block", func(_ *flow.Block, _ *flow.ExecutionResult, data *flow.BlockExecutionData) {
+			data.BlockID = flow.HexToID("b2")
+		}},
+		{"result block", func(_ *flow.Block, result *flow.ExecutionResult, _ *flow.BlockExecutionData) {
+			result.BlockID = flow.HexToID("b2")
+		}},
+		{"missing chunk", func(_ *flow.Block, _ *flow.ExecutionResult, data *flow.BlockExecutionData) {
+			data.ChunkExecutionData = data.ChunkExecutionData[:1]
+		}},
+		{"nil chunk", func(_ *flow.Block, _ *flow.ExecutionResult, data *flow.BlockExecutionData) {
+			data.ChunkExecutionData[1] = nil
+		}},
+		{"missing collection", func(block *flow.Block, _ *flow.ExecutionResult, _ *flow.BlockExecutionData) {
+			block.CollectionGuarantees = nil
+		}},
+		{"collection", func(_ *flow.Block, _ *flow.ExecutionResult, data *flow.BlockExecutionData) {
+			txs := data.ChunkExecutionData[0].Transactions
+			txs[0], txs[1] = txs[1], txs[0]
+		}},
+		{"transaction count", func(_ *flow.Block, result *flow.ExecutionResult, _ *flow.BlockExecutionData) {
+			result.Chunks[1].NumberOfTransactions = 2
+		}},
+		{"events", func(_ *flow.Block, _ *flow.ExecutionResult, data *flow.BlockExecutionData) {
+			data.ChunkExecutionData[0].Events = data.ChunkExecutionData[0].Events[:1]
+		}},
+		{"event payload", func(_ *flow.Block, _ *flow.ExecutionResult, data *flow.BlockExecutionData) {
+			data.ChunkExecutionData[1].Events[0].Payload = []byte("tampered")
+		}},
+		{"start state", func(_ *flow.Block, _ *flow.ExecutionResult, data *flow.BlockExecutionData) {
+			data.ChunkExecutionData[0].TrieUpdate.RootHash = flow.StateCommitment(flow.HexToID("d1"))
+		}},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			block, result, data := executionDataFixtures(t)
+			tt.modify(block, result, data)
+
+			err := verification.VerifyExecutionData(block, result, data)
+			assert.True(t, errors.Is(err, verification.ErrInvalidExecutionData), "unexpected error: %v", err)
+		})
+	}
+}
+
+type executionDataClient struct {
+	block *flow.Block
+	data  *flow.BlockExecutionData
+	err   error
+}
+
+func (c executionDataClient) GetBlockByID(_ context.Context, _ flow.Identifier, _ ...grpc.CallOption) (*flow.Block, error) {
+	return c.block, nil
+}
+
+func (c executionDataClient) GetExecutionDataByBlockID(
+	_ context.Context,
+	_ flow.Identifier,
+	_ ...grpc.CallOption,
+) (*flow.BlockExecutionData, error) {
+	return c.data, c.err
+}
+
+func TestFetchExecutionData(t *testing.T) {
+	ctx := context.Background()
+	block, result, data := executionDataFixtures(t)
+
+	fetched, err := verification.FetchExecutionData(ctx, executionDataClient{block: block, data: data}, result)
+	require.NoError(t, err)
+	assert.Equal(t, data, fetched)
+
+	data.ChunkExecutionData[0].Events = nil
+
+	_, err = verification.FetchExecutionData(ctx, executionDataClient{block: block, data: data}, result)
+	assert.True(t, errors.Is(err, verification.ErrInvalidExecutionData))
+
+	failure := errors.New("unavailable")
+
+	_, err = verification.FetchExecutionData(ctx, executionDataClient{block: block, err: failure}, result)
+	assert.True(t, errors.Is(err, failure))
+}
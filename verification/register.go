@@ -0,0 +1,226 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// ErrInvalidRegisterProof is wrapped by all errors returned for register proofs that fail verification.
+var ErrInvalidRegisterProof = errors.New("verification: invalid register proof")
+
+// The execution state is a sparse Merkle trie of height 256, in which each
+// register is stored at the leaf given by the 256-bit path of its ID. Subtries
+// holding a single register are compacted into a leaf at their root, and
+// subtries holding no register have a default hash.
+const trieHeight = 256
+
+// Key part types of the ledger keys of registers.
+const (
+	keyPartOwner = 0
+	keyPartKey   = 2
+)
+
+// defaultHashes are the hashes of empty subtries, by height.
+var defaultHashes = func() [trieHeight + 1][32]byte {
+	var hashes [trieHeight + 1][32]byte
+	copy(hashes[0][:], crypto.NewSHA3_256().ComputeHash([]byte("default:")))
+
+	for h := 1; h <= trieHeight; h++ {
+		hashes[h] = hashInterNode(hashes[h-1], hashes[h-1])
+	}
+
+	return hashes
+}()
+
+// A RegisterProof is a Merkle proof of the value of a register in the
+// execution state.
+//
+// The proof lists the hashes of the non-empty siblings of the nodes on the
+// path from the register to the root of the trie, starting from the root.
+type RegisterProof struct {
+	RegisterID flow.RegisterID
+	// Value is the value of the register, or empty if the register is not allocated.
+	Value []byte
+	// Steps is the depth of the compacted leaf holding the register.
+	Steps uint8
+	// Flags has a bit set for every level, starting from the root, at which the
+	// sibling is not empty.
+	Flags []byte
+	// Interims are the hashes of the non-empty siblings, starting from the root.
+	Interims [][32]byte
+}
+
+// RegisterPath returns the path of a register in the execution state trie.
+//
+// The path is the SHA3-256 hash of the canonical form of the ledger key of
+// the register, which is made of the owner and key parts of the register ID.
+func RegisterPath(id flow.RegisterID) [32]byte {
+	canonical := fmt.Sprintf("/%d/%s/%d/%s", keyPartOwner, id.Owner, keyPartKey, id.Key)
+
+	var path [32]byte
+	copy(path[:], crypto.NewSHA3_256().ComputeHash([]byte(canonical)))
+
+	return path
+}
+
+// Verify checks that the register has the value of the proof in the
+// execution state with the given commitment.
+//
+// The commitment must be trusted, e.g. the final state of a verified seal.
+func (p *RegisterProof) Verify(commitment flow.StateCommitment) error {
+	root, err := p.root(RegisterPath(p.RegisterID))
+	if err != nil {
+		return err
+	}
+
+	if root != commitment {
+		return invalidRegisterProof("register %s does not match state commitment %x", p.RegisterID, commitment[:])
+	}
+
+	return nil
+}
+
+// root computes the root hash of the trie from the proof, hashing from the
+// compacted leaf holding the register up to the root.
+func (p *RegisterProof) root(path [32]byte) (flow.StateCommitment, error) {
+	if len(p.Flags)*8 < int(p.Steps) {
+		return flow.StateCommitment{}, invalidRegisterProof("%d flags for %d steps", len(p.Flags)*8, p.Steps)
+	}
+
+	leafHeight := trieHeight - int(p.Steps)
+	computed := compactLeafHash(path, p.Value, leafHeight)
+
+	interim := len(p.Interims) - 1
+
+	for h := leafHeight + 1; h <= trieHeight; h++ {
+		sibling := defaultHashes[h-1]
+
+		if bit(p.Flags, trieHeight-h) == 1 {
+			if interim < 0 {
+				return flow.StateCommitment{}, invalidRegisterProof("too few interim hashes")
+			}
+			sibling = p.Interims[interim]
+			interim--
+		}
+
+		if bit(path[:], trieHeight-h) == 1 {
+			computed = hashInterNode(sibling, computed)
+		} else {
+			computed = hashInterNode(computed, sibling)
+		}
+	}
+
+	if interim >= 0 {
+		return flow.StateCommitment{}, invalidRegisterProof("too many interim hashes")
+	}
+
+	return flow.StateCommitment(computed), nil
+}
+
+// compactLeafHash returns the hash of a subtrie of the given height holding a
+// single register.
+func compactLeafHash(path [32]byte, value []byte, height int) [32]byte {
+	if len(value) == 0 {
+		return defaultHashes[height]
+	}
+
+	var computed [32]byte
+	copy(computed[:], crypto.NewSHA3_256().ComputeHash(append(path[:], value...)))
+
+	for h := 1; h <= height; h++ {
+		if bit(path[:], trieHeight-h) == 1 {
+			computed = hashInterNode(defaultHashes[h-1], computed)
+		} else {
+			computed = hashInterNode(computed, defaultHashes[h-1])
+		}
+	}
+
+	return computed
+}
+
+func hashInterNode(left, right [32]byte) [32]byte {
+	var h [32]byte
+	copy(h[:], crypto.NewSHA3_256().ComputeHash(append(left[:], right[:]...)))
+	return h
+}
+
+// bit returns the bit at the given index of b, starting from the most
+// significant bit of the first byte.
+func bit(b []byte, index int) int {
+	return int(b[index/8]>>(7-index%8)) & 1
+}
+
+// A RegisterProofClient fetches the values of registers together with their
+// proofs.
+//
+// The Access API does not serve register proofs yet: implement this interface
+// for the nodes or services that do.
+type RegisterProofClient interface {
+	GetRegisterProofs(ctx context.Context, blockID flow.Identifier, registerIDs []flow.RegisterID) ([]*RegisterProof, error)
+}
+
+// ReadRegisters fetches the values of registers after the execution of a
+// block, and verifies them against the trusted state commitment of the block.
+//
+// The values are returned in the order of the register IDs; registers that
+// are not allocated have an empty value.
+func ReadRegisters(
+	ctx context.Context,
+	client RegisterProofClient,
+	blockID flow.Identifier,
+	commitment flow.StateCommitment,
+	registerIDs []flow.RegisterID,
+) ([][]byte, error) {
+	proofs, err := client.GetRegisterProofs(ctx, blockID, registerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("verification: failed to fetch register proofs: %w", err)
+	}
+
+	if len(proofs) != len(registerIDs) {
+		return nil, invalidRegisterProof("%d proofs for %d registers", len(proofs), len(registerIDs))
+	}
+
+	values := make([][]byte, len(proofs))
+
+	for i, proof := range proofs {
+		id := registerIDs[i]
+		if proof.RegisterID != id {
+			return nil, invalidRegisterProof("proof for register %s, expected %s", proof.RegisterID, id)
+		}
+
+		err := proof.Verify(commitment)
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = append([]byte(nil), proof.Value...)
+	}
+
+	return values, nil
+}
+
+func invalidRegisterProof(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidRegisterProof, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,171 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package verification_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/verification"
+)
+
+// The register proofs below were produced by the ledger of flow-go, for a trie
+// holding three registers with the values 1, 2 and 3.
+var registerCommitment = flow.StateCommitment(flow.HexToID("40667120a04c2a33b3b44ac35e7c9c867dfd6ef206d060fb4309b36012b64b4a"))
+
+func hash(t *testing.T, h string) [32]byte {
+	b, err := hex.DecodeString(h)
+	require.NoError(t, err)
+
+	var hash [32]byte
+	copy(hash[:], b)
+	return hash
+}
+
+func registerProofs(t *testing.T) []*verification.RegisterProof {
+	return []*verification.RegisterProof{
+		{
+			RegisterID: flow.NewRegisterID(flow.HexToAddress("01"), "balance"),
+			Value:      []byte{1},
+			Steps:      2,
+			Flags:      []byte{0xc0},
+			Interims: [][32]byte{
+				hash(t, "826cdb418b7f249da8acd825b67f37b5e752d4b95fba04f66046b5057bfcad4d"),
+				hash(t, "59ccd25c8e7b50e95d548d1727583cc87a9537fa5fe2df81a38a1d061b4e998b"),
+			},
+		},
+		{
+			RegisterID: flow.NewRegisterID(flow.HexToAddress("01"), "storage_used"),
+			Value:      []byte{2},
+			Steps:      2,
+			Flags:      []byte{0xc0},
+			Interims: [][32]byte{
+				hash(t, "826cdb418b7f249da8acd825b67f37b5e752d4b95fba04f66046b5057bfcad4d"),
+				hash(t, "67762d326709ee83571e901b4efeabb93220f0c6d0aa7c4a71546b0a782ea764"),
+			},
+		},
+		{
+			RegisterID: flow.NewRegisterID(flow.HexToAddress("02"), "balance"),
+			Value:      []byte{3},
+			Steps:      1,
+			Flags:      []byte{0x80},
+			Interims: [][32]byte{
+				hash(t, "a999607e69f8ffe69949c4077aab3c16f1e539f50ea629c11d579e6b9881329a"),
+			},
+		},
+	}
+}
+
+func TestRegisterPath(t *testing.T) {
+	path := verification.RegisterPath(flow.NewRegisterID(flow.HexToAddress("01"), "balance"))
+	assert.Equal(t, hash(t, "ff6697e3542af2230cd5b7f055f2aebe3df06bb21b4d6f937b2f2ce51eb56e92"), path)
+}
+
+func TestRegisterProof_Verify(t *testing.T) {
+	for _, proof := range registerProofs(t) {
+		assert.NoError(t, proof.Verify(registerCommitment), proof.RegisterID.String())
+	}
+
+	invalid := []struct {
+		name   string
+		modify func(p *verification.RegisterProof)
+	}{
+		{"value", func(p *verification.RegisterProof) { p.Value = []byte{4} }},
+		{"empty value", func(p *verification.RegisterProof) { p.Value = nil }},
+		{"register", func(p *verification.RegisterProof) { p.RegisterID.Key = "absent" }},
+		{"interim", func(p *verification.RegisterProof) { p.Interims[1][0] ^= 1 }},
+		{"missing interim", func(p *verification.RegisterProof) { p.Interims = p.Interims[:1] }},
+		{"extra interim", func(p *verification.RegisterProof) { p.Interims = append(p.Interims, p.Interims[0]) }},
+		{"flags", func(p *verification.RegisterProof) { p.Flags = []byte{0x80} }},
+		{"missing flags", func(p *verification.RegisterProof) { p.Flags = nil }},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			proof := registerProofs(t)[0]
+			tt.modify(proof)
+
+			err := proof.Verify(registerCommitment)
+			assert.True(t, errors.Is(err, verification.ErrInvalidRegisterProof), "unexpected error: %v", err)
+		})
+	}
+
+	t.Run("commitment", func(t *testing.T) {
+		err := registerProofs(t)[0].Verify(finalState)
+		assert.True(t, errors.Is(err, verification.ErrInvalidRegisterProof))
+	})
+}
+
+type registerProofClient struct {
+	proofs []*verification.RegisterProof
+	err    error
+}
+
+func (c registerProofClient) GetRegisterProofs(
+	_ context.Context,
+	_ flow.Identifier,
+	_ []flow.RegisterID,
+) ([]*verification.RegisterProof, error) {
+	return c.proofs, c.err
+}
+
+func TestReadRegisters(t *testing.T) {
+	ctx := context.Background()
+
+	proofs := registerProofs(t)
+	registerIDs := []flow.RegisterID{proofs[0].RegisterID, proofs[1].RegisterID, proofs[2].RegisterID}
+
+	values, err := verification.ReadRegisters(ctx, registerProofClient{proofs: proofs}, blockID, registerCommitment, registerIDs)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{1}, {2}, {3}}, values)
+
+	t.Run("order", func(t *testing.T) {
+		reordered := []flow.RegisterID{registerIDs[1], registerIDs[0], registerIDs[2]}
+
+		_, err := verification.ReadRegisters(ctx, registerProofClient{proofs: proofs}, blockID, registerCommitment, reordered)
+		assert.True(t, errors.Is(err, verification.ErrInvalidRegisterProof))
+	})
+
+	t.Run("count", func(t *testing.T) {
+		_, err := verification.ReadRegisters(ctx, registerProofClient{proofs: proofs[:2]}, blockID, registerCommitment, registerIDs)
+		assert.True(t, errors.Is(err, verification.ErrInvalidRegisterProof))
+	})
+
+	t.Run("invalid proof", func(t *testing.T) {
+		proofs := registerProofs(t)
+		proofs[2].Value = []byte{4}
+
+		_, err := verification.ReadRegisters(ctx, registerProofClient{proofs: proofs}, blockID, registerCommitment, registerIDs)
+		assert.True(t, errors.Is(err, verification.ErrInvalidRegisterProof))
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		failure := errors.New("unavailable")
+
+		_, err := verification.ReadRegisters(ctx, registerProofClient{err: failure}, blockID, registerCommitment, registerIDs)
+		assert.True(t, errors.Is(err, failure))
+		assert.False(t, errors.Is(err, verification.ErrInvalidRegisterProof))
+	})
+}
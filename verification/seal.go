@@ -0,0 +1,245 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// ErrInvalidSeal is wrapped by all errors returned for seals that fail verification.
+var ErrInvalidSeal = errors.New("verification: invalid seal")
+
+// A SignatureVerifier checks the signature of a verification node over the approval of a chunk.
+//
+// Approval signatures are BLS signatures over a protocol-specific encoding of the
+// attestation, so their cryptographic verification is delegated to the application,
+// for instance using the hashing and BLS implementations of flow-go.
+type SignatureVerifier func(signer Identity, result *flow.ExecutionResult, chunkIndex uint64, signature []byte) (bool, error)
+
+// A ResultIDFunc computes the ID of an execution result.
+//
+// Result IDs are hashes over the protocol encoding of the result, which the SDK does
+// not reproduce, so their computation is delegated to the application, for instance
+// using the ID method of flow-go results.
+type ResultIDFunc func(result *flow.ExecutionResult) (flow.Identifier, error)
+
+// A Verifier checks seals against the identity table of a trusted snapshot.
+type Verifier struct {
+	snapshot          *Snapshot
+	requiredApprovals int
+	verifySignature   SignatureVerifier
+	resultID          ResultIDFunc
+}
+
+// An Option configures a Verifier.
+type Option func(*Verifier)
+
+// WithRequiredApprovals sets the minimum number of approvals required for every chunk.
+// The default is one.
+func WithRequiredApprovals(n int) Option {
+	return func(v *Verifier) {
+		v.requiredApprovals = n
+	}
+}
+
+// WithSignatureVerifier sets the function used to verify approval signatures.
+//
+// A signature verifier is required.
+func WithSignatureVerifier(verifier SignatureVerifier) Option {
+	return func(v *Verifier) {
+		v.verifySignature = verifier
+	}
+}
+
+// WithResultID sets the function used to compute the ID of sealed results.
+//
+// A result ID function is required.
+func WithResultID(resultID ResultIDFunc) Option {
+	return func(v *Verifier) {
+		v.resultID = resultID
+	}
+}
+
+// NewVerifier creates a verifier trusting the identity table of the given snapshot.
+//
+// An error is returned if no signature verifier or result ID function is configured,
+// because a seal cannot be trusted without checking both.
+func NewVerifier(snapshot *Snapshot, opts ...Option) (*Verifier, error) {
+	v := &Verifier{
+		snapshot:          snapshot,
+		requiredApprovals: 1,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if v.verifySignature == nil {
+		return nil, flowerrors.New(flowerrors.ErrInvalidArgument, "verification: a signature verifier is required")
+	}
+
+	if v.resultID == nil {
+		return nil, flowerrors.New(flowerrors.ErrInvalidArgument, "verification: a result ID function is required")
+	}
+
+	return v, nil
+}
+
+// VerifySeal checks that a seal is consistent with the execution result it seals and
+// with the identity table of the trusted snapshot.
+//
+// The result should be fetched by the ID referenced in the seal, e.g. with
+// client.GetExecutionResultByID. The following properties are verified:
+//
+//   - the ID of the result is the result ID of the seal
+//   - the seal and the result refer to the same block
+//   - the final state of the seal is the end state of the last chunk of the result
+//   - every chunk is approved by the required number of distinct, staked verification nodes
+//   - every approval signature is valid
+func (v *Verifier) VerifySeal(seal *flow.BlockSeal, result *flow.ExecutionResult) error {
+	resultID, err := v.resultID(result)
+	if err != nil {
+		return fmt.Errorf("verification: failed to compute ID of result for block %s: %w", result.BlockID, err)
+	}
+
+	if resultID != seal.ResultID {
+		return invalidSeal("seal is for result %s, but result has ID %s", seal.ResultID, resultID)
+	}
+
+	if seal.BlockID != result.BlockID {
+		return invalidSeal("seal is for block %s, but result is for block %s", seal.BlockID, result.BlockID)
+	}
+
+	if len(result.Chunks) == 0 {
+		return invalidSeal("result for block %s has no chunks", result.BlockID)
+	}
+
+	finalChunk := result.Chunks[len(result.Chunks)-1]
+	if seal.FinalState != finalChunk.EndState {
+		return invalidSeal(
+			"seal final state %x does not match result end state %x",
+			seal.FinalState[:],
+			finalChunk.EndState[:],
+		)
+	}
+
+	if len(seal.AggregatedApprovalSigs) != len(result.Chunks) {
+		return invalidSeal(
+			"seal has approvals for %d chunks, but result has %d chunks",
+			len(seal.AggregatedApprovalSigs),
+			len(result.Chunks),
+		)
+	}
+
+	for i, approvals := range seal.AggregatedApprovalSigs {
+		err := v.verifyApprovals(result, uint64(i), approvals)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *Verifier) verifyApprovals(result *flow.ExecutionResult, chunkIndex uint64, approvals *flow.AggregatedSignature) error {
+	if len(approvals.SignerIDs) != len(approvals.VerifierSignatures) {
+		return invalidSeal(
+			"chunk %d has %d signers but %d signatures",
+			chunkIndex,
+			len(approvals.SignerIDs),
+			len(approvals.VerifierSignatures),
+		)
+	}
+
+	if len(approvals.SignerIDs) < v.requiredApprovals {
+		return invalidSeal(
+			"chunk %d has %d approvals, %d required",
+			chunkIndex,
+			len(approvals.SignerIDs),
+			v.requiredApprovals,
+		)
+	}
+
+	seen := make(map[flow.Identifier]struct{}, len(approvals.SignerIDs))
+
+	for j, signerID := range approvals.SignerIDs {
+		if _, ok := seen[signerID]; ok {
+			return invalidSeal("chunk %d is approved twice by node %s", chunkIndex, signerID)
+		}
+		seen[signerID] = struct{}{}
+
+		signer, ok := v.snapshot.Identity(signerID)
+		if !ok {
+			return invalidSeal("chunk %d is approved by unknown node %s", chunkIndex, signerID)
+		}
+
+		if signer.Role != RoleVerification {
+			return invalidSeal("chunk %d is approved by %s node %s", chunkIndex, signer.Role, signerID)
+		}
+
+		if signer.Weight == 0 {
+			return invalidSeal("chunk %d is approved by unstaked node %s", chunkIndex, signerID)
+		}
+
+		valid, err := v.verifySignature(signer, result, chunkIndex, approvals.VerifierSignatures[j])
+		if err != nil {
+			return fmt.Errorf("verification: failed to verify approval of chunk %d by node %s: %w", chunkIndex, signerID, err)
+		}
+		if !valid {
+			return invalidSeal("chunk %d has an invalid approval signature from node %s", chunkIndex, signerID)
+		}
+	}
+
+	return nil
+}
+
+// A ResultClient is the subset of the Flow Access API client used to fetch sealed results.
+//
+// It is satisfied by *client.Client.
+type ResultClient interface {
+	GetExecutionResultByID(ctx context.Context, resultID flow.Identifier, opts ...grpc.CallOption) (*flow.ExecutionResult, error)
+}
+
+// VerifyBlockSeals fetches the results sealed by a block and verifies each of its seals.
+func (v *Verifier) VerifyBlockSeals(ctx context.Context, client ResultClient, block *flow.Block) error {
+	for _, seal := range block.Seals {
+		result, err := client.GetExecutionResultByID(ctx, seal.ResultID)
+		if err != nil {
+			return fmt.Errorf("verification: failed to fetch result %s: %w", seal.ResultID, err)
+		}
+
+		err = v.VerifySeal(seal, result)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func invalidSeal(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidSeal, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,147 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package verification checks data served by access nodes against a trusted protocol state.
+//
+// A protocol state snapshot, obtained once from a trusted source (for example with
+// client.GetLatestProtocolStateSnapshot from an access node operated by the application),
+// provides the identity table of the network. Seals and execution results subsequently
+// received from any access node can be checked for consistency with that table, which
+// reduces the trust placed in the serving node.
+//
+// The final state commitment of a verified seal in turn allows to check the values of
+// registers with their Merkle proofs, using RegisterProof. A verified execution result
+// in turn allows to check the execution data of its block, using VerifyExecutionData.
+package verification
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+)
+
+// Roles of the nodes of a Flow network.
+const (
+	RoleCollection   = "collection"
+	RoleConsensus    = "consensus"
+	RoleExecution    = "execution"
+	RoleVerification = "verification"
+	RoleAccess       = "access"
+)
+
+// An Identity is a staked node of the network.
+type Identity struct {
+	NodeID flow.Identifier
+	Role   string
+	Weight uint64
+	// StakingPubKey is the encoded BLS staking key of the node.
+	StakingPubKey []byte
+}
+
+// A Snapshot is the subset of a protocol state snapshot needed to verify seals.
+type Snapshot struct {
+	// Head is the block at which the snapshot was taken.
+	Head flow.BlockHeader
+	// Identities is the identity table of the current epoch.
+	Identities []Identity
+}
+
+// Identity returns the identity of the node with the given ID.
+func (s *Snapshot) Identity(nodeID flow.Identifier) (Identity, bool) {
+	for _, identity := range s.Identities {
+		if identity.NodeID == nodeID {
+			return identity, true
+		}
+	}
+	return Identity{}, false
+}
+
+// encodableSnapshot mirrors the JSON encoding of protocol state snapshots served by
+// the Access API. Only the fields used for verification are decoded.
+type encodableSnapshot struct {
+	Head *struct {
+		ParentID  string
+		Height    uint64
+		Timestamp time.Time
+	}
+	Identities []struct {
+		NodeID        string
+		Role          string
+		Weight        uint64
+		Stake         uint64 // name of the weight field in older protocol versions
+		StakingPubKey []byte
+	}
+}
+
+// DecodeSnapshot decodes a JSON-encoded protocol state snapshot, as returned by
+// client.GetLatestProtocolStateSnapshot.
+func DecodeSnapshot(data []byte) (*Snapshot, error) {
+	var encodable encodableSnapshot
+
+	err := json.Unmarshal(data, &encodable)
+	if err != nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrDecoding, "verification: failed to decode snapshot: %w", err)
+	}
+
+	if encodable.Head == nil {
+		return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "verification: snapshot has no head")
+	}
+
+	if len(encodable.Identities) == 0 {
+		return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "verification: snapshot has no identities")
+	}
+
+	parentID, err := flow.ParseID(encodable.Head.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("verification: failed to decode snapshot head: %w", err)
+	}
+
+	snapshot := &Snapshot{
+		Head: flow.BlockHeader{
+			ParentID:  parentID,
+			Height:    encodable.Head.Height,
+			Timestamp: encodable.Head.Timestamp,
+		},
+		Identities: make([]Identity, len(encodable.Identities)),
+	}
+
+	for i, identity := range encodable.Identities {
+		weight := identity.Weight
+		if weight == 0 {
+			weight = identity.Stake
+		}
+
+		nodeID, err := flow.ParseID(identity.NodeID)
+		if err != nil {
+			return nil, fmt.Errorf("verification: failed to decode identity %d: %w", i, err)
+		}
+
+		snapshot.Identities[i] = Identity{
+			NodeID:        nodeID,
+			Role:          strings.ToLower(identity.Role),
+			Weight:        weight,
+			StakingPubKey: identity.StakingPubKey,
+		}
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,266 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package verification_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/verification"
+)
+
+const snapshotJSON = `{
+	"Head": {
+		"ChainID": "flow-mainnet",
+		"ParentID": "0100000000000000000000000000000000000000000000000000000000000000",
+		"Height": 100,
+		"Timestamp": "2021-06-01T00:00:00Z"
+	},
+	"Identities": [
+		{"NodeID": "a100000000000000000000000000000000000000000000000000000000000000", "Role": "verification", "Weight": 1000, "StakingPubKey": "AQI="},
+		{"NodeID": "a200000000000000000000000000000000000000000000000000000000000000", "Role": "verification", "Stake": 1000},
+		{"NodeID": "a300000000000000000000000000000000000000000000000000000000000000", "Role": "verification", "Weight": 0},
+		{"NodeID": "e100000000000000000000000000000000000000000000000000000000000000", "Role": "execution", "Weight": 1000}
+	]
+}`
+
+var (
+	verifierA  = flow.HexToID("a1")
+	verifierB  = flow.HexToID("a2")
+	unstaked   = flow.HexToID("a3")
+	executor   = flow.HexToID("e1")
+	blockID    = flow.HexToID("b1")
+	resultID   = flow.HexToID("c1")
+	finalState = flow.StateCommitment(flow.HexToID("d1"))
+)
+
+func fixtures() (*flow.BlockSeal, *flow.ExecutionResult) {
+	result := &flow.ExecutionResult{
+		BlockID: blockID,
+		Chunks: []*flow.Chunk{
+			{Index: 0, EndState: flow.StateCommitment(flow.HexToID("d0"))},
+			{Index: 1, EndState: finalState},
+		},
+	}
+
+	seal := &flow.BlockSeal{
+		BlockID:    blockID,
+		ResultID:   resultID,
+		FinalState: finalState,
+		AggregatedApprovalSigs: []*flow.AggregatedSignature{
+			{SignerIDs: []flow.Identifier{verifierA}, VerifierSignatures: [][]byte{[]byte("a")}},
+			{SignerIDs: []flow.Identifier{verifierA, verifierB}, VerifierSignatures: [][]byte{[]byte("a"), []byte("b")}},
+		},
+	}
+
+	return seal, result
+}
+
+func TestDecodeSnapshot(t *testing.T) {
+	snapshot, err := verification.DecodeSnapshot([]byte(snapshotJSON))
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(100), snapshot.Head.Height)
+	require.Len(t, snapshot.Identities, 4)
+
+	identity, ok := snapshot.Identity(verifierA)
+	require.True(t, ok)
+	assert.Equal(t, verification.RoleVerification, identity.Role)
+	assert.Equal(t, uint64(1000), identity.Weight)
+	assert.Equal(t, []byte{1, 2}, identity.StakingPubKey)
+
+	identity, ok = snapshot.Identity(verifierB)
+	require.True(t, ok)
+	assert.Equal(t, uint64(1000), identity.Weight)
+
+	_, err = verification.DecodeSnapshot([]byte(`{"Identities": []}`))
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument), "unexpected error: %v", err)
+
+	_, err = verification.DecodeSnapshot([]byte(`{`))
+	assert.True(t, errors.Is(err, flowerrors.ErrDecoding), "unexpected error: %v", err)
+
+	malformed := strings.Replace(snapshotJSON, `"a200000000000000000000000000000000000000000000000000000000000000"`, `"a2"`, 1)
+	_, err = verification.DecodeSnapshot([]byte(malformed))
+	assert.True(t, errors.Is(err, flowerrors.ErrDecoding), "unexpected error: %v", err)
+
+	malformed = strings.Replace(snapshotJSON, `"0100000000000000000000000000000000000000000000000000000000000000"`, `"not hex"`, 1)
+	_, err = verification.DecodeSnapshot([]byte(malformed))
+	assert.True(t, errors.Is(err, flowerrors.ErrDecoding), "unexpected error: %v", err)
+}
+
+func acceptSignatures(verification.Identity, *flow.ExecutionResult, uint64, []byte) (bool, error) {
+	return true, nil
+}
+
+func fixedResultID(*flow.ExecutionResult) (flow.Identifier, error) {
+	return resultID, nil
+}
+
+func newVerifier(t *testing.T, snapshot *verification.Snapshot, opts ...verification.Option) *verification.Verifier {
+	opts = append([]verification.Option{
+		verification.WithSignatureVerifier(acceptSignatures),
+		verification.WithResultID(fixedResultID),
+	}, opts...)
+
+	verifier, err := verification.NewVerifier(snapshot, opts...)
+	require.NoError(t, err)
+	return verifier
+}
+
+func TestNewVerifier(t *testing.T) {
+	snapshot, err := verification.DecodeSnapshot([]byte(snapshotJSON))
+	require.NoError(t, err)
+
+	_, err = verification.NewVerifier(snapshot, verification.WithResultID(fixedResultID))
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument), "unexpected error: %v", err)
+
+	_, err = verification.NewVerifier(snapshot, verification.WithSignatureVerifier(acceptSignatures))
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument), "unexpected error: %v", err)
+}
+
+func TestVerifier_VerifySeal(t *testing.T) {
+	snapshot, err := verification.DecodeSnapshot([]byte(snapshotJSON))
+	require.NoError(t, err)
+
+	t.Run("Valid", func(t *testing.T) {
+		seal, result := fixtures()
+
+		err := newVerifier(t, snapshot).VerifySeal(seal, result)
+		assert.NoError(t, err)
+	})
+
+	invalid := []struct {
+		name   string
+		modify func(seal *flow.BlockSeal, result *flow.ExecutionResult)
+	}{
+		{"Result mismatch", func(seal *flow.BlockSeal, _ *flow.ExecutionResult) {
+			seal.ResultID = flow.HexToID("c2")
+		}},
+		{"Block mismatch", func(seal *flow.BlockSeal, _ *flow.ExecutionResult) {
+			seal.BlockID = flow.HexToID("b2")
+		}},
+		{"Final state mismatch", func(seal *flow.BlockSeal, _ *flow.ExecutionResult) {
+			seal.FinalState = flow.StateCommitment(flow.HexToID("d0"))
+		}},
+		{"Missing chunk approvals", func(seal *flow.BlockSeal, _ *flow.ExecutionResult) {
+			seal.AggregatedApprovalSigs = seal.AggregatedApprovalSigs[:1]
+		}},
+		{"No approvals", func(seal *flow.BlockSeal, _ *flow.ExecutionResult) {
+			seal.AggregatedApprovalSigs[0] = &flow.AggregatedSignature{}
+		}},
+		{"Unknown signer", func(seal *flow.BlockSeal, _ *flow.ExecutionResult) {
+			seal.AggregatedApprovalSigs[0].SignerIDs[0] = flow.HexToID("ff")
+		}},
+		{"Unstaked signer", func(seal *flow.BlockSeal, _ *flow.ExecutionResult) {
+			seal.AggregatedApprovalSigs[0].SignerIDs[0] = unstaked
+		}},
+		{"Wrong role", func(seal *flow.BlockSeal, _ *flow.ExecutionResult) {
+			seal.AggregatedApprovalSigs[0].SignerIDs[0] = executor
+		}},
+		{"Duplicate signer", func(seal *flow.BlockSeal, _ *flow.ExecutionResult) {
+			seal.AggregatedApprovalSigs[1].SignerIDs[1] = verifierA
+		}},
+		{"Signature count mismatch", func(seal *flow.BlockSeal, _ *flow.ExecutionResult) {
+			seal.AggregatedApprovalSigs[1].VerifierSignatures = seal.AggregatedApprovalSigs[1].VerifierSignatures[:1]
+		}},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			seal, result := fixtures()
+			tt.modify(seal, result)
+
+			err := newVerifier(t, snapshot).VerifySeal(seal, result)
+			assert.True(t, errors.Is(err, verification.ErrInvalidSeal), "unexpected error: %v", err)
+		})
+	}
+
+	t.Run("Required approvals", func(t *testing.T) {
+		seal, result := fixtures()
+
+		err := newVerifier(t, snapshot, verification.WithRequiredApprovals(2)).VerifySeal(seal, result)
+		assert.True(t, errors.Is(err, verification.ErrInvalidSeal))
+	})
+
+	t.Run("Signature verifier", func(t *testing.T) {
+		seal, result := fixtures()
+
+		var verified []uint64
+		verifier := newVerifier(t, snapshot, verification.WithSignatureVerifier(
+			func(signer verification.Identity, _ *flow.ExecutionResult, chunkIndex uint64, signature []byte) (bool, error) {
+				verified = append(verified, chunkIndex)
+				return signer.NodeID != verifierB || string(signature) == "b", nil
+			},
+		))
+
+		require.NoError(t, verifier.VerifySeal(seal, result))
+		assert.Equal(t, []uint64{0, 1, 1}, verified)
+
+		seal.AggregatedApprovalSigs[1].VerifierSignatures[1] = []byte("forged")
+		err := verifier.VerifySeal(seal, result)
+		assert.True(t, errors.Is(err, verification.ErrInvalidSeal))
+	})
+
+	t.Run("Result ID error", func(t *testing.T) {
+		seal, result := fixtures()
+
+		failure := errors.New("unsupported result")
+		verifier := newVerifier(t, snapshot, verification.WithResultID(
+			func(*flow.ExecutionResult) (flow.Identifier, error) {
+				return flow.EmptyID, failure
+			},
+		))
+
+		err := verifier.VerifySeal(seal, result)
+		assert.True(t, errors.Is(err, failure), "unexpected error: %v", err)
+	})
+}
+
+type fakeResults map[flow.Identifier]*flow.ExecutionResult
+
+func (r fakeResults) GetExecutionResultByID(_ context.Context, id flow.Identifier, _ ...grpc.CallOption) (*flow.ExecutionResult, error) {
+	result, ok := r[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return result, nil
+}
+
+func TestVerifier_VerifyBlockSeals(t *testing.T) {
+	snapshot, err := verification.DecodeSnapshot([]byte(snapshotJSON))
+	require.NoError(t, err)
+
+	verifier := newVerifier(t, snapshot)
+	seal, result := fixtures()
+	block := &flow.Block{BlockPayload: flow.BlockPayload{Seals: []*flow.BlockSeal{seal}}}
+
+	err = verifier.VerifyBlockSeals(context.Background(), fakeResults{resultID: result}, block)
+	assert.NoError(t, err)
+
+	err = verifier.VerifyBlockSeals(context.Background(), fakeResults{}, block)
+	assert.Error(t, err)
+}
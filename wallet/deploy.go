@@ -0,0 +1,100 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+type deployOptions struct {
+	update    bool
+	chunkSize int
+}
+
+// A DeployOption configures DeployContract.
+type DeployOption func(*deployOptions)
+
+// WithUpdate updates a contract already deployed to the account instead of
+// adding a new one.
+func WithUpdate() DeployOption {
+	return func(o *deployOptions) {
+		o.update = true
+	}
+}
+
+// WithChunkSize sets the number of bytes of code sent by each transaction of a
+// chunked deployment. The default is templates.DefaultContractChunkSize.
+func WithChunkSize(size int) DeployOption {
+	return func(o *deployOptions) {
+		o.chunkSize = size
+	}
+}
+
+// DeployContract deploys a contract to the account, or updates it with
+// WithUpdate, and checks that the account holds the code of the contract once
+// the deployment is sealed.
+//
+// A contract whose code fits in one chunk is deployed by a single transaction.
+// Larger contracts are deployed in several transactions, generated by
+// templates.ChunkedContractDeployment, each of which is sent with the account
+// and awaited until it is sealed before the next one is sent.
+func DeployContract(ctx context.Context, account *Account, contract templates.Contract, opts ...DeployOption) error {
+	o := &deployOptions{chunkSize: templates.DefaultContractChunkSize}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.chunkSize <= 0 {
+		o.chunkSize = templates.DefaultContractChunkSize
+	}
+
+	var txs []*flow.Transaction
+
+	switch {
+	case len(contract.Source) > o.chunkSize:
+		txs = templates.ChunkedContractDeployment(account.Address(), contract, o.chunkSize, o.update)
+	case o.update:
+		txs = []*flow.Transaction{templates.UpdateAccountContract(account.Address(), contract)}
+	default:
+		txs = []*flow.Transaction{templates.AddAccountContract(account.Address(), contract)}
+	}
+
+	for i, tx := range txs {
+		if _, err := account.SendAndWait(ctx, tx); err != nil {
+			return fmt.Errorf("wallet: deployment of contract %s failed at transaction %d of %d: %w", contract.Name, i+1, len(txs), err)
+		}
+	}
+
+	deployed, err := account.client.GetAccountAtLatestBlock(ctx, account.Address())
+	if err != nil {
+		return fmt.Errorf("wallet: failed to get account %s: %w", account.Address(), err)
+	}
+
+	code, ok := deployed.Contracts[contract.Name]
+	if !ok || !bytes.Equal(code, contract.SourceBytes()) {
+		return fmt.Errorf("wallet: account %s does not hold the deployed code of contract %s", account.Address(), contract.Name)
+	}
+
+	return nil
+}
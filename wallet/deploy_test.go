@@ -0,0 +1,106 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wallet_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go-sdk/wallet"
+)
+
+func TestDeployContract(t *testing.T) {
+	ctx := context.Background()
+	sealed := &flow.TransactionResult{Status: flow.TransactionStatusSealed}
+
+	contract := templates.Contract{
+		Name:   "Large",
+		Source: "access(all) contract Large {" + strings.Repeat(" ", 50) + "}",
+	}
+
+	t.Run("Chunked", func(t *testing.T) {
+		f := newFixture(t)
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+		f.client.On("GetLatestBlockHeader", ctx, false).Return(&f.header, nil)
+
+		var sent []flow.Transaction
+		f.client.On("SendTransaction", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { sent = append(sent, args.Get(1).(flow.Transaction)) }).
+			Return(nil).Times(4)
+		f.client.On("GetTransactionResult", ctx, mock.Anything).Return(sealed, nil).Times(4)
+
+		account := f.newAccount(t)
+
+		deployed := *f.account
+		deployed.Contracts = map[string][]byte{contract.Name: contract.SourceBytes()}
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(&deployed, nil).Once()
+
+		err := wallet.DeployContract(ctx, account, contract, wallet.WithChunkSize(30))
+		require.NoError(t, err)
+
+		require.Len(t, sent, 4)
+		for i, tx := range sent {
+			assert.Equal(t, f.key.SequenceNumber+uint64(i), tx.ProposalKey.SequenceNumber)
+		}
+		assert.Contains(t, string(sent[3].Script), "HashAlgorithm.SHA3_256")
+	})
+
+	t.Run("Single transaction", func(t *testing.T) {
+		f := newFixture(t)
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+		f.client.On("GetLatestBlockHeader", ctx, false).Return(&f.header, nil).Once()
+
+		var sent flow.Transaction
+		f.client.On("SendTransaction", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { sent = args.Get(1).(flow.Transaction) }).
+			Return(nil).Once()
+		f.client.On("GetTransactionResult", ctx, mock.Anything).Return(sealed, nil).Once()
+
+		account := f.newAccount(t)
+
+		// the account does not hold the deployed code
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+
+		err := wallet.DeployContract(ctx, account, contract, wallet.WithUpdate())
+		assert.Error(t, err)
+		assert.Equal(t, templates.UpdateAccountContract(f.account.Address, contract).Script, sent.Script)
+	})
+
+	t.Run("Failed chunk", func(t *testing.T) {
+		f := newFixture(t)
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+		f.client.On("GetLatestBlockHeader", ctx, false).Return(&f.header, nil).Once()
+		f.client.On("SendTransaction", ctx, mock.Anything).Return(nil).Once()
+		f.client.On("GetTransactionResult", ctx, mock.Anything).
+			Return(&flow.TransactionResult{Status: flow.TransactionStatusSealed, Error: assert.AnError}, nil).Once()
+
+		account := f.newAccount(t)
+
+		err := wallet.DeployContract(ctx, account, contract, wallet.WithChunkSize(30))
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Contains(t, err.Error(), "transaction 1 of 4")
+	})
+}
@@ -0,0 +1,184 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+// ProvisionKeys adds n copies of a key to the account and returns the indices
+// of the added keys.
+//
+// The copies share the public key, algorithms and weight of the given key, so
+// that they can all be used with the same signer by a Dispatcher. They are
+// added by a single transaction, which is sent with the account and awaited
+// until it is sealed.
+func ProvisionKeys(ctx context.Context, account *Account, key *flow.AccountKey, n int) ([]int, error) {
+	if n <= 0 {
+		return nil, flowerrors.Errorf(flowerrors.ErrInvalidArgument, "wallet: cannot provision %d keys", n)
+	}
+
+	before, err := account.client.GetAccountAtLatestBlock(ctx, account.Address())
+	if err != nil {
+		return nil, fmt.Errorf("wallet: failed to get account %s: %w", account.Address(), err)
+	}
+
+	// keys are appended to the account, after the revoked ones
+	nextIndex := 0
+	for _, k := range before.Keys {
+		if k.Index >= nextIndex {
+			nextIndex = k.Index + 1
+		}
+	}
+
+	keys := make([]*flow.AccountKey, n)
+	for i := range keys {
+		keys[i] = key
+	}
+
+	_, err = account.SendAndWait(ctx, templates.AddAccountKeys(account.Address(), keys))
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := account.client.GetAccountAtLatestBlock(ctx, account.Address())
+	if err != nil {
+		return nil, fmt.Errorf("wallet: failed to get account %s: %w", account.Address(), err)
+	}
+
+	var indices []int
+	for _, k := range after.Keys {
+		if k.Index >= nextIndex && !k.Revoked && k.PublicKey.Equals(key.PublicKey) {
+			indices = append(indices, k.Index)
+		}
+	}
+
+	if len(indices) != n {
+		return nil, fmt.Errorf("wallet: added %d keys to account %s, found %d", n, account.Address(), len(indices))
+	}
+
+	return indices, nil
+}
+
+// A Dispatcher sends transactions with several keys of the same account.
+//
+// Transactions proposed with one key must use consecutive sequence numbers,
+// which limits an account with a single key to one transaction per block. A
+// Dispatcher assigns the transactions it sends to its keys in turn, each of
+// which tracks its own sequence number, so that an account provisioned with n
+// keys can have n transactions in every block. Every key is also the payer of
+// the transactions it proposes, so it needs full weight.
+//
+// A Dispatcher is safe for concurrent use: transactions assigned to different
+// keys are signed and submitted in parallel.
+type Dispatcher struct {
+	accounts []*Account
+	next     uint64
+}
+
+// NewDispatcher returns a dispatcher sending transactions with the keys at the
+// given indices, all of which sign with the same signer.
+//
+// The account is fetched once to read the sequence numbers of all keys. The
+// options apply to the Account of every key.
+func NewDispatcher(
+	ctx context.Context,
+	client Client,
+	address flow.Address,
+	keyIndices []int,
+	signer crypto.Signer,
+	opts ...Option,
+) (*Dispatcher, error) {
+	if len(keyIndices) == 0 {
+		return nil, flowerrors.New(flowerrors.ErrInvalidArgument, "wallet: dispatcher needs at least one key")
+	}
+
+	account, err := client.GetAccountAtLatestBlock(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("wallet: failed to get account %s: %w", address, err)
+	}
+
+	d := &Dispatcher{
+		accounts: make([]*Account, len(keyIndices)),
+	}
+
+	for i, keyIndex := range keyIndices {
+		a := newAccount(client, address, keyIndex, signer, opts...)
+
+		if err := a.syncFrom(account); err != nil {
+			return nil, err
+		}
+
+		d.accounts[i] = a
+	}
+
+	return d, nil
+}
+
+// Address returns the address of the account.
+func (d *Dispatcher) Address() flow.Address {
+	return d.accounts[0].Address()
+}
+
+// Accounts returns an Account for every key of the dispatcher.
+func (d *Dispatcher) Accounts() []*Account {
+	accounts := make([]*Account, len(d.accounts))
+	copy(accounts, d.accounts)
+	return accounts
+}
+
+// SequenceNumbers returns the sequence number the next transaction proposed
+// with each key will use, by key index.
+func (d *Dispatcher) SequenceNumbers() map[int]uint64 {
+	numbers := make(map[int]uint64, len(d.accounts))
+	for _, a := range d.accounts {
+		numbers[a.KeyIndex()] = a.SequenceNumber()
+	}
+	return numbers
+}
+
+// Send completes, signs and submits a transaction with the next key in turn,
+// like Account.Send, and returns its ID.
+func (d *Dispatcher) Send(ctx context.Context, tx *flow.Transaction) (flow.Identifier, error) {
+	return d.nextAccount().Send(ctx, tx)
+}
+
+// SendAndWait sends a transaction like Send and waits until it is sealed.
+func (d *Dispatcher) SendAndWait(ctx context.Context, tx *flow.Transaction) (*flow.TransactionResult, error) {
+	a := d.nextAccount()
+
+	txID, err := a.Send(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Wait(ctx, txID)
+}
+
+func (d *Dispatcher) nextAccount() *Account {
+	n := atomic.AddUint64(&d.next, 1) - 1
+	return d.accounts[n%uint64(len(d.accounts))]
+}
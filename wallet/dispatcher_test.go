@@ -0,0 +1,144 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wallet_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go-sdk/wallet"
+)
+
+// withKeyCopies returns a copy of the account with n more copies of its key.
+func withKeyCopies(account *flow.Account, n int) *flow.Account {
+	copied := *account
+	copied.Keys = append([]*flow.AccountKey(nil), account.Keys...)
+
+	for i := 0; i < n; i++ {
+		key := *account.Keys[0]
+		key.Index = account.Keys[0].Index + i + 1
+		key.SequenceNumber = uint64(10 * (i + 1))
+		copied.Keys = append(copied.Keys, &key)
+	}
+
+	return &copied
+}
+
+func TestProvisionKeys(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFixture(t)
+	provisioned := withKeyCopies(f.account, 2)
+
+	f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Twice()
+	f.client.On("GetLatestBlockHeader", ctx, false).Return(&f.header, nil).Once()
+
+	var sent flow.Transaction
+	f.client.On("SendTransaction", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(flow.Transaction) }).
+		Return(nil).Once()
+	f.client.On("GetTransactionResult", ctx, mock.Anything).
+		Return(&flow.TransactionResult{Status: flow.TransactionStatusSealed}, nil).Once()
+	f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(provisioned, nil).Once()
+
+	account := f.newAccount(t)
+
+	indices, err := wallet.ProvisionKeys(ctx, account, f.key, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int{f.key.Index + 1, f.key.Index + 2}, indices)
+
+	expected := templates.AddAccountKeys(f.account.Address, []*flow.AccountKey{f.key, f.key})
+	assert.Equal(t, expected.Script, sent.Script)
+	assert.Equal(t, expected.Arguments, sent.Arguments)
+
+	_, err = wallet.ProvisionKeys(ctx, account, f.key, 0)
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFixture(t)
+	account := withKeyCopies(f.account, 2)
+
+	f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(account, nil).Once()
+	f.client.On("GetLatestBlockHeader", ctx, false).Return(&f.header, nil).Times(4)
+
+	var sent []flow.Transaction
+	f.client.On("SendTransaction", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { sent = append(sent, args.Get(1).(flow.Transaction)) }).
+		Return(nil).Times(4)
+
+	first := f.key.Index
+
+	dispatcher, err := wallet.NewDispatcher(ctx, f.client, f.account.Address, []int{first, first + 1, first + 2}, f.signer.Signer)
+	require.NoError(t, err)
+	assert.Equal(t, f.account.Address, dispatcher.Address())
+	assert.Len(t, dispatcher.Accounts(), 3)
+
+	for i := 0; i < 4; i++ {
+		_, err := dispatcher.Send(ctx, flow.NewTransaction().SetScript([]byte("transaction {}")))
+		require.NoError(t, err)
+	}
+
+	require.Len(t, sent, 4)
+
+	proposals := make([]flow.ProposalKey, len(sent))
+	for i, tx := range sent {
+		proposals[i] = tx.ProposalKey
+		assert.Equal(t, f.account.Address, tx.Payer)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, tx.ProposalKey.KeyIndex, tx.EnvelopeSignatures[0].KeyIndex)
+	}
+
+	assert.Equal(t, []flow.ProposalKey{
+		{Address: f.account.Address, KeyIndex: first, SequenceNumber: f.key.SequenceNumber},
+		{Address: f.account.Address, KeyIndex: first + 1, SequenceNumber: 10},
+		{Address: f.account.Address, KeyIndex: first + 2, SequenceNumber: 20},
+		{Address: f.account.Address, KeyIndex: first, SequenceNumber: f.key.SequenceNumber + 1},
+	}, proposals)
+
+	assert.Equal(t, map[int]uint64{
+		first:     f.key.SequenceNumber + 2,
+		first + 1: 11,
+		first + 2: 21,
+	}, dispatcher.SequenceNumbers())
+}
+
+func TestNewDispatcher_Invalid(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFixture(t)
+
+	_, err := wallet.NewDispatcher(ctx, f.client, f.account.Address, nil, f.signer.Signer)
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+
+	f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+
+	_, err = wallet.NewDispatcher(ctx, f.client, f.account.Address, []int{f.key.Index, f.key.Index + 1}, f.signer.Signer)
+	assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+}
@@ -0,0 +1,261 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/logging"
+)
+
+// An Intent is a transaction scheduled to be sent by a Scheduler.
+type Intent struct {
+	// ID identifies the intent within its scheduler.
+	ID uint64
+	// Transaction is the unsigned transaction to send.
+	Transaction *flow.Transaction
+	// Height is the finalized block height at which the transaction is sent,
+	// or zero if the intent is scheduled at a time.
+	Height uint64
+	// Time is the time at which the transaction is sent, or the zero time if
+	// the intent is scheduled at a height.
+	Time time.Time
+}
+
+// due returns true if the intent should be sent at the given finalized height and time.
+func (i *Intent) due(height uint64, now time.Time) bool {
+	if i.Height > 0 {
+		return height >= i.Height
+	}
+
+	return !now.Before(i.Time)
+}
+
+// A Scheduler holds transactions until a block height or a time is reached,
+// then sends them with an Account.
+//
+// The transactions are completed and signed when they are sent, so that they
+// use a fresh reference block and the current sequence number of the account
+// key, however long they were held. Intents are kept in memory and are lost
+// when the process exits.
+type Scheduler struct {
+	account  *Account
+	interval time.Duration
+	onSent   func(intent Intent, txID flow.Identifier, err error)
+
+	mut     sync.Mutex
+	nextID  uint64
+	intents map[uint64]*Intent
+}
+
+// A SchedulerOption configures a Scheduler.
+type SchedulerOption func(*Scheduler)
+
+// WithCheckInterval sets the time between two checks for due intents. The
+// default is DefaultPollInterval.
+func WithCheckInterval(interval time.Duration) SchedulerOption {
+	return func(s *Scheduler) {
+		s.interval = interval
+	}
+}
+
+// WithSentHandler sets a function that the scheduler calls after sending the
+// transaction of an intent, with the ID of the sent transaction or the error
+// that prevented sending it.
+//
+// Intents that fail to be sent are not retried.
+func WithSentHandler(f func(intent Intent, txID flow.Identifier, err error)) SchedulerOption {
+	return func(s *Scheduler) {
+		s.onSent = f
+	}
+}
+
+// NewScheduler returns a scheduler sending transactions with the account.
+func NewScheduler(account *Account, opts ...SchedulerOption) *Scheduler {
+	s := &Scheduler{
+		account:  account,
+		interval: DefaultPollInterval,
+		intents:  make(map[uint64]*Intent),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// ScheduleAtHeight schedules a transaction to be sent once the block at the
+// given height is finalized, and returns the ID of the intent.
+//
+// The transaction must have no reference block, proposal key or signatures:
+// they are set when the transaction is sent.
+func (s *Scheduler) ScheduleAtHeight(tx *flow.Transaction, height uint64) (uint64, error) {
+	if height == 0 {
+		return 0, flowerrors.New(flowerrors.ErrInvalidArgument, "wallet: height must be greater than zero")
+	}
+
+	return s.schedule(&Intent{Transaction: tx, Height: height})
+}
+
+// ScheduleAt schedules a transaction to be sent at the given time, and returns
+// the ID of the intent.
+//
+// The transaction must have no reference block, proposal key or signatures:
+// they are set when the transaction is sent.
+func (s *Scheduler) ScheduleAt(tx *flow.Transaction, t time.Time) (uint64, error) {
+	if t.IsZero() {
+		return 0, flowerrors.New(flowerrors.ErrInvalidArgument, "wallet: time must not be zero")
+	}
+
+	return s.schedule(&Intent{Transaction: tx, Time: t})
+}
+
+func (s *Scheduler) schedule(intent *Intent) (uint64, error) {
+	tx := intent.Transaction
+
+	switch {
+	case tx.ReferenceBlockID != flow.EmptyID:
+		return 0, flowerrors.New(flowerrors.ErrInvalidArgument, "wallet: scheduled transaction must not have a reference block")
+	case tx.ProposalKey.Address != flow.EmptyAddress:
+		return 0, flowerrors.New(flowerrors.ErrInvalidArgument, "wallet: scheduled transaction must not have a proposal key")
+	case len(tx.PayloadSignatures) > 0 || len(tx.EnvelopeSignatures) > 0:
+		return 0, flowerrors.New(flowerrors.ErrInvalidArgument, "wallet: scheduled transaction must not be signed")
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.nextID++
+	intent.ID = s.nextID
+	s.intents[intent.ID] = intent
+
+	return intent.ID, nil
+}
+
+// Cancel removes an intent that has not been sent yet.
+//
+// It fails with flowerrors.ErrNotFound if there is no such intent, e.g.
+// because it was already sent.
+func (s *Scheduler) Cancel(id uint64) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if _, ok := s.intents[id]; !ok {
+		return flowerrors.Errorf(flowerrors.ErrNotFound, "wallet: no scheduled intent %d", id)
+	}
+
+	delete(s.intents, id)
+	return nil
+}
+
+// Intents returns the intents that have not been sent yet, in the order they
+// were scheduled.
+func (s *Scheduler) Intents() []Intent {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	intents := make([]Intent, 0, len(s.intents))
+	for _, intent := range s.intents {
+		intents = append(intents, *intent)
+	}
+
+	sort.Slice(intents, func(i, j int) bool {
+		return intents[i].ID < intents[j].ID
+	})
+
+	return intents
+}
+
+// Run sends the transactions of due intents until the context is cancelled.
+//
+// Run returns an error if the latest block cannot be read; errors sending a
+// transaction are reported to the handler set with WithSentHandler.
+func (s *Scheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.SendDue(ctx)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDue sends the transactions of the intents that are due at the latest
+// finalized block height and the current time.
+func (s *Scheduler) SendDue(ctx context.Context) error {
+	header, err := s.account.client.GetLatestBlockHeader(ctx, false)
+	if err != nil {
+		return fmt.Errorf("wallet: failed to get latest block: %w", err)
+	}
+
+	for _, intent := range s.take(header.Height, time.Now()) {
+		// the scheduled transaction is left unsigned, so that the intent
+		// reported to the handler is the one that was scheduled
+		tx := *intent.Transaction
+
+		txID, err := s.account.Send(ctx, &tx)
+		if err != nil {
+			s.account.logger.Log(logging.WarnLevel, "failed to send scheduled transaction",
+				logging.Uint64("intent", intent.ID),
+				logging.Error(err),
+			)
+		}
+
+		if s.onSent != nil {
+			s.onSent(intent, txID, err)
+		}
+	}
+
+	return nil
+}
+
+// take removes and returns the due intents, in the order they were scheduled.
+func (s *Scheduler) take(height uint64, now time.Time) []Intent {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	var due []Intent
+	for id, intent := range s.intents {
+		if intent.due(height, now) {
+			due = append(due, *intent)
+			delete(s.intents, id)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].ID < due[j].ID
+	})
+
+	return due
+}
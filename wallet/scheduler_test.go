@@ -0,0 +1,130 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wallet_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/wallet"
+)
+
+func TestScheduler(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFixture(t)
+	f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+
+	type sentIntent struct {
+		intent wallet.Intent
+		txID   flow.Identifier
+		err    error
+	}
+
+	var reported []sentIntent
+	scheduler := wallet.NewScheduler(f.newAccount(t), wallet.WithSentHandler(func(intent wallet.Intent, txID flow.Identifier, err error) {
+		reported = append(reported, sentIntent{intent, txID, err})
+	}))
+
+	atHeight, err := scheduler.ScheduleAtHeight(flow.NewTransaction().SetScript([]byte("height")), 10)
+	require.NoError(t, err)
+
+	atTime, err := scheduler.ScheduleAt(flow.NewTransaction().SetScript([]byte("time")), time.Now().Add(-time.Second))
+	require.NoError(t, err)
+
+	cancelled, err := scheduler.ScheduleAt(flow.NewTransaction().SetScript([]byte("cancelled")), time.Now())
+	require.NoError(t, err)
+	require.NoError(t, scheduler.Cancel(cancelled))
+
+	intents := scheduler.Intents()
+	require.Len(t, intents, 2)
+	assert.Equal(t, atHeight, intents[0].ID)
+	assert.Equal(t, uint64(10), intents[0].Height)
+	assert.Equal(t, atTime, intents[1].ID)
+
+	var sent []flow.Transaction
+	f.client.On("SendTransaction", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { sent = append(sent, args.Get(1).(flow.Transaction)) }).
+		Return(nil).Twice()
+
+	// the transaction scheduled at a time is sent before height 10 is reached
+	before := f.header
+	before.Height = 9
+	f.client.On("GetLatestBlockHeader", ctx, false).Return(&before, nil).Twice()
+
+	require.NoError(t, scheduler.SendDue(ctx))
+	require.Len(t, sent, 1)
+	assert.Equal(t, []byte("time"), sent[0].Script)
+
+	after := f.header
+	after.Height = 10
+	f.client.On("GetLatestBlockHeader", ctx, false).Return(&after, nil).Twice()
+
+	require.NoError(t, scheduler.SendDue(ctx))
+	require.Len(t, sent, 2)
+	assert.Equal(t, []byte("height"), sent[1].Script)
+
+	// reference blocks and sequence numbers are acquired when sending
+	assert.Equal(t, before.ID, sent[0].ReferenceBlockID)
+	assert.Equal(t, after.ID, sent[1].ReferenceBlockID)
+	assert.Equal(t, f.key.SequenceNumber, sent[0].ProposalKey.SequenceNumber)
+	assert.Equal(t, f.key.SequenceNumber+1, sent[1].ProposalKey.SequenceNumber)
+
+	require.Len(t, reported, 2)
+	assert.Equal(t, atTime, reported[0].intent.ID)
+	assert.Equal(t, sent[0].ID(), reported[0].txID)
+	assert.NoError(t, reported[0].err)
+	assert.Empty(t, reported[1].intent.Transaction.EnvelopeSignatures)
+
+	assert.Empty(t, scheduler.Intents())
+
+	err = scheduler.Cancel(atHeight)
+	assert.True(t, errors.Is(err, flowerrors.ErrNotFound))
+}
+
+func TestScheduler_Invalid(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFixture(t)
+	f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+
+	scheduler := wallet.NewScheduler(f.newAccount(t))
+
+	_, err := scheduler.ScheduleAtHeight(flow.NewTransaction(), 0)
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+
+	_, err = scheduler.ScheduleAt(flow.NewTransaction(), time.Time{})
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+
+	_, err = scheduler.ScheduleAtHeight(flow.NewTransaction().SetReferenceBlockID(f.header.ID), 1)
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+
+	_, err = scheduler.ScheduleAtHeight(flow.NewTransaction().SetProposalKey(f.account.Address, 0, 1), 1)
+	assert.True(t, errors.Is(err, flowerrors.ErrInvalidArgument))
+
+	assert.Empty(t, scheduler.Intents())
+}
@@ -0,0 +1,433 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package wallet sends transactions on behalf of Flow accounts.
+//
+// An Account ties together the pieces needed for a basic send: the signer of
+// one of the account's keys, the sequence number of that key, the reference
+// block and gas limit of the transaction, the signatures of other parties
+// resolved through a SignerResolver, and submission to an access node:
+//
+//	account, err := wallet.NewAccount(ctx, flowClient, address, keyIndex, signer)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	tx := flow.NewTransaction().
+//	    SetScript(script).
+//	    AddAuthorizer(account.Address())
+//
+//	result, err := account.SendAndWait(ctx, tx)
+//
+// The account tracks the sequence number of its key locally, so that several
+// transactions can be sent without waiting for each other to be sealed. A
+// Scheduler holds transactions for an account until a block height or a time
+// is reached.
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/logging"
+)
+
+// A Client is the subset of the Flow Access API client used by an Account.
+//
+// It is satisfied by *client.Client.
+type Client interface {
+	GetAccountAtLatestBlock(ctx context.Context, address flow.Address, opts ...grpc.CallOption) (*flow.Account, error)
+	GetLatestBlockHeader(ctx context.Context, isSealed bool, opts ...grpc.CallOption) (*flow.BlockHeader, error)
+	SendTransaction(ctx context.Context, tx flow.Transaction, opts ...grpc.CallOption) error
+	GetTransactionResult(ctx context.Context, txID flow.Identifier, opts ...grpc.CallOption) (*flow.TransactionResult, error)
+}
+
+// DefaultPollInterval is the default time between two polls of a transaction result.
+const DefaultPollInterval = time.Second
+
+// A KeySigner is a signer for a key of an account.
+type KeySigner struct {
+	Address  flow.Address
+	KeyIndex int
+	Signer   crypto.Signer
+}
+
+// A SignerResolver resolves the key an account signs transactions with.
+//
+// An Account uses its resolver to sign for the authorizers and payer of a
+// transaction that are not the account itself.
+type SignerResolver interface {
+	ResolveSigner(ctx context.Context, address flow.Address) (KeySigner, error)
+}
+
+// Signers is a SignerResolver for a fixed set of keys.
+type Signers []KeySigner
+
+// ResolveSigner returns the first signer for the address.
+func (s Signers) ResolveSigner(_ context.Context, address flow.Address) (KeySigner, error) {
+	for _, signer := range s {
+		if signer.Address == address {
+			return signer, nil
+		}
+	}
+
+	return KeySigner{}, flowerrors.Errorf(flowerrors.ErrNotFound, "wallet: no signer for account %s", address)
+}
+
+// An Account signs and sends transactions with one of the keys of a Flow account.
+//
+// The account is the proposer of the transactions it sends and, unless the
+// transaction has a payer, their payer. An Account is safe for concurrent use;
+// transactions are signed and submitted one at a time so that their sequence
+// numbers are assigned in order.
+type Account struct {
+	client       Client
+	key          KeySigner
+	resolver     SignerResolver
+	gasLimit     uint64
+	pollInterval time.Duration
+	logger       logging.Logger
+	onStatus     func(txID flow.Identifier, status flow.TransactionStatus)
+
+	mut            sync.Mutex
+	sequenceNumber uint64
+	// stale is set when the local sequence number may have diverged from the
+	// chain, e.g. after a failed submission
+	stale bool
+}
+
+// An Option configures an Account.
+type Option func(*Account)
+
+// WithSignerResolver sets the resolver used to sign for the other parties of a transaction.
+func WithSignerResolver(resolver SignerResolver) Option {
+	return func(a *Account) {
+		a.resolver = resolver
+	}
+}
+
+// WithGasLimit sets the gas limit of transactions that have none. The default
+// is flow.DefaultTransactionGasLimit.
+func WithGasLimit(limit uint64) Option {
+	return func(a *Account) {
+		a.gasLimit = limit
+	}
+}
+
+// WithPollInterval sets the time between two polls of a transaction result in SendAndWait.
+func WithPollInterval(interval time.Duration) Option {
+	return func(a *Account) {
+		a.pollInterval = interval
+	}
+}
+
+// WithStatusHandler sets a function that Wait and SendAndWait call whenever the
+// status of the awaited transaction changes, e.g. to show that it was finalized
+// or executed while it is not sealed yet.
+func WithStatusHandler(f func(txID flow.Identifier, status flow.TransactionStatus)) Option {
+	return func(a *Account) {
+		a.onStatus = f
+	}
+}
+
+// WithLogger sets the logger the account reports sent transactions to.
+func WithLogger(logger logging.Logger) Option {
+	return func(a *Account) {
+		a.logger = logging.OrNop(logger)
+	}
+}
+
+// NewAccount returns an account that signs with the key at keyIndex.
+//
+// The account is fetched to check that the key exists and is not revoked, and
+// to read its current sequence number.
+func NewAccount(
+	ctx context.Context,
+	client Client,
+	address flow.Address,
+	keyIndex int,
+	signer crypto.Signer,
+	opts ...Option,
+) (*Account, error) {
+	a := newAccount(client, address, keyIndex, signer, opts...)
+
+	if err := a.Sync(ctx); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// newAccount returns an account that has not read its sequence number yet.
+func newAccount(client Client, address flow.Address, keyIndex int, signer crypto.Signer, opts ...Option) *Account {
+	a := &Account{
+		client: client,
+		key: KeySigner{
+			Address:  address,
+			KeyIndex: keyIndex,
+			Signer:   signer,
+		},
+		resolver:     Signers(nil),
+		gasLimit:     flow.DefaultTransactionGasLimit,
+		pollInterval: DefaultPollInterval,
+		logger:       logging.Nop(),
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// Address returns the address of the account.
+func (a *Account) Address() flow.Address {
+	return a.key.Address
+}
+
+// KeyIndex returns the index of the key the account signs with.
+func (a *Account) KeyIndex() int {
+	return a.key.KeyIndex
+}
+
+// SequenceNumber returns the sequence number the next transaction will be proposed with.
+func (a *Account) SequenceNumber() uint64 {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	return a.sequenceNumber
+}
+
+// ResolveSigner implements SignerResolver: it returns the signer of the account
+// for its own address and defers to the account's resolver for other addresses.
+func (a *Account) ResolveSigner(ctx context.Context, address flow.Address) (KeySigner, error) {
+	if address == a.key.Address {
+		return a.key, nil
+	}
+
+	return a.resolver.ResolveSigner(ctx, address)
+}
+
+// Sync reads the sequence number of the account key from the chain.
+//
+// Sync is called by NewAccount, and by Send after a failed submission. It only
+// needs to be called directly if transactions are proposed with the same key
+// outside of the account.
+func (a *Account) Sync(ctx context.Context) error {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	return a.sync(ctx)
+}
+
+func (a *Account) sync(ctx context.Context) error {
+	account, err := a.client.GetAccountAtLatestBlock(ctx, a.key.Address)
+	if err != nil {
+		return fmt.Errorf("wallet: failed to get account %s: %w", a.key.Address, err)
+	}
+
+	return a.syncFrom(account)
+}
+
+// syncFrom reads the sequence number of the account key from a fetched account.
+func (a *Account) syncFrom(account *flow.Account) error {
+	for _, key := range account.Keys {
+		if key.Index != a.key.KeyIndex {
+			continue
+		}
+
+		if key.Revoked {
+			return flowerrors.Errorf(flowerrors.ErrInvalidArgument, "wallet: key %d of account %s is revoked", key.Index, a.key.Address)
+		}
+
+		a.sequenceNumber = key.SequenceNumber
+		a.stale = false
+
+		return nil
+	}
+
+	return flowerrors.Errorf(flowerrors.ErrNotFound, "wallet: account %s has no key %d", a.key.Address, a.key.KeyIndex)
+}
+
+// Send completes, signs and submits a transaction and returns its ID.
+//
+// The transaction is modified in place: unless they are already set, the
+// reference block is set to the latest finalized block, the gas limit to the
+// account's default, the proposal key to the account key and the payer to the
+// account. Every authorizer and the payer then sign the transaction, using
+// the account's signer for the account itself and the signer resolver for
+// other addresses. Parties that have already signed are not signed for again.
+func (a *Account) Send(ctx context.Context, tx *flow.Transaction) (flow.Identifier, error) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	if a.stale {
+		if err := a.sync(ctx); err != nil {
+			return flow.EmptyID, err
+		}
+	}
+
+	proposing := tx.ProposalKey.Address == flow.EmptyAddress
+	if proposing {
+		tx.SetProposalKey(a.key.Address, a.key.KeyIndex, a.sequenceNumber)
+	}
+
+	if tx.Payer == flow.EmptyAddress {
+		tx.SetPayer(a.key.Address)
+	}
+
+	if tx.GasLimit == 0 {
+		tx.SetGasLimit(a.gasLimit)
+	}
+
+	if tx.ReferenceBlockID == flow.EmptyID {
+		header, err := a.client.GetLatestBlockHeader(ctx, false)
+		if err != nil {
+			return flow.EmptyID, fmt.Errorf("wallet: failed to get reference block: %w", err)
+		}
+		tx.SetReferenceBlockID(header.ID)
+	}
+
+	if err := a.sign(ctx, tx); err != nil {
+		return flow.EmptyID, err
+	}
+
+	if err := a.client.SendTransaction(ctx, *tx); err != nil {
+		// the transaction may or may not have been accepted, so the sequence
+		// number is read from the chain before the next send
+		a.stale = true
+		return flow.EmptyID, fmt.Errorf("wallet: failed to send transaction: %w", err)
+	}
+
+	if proposing {
+		a.sequenceNumber++
+	}
+
+	txID := tx.ID()
+
+	a.logger.Log(logging.DebugLevel, "sent transaction",
+		logging.TransactionID(txID),
+		logging.Address(a.key.Address),
+		logging.Uint64("sequence_number", tx.ProposalKey.SequenceNumber),
+	)
+
+	return txID, nil
+}
+
+// sign adds the payload signatures of the proposer and authorizers that are
+// not the payer, followed by the envelope signature of the payer.
+func (a *Account) sign(ctx context.Context, tx *flow.Transaction) error {
+	signed := make(map[flow.Address]bool)
+	for _, sig := range tx.PayloadSignatures {
+		signed[sig.Address] = true
+	}
+
+	payloadSigners := append([]flow.Address{tx.ProposalKey.Address}, tx.Authorizers...)
+
+	for _, address := range payloadSigners {
+		if address == tx.Payer || signed[address] {
+			continue
+		}
+
+		key, err := a.ResolveSigner(ctx, address)
+		if err != nil {
+			return err
+		}
+
+		if address == tx.ProposalKey.Address {
+			key.KeyIndex = tx.ProposalKey.KeyIndex
+		}
+
+		if err := tx.SignPayload(address, key.KeyIndex, key.Signer); err != nil {
+			return fmt.Errorf("wallet: failed to sign transaction for %s: %w", address, err)
+		}
+
+		signed[address] = true
+	}
+
+	for _, sig := range tx.EnvelopeSignatures {
+		if sig.Address == tx.Payer {
+			return nil
+		}
+	}
+
+	payer, err := a.ResolveSigner(ctx, tx.Payer)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.SignEnvelope(tx.Payer, payer.KeyIndex, payer.Signer); err != nil {
+		return fmt.Errorf("wallet: failed to sign transaction for %s: %w", tx.Payer, err)
+	}
+
+	return nil
+}
+
+// SendAndWait sends a transaction like Send and waits until it is sealed.
+//
+// If the transaction is sealed with an error, the result is returned together
+// with an error wrapping the result's error.
+func (a *Account) SendAndWait(ctx context.Context, tx *flow.Transaction) (*flow.TransactionResult, error) {
+	txID, err := a.Send(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Wait(ctx, txID)
+}
+
+// Wait polls the result of a transaction until it is sealed or the context is done.
+func (a *Account) Wait(ctx context.Context, txID flow.Identifier) (*flow.TransactionResult, error) {
+	ticker := time.NewTicker(a.pollInterval)
+	defer ticker.Stop()
+
+	status := flow.TransactionStatusUnknown
+
+	for {
+		result, err := a.client.GetTransactionResult(ctx, txID)
+		if err != nil {
+			return nil, fmt.Errorf("wallet: failed to get result of transaction %s: %w", txID, err)
+		}
+
+		if result.Status != status {
+			status = result.Status
+			if a.onStatus != nil {
+				a.onStatus(txID, status)
+			}
+		}
+
+		if result.Status == flow.TransactionStatusSealed {
+			if result.Error != nil {
+				return result, fmt.Errorf("wallet: transaction %s failed: %w", txID, result.Error)
+			}
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wallet: transaction %s is not sealed: %w", txID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,285 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wallet_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client/mocks"
+	"github.com/onflow/flow-go-sdk/flowerrors"
+	"github.com/onflow/flow-go-sdk/test"
+	"github.com/onflow/flow-go-sdk/wallet"
+)
+
+type fixture struct {
+	client  *mocks.AccessClient
+	account *flow.Account
+	key     *flow.AccountKey
+	signer  wallet.KeySigner
+	header  flow.BlockHeader
+}
+
+func newFixture(t *testing.T) *fixture {
+	key, signer := test.AccountKeyGenerator().NewWithSigner()
+	account := test.AccountGenerator().New()
+	account.Keys = []*flow.AccountKey{key}
+
+	return &fixture{
+		client:  mocks.NewAccessClient(t),
+		account: account,
+		key:     key,
+		signer:  wallet.KeySigner{Address: account.Address, KeyIndex: key.Index, Signer: signer},
+		header:  test.BlockHeaderGenerator().New(),
+	}
+}
+
+func (f *fixture) newAccount(t *testing.T, opts ...wallet.Option) *wallet.Account {
+	account, err := wallet.NewAccount(context.Background(), f.client, f.signer.Address, f.signer.KeyIndex, f.signer.Signer, opts...)
+	require.NoError(t, err)
+	return account
+}
+
+func TestNewAccount(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Reads sequence number", func(t *testing.T) {
+		f := newFixture(t)
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+
+		account := f.newAccount(t)
+
+		assert.Equal(t, f.account.Address, account.Address())
+		assert.Equal(t, f.key.Index, account.KeyIndex())
+		assert.Equal(t, f.key.SequenceNumber, account.SequenceNumber())
+	})
+
+	t.Run("Missing key", func(t *testing.T) {
+		f := newFixture(t)
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+
+		_, err := wallet.NewAccount(ctx, f.client, f.account.Address, f.key.Index+1, f.signer.Signer)
+		assert.ErrorIs(t, err, flowerrors.ErrNotFound)
+	})
+
+	t.Run("Revoked key", func(t *testing.T) {
+		f := newFixture(t)
+		f.key.Revoked = true
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+
+		_, err := wallet.NewAccount(ctx, f.client, f.account.Address, f.key.Index, f.signer.Signer)
+		assert.ErrorIs(t, err, flowerrors.ErrInvalidArgument)
+	})
+
+	t.Run("Client error", func(t *testing.T) {
+		f := newFixture(t)
+		failure := errors.New("unavailable")
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(nil, failure).Once()
+
+		_, err := wallet.NewAccount(ctx, f.client, f.account.Address, f.key.Index, f.signer.Signer)
+		assert.ErrorIs(t, err, failure)
+	})
+}
+
+func TestAccount_Send(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Completes and signs transaction", func(t *testing.T) {
+		f := newFixture(t)
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+		f.client.On("GetLatestBlockHeader", ctx, false).Return(&f.header, nil).Twice()
+
+		var sent []flow.Transaction
+		f.client.On("SendTransaction", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { sent = append(sent, args.Get(1).(flow.Transaction)) }).
+			Return(nil).Twice()
+
+		account := f.newAccount(t)
+
+		for i := 0; i < 2; i++ {
+			tx := flow.NewTransaction().
+				SetScript([]byte(`transaction { prepare(signer: AuthAccount) {} }`)).
+				AddAuthorizer(account.Address())
+
+			txID, err := account.Send(ctx, tx)
+			require.NoError(t, err)
+			assert.Equal(t, tx.ID(), txID)
+		}
+
+		require.Len(t, sent, 2)
+		for i, tx := range sent {
+			assert.Equal(t, f.header.ID, tx.ReferenceBlockID)
+			assert.Equal(t, uint64(flow.DefaultTransactionGasLimit), tx.GasLimit)
+			assert.Equal(t, f.account.Address, tx.Payer)
+			assert.Equal(t, flow.ProposalKey{
+				Address:        f.account.Address,
+				KeyIndex:       f.key.Index,
+				SequenceNumber: f.key.SequenceNumber + uint64(i),
+			}, tx.ProposalKey)
+
+			assert.Empty(t, tx.PayloadSignatures)
+			require.Len(t, tx.EnvelopeSignatures, 1)
+			assert.Equal(t, f.account.Address, tx.EnvelopeSignatures[0].Address)
+		}
+
+		assert.Equal(t, f.key.SequenceNumber+2, account.SequenceNumber())
+	})
+
+	t.Run("Resolves other signers", func(t *testing.T) {
+		f := newFixture(t)
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+		f.client.On("SendTransaction", ctx, mock.Anything).Return(nil).Once()
+
+		authorizerKey, authorizerSigner := test.AccountKeyGenerator().NewWithSigner()
+		authorizer := flow.HexToAddress("01cf0e2f2f715450")
+		payer := flow.HexToAddress("179b6b1cb6755e31")
+
+		account := f.newAccount(t,
+			wallet.WithGasLimit(100),
+			wallet.WithSignerResolver(wallet.Signers{
+				{Address: authorizer, KeyIndex: authorizerKey.Index, Signer: authorizerSigner},
+				{Address: payer, KeyIndex: 3, Signer: authorizerSigner},
+			}),
+		)
+
+		tx := (&flow.Transaction{}).
+			SetReferenceBlockID(f.header.ID).
+			SetPayer(payer).
+			AddAuthorizer(authorizer).
+			AddAuthorizer(account.Address())
+
+		_, err := account.Send(ctx, tx)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(100), tx.GasLimit)
+		require.Len(t, tx.PayloadSignatures, 2)
+		assert.ElementsMatch(t,
+			[]flow.Address{authorizer, account.Address()},
+			[]flow.Address{tx.PayloadSignatures[0].Address, tx.PayloadSignatures[1].Address},
+		)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, payer, tx.EnvelopeSignatures[0].Address)
+		assert.Equal(t, 3, tx.EnvelopeSignatures[0].KeyIndex)
+	})
+
+	t.Run("Unknown signer", func(t *testing.T) {
+		f := newFixture(t)
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+
+		account := f.newAccount(t)
+
+		tx := flow.NewTransaction().
+			SetReferenceBlockID(f.header.ID).
+			AddAuthorizer(flow.HexToAddress("01cf0e2f2f715450"))
+
+		_, err := account.Send(ctx, tx)
+		assert.ErrorIs(t, err, flowerrors.ErrNotFound)
+		assert.Equal(t, f.key.SequenceNumber, account.SequenceNumber())
+	})
+
+	t.Run("Resyncs after failed submission", func(t *testing.T) {
+		f := newFixture(t)
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Twice()
+
+		failure := errors.New("connection reset")
+		f.client.On("SendTransaction", ctx, mock.Anything).Return(failure).Once()
+		f.client.On("SendTransaction", ctx, mock.Anything).Return(nil).Once()
+
+		account := f.newAccount(t)
+
+		_, err := account.Send(ctx, flow.NewTransaction().SetReferenceBlockID(f.header.ID))
+		assert.ErrorIs(t, err, failure)
+
+		// the first transaction was accepted after all
+		f.key.SequenceNumber++
+
+		tx := flow.NewTransaction().SetReferenceBlockID(f.header.ID)
+		_, err = account.Send(ctx, tx)
+		require.NoError(t, err)
+
+		assert.Equal(t, f.key.SequenceNumber, tx.ProposalKey.SequenceNumber)
+		assert.Equal(t, f.key.SequenceNumber+1, account.SequenceNumber())
+	})
+}
+
+func TestAccount_SendAndWait(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Waits until sealed", func(t *testing.T) {
+		f := newFixture(t)
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+		f.client.On("SendTransaction", ctx, mock.Anything).Return(nil).Once()
+		f.client.On("GetTransactionResult", ctx, mock.Anything).
+			Return(&flow.TransactionResult{Status: flow.TransactionStatusExecuted}, nil).Twice()
+		f.client.On("GetTransactionResult", ctx, mock.Anything).
+			Return(&flow.TransactionResult{Status: flow.TransactionStatusSealed}, nil).Once()
+
+		var statuses []flow.TransactionStatus
+		account := f.newAccount(t,
+			wallet.WithPollInterval(time.Millisecond),
+			wallet.WithStatusHandler(func(_ flow.Identifier, status flow.TransactionStatus) {
+				statuses = append(statuses, status)
+			}),
+		)
+
+		result, err := account.SendAndWait(ctx, flow.NewTransaction().SetReferenceBlockID(f.header.ID))
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+		assert.Equal(t, []flow.TransactionStatus{flow.TransactionStatusExecuted, flow.TransactionStatusSealed}, statuses)
+	})
+
+	t.Run("Failed transaction", func(t *testing.T) {
+		f := newFixture(t)
+		f.client.On("GetAccountAtLatestBlock", ctx, f.account.Address).Return(f.account, nil).Once()
+		f.client.On("SendTransaction", ctx, mock.Anything).Return(nil).Once()
+
+		failure := errors.New("panic: out of tokens")
+		f.client.On("GetTransactionResult", ctx, mock.Anything).
+			Return(&flow.TransactionResult{Status: flow.TransactionStatusSealed, Error: failure}, nil).Once()
+
+		account := f.newAccount(t)
+
+		result, err := account.SendAndWait(ctx, flow.NewTransaction().SetReferenceBlockID(f.header.ID))
+		assert.ErrorIs(t, err, failure)
+		require.NotNil(t, result)
+		assert.Equal(t, failure, result.Error)
+	})
+
+	t.Run("Context done", func(t *testing.T) {
+		f := newFixture(t)
+		f.client.On("GetAccountAtLatestBlock", mock.Anything, f.account.Address).Return(f.account, nil).Once()
+		f.client.On("GetTransactionResult", mock.Anything, mock.Anything).
+			Return(&flow.TransactionResult{Status: flow.TransactionStatusPending}, nil)
+
+		account := f.newAccount(t, wallet.WithPollInterval(time.Millisecond))
+
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+
+		_, err := account.Wait(ctx, flow.EmptyID)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}